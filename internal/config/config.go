@@ -27,6 +27,12 @@ type (
 		CloudStorageConfig   CloudStorageConfig          `json:"cloud_storage_config"`
 		Migration            MigrationConfiguration      `json:"migration"`
 		SQLTransaction       SQLTransactionConfiguration `json:"sql_transaction"`
+		FXRevaluation        FXRevaluationConfig         `json:"fx_revaluation"`
+		YearEndClosing       YearEndClosingConfig        `json:"year_end_closing"`
+		CashFlow             CashFlowConfig              `json:"cash_flow"`
+		Consolidation        ConsolidationConfig         `json:"consolidation"`
+		Reconciliation       ReconciliationConfig        `json:"reconciliation"`
+		SuspenseClearing     SuspenseClearingConfig      `json:"suspense_clearing"`
 
 		GcloudProjectID    string `json:"gcloud_project_id"`
 		BigQueryDataset    string `json:"big_query_dataset"`
@@ -75,10 +81,6 @@ type (
 		IsT24CreateAccountPAS                  map[string]bool                     `json:"is_t24_create_account_pas"`
 		LimitAccountSubLedger                  int                                 `json:"limit_account_sub_ledger"`
 		LimitAccountTrialBalance               int                                 `json:"limit_account_trial_balance"`
-		InvestedAccountNumber                  map[string]string                   `json:"invested_account_number"`
-		ReceivablesAccountNumber               map[string]string                   `json:"receivables_account_number"`
-		MultiLoanAccount                       map[string]string                   `json:"multi_loan_account"`
-		LenderInstiReceivablesAccount          map[string]string                   `json:"lender_insti_receivables_account"`
 		LoanPartnerAccountConfig               map[string]LoanPartnerAccountConfig `json:"loan_partner_account_config"`
 		LoanPartnerAccountEntities             []string                            `json:"loan_partner_account_entities"`
 		CashInTransitRepaymentEntity           map[string]string                   `json:"cash_in_transit_repayment_entity"`
@@ -86,6 +88,14 @@ type (
 		IsSkipAllZeroAmountAccountDailyBalance bool                                `json:"is_skip_all_zero_amount_account_daily_balance"`
 		IsSequentialAccountDailyBalance        bool                                `json:"is_sequential_account_daily_balance"`
 		MaxConcurrentAccountBalance            int                                 `json:"max_concurrent_account_balance"`
+		MaxBatchAccountBalance                 int                                 `json:"max_batch_account_balance"` // max account numbers of a balance batch lookup, default 100
+		MaxBulkUpdateAccounts                  int                                 `json:"max_bulk_update_accounts"`  // max accounts of a bulk update request, default 100
+
+		// deprecated, the relationship rules fall back to the maps until the rules seeded from them are verified
+		InvestedAccountNumber         map[string]string `json:"invested_account_number"`          // source sub category -> target sub category
+		ReceivablesAccountNumber      map[string]string `json:"receivables_account_number"`       // source sub category -> target sub category
+		MultiLoanAccount              map[string]string `json:"multi_loan_account"`               // source sub category -> target sub category
+		LenderInstiReceivablesAccount map[string]string `json:"lender_insti_receivables_account"` // lender account number -> receivables account number
 	}
 
 	LoanPartnerAccountConfig struct {
@@ -97,7 +107,16 @@ type (
 	}
 
 	JournalConfig struct {
-		SplitIdPadWidth int64 `json:"split_id_pad_width"`
+		SplitIdPadWidth    int64  `json:"split_id_pad_width"`
+		ClosedPeriodPolicy string `json:"closed_period_policy"` // reject (default) or redirect
+
+		// IntercompanyAccounts key is entityCode:counterpartyEntityCode, the accounts belong to the first entity
+		IntercompanyAccounts map[string]IntercompanyAccountConfig `json:"intercompany_accounts"`
+	}
+
+	IntercompanyAccountConfig struct {
+		DueFromAccount string `json:"due_from_account"` // receivable from the counterparty entity
+		DueToAccount   string `json:"due_to_account"`   // payable to the counterparty entity
 	}
 
 	IGateClient struct {
@@ -165,6 +184,7 @@ type (
 		PASAccountStreamDLQ       KafkaPublisherConfiguration `json:"pas_account_stream_dlq"`
 		JournalEntryCreated       KafkaPublisherConfiguration `json:"journal_entry_created"`
 		JournalEntryCreatedDLQ    KafkaPublisherConfiguration `json:"journal_entry_created_dlq"`
+		UploadJobStream           KafkaPublisherConfiguration `json:"upload_job_stream"`
 
 		PASAccountStreamMigration    KafkaPublisherConfiguration `json:"pas_account_stream_migration"`
 		PASAccountStreamMigrationDLQ KafkaPublisherConfiguration `json:"pas_account_stream_migration_dlq"`
@@ -183,6 +203,7 @@ type (
 		NotificationStream     KafkaConsumerConfiguration `json:"notification_stream"`
 		PASAccountStream       KafkaConsumerConfiguration `json:"pas_account_stream"`
 		JournalEntryCreatedDLQ KafkaConsumerConfiguration `json:"journal_entry_created_dlq"`
+		UploadJobStream        KafkaConsumerConfiguration `json:"upload_job_stream"`
 
 		PASAccountStreamMigration    KafkaConsumerConfiguration `json:"pas_account_stream_migration"`
 		AccountMigrationStream       KafkaConsumerConfiguration `json:"account_migration_stream"`
@@ -200,6 +221,7 @@ type (
 		BucketName                    string        `json:"bucket_name"`
 		TrialBalanceDetailURLDuration time.Duration `json:"trial_balance_detail_url_duration"`
 		SubLedgerURLDuration          time.Duration `json:"sub_ledger_url_duration"`
+		UploadJobURLDuration          time.Duration `json:"upload_job_url_duration"`
 	}
 
 	MigrationConfiguration struct {
@@ -210,6 +232,62 @@ type (
 		BulkLimit int `json:"bulk_limit"`
 	}
 
+	FXRevaluationConfig struct {
+		RateSource      string                                `json:"rate_source"`    // gcs or local
+		RateFilePath    string                                `json:"rate_file_path"` // directory of the rate files, one file per date named YYYY-MM-DD.csv
+		OrderType       string                                `json:"order_type"`
+		TransactionType string                                `json:"transaction_type"`
+		Accounts        map[string]FXRevaluationAccountConfig `json:"accounts"` // key is entity code
+	}
+
+	FXRevaluationAccountConfig struct {
+		RevaluationAccount string `json:"revaluation_account"`
+		GainAccount        string `json:"gain_account"`
+		LossAccount        string `json:"loss_account"`
+	}
+
+	YearEndClosingConfig struct {
+		OrderType                string            `json:"order_type"`
+		TransactionType          string            `json:"transaction_type"`
+		RetainedEarningsAccounts map[string]string `json:"retained_earnings_accounts"` // key is entity code
+	}
+
+	CashFlowConfig struct {
+		CashSubCategories []string          `json:"cash_sub_categories"`
+		Activities        map[string]string `json:"activities"` // key is order type or order type:transaction type, value is operating, investing or financing
+	}
+
+	ConsolidationConfig struct {
+		IntercompanyPairs []IntercompanyPairConfig `json:"intercompany_pairs"`
+	}
+
+	// IntercompanyPairConfig is a pair of intercompany sub categories which are eliminated against each other
+	IntercompanyPairConfig struct {
+		ReceivableSubCategory string `json:"receivable_sub_category"`
+		PayableSubCategory    string `json:"payable_sub_category"`
+	}
+
+	ReconciliationConfig struct {
+		MatchDateWindowDays int `json:"match_date_window_days"` // a statement line is auto matched to a split within the days, default 3
+	}
+
+	SuspenseClearingConfig struct {
+		SubCategories       []string                     `json:"sub_categories"` // suspense sub categories scanned by the clearing job
+		OrderType           string                       `json:"order_type"`
+		TransactionType     string                       `json:"transaction_type"`
+		LookbackDays        int                          `json:"lookback_days"`         // entries older than the days are not paired but still reported as aged, default 180
+		AgeingThresholdDays int                          `json:"ageing_threshold_days"` // uncleared entries older than the days are reported, default 30
+		Rules               []SuspenseClearingRuleConfig `json:"rules"`                 // applied in order
+	}
+
+	// SuspenseClearingRuleConfig pairs offsetting suspense entries with the same key, an entry without the key is skipped by the rule
+	SuspenseClearingRuleConfig struct {
+		Name        string   `json:"name"`
+		MatchBy     string   `json:"match_by"`     // referenceNumber, orderType or metadata
+		MetadataKey string   `json:"metadata_key"` // required when match_by is metadata
+		OrderTypes  []string `json:"order_types"`  // optional, only entries of the order types are paired
+	}
+
 	AcuanLibConfig struct {
 		Kafka                 AcuanLibKafkaConfig `json:"kafka"`
 		SourceSystem          string              `json:"source_system"`
@@ -17,6 +17,7 @@ const (
 	accountRelationshipMigration = "account_relationships_migration"
 	customerUpdatedStream        = "customer_updated_stream"
 	journalEntryCreatedDLQ       = "journal_entry_created_dlq"
+	uploadJobStream              = "upload_job_stream"
 )
 
 var (
@@ -25,6 +26,7 @@ var (
 		journalStream,
 		journalStreamDLQ,
 		notificationStream,
+		uploadJobStream,
 	}
 )
 
@@ -48,6 +50,8 @@ func NewKafkaConsumer(ctx context.Context, consumerName string, contract *contra
 		return newCustomerUpdatedStreamSubscriber(ctx, contract)
 	case journalEntryCreatedDLQ:
 		return newJournalEntryCreatedDLQSubscriber(ctx, contract)
+	case uploadJobStream:
+		return newUploadJobStreamSubscriber(ctx, contract)
 	default:
 		xlog.Error(ctx, "invalid consumer instance name")
 	}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderUserEmail is set by the api gateway with the email of the authenticated user,
+// the api is only reachable from the gateway with the secret key so the header is trusted.
+const HeaderUserEmail = "X-User-Email"
+
+// GetActor returns the authenticated user of the request, it is the maker or the checker of the maker-checker flows.
+func GetActor(c echo.Context) (string, error) {
+	actor := c.Request().Header.Get(HeaderUserEmail)
+	if actor == "" {
+		return "", errors.New("required user email")
+	}
+
+	return actor, nil
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -25,3 +27,16 @@ func (m *AppMiddleware) InternalAuth() func(echo.HandlerFunc) echo.HandlerFunc {
 		}
 	}
 }
+
+// FeatureFlag rejects the request when the flag is disabled.
+func (m *AppMiddleware) FeatureFlag(flag models.FlagEnum) func(echo.HandlerFunc) echo.HandlerFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !m.c.Flagger.IsEnabled(flag.String()) {
+				return commonhttp.RestErrorResponse(c, http.StatusForbidden, fmt.Errorf("%s is disabled", flag.String()))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -99,7 +99,7 @@ func NewHTTPServer(
 	gV1 := gAPI.Group("/v1")
 	// v1Group middleware
 	gV1.Use(m.InternalAuth())
-	httpv1.Route(gV1, contract)
+	httpv1.Route(gV1, contract, &m)
 
 	// v2 group
 	gV2 := gAPI.Group("/v2")
@@ -16,23 +16,33 @@ import (
 )
 
 type accountHandler struct {
-	service services.AccountService
+	service          services.AccountService
+	uploadJobService services.UploadJobService
 }
 
 // New account handler will initialize the account/ resources endpoint
-func New(app *echo.Group, accountSrv services.AccountService) {
+func New(app *echo.Group, accountSrv services.AccountService, uploadJobSrv services.UploadJobService) {
 	ah := accountHandler{
-		service: accountSrv,
+		service:          accountSrv,
+		uploadJobService: uploadJobSrv,
 	}
 	account := app.Group("/accounts")
 	account.POST("", ah.createAccount)
 	account.GET("", ah.getAccountList)
 	account.GET("/:accountNumber", ah.getByAccountNumber)
+	account.GET("/:accountNumber/balance", ah.getAccountBalance)
+	account.POST("/balances", ah.getAccountBalances)
 	account.PATCH("/:accountNumber", ah.updateAccount)
 	account.PUT("/:accountNumber/entity", ah.updateAccountEntity)
+	account.PATCH("/:accountNumber/status", ah.updateAccountStatus)
+	account.GET("/:accountNumber/history", ah.getAccountHistory)
+	account.POST("/:accountNumber/parent", ah.setAccountParent)
+	account.DELETE("/:accountNumber/parent", ah.removeAccountParent)
+	account.GET("/:accountNumber/hierarchy", ah.getAccountHierarchy)
 	account.GET("/download", ah.downloadCSVGetAccountList)
 	account.GET("/alt-ids", ah.checkAltIdIsExist)
 	account.POST("/upload", ah.uploadAccount)
+	account.POST("/bulk-update", ah.bulkUpdateAccounts)
 	/*
 		todo: remove GET /t24/:legacyId if no traffic again in the future
 	*/
@@ -46,6 +56,13 @@ func New(app *echo.Group, accountSrv services.AccountService) {
 
 	loanAccount := app.Group("/loan-accounts")
 	loanAccount.GET("/advance-account/:loanAccountNumber", ah.getLoanAdvanceAccountByLoanAccount)
+
+	relationshipRule := app.Group("/account-relationship-rules")
+	relationshipRule.POST("", ah.createAccountRelationshipRule)
+	relationshipRule.GET("", ah.getAccountRelationshipRules)
+	relationshipRule.GET("/:id", ah.getAccountRelationshipRuleById)
+	relationshipRule.PUT("/:id", ah.updateAccountRelationshipRule)
+	relationshipRule.DELETE("/:id", ah.deleteAccountRelationshipRule)
 }
 
 // @Summary 	Create Account
@@ -149,6 +166,8 @@ func (ah accountHandler) updateAccount(c echo.Context) error {
 		OwnerID:       req.OwnerID,
 		AltID:         req.AltID,
 		LegacyId:      req.LegacyID,
+		ChangedBy:     req.UpdatedBy,
+		Reason:        req.Reason,
 	})
 	if err != nil {
 		if errors.Is(err, models.GetErrMap(models.ErrKeyAccountNumberNotFound)) {
@@ -190,6 +209,8 @@ func (ah accountHandler) updateAccountEntity(c echo.Context) error {
 	res, err := ah.service.UpdateAccountEntity(c.Request().Context(), models.UpdateAccountEntity{
 		AccountNumber: req.AccountNumber,
 		EntityCode:    req.EntityCode,
+		ChangedBy:     req.UpdatedBy,
+		Reason:        req.Reason,
 	})
 	if err != nil {
 		if errors.Is(err, models.GetErrMap(models.ErrKeyAccountNumberNotFound)) ||
@@ -374,7 +395,7 @@ func (ah accountHandler) checkAltIdIsExist(c echo.Context) error {
 // @Produce		text/csv
 // @Param	X-Secret-Key header string true "X-Secret-Key"
 // @Param	file formData file true "csv file"
-// @Success 200 string success "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Success 202 {object} models.UploadJobResponse "Response indicates that the file is stored & will be processed in the background, the progress is available in GET /v1/uploads/:id"
 // @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while upload account"
 // @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while upload account"
 // @Router 	/v1/accounts/upload [post]
@@ -388,11 +409,15 @@ func (ah accountHandler) uploadAccount(c echo.Context) error {
 		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, errors.New("file not csv"))
 	}
 
-	if err = ah.service.ProcessUploadAccounts(c.Request().Context(), file); err != nil {
+	job, err := ah.uploadJobService.CreateUploadJob(c.Request().Context(), models.CreateUploadJobRequest{
+		Type: models.UploadJobTypeAccount,
+		File: file,
+	})
+	if err != nil {
 		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
 	}
 
-	return commonhttp.RestSuccessResponse(c, http.StatusOK, "success")
+	return commonhttp.RestSuccessResponse(c, http.StatusAccepted, job.ToResponse())
 }
 
 // @Summary 	Get all account by params
@@ -0,0 +1,93 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Get account balance
+// @Description Get the current balance of the account, or the balance at a point in time when asOf is set
+// @Tags 		Accounts
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param 	accountNumber path string true "account identifier"
+// @Param   params query models.DoGetAccountBalanceRequest true "Get account balance query parameters"
+// @Success 200 {object} models.AccountBalanceResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while get account balance"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Data not found. This can happen if data not found while get account balance"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while get account balance"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get account balance"
+// @Router 	/v1/accounts/{accountNumber}/balance [get]
+func (ah accountHandler) getAccountBalance(c echo.Context) error {
+	req := new(models.DoGetAccountBalanceRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	asOf, err := req.ToAsOf()
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	res, err := ah.service.GetAccountBalance(c.Request().Context(), req.AccountNumber, asOf)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyAccountNumberNotFound)) {
+			statusCode = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, statusCode, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, res.ToResponse())
+}
+
+// @Summary 	Get account balances
+// @Description Get the balance of the accounts in a batch, an unknown account is returned in notFoundAccountNumbers
+// @Tags 		Accounts
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param 	payload body models.DoGetAccountBalancesRequest true "A JSON object containing the account numbers"
+// @Success 200 {object} models.AccountBalancesResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the account numbers exceed the batch limit"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while get account balances"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get account balances"
+// @Router 	/v1/accounts/balances [post]
+func (ah accountHandler) getAccountBalances(c echo.Context) error {
+	req := new(models.DoGetAccountBalancesRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	opts, err := req.ToFilterOpts()
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	res, err := ah.service.GetAccountBalances(c.Request().Context(), *opts)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			statusCode = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, statusCode, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, res.ToResponse())
+}
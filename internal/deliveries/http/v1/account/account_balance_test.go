@@ -0,0 +1,217 @@
+package account
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_getAccountBalance(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	asOf, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDDWithTime, "2024-04-30 13:00:00")
+	balanceDate, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-04-29")
+
+	type args struct {
+		url string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case",
+			args: args{url: "/api/v1/accounts/142001000000001/balance?asOf=2024-04-30%2013:00:00"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountBalance(gomock.AssignableToTypeOf(context.Background()), "142001000000001", asOf).
+					Return(models.AccountBalance{
+						AccountNumber:    "142001000000001",
+						EntityCode:       "001",
+						SubCategoryCode:  "14201",
+						Currency:         "IDR",
+						AsOf:             asOf,
+						DailyBalanceDate: &balanceDate,
+						DailyBalance:     decimal.NewFromInt(100000),
+						DebitMovement:    decimal.NewFromInt(50000),
+						CreditMovement:   decimal.Zero,
+						Balance:          decimal.NewFromInt(150000),
+					}, nil)
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"accountBalance","accountNumber":"142001000000001","entityCode":"001","subCategoryCode":"14201","currency":"IDR","asOf":"2024-04-30 13:00:00","balance":"150000","dailyBalanceDate":"2024-04-29","dailyBalance":"100000","debitMovement":"50000","creditMovement":"0"}`,
+				wantCode: 200,
+			},
+		},
+		{
+			name: "error case - invalid as of",
+			args: args{url: "/api/v1/accounts/142001000000001/balance?asOf=30-04-2024"},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"invalid format date caused by asOf format must be YYYY-MM-DD or YYYY-MM-DD HH:mm:ss"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error case - account number not found",
+			args: args{url: "/api/v1/accounts/142001000000001/balance"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountBalance(gomock.AssignableToTypeOf(context.Background()), "142001000000001", gomock.Any()).
+					Return(models.AccountBalance{}, models.GetErrMap(models.ErrKeyAccountNumberNotFound))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"account number not found"}`,
+				wantCode: 404,
+			},
+		},
+		{
+			name: "error case - internal server error",
+			args: args{url: "/api/v1/accounts/142001000000001/balance"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountBalance(gomock.AssignableToTypeOf(context.Background()), "142001000000001", gomock.Any()).
+					Return(models.AccountBalance{}, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.args.url, nil)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_getAccountBalances(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	asOf, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDDWithTime, "2024-04-30 23:59:59")
+
+	type args struct {
+		req string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case",
+			args: args{req: `{"accountNumbers":["142001000000001","999001000000001"],"asOf":"2024-04-30"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountBalances(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, opts models.AccountBalanceFilterOptions) (models.AccountBalances, error) {
+						assert.Equal(t, []string{"142001000000001", "999001000000001"}, opts.AccountNumbers)
+						assert.Equal(t, "2024-04-30 23:59:59", opts.AsOf.Format(atime.DateFormatYYYYMMDDWithTime))
+						return models.AccountBalances{
+							AsOf: opts.AsOf,
+							Balances: []models.AccountBalance{{
+								AccountNumber:   "142001000000001",
+								EntityCode:      "001",
+								SubCategoryCode: "14201",
+								Currency:        "IDR",
+								AsOf:            asOf,
+								Balance:         decimal.NewFromInt(50000),
+								DebitMovement:   decimal.NewFromInt(50000),
+							}},
+							NotFoundAccountNumbers: []string{"999001000000001"},
+						}, nil
+					})
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"accountBalances","asOf":"2024-04-30 23:59:59","balances":[{"kind":"accountBalance","accountNumber":"142001000000001","entityCode":"001","subCategoryCode":"14201","currency":"IDR","asOf":"2024-04-30 23:59:59","balance":"50000","dailyBalance":"0","debitMovement":"50000","creditMovement":"0"}],"notFoundAccountNumbers":["999001000000001"]}`,
+				wantCode: 200,
+			},
+		},
+		{
+			name: "error case - validation",
+			args: args{req: `{"accountNumbers":[]}`},
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"UNKNOW","field":"accountNumbers","message":"min 1"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error case - exceed batch limit",
+			args: args{req: `{"accountNumbers":["142001000000001","142001000000002"]}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountBalances(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(models.AccountBalances{}, models.GetErrMap(models.ErrKeyAccountBalanceBatchExceeded, "max account numbers is 1"))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"account numbers exceed the balance batch limit caused by max account numbers is 1"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error case - internal server error",
+			args: args{req: `{"accountNumbers":["142001000000001"]}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountBalances(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(models.AccountBalances{}, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/accounts/balances", strings.NewReader(tt.args.req))
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Bulk update accounts
+// @Description Update the name, owner id, alt id, legacy id, entity code & metadata of many accounts from a json body or a csv file.
+// @Description The json body is updated inside the request up to the bulk update limit & the result of every row is in the report.
+// @Description The csv file is stored & processed by an upload job in the background, the progress & the failed rows are available in GET /v1/uploads/:id.
+// @Description The csv columns are matched by the header: account_number, name, owner_id, alt_id, t24_account_number, entity_code & metadata, an empty cell keeps the current value.
+// @Description Every row is updated on its own, a failed row does not stop the next rows
+// @Tags 		Accounts
+// @Accept		json
+// @Accept		multipart/form-data
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param 	payload body models.DoBulkUpdateAccountsRequest false "A JSON object containing bulk update accounts payload"
+// @Param	file formData file false "csv file"
+// @Param	updatedBy formData string false "updated by of the csv file"
+// @Param	reason formData string false "reason of the csv file"
+// @Success 200 {object} models.AccountBulkUpdateReport "Response indicates that the request succeeded and the result of every row is transmitted in the message body"
+// @Success 202 {object} models.UploadJobResponse "Response indicates that the csv file is stored & will be processed in the background, the progress is available in GET /v1/uploads/:id"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the file is invalid or the accounts exceed the bulk update limit"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while bulk update accounts"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while bulk update accounts"
+// @Router 	/v1/accounts/bulk-update [post]
+func (ah accountHandler) bulkUpdateAccounts(c echo.Context) error {
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		return ah.uploadBulkUpdateAccounts(c)
+	}
+
+	req := new(models.DoBulkUpdateAccountsRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	report, err := ah.service.BulkUpdateAccounts(c.Request().Context(), req.ToBulkUpdateAccountsIn())
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, report)
+}
+
+// uploadBulkUpdateAccounts stores the bulk update csv as an upload job, the file is not processed inside the request.
+func (ah accountHandler) uploadBulkUpdateAccounts(c echo.Context) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if strings.ToLower(path.Ext(file.Filename)) != models.AccountBulkUpdateExtCSV {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, errors.New("file not csv"))
+	}
+
+	req := models.DoUploadBulkUpdateAccountsRequest{
+		UpdatedBy: c.FormValue("updatedBy"),
+		Reason:    c.FormValue("reason"),
+	}
+	if err = validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	job, err := ah.uploadJobService.CreateUploadJob(c.Request().Context(), models.CreateUploadJobRequest{
+		Type:      models.UploadJobTypeAccountBulkUpdate,
+		File:      file,
+		CreatedBy: req.UpdatedBy,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusAccepted, job.ToResponse())
+}
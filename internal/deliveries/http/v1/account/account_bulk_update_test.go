@@ -0,0 +1,180 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_bulkUpdateAccounts(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	report := models.AccountBulkUpdateReport{
+		Kind:        models.KindAccountBulkUpdateReport,
+		TotalRows:   2,
+		SuccessRows: 1,
+		FailedRows:  1,
+		Rows: []models.AccountBulkUpdateRowReport{
+			{Row: 1, AccountNumber: "21100100000001", Status: models.AccountBulkUpdateRowStatusSuccess, Errors: []string{}},
+			{Row: 2, AccountNumber: "21100100000002", Status: models.AccountBulkUpdateRowStatusFailed, Errors: []string{"account number not found"}},
+		},
+	}
+	wantReport := `{"kind":"accountBulkUpdateReport","totalRows":2,"successRows":1,"failedRows":1,"rows":[{"row":1,"accountNumber":"21100100000001","status":"success","errors":[]},{"row":2,"accountNumber":"21100100000002","status":"failed","errors":["account number not found"]}]}`
+
+	type args struct {
+		req         string
+		contentType string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case - json",
+			args: args{
+				req:         `{"accounts":[{"accountNumber":"21100100000001","name":"Lender Yang Baik"},{"accountNumber":"21100100000002","metadata":{"segment":"retail"}}],"updatedBy":"tono@amartha.com","reason":"rename"}`,
+				contentType: echo.MIMEApplicationJSON,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					BulkUpdateAccounts(gomock.AssignableToTypeOf(context.Background()), models.BulkUpdateAccountsIn{
+						Accounts: []models.BulkUpdateAccount{
+							{Row: 1, AccountNumber: "21100100000001", Name: "Lender Yang Baik"},
+							{Row: 2, AccountNumber: "21100100000002", Metadata: &models.Metadata{"segment": "retail"}},
+						},
+						ChangedBy: "tono@amartha.com",
+						Reason:    "rename",
+					}).
+					Return(report, nil)
+			},
+			expectation: expectation{
+				wantRes:  wantReport,
+				wantCode: 200,
+			},
+		},
+		{
+			name: "success case - csv is processed by an upload job",
+			args: args{
+				req:         "account_number,name\n21100100000001,Lender Yang Baik\n21100100000002,Lender\n",
+				contentType: echo.MIMEMultipartForm,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockUploadJobService.EXPECT().
+					CreateUploadJob(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in models.CreateUploadJobRequest) (*models.UploadJob, error) {
+						require.Equal(t, models.UploadJobTypeAccountBulkUpdate, in.Type)
+						require.Equal(t, "accounts.csv", in.File.Filename)
+						require.Equal(t, "tono@amartha.com", in.CreatedBy)
+						require.Equal(t, "rename", in.Reason)
+						return &models.UploadJob{
+							ID:        1,
+							Type:      in.Type,
+							Filename:  in.File.Filename,
+							CreatedBy: in.CreatedBy,
+							Reason:    in.Reason,
+							Status:    models.UploadJobStatusPending,
+						}, nil
+					})
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"uploadJob","id":1,"type":"accountBulkUpdate","filename":"accounts.csv","createdBy":"tono@amartha.com","reason":"rename","status":"pending","totalRows":0,"processedRows":0,"successRows":0,"failedRows":0,"failures":[],"startedAt":null,"finishedAt":null,"createdAt":"0001-01-01T00:00:00Z","updatedAt":"0001-01-01T00:00:00Z"}`,
+				wantCode: 202,
+			},
+		},
+		{
+			name: "error case - create upload job",
+			args: args{
+				req:         "account_number,name\n21100100000001,Lender Yang Baik\n",
+				contentType: echo.MIMEMultipartForm,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockUploadJobService.EXPECT().
+					CreateUploadJob(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+				wantCode: 500,
+			},
+		},
+		{
+			name: "error case - validation",
+			args: args{
+				req:         `{"accounts":[]}`,
+				contentType: echo.MIMEApplicationJSON,
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"INVALID_LENGTH","field":"accounts","message":"field must have at least 1 item"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error case - accounts exceed the limit",
+			args: args{
+				req:         `{"accounts":[{"accountNumber":"21100100000001"}]}`,
+				contentType: echo.MIMEApplicationJSON,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					BulkUpdateAccounts(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(models.AccountBulkUpdateReport{}, models.GetErrMap(models.ErrKeyAccountBulkUpdateExceeded, "max accounts is 100, upload a csv file for more accounts"))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"accounts exceed the bulk update limit caused by max accounts is 100, upload a csv file for more accounts"}`,
+				wantCode: 400,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			body := strings.NewReader(tt.args.req)
+			contentType := tt.args.contentType
+			if contentType == echo.MIMEMultipartForm {
+				buf := new(bytes.Buffer)
+				writer := multipart.NewWriter(buf)
+				part, err := writer.CreateFormFile("file", "accounts.csv")
+				require.NoError(t, err)
+				_, err = part.Write([]byte(tt.args.req))
+				require.NoError(t, err)
+				require.NoError(t, writer.WriteField("updatedBy", "tono@amartha.com"))
+				require.NoError(t, writer.WriteField("reason", "rename"))
+				require.NoError(t, writer.Close())
+
+				body = strings.NewReader(buf.String())
+				contentType = writer.FormDataContentType()
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/accounts/bulk-update", body)
+			r.Header.Set(echo.HeaderContentType, contentType)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package account
+
+import (
+	"net/http"
+	"strings"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Set account parent
+// @Description Link the account to the parent account, e.g. the accounts of a lender under the lender master or the loan accounts under the partner
+// @Tags 		Accounts
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	accountNumber path string true "account identifier"
+// @Param 	payload body models.DoCreateAccountParentRequest true "A JSON object containing set account parent payload"
+// @Success 201 {object} models.AccountRelationResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the parent account is the account or a descendant of the account"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Data not found. This can happen if the account or the parent account is not found"
+// @Failure 409 {object} commonhttp.RestErrorResponseModel "Data is exist. This can happen if the account already has a parent account"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while set account parent"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while set account parent"
+// @Router 	/v1/accounts/{accountNumber}/parent [post]
+func (ah accountHandler) setAccountParent(c echo.Context) error {
+	req := new(models.DoCreateAccountParentRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := ah.service.SetAccountParent(c.Request().Context(), req.ToAccountRelation())
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		} else if strings.Contains(err.Error(), models.ErrCodeDataIsExist) {
+			code = http.StatusConflict
+		} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusCreated, out.ToResponse())
+}
+
+// @Summary 	Remove account parent
+// @Description Unlink the account from the parent account, the lender & loan advance accounts linked by the account relationship rules are kept
+// @Tags 		Accounts
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	accountNumber path string true "account identifier"
+// @Success 204 "Response indicates that the request succeeded and the resources has been deleted"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Data not found. This can happen if the account has no parent account"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while remove account parent"
+// @Router 	/v1/accounts/{accountNumber}/parent [delete]
+func (ah accountHandler) removeAccountParent(c echo.Context) error {
+	if err := ah.service.RemoveAccountParent(c.Request().Context(), c.Param("accountNumber")); err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary 	Get account hierarchy
+// @Description Get the subtree of the account with the closing balance of every account & the balance rolled up from the children of the same currency
+// @Tags 		Accounts
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	accountNumber path string true "account identifier"
+// @Param   params query models.DoGetAccountHierarchyRequest true "Get account hierarchy query parameters"
+// @Success 200 {object} models.AccountHierarchyResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the balance date format is invalid"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Data not found. This can happen if the account is not found"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while get account hierarchy"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get account hierarchy"
+// @Router 	/v1/accounts/{accountNumber}/hierarchy [get]
+func (ah accountHandler) getAccountHierarchy(c echo.Context) error {
+	req := new(models.DoGetAccountHierarchyRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	balanceDate, err := req.ToBalanceDate()
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	res, err := ah.service.GetAccountHierarchy(c.Request().Context(), req.AccountNumber, balanceDate)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, res.ToResponse())
+}
@@ -0,0 +1,263 @@
+package account
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_setAccountParent(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	type args struct {
+		req string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case",
+			args: args{req: `{"parentAccountNumber":"211001000000001"}`},
+			doMock: func(args args, expectation expectation) {
+				in := models.AccountRelation{
+					ParentAccountNumber: "211001000000001",
+					AccountNumber:       "211001000381110",
+					Relation:            models.AccountRelationChild,
+				}
+				testHelper.mockAccountService.EXPECT().
+					SetAccountParent(gomock.AssignableToTypeOf(context.Background()), in).
+					Return(&in, nil)
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"accountHierarchy","parentAccountNumber":"211001000000001","accountNumber":"211001000381110","relation":"child"}`,
+				wantCode: 201,
+			},
+		},
+		{
+			name: "error case - validation",
+			args: args{req: `{}`},
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"MISSING_FIELD","field":"parentAccountNumber","message":"field is missing"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error case - parent is a descendant",
+			args: args{req: `{"parentAccountNumber":"211001000000001"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					SetAccountParent(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, models.GetErrMap(models.ErrKeyInvalidAccountParent))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"parent account must not be the account or a descendant of the account"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error case - account not found",
+			args: args{req: `{"parentAccountNumber":"211001000000001"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					SetAccountParent(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, models.GetErrMap(models.ErrKeyAccountNumberNotFound))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"account number not found"}`,
+				wantCode: 404,
+			},
+		},
+		{
+			name: "error case - parent is exist",
+			args: args{req: `{"parentAccountNumber":"211001000000001"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					SetAccountParent(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, models.GetErrMap(models.ErrKeyAccountParentIsExist))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_IS_EXIST","message":"account already has a parent account"}`,
+				wantCode: 409,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/accounts/211001000381110/parent", strings.NewReader(tt.args.req))
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_removeAccountParent(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name: "success case",
+			doMock: func() {
+				testHelper.mockAccountService.EXPECT().
+					RemoveAccountParent(gomock.AssignableToTypeOf(context.Background()), "211001000381110").
+					Return(nil)
+			},
+			expectation: expectation{
+				wantCode: 204,
+			},
+		},
+		{
+			name: "error case - parent not found",
+			doMock: func() {
+				testHelper.mockAccountService.EXPECT().
+					RemoveAccountParent(gomock.AssignableToTypeOf(context.Background()), "211001000381110").
+					Return(models.GetErrMap(models.ErrKeyAccountParentNotFound))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"parent account of the account not found"}`,
+				wantCode: 404,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/accounts/211001000381110/parent", nil)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_getAccountHierarchy(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	balanceDate := time.Date(2024, 4, 30, 0, 0, 0, 0, time.Local)
+
+	type args struct {
+		url string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case",
+			args: args{url: "/api/v1/accounts/211001000000001/hierarchy?balanceDate=2024-04-30"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountHierarchy(gomock.AssignableToTypeOf(context.Background()), "211001000000001", gomock.Any()).
+					Return(models.AccountHierarchy{
+						AccountNumber:   "211001000000001",
+						AccountName:     "Lender Master",
+						CoaTypeCode:     "LIA",
+						SubCategoryCode: "21101",
+						Currency:        "IDR",
+						BalanceDate:     balanceDate,
+						RolledUpBalance: decimal.NewFromInt(100000),
+						Children: []models.AccountHierarchy{{
+							AccountNumber:   "211001000381110",
+							AccountName:     "Lender CIH",
+							CoaTypeCode:     "LIA",
+							SubCategoryCode: "21102",
+							Currency:        "IDR",
+							Relation:        models.AccountRelationChild,
+							BalanceDate:     balanceDate,
+							Balance:         decimal.NewFromInt(100000),
+							RolledUpBalance: decimal.NewFromInt(100000),
+						}},
+					}, nil)
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"accountHierarchy","accountNumber":"211001000000001","accountName":"Lender Master","coaTypeCode":"LIA","subCategoryCode":"21101","currency":"IDR","balanceDate":"2024-04-30","balance":"0","rolledUpBalance":"100000","children":[{"kind":"accountHierarchy","accountNumber":"211001000381110","accountName":"Lender CIH","coaTypeCode":"LIA","subCategoryCode":"21102","currency":"IDR","relation":"child","balanceDate":"2024-04-30","balance":"100000","rolledUpBalance":"100000","children":[]}]}`,
+				wantCode: 200,
+			},
+		},
+		{
+			name: "error case - invalid balance date",
+			args: args{url: "/api/v1/accounts/211001000000001/hierarchy?balanceDate=30-04-2024"},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"invalid format date caused by balanceDate format must be YYYY-MM-DD"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error case - account not found",
+			args: args{url: "/api/v1/accounts/211001000000001/hierarchy"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountHierarchy(gomock.AssignableToTypeOf(context.Background()), "211001000000001", gomock.Any()).
+					Return(models.AccountHierarchy{}, models.GetErrMap(models.ErrKeyAccountNumberNotFound))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"account number not found"}`,
+				wantCode: 404,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.args.url, nil)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
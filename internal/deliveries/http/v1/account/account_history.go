@@ -0,0 +1,51 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Get account history
+// @Description Get the change log of the account with the before & after value, the actor and the reason of every change
+// @Tags 		Accounts
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param 	accountNumber path string true "account identifier"
+// @Param   params query models.DoGetAccountHistoryRequest true "Get account history query parameters"
+// @Success 200 {object} commonhttp.RestTotalRowResponseModel{contents=[]models.AccountChangeLogResponse} "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Data not found. This can happen if data not found while get account history"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while get account history"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get account history"
+// @Router 	/v1/accounts/{accountNumber}/history [get]
+func (ah accountHandler) getAccountHistory(c echo.Context) error {
+	req := new(models.DoGetAccountHistoryRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	res, err := ah.service.GetAccountHistory(c.Request().Context(), req.ToFilterOpts())
+	if err != nil {
+		if errors.Is(err, models.GetErrMap(models.ErrKeyAccountNumberNotFound)) {
+			return commonhttp.RestErrorResponse(c, http.StatusNotFound, err)
+		}
+		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	data := []models.AccountChangeLogResponse{}
+	for _, v := range res {
+		data = append(data, v.ToResponse())
+	}
+
+	return commonhttp.RestSuccessResponseListWithTotalRows(c, data, len(data))
+}
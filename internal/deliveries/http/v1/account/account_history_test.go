@@ -0,0 +1,123 @@
+package account
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_getAccountHistory(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	type args struct {
+		url string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case",
+			args: args{url: "/api/v1/accounts/22200100000001/history?field=name"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountHistory(gomock.AssignableToTypeOf(context.Background()), models.AccountChangeLogFilterOptions{
+						AccountNumber: "22200100000001",
+						Field:         models.AccountChangeLogFieldName,
+					}).
+					Return([]models.AccountChangeLog{{
+						ID:            1,
+						AccountNumber: "22200100000001",
+						Field:         models.AccountChangeLogFieldName,
+						BeforeValue:   "Lender Yang Baik",
+						AfterValue:    "Lender Yang Sangat Baik",
+						ChangedBy:     "tono@amartha.com",
+						Reason:        "customer name correction",
+						CreatedAt:     time.Date(2024, 4, 30, 13, 0, 0, 0, time.UTC),
+					}}, nil)
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"collection","contents":[{"kind":"accountChangeLog","id":1,"accountNumber":"22200100000001","field":"name","beforeValue":"Lender Yang Baik","afterValue":"Lender Yang Sangat Baik","changedBy":"tono@amartha.com","reason":"customer name correction","createdAt":"2024-04-30T13:00:00Z"}],"total_rows":1}`,
+				wantCode: 200,
+			},
+		},
+		{
+			name: "success case - empty history",
+			args: args{url: "/api/v1/accounts/22200100000001/history"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountHistory(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, nil)
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"collection","contents":[],"total_rows":0}`,
+				wantCode: 200,
+			},
+		},
+		{
+			name: "error case - validation",
+			args: args{url: "/api/v1/accounts/22200100000001/history?field=balance"},
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"INVALID_VALUES","field":"field","message":"one of name ownerId altId legacyId entityCode status metadata"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error case - account number not found",
+			args: args{url: "/api/v1/accounts/22200100000001/history"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountHistory(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, models.GetErrMap(models.ErrKeyAccountNumberNotFound))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"account number not found"}`,
+				wantCode: 404,
+			},
+		},
+		{
+			name: "error case - internal server error",
+			args: args{url: "/api/v1/accounts/22200100000001/history"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountHistory(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.args.url, nil)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
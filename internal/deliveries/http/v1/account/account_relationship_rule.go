@@ -0,0 +1,186 @@
+package account
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Create Account Relationship Rule
+// @Description Create the rule evaluated when an account of the source sub category is created, the target account is generated or linked by the relation.
+// @Description A rule with the source account number applies only to the account, a rule with the target account number links the fixed target account
+// @Tags 		Account Relationship Rules
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	payload body models.CreateAccountRelationshipRuleRequest true "A JSON object containing create account relationship rule payload"
+// @Success 201 {object} models.AccountRelationshipRuleResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the source or target account is not in the sub category of the rule"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the source or target sub category or account is not found"
+// @Failure 409 {object} commonhttp.RestErrorResponseModel "Data is exist. This can happen if the source sub category already has a rule of the relation"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while create account relationship rule"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while create account relationship rule"
+// @Router 	/v1/account-relationship-rules [post]
+func (ah accountHandler) createAccountRelationshipRule(c echo.Context) error {
+	req := new(models.CreateAccountRelationshipRuleRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := ah.service.CreateAccountRelationshipRule(c.Request().Context(), req.ToAccountRelationshipRule())
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		} else if strings.Contains(err.Error(), models.ErrCodeDataIsExist) {
+			code = http.StatusConflict
+		} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusCreated, out.ToResponse())
+}
+
+// @Summary 	Get Account Relationship Rules
+// @Description Get Account Relationship Rules
+// @Tags 		Account Relationship Rules
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param   params query models.GetAccountRelationshipRuleRequest true "Get account relationship rules query parameters"
+// @Success 200 {object} commonhttp.RestTotalRowResponseModel{contents=[]models.AccountRelationshipRuleResponse{}} "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while get account relationship rules"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while get account relationship rules"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get account relationship rules"
+// @Router 	/v1/account-relationship-rules [get]
+func (ah accountHandler) getAccountRelationshipRules(c echo.Context) error {
+	queryFilter := new(models.GetAccountRelationshipRuleRequest)
+	if err := c.Bind(queryFilter); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(queryFilter); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	res, err := ah.service.GetAccountRelationshipRules(c.Request().Context(), queryFilter.ToFilterOpts())
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	data := []models.AccountRelationshipRuleResponse{}
+	for _, v := range res {
+		data = append(data, v.ToResponse())
+	}
+
+	return commonhttp.RestSuccessResponseListWithTotalRows(c, data, len(data))
+}
+
+// @Summary 	Get Account Relationship Rule
+// @Description Get Account Relationship Rule by id
+// @Tags 		Account Relationship Rules
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	id path int true "Account Relationship Rule Id"
+// @Success 200 {object} models.AccountRelationshipRuleResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the id is not a number"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the account relationship rule is not found"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get account relationship rule"
+// @Router 	/v1/account-relationship-rules/{id} [get]
+func (ah accountHandler) getAccountRelationshipRuleById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	out, err := ah.service.GetAccountRelationshipRuleById(c.Request().Context(), id)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
+
+// @Summary 	Update Account Relationship Rule
+// @Description Update the target sub category, target account number, cardinality, naming template and status of the account relationship rule
+// @Tags 		Account Relationship Rules
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	id path int true "Account Relationship Rule Id"
+// @Param	payload body models.UpdateAccountRelationshipRuleRequest true "A JSON object containing update account relationship rule payload"
+// @Success 200 {object} models.AccountRelationshipRuleResponse "Response indicates that the request succeeded and the resources has been retransmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the target account is not in the target sub category"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the account relationship rule, the target sub category or the target account is not found"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while update account relationship rule"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while update account relationship rule"
+// @Router 	/v1/account-relationship-rules/{id} [put]
+func (ah accountHandler) updateAccountRelationshipRule(c echo.Context) error {
+	req := new(models.UpdateAccountRelationshipRuleRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := ah.service.UpdateAccountRelationshipRule(c.Request().Context(), req.ToAccountRelationshipRule())
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
+
+// @Summary 	Delete Account Relationship Rule
+// @Description Delete Account Relationship Rule by id, the existing relationships of the accounts are kept
+// @Tags 		Account Relationship Rules
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	id path int true "Account Relationship Rule Id"
+// @Success 204 "Response indicates that the request succeeded and the resources has been deleted"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the id is not a number"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the account relationship rule is not found"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while delete account relationship rule"
+// @Router 	/v1/account-relationship-rules/{id} [delete]
+func (ah accountHandler) deleteAccountRelationshipRule(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err = ah.service.DeleteAccountRelationshipRule(c.Request().Context(), id); err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
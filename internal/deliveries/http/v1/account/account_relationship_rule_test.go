@@ -0,0 +1,397 @@
+package account
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testAccountRelationshipRule = models.AccountRelationshipRule{
+	ID:                    1,
+	SourceSubCategoryCode: "21101",
+	TargetSubCategoryCode: "21201",
+	Relation:              models.AccountRelationInvested,
+	Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+	NameTemplate:          "{name} - Invested",
+	Status:                models.StatusActive,
+	CreatedAt:             time.Date(2024, 4, 30, 13, 0, 0, 0, time.UTC),
+	UpdatedAt:             time.Date(2024, 4, 30, 13, 0, 0, 0, time.UTC),
+}
+
+const testAccountRelationshipRuleResponse = `{"kind":"accountRelationshipRule","id":1,"sourceSubCategoryCode":"21101","targetSubCategoryCode":"21201","relation":"invested","cardinality":"oneToOne","nameTemplate":"{name} - Invested","status":"active","createdAt":"2024-04-30T13:00:00Z","updatedAt":"2024-04-30T13:00:00Z"}`
+
+func Test_Handler_createAccountRelationshipRule(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	type args struct {
+		req string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case",
+			args: args{req: `{"sourceSubCategoryCode":"21101","targetSubCategoryCode":"21201","relation":"invested","cardinality":"oneToOne","nameTemplate":"{name} - Invested"}`},
+			doMock: func(args args, expectation expectation) {
+				rule := testAccountRelationshipRule
+				testHelper.mockAccountService.EXPECT().
+					CreateAccountRelationshipRule(gomock.AssignableToTypeOf(context.Background()), models.AccountRelationshipRule{
+						SourceSubCategoryCode: "21101",
+						TargetSubCategoryCode: "21201",
+						Relation:              models.AccountRelationInvested,
+						Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+						NameTemplate:          "{name} - Invested",
+						Status:                models.StatusActive,
+					}).
+					Return(&rule, nil)
+			},
+			expectation: expectation{
+				wantRes:  testAccountRelationshipRuleResponse,
+				wantCode: 201,
+			},
+		},
+		{
+			name: "error case - validation",
+			args: args{req: `{"sourceSubCategoryCode":"21101","targetSubCategoryCode":"21201","relation":"parent","cardinality":"oneToOne"}`},
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"INVALID_VALUES","field":"relation","message":"one of invested receivables loanAdvancePayment"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error case - sub category not found",
+			args: args{req: `{"sourceSubCategoryCode":"21101","targetSubCategoryCode":"21201","relation":"invested","cardinality":"oneToOne"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					CreateAccountRelationshipRule(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, models.GetErrMap(models.ErrKeySubCategoryCodeNotFound))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"sub category code not found"}`,
+				wantCode: 404,
+			},
+		},
+		{
+			name: "error case - rule is exist",
+			args: args{req: `{"sourceSubCategoryCode":"21101","targetSubCategoryCode":"21201","relation":"invested","cardinality":"oneToOne"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					CreateAccountRelationshipRule(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, models.GetErrMap(models.ErrKeyAccountRelationshipRuleIsExist))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_IS_EXIST","message":"account relationship rule of the source sub category and relation is exist"}`,
+				wantCode: 409,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/account-relationship-rules", strings.NewReader(tt.args.req))
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_getAccountRelationshipRules(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	type args struct {
+		url string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case",
+			args: args{url: "/api/v1/account-relationship-rules?sourceSubCategoryCode=21101&status=active"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountRelationshipRules(gomock.AssignableToTypeOf(context.Background()), models.AccountRelationshipRuleFilterOptions{
+						SourceSubCategoryCode: "21101",
+						Status:                models.StatusActive,
+					}).
+					Return([]models.AccountRelationshipRule{testAccountRelationshipRule}, nil)
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"collection","contents":[` + testAccountRelationshipRuleResponse + `],"total_rows":1}`,
+				wantCode: 200,
+			},
+		},
+		{
+			name: "error case - validation",
+			args: args{url: "/api/v1/account-relationship-rules?status=deleted"},
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"INVALID_VALUES","field":"status","message":"one of active or inactive"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error case - internal server error",
+			args: args{url: "/api/v1/account-relationship-rules"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountRelationshipRules(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.args.url, nil)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_getAccountRelationshipRuleById(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	type args struct {
+		url string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case",
+			args: args{url: "/api/v1/account-relationship-rules/1"},
+			doMock: func(args args, expectation expectation) {
+				rule := testAccountRelationshipRule
+				testHelper.mockAccountService.EXPECT().
+					GetAccountRelationshipRuleById(gomock.AssignableToTypeOf(context.Background()), 1).
+					Return(&rule, nil)
+			},
+			expectation: expectation{
+				wantRes:  testAccountRelationshipRuleResponse,
+				wantCode: 200,
+			},
+		},
+		{
+			name: "error case - invalid id",
+			args: args{url: "/api/v1/account-relationship-rules/abc"},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":400,"message":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error case - not found",
+			args: args{url: "/api/v1/account-relationship-rules/1"},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					GetAccountRelationshipRuleById(gomock.AssignableToTypeOf(context.Background()), 1).
+					Return(nil, models.GetErrMap(models.ErrKeyAccountRelationshipRuleNotFound))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"account relationship rule not found"}`,
+				wantCode: 404,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, tt.args.url, nil)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_updateAccountRelationshipRule(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	type args struct {
+		req string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case",
+			args: args{req: `{"targetSubCategoryCode":"21201","cardinality":"oneToOne","nameTemplate":"{name} - Invested","status":"active"}`},
+			doMock: func(args args, expectation expectation) {
+				rule := testAccountRelationshipRule
+				testHelper.mockAccountService.EXPECT().
+					UpdateAccountRelationshipRule(gomock.AssignableToTypeOf(context.Background()), models.AccountRelationshipRule{
+						ID:                    1,
+						TargetSubCategoryCode: "21201",
+						Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+						NameTemplate:          "{name} - Invested",
+						Status:                models.StatusActive,
+					}).
+					Return(&rule, nil)
+			},
+			expectation: expectation{
+				wantRes:  testAccountRelationshipRuleResponse,
+				wantCode: 200,
+			},
+		},
+		{
+			name: "error case - validation",
+			args: args{req: `{"targetSubCategoryCode":"21201","cardinality":"manyToMany","status":"active"}`},
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"INVALID_VALUES","field":"cardinality","message":"one of oneToOne manyToOne"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error case - not found",
+			args: args{req: `{"targetSubCategoryCode":"21201","cardinality":"oneToOne","status":"inactive"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					UpdateAccountRelationshipRule(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(nil, models.GetErrMap(models.ErrKeyAccountRelationshipRuleNotFound))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"account relationship rule not found"}`,
+				wantCode: 404,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodPut, "/api/v1/account-relationship-rules/1", strings.NewReader(tt.args.req))
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_deleteAccountRelationshipRule(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name: "success case",
+			doMock: func() {
+				testHelper.mockAccountService.EXPECT().
+					DeleteAccountRelationshipRule(gomock.AssignableToTypeOf(context.Background()), 1).
+					Return(nil)
+			},
+			expectation: expectation{
+				wantCode: 204,
+			},
+		},
+		{
+			name: "error case - not found",
+			doMock: func() {
+				testHelper.mockAccountService.EXPECT().
+					DeleteAccountRelationshipRule(gomock.AssignableToTypeOf(context.Background()), 1).
+					Return(models.GetErrMap(models.ErrKeyAccountRelationshipRuleNotFound))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"account relationship rule not found"}`,
+				wantCode: 404,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			r := httptest.NewRequest(http.MethodDelete, "/api/v1/account-relationship-rules/1", nil)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
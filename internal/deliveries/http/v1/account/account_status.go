@@ -0,0 +1,56 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Update Account Status
+// @Description Move the account to active, frozen_credit_only, frozen_debit_only, dormant or closed, closing the account requires a zero balance
+// @Tags 		Accounts
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	accountNumber path string true "account identifier"
+// @Param 	payload body models.DoUpdateAccountStatusRequest true "A JSON object containing update account status payload"
+// @Success 200 {object} models.DoUpdateAccountStatusResponse "Response indicates that the request succeeded and the resources has been retransmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the status transition is not allowed or the balance is not zero while close the account"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Data not found. This can happen if there is an data not found while update account status"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while update account status"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while update account status"
+// @Router 	/v1/accounts/{accountNumber}/status [patch]
+func (ah accountHandler) updateAccountStatus(c echo.Context) error {
+	req := new(models.DoUpdateAccountStatusRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	res, err := ah.service.UpdateAccountStatus(c.Request().Context(), models.UpdateAccountStatus{
+		AccountNumber: req.AccountNumber,
+		Status:        req.Status,
+		ChangedBy:     req.UpdatedBy,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		if errors.Is(err, models.GetErrMap(models.ErrKeyAccountNumberNotFound)) {
+			return commonhttp.RestErrorResponse(c, http.StatusNotFound, err)
+		}
+		if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+		}
+		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, res.ToResponse())
+}
@@ -0,0 +1,118 @@
+package account
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_updateAccountStatus(t *testing.T) {
+	testHelper := accountTestHelper(t)
+
+	type args struct {
+		req string
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case",
+			args: args{req: `{"status":"frozen_credit_only"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					UpdateAccountStatus(gomock.AssignableToTypeOf(context.Background()), models.UpdateAccountStatus{
+						AccountNumber: "22200100000001",
+						Status:        models.AccountStatusFrozenCreditOnly,
+					}).
+					Return(models.UpdateAccountStatus{
+						AccountNumber: "22200100000001",
+						Status:        models.AccountStatusFrozenCreditOnly,
+					}, nil)
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"account","accountNumber":"22200100000001","status":"frozen_credit_only"}`,
+				wantCode: 200,
+			},
+		},
+		{
+			name: "error case - validation",
+			args: args{req: `{}`},
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"MISSING_FIELD","field":"status","message":"field is missing"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error case - account number not found",
+			args: args{req: `{"status":"dormant"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					UpdateAccountStatus(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(models.UpdateAccountStatus{}, models.GetErrMap(models.ErrKeyAccountNumberNotFound))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"account number not found"}`,
+				wantCode: 404,
+			},
+		},
+		{
+			name: "error case - balance is not zero",
+			args: args{req: `{"status":"closed"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					UpdateAccountStatus(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(models.UpdateAccountStatus{}, models.GetErrMap(models.ErrKeyAccountBalanceNotZero))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"account balance must be zero to close the account"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error case - internal server error",
+			args: args{req: `{"status":"dormant"}`},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountService.EXPECT().
+					UpdateAccountStatus(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+					Return(models.UpdateAccountStatus{}, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodPatch, "/api/v1/accounts/22200100000001/status", strings.NewReader(tt.args.req))
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -1241,15 +1241,20 @@ func Test_Handler_uploadAccount(t *testing.T) {
 		{
 			name: "happy path",
 			expectation: expectation{
-				wantRes:  `"success"`,
-				wantCode: 200,
+				wantRes:  `{"kind":"uploadJob","id":1,"type":"account","filename":"upload_accounts.csv","status":"pending","totalRows":0,"processedRows":0,"successRows":0,"failedRows":0,"failures":[],"startedAt":null,"finishedAt":null,"createdAt":"0001-01-01T00:00:00Z","updatedAt":"0001-01-01T00:00:00Z"}`,
+				wantCode: 202,
 			},
 			args: args{
 				ctx:      context.TODO(),
 				fileName: "../../../../../storages/upload_accounts.csv",
 			},
 			doMock: func(args args) {
-				testHelper.mockAccountService.EXPECT().ProcessUploadAccounts(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockUploadJobService.EXPECT().CreateUploadJob(gomock.Any(), gomock.Any()).Return(&models.UploadJob{
+					ID:       1,
+					Type:     models.UploadJobTypeAccount,
+					Filename: "upload_accounts.csv",
+					Status:   models.UploadJobStatusPending,
+				}, nil)
 			},
 		},
 		{
@@ -1263,7 +1268,7 @@ func Test_Handler_uploadAccount(t *testing.T) {
 				fileName: "../../../../../storages/upload_accounts.csv",
 			},
 			doMock: func(args args) {
-				testHelper.mockAccountService.EXPECT().ProcessUploadAccounts(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				testHelper.mockUploadJobService.EXPECT().CreateUploadJob(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 			},
 		},
 		{
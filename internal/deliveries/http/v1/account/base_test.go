@@ -18,9 +18,10 @@ func TestMain(m *testing.M) {
 }
 
 type testAccountHelper struct {
-	router             *echo.Echo
-	mockCtrl           *gomock.Controller
-	mockAccountService *mock.MockAccountService
+	router               *echo.Echo
+	mockCtrl             *gomock.Controller
+	mockAccountService   *mock.MockAccountService
+	mockUploadJobService *mock.MockUploadJobService
 }
 
 func accountTestHelper(t *testing.T) testAccountHelper {
@@ -30,14 +31,16 @@ func accountTestHelper(t *testing.T) testAccountHelper {
 	defer mockCtrl.Finish()
 
 	mockAccountSvc := mock.NewMockAccountService(mockCtrl)
+	mockUploadJobSvc := mock.NewMockUploadJobService(mockCtrl)
 
 	app := echo.New()
 	v1Group := app.Group("/api/v1")
-	New(v1Group, mockAccountSvc)
+	New(v1Group, mockAccountSvc, mockUploadJobSvc)
 
 	return testAccountHelper{
-		router:             app,
-		mockCtrl:           mockCtrl,
-		mockAccountService: mockAccountSvc,
+		router:               app,
+		mockCtrl:             mockCtrl,
+		mockAccountService:   mockAccountSvc,
+		mockUploadJobService: mockUploadJobSvc,
 	}
 }
@@ -10,19 +10,24 @@ type accountingHandler struct {
 	services.AccountingService
 	services.JournalService
 	services.TrialBalanceService
+	services.UploadJobService
 }
 
-func New(app *echo.Group, accountingSrv services.AccountingService, journalSrv services.JournalService, trialBalanceSrv services.TrialBalanceService) {
+// New registers the accounting routes, directPosting guards the manual journals posted without the maker-checker drafts.
+func New(app *echo.Group, accountingSrv services.AccountingService, journalSrv services.JournalService, trialBalanceSrv services.TrialBalanceService, uploadJobSrv services.UploadJobService, directPosting echo.MiddlewareFunc) {
 	ah := accountingHandler{
 		accountingSrv,
 		journalSrv,
 		trialBalanceSrv,
+		uploadJobSrv,
 	}
 	trialBalance := app.Group("/trial-balances")
 	trialBalance.GET("", ah.getTrialBalance)
 	trialBalance.GET("/download", ah.downloadCSVgetTrialBalance)
+	trialBalance.GET("/consolidated", ah.getConsolidatedTrialBalance)
 	trialBalance.POST("/:period/close", ah.closeTrialBalance)
 	trialBalance.POST("/adjustment", ah.adjustmentTrialBalance)
+	trialBalance.POST("/fiscal-years/:fiscalYear/close", ah.closeFiscalYear)
 
 	trialBalance.GET("/details", ah.getTrialBalanceDetails)
 	trialBalance.GET("/sub-categories/:subCategoryCode", ah.getTrialBalanceBySubCategoryCode)
@@ -40,14 +45,37 @@ func New(app *echo.Group, accountingSrv services.AccountingService, journalSrv s
 	subLedger.GET("/download", ah.downloadSubLedgerCSV)
 
 	journal := app.Group("/journals")
-	journal.POST("", ah.create)
+	journal.POST("", ah.create, directPosting)
 	journal.POST("/publish", ah.publish)
-	journal.POST("/upload", ah.uploadJournal)
+	journal.POST("/upload", ah.uploadJournal, directPosting)
+	journal.POST("/drafts", ah.createJournalDraft)
+	journal.GET("/drafts", ah.getJournalDrafts)
+	journal.GET("/drafts/:id", ah.getJournalDraftById)
+	journal.POST("/drafts/:id/submit", ah.submitJournalDraft)
+	journal.POST("/drafts/:id/approve", ah.approveJournalDraft)
+	journal.POST("/drafts/:id/reject", ah.rejectJournalDraft)
 	journal.GET("/:transactionId", ah.getByTransactionId)
+	journal.POST("/:transactionId/reverse", ah.reverse)
+
+	recurringJournal := app.Group("/recurring-journals")
+	recurringJournal.POST("", ah.createRecurringJournal)
+	recurringJournal.GET("", ah.getRecurringJournals)
+	recurringJournal.GET("/:id", ah.getRecurringJournalById)
+
+	suspense := app.Group("/suspense")
+	suspense.GET("/ageing", ah.getSuspenseAgeing)
 
 	balanceSheet := app.Group("/balance-sheets")
 	balanceSheet.GET("", ah.getBalanceSheet)
 	balanceSheet.GET("/download", ah.downloadCSVBalanceSheet)
 
+	profitLoss := app.Group("/profit-loss")
+	profitLoss.GET("", ah.getProfitLoss)
+	profitLoss.GET("/download", ah.downloadCSVProfitLoss)
+
+	cashFlow := app.Group("/cash-flow")
+	cashFlow.GET("", ah.getCashFlow)
+	cashFlow.GET("/download", ah.downloadCSVCashFlow)
+
 	app.POST("/jobs/trial-balance", ah.runJobTrialBalance)
 }
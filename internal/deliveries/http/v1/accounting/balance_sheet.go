@@ -90,7 +90,11 @@ func (ah accountingHandler) downloadCSVBalanceSheet(c echo.Context) error {
 
 	b, filename, err := ah.DownloadCSVGetBalanceSheet(c.Request().Context(), *opts, res)
 	if err != nil {
-		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyCurrencyNotFound)) {
+			statusCode = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, statusCode, err)
 	}
 
 	return commonhttp.RestSuccessResponseCSV(c, b, filename)
@@ -77,6 +77,54 @@ func Test_Handler_getBalanceSheet(t *testing.T) {
 				testHelper.mockAccountingService.EXPECT().GetBalanceSheet(args.ctx, *defaultOpts).Return(models.GetBalanceSheetResponse{}, models.GetErrMap(models.ErrKeyDatabaseError))
 			},
 		},
+		{
+			name: "error case - too many compare dates",
+			args: args{
+				ctx:         context.Background(),
+				contentType: echo.MIMEApplicationJSON,
+				req: url.Values{
+					"entityCode":       []string{"111"},
+					"balanceSheetDate": []string{"2023-12-31"},
+					"compareDates":     []string{"2023-11-30,2023-10-31,2023-09-30,2023-08-31,2022-12-31"},
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":400,"message":"compareDates can have a maximum of 4 dates"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error case - compare date is the balance sheet date",
+			args: args{
+				ctx:         context.Background(),
+				contentType: echo.MIMEApplicationJSON,
+				req: url.Values{
+					"entityCode":       []string{"111"},
+					"balanceSheetDate": []string{"2023-12-31"},
+					"compareDates":     []string{"2023-11-30,2023-12-31"},
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"compare date cannot be the balance sheet date or repeated caused by date 2023-12-31 is duplicated"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error case - repeated compare date",
+			args: args{
+				ctx:         context.Background(),
+				contentType: echo.MIMEApplicationJSON,
+				req: url.Values{
+					"entityCode":       []string{"111"},
+					"balanceSheetDate": []string{"2023-12-31"},
+					"compareDates":     []string{"2023-11-30, 2023-11-30"},
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"compare date cannot be the balance sheet date or repeated caused by date 2023-11-30 is duplicated"}`,
+				wantCode: 400,
+			},
+		},
 		{
 			name: "error case - entity code not found",
 			args: args{
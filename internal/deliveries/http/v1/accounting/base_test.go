@@ -21,6 +21,7 @@ type testAccountHelper struct {
 	mockCtrl              *gomock.Controller
 	mockAccountingService *mock.MockAccountingService
 	mockJournalService    *mock.MockJournalService
+	mockUploadJobService  *mock.MockUploadJobService
 }
 
 func accountingTestHelper(t *testing.T) testAccountHelper {
@@ -32,15 +33,17 @@ func accountingTestHelper(t *testing.T) testAccountHelper {
 	mockAccountingSvc := mock.NewMockAccountingService(mockCtrl)
 	mockJournalSvc := mock.NewMockJournalService(mockCtrl)
 	mockTrialBalanceSvc := mock.NewMockTrialBalanceService(mockCtrl)
+	mockUploadJobSvc := mock.NewMockUploadJobService(mockCtrl)
 
 	app := echo.New()
 	v1Group := app.Group("/api/v1")
-	New(v1Group, mockAccountingSvc, mockJournalSvc, mockTrialBalanceSvc)
+	New(v1Group, mockAccountingSvc, mockJournalSvc, mockTrialBalanceSvc, mockUploadJobSvc, func(next echo.HandlerFunc) echo.HandlerFunc { return next })
 
 	return testAccountHelper{
 		router:                app,
 		mockCtrl:              mockCtrl,
 		mockAccountingService: mockAccountingSvc,
 		mockJournalService:    mockJournalSvc,
+		mockUploadJobService:  mockUploadJobSvc,
 	}
 }
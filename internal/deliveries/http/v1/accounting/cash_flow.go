@@ -0,0 +1,101 @@
+package accounting
+
+import (
+	"errors"
+	"net/http"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Get Cash Flow
+// @Description Get Cash Flow
+// @Tags 		Accounting
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param   params query models.GetCashFlowRequest true "Get cash flow query parameters"
+// @Success 200 {object} models.GetCashFlowResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while get cash flow"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if there is an error while get cash flow"
+// @Failure 422 {object} commonhttp.RestErrorResponseModel "Validation error. This can happen if there is an error while get cash flow"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get cash flow"
+// @Router 	/v1/cash-flow [get]
+func (ah accountingHandler) getCashFlow(c echo.Context) error {
+	queryFilter := new(models.GetCashFlowRequest)
+	if err := c.Bind(queryFilter); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(queryFilter); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	opts, err := queryFilter.ToFilterOpts()
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	res, err := ah.GetCashFlow(c.Request().Context(), *opts)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyEntityCodeNotFound)) {
+			statusCode = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, statusCode, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, res)
+}
+
+// @Summary 	Download CSV Cash Flow
+// @Description Download CSV Cash Flow
+// @Tags 		Accounting
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param   params query models.GetCashFlowRequest true "Download cash flow query parameters"
+// @Success 200 {object} models.GetCashFlowResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while download cash flow"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if there is an error while download cash flow"
+// @Failure 422 {object} commonhttp.RestErrorResponseModel "Validation error. This can happen if there is an error while download cash flow"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while download cash flow"
+// @Router 	/v1/cash-flow/download [get]
+func (ah accountingHandler) downloadCSVCashFlow(c echo.Context) error {
+	queryFilter := new(models.GetCashFlowRequest)
+	if err := c.Bind(queryFilter); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(queryFilter); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	opts, err := queryFilter.ToFilterOpts()
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	res, err := ah.GetCashFlow(c.Request().Context(), *opts)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyEntityCodeNotFound)) {
+			statusCode = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, statusCode, err)
+	}
+
+	b, filename, err := ah.DownloadCSVGetCashFlow(c.Request().Context(), *opts, res)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyCurrencyNotFound)) {
+			statusCode = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, statusCode, err)
+	}
+
+	return commonhttp.RestSuccessResponseCSV(c, b, filename)
+}
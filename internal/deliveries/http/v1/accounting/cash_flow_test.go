@@ -0,0 +1,240 @@
+package accounting
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_getCashFlow(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	defaultQueryFilter := new(models.GetCashFlowRequest)
+	defaultQueryFilter.EntityCode = "111"
+	defaultQueryFilter.Period = "2023-12"
+	defaultOpts, _ := defaultQueryFilter.ToFilterOpts()
+
+	type args struct {
+		ctx         context.Context
+		contentType string
+		req         url.Values
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	testCases := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case - get cash flow",
+			args: args{
+				ctx:         context.Background(),
+				contentType: echo.MIMEApplicationJSON,
+				req: url.Values{
+					"entityCode": []string{"111"},
+					"period":     []string{"2023-12"},
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"","entityCode":"","entityName":"","entityDesc":"","startDate":"","endDate":"","openingBalance":"","activities":null,"netCashFlow":"","closingBalance":""}`,
+				wantCode: 200,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountingService.EXPECT().GetCashFlow(args.ctx, *defaultOpts).Return(models.GetCashFlowResponse{}, nil)
+			},
+		},
+		{
+			name: "error case - period and date range are filled",
+			args: args{
+				ctx:         context.Background(),
+				contentType: echo.MIMEApplicationJSON,
+				req: url.Values{
+					"entityCode": []string{"111"},
+					"period":     []string{"2023-12"},
+					"startDate":  []string{"2023-12-01"},
+					"endDate":    []string{"2023-12-31"},
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":400,"message":"cannot use both 'period' and 'startDate/endDate' in the same request"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error case - database error",
+			args: args{
+				ctx:         context.Background(),
+				contentType: echo.MIMEApplicationJSON,
+				req: url.Values{
+					"entityCode": []string{"111"},
+					"period":     []string{"2023-12"},
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountingService.EXPECT().GetCashFlow(args.ctx, *defaultOpts).Return(models.GetCashFlowResponse{}, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+		},
+		{
+			name: "error case - entity code not found",
+			args: args{
+				ctx:         context.Background(),
+				contentType: echo.MIMEApplicationJSON,
+				req: url.Values{
+					"entityCode": []string{"000"},
+					"period":     []string{"2023-12"},
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"entity code not found"}`,
+				wantCode: 404,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountingService.EXPECT().GetCashFlow(args.ctx, gomock.Any()).Return(models.GetCashFlowResponse{}, models.GetErrMap(models.ErrKeyEntityCodeNotFound))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock(tc.args, tc.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/cash-flow?%s", tc.args.req.Encode()), nil)
+			r.Header.Set(echo.HeaderContentType, tc.args.contentType)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tc.expectation.wantCode, w.Code)
+			require.Equal(t, tc.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_downloadCSVCashFlow(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	defaultQueryFilter := new(models.GetCashFlowRequest)
+	defaultQueryFilter.EntityCode = "111"
+	defaultQueryFilter.Period = "2023-12"
+	defaultOpts, _ := defaultQueryFilter.ToFilterOpts()
+	req := url.Values{
+		"entityCode": []string{"111"},
+		"period":     []string{"2023-12"},
+	}
+	res := models.GetCashFlowResponse{
+		EntityCode:     "111",
+		StartDate:      "2023-12-01",
+		EndDate:        "2023-12-31",
+		OpeningBalance: "0,00",
+		Activities: []models.CashFlowActivity{
+			{
+				Activity: models.CashFlowActivityOperating,
+				Amount:   "0,00",
+			},
+		},
+		NetCashFlow:    "0,00",
+		ClosingBalance: "0,00",
+	}
+
+	type args struct {
+		ctx         context.Context
+		contentType string
+		req         url.Values
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success case - download cash flow",
+			args: args{
+				ctx:         context.Background(),
+				contentType: echo.MIMEApplicationJSON,
+				req:         req,
+			},
+			expectation: expectation{
+				wantCode: 200,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountingService.EXPECT().GetCashFlow(args.ctx, *defaultOpts).Return(res, nil)
+				testHelper.mockAccountingService.EXPECT().DownloadCSVGetCashFlow(gomock.Any(), *defaultOpts, res).Return(&bytes.Buffer{}, "", nil)
+			},
+		},
+		{
+			name: "error case - entity not found - get cash flow",
+			args: args{
+				ctx:         context.Background(),
+				contentType: echo.MIMEApplicationJSON,
+				req:         req,
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"entity code not found"}`,
+				wantCode: 404,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountingService.EXPECT().GetCashFlow(args.ctx, *defaultOpts).Return(res, models.GetErrMap(models.ErrKeyEntityCodeNotFound))
+			},
+		},
+		{
+			name: "error case - download csv cash flow",
+			args: args{
+				ctx:         context.Background(),
+				contentType: echo.MIMEApplicationJSON,
+				req:         req,
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+				wantCode: 500,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountingService.EXPECT().GetCashFlow(args.ctx, *defaultOpts).Return(res, nil)
+				testHelper.mockAccountingService.EXPECT().DownloadCSVGetCashFlow(gomock.Any(), *defaultOpts, res).Return(&bytes.Buffer{}, "", assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/cash-flow/download?%s", tt.args.req.Encode()), nil)
+			r.Header.Set(echo.HeaderContentType, tt.args.contentType)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package accounting
 
 import (
-	"context"
 	"errors"
 	"net/http"
 	"path"
@@ -15,7 +14,7 @@ import (
 )
 
 // @Summary 	Create Journal Transaction
-// @Description Create Journal Transaction
+// @Description Create Journal Transaction without the maker-checker drafts, it is only enabled by the PAS-BE-flag-journal-direct-posting flag
 // @Tags 		Accounting
 // @Accept  	json
 // @Produce  	json
@@ -23,6 +22,7 @@ import (
 // @Param	payload body models.JournalRequest true "A JSON object containing create journal payload"
 // @Success 201 {object} models.JournalResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
 // @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while create journal"
+// @Failure 403 {object} commonhttp.RestErrorResponseModel "Forbidden error. This can happen if the direct posting is disabled, the journal is posted through POST /v1/journals/drafts"
 // @Failure 409 {object} commonhttp.RestErrorResponseModel "Data is exist. This can happen if transactionId is exist"
 // @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while create journal"
 // @Router 	/v1/journals [post]
@@ -86,30 +86,69 @@ func (ah accountingHandler) publish(c echo.Context) error {
 }
 
 // @Summary 	Upload Journal Transaction
-// @Description Upload Journal Transaction
+// @Description Upload Journal Transaction from a csv or xlsx file, the columns are matched by the header & the rows with the same transaction_id are a journal.
+// @Description Every row is either a line with the account, debit & credit columns or numbered pairs of lines with the account_debitN, account_creditN & amountN columns.
+// @Description Set validateOnly to run every check of posting the journals without publishing them & get the report of every row.
+// @Description The journals are posted without the maker-checker drafts, it is only enabled by the PAS-BE-flag-journal-direct-posting flag
 // @Tags 		Accounting
 // @Accept		multipart/form-data
+// @Produce		json
 // @Produce		text/csv
 // @Param	X-Secret-Key header string true "X-Secret-Key"
-// @Param	file formData file true "csv file"
-// @Success 200 string success "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Param	file formData file true "csv or xlsx file"
+// @Param	validateOnly query bool false "validate the file without publishing the journals"
+// @Param	format query string false "format of the validation report" Enums(json, csv)
+// @Success 200 {object} models.UploadJournalReport "Response indicates that the request succeeded, the validation report is returned when validateOnly is true"
+// @Success 202 {object} models.UploadJobResponse "Response indicates that the file is stored & will be processed in the background, the progress is available in GET /v1/uploads/:id"
 // @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while upload journal"
+// @Failure 403 {object} commonhttp.RestErrorResponseModel "Forbidden error. This can happen if the direct posting is disabled"
 // @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while upload journal"
 // @Router 	/v1/journals/upload [post]
 func (ah accountingHandler) uploadJournal(c echo.Context) error {
+	req := models.UploadJournalRequest{
+		ValidateOnly: c.QueryParam("validateOnly"),
+		Format:       c.QueryParam("format"),
+	}
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
 	file, err := c.FormFile("file")
 	if err != nil {
 		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
 	}
 
-	if path.Ext(file.Filename) != ".csv" {
-		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, errors.New("file not csv"))
+	if ext := strings.ToLower(path.Ext(file.Filename)); ext != models.UploadJournalExtCSV && ext != models.UploadJournalExtXLSX {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, errors.New("file not csv or xlsx"))
 	}
 
-	ctx := context.WithoutCancel(c.Request().Context())
-	go ah.JournalService.ProcessUploadJournal(ctx, file)
+	if req.IsValidateOnly() {
+		report, err := ah.JournalService.ValidateUploadJournal(c.Request().Context(), file)
+		if err != nil {
+			return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+		}
+
+		if req.Format != models.UploadJournalReportFormatCSV {
+			return commonhttp.RestSuccessResponse(c, http.StatusOK, report)
+		}
+
+		b, filename, err := ah.JournalService.DownloadCSVUploadJournalReport(c.Request().Context(), report)
+		if err != nil {
+			return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+		}
 
-	return commonhttp.RestSuccessResponse(c, http.StatusOK, "processing")
+		return commonhttp.RestSuccessResponseCSV(c, b, filename)
+	}
+
+	job, err := ah.UploadJobService.CreateUploadJob(c.Request().Context(), models.CreateUploadJobRequest{
+		Type: models.UploadJobTypeJournal,
+		File: file,
+	})
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusAccepted, job.ToResponse())
 }
 
 // @Summary 	Get journal Detail by transaction id
@@ -139,3 +178,43 @@ func (ah accountingHandler) getByTransactionId(c echo.Context) error {
 
 	return commonhttp.RestSuccessResponseListWithTotalRows(c, data, len(data))
 }
+
+// @Summary 	Reverse Journal Transaction
+// @Description Reverse Journal Transaction, post a contra journal of the whole transaction or of the given journal lines
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param 	transactionId path string true "transaction identifier"
+// @Param	payload body models.ReverseJournalRequest true "A JSON object containing reverse journal payload"
+// @Success 201 {object} models.JournalResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while reverse journal"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Data not found. This can happen if transactionId or journalId not found"
+// @Failure 409 {object} commonhttp.RestErrorResponseModel "Data is exist. This can happen if journal already reversed"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while reverse journal"
+// @Router 	/v1/journals/:transactionId/reverse [post]
+func (ah accountingHandler) reverse(c echo.Context) error {
+	req := new(models.ReverseJournalRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	result, err := ah.JournalService.ReverseJournal(c.Request().Context(), *req)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		} else if strings.Contains(err.Error(), models.ErrCodeDataIsExist) {
+			code = http.StatusConflict
+		} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusCreated, result.ToResponse())
+}
@@ -0,0 +1,275 @@
+package accounting
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Create Journal Draft
+// @Description Create a manual journal draft, the journal is only posted when the draft is approved by a checker
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	X-User-Email header string true "email of the authenticated user set by the gateway, it is the maker"
+// @Param	payload body models.CreateJournalDraftRequest true "A JSON object containing create journal draft payload"
+// @Success 201 {object} models.JournalDraftResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the journal is not valid"
+// @Failure 401 {object} commonhttp.RestErrorResponseModel "Unauthorized error. This can happen if the user email is empty"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the account is not found"
+// @Failure 409 {object} commonhttp.RestErrorResponseModel "Data is exist. This can happen if transactionId is posted or has a pending draft"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while create journal draft"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while create journal draft"
+// @Router 	/v1/journals/drafts [post]
+func (ah accountingHandler) createJournalDraft(c echo.Context) error {
+	req := new(models.CreateJournalDraftRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	actor, err := commonhttp.GetActor(c)
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusUnauthorized, err)
+	}
+	req.CreatedBy = actor
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := ah.JournalService.CreateJournalDraft(c.Request().Context(), *req)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		} else if strings.Contains(err.Error(), models.ErrCodeDataIsExist) {
+			code = http.StatusConflict
+		} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusCreated, out.ToResponse())
+}
+
+// @Summary 	Get Journal Drafts
+// @Description Get Journal Drafts
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param   params query models.GetJournalDraftRequest true "Get journal drafts query parameters"
+// @Success 200 {object} commonhttp.RestTotalRowResponseModel{contents=[]models.JournalDraftResponse{}} "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while get journal drafts"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while get journal drafts"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get journal drafts"
+// @Router 	/v1/journals/drafts [get]
+func (ah accountingHandler) getJournalDrafts(c echo.Context) error {
+	queryFilter := new(models.GetJournalDraftRequest)
+	if err := c.Bind(queryFilter); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(queryFilter); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	res, err := ah.JournalService.GetJournalDrafts(c.Request().Context(), queryFilter.ToFilterOpts())
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	data := []models.JournalDraftResponse{}
+	for _, v := range res {
+		data = append(data, v.ToResponse())
+	}
+
+	return commonhttp.RestSuccessResponseListWithTotalRows(c, data, len(data))
+}
+
+// @Summary 	Get Journal Draft
+// @Description Get Journal Draft by id
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	id path int true "Journal Draft Id"
+// @Success 200 {object} models.JournalDraftResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the id is not a number"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the journal draft is not found"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get journal draft"
+// @Router 	/v1/journals/drafts/{id} [get]
+func (ah accountingHandler) getJournalDraftById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	out, err := ah.JournalService.GetJournalDraftById(c.Request().Context(), id)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
+
+// @Summary 	Submit Journal Draft
+// @Description Submit the journal draft for approval, only the maker can submit the draft
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	X-User-Email header string true "email of the authenticated user set by the gateway, it is the maker"
+// @Param	id path int true "Journal Draft Id"
+// @Success 200 {object} models.JournalDraftResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the draft is not in draft status"
+// @Failure 401 {object} commonhttp.RestErrorResponseModel "Unauthorized error. This can happen if the user email is empty"
+// @Failure 403 {object} commonhttp.RestErrorResponseModel "Forbidden error. This can happen if the submitter is not the maker"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the journal draft is not found"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while submit journal draft"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while submit journal draft"
+// @Router 	/v1/journals/drafts/{id}/submit [post]
+func (ah accountingHandler) submitJournalDraft(c echo.Context) error {
+	req := new(models.SubmitJournalDraftRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	actor, err := commonhttp.GetActor(c)
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusUnauthorized, err)
+	}
+	req.SubmittedBy = actor
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := ah.JournalService.SubmitJournalDraft(c.Request().Context(), *req)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyJournalDraftMakerOnly)) {
+			code = http.StatusForbidden
+		} else if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
+
+// @Summary 	Approve Journal Draft
+// @Description Approve the submitted journal draft & post the journal, the checker must not be the maker
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	X-User-Email header string true "email of the authenticated user set by the gateway, it is the checker"
+// @Param	id path int true "Journal Draft Id"
+// @Success 200 {object} models.JournalDraftResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the draft is not submitted or the journal is not valid"
+// @Failure 401 {object} commonhttp.RestErrorResponseModel "Unauthorized error. This can happen if the user email is empty"
+// @Failure 403 {object} commonhttp.RestErrorResponseModel "Forbidden error. This can happen if the checker is the maker"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the journal draft or the account is not found"
+// @Failure 409 {object} commonhttp.RestErrorResponseModel "Data is exist. This can happen if transactionId is already posted"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while approve journal draft"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while approve journal draft"
+// @Router 	/v1/journals/drafts/{id}/approve [post]
+func (ah accountingHandler) approveJournalDraft(c echo.Context) error {
+	req := new(models.ApproveJournalDraftRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	actor, err := commonhttp.GetActor(c)
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusUnauthorized, err)
+	}
+	req.ApprovedBy = actor
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := ah.JournalService.ApproveJournalDraft(c.Request().Context(), *req)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyJournalDraftSelfReview)) {
+			code = http.StatusForbidden
+		} else if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		} else if strings.Contains(err.Error(), models.ErrCodeDataIsExist) {
+			code = http.StatusConflict
+		} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
+
+// @Summary 	Reject Journal Draft
+// @Description Reject the submitted journal draft without posting it, the checker must not be the maker
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	X-User-Email header string true "email of the authenticated user set by the gateway, it is the checker"
+// @Param	id path int true "Journal Draft Id"
+// @Param	payload body models.RejectJournalDraftRequest true "A JSON object containing reject journal draft payload"
+// @Success 200 {object} models.JournalDraftResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the draft is not submitted"
+// @Failure 401 {object} commonhttp.RestErrorResponseModel "Unauthorized error. This can happen if the user email is empty"
+// @Failure 403 {object} commonhttp.RestErrorResponseModel "Forbidden error. This can happen if the checker is the maker"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the journal draft is not found"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while reject journal draft"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while reject journal draft"
+// @Router 	/v1/journals/drafts/{id}/reject [post]
+func (ah accountingHandler) rejectJournalDraft(c echo.Context) error {
+	req := new(models.RejectJournalDraftRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	actor, err := commonhttp.GetActor(c)
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusUnauthorized, err)
+	}
+	req.RejectedBy = actor
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := ah.JournalService.RejectJournalDraft(c.Request().Context(), *req)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyJournalDraftSelfReview)) {
+			code = http.StatusForbidden
+		} else if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
@@ -0,0 +1,386 @@
+package accounting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_createJournalDraft(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	ctx := context.Background()
+	req := models.CreateJournalDraftRequest{
+		JournalRequest: models.JournalRequest{
+			ReferenceNumber: "ADJ-001",
+			TransactionId:   "6de11650-dbee-4f67-9ade-ececc7a02571",
+			OrderType:       "ADJ",
+			TransactionDate: "2024-01-29 17:31:21",
+			ProcessingDate:  "2024-01-29 17:31:21",
+			Currency:        "IDR",
+			Transactions: []models.Transaction{
+				{TransactionType: "ADJIN", Account: "511001000000001", Amount: decimal.NewFromInt(100000), IsDebit: true},
+				{TransactionType: "ADJIN", Account: "141001000000001", Amount: decimal.NewFromInt(100000)},
+			},
+		},
+		CreatedBy: "tono@amartha.com",
+	}
+	out := req.ToJournalDraft()
+	out.ID = 1
+	successRes, _ := json.Marshal(out.ToResponse())
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		req         models.CreateJournalDraftRequest
+		actor       string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name:  "success",
+			req:   req,
+			actor: "tono@amartha.com",
+			expectation: expectation{
+				wantRes:  string(successRes),
+				wantCode: 201,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().CreateJournalDraft(ctx, req).Return(&out, nil)
+			},
+		},
+		{
+			name: "error - user email is empty",
+			req:  req,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":401,"message":"required user email"}`,
+				wantCode: 401,
+			},
+		},
+		{
+			name:  "error - pending draft is exist",
+			req:   req,
+			actor: "tono@amartha.com",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_IS_EXIST","message":"pending journal draft with the transaction id is exist"}`,
+				wantCode: 409,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().CreateJournalDraft(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyJournalDraftIsExist))
+			},
+		},
+		{
+			name:  "error - journal not balanced",
+			req:   req,
+			actor: "tono@amartha.com",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"total debit and total credit are not balanced"}`,
+				wantCode: 400,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().CreateJournalDraft(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyJournalNotBalanced))
+			},
+		},
+		{
+			name:  "error - database error",
+			req:   req,
+			actor: "tono@amartha.com",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().CreateJournalDraft(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			var b bytes.Buffer
+			err := json.NewEncoder(&b).Encode(tt.req)
+			require.NoError(t, err)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/journals/drafts", &b)
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			r.Header.Set(commonhttp.HeaderUserEmail, tt.actor)
+			w := httptest.NewRecorder()
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_getJournalDrafts(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	ctx := context.Background()
+
+	type expectation struct {
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		query       string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name:  "success",
+			query: "?status=submitted",
+			expectation: expectation{
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().
+					GetJournalDrafts(ctx, models.JournalDraftFilterOptions{Status: models.JournalDraftStatusSubmitted}).
+					Return([]models.JournalDraft{{ID: 1, Status: models.JournalDraftStatusSubmitted}}, nil)
+			},
+		},
+		{
+			name:  "error - validation",
+			query: "?status=posted",
+			expectation: expectation{
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error - database error",
+			expectation: expectation{
+				wantCode: 500,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().GetJournalDrafts(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/journals/drafts"+tt.query, nil)
+			w := httptest.NewRecorder()
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+		})
+	}
+}
+
+func Test_Handler_getJournalDraftById(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	ctx := context.Background()
+	out := &models.JournalDraft{ID: 1, TransactionId: "trx-1", Status: models.JournalDraftStatusDraft, CreatedBy: "tono@amartha.com"}
+	successRes, _ := json.Marshal(out.ToResponse())
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		id          string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name: "success",
+			id:   "1",
+			expectation: expectation{
+				wantRes:  string(successRes),
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().GetJournalDraftById(ctx, 1).Return(out, nil)
+			},
+		},
+		{
+			name: "error - id is not a number",
+			id:   "abc",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":400,"message":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error - not found",
+			id:   "2",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"journal draft not found"}`,
+				wantCode: 404,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().GetJournalDraftById(ctx, 2).Return(nil, models.GetErrMap(models.ErrKeyJournalDraftNotFound))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/journals/drafts/%s", tt.id), nil)
+			w := httptest.NewRecorder()
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_reviewJournalDraft(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	ctx := context.Background()
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		action      string
+		actor       string
+		body        string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name:   "success - submit, the user in the body is ignored",
+			action: "submit",
+			actor:  "tono@amartha.com",
+			body:   `{"submittedBy":"budi@amartha.com"}`,
+			expectation: expectation{
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().
+					SubmitJournalDraft(ctx, models.SubmitJournalDraftRequest{ID: 1, SubmittedBy: "tono@amartha.com"}).
+					Return(&models.JournalDraft{ID: 1, Status: models.JournalDraftStatusSubmitted}, nil)
+			},
+		},
+		{
+			name:   "error - submit by other than the maker",
+			action: "submit",
+			actor:  "budi@amartha.com",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"only the maker can submit the journal draft"}`,
+				wantCode: 403,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().SubmitJournalDraft(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyJournalDraftMakerOnly))
+			},
+		},
+		{
+			name:   "success - approve",
+			action: "approve",
+			actor:  "budi@amartha.com",
+			expectation: expectation{
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().
+					ApproveJournalDraft(ctx, models.ApproveJournalDraftRequest{ID: 1, ApprovedBy: "budi@amartha.com"}).
+					Return(&models.JournalDraft{ID: 1, Status: models.JournalDraftStatusApproved}, nil)
+			},
+		},
+		{
+			name:   "error - approve own draft",
+			action: "approve",
+			actor:  "tono@amartha.com",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"maker cannot approve or reject own journal draft"}`,
+				wantCode: 403,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().ApproveJournalDraft(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyJournalDraftSelfReview))
+			},
+		},
+		{
+			name:   "error - approve already posted transaction id",
+			action: "approve",
+			actor:  "budi@amartha.com",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_IS_EXIST","message":"transaction id is exist"}`,
+				wantCode: 409,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().ApproveJournalDraft(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyTransactionIdIsExist))
+			},
+		},
+		{
+			name:   "error - approve without user email",
+			action: "approve",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":401,"message":"required user email"}`,
+				wantCode: 401,
+			},
+		},
+		{
+			name:   "success - reject",
+			action: "reject",
+			actor:  "budi@amartha.com",
+			body:   `{"reason":"wrong account"}`,
+			expectation: expectation{
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().
+					RejectJournalDraft(ctx, models.RejectJournalDraftRequest{ID: 1, RejectedBy: "budi@amartha.com", Reason: "wrong account"}).
+					Return(&models.JournalDraft{ID: 1, Status: models.JournalDraftStatusRejected}, nil)
+			},
+		},
+		{
+			name:   "error - reject draft which is not submitted",
+			action: "reject",
+			actor:  "budi@amartha.com",
+			body:   `{"reason":"wrong account"}`,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"journal draft status does not allow the action"}`,
+				wantCode: 400,
+			},
+			doMock: func() {
+				testHelper.mockJournalService.EXPECT().RejectJournalDraft(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyInvalidJournalDraftStatus))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/journals/drafts/1/%s", tt.action), strings.NewReader(tt.body))
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			r.Header.Set(commonhttp.HeaderUserEmail, tt.actor)
+			w := httptest.NewRecorder()
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			if tt.expectation.wantRes != "" {
+				require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+			}
+		})
+	}
+}
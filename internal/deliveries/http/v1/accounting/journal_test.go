@@ -17,6 +17,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -384,6 +385,7 @@ func Test_Handler_uploadJournal(t *testing.T) {
 		ctx         context.Context
 		contentType string
 		fileName    string
+		query       string
 	}
 	type expectation struct {
 		wantRes  string
@@ -398,15 +400,34 @@ func Test_Handler_uploadJournal(t *testing.T) {
 		{
 			name: "happy path",
 			expectation: expectation{
-				wantRes:  `"processing"`,
-				wantCode: 200,
+				wantRes:  `{"kind":"uploadJob","id":1,"type":"journal","filename":"upload_journals.csv","status":"pending","totalRows":0,"processedRows":0,"successRows":0,"failedRows":0,"failures":[],"startedAt":null,"finishedAt":null,"createdAt":"0001-01-01T00:00:00Z","updatedAt":"0001-01-01T00:00:00Z"}`,
+				wantCode: 202,
 			},
 			args: args{
 				ctx:      context.TODO(),
 				fileName: "../../../../../storages/upload_journals.csv",
 			},
 			doMock: func(args args) {
-				testHelper.mockJournalService.EXPECT().ProcessUploadJournal(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockUploadJobService.EXPECT().CreateUploadJob(gomock.Any(), gomock.Any()).Return(&models.UploadJob{
+					ID:       1,
+					Type:     models.UploadJobTypeJournal,
+					Filename: "upload_journals.csv",
+					Status:   models.UploadJobStatusPending,
+				}, nil)
+			},
+		},
+		{
+			name: "error create upload job",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+				wantCode: 500,
+			},
+			args: args{
+				ctx:      context.TODO(),
+				fileName: "../../../../../storages/upload_journals.csv",
+			},
+			doMock: func(args args) {
+				testHelper.mockUploadJobService.EXPECT().CreateUploadJob(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -427,7 +448,7 @@ func Test_Handler_uploadJournal(t *testing.T) {
 		{
 			name: "error file not csv",
 			expectation: expectation{
-				wantRes:  `{"status":"error","code":400,"message":"file not csv"}`,
+				wantRes:  `{"status":"error","code":400,"message":"file not csv or xlsx"}`,
 				wantCode: 400,
 			},
 			args: args{
@@ -438,6 +459,94 @@ func Test_Handler_uploadJournal(t *testing.T) {
 				testHelper.mockJournalService.EXPECT().ProcessUploadJournal(gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
+		{
+			name: "happy path validate only",
+			expectation: expectation{
+				wantRes:  `{"kind":"uploadJournalReport","filename":"upload_journals.csv","totalRows":1,"validRows":0,"invalidRows":1,"rows":[{"row":2,"transactionId":"b13583d7-adba-4aba-9261-3dee86867ec7s","status":"invalid","errors":["account number not found caused by 142001000000001"]}]}`,
+				wantCode: 200,
+			},
+			args: args{
+				ctx:      context.TODO(),
+				fileName: "../../../../../storages/upload_journals.csv",
+				query:    "?validateOnly=true",
+			},
+			doMock: func(args args) {
+				testHelper.mockJournalService.EXPECT().ValidateUploadJournal(gomock.Any(), gomock.Any()).Return(models.UploadJournalReport{
+					Kind:        models.KindUploadJournalReport,
+					Filename:    "upload_journals.csv",
+					TotalRows:   1,
+					InvalidRows: 1,
+					Rows: []models.UploadJournalRowReport{
+						{
+							Row:           2,
+							TransactionId: "b13583d7-adba-4aba-9261-3dee86867ec7s",
+							Status:        models.UploadJournalRowStatusInvalid,
+							Errors:        []string{"account number not found caused by 142001000000001"},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "happy path validate only csv",
+			expectation: expectation{
+				wantRes:  "reference_number,transaction_id,validation_status,validation_errors",
+				wantCode: 200,
+			},
+			args: args{
+				ctx:      context.TODO(),
+				fileName: "../../../../../storages/upload_journals.csv",
+				query:    "?validateOnly=true&format=csv",
+			},
+			doMock: func(args args) {
+				testHelper.mockJournalService.EXPECT().ValidateUploadJournal(gomock.Any(), gomock.Any()).Return(models.UploadJournalReport{}, nil)
+				testHelper.mockJournalService.EXPECT().DownloadCSVUploadJournalReport(gomock.Any(), gomock.Any()).
+					Return(bytes.NewBufferString("reference_number,transaction_id,validation_status,validation_errors"), "Validation-upload_journals.csv", nil)
+			},
+		},
+		{
+			name: "error validate only",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+				wantCode: 500,
+			},
+			args: args{
+				ctx:      context.TODO(),
+				fileName: "../../../../../storages/upload_journals.csv",
+				query:    "?validateOnly=true",
+			},
+			doMock: func(args args) {
+				testHelper.mockJournalService.EXPECT().ValidateUploadJournal(gomock.Any(), gomock.Any()).Return(models.UploadJournalReport{}, assert.AnError)
+			},
+		},
+		{
+			name: "error validate only csv",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+				wantCode: 500,
+			},
+			args: args{
+				ctx:      context.TODO(),
+				fileName: "../../../../../storages/upload_journals.csv",
+				query:    "?validateOnly=true&format=csv",
+			},
+			doMock: func(args args) {
+				testHelper.mockJournalService.EXPECT().ValidateUploadJournal(gomock.Any(), gomock.Any()).Return(models.UploadJournalReport{}, nil)
+				testHelper.mockJournalService.EXPECT().DownloadCSVUploadJournalReport(gomock.Any(), gomock.Any()).Return(nil, "", assert.AnError)
+			},
+		},
+		{
+			name: "error invalid format",
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"UNKNOW","field":"format","message":"oneof json csv"}]}`,
+				wantCode: 422,
+			},
+			args: args{
+				ctx:      context.TODO(),
+				fileName: "../../../../../storages/upload_journals.csv",
+				query:    "?validateOnly=true&format=xlsx",
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -458,7 +567,7 @@ func Test_Handler_uploadJournal(t *testing.T) {
 			require.NoError(t, err)
 			require.NoError(t, writer.Close())
 
-			r := httptest.NewRequest(http.MethodPost, "/api/v1/journals/upload", body)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/journals/upload"+tt.args.query, body)
 			if tt.args.contentType == "" {
 				r.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
 			}
@@ -622,3 +731,174 @@ func Test_Handler_getByTransactionId(t *testing.T) {
 		})
 	}
 }
+
+func Test_Handler_reverseJournal(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+
+	type args struct {
+		ctx           context.Context
+		contentType   string
+		transactionId string
+		req           *models.ReverseJournalRequest
+	}
+	reversal := models.JournalRequest{
+		ReferenceNumber: "123456",
+		TransactionId:   "6de11650-dbee-4f67-9ade-ececc7a02572",
+		OrderType:       "DSB",
+		TransactionDate: "2023-12-29 00:00:00",
+		ProcessingDate:  "2023-12-29 00:00:00",
+		Currency:        "IDR",
+		Transactions: []models.Transaction{
+			{
+				TransactionType: "DSBAB",
+				Account:         "11100100000001",
+				Narrative:       "Debit",
+				Amount:          decimal.NewFromInt(5000001),
+				IsDebit:         false,
+			},
+			{
+				TransactionType: "DSBAB",
+				Account:         "11200100000006",
+				Narrative:       "Credit",
+				Amount:          decimal.NewFromInt(5000001),
+				IsDebit:         true,
+			},
+		},
+		Metadata: &models.Metadata{
+			models.MetadataKeyReversalOf: "6de11650-dbee-4f67-9ade-ececc7a02571",
+		},
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:           context.Background(),
+				contentType:   echo.MIMEApplicationJSON,
+				transactionId: "6de11650-dbee-4f67-9ade-ececc7a02571",
+				req: &models.ReverseJournalRequest{
+					Reason: "wrong account",
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"kind":"journal","referenceNumber":"123456","transactionId":"6de11650-dbee-4f67-9ade-ececc7a02572","orderType":"DSB","transactionDate":"2023-12-29 00:00:00","processingDate":"2023-12-29 00:00:00","currency":"IDR","transactions":[{"transactionType":"DSBAB","transactionTypeName":"","account":"11100100000001","narrative":"Debit","amount":"5000001","isDebit":false},{"transactionType":"DSBAB","transactionTypeName":"","account":"11200100000006","narrative":"Credit","amount":"5000001","isDebit":true}],"metadata":{"reversalOf":"6de11650-dbee-4f67-9ade-ececc7a02571"}}`,
+				wantCode: 201,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockJournalService.EXPECT().ReverseJournal(args.ctx, models.ReverseJournalRequest{
+					TransactionId: args.transactionId,
+					Reason:        args.req.Reason,
+				}).Return(reversal, nil)
+			},
+		},
+		{
+			name: "error - validation",
+			args: args{
+				ctx:           context.Background(),
+				contentType:   echo.MIMEApplicationJSON,
+				transactionId: "6de11650-dbee-4f67-9ade-ececc7a02571",
+				req: &models.ReverseJournalRequest{
+					Reason: strings.Repeat("a", 256),
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"INVALID_LENGTH","field":"reason","message":"field can have a maximum length of 255 characters"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error case - journal id not found",
+			args: args{
+				ctx:           context.Background(),
+				contentType:   echo.MIMEApplicationJSON,
+				transactionId: "6de11650-dbee-4f67-9ade-ececc7a02571",
+				req: &models.ReverseJournalRequest{
+					JournalIds: []string{"2024060601093678"},
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"journal id not found caused by 2024060601093678"}`,
+				wantCode: 404,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockJournalService.EXPECT().ReverseJournal(args.ctx, gomock.Any()).Return(models.JournalRequest{}, models.GetErrMap(models.ErrKeyJournalIdNotFound, "2024060601093678"))
+			},
+		},
+		{
+			name: "error case - journal already reversed",
+			args: args{
+				ctx:           context.Background(),
+				contentType:   echo.MIMEApplicationJSON,
+				transactionId: "6de11650-dbee-4f67-9ade-ececc7a02571",
+				req:           &models.ReverseJournalRequest{},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_IS_EXIST","message":"journal already reversed"}`,
+				wantCode: 409,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockJournalService.EXPECT().ReverseJournal(args.ctx, gomock.Any()).Return(models.JournalRequest{}, models.GetErrMap(models.ErrKeyJournalAlreadyReversed))
+			},
+		},
+		{
+			name: "error case - journal not balanced",
+			args: args{
+				ctx:           context.Background(),
+				contentType:   echo.MIMEApplicationJSON,
+				transactionId: "6de11650-dbee-4f67-9ade-ececc7a02571",
+				req: &models.ReverseJournalRequest{
+					JournalIds: []string{"2024060601093678"},
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"total debit and total credit are not balanced"}`,
+				wantCode: 400,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockJournalService.EXPECT().ReverseJournal(args.ctx, gomock.Any()).Return(models.JournalRequest{}, models.GetErrMap(models.ErrKeyJournalNotBalanced))
+			},
+		},
+		{
+			name: "error - Internal server error",
+			args: args{
+				ctx:           context.Background(),
+				contentType:   echo.MIMEApplicationJSON,
+				transactionId: "6de11650-dbee-4f67-9ade-ececc7a02571",
+				req:           &models.ReverseJournalRequest{},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockJournalService.EXPECT().ReverseJournal(args.ctx, gomock.Any()).Return(models.JournalRequest{}, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+			var b bytes.Buffer
+			err := json.NewEncoder(&b).Encode(tt.args.req)
+			require.NoError(t, err)
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/journals/%s/reverse", tt.args.transactionId), &b)
+			r.Header.Set(echo.HeaderContentType, tt.args.contentType)
+			w := httptest.NewRecorder()
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package accounting
+
+import (
+	"errors"
+	"net/http"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Get Profit Loss
+// @Description Get Profit Loss
+// @Tags 		Accounting
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param   params query models.GetProfitLossRequest true "Get profit loss query parameters"
+// @Success 200 {object} models.GetProfitLossResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while get profit loss"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if there is an error while get profit loss"
+// @Failure 422 {object} commonhttp.RestErrorResponseModel "Validation error. This can happen if there is an error while get profit loss"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get profit loss"
+// @Router 	/v1/profit-loss [get]
+func (ah accountingHandler) getProfitLoss(c echo.Context) error {
+	queryFilter := new(models.GetProfitLossRequest)
+	if err := c.Bind(queryFilter); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(queryFilter); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	opts, err := queryFilter.ToFilterOpts()
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	res, err := ah.GetProfitLoss(c.Request().Context(), *opts)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyEntityCodeNotFound)) {
+			statusCode = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, statusCode, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, res)
+}
+
+// @Summary 	Download CSV Profit Loss
+// @Description Download CSV Profit Loss
+// @Tags 		Accounting
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param   params query models.GetProfitLossRequest true "Download profit loss query parameters"
+// @Success 200 {object} models.GetProfitLossResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while download profit loss"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if there is an error while download profit loss"
+// @Failure 422 {object} commonhttp.RestErrorResponseModel "Validation error. This can happen if there is an error while download profit loss"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while download profit loss"
+// @Router 	/v1/profit-loss/download [get]
+func (ah accountingHandler) downloadCSVProfitLoss(c echo.Context) error {
+	queryFilter := new(models.GetProfitLossRequest)
+	if err := c.Bind(queryFilter); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(queryFilter); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	opts, err := queryFilter.ToFilterOpts()
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	res, err := ah.GetProfitLoss(c.Request().Context(), *opts)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyEntityCodeNotFound)) {
+			statusCode = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, statusCode, err)
+	}
+
+	b, filename, err := ah.DownloadCSVGetProfitLoss(c.Request().Context(), *opts, res)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, models.GetErrMap(models.ErrKeyCurrencyNotFound)) {
+			statusCode = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, statusCode, err)
+	}
+
+	return commonhttp.RestSuccessResponseCSV(c, b, filename)
+}
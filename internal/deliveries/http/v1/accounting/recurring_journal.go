@@ -0,0 +1,115 @@
+package accounting
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Create Recurring Journal
+// @Description Create a journal template which is posted on schedule by the recurring journal job
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	payload body models.CreateRecurringJournalRequest true "A JSON object containing create recurring journal payload"
+// @Success 201 {object} models.RecurringJournalResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while create recurring journal"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the account is not found"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while create recurring journal"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while create recurring journal"
+// @Router 	/v1/recurring-journals [post]
+func (ah accountingHandler) createRecurringJournal(c echo.Context) error {
+	req := new(models.CreateRecurringJournalRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := ah.CreateRecurringJournal(c.Request().Context(), *req)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusCreated, out.ToResponse())
+}
+
+// @Summary 	Get Recurring Journals
+// @Description Get Recurring Journals
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param   params query models.GetRecurringJournalRequest true "Get recurring journals query parameters"
+// @Success 200 {object} commonhttp.RestTotalRowResponseModel{contents=[]models.RecurringJournalResponse{}} "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while get recurring journals"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while get recurring journals"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get recurring journals"
+// @Router 	/v1/recurring-journals [get]
+func (ah accountingHandler) getRecurringJournals(c echo.Context) error {
+	queryFilter := new(models.GetRecurringJournalRequest)
+	if err := c.Bind(queryFilter); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(queryFilter); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	res, err := ah.GetRecurringJournals(c.Request().Context(), queryFilter.ToFilterOpts())
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	data := []models.RecurringJournalResponse{}
+	for _, v := range res {
+		data = append(data, v.ToResponse())
+	}
+
+	return commonhttp.RestSuccessResponseListWithTotalRows(c, data, len(data))
+}
+
+// @Summary 	Get Recurring Journal
+// @Description Get Recurring Journal by id
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	id path int true "Recurring Journal Id"
+// @Success 200 {object} models.RecurringJournalResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the id is not a number"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the recurring journal is not found"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get recurring journal"
+// @Router 	/v1/recurring-journals/{id} [get]
+func (ah accountingHandler) getRecurringJournalById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	out, err := ah.GetRecurringJournalById(c.Request().Context(), id)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
@@ -0,0 +1,245 @@
+package accounting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_createRecurringJournal(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	ctx := context.Background()
+	req := models.CreateRecurringJournalRequest{
+		Name:      "Prepaid insurance amortisation",
+		OrderType: "AMR",
+		Currency:  "IDR",
+		Transactions: []models.Transaction{
+			{TransactionType: "AMRIN", Account: "511001000000001", Amount: decimal.NewFromInt(100000), IsDebit: true},
+			{TransactionType: "AMRIN", Account: "141001000000001", Amount: decimal.NewFromInt(100000)},
+		},
+		Frequency: models.RecurringJournalFrequencyEndOfMonth,
+		StartDate: "2024-01-01",
+		CreatedBy: "tono@amartha.com",
+	}
+	out, _ := req.ToRecurringJournal()
+	out.ID = 1
+	successRes, _ := json.Marshal(out.ToResponse())
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		req         models.CreateRecurringJournalRequest
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name: "success",
+			req:  req,
+			expectation: expectation{
+				wantRes:  string(successRes),
+				wantCode: 201,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().CreateRecurringJournal(ctx, gomock.Any()).Return(out, nil)
+			},
+		},
+		{
+			name: "error - validation",
+			req: func() models.CreateRecurringJournalRequest {
+				r := req
+				r.Frequency = "weekly"
+				return r
+			}(),
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"UNKNOW","field":"frequency","message":"oneof daily monthly endOfMonth"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error - invalid schedule",
+			req:  req,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"recurring journal schedule is invalid"}`,
+				wantCode: 400,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().CreateRecurringJournal(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyInvalidRecurringJournalSchedule))
+			},
+		},
+		{
+			name: "error - account not found",
+			req:  req,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"account number not found"}`,
+				wantCode: 404,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().CreateRecurringJournal(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyAccountNumberNotFound))
+			},
+		},
+		{
+			name: "error - database error",
+			req:  req,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().CreateRecurringJournal(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			var b bytes.Buffer
+			err := json.NewEncoder(&b).Encode(tt.req)
+			require.NoError(t, err)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/recurring-journals", &b)
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			w := httptest.NewRecorder()
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_getRecurringJournals(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	ctx := context.Background()
+
+	type expectation struct {
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		query       string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name:  "success",
+			query: "?status=active",
+			expectation: expectation{
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().
+					GetRecurringJournals(ctx, models.RecurringJournalFilterOptions{Status: models.RecurringJournalStatusActive}).
+					Return([]models.RecurringJournal{{ID: 1, Status: models.RecurringJournalStatusActive}}, nil)
+			},
+		},
+		{
+			name:  "error - validation",
+			query: "?status=deleted",
+			expectation: expectation{
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error - database error",
+			expectation: expectation{
+				wantCode: 500,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().GetRecurringJournals(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/recurring-journals"+tt.query, nil)
+			w := httptest.NewRecorder()
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+		})
+	}
+}
+
+func Test_Handler_getRecurringJournalById(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	ctx := context.Background()
+	out := &models.RecurringJournal{ID: 1, Name: "Monthly fee", Status: models.RecurringJournalStatusActive}
+	successRes, _ := json.Marshal(out.ToResponse())
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		id          string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name: "success",
+			id:   "1",
+			expectation: expectation{
+				wantRes:  string(successRes),
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().GetRecurringJournalById(ctx, 1).Return(out, nil)
+			},
+		},
+		{
+			name: "error - invalid id",
+			id:   "abc",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":400,"message":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error - not found",
+			id:   "2",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"recurring journal not found"}`,
+				wantCode: 404,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().GetRecurringJournalById(ctx, 2).Return(nil, models.GetErrMap(models.ErrKeyRecurringJournalNotFound))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/recurring-journals/%s", tt.id), nil)
+			w := httptest.NewRecorder()
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package accounting
+
+import (
+	"net/http"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary 	Get Suspense Ageing
+// @Description Get the suspense entries which are not cleared by the suspense clearing job & are older than the min age days
+// @Tags 		Accounting
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param   params query models.GetSuspenseAgeingRequest true "Get suspense ageing query parameters"
+// @Success 200 {object} models.SuspenseAgeingResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the date is not valid"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while get suspense ageing"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get suspense ageing"
+// @Router 	/v1/suspense/ageing [get]
+func (ah accountingHandler) getSuspenseAgeing(c echo.Context) error {
+	queryFilter := new(models.GetSuspenseAgeingRequest)
+	if err := c.Bind(queryFilter); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(queryFilter); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	opts, err := queryFilter.ToFilterOpts()
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	out, err := ah.GetSuspenseAgeing(c.Request().Context(), *opts)
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
@@ -0,0 +1,112 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_getSuspenseAgeing(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	ctx := context.Background()
+	date, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-04-30")
+	out := &models.SuspenseAgeing{
+		Date:       date,
+		MinAgeDays: 30,
+		Entries: []models.SuspenseEntry{
+			{
+				AccountNumber:   "219001000000001",
+				EntityCode:      "001",
+				SubCategoryCode: "21901",
+				Currency:        models.CurrencyIDR,
+				Split: models.GetSubLedgerOut{
+					TransactionID:   "trx-1",
+					JournalID:       "journal-1",
+					ReferenceNumber: "DSB_1",
+					OrderType:       "DSB",
+					TransactionDate: time.Date(2024, time.March, 20, 9, 20, 0, 0, date.Location()),
+					Debit:           decimal.NewFromInt(100000),
+					Credit:          decimal.Zero,
+				},
+			},
+		},
+	}
+	successRes, _ := json.Marshal(out.ToResponse())
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		query       string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name:  "success",
+			query: "?date=2024-04-30&minAgeDays=30",
+			expectation: expectation{
+				wantRes:  string(successRes),
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().
+					GetSuspenseAgeing(ctx, models.SuspenseAgeingFilterOptions{Date: date, MinAgeDays: 30}).
+					Return(out, nil)
+			},
+		},
+		{
+			name:  "error - validation",
+			query: "?minAgeDays=30",
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"UNKNOW","field":"date","message":"required"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name:  "error - invalid date",
+			query: "?date=30-04-2024",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"invalid format date caused by date format must be YYYY-MM-DD"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name:  "error - database error",
+			query: "?date=2024-04-30",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().GetSuspenseAgeing(ctx, gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/suspense/ageing"+tt.query, nil)
+			w := httptest.NewRecorder()
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
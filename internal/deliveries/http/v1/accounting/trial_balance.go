@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 
 	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
 	"bitbucket.org/Amartha/go-accounting/internal/models"
@@ -52,6 +53,46 @@ func (ah accountingHandler) getTrialBalance(c echo.Context) error {
 	return commonhttp.RestSuccessResponseListWithTotalRows(c, res, len(res.COATypes))
 }
 
+// @Summary 	Get Consolidated Trial Balance
+// @Description Get Consolidated Trial Balance across entities with intercompany elimination
+// @Tags 		Accounting
+// @Accept		json
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param   params query models.DoGetConsolidatedTrialBalanceRequest true "Get consolidated trial balance query parameters"
+// @Success 200 {object} models.GetConsolidatedTrialBalanceResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while get consolidated trial balance"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if there is an error while get consolidated trial balance"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation get consolidated trial balance"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get consolidated trial balance"
+// @Router 	/v1/trial-balances/consolidated [get]
+func (ah accountingHandler) getConsolidatedTrialBalance(c echo.Context) error {
+	queryFilter := new(models.DoGetConsolidatedTrialBalanceRequest)
+	if err := c.Bind(queryFilter); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(queryFilter); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	opts, err := queryFilter.ToFilterOpts()
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	res, err := ah.GetConsolidatedTrialBalance(c.Request().Context(), *opts)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, statusCode, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, res)
+}
+
 // @Summary 	Download Trial Balance Send to Email
 // @Description Download Trial Balance Send to Email
 // @Tags 		Accounting
@@ -231,6 +272,45 @@ func (ah accountingHandler) closeTrialBalance(c echo.Context) error {
 	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToCloseTrialBalanceResponse())
 }
 
+// @Summary 	Close Fiscal Year
+// @Description Close the fiscal year of an entity, the income & expense accounts are closed into the retained earnings account and every period of the year is closed
+// @Tags 		Accounting
+// @Accept		json
+// @Produce		json
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param		fiscalYear path string true "Fiscal Year" example("2024")
+// @Param		params body models.YearEndClosingRequest true "Close fiscal year request body"
+// @Success 	200 {object} models.YearEndClosingResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 	400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while close fiscal year"
+// @Failure 	422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation close fiscal year"
+// @Failure 	404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if there is an error while close fiscal year"
+// @Failure 	500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while close fiscal year"
+// @Router 		/v1/trial-balances/fiscal-years/{fiscalYear}/close [post]
+func (ah accountingHandler) closeFiscalYear(c echo.Context) error {
+	req := new(models.YearEndClosingRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	req.FiscalYear = c.Param("fiscalYear")
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := ah.CloseFiscalYear(c.Request().Context(), *req)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+			code = http.StatusBadRequest
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToYearEndClosingResponse())
+}
+
 // @Summary 	Trigger Adjustment Trial Balance
 // @Description Trigger Adjustment Trial Balance
 // @Tags 		Accounting
@@ -635,3 +635,235 @@ func Test_Handler_adjustmentTrialBalance(t *testing.T) {
 		})
 	}
 }
+
+func Test_Handler_closeFiscalYear(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	ctx := context.Background()
+	out := models.YearEndClosing{
+		FiscalYear:              "2024",
+		EntityCode:              "001",
+		RetainedEarningsAccount: "311001000000001",
+		ClosedBy:                "tono@amartha.com",
+	}
+	successRes, _ := json.Marshal(out.ToYearEndClosingResponse())
+
+	type args struct {
+		contentType string
+		fiscalYear  string
+		req         *models.YearEndClosingRequest
+	}
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectation expectation
+		doMock      func(args args, expectation expectation)
+	}{
+		{
+			name: "success",
+			args: args{
+				contentType: echo.MIMEApplicationJSON,
+				fiscalYear:  "2024",
+				req: &models.YearEndClosingRequest{
+					EntityCode: "001",
+					ClosedBy:   "tono@amartha.com",
+				},
+			},
+			expectation: expectation{
+				wantRes:  string(successRes),
+				wantCode: 200,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountingService.EXPECT().CloseFiscalYear(ctx, models.YearEndClosingRequest{
+					FiscalYear: "2024",
+					EntityCode: "001",
+					ClosedBy:   "tono@amartha.com",
+				}).Return(out, nil)
+			},
+		},
+		{
+			name: "error - validation",
+			args: args{
+				contentType: echo.MIMEApplicationJSON,
+				fiscalYear:  "2024",
+				req: &models.YearEndClosingRequest{
+					EntityCode: "001",
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"UNKNOW","field":"closedBy","message":"required"}]}`,
+				wantCode: 422,
+			},
+		},
+		{
+			name: "error - fiscal year already closed",
+			args: args{
+				contentType: echo.MIMEApplicationJSON,
+				fiscalYear:  "2024",
+				req: &models.YearEndClosingRequest{
+					EntityCode: "001",
+					ClosedBy:   "tono@amartha.com",
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"fiscal year already closed"}`,
+				wantCode: 400,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountingService.EXPECT().CloseFiscalYear(ctx, gomock.Any()).Return(models.YearEndClosing{}, models.GetErrMap(models.ErrKeyFiscalYearAlreadyClosed))
+			},
+		},
+		{
+			name: "error - retained earnings account not found",
+			args: args{
+				contentType: echo.MIMEApplicationJSON,
+				fiscalYear:  "2024",
+				req: &models.YearEndClosingRequest{
+					EntityCode: "001",
+					ClosedBy:   "tono@amartha.com",
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"retained earnings account is not configured"}`,
+				wantCode: 404,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountingService.EXPECT().CloseFiscalYear(ctx, gomock.Any()).Return(models.YearEndClosing{}, models.GetErrMap(models.ErrKeyRetainedEarningsAccountNotFound))
+			},
+		},
+		{
+			name: "error - internal server error",
+			args: args{
+				contentType: echo.MIMEApplicationJSON,
+				fiscalYear:  "2024",
+				req: &models.YearEndClosingRequest{
+					EntityCode: "001",
+					ClosedBy:   "tono@amartha.com",
+				},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"BQ_ERROR","message":"bq error"}`,
+				wantCode: 500,
+			},
+			doMock: func(args args, expectation expectation) {
+				testHelper.mockAccountingService.EXPECT().CloseFiscalYear(ctx, gomock.Any()).Return(models.YearEndClosing{}, models.GetErrMap(models.ErrKeyBigQueryError))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args, tt.expectation)
+			}
+			var b bytes.Buffer
+			err := json.NewEncoder(&b).Encode(tt.args.req)
+			require.NoError(t, err)
+			r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/trial-balances/fiscal-years/%s/close", tt.args.fiscalYear), &b)
+			r.Header.Set(echo.HeaderContentType, tt.args.contentType)
+			w := httptest.NewRecorder()
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_getConsolidatedTrialBalance(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	ctx := context.Background()
+	defaultOpts, _ := models.DoGetConsolidatedTrialBalanceRequest{EntityCodes: "001,002", Period: "2023-12"}.ToFilterOpts()
+	res := models.GetConsolidatedTrialBalanceResponse{
+		Kind:        models.KindConsolidatedTrialBalance,
+		EntityCodes: []string{"001", "002"},
+		ClosingDate: "2023-12",
+	}
+	successRes, _ := json.Marshal(res)
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		req         url.Values
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name: "success case - get consolidated trial balance",
+			req: url.Values{
+				"entityCodes": []string{"001,002"},
+				"period":      []string{"2023-12"},
+			},
+			expectation: expectation{
+				wantRes:  string(successRes),
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().GetConsolidatedTrialBalance(ctx, *defaultOpts).Return(res, nil)
+			},
+		},
+		{
+			name: "error case - period and date range are filled",
+			req: url.Values{
+				"period":    []string{"2023-12"},
+				"startDate": []string{"2023-12-01"},
+				"endDate":   []string{"2023-12-31"},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":400,"message":"cannot use both 'period' and 'startDate/endDate' in the same request"}`,
+				wantCode: 400,
+			},
+		},
+		{
+			name: "error case - entity code not found",
+			req: url.Values{
+				"entityCodes": []string{"001,002"},
+				"period":      []string{"2023-12"},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"entity code not found"}`,
+				wantCode: 404,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().GetConsolidatedTrialBalance(ctx, gomock.Any()).Return(models.GetConsolidatedTrialBalanceResponse{}, models.GetErrMap(models.ErrKeyEntityCodeNotFound))
+			},
+		},
+		{
+			name: "error case - database error",
+			req: url.Values{
+				"period": []string{"2023-12"},
+			},
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATABASE_ERROR","message":"database error"}`,
+				wantCode: 500,
+			},
+			doMock: func() {
+				testHelper.mockAccountingService.EXPECT().GetConsolidatedTrialBalance(ctx, gomock.Any()).Return(models.GetConsolidatedTrialBalanceResponse{}, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/trial-balances/consolidated?%s", tt.req.Encode()), nil)
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			w := httptest.NewRecorder()
+
+			testHelper.router.NewContext(r, w)
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -20,6 +20,7 @@ type testPublisherHelper struct {
 	router               *echo.Echo
 	mockCtrl             *gomock.Controller
 	mockPublisherService *mock.MockPublisherService
+	mockUploadJobService *mock.MockUploadJobService
 }
 
 func publisherTestHelper(t *testing.T) testPublisherHelper {
@@ -29,14 +30,16 @@ func publisherTestHelper(t *testing.T) testPublisherHelper {
 	defer mockCtrl.Finish()
 
 	mockPublisherService := mock.NewMockPublisherService(mockCtrl)
+	mockUploadJobService := mock.NewMockUploadJobService(mockCtrl)
 
 	app := echo.New()
 	v1Group := app.Group("/api/v1")
-	New(v1Group, mockPublisherService)
+	New(v1Group, mockPublisherService, mockUploadJobService)
 
 	return testPublisherHelper{
 		router:               app,
 		mockCtrl:             mockCtrl,
 		mockPublisherService: mockPublisherService,
+		mockUploadJobService: mockUploadJobService,
 	}
 }
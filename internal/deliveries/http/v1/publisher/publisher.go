@@ -1,7 +1,6 @@
 package account
 
 import (
-	"context"
 	"errors"
 	"net/http"
 	"path"
@@ -14,12 +13,14 @@ import (
 )
 
 type publisherHandler struct {
-	service services.PublisherService
+	service          services.PublisherService
+	uploadJobService services.UploadJobService
 }
 
-func New(app *echo.Group, srv services.PublisherService) {
+func New(app *echo.Group, srv services.PublisherService, uploadJobSrv services.UploadJobService) {
 	ah := publisherHandler{
-		service: srv,
+		service:          srv,
+		uploadJobService: uploadJobSrv,
 	}
 	publisher := app.Group("/topics")
 	publisher.POST("/:topic", ah.publish)
@@ -32,7 +33,7 @@ func New(app *echo.Group, srv services.PublisherService) {
 // @Produce		text/csv
 // @Param	X-Secret-Key header string true "X-Secret-Key"
 // @Param	file formData file true "csv file"
-// @Success 200 string processing "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Success 202 {object} models.UploadJobResponse "Response indicates that the file is stored & will be processed in the background, the progress is available in GET /v1/uploads/:id"
 // @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if there is an error while publish message"
 // @Failure 422 {object} commonhttp.RestErrorResponseModel "Validation error. This can happen if there is an error while publish message"
 // @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while publish message"
@@ -56,8 +57,14 @@ func (ah publisherHandler) publish(c echo.Context) error {
 
 	req.Message = file
 
-	ctx := context.WithoutCancel(c.Request().Context())
-	go ah.service.PublishMessage(ctx, *req)
+	job, err := ah.uploadJobService.CreateUploadJob(c.Request().Context(), models.CreateUploadJobRequest{
+		Type:  models.UploadJobTypePublisher,
+		Topic: req.Topic,
+		File:  req.Message,
+	})
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
 
-	return commonhttp.RestSuccessResponse(c, http.StatusOK, "processing")
+	return commonhttp.RestSuccessResponse(c, http.StatusAccepted, job.ToResponse())
 }
@@ -12,7 +12,10 @@ import (
 	"strings"
 	"testing"
 
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
 	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -39,8 +42,29 @@ func Test_Handler_publish(t *testing.T) {
 		{
 			name: "success case",
 			expectation: expectation{
-				wantRes:  `"processing"`,
-				wantCode: 200,
+				wantRes:  `{"kind":"uploadJob","id":1,"type":"publisher","filename":"test_publish.json","topic":"account","status":"pending","totalRows":0,"processedRows":0,"successRows":0,"failedRows":0,"failures":[],"startedAt":null,"finishedAt":null,"createdAt":"0001-01-01T00:00:00Z","updatedAt":"0001-01-01T00:00:00Z"}`,
+				wantCode: 202,
+			},
+			args: args{
+				ctx:      context.TODO(),
+				topic:    "account",
+				fileName: "../../../../../storages/test_publish.json",
+			},
+			doMock: func(args args) {
+				testHelper.mockUploadJobService.EXPECT().CreateUploadJob(gomock.Any(), gomock.Any()).Return(&models.UploadJob{
+					ID:       1,
+					Type:     models.UploadJobTypePublisher,
+					Filename: "test_publish.json",
+					Topic:    "account",
+					Status:   models.UploadJobStatusPending,
+				}, nil)
+			},
+		},
+		{
+			name: "error case - create upload job",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+				wantCode: 500,
 			},
 			args: args{
 				ctx:      context.TODO(),
@@ -48,7 +72,7 @@ func Test_Handler_publish(t *testing.T) {
 				fileName: "../../../../../storages/test_publish.json",
 			},
 			doMock: func(args args) {
-				testHelper.mockPublisherService.EXPECT().PublishMessage(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockUploadJobService.EXPECT().CreateUploadJob(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 			},
 		},
 		{
@@ -0,0 +1,181 @@
+package reconciliation
+
+import (
+	"net/http"
+	"strings"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+	"bitbucket.org/Amartha/go-accounting/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type reconciliationHandler struct {
+	reconciliationService services.ReconciliationService
+}
+
+// New reconciliation handler will initialize the reconciliations/ resources endpoint
+func New(app *echo.Group, reconciliationSrv services.ReconciliationService) {
+	h := reconciliationHandler{
+		reconciliationService: reconciliationSrv,
+	}
+	reconciliation := app.Group("/reconciliations")
+	reconciliation.POST("/statements", h.importBankStatement)
+	reconciliation.GET("/outstanding", h.getOutstanding)
+	reconciliation.POST("/statement-lines/:id/match", h.matchBankStatementLine)
+	reconciliation.POST("/statement-lines/:id/unmatch", h.unmatchBankStatementLine)
+}
+
+// @Summary 	Import Bank Statement
+// @Description Import a bank or virtual account statement, the lines are auto matched with the splits of the account on the amount, the date window & the reference number
+// @Tags 		Reconciliations
+// @Accept		multipart/form-data
+// @Produce		json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	file formData file true "csv or mt940 statement file"
+// @Param	accountNumber formData string true "cash in transit account number"
+// @Param	format formData string true "format of the statement file" Enums(csv, mt940)
+// @Param	importedBy formData string true "email of the importer"
+// @Success 201 {object} models.BankStatementResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the file is missing or the statement is not valid"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the account is not found"
+// @Failure 409 {object} commonhttp.RestErrorResponseModel "Data is exist. This can happen if the same statement file is already imported to the account"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while import bank statement"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while import bank statement"
+// @Router 	/v1/reconciliations/statements [post]
+func (h reconciliationHandler) importBankStatement(c echo.Context) error {
+	req := new(models.ImportBankStatementRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+	req.File = file
+
+	out, err := h.reconciliationService.ImportBankStatement(c.Request().Context(), *req)
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, errorStatusCode(err), err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusCreated, out.ToResponse())
+}
+
+// @Summary 	Get Reconciliation Outstanding
+// @Description Get the unmatched statement lines & the unmatched splits of the account in the period
+// @Tags 		Reconciliations
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param   params query models.GetReconciliationOutstandingRequest true "Get reconciliation outstanding query parameters"
+// @Success 200 {object} models.ReconciliationOutstandingResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the period is not valid"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while get reconciliation outstanding"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get reconciliation outstanding"
+// @Router 	/v1/reconciliations/outstanding [get]
+func (h reconciliationHandler) getOutstanding(c echo.Context) error {
+	queryFilter := new(models.GetReconciliationOutstandingRequest)
+	if err := c.Bind(queryFilter); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(queryFilter); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	opts, err := queryFilter.ToFilterOpts()
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	out, err := h.reconciliationService.GetReconciliationOutstanding(c.Request().Context(), *opts)
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
+
+// @Summary 	Match Bank Statement Line
+// @Description Match the statement line with a split of the same account, amount & side manually
+// @Tags 		Reconciliations
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	id path int true "Bank Statement Line Id"
+// @Param	payload body models.MatchBankStatementLineRequest true "A JSON object containing match bank statement line payload"
+// @Success 200 {object} models.BankStatementLineResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the journal does not match the line"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the line or the journal is not found"
+// @Failure 409 {object} commonhttp.RestErrorResponseModel "Data is exist. This can happen if the line or the journal is already matched"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while match bank statement line"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while match bank statement line"
+// @Router 	/v1/reconciliations/statement-lines/{id}/match [post]
+func (h reconciliationHandler) matchBankStatementLine(c echo.Context) error {
+	req := new(models.MatchBankStatementLineRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := h.reconciliationService.MatchBankStatementLine(c.Request().Context(), *req)
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, errorStatusCode(err), err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
+
+// @Summary 	Unmatch Bank Statement Line
+// @Description Unmatch the matched statement line, the line & the split are back to the outstanding items
+// @Tags 		Reconciliations
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	id path int true "Bank Statement Line Id"
+// @Success 200 {object} models.BankStatementLineResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the line is not matched"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the line is not found"
+// @Failure 422 {object} commonhttp.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while unmatch bank statement line"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while unmatch bank statement line"
+// @Router 	/v1/reconciliations/statement-lines/{id}/unmatch [post]
+func (h reconciliationHandler) unmatchBankStatementLine(c echo.Context) error {
+	req := new(models.UnmatchBankStatementLineRequest)
+	if err := c.Bind(req); err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return commonhttp.RestErrorValidationResponse(c, err)
+	}
+
+	out, err := h.reconciliationService.UnmatchBankStatementLine(c.Request().Context(), *req)
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, errorStatusCode(err), err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
+
+func errorStatusCode(err error) int {
+	if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+		return http.StatusNotFound
+	} else if strings.Contains(err.Error(), models.ErrCodeDataIsExist) {
+		return http.StatusConflict
+	} else if strings.Contains(err.Error(), models.ErrCodeInvalidValues) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,505 @@
+package reconciliation
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/services/mock"
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testReconciliationHelper struct {
+	router      *echo.Echo
+	mockCtrl    *gomock.Controller
+	mockService *mock.MockReconciliationService
+}
+
+func reconciliationTestHelper(t *testing.T) testReconciliationHelper {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := mock.NewMockReconciliationService(mockCtrl)
+
+	app := echo.New()
+	v1Group := app.Group("/api/v1")
+	New(v1Group, mockSvc)
+
+	return testReconciliationHelper{
+		router:      app,
+		mockCtrl:    mockCtrl,
+		mockService: mockSvc,
+	}
+}
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
+
+func newStatementForm(t *testing.T, fields map[string]string, withFile bool) (*bytes.Buffer, string) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	for k, v := range fields {
+		require.NoError(t, mw.WriteField(k, v))
+	}
+	if withFile {
+		fw, err := mw.CreateFormFile("file", "statement.csv")
+		require.NoError(t, err)
+		_, err = fw.Write([]byte("date,reference_number,description,debit,credit\n2024-04-23,DSB_1,VA repayment,,100000\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+
+	return body, mw.FormDataContentType()
+}
+
+func Test_Handler_importBankStatement(t *testing.T) {
+	testHelper := reconciliationTestHelper(t)
+
+	lineDate := time.Date(2024, 4, 23, 0, 0, 0, 0, time.UTC)
+	fields := map[string]string{
+		"accountNumber": "142001000000001",
+		"format":        "csv",
+		"importedBy":    "tono@amartha.com",
+	}
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		fields      map[string]string
+		withFile    bool
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name:     "success",
+			fields:   fields,
+			withFile: true,
+			expectation: expectation{
+				wantRes:  `{"kind":"bankStatement","id":1,"accountNumber":"142001000000001","format":"csv","filename":"statement.csv","importedBy":"tono@amartha.com","totalLines":1,"matchedLines":1,"unmatchedLines":0,"lines":[{"kind":"bankStatementLine","id":1,"statementId":1,"accountNumber":"142001000000001","lineDate":"2024-04-23","amount":"100000","isCredit":true,"referenceNumber":"DSB_1","description":"VA repayment","status":"matched","transactionId":"trx-1","journalId":"split-1","matchType":"auto"}],"createdAt":"0001-01-01T00:00:00Z"}`,
+				wantCode: 201,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().ImportBankStatement(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, in models.ImportBankStatementRequest) (*models.BankStatement, error) {
+						assert.Equal(t, "statement.csv", in.File.Filename)
+						return &models.BankStatement{
+							ID:            1,
+							AccountNumber: in.AccountNumber,
+							Format:        in.Format,
+							Filename:      in.File.Filename,
+							ImportedBy:    in.ImportedBy,
+							Lines: []models.BankStatementLine{{
+								ID:              1,
+								StatementID:     1,
+								AccountNumber:   in.AccountNumber,
+								LineDate:        lineDate,
+								Amount:          decimal.NewFromInt(100000),
+								IsCredit:        true,
+								ReferenceNumber: "DSB_1",
+								Description:     "VA repayment",
+								Status:          models.BankStatementLineStatusMatched,
+								TransactionId:   "trx-1",
+								JournalId:       "split-1",
+								MatchType:       models.BankStatementMatchTypeAuto,
+							}},
+						}, nil
+					})
+			},
+		},
+		{
+			name:     "error file is missing",
+			fields:   fields,
+			withFile: false,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":400,"message":"http: no such file"}`,
+				wantCode: 400,
+			},
+			doMock: func() {},
+		},
+		{
+			name: "error validation",
+			fields: map[string]string{
+				"accountNumber": "142001000000001",
+				"format":        "xls",
+				"importedBy":    "tono@amartha.com",
+			},
+			withFile: true,
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"UNKNOW","field":"format","message":"oneof csv mt940"}]}`,
+				wantCode: 422,
+			},
+			doMock: func() {},
+		},
+		{
+			name:     "error account not found",
+			fields:   fields,
+			withFile: true,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"account number not found"}`,
+				wantCode: 404,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().ImportBankStatement(gomock.Any(), gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyAccountNumberNotFound))
+			},
+		},
+		{
+			name:     "error statement is already imported",
+			fields:   fields,
+			withFile: true,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_IS_EXIST","message":"bank statement is already imported caused by statement.csv"}`,
+				wantCode: 409,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().ImportBankStatement(gomock.Any(), gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyBankStatementIsImported, "statement.csv"))
+			},
+		},
+		{
+			name:     "error invalid statement",
+			fields:   fields,
+			withFile: true,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"bank statement file is invalid caused by row 2: credit must be greater than zero"}`,
+				wantCode: 400,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().ImportBankStatement(gomock.Any(), gomock.Any()).Return(nil, models.GetErrMap(models.ErrKeyInvalidBankStatement, "row 2: credit must be greater than zero"))
+			},
+		},
+		{
+			name:     "error internal server",
+			fields:   fields,
+			withFile: true,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+				wantCode: 500,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().ImportBankStatement(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			body, contentType := newStatementForm(t, tt.fields, tt.withFile)
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/reconciliations/statements", body)
+			r.Header.Set(echo.HeaderContentType, contentType)
+			w := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_getOutstanding(t *testing.T) {
+	testHelper := reconciliationTestHelper(t)
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		urlCalled   string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/reconciliations/outstanding?accountNumber=142001000000001&startDate=2024-04-01&endDate=2024-04-30",
+			expectation: expectation{
+				wantRes:  `{"kind":"reconciliationOutstanding","accountNumber":"142001000000001","startDate":"2024-04-01","endDate":"2024-04-30","totalStatementCredit":"0","totalStatementDebit":"50000","totalLedgerDebit":"100000","totalLedgerCredit":"0","statementLines":[{"kind":"bankStatementLine","id":2,"statementId":1,"accountNumber":"142001000000001","lineDate":"2024-04-24","amount":"50000","isCredit":false,"referenceNumber":"","description":"admin fee","status":"unmatched"}],"ledgerItems":[{"kind":"reconciliationLedgerItem","transactionId":"trx-2","journalId":"split-2","referenceNumber":"DSB_2","transactionDate":"2024-04-25 10:00:00","orderType":"DSB","transactionType":"DSBAC","narrative":"Disbursement","debit":"100000","credit":"0"}]}`,
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetReconciliationOutstanding(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, opts models.BankStatementLineFilterOptions) (*models.ReconciliationOutstanding, error) {
+						assert.Equal(t, models.BankStatementLineStatusUnmatched, opts.Status)
+						return &models.ReconciliationOutstanding{
+							AccountNumber: opts.AccountNumber,
+							StartDate:     opts.StartDate,
+							EndDate:       opts.EndDate,
+							StatementLines: []models.BankStatementLine{{
+								ID:            2,
+								StatementID:   1,
+								AccountNumber: opts.AccountNumber,
+								LineDate:      time.Date(2024, 4, 24, 0, 0, 0, 0, time.UTC),
+								Amount:        decimal.NewFromInt(50000),
+								Description:   "admin fee",
+								Status:        models.BankStatementLineStatusUnmatched,
+							}},
+							LedgerItems: []models.GetSubLedgerOut{{
+								TransactionID:   "trx-2",
+								JournalID:       "split-2",
+								ReferenceNumber: "DSB_2",
+								TransactionDate: time.Date(2024, 4, 25, 10, 0, 0, 0, opts.StartDate.Location()),
+								OrderType:       "DSB",
+								TransactionType: "DSBAC",
+								Narrative:       "Disbursement",
+								Debit:           decimal.NewFromInt(100000),
+								Credit:          decimal.Zero,
+							}},
+						}, nil
+					})
+			},
+		},
+		{
+			name:      "error validation",
+			urlCalled: "/api/v1/reconciliations/outstanding?startDate=2024-04-01&endDate=2024-04-30",
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"MISSING_FIELD","field":"accountNumber","message":"field is missing"}]}`,
+				wantCode: 422,
+			},
+			doMock: func() {},
+		},
+		{
+			name:      "error invalid date",
+			urlCalled: "/api/v1/reconciliations/outstanding?accountNumber=142001000000001&startDate=01-04-2024&endDate=2024-04-30",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"invalid format date caused by date 01-04-2024 format must be YYYY-MM-DD"}`,
+				wantCode: 400,
+			},
+			doMock: func() {},
+		},
+		{
+			name:      "error internal server",
+			urlCalled: "/api/v1/reconciliations/outstanding?accountNumber=142001000000001&startDate=2024-04-01&endDate=2024-04-30",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+				wantCode: 500,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetReconciliationOutstanding(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			r := httptest.NewRequest(http.MethodGet, tt.urlCalled, nil)
+			w := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_matchBankStatementLine(t *testing.T) {
+	testHelper := reconciliationTestHelper(t)
+
+	req := models.MatchBankStatementLineRequest{
+		ID:            2,
+		TransactionId: "trx-2",
+		JournalId:     "split-2",
+		MatchedBy:     "tono@amartha.com",
+	}
+	payload := `{"transactionId":"trx-2","journalId":"split-2","matchedBy":"tono@amartha.com"}`
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		urlCalled   string
+		payload     string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/reconciliations/statement-lines/2/match",
+			payload:   payload,
+			expectation: expectation{
+				wantRes:  `{"kind":"bankStatementLine","id":2,"statementId":1,"accountNumber":"142001000000001","lineDate":"2024-04-24","amount":"100000","isCredit":true,"referenceNumber":"","description":"","status":"matched","transactionId":"trx-2","journalId":"split-2","matchType":"manual","matchedBy":"tono@amartha.com"}`,
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().MatchBankStatementLine(gomock.Any(), req).Return(&models.BankStatementLine{
+					ID:            2,
+					StatementID:   1,
+					AccountNumber: "142001000000001",
+					LineDate:      time.Date(2024, 4, 24, 0, 0, 0, 0, time.UTC),
+					Amount:        decimal.NewFromInt(100000),
+					IsCredit:      true,
+					Status:        models.BankStatementLineStatusMatched,
+					TransactionId: req.TransactionId,
+					JournalId:     req.JournalId,
+					MatchType:     models.BankStatementMatchTypeManual,
+					MatchedBy:     req.MatchedBy,
+				}, nil)
+			},
+		},
+		{
+			name:      "error validation",
+			urlCalled: "/api/v1/reconciliations/statement-lines/2/match",
+			payload:   `{"transactionId":"trx-2","journalId":"split-2"}`,
+			expectation: expectation{
+				wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"UNKNOW","field":"matchedBy","message":"required"}]}`,
+				wantCode: 422,
+			},
+			doMock: func() {},
+		},
+		{
+			name:      "error line not found",
+			urlCalled: "/api/v1/reconciliations/statement-lines/2/match",
+			payload:   payload,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"bank statement line not found"}`,
+				wantCode: 404,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().MatchBankStatementLine(gomock.Any(), req).Return(nil, models.GetErrMap(models.ErrKeyBankStatementLineNotFound))
+			},
+		},
+		{
+			name:      "error journal is reconciled",
+			urlCalled: "/api/v1/reconciliations/statement-lines/2/match",
+			payload:   payload,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_IS_EXIST","message":"journal is already matched to a bank statement line caused by 3"}`,
+				wantCode: 409,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().MatchBankStatementLine(gomock.Any(), req).Return(nil, models.GetErrMap(models.ErrKeyJournalIsReconciled, "3"))
+			},
+		},
+		{
+			name:      "error mismatch",
+			urlCalled: "/api/v1/reconciliations/statement-lines/2/match",
+			payload:   payload,
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"journal does not match the bank statement line"}`,
+				wantCode: 400,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().MatchBankStatementLine(gomock.Any(), req).Return(nil, models.GetErrMap(models.ErrKeyBankStatementLineMismatch))
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			r := httptest.NewRequest(http.MethodPost, tt.urlCalled, strings.NewReader(tt.payload))
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			w := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
+
+func Test_Handler_unmatchBankStatementLine(t *testing.T) {
+	testHelper := reconciliationTestHelper(t)
+
+	req := models.UnmatchBankStatementLineRequest{ID: 2}
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		urlCalled   string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/reconciliations/statement-lines/2/unmatch",
+			expectation: expectation{
+				wantRes:  `{"kind":"bankStatementLine","id":2,"statementId":1,"accountNumber":"142001000000001","lineDate":"2024-04-24","amount":"100000","isCredit":true,"referenceNumber":"","description":"","status":"unmatched"}`,
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().UnmatchBankStatementLine(gomock.Any(), req).Return(&models.BankStatementLine{
+					ID:            2,
+					StatementID:   1,
+					AccountNumber: "142001000000001",
+					LineDate:      time.Date(2024, 4, 24, 0, 0, 0, 0, time.UTC),
+					Amount:        decimal.NewFromInt(100000),
+					IsCredit:      true,
+					Status:        models.BankStatementLineStatusUnmatched,
+				}, nil)
+			},
+		},
+		{
+			name:      "error id not a number",
+			urlCalled: "/api/v1/reconciliations/statement-lines/abc/unmatch",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":400,"message":"code=400, message=strconv.ParseInt: parsing \"abc\": invalid syntax, internal=strconv.ParseInt: parsing \"abc\": invalid syntax"}`,
+				wantCode: 400,
+			},
+			doMock: func() {},
+		},
+		{
+			name:      "error line is not matched",
+			urlCalled: "/api/v1/reconciliations/statement-lines/2/unmatch",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"INVALID_VALUES","message":"bank statement line is not matched caused by 2"}`,
+				wantCode: 400,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().UnmatchBankStatementLine(gomock.Any(), req).Return(nil, models.GetErrMap(models.ErrKeyBankStatementLineNotMatched, "2"))
+			},
+		},
+		{
+			name:      "error internal server",
+			urlCalled: "/api/v1/reconciliations/statement-lines/2/unmatch",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+				wantCode: 500,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().UnmatchBankStatementLine(gomock.Any(), req).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			r := httptest.NewRequest(http.MethodPost, tt.urlCalled, nil)
+			w := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package upload
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	commonhttp "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/common"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type uploadHandler struct {
+	uploadJobService services.UploadJobService
+}
+
+// New upload handler will initialize the uploads/ resources endpoint
+func New(app *echo.Group, uploadJobSrv services.UploadJobService) {
+	h := uploadHandler{
+		uploadJobService: uploadJobSrv,
+	}
+	upload := app.Group("/uploads")
+	upload.GET("/:id", h.getUploadJobById)
+}
+
+// @Summary 	Get Upload Job
+// @Description Get the progress, row counts & failed rows of an upload job, the error file url is returned when there is any failed row
+// @Tags 		Uploads
+// @Accept  	json
+// @Produce  	json
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	id path int true "Upload Job Id"
+// @Success 200 {object} models.UploadJobResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} commonhttp.RestErrorResponseModel "Bad request error. This can happen if the id is not a number"
+// @Failure 404 {object} commonhttp.RestErrorResponseModel "Not found error. This can happen if the upload job is not found"
+// @Failure 500 {object} commonhttp.RestErrorResponseModel "Internal server error. This can happen if there is an error while get upload job"
+// @Router 	/v1/uploads/{id} [get]
+func (h uploadHandler) getUploadJobById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return commonhttp.RestErrorResponse(c, http.StatusBadRequest, err)
+	}
+
+	out, err := h.uploadJobService.GetUploadJobById(c.Request().Context(), id)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), models.ErrCodeDataNotFound) {
+			code = http.StatusNotFound
+		}
+		return commonhttp.RestErrorResponse(c, code, err)
+	}
+
+	return commonhttp.RestSuccessResponse(c, http.StatusOK, out.ToResponse())
+}
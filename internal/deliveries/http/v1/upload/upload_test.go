@@ -0,0 +1,130 @@
+package upload
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/services/mock"
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testUploadHelper struct {
+	router      *echo.Echo
+	mockCtrl    *gomock.Controller
+	mockService *mock.MockUploadJobService
+}
+
+func uploadTestHelper(t *testing.T) testUploadHelper {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := mock.NewMockUploadJobService(mockCtrl)
+
+	app := echo.New()
+	v1Group := app.Group("/api/v1")
+	New(v1Group, mockSvc)
+
+	return testUploadHelper{
+		router:      app,
+		mockCtrl:    mockCtrl,
+		mockService: mockSvc,
+	}
+}
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
+
+func Test_Handler_getUploadJobById(t *testing.T) {
+	testHelper := uploadTestHelper(t)
+
+	type expectation struct {
+		wantRes  string
+		wantCode int
+	}
+	tests := []struct {
+		name        string
+		urlCalled   string
+		expectation expectation
+		doMock      func()
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/uploads/1",
+			expectation: expectation{
+				wantRes:  `{"kind":"uploadJob","id":1,"type":"account","filename":"upload_accounts.csv","status":"completed","totalRows":2,"processedRows":2,"successRows":1,"failedRows":1,"failures":[{"row":3,"error":"account number is exist"}],"errorFileUrl":"https://storage.googleapis.com/bucket/upload_jobs/account/1_errors.csv","startedAt":null,"finishedAt":null,"createdAt":"0001-01-01T00:00:00Z","updatedAt":"0001-01-01T00:00:00Z"}`,
+				wantCode: 200,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(&models.UploadJob{
+					ID:            1,
+					Type:          models.UploadJobTypeAccount,
+					Filename:      "upload_accounts.csv",
+					Status:        models.UploadJobStatusCompleted,
+					TotalRows:     2,
+					ProcessedRows: 2,
+					SuccessRows:   1,
+					FailedRows:    1,
+					Failures:      models.UploadJobFailures{{Row: 3, Error: "account number is exist"}},
+					ErrorFileURL:  "https://storage.googleapis.com/bucket/upload_jobs/account/1_errors.csv",
+				}, nil)
+			},
+		},
+		{
+			name:      "error id not a number",
+			urlCalled: "/api/v1/uploads/abc",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":400,"message":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+				wantCode: 400,
+			},
+			doMock: func() {},
+		},
+		{
+			name:      "error not found",
+			urlCalled: "/api/v1/uploads/1",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":"DATA_NOT_FOUND","message":"upload job not found"}`,
+				wantCode: 404,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(nil, models.GetErrMap(models.ErrKeyUploadJobNotFound))
+			},
+		},
+		{
+			name:      "error internal server",
+			urlCalled: "/api/v1/uploads/1",
+			expectation: expectation{
+				wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+				wantCode: 500,
+			},
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			r := httptest.NewRequest(http.MethodGet, tt.urlCalled, nil)
+			w := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(w, r)
+
+			require.Equal(t, tt.expectation.wantCode, w.Code)
+			require.Equal(t, tt.expectation.wantRes, strings.Trim(w.Body.String(), "\n"))
+		})
+	}
+}
@@ -2,6 +2,8 @@ package httpv1
 
 import (
 	"bitbucket.org/Amartha/go-accounting/internal/contract"
+	"bitbucket.org/Amartha/go-accounting/internal/deliveries/http/middleware"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
 
 	v1account "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/v1/account"
 	v1accounting "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/v1/accounting"
@@ -13,15 +15,17 @@ import (
 	v1migration "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/v1/migration"
 	v1producttype "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/v1/producttype"
 	v1publisher "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/v1/publisher"
+	v1reconciliation "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/v1/reconciliation"
 	v1subcategory "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/v1/sub_category"
+	v1upload "bitbucket.org/Amartha/go-accounting/internal/deliveries/http/v1/upload"
 
 	"github.com/labstack/echo/v4"
 )
 
 // v1Group register api
-func Route(g *echo.Group, c *contract.Contract) {
-	v1account.New(g, c.Service.Account)
-	v1accounting.New(g, c.Service.Accounting, c.Service.Journal, c.Service.TrialBalance)
+func Route(g *echo.Group, c *contract.Contract, m *middleware.AppMiddleware) {
+	v1account.New(g, c.Service.Account, c.Service.UploadJob)
+	v1accounting.New(g, c.Service.Accounting, c.Service.Journal, c.Service.TrialBalance, c.Service.UploadJob, m.FeatureFlag(models.FlagJournalDirectPosting))
 	v1cache.New(g, c.Service.Account)
 	v1category.New(g, c.Service.Category)
 	v1coatype.New(g, c.Service.COAType)
@@ -29,6 +33,8 @@ func Route(g *echo.Group, c *contract.Contract) {
 	v1loanpartneraccount.New(g, c.Service.LoanPartnerAccount)
 	v1migration.New(g, c.Service.Migration)
 	v1producttype.New(g, c.Service.ProductType)
-	v1publisher.New(g, c.Service.PublisherService)
+	v1publisher.New(g, c.Service.PublisherService, c.Service.UploadJob)
+	v1reconciliation.New(g, c.Service.Reconciliation)
 	v1subcategory.New(g, c.Service.SubCategory)
+	v1upload.New(g, c.Service.UploadJob)
 }
@@ -3,6 +3,7 @@ package job
 import (
 	"context"
 	"errors"
+	"maps"
 	"time"
 
 	"bitbucket.org/Amartha/go-accounting/internal/config"
@@ -10,6 +11,7 @@ import (
 	"bitbucket.org/Amartha/go-accounting/internal/services"
 	"bitbucket.org/Amartha/go-x/log/ctxdata"
 
+	v1account "bitbucket.org/Amartha/go-accounting/internal/deliveries/job/v1/account"
 	v1accounting "bitbucket.org/Amartha/go-accounting/internal/deliveries/job/v1/accounting"
 	xlog "bitbucket.org/Amartha/go-x/log"
 
@@ -25,8 +27,11 @@ type Job struct {
 func New(cfg *config.Configuration, srv *services.Services) *Job {
 	v1group := "v1"
 
+	v1routes := v1accounting.Routes(srv.Accounting)
+	maps.Copy(v1routes, v1account.Routes(srv.Account))
+
 	jobRoutes := map[string]map[string]func(ctx context.Context, date time.Time) error{
-		v1group: v1accounting.Routes(srv.Accounting),
+		v1group: v1routes,
 		// add other version routes
 	}
 
@@ -0,0 +1,35 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/services"
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+type accountHandler struct {
+	accountService services.AccountService
+}
+
+func Routes(as services.AccountService) map[string]func(ctx context.Context, date time.Time) error {
+	handler := accountHandler{
+		accountService: as,
+	}
+	return map[string]func(ctx context.Context, date time.Time) error{
+		"SeedAccountRelationshipRules": handler.SeedAccountRelationshipRules,
+	}
+}
+
+// go run cmd/job/main.go run -v=v1 -n=SeedAccountRelationshipRules
+func (ah *accountHandler) SeedAccountRelationshipRules(ctx context.Context, date time.Time) error {
+	rules, err := ah.accountService.SeedAccountRelationshipRules(ctx)
+	for _, v := range rules {
+		xlog.Info(ctx, "[JOB-SeedAccountRelationshipRules]", xlog.Any("rule", v))
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package account
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_accountHandler_SeedAccountRelationshipRules(t *testing.T) {
+	testHelper := accountTestHelper(t)
+	type args struct {
+		ctx  context.Context
+		date time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success case - SeedAccountRelationshipRules",
+			args: args{
+				ctx: context.TODO(),
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountService.EXPECT().SeedAccountRelationshipRules(gomock.AssignableToTypeOf(args.ctx)).Return([]models.AccountRelationshipRule{{ID: 1}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - SeedAccountRelationshipRules",
+			args: args{
+				ctx: context.TODO(),
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountService.EXPECT().SeedAccountRelationshipRules(gomock.AssignableToTypeOf(args.ctx)).Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+			ah := &accountHandler{
+				accountService: testHelper.mockAccountService,
+			}
+			err := ah.SeedAccountRelationshipRules(tt.args.ctx, tt.args.date)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package account
+
+import (
+	"os"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/services/mock"
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"go.uber.org/mock/gomock"
+)
+
+type testAccountJobHelper struct {
+	mockCtrl           *gomock.Controller
+	mockAccountService *mock.MockAccountService
+}
+
+func accountTestHelper(t *testing.T) testAccountJobHelper {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAccountService := mock.NewMockAccountService(mockCtrl)
+
+	Routes(mockAccountService)
+
+	return testAccountJobHelper{
+		mockCtrl:           mockCtrl,
+		mockAccountService: mockAccountService,
+	}
+}
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
@@ -5,7 +5,9 @@ import (
 	"os"
 	"time"
 
+	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
 	"bitbucket.org/Amartha/go-accounting/internal/services"
 )
 
@@ -22,6 +24,11 @@ func Routes(acts services.AccountingService) map[string]func(ctx context.Context
 		"GenerateAccountDailyBalanceAndTrialBalance":            handler.GenerateAccountDailyBalanceAndTrialBalance,
 		"GenerateRangeAccountDailyBalanceAndTrialBalance":       handler.GenerateRangeAccountDailyBalanceAndTrialBalance,
 		"GenerateRangeAccountDailyBalanceAndTrialBalanceCustom": handler.GenerateRangeAccountDailyBalanceAndTrialBalanceCustom,
+		"RevalueForeignCurrencyBalances":                        handler.RevalueForeignCurrencyBalances,
+		"CloseFiscalYear":                                       handler.CloseFiscalYear,
+		"RunRecurringJournals":                                  handler.RunRecurringJournals,
+		"ClearSuspenseAccounts":                                 handler.ClearSuspenseAccounts,
+		"CheckLedgerIntegrity":                                  handler.CheckLedgerIntegrity,
 	}
 }
 
@@ -85,3 +92,73 @@ func (rh *accountingHandler) GenerateRangeAccountDailyBalanceAndTrialBalanceCust
 
 	return nil
 }
+
+// go run cmd/job/main.go run -v=v1 -n=RevalueForeignCurrencyBalances -d=2024-01-31
+func (rh *accountingHandler) RevalueForeignCurrencyBalances(ctx context.Context, date time.Time) error {
+	if err := rh.accountingService.RevalueForeignCurrencyBalances(ctx, date); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ENTITY_CODE=001 go run cmd/job/main.go run -v=v1 -n=CloseFiscalYear -d=2024-12-31
+func (rh *accountingHandler) CloseFiscalYear(ctx context.Context, date time.Time) error {
+	if _, err := rh.accountingService.CloseFiscalYear(ctx, models.YearEndClosingRequest{
+		FiscalYear: date.Format(atime.DateFormatYYYY),
+		EntityCode: os.Getenv("ENTITY_CODE"),
+		ClosedBy:   godbledger.UserSystem.Id,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// go run cmd/job/main.go run -v=v1 -n=RunRecurringJournals -d=2024-01-31
+func (rh *accountingHandler) RunRecurringJournals(ctx context.Context, date time.Time) error {
+	if _, err := rh.accountingService.RunRecurringJournals(ctx, date); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// go run cmd/job/main.go run -v=v1 -n=ClearSuspenseAccounts -d=2024-01-31
+func (rh *accountingHandler) ClearSuspenseAccounts(ctx context.Context, date time.Time) error {
+	// the daily balance of today is not generated yet
+	if atime.DateEqualToday(date) {
+		date = date.AddDate(0, 0, -1)
+	}
+	if _, err := rh.accountingService.ClearSuspenseAccounts(ctx, date); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ENTITY_CODE=001 DATE_END=2024-01-31 go run cmd/job/main.go run -v=v1 -n=CheckLedgerIntegrity -d=2024-01-01
+func (rh *accountingHandler) CheckLedgerIntegrity(ctx context.Context, date time.Time) error {
+	// the daily balance of today is not generated yet
+	if atime.DateEqualToday(date) {
+		date = date.AddDate(0, 0, -1)
+	}
+	end := date
+	if v := os.Getenv("DATE_END"); v != "" {
+		de, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, v)
+		if err != nil {
+			return err
+		}
+		end = de
+	}
+
+	if _, err := rh.accountingService.CheckLedgerIntegrity(ctx, models.LedgerIntegrityRequest{
+		EntityCode: os.Getenv("ENTITY_CODE"),
+		StartDate:  date,
+		EndDate:    end,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -171,3 +171,310 @@ func Test_accountingHandler_GenerateRangeAccountDailyBalanceAndTrialBalance(t *t
 		})
 	}
 }
+
+func Test_accountingHandler_RevalueForeignCurrencyBalances(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	date, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-01-31")
+	type args struct {
+		ctx  context.Context
+		date time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success case - RevalueForeignCurrencyBalances",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().RevalueForeignCurrencyBalances(gomock.AssignableToTypeOf(args.ctx), args.date).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - RevalueForeignCurrencyBalances",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().RevalueForeignCurrencyBalances(gomock.AssignableToTypeOf(args.ctx), args.date).Return(models.GetErrMap(models.ErrKeyFxRateNotFound))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+			rh := &accountingHandler{
+				accountingService: testHelper.mockAccountingService,
+			}
+			err := rh.RevalueForeignCurrencyBalances(tt.args.ctx, tt.args.date)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_accountingHandler_CloseFiscalYear(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	date, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-12-31")
+	t.Setenv("ENTITY_CODE", "001")
+	req := models.YearEndClosingRequest{
+		FiscalYear: "2024",
+		EntityCode: "001",
+		ClosedBy:   "system",
+	}
+	type args struct {
+		ctx  context.Context
+		date time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success case - CloseFiscalYear",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().CloseFiscalYear(gomock.AssignableToTypeOf(args.ctx), req).Return(models.YearEndClosing{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - CloseFiscalYear",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().CloseFiscalYear(gomock.AssignableToTypeOf(args.ctx), req).Return(models.YearEndClosing{}, models.GetErrMap(models.ErrKeyFiscalYearAlreadyClosed))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+			rh := &accountingHandler{
+				accountingService: testHelper.mockAccountingService,
+			}
+			err := rh.CloseFiscalYear(tt.args.ctx, tt.args.date)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_accountingHandler_RunRecurringJournals(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	date, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-01-31")
+	type args struct {
+		ctx  context.Context
+		date time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success case - RunRecurringJournals",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().RunRecurringJournals(gomock.AssignableToTypeOf(args.ctx), date).Return(models.RunRecurringJournalResult{Posted: 1}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - RunRecurringJournals",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().RunRecurringJournals(gomock.AssignableToTypeOf(args.ctx), date).Return(models.RunRecurringJournalResult{}, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+			rh := &accountingHandler{
+				accountingService: testHelper.mockAccountingService,
+			}
+			err := rh.RunRecurringJournals(tt.args.ctx, tt.args.date)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_accountingHandler_ClearSuspenseAccounts(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	date, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-01-31")
+	today, _ := atime.NowZeroTime()
+	type args struct {
+		ctx  context.Context
+		date time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success case - ClearSuspenseAccounts",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().ClearSuspenseAccounts(gomock.AssignableToTypeOf(args.ctx), date).Return(models.ClearSuspenseAccountsResult{Cleared: 1}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success case - today is cleared with the balance of yesterday",
+			args: args{
+				ctx:  context.TODO(),
+				date: today,
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().ClearSuspenseAccounts(gomock.AssignableToTypeOf(args.ctx), today.AddDate(0, 0, -1)).Return(models.ClearSuspenseAccountsResult{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - ClearSuspenseAccounts",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().ClearSuspenseAccounts(gomock.AssignableToTypeOf(args.ctx), date).Return(models.ClearSuspenseAccountsResult{}, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+			rh := &accountingHandler{
+				accountingService: testHelper.mockAccountingService,
+			}
+			err := rh.ClearSuspenseAccounts(tt.args.ctx, tt.args.date)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_accountingHandler_CheckLedgerIntegrity(t *testing.T) {
+	testHelper := accountingTestHelper(t)
+	date, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-01-01")
+	dateEnd, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-01-31")
+	t.Setenv("ENTITY_CODE", "001")
+	type args struct {
+		ctx     context.Context
+		date    time.Time
+		dateEnd string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success case - CheckLedgerIntegrity",
+			args: args{
+				ctx:     context.TODO(),
+				date:    date,
+				dateEnd: "2024-01-31",
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().CheckLedgerIntegrity(gomock.AssignableToTypeOf(args.ctx), models.LedgerIntegrityRequest{
+					EntityCode: "001",
+					StartDate:  date,
+					EndDate:    dateEnd,
+				}).Return(models.LedgerIntegrityReport{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success case - without date end the date is checked",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().CheckLedgerIntegrity(gomock.AssignableToTypeOf(args.ctx), models.LedgerIntegrityRequest{
+					EntityCode: "001",
+					StartDate:  date,
+					EndDate:    date,
+				}).Return(models.LedgerIntegrityReport{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - invalid date end",
+			args: args{
+				ctx:     context.TODO(),
+				date:    date,
+				dateEnd: "31-01-2024",
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - CheckLedgerIntegrity",
+			args: args{
+				ctx:     context.TODO(),
+				date:    date,
+				dateEnd: "2024-01-31",
+			},
+			doMock: func(args args) {
+				testHelper.mockAccountingService.EXPECT().CheckLedgerIntegrity(gomock.AssignableToTypeOf(args.ctx), gomock.Any()).Return(models.LedgerIntegrityReport{}, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DATE_END", tt.args.dateEnd)
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+			rh := &accountingHandler{
+				accountingService: testHelper.mockAccountingService,
+			}
+			err := rh.CheckLedgerIntegrity(tt.args.ctx, tt.args.date)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
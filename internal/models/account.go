@@ -156,6 +156,8 @@ type (
 		OwnerID       string           `json:"ownerId" validate:"required,alphanum,min=1,max=15" example:"12345"`
 		AltID         string           `json:"altId" validate:"omitempty,alphanumDashUscore,max=100" example:"534534534555353523523423423"`
 		LegacyID      *AccountLegacyId `json:"legacyId"`
+		UpdatedBy     string           `json:"updatedBy" validate:"max=100" example:"tono@amartha.com"`
+		Reason        string           `json:"reason" validate:"max=255" example:"customer name correction"`
 	}
 	DoUpdateAccountResponse struct {
 		Kind    string `json:"kind" example:"account"`
@@ -169,6 +171,14 @@ type (
 		AltID         string
 		LegacyId      *AccountLegacyId
 		AccountNumber string
+
+		// EntityCode & Metadata are only updated by the bulk update, an empty entity code & nil metadata are not updated
+		EntityCode string
+		Metadata   *Metadata
+
+		// ChangedBy & Reason are written to the account change log
+		ChangedBy string
+		Reason    string
 	}
 
 	UpdateLegacyId struct {
@@ -450,6 +460,8 @@ type (
 	DoUpdateAccountEntityRequest struct {
 		AccountNumber string `param:"accountNumber" json:"accountNumber" validate:"required" example:"21100100000001"`
 		EntityCode    string `json:"entityCode" validate:"required,min=3,max=5,numeric" example:"001"`
+		UpdatedBy     string `json:"updatedBy" validate:"max=100" example:"tono@amartha.com"`
+		Reason        string `json:"reason" validate:"max=255" example:"wrong entity"`
 	}
 	DoUpdateAccountEntityResponse struct {
 		Kind       string `json:"kind" example:"account"`
@@ -458,6 +470,10 @@ type (
 	UpdateAccountEntity struct {
 		EntityCode    string
 		AccountNumber string
+
+		// ChangedBy & Reason are written to the account change log
+		ChangedBy string
+		Reason    string
 	}
 )
 
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	KindAccountBalance  = "accountBalance"
+	KindAccountBalances = "accountBalances"
+)
+
+type DoGetAccountBalanceRequest struct {
+	AccountNumber string `param:"accountNumber" json:"-" validate:"required"`
+	AsOf          string `query:"asOf" json:"asOf" example:"2024-04-30 13:00:00"`
+}
+
+type DoGetAccountBalancesRequest struct {
+	AccountNumbers []string `json:"accountNumbers" validate:"required,min=1,dive,required" example:"142001000000001,212001000000001"`
+	AsOf           string   `json:"asOf" example:"2024-04-30"`
+}
+
+// AccountBalanceFilterOptions returns the balance of the accounts at the as of time, the splits at the as of time are included.
+type AccountBalanceFilterOptions struct {
+	AccountNumbers []string
+	AsOf           time.Time
+}
+
+func (req DoGetAccountBalanceRequest) ToAsOf() (time.Time, error) {
+	return toAccountBalanceAsOf(req.AsOf)
+}
+
+func (req DoGetAccountBalancesRequest) ToFilterOpts() (*AccountBalanceFilterOptions, error) {
+	asOf, err := toAccountBalanceAsOf(req.AsOf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountBalanceFilterOptions{
+		AccountNumbers: req.AccountNumbers,
+		AsOf:           asOf,
+	}, nil
+}
+
+// toAccountBalanceAsOf returns now when the as of is not set, a date without time is the end of the date.
+func toAccountBalanceAsOf(asOf string) (time.Time, error) {
+	if asOf == "" {
+		return atime.Now(), nil
+	}
+
+	if date, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDDWithTime, asOf); err == nil {
+		return date, nil
+	}
+
+	date, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, asOf)
+	if err != nil {
+		return time.Time{}, GetErrMap(ErrKeyInvalidFormatDate, "asOf format must be YYYY-MM-DD or YYYY-MM-DD HH:mm:ss")
+	}
+	_, date = atime.StartDateEndDate(date, date)
+
+	return date, nil
+}
+
+type AccountBalanceResponse struct {
+	Kind             string          `json:"kind" example:"accountBalance"`
+	AccountNumber    string          `json:"accountNumber" example:"142001000000001"`
+	EntityCode       string          `json:"entityCode" example:"001"`
+	SubCategoryCode  string          `json:"subCategoryCode" example:"14201"`
+	Currency         string          `json:"currency" example:"IDR"`
+	AsOf             string          `json:"asOf" example:"2024-04-30 13:00:00"`
+	Balance          decimal.Decimal `json:"balance" example:"150000"`
+	DailyBalanceDate string          `json:"dailyBalanceDate,omitempty" example:"2024-04-29"`
+	DailyBalance     decimal.Decimal `json:"dailyBalance" example:"100000"`
+	DebitMovement    decimal.Decimal `json:"debitMovement" example:"50000"`
+	CreditMovement   decimal.Decimal `json:"creditMovement" example:"0"`
+}
+
+type AccountBalancesResponse struct {
+	Kind                   string                   `json:"kind" example:"accountBalances"`
+	AsOf                   string                   `json:"asOf" example:"2024-04-30 13:00:00"`
+	Balances               []AccountBalanceResponse `json:"balances"`
+	NotFoundAccountNumbers []string                 `json:"notFoundAccountNumbers"`
+}
+
+// AccountBalance is the closing balance of the latest daily balance before the as of date, plus the movements
+// of the splits after the daily balance date until the as of time.
+type AccountBalance struct {
+	AccountNumber    string
+	EntityCode       string
+	SubCategoryCode  string
+	Currency         string
+	AsOf             time.Time
+	DailyBalanceDate *time.Time
+	DailyBalance     decimal.Decimal
+	DebitMovement    decimal.Decimal
+	CreditMovement   decimal.Decimal
+	Balance          decimal.Decimal
+}
+
+type AccountBalances struct {
+	AsOf                   time.Time
+	Balances               []AccountBalance
+	NotFoundAccountNumbers []string
+}
+
+func (ab *AccountBalance) ToResponse() AccountBalanceResponse {
+	res := AccountBalanceResponse{
+		Kind:            KindAccountBalance,
+		AccountNumber:   ab.AccountNumber,
+		EntityCode:      ab.EntityCode,
+		SubCategoryCode: ab.SubCategoryCode,
+		Currency:        ab.Currency,
+		AsOf:            ab.AsOf.In(atime.GetLocation()).Format(atime.DateFormatYYYYMMDDWithTime),
+		Balance:         ab.Balance,
+		DailyBalance:    ab.DailyBalance,
+		DebitMovement:   ab.DebitMovement,
+		CreditMovement:  ab.CreditMovement,
+	}
+	if ab.DailyBalanceDate != nil {
+		res.DailyBalanceDate = ab.DailyBalanceDate.Format(atime.DateFormatYYYYMMDD)
+	}
+
+	return res
+}
+
+func (ab *AccountBalances) ToResponse() AccountBalancesResponse {
+	res := AccountBalancesResponse{
+		Kind:                   KindAccountBalances,
+		AsOf:                   ab.AsOf.In(atime.GetLocation()).Format(atime.DateFormatYYYYMMDDWithTime),
+		Balances:               []AccountBalanceResponse{},
+		NotFoundAccountNumbers: []string{},
+	}
+	for _, v := range ab.Balances {
+		res.Balances = append(res.Balances, v.ToResponse())
+	}
+	res.NotFoundAccountNumbers = append(res.NotFoundAccountNumbers, ab.NotFoundAccountNumbers...)
+
+	return res
+}
@@ -16,6 +16,7 @@ type AccountBalanceDaily struct {
 	CreditMovement  decimal.Decimal
 	OpeningBalance  decimal.Decimal
 	ClosingBalance  decimal.Decimal
+	Currency        string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
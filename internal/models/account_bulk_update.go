@@ -0,0 +1,128 @@
+package models
+
+const (
+	KindAccountBulkUpdateReport = "accountBulkUpdateReport"
+)
+
+// status of a row in the bulk update account report
+const (
+	AccountBulkUpdateRowStatusSuccess = "success"
+	AccountBulkUpdateRowStatusFailed  = "failed"
+)
+
+// extension of the bulk update account file
+const (
+	AccountBulkUpdateExtCSV = ".csv"
+)
+
+// column of the bulk update account csv, the columns are matched by the header name so the order of the columns is free.
+// Only the account number column is required, an empty cell keeps the current value of the account.
+const (
+	AccountBulkUpdateColumnAccountNumber    = "account_number"
+	AccountBulkUpdateColumnName             = "name"
+	AccountBulkUpdateColumnOwnerID          = "owner_id"
+	AccountBulkUpdateColumnAltID            = "alt_id"
+	AccountBulkUpdateColumnT24AccountNumber = "t24_account_number"
+	AccountBulkUpdateColumnEntityCode       = "entity_code"
+
+	// a json object merged into the current metadata, a key with a null value is removed
+	AccountBulkUpdateColumnMetadata = "metadata"
+)
+
+type DoBulkUpdateAccountsRequest struct {
+	Accounts  []BulkUpdateAccount `json:"accounts" validate:"required,min=1"`
+	UpdatedBy string              `json:"updatedBy" validate:"max=100" example:"tono@amartha.com"`
+	Reason    string              `json:"reason" validate:"max=255" example:"lender accounts migration to the new entity"`
+}
+
+// DoUploadBulkUpdateAccountsRequest is the form of the bulk update csv, the csv is processed by an upload job.
+type DoUploadBulkUpdateAccountsRequest struct {
+	UpdatedBy string `json:"updatedBy" validate:"max=100"`
+	Reason    string `json:"reason" validate:"max=255"`
+}
+
+// BulkUpdateAccount is a row of the bulk update, an empty field keeps the current value of the account
+// & the metadata is merged into the current metadata.
+type BulkUpdateAccount struct {
+	AccountNumber string           `json:"accountNumber" validate:"required" example:"21100100000001"`
+	Name          string           `json:"name" validate:"max=100" example:"Lender Yang Baik"`
+	OwnerID       string           `json:"ownerId" validate:"omitempty,alphanum,max=15" example:"12345"`
+	AltID         string           `json:"altId" validate:"omitempty,alphanumDashUscore,max=100" example:"534534534555353523523423423"`
+	LegacyId      *AccountLegacyId `json:"legacyId" swaggertype:"object,string" example:"t24AccountNumber:1234567890,t24ArrangementId:1234567890"`
+	EntityCode    string           `json:"entityCode" validate:"omitempty,numeric,min=3,max=3" example:"001"`
+	Metadata      *Metadata        `json:"metadata" swaggertype:"object,string" example:"segment:retail"`
+
+	// Row is the row number in the report, the header is the first row of a csv.
+	Row int `json:"-"`
+
+	// Err is the error of parsing a csv row, the row is failed without being updated.
+	Err error `json:"-"`
+}
+
+type BulkUpdateAccountsIn struct {
+	Accounts []BulkUpdateAccount
+
+	// ChangedBy & Reason are written to the account change log of every account
+	ChangedBy string
+	Reason    string
+}
+
+func (req DoBulkUpdateAccountsRequest) ToBulkUpdateAccountsIn() BulkUpdateAccountsIn {
+	accounts := make([]BulkUpdateAccount, len(req.Accounts))
+	for i, v := range req.Accounts {
+		if v.Row == 0 {
+			v.Row = i + 1
+		}
+		accounts[i] = v
+	}
+
+	return BulkUpdateAccountsIn{
+		Accounts:  accounts,
+		ChangedBy: req.UpdatedBy,
+		Reason:    req.Reason,
+	}
+}
+
+type AccountBulkUpdateReport struct {
+	Kind        string                       `json:"kind" example:"accountBulkUpdateReport"`
+	TotalRows   int                          `json:"totalRows" example:"2"`
+	SuccessRows int                          `json:"successRows" example:"1"`
+	FailedRows  int                          `json:"failedRows" example:"1"`
+	Rows        []AccountBulkUpdateRowReport `json:"rows"`
+}
+
+// AccountBulkUpdateRowReport is the result of a row, a failed row is not updated.
+type AccountBulkUpdateRowReport struct {
+	Row           int      `json:"row" example:"2"`
+	AccountNumber string   `json:"accountNumber" example:"21100100000001"`
+	Status        string   `json:"status" example:"failed"`
+	Errors        []string `json:"errors" example:"account number not found"`
+}
+
+// AddError adds the message of the error, every error of a multi error is added.
+func (r *AccountBulkUpdateRowReport) AddError(err error) {
+	if errs, ok := err.(interface{ WrappedErrors() []error }); ok {
+		for _, v := range errs.WrappedErrors() {
+			r.AddError(v)
+		}
+		return
+	}
+	if errMap, ok := IsErrMap(err); ok {
+		r.Errors = append(r.Errors, errMap.Message)
+		return
+	}
+	r.Errors = append(r.Errors, err.Error())
+}
+
+func (report *AccountBulkUpdateReport) AddRow(row AccountBulkUpdateRowReport) {
+	row.Status = AccountBulkUpdateRowStatusSuccess
+	if len(row.Errors) > 0 {
+		row.Status = AccountBulkUpdateRowStatusFailed
+		report.FailedRows++
+	} else {
+		row.Errors = []string{}
+		report.SuccessRows++
+	}
+	report.TotalRows++
+	report.Rows = append(report.Rows, row)
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	KindAccountChangeLog = "accountChangeLog"
+)
+
+// fields of the account written to the change log
+const (
+	AccountChangeLogFieldName       = "name"
+	AccountChangeLogFieldOwnerID    = "ownerId"
+	AccountChangeLogFieldAltID      = "altId"
+	AccountChangeLogFieldLegacyID   = "legacyId"
+	AccountChangeLogFieldEntityCode = "entityCode"
+	AccountChangeLogFieldStatus     = "status"
+	AccountChangeLogFieldMetadata   = "metadata"
+)
+
+// actor of the change log when the change is not made by a user
+const (
+	AccountChangeLogActorSystem     = "system"
+	AccountChangeLogActorGoCustomer = "go-customer"
+	AccountChangeLogActorMigration  = "t24-migration"
+)
+
+type DoGetAccountHistoryRequest struct {
+	AccountNumber string `param:"accountNumber" json:"-" validate:"required"`
+	Field         string `query:"field" json:"field" validate:"omitempty,oneof=name ownerId altId legacyId entityCode status metadata" example:"name"`
+}
+
+type AccountChangeLogFilterOptions struct {
+	AccountNumber string
+	Field         string
+}
+
+func (req DoGetAccountHistoryRequest) ToFilterOpts() AccountChangeLogFilterOptions {
+	return AccountChangeLogFilterOptions{
+		AccountNumber: req.AccountNumber,
+		Field:         req.Field,
+	}
+}
+
+type AccountChangeLogResponse struct {
+	Kind          string    `json:"kind" example:"accountChangeLog"`
+	ID            int       `json:"id" example:"1"`
+	AccountNumber string    `json:"accountNumber" example:"21100100000001"`
+	Field         string    `json:"field" example:"name"`
+	BeforeValue   string    `json:"beforeValue" example:"Lender Yang Baik"`
+	AfterValue    string    `json:"afterValue" example:"Lender Yang Sangat Baik"`
+	ChangedBy     string    `json:"changedBy" example:"tono@amartha.com"`
+	Reason        string    `json:"reason" example:"customer name correction"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// AccountFieldChange is the before & after value of a field of the account.
+type AccountFieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// CreateAccountChangeLog is a row of the append-only acct_account_change_log table.
+type CreateAccountChangeLog struct {
+	AccountNumber string
+	Field         string
+	BeforeValue   string
+	AfterValue    string
+	ChangedBy     string
+	Reason        string
+}
+
+type AccountChangeLog struct {
+	ID            int
+	AccountNumber string
+	Field         string
+	BeforeValue   string
+	AfterValue    string
+	ChangedBy     string
+	Reason        string
+	CreatedAt     time.Time
+}
+
+func (l *AccountChangeLog) ToResponse() AccountChangeLogResponse {
+	return AccountChangeLogResponse{
+		Kind:          KindAccountChangeLog,
+		ID:            l.ID,
+		AccountNumber: l.AccountNumber,
+		Field:         l.Field,
+		BeforeValue:   l.BeforeValue,
+		AfterValue:    l.AfterValue,
+		ChangedBy:     l.ChangedBy,
+		Reason:        l.Reason,
+		CreatedAt:     l.CreatedAt,
+	}
+}
+
+// NewAccountChangeLogs returns the change logs of the changed fields only,
+// the change is made by the system when changedBy is empty.
+func NewAccountChangeLogs(accountNumber, changedBy, reason string, changes ...AccountFieldChange) []CreateAccountChangeLog {
+	if changedBy == "" {
+		changedBy = AccountChangeLogActorSystem
+	}
+
+	logs := make([]CreateAccountChangeLog, 0, len(changes))
+	for _, v := range changes {
+		if v.Before == v.After {
+			continue
+		}
+		logs = append(logs, CreateAccountChangeLog{
+			AccountNumber: accountNumber,
+			Field:         v.Field,
+			BeforeValue:   v.Before,
+			AfterValue:    v.After,
+			ChangedBy:     changedBy,
+			Reason:        reason,
+		})
+	}
+
+	return logs
+}
+
+// ChangeLogValue returns the json of the legacy id to be written to the change log, a nil legacy id is empty.
+func (al *AccountLegacyId) ChangeLogValue() string {
+	if al == nil {
+		return ""
+	}
+
+	b, err := json.Marshal(al)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+// ChangeLogValue returns the json of the metadata to be written to the change log, a nil metadata is empty.
+func (m *Metadata) ChangeLogValue() string {
+	if m == nil {
+		return ""
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	KindAccountHierarchy = "accountHierarchy"
+)
+
+// AccountRelationChild is the relation of an account linked to its parent account, e.g. the accounts of a lender master
+// or the loan accounts of a partner, the other relations are written by the account relationship rules.
+const AccountRelationChild = "child"
+
+type DoCreateAccountParentRequest struct {
+	AccountNumber       string `param:"accountNumber" json:"-" validate:"required"`
+	ParentAccountNumber string `json:"parentAccountNumber" validate:"required" example:"211001000000001"`
+}
+
+type DoGetAccountHierarchyRequest struct {
+	AccountNumber string `param:"accountNumber" json:"-" validate:"required"`
+	BalanceDate   string `query:"balanceDate" json:"balanceDate" example:"2024-04-30"`
+}
+
+func (req DoCreateAccountParentRequest) ToAccountRelation() AccountRelation {
+	return AccountRelation{
+		ParentAccountNumber: req.ParentAccountNumber,
+		AccountNumber:       req.AccountNumber,
+		Relation:            AccountRelationChild,
+	}
+}
+
+// ToBalanceDate returns yesterday when the balance date is not set, the daily balance of today is not closed yet.
+func (req DoGetAccountHierarchyRequest) ToBalanceDate() (time.Time, error) {
+	if req.BalanceDate == "" {
+		return *atime.YesterdayTime(), nil
+	}
+
+	date, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, req.BalanceDate)
+	if err != nil {
+		return time.Time{}, GetErrMap(ErrKeyInvalidFormatDate, "balanceDate format must be YYYY-MM-DD")
+	}
+
+	return date, nil
+}
+
+// AccountRelation links an account to its parent account, an account has at most one parent account.
+type AccountRelation struct {
+	ParentAccountNumber string
+	AccountNumber       string
+	Relation            string
+}
+
+type AccountRelationFilterOptions struct {
+	ParentAccountNumbers []string
+	AccountNumbers       []string
+	Relations            []string
+	IncludeUnlinked      bool // include the lender & loan accounts without the child account of the relation, the account number is empty
+}
+
+type AccountRelationResponse struct {
+	Kind                string `json:"kind" example:"accountHierarchy"`
+	ParentAccountNumber string `json:"parentAccountNumber" example:"211001000000001"`
+	AccountNumber       string `json:"accountNumber" example:"211001000381110"`
+	Relation            string `json:"relation" example:"child"`
+}
+
+func (ar *AccountRelation) ToResponse() AccountRelationResponse {
+	return AccountRelationResponse{
+		Kind:                KindAccountHierarchy,
+		ParentAccountNumber: ar.ParentAccountNumber,
+		AccountNumber:       ar.AccountNumber,
+		Relation:            ar.Relation,
+	}
+}
+
+// AccountHierarchy is the subtree of an account, the rolled up balance is the closing balance of the account
+// plus the rolled up balance of the children of the same coa type & currency.
+type AccountHierarchy struct {
+	AccountNumber   string
+	AccountName     string
+	CoaTypeCode     string
+	SubCategoryCode string
+	Currency        string
+	Relation        string
+	BalanceDate     time.Time
+	Balance         decimal.Decimal
+	RolledUpBalance decimal.Decimal
+	Children        []AccountHierarchy
+}
+
+type AccountHierarchyResponse struct {
+	Kind            string                     `json:"kind" example:"accountHierarchy"`
+	AccountNumber   string                     `json:"accountNumber" example:"211001000000001"`
+	AccountName     string                     `json:"accountName" example:"Lender Yang Baik"`
+	CoaTypeCode     string                     `json:"coaTypeCode" example:"LIA"`
+	SubCategoryCode string                     `json:"subCategoryCode" example:"21101"`
+	Currency        string                     `json:"currency" example:"IDR"`
+	Relation        string                     `json:"relation,omitempty" example:"child"`
+	BalanceDate     string                     `json:"balanceDate" example:"2024-04-30"`
+	Balance         decimal.Decimal            `json:"balance" example:"100000"`
+	RolledUpBalance decimal.Decimal            `json:"rolledUpBalance" example:"150000"`
+	Children        []AccountHierarchyResponse `json:"children"`
+}
+
+func (ah *AccountHierarchy) ToResponse() AccountHierarchyResponse {
+	res := AccountHierarchyResponse{
+		Kind:            KindAccountHierarchy,
+		AccountNumber:   ah.AccountNumber,
+		AccountName:     ah.AccountName,
+		CoaTypeCode:     ah.CoaTypeCode,
+		SubCategoryCode: ah.SubCategoryCode,
+		Currency:        ah.Currency,
+		Relation:        ah.Relation,
+		BalanceDate:     ah.BalanceDate.Format(atime.DateFormatYYYYMMDD),
+		Balance:         ah.Balance,
+		RolledUpBalance: ah.RolledUpBalance,
+		Children:        []AccountHierarchyResponse{},
+	}
+	for _, v := range ah.Children {
+		res.Children = append(res.Children, v.ToResponse())
+	}
+
+	return res
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	KindAccountRelationshipRule = "accountRelationshipRule"
+)
+
+// relation of the target account to the source account, the relation decides where the relationship is stored
+const (
+	// AccountRelationInvested is stored as the invested account of the lender account
+	AccountRelationInvested = "invested"
+	// AccountRelationReceivables is stored as the receivables account of the lender account
+	AccountRelationReceivables = "receivables"
+	// AccountRelationLoanAdvancePayment is stored as the loan advance payment account of the loan account
+	AccountRelationLoanAdvancePayment = "loanAdvancePayment"
+)
+
+// cardinality of the source accounts of an owner to the target account
+const (
+	// AccountRelationshipCardinalityOneToOne every source account has its own target account
+	AccountRelationshipCardinalityOneToOne = "oneToOne"
+	// AccountRelationshipCardinalityManyToOne the source accounts of an owner share the same target account
+	AccountRelationshipCardinalityManyToOne = "manyToOne"
+)
+
+// placeholders of the naming template, replaced by the values of the source account
+const (
+	AccountNameTemplateName          = "{name}"
+	AccountNameTemplateAccountNumber = "{accountNumber}"
+	AccountNameTemplateAltId         = "{altId}"
+)
+
+type CreateAccountRelationshipRuleRequest struct {
+	SourceSubCategoryCode string `json:"sourceSubCategoryCode" validate:"required,numeric,min=5,max=5" example:"21101"`
+	SourceAccountNumber   string `json:"sourceAccountNumber" validate:"omitempty,numeric" example:"21100100000001"`
+	TargetSubCategoryCode string `json:"targetSubCategoryCode" validate:"required,numeric,min=5,max=5" example:"21201"`
+	TargetAccountNumber   string `json:"targetAccountNumber" validate:"omitempty,numeric" example:"14200100000001"`
+	Relation              string `json:"relation" validate:"required,oneof=invested receivables loanAdvancePayment" example:"invested"`
+	Cardinality           string `json:"cardinality" validate:"required,oneof=oneToOne manyToOne" example:"oneToOne"`
+	NameTemplate          string `json:"nameTemplate" validate:"max=100" example:"{name} - Invested"`
+}
+
+func (req CreateAccountRelationshipRuleRequest) ToAccountRelationshipRule() AccountRelationshipRule {
+	return AccountRelationshipRule{
+		SourceSubCategoryCode: req.SourceSubCategoryCode,
+		SourceAccountNumber:   req.SourceAccountNumber,
+		TargetSubCategoryCode: req.TargetSubCategoryCode,
+		TargetAccountNumber:   req.TargetAccountNumber,
+		Relation:              req.Relation,
+		Cardinality:           req.Cardinality,
+		NameTemplate:          req.NameTemplate,
+		Status:                StatusActive,
+	}
+}
+
+type UpdateAccountRelationshipRuleRequest struct {
+	ID                    int    `param:"id" json:"-" validate:"required"`
+	TargetSubCategoryCode string `json:"targetSubCategoryCode" validate:"required,numeric,min=5,max=5" example:"21201"`
+	TargetAccountNumber   string `json:"targetAccountNumber" validate:"omitempty,numeric" example:"14200100000001"`
+	Cardinality           string `json:"cardinality" validate:"required,oneof=oneToOne manyToOne" example:"oneToOne"`
+	NameTemplate          string `json:"nameTemplate" validate:"max=100" example:"{name} - Invested"`
+	Status                string `json:"status" validate:"required,oneof=active inactive" example:"active"`
+}
+
+func (req UpdateAccountRelationshipRuleRequest) ToAccountRelationshipRule() AccountRelationshipRule {
+	return AccountRelationshipRule{
+		ID:                    req.ID,
+		TargetSubCategoryCode: req.TargetSubCategoryCode,
+		TargetAccountNumber:   req.TargetAccountNumber,
+		Cardinality:           req.Cardinality,
+		NameTemplate:          req.NameTemplate,
+		Status:                req.Status,
+	}
+}
+
+type GetAccountRelationshipRuleRequest struct {
+	SourceSubCategoryCode string `query:"sourceSubCategoryCode" json:"sourceSubCategoryCode" validate:"omitempty,numeric,min=5,max=5" example:"21101"`
+	Status                string `query:"status" json:"status" validate:"omitempty,oneof=active inactive" example:"active"`
+}
+
+type AccountRelationshipRuleFilterOptions struct {
+	SourceSubCategoryCode string
+	Status                string
+}
+
+func (req GetAccountRelationshipRuleRequest) ToFilterOpts() AccountRelationshipRuleFilterOptions {
+	return AccountRelationshipRuleFilterOptions{
+		SourceSubCategoryCode: req.SourceSubCategoryCode,
+		Status:                req.Status,
+	}
+}
+
+type AccountRelationshipRuleResponse struct {
+	Kind                  string    `json:"kind" example:"accountRelationshipRule"`
+	ID                    int       `json:"id" example:"1"`
+	SourceSubCategoryCode string    `json:"sourceSubCategoryCode" example:"21101"`
+	SourceAccountNumber   string    `json:"sourceAccountNumber,omitempty" example:"21100100000001"`
+	TargetSubCategoryCode string    `json:"targetSubCategoryCode" example:"21201"`
+	TargetAccountNumber   string    `json:"targetAccountNumber,omitempty" example:"14200100000001"`
+	Relation              string    `json:"relation" example:"invested"`
+	Cardinality           string    `json:"cardinality" example:"oneToOne"`
+	NameTemplate          string    `json:"nameTemplate" example:"{name} - Invested"`
+	Status                string    `json:"status" example:"active"`
+	CreatedAt             time.Time `json:"createdAt"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+// AccountRelationshipRule generates the target account of the relation when an account of the source sub category is created,
+// a source sub category has at most one rule per relation & source account number.
+// a rule with the source account number applies only to the account & takes precedence over the rule of the sub category,
+// a rule with the target account number links the account to the fixed target account instead of generating it.
+type AccountRelationshipRule struct {
+	ID                    int
+	SourceSubCategoryCode string
+	SourceAccountNumber   string
+	TargetSubCategoryCode string
+	TargetAccountNumber   string
+	Relation              string
+	Cardinality           string
+	NameTemplate          string
+	Status                string
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+func (r *AccountRelationshipRule) ToResponse() AccountRelationshipRuleResponse {
+	return AccountRelationshipRuleResponse{
+		Kind:                  KindAccountRelationshipRule,
+		ID:                    r.ID,
+		SourceSubCategoryCode: r.SourceSubCategoryCode,
+		SourceAccountNumber:   r.SourceAccountNumber,
+		TargetSubCategoryCode: r.TargetSubCategoryCode,
+		TargetAccountNumber:   r.TargetAccountNumber,
+		Relation:              r.Relation,
+		Cardinality:           r.Cardinality,
+		NameTemplate:          r.NameTemplate,
+		Status:                r.Status,
+		CreatedAt:             r.CreatedAt,
+		UpdatedAt:             r.UpdatedAt,
+	}
+}
+
+// AccountName returns the name of the target account from the naming template,
+// the target account has the name of the source account when the template is empty.
+func (r *AccountRelationshipRule) AccountName(source CreateAccount) string {
+	if r.NameTemplate == "" {
+		return source.Name
+	}
+
+	return strings.NewReplacer(
+		AccountNameTemplateName, source.Name,
+		AccountNameTemplateAccountNumber, source.AccountNumber,
+		AccountNameTemplateAltId, source.AltId,
+	).Replace(r.NameTemplate)
+}
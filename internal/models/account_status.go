@@ -1,20 +1,109 @@
 package models
 
+import "slices"
+
 type AccountStatus int32
 
 const (
 	ACCOUNT_STATUS_ACTIVE AccountStatus = iota
 	ACCOUNT_STATUS_INACTIVE
+	ACCOUNT_STATUS_FROZEN_CREDIT_ONLY
+	ACCOUNT_STATUS_FROZEN_DEBIT_ONLY
+	ACCOUNT_STATUS_DORMANT
+	ACCOUNT_STATUS_CLOSED
 )
 
 const (
 	AccountStatusActive   = "active"
 	AccountStatusInActive = "inactive"
+
+	// AccountStatusFrozenCreditOnly only accepts credit postings, every debit posting is rejected.
+	AccountStatusFrozenCreditOnly = "frozen_credit_only"
+	// AccountStatusFrozenDebitOnly only accepts debit postings, every credit posting is rejected.
+	AccountStatusFrozenDebitOnly = "frozen_debit_only"
+	// AccountStatusDormant still accepts credit postings, the account must be reactivated before any debit posting.
+	AccountStatusDormant = "dormant"
+	// AccountStatusClosed is the final status of the account, every posting is rejected.
+	AccountStatusClosed = "closed"
 )
 
 var (
 	MapAccountStatus = map[AccountStatus]string{
-		ACCOUNT_STATUS_ACTIVE:   AccountStatusActive,
-		ACCOUNT_STATUS_INACTIVE: AccountStatusInActive,
+		ACCOUNT_STATUS_ACTIVE:             AccountStatusActive,
+		ACCOUNT_STATUS_INACTIVE:           AccountStatusInActive,
+		ACCOUNT_STATUS_FROZEN_CREDIT_ONLY: AccountStatusFrozenCreditOnly,
+		ACCOUNT_STATUS_FROZEN_DEBIT_ONLY:  AccountStatusFrozenDebitOnly,
+		ACCOUNT_STATUS_DORMANT:            AccountStatusDormant,
+		ACCOUNT_STATUS_CLOSED:             AccountStatusClosed,
+	}
+
+	// MapAccountStatusTransition is the next statuses allowed from the current status of the account,
+	// an account without status is an active account & the inactive status is kept for the existing accounts.
+	MapAccountStatusTransition = map[string][]string{
+		AccountStatusActive:           {AccountStatusFrozenCreditOnly, AccountStatusFrozenDebitOnly, AccountStatusDormant, AccountStatusClosed},
+		AccountStatusInActive:         {AccountStatusActive, AccountStatusClosed},
+		AccountStatusFrozenCreditOnly: {AccountStatusActive, AccountStatusFrozenDebitOnly, AccountStatusClosed},
+		AccountStatusFrozenDebitOnly:  {AccountStatusActive, AccountStatusFrozenCreditOnly, AccountStatusClosed},
+		AccountStatusDormant:          {AccountStatusActive, AccountStatusClosed},
+		AccountStatusClosed:           {},
+	}
+)
+
+// IsValidAccountStatus returns true when the status is one of the lifecycle statuses of the account.
+func IsValidAccountStatus(status string) bool {
+	_, ok := MapAccountStatusTransition[status]
+	return ok && status != AccountStatusInActive
+}
+
+// CanTransitionAccountStatus returns true when the account is allowed to move from the current status to the next status.
+func CanTransitionAccountStatus(current, next string) bool {
+	if current == "" {
+		current = AccountStatusActive
+	}
+
+	return slices.Contains(MapAccountStatusTransition[current], next)
+}
+
+// IsPostingAllowed returns true when the status of the account accepts a debit or credit posting.
+func IsPostingAllowed(status string, isDebit bool) bool {
+	switch status {
+	case AccountStatusClosed:
+		return false
+	case AccountStatusFrozenCreditOnly, AccountStatusDormant:
+		return !isDebit
+	case AccountStatusFrozenDebitOnly:
+		return isDebit
+	default:
+		return true
+	}
+}
+
+type (
+	DoUpdateAccountStatusRequest struct {
+		AccountNumber string `param:"accountNumber" json:"-" validate:"required"`
+		Status        string `json:"status" validate:"required" example:"frozen_credit_only"`
+		UpdatedBy     string `json:"updatedBy" validate:"max=100" example:"tono@amartha.com"`
+		Reason        string `json:"reason" validate:"max=255" example:"fraud investigation"`
+	}
+	DoUpdateAccountStatusResponse struct {
+		Kind          string `json:"kind" example:"account"`
+		AccountNumber string `json:"accountNumber" example:"21100100000001"`
+		Status        string `json:"status" example:"frozen_credit_only"`
+	}
+	UpdateAccountStatus struct {
+		Status        string
+		AccountNumber string
+
+		// ChangedBy & Reason are written to the account change log
+		ChangedBy string
+		Reason    string
 	}
 )
+
+func (a *UpdateAccountStatus) ToResponse() *DoUpdateAccountStatusResponse {
+	return &DoUpdateAccountStatusResponse{
+		Kind:          KindAccount,
+		AccountNumber: a.AccountNumber,
+		Status:        a.Status,
+	}
+}
@@ -1,3 +1,13 @@
 package models
 
+import "strings"
+
 var CurrencyIDR = "IDR"
+
+// reportCurrency returns the currency of a report request, the report is in IDR when the currency is empty.
+func reportCurrency(currency string) string {
+	if currency == "" {
+		return CurrencyIDR
+	}
+	return strings.ToUpper(currency)
+}
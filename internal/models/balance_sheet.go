@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
@@ -12,21 +14,31 @@ const (
 	KindBalanceSheet     = "balanceSheet"
 	EntityCodeAMF        = "001"
 	KindBalanceSheetData = "balanceSheetData"
+
+	KindBalanceSheetComparison = "balanceSheetComparison"
+
+	// MaxBalanceSheetCompareDates is the maximum number of dates compared against the balance sheet date
+	MaxBalanceSheetCompareDates = 4
 )
 
 type GetBalanceSheetRequest struct {
 	EntityCode       string `json:"entityCode" query:"entityCode" example:"001"`
 	BalanceSheetDate string `json:"balanceSheetDate" query:"balanceSheetDate" example:"2023-12-01"`
+	CompareDates     string `json:"compareDates" query:"compareDates" example:"2023-11-30,2022-12-31"`
+	Currency         string `json:"currency" query:"currency" validate:"omitempty,alpha,min=3,max=3" example:"IDR"`
 }
 
 type BalanceSheetFilterOptions struct {
 	EntityCode       string
 	BalanceSheetDate time.Time
+	CompareDates     []time.Time
+	Currency         string
 }
 
 func (req GetBalanceSheetRequest) ToFilterOpts() (opts *BalanceSheetFilterOptions, err error) {
 	opts = &BalanceSheetFilterOptions{
 		EntityCode: req.EntityCode,
+		Currency:   reportCurrency(req.Currency),
 	}
 
 	if req.EntityCode == "" {
@@ -45,6 +57,29 @@ func (req GetBalanceSheetRequest) ToFilterOpts() (opts *BalanceSheetFilterOption
 		// default is end of previous month
 		_, opts.BalanceSheetDate = atime.PrevMonth(atime.Now())
 	}
+
+	if req.CompareDates != "" {
+		dates := strings.Split(req.CompareDates, ",")
+		if len(dates) > MaxBalanceSheetCompareDates {
+			return nil, fmt.Errorf("compareDates can have a maximum of %d dates", MaxBalanceSheetCompareDates)
+		}
+		seen := map[string]bool{opts.BalanceSheetDate.Format(atime.DateFormatYYYYMMDD): true}
+		for _, v := range dates {
+			date, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, strings.TrimSpace(v))
+			if err != nil {
+				return nil, GetErrMap(ErrKeyInvalidFormatDate, fmt.Sprintf("date %s format must be YYYY-MM-DD", v))
+			}
+			if atime.DateEqualToday(date) || atime.Now().Before(date) {
+				return nil, GetErrMap(ErrKeyBalanceSheetDateIsTodayOrLater)
+			}
+			formattedDate := date.Format(atime.DateFormatYYYYMMDD)
+			if seen[formattedDate] {
+				return nil, GetErrMap(ErrKeyBalanceSheetCompareDateIsDuplicated, fmt.Sprintf("date %s is duplicated", formattedDate))
+			}
+			seen[formattedDate] = true
+			opts.CompareDates = append(opts.CompareDates, date)
+		}
+	}
 	return opts, nil
 }
 
@@ -57,6 +92,9 @@ type GetBalanceSheetResponse struct {
 	BalanceSheetDate string `json:"balanceSheetDate" example:"2023-01-01"`
 
 	BalanceSheet BalanceSheetData `json:"balanceSheet"`
+
+	// Comparison is only filled when the request has compare dates
+	Comparison *BalanceSheetComparison `json:"comparison,omitempty"`
 }
 
 type BalanceSheetData struct {
@@ -92,3 +130,56 @@ type BalanceSheetOut struct {
 	BalanceSheet    map[string][]BalanceCategory
 	TotalPerCOAType map[string]decimal.Decimal
 }
+
+// BalanceSheetComparison compares the balance sheet date against the compare dates,
+// the first amount is the balance sheet date and every variance is the balance sheet date minus a compare date.
+type BalanceSheetComparison struct {
+	Kind  string   `json:"kind" example:"balanceSheetComparison"`
+	Dates []string `json:"dates" example:"2023-12-31,2023-11-30"`
+
+	Assets     []ComparativeBalanceCategory `json:"assets"`
+	TotalAsset ComparativeBalance           `json:"totalAsset"`
+
+	Liabilities    []ComparativeBalanceCategory `json:"liabilities"`
+	TotalLiability ComparativeBalance           `json:"totalLiability"`
+
+	CatchAll ComparativeBalance `json:"catchAll"`
+}
+
+type ComparativeBalanceCategory struct {
+	CategoryCode  string                          `json:"categoryCode" example:"111"`
+	CategoryName  string                          `json:"categoryName" example:"Cash"`
+	Balance       ComparativeBalance              `json:"balance"`
+	SubCategories []ComparativeBalanceSubCategory `json:"subCategories"`
+}
+
+type ComparativeBalanceSubCategory struct {
+	SubCategoryCode string             `json:"subCategoryCode" example:"11101"`
+	SubCategoryName string             `json:"subCategoryName" example:"Cash in Bank"`
+	Balance         ComparativeBalance `json:"balance"`
+}
+
+type ComparativeBalance struct {
+	Amounts    []string          `json:"amounts" example:"1.000.000,00"`
+	DecAmounts []decimal.Decimal `json:"-"`
+	Variances  []BalanceVariance `json:"variances"`
+}
+
+// BalanceVariance is the variance against a compare date, the percentage is empty when the compare amount is zero.
+type BalanceVariance struct {
+	CompareDate   string           `json:"compareDate" example:"2023-11-30"`
+	Amount        string           `json:"amount" example:"100.000,00"`
+	DecAmount     decimal.Decimal  `json:"-"`
+	Percentage    string           `json:"percentage" example:"11.11"`
+	DecPercentage *decimal.Decimal `json:"-"`
+}
+
+type BalancePerSubCategoryOut struct {
+	ClosingDate       time.Time
+	COAType           string
+	CategoryCode      string
+	CategoryName      string
+	SubCategoryCode   string
+	SubCategoryName   string
+	SumClosingBalance decimal.Decimal
+}
@@ -0,0 +1,282 @@
+package models
+
+import (
+	"mime/multipart"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	KindBankStatement             = "bankStatement"
+	KindBankStatementLine         = "bankStatementLine"
+	KindReconciliationLedgerItem  = "reconciliationLedgerItem"
+	KindReconciliationOutstanding = "reconciliationOutstanding"
+)
+
+// format of an imported bank or virtual account statement file
+const (
+	BankStatementFormatCSV   = "csv"
+	BankStatementFormatMT940 = "mt940"
+)
+
+// status of a bank statement line, a matched line is paired with a split of the sub ledger
+const (
+	BankStatementLineStatusUnmatched = "unmatched"
+	BankStatementLineStatusMatched   = "matched"
+)
+
+// how a bank statement line is matched
+const (
+	BankStatementMatchTypeAuto   = "auto"
+	BankStatementMatchTypeManual = "manual"
+)
+
+// columns of a csv bank statement, the columns are read by the header so the order does not matter
+const (
+	BankStatementColumnDate            = "date"
+	BankStatementColumnReferenceNumber = "reference_number"
+	BankStatementColumnDescription     = "description"
+	BankStatementColumnDebit           = "debit"
+	BankStatementColumnCredit          = "credit"
+)
+
+type ImportBankStatementRequest struct {
+	AccountNumber string                `form:"accountNumber" json:"accountNumber" validate:"required" example:"142001000000001"`
+	Format        string                `form:"format" json:"format" validate:"required,oneof=csv mt940" example:"mt940"`
+	ImportedBy    string                `form:"importedBy" json:"importedBy" validate:"required" example:"tono@amartha.com"`
+	File          *multipart.FileHeader `form:"-" json:"-"`
+}
+
+type MatchBankStatementLineRequest struct {
+	ID            int    `param:"id" json:"-" validate:"required"`
+	TransactionId string `json:"transactionId" validate:"required" example:"6de11650-dbee-4f67-9ade-ececc7a02571"`
+	JournalId     string `json:"journalId" validate:"required" example:"0e8d8c3a-4d3f-4b4f-9a59-1e6f2a2b6e11"`
+	MatchedBy     string `json:"matchedBy" validate:"required" example:"tono@amartha.com"`
+}
+
+type UnmatchBankStatementLineRequest struct {
+	ID int `param:"id" json:"-" validate:"required"`
+}
+
+type GetReconciliationOutstandingRequest struct {
+	AccountNumber string `query:"accountNumber" json:"accountNumber" validate:"required" example:"142001000000001"`
+	StartDate     string `query:"startDate" json:"startDate" validate:"required" example:"2024-04-01"`
+	EndDate       string `query:"endDate" json:"endDate" validate:"required" example:"2024-04-30"`
+}
+
+// BankStatementLineFilterOptions returns the lines of the account whose statement date is within the dates when they are set.
+type BankStatementLineFilterOptions struct {
+	StatementID   int
+	AccountNumber string
+	Status        string
+	StartDate     time.Time
+	EndDate       time.Time
+}
+
+func (req GetReconciliationOutstandingRequest) ToFilterOpts() (*BankStatementLineFilterOptions, error) {
+	start, end, err := toFilterDateOpts(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BankStatementLineFilterOptions{
+		AccountNumber: req.AccountNumber,
+		Status:        BankStatementLineStatusUnmatched,
+		StartDate:     start,
+		EndDate:       end,
+	}, nil
+}
+
+type BankStatementResponse struct {
+	Kind           string                      `json:"kind" example:"bankStatement"`
+	ID             int                         `json:"id" example:"1"`
+	AccountNumber  string                      `json:"accountNumber" example:"142001000000001"`
+	Format         string                      `json:"format" example:"mt940"`
+	Filename       string                      `json:"filename" example:"statement_april.sta"`
+	ImportedBy     string                      `json:"importedBy" example:"tono@amartha.com"`
+	TotalLines     int                         `json:"totalLines" example:"3"`
+	MatchedLines   int                         `json:"matchedLines" example:"2"`
+	UnmatchedLines int                         `json:"unmatchedLines" example:"1"`
+	Lines          []BankStatementLineResponse `json:"lines"`
+	CreatedAt      time.Time                   `json:"createdAt"`
+}
+
+type BankStatementLineResponse struct {
+	Kind            string          `json:"kind" example:"bankStatementLine"`
+	ID              int             `json:"id" example:"1"`
+	StatementID     int             `json:"statementId" example:"1"`
+	AccountNumber   string          `json:"accountNumber" example:"142001000000001"`
+	LineDate        string          `json:"lineDate" example:"2024-04-23"`
+	Amount          decimal.Decimal `json:"amount" example:"100000"`
+	IsCredit        bool            `json:"isCredit" example:"true"`
+	ReferenceNumber string          `json:"referenceNumber" example:"DSB_1465621"`
+	Description     string          `json:"description" example:"VA repayment"`
+	Status          string          `json:"status" example:"matched"`
+	TransactionId   string          `json:"transactionId,omitempty" example:"6de11650-dbee-4f67-9ade-ececc7a02571"`
+	JournalId       string          `json:"journalId,omitempty" example:"0e8d8c3a-4d3f-4b4f-9a59-1e6f2a2b6e11"`
+	MatchType       string          `json:"matchType,omitempty" example:"auto"`
+	MatchedBy       string          `json:"matchedBy,omitempty" example:"tono@amartha.com"`
+	MatchedAt       *time.Time      `json:"matchedAt,omitempty"`
+}
+
+type ReconciliationLedgerItemResponse struct {
+	Kind            string          `json:"kind" example:"reconciliationLedgerItem"`
+	TransactionId   string          `json:"transactionId" example:"6de11650-dbee-4f67-9ade-ececc7a02571"`
+	JournalId       string          `json:"journalId" example:"0e8d8c3a-4d3f-4b4f-9a59-1e6f2a2b6e11"`
+	ReferenceNumber string          `json:"referenceNumber" example:"DSB_1465621"`
+	TransactionDate string          `json:"transactionDate" example:"2024-04-23 09:20:00"`
+	OrderType       string          `json:"orderType" example:"DSB"`
+	TransactionType string          `json:"transactionType" example:"DSBAC"`
+	Narrative       string          `json:"narrative" example:"Disbursement"`
+	Debit           decimal.Decimal `json:"debit" example:"100000"`
+	Credit          decimal.Decimal `json:"credit" example:"0"`
+}
+
+type ReconciliationOutstandingResponse struct {
+	Kind                 string                             `json:"kind" example:"reconciliationOutstanding"`
+	AccountNumber        string                             `json:"accountNumber" example:"142001000000001"`
+	StartDate            string                             `json:"startDate" example:"2024-04-01"`
+	EndDate              string                             `json:"endDate" example:"2024-04-30"`
+	TotalStatementCredit decimal.Decimal                    `json:"totalStatementCredit" example:"100000"`
+	TotalStatementDebit  decimal.Decimal                    `json:"totalStatementDebit" example:"0"`
+	TotalLedgerDebit     decimal.Decimal                    `json:"totalLedgerDebit" example:"50000"`
+	TotalLedgerCredit    decimal.Decimal                    `json:"totalLedgerCredit" example:"0"`
+	StatementLines       []BankStatementLineResponse        `json:"statementLines"`
+	LedgerItems          []ReconciliationLedgerItemResponse `json:"ledgerItems"`
+}
+
+// BankStatement is an imported statement file of a bank or virtual account, the lines are reconciled against
+// the sub ledger of the cash in transit account.
+type BankStatement struct {
+	ID            int
+	AccountNumber string
+	Format        string
+	Filename      string
+	ImportedBy    string
+	Checksum      string // sha256 of the statement file, the same file can be imported once per account
+	Lines         []BankStatementLine
+	CreatedAt     time.Time
+}
+
+// BankStatementLine is a mutation of the statement. A credit of the statement is money in, so it is matched
+// with a debit split of the cash in transit account & a debit of the statement is matched with a credit split.
+type BankStatementLine struct {
+	ID              int
+	StatementID     int
+	AccountNumber   string
+	LineDate        time.Time
+	Amount          decimal.Decimal
+	IsCredit        bool
+	ReferenceNumber string
+	Description     string
+	Status          string
+	TransactionId   string
+	JournalId       string
+	MatchType       string
+	MatchedBy       string
+	MatchedAt       *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ReconciliationOutstanding is the unmatched statement lines & the unmatched splits of an account in a period.
+type ReconciliationOutstanding struct {
+	AccountNumber  string
+	StartDate      time.Time
+	EndDate        time.Time
+	StatementLines []BankStatementLine
+	LedgerItems    []GetSubLedgerOut
+}
+
+// IsLedgerDebit returns the side of the split that matches the line.
+func (l *BankStatementLine) IsLedgerDebit() bool {
+	return l.IsCredit
+}
+
+func (l *BankStatementLine) ToResponse() BankStatementLineResponse {
+	return BankStatementLineResponse{
+		Kind:            KindBankStatementLine,
+		ID:              l.ID,
+		StatementID:     l.StatementID,
+		AccountNumber:   l.AccountNumber,
+		LineDate:        l.LineDate.Format(atime.DateFormatYYYYMMDD),
+		Amount:          l.Amount,
+		IsCredit:        l.IsCredit,
+		ReferenceNumber: l.ReferenceNumber,
+		Description:     l.Description,
+		Status:          l.Status,
+		TransactionId:   l.TransactionId,
+		JournalId:       l.JournalId,
+		MatchType:       l.MatchType,
+		MatchedBy:       l.MatchedBy,
+		MatchedAt:       l.MatchedAt,
+	}
+}
+
+func (bs *BankStatement) ToResponse() BankStatementResponse {
+	res := BankStatementResponse{
+		Kind:          KindBankStatement,
+		ID:            bs.ID,
+		AccountNumber: bs.AccountNumber,
+		Format:        bs.Format,
+		Filename:      bs.Filename,
+		ImportedBy:    bs.ImportedBy,
+		TotalLines:    len(bs.Lines),
+		Lines:         []BankStatementLineResponse{},
+		CreatedAt:     bs.CreatedAt,
+	}
+	for _, v := range bs.Lines {
+		if v.Status == BankStatementLineStatusMatched {
+			res.MatchedLines++
+		} else {
+			res.UnmatchedLines++
+		}
+		res.Lines = append(res.Lines, v.ToResponse())
+	}
+
+	return res
+}
+
+func (ro *ReconciliationOutstanding) ToResponse() ReconciliationOutstandingResponse {
+	res := ReconciliationOutstandingResponse{
+		Kind:                 KindReconciliationOutstanding,
+		AccountNumber:        ro.AccountNumber,
+		StartDate:            ro.StartDate.In(atime.GetLocation()).Format(atime.DateFormatYYYYMMDD),
+		EndDate:              ro.EndDate.In(atime.GetLocation()).Format(atime.DateFormatYYYYMMDD),
+		TotalStatementCredit: decimal.Zero,
+		TotalStatementDebit:  decimal.Zero,
+		TotalLedgerDebit:     decimal.Zero,
+		TotalLedgerCredit:    decimal.Zero,
+		StatementLines:       []BankStatementLineResponse{},
+		LedgerItems:          []ReconciliationLedgerItemResponse{},
+	}
+	for _, v := range ro.StatementLines {
+		if v.IsCredit {
+			res.TotalStatementCredit = res.TotalStatementCredit.Add(v.Amount)
+		} else {
+			res.TotalStatementDebit = res.TotalStatementDebit.Add(v.Amount)
+		}
+		res.StatementLines = append(res.StatementLines, v.ToResponse())
+	}
+	for _, v := range ro.LedgerItems {
+		res.TotalLedgerDebit = res.TotalLedgerDebit.Add(v.Debit)
+		res.TotalLedgerCredit = res.TotalLedgerCredit.Add(v.Credit)
+		res.LedgerItems = append(res.LedgerItems, ReconciliationLedgerItemResponse{
+			Kind:            KindReconciliationLedgerItem,
+			TransactionId:   v.TransactionID,
+			JournalId:       v.JournalID,
+			ReferenceNumber: v.ReferenceNumber,
+			TransactionDate: v.TransactionDate.In(atime.GetLocation()).Format(atime.DateFormatYYYYMMDDWithTime),
+			OrderType:       v.OrderType,
+			TransactionType: v.TransactionType,
+			Narrative:       v.Narrative,
+			Debit:           v.Debit,
+			Credit:          v.Credit,
+		})
+	}
+
+	return res
+}
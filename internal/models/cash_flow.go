@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	KindCashFlow = "cashFlow"
+)
+
+// activities of a cash flow statement
+const (
+	CashFlowActivityOperating = "operating"
+	CashFlowActivityInvesting = "investing"
+	CashFlowActivityFinancing = "financing"
+)
+
+// CashFlowActivities is the order of the activities in the cash flow statement.
+var CashFlowActivities = []string{CashFlowActivityOperating, CashFlowActivityInvesting, CashFlowActivityFinancing}
+
+type GetCashFlowRequest struct {
+	EntityCode string `json:"entityCode" query:"entityCode" example:"001"`
+	StartDate  string `json:"startDate" query:"startDate" example:"2023-01-01"`
+	EndDate    string `json:"endDate" query:"endDate" example:"2023-01-31"`
+	Period     string `json:"period" query:"period" example:"2023-01"`
+	Currency   string `json:"currency" query:"currency" validate:"omitempty,alpha,min=3,max=3" example:"IDR"`
+}
+
+type CashFlowFilterOptions struct {
+	EntityCode string
+	StartDate  time.Time
+	EndDate    time.Time
+	Currency   string
+}
+
+func (req GetCashFlowRequest) ToFilterOpts() (opts *CashFlowFilterOptions, err error) {
+	opts = &CashFlowFilterOptions{
+		EntityCode: req.EntityCode,
+		Currency:   reportCurrency(req.Currency),
+	}
+
+	if req.EntityCode == "" {
+		opts.EntityCode = EntityCodeAMF
+	}
+
+	opts.StartDate, opts.EndDate, err = parsePeriodOrDateRange(req.Period, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+type GetCashFlowResponse struct {
+	Kind       string `json:"kind" example:"cashFlow"`
+	EntityCode string `json:"entityCode" example:"001"`
+	EntityName string `json:"entityName" example:"AMF"`
+	EntityDesc string `json:"entityDesc" example:"PT. Amartha Mikro Fintek"`
+	StartDate  string `json:"startDate" example:"2023-01-01"`
+	EndDate    string `json:"endDate" example:"2023-01-31"`
+
+	OpeningBalance    string          `json:"openingBalance" example:"1.000.000,00"`
+	DecOpeningBalance decimal.Decimal `json:"-"`
+
+	Activities []CashFlowActivity `json:"activities"`
+
+	NetCashFlow    string          `json:"netCashFlow" example:"1.000.000,00"`
+	DecNetCashFlow decimal.Decimal `json:"-"`
+
+	ClosingBalance    string          `json:"closingBalance" example:"2.000.000,00"`
+	DecClosingBalance decimal.Decimal `json:"-"`
+}
+
+type CashFlowActivity struct {
+	Activity  string          `json:"activity" example:"operating"`
+	Items     []CashFlowItem  `json:"items"`
+	Amount    string          `json:"amount" example:"1.000.000,00"`
+	DecAmount decimal.Decimal `json:"-"`
+}
+
+// CashFlowItem is the net cash movement of a transaction type, cash in is positive and cash out is negative.
+type CashFlowItem struct {
+	OrderType           string          `json:"orderType" example:"TUP"`
+	TransactionType     string          `json:"transactionType" example:"TUPVA"`
+	TransactionTypeName string          `json:"transactionTypeName" example:"Topup VA"`
+	Amount              string          `json:"amount" example:"1.000.000,00"`
+	DecAmount           decimal.Decimal `json:"-"`
+}
+
+// CashFlowMovementOut is the movement of the cash accounts per transaction type, the movements are in the minor unit.
+type CashFlowMovementOut struct {
+	OrderType           string
+	TransactionType     string
+	TransactionTypeName string
+	DebitMovement       decimal.Decimal
+	CreditMovement      decimal.Decimal
+}
@@ -6,8 +6,15 @@ const (
 	KindCOAType      = "coaType"
 	COATypeAsset     = "AST"
 	COATypeLiability = "LIA"
+	COATypeIncome    = "INC"
+	COATypeExpense   = "EXP"
 )
 
+// IsCreditNormalBalance returns true when the balance of the coa type increases on credit.
+func IsCreditNormalBalance(coaTypeCode string) bool {
+	return coaTypeCode == COATypeLiability || coaTypeCode == COATypeIncome
+}
+
 type CreateCOATypeRequest struct {
 	Code          string `json:"code" validate:"required,alpha,min=3,max=3" example:"ASS"`
 	Name          string `json:"name" validate:"required,min=1,max=50" example:"Asset"`
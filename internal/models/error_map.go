@@ -15,6 +15,8 @@ const (
 	ErrKeyDoCreateCategoryRequestNameMax             = "DoCreateCategoryRequest.name_max"
 	ErrKeyDoUpdateCategoryRequestNameMax             = "DoUpdateCategoryRequest.name_max"
 	ErrKeyDoUpdateAccountRequestNameMax              = "DoUpdateAccountRequest.name_max"
+	ErrKeyBulkUpdateAccountNameMax                   = "BulkUpdateAccount.name_max"
+	ErrKeyBulkUpdateAccountAltIdMax                  = "BulkUpdateAccount.altId_max"
 	ErrKeyCreateProductTypeRequestCodeMax            = "CreateProductTypeRequest.code_max"
 	ErrKeyCreateProductTypeRequestCodeMin            = "CreateProductTypeRequest.code_min"
 	ErrKeyCreateProductTypeRequestNameMax            = "CreateProductTypeRequest.name_max"
@@ -33,14 +35,47 @@ const (
 	ErrKeyFailedUnmarshal                            = "failedUnmarshal"
 	ErrKeyFailedMarshal                              = "failedMarshal"
 	ErrKeyInvalidIgateMethod                         = "invalidIgateMethod"
+	ErrKeyInvalidFxRateSource                        = "invalidFxRateSource"
+	ErrKeyInvalidFxRateFile                          = "invalidFxRateFile"
+	ErrKeyInvalidFxRevaluationDate                   = "invalidFxRevaluationDate"
 	ErrKeyAccountNumberDifferentEntity               = "accountNumberDifferentEntity"
 	ErrKeyTransactionIdsNotSync                      = "transactionIdsNotSync"
+	ErrKeyJournalNotBalanced                         = "journalNotBalanced"
+	ErrKeyJournalReversalNotBalanced                 = "journalReversalNotBalanced"
+	ErrKeyPeriodClosed                               = "periodClosed"
+	ErrKeyClosedPeriodApproverRequired               = "closedPeriodApproverRequired"
+	ErrKeyOpenPeriodNotFound                         = "openPeriodNotFound"
+	ErrKeyAccountCurrencyMismatch                    = "accountCurrencyMismatch"
+	ErrKeyFiscalYearNotEnded                         = "fiscalYearNotEnded"
+	ErrKeyFiscalYearAlreadyClosed                    = "fiscalYearAlreadyClosed"
+	ErrKeyInvalidRecurringJournalSchedule            = "invalidRecurringJournalSchedule"
+	ErrKeyInvalidJournalDraftStatus                  = "invalidJournalDraftStatus"
+	ErrKeyJournalDraftMakerOnly                      = "journalDraftMakerOnly"
+	ErrKeyJournalDraftSelfReview                     = "journalDraftSelfReview"
+	ErrKeyInvalidBankStatement                       = "invalidBankStatement"
+	ErrKeyBankStatementLineMismatch                  = "bankStatementLineMismatch"
+	ErrKeyBankStatementLineNotMatched                = "bankStatementLineNotMatched"
+	ErrKeyInvalidSuspenseClearingRule                = "invalidSuspenseClearingRule"
+	ErrKeyAccountBalanceBatchExceeded                = "accountBalanceBatchExceeded"
+	ErrKeyAccountBulkUpdateExceeded                  = "accountBulkUpdateExceeded"
+	ErrKeyAccountBulkUpdateDuplicated                = "accountBulkUpdateDuplicated"
+	ErrKeyInvalidAccountBulkUpdateFile               = "invalidAccountBulkUpdateFile"
+	ErrKeyInvalidAccountStatus                       = "invalidAccountStatus"
+	ErrKeyAccountStatusTransitionNotAllowed          = "accountStatusTransitionNotAllowed"
+	ErrKeyAccountBalanceNotZero                      = "accountBalanceNotZero"
+	ErrKeyAccountStatusPostingNotAllowed             = "accountStatusPostingNotAllowed"
 	ErrKeyAccountNumberNotFound                      = "accountNumberNotFound"
 	ErrKeyLegacyIdNotFound                           = "legacyIdNotFound"
 	ErrKeyAccountTypeNotValid                        = "accountTypeNotValid"
 	ErrKeyCategoryCodeNotFound                       = "categoryCodeNotFound"
 	ErrKeyCoaTypeNotFound                            = "coaTypeNotFound"
 	ErrKeyCurrencyNotFound                           = "currencyNotFound"
+	ErrKeyFxRateNotFound                             = "fxRateNotFound"
+	ErrKeyFxRevaluationAccountNotFound               = "fxRevaluationAccountNotFound"
+	ErrKeyRetainedEarningsAccountNotFound            = "retainedEarningsAccountNotFound"
+	ErrKeyCashSubCategoryNotFound                    = "cashSubCategoryNotFound"
+	ErrKeyInvalidCashFlowActivity                    = "invalidCashFlowActivity"
+	ErrKeyIntercompanyAccountNotFound                = "intercompanyAccountNotFound"
 	ErrKeyDataNotFound                               = "dataNotFound"
 	ErrKeyEntityCodeNotFound                         = "entityCodeNotFound"
 	ErrKeyProductTypeNotFound                        = "productTypeNotFound"
@@ -49,6 +84,13 @@ const (
 	ErrKeyTransactionIdNotFound                      = "transactionIdNotFound"
 	ErrKeyLoanPartnerAccountNotFound                 = "loanPartnerAccountNotFound"
 	ErrKeyEntityNotFound                             = "entityNotFound"
+	ErrKeyJournalIdNotFound                          = "journalIdNotFound"
+	ErrKeyRecurringJournalNotFound                   = "recurringJournalNotFound"
+	ErrKeyJournalDraftNotFound                       = "journalDraftNotFound"
+	ErrKeyUploadJobNotFound                          = "uploadJobNotFound"
+	ErrKeyBankStatementLineNotFound                  = "bankStatementLineNotFound"
+	ErrKeyAccountRelationshipRuleNotFound            = "accountRelationshipRuleNotFound"
+	ErrKeyAccountParentNotFound                      = "accountParentNotFound"
 	ErrKeyProductTypeCodeIsExist                     = "productTypeCodeIsExist"
 	ErrKeyAccountTypeIsExist                         = "accountTypeIsExist"
 	ErrKeyAltIdIsExist                               = "altIdIsExist"
@@ -63,7 +105,15 @@ const (
 	ErrKeyLegacyIdalreadyExists                      = "legacyIDAlreadyExists"
 	ErrKeyJournalAccountIsExist                      = "journalAccountIsExist"
 	ErrKeyAccountNumberIsExist                       = "accountNumberIsExist"
+	ErrKeyJournalAlreadyReversed                     = "journalAlreadyReversed"
+	ErrKeyJournalDraftIsExist                        = "journalDraftIsExist"
+	ErrKeyBankStatementLineIsMatched                 = "bankStatementLineIsMatched"
+	ErrKeyBankStatementIsImported                    = "bankStatementIsImported"
+	ErrKeyJournalIsReconciled                        = "journalIsReconciled"
+	ErrKeyAccountRelationshipRuleIsExist             = "accountRelationshipRuleIsExist"
+	ErrKeyAccountParentIsExist                       = "accountParentIsExist"
 	ErrKeyAccountNumberRequired                      = "accountNumber_required"
+	ErrKeyAccountsRequired                           = "accounts_required"
 	ErrKeyAccountTypeRequired                        = "accountType_required"
 	ErrKeyAltIdRequired                              = "altId_required"
 	ErrKeyCategoryCodeRequired                       = "categoryCode_required"
@@ -102,6 +152,14 @@ const (
 	ErrKeyLoanKindRequired                           = "loanKind_required"
 	ErrKeyLoanSubCategoryCodeRequired                = "loanSubCategoryCode_required"
 	ErrKeyAdjustmentDateRequired                     = "adjustmentDate_required"
+	ErrKeyApprovedByRequired                         = "approvedBy_required"
+	ErrKeySubmittedByRequired                        = "submittedBy_required"
+	ErrKeyRejectedByRequired                         = "rejectedBy_required"
+	ErrKeySourceSubCategoryCodeRequired              = "sourceSubCategoryCode_required"
+	ErrKeyTargetSubCategoryCodeRequired              = "targetSubCategoryCode_required"
+	ErrKeyRelationRequired                           = "relation_required"
+	ErrKeyCardinalityRequired                        = "cardinality_required"
+	ErrKeyParentAccountNumberRequired                = "parentAccountNumber_required"
 	ErrKeyOwnerIdRequiredWithoutAll                  = "ownerId_required_without_all"
 	ErrKeyAltIdRequiredWithoutAll                    = "altId_required_without_all"
 	ErrKeyAccountNumbersRequiredWithoutAll           = "accountNumbers_required_without_all"
@@ -128,10 +186,13 @@ const (
 	ErrKeyNameMax                                    = "name_max"
 	ErrKeyOwnerIdMax                                 = "ownerId_max"
 	ErrKeyOwnerIdMin                                 = "ownerId_min"
+	ErrKeyAccountsMin                                = "accounts_min"
 	ErrKeyProductTypeCodeMax                         = "productTypeCode_max"
 	ErrKeyProductTypeCodeMin                         = "productTypeCode_min"
 	ErrKeySubCategoryCodeMax                         = "subCategoryCode_max"
 	ErrKeySubCategoryCodeMin                         = "subCategoryCode_min"
+	ErrKeyReasonMax                                  = "reason_max"
+	ErrKeyNameTemplateMax                            = "nameTemplate_max"
 	ErrKeyAltIdAlphanumDashUscore                    = "altId_alphanumDashUscore"
 	ErrKeyCategoryCodeNumeric                        = "categoryCode_numeric"
 	ErrKeyCodeAlpha                                  = "code_alpha"
@@ -155,13 +216,21 @@ const (
 	ErrKeyStartDateIsAfterEndDate                    = "startDateIsAfterEndDate"
 	ErrKeyStatusOneof                                = "status_oneof"
 	ErrKeySubCategoryCodeNumeric                     = "subCategoryCode_numeric"
+	ErrKeySourceAccountNumberNumeric                 = "sourceAccountNumber_numeric"
+	ErrKeyTargetAccountNumberNumeric                 = "targetAccountNumber_numeric"
 	ErrKeyTransactionsEmpty                          = "transactionsEmpty"
 	ErrKeySortOneof                                  = "sort_oneof"
 	ErrKeySortByOneof                                = "sortBy_oneof"
 	ErrKeySearchByOneof                              = "searchBy_oneof"
 	ErrKeyJobNameOneof                               = "jobName_oneof"
 	ErrKeyAccountTypeOneof                           = "accountType_oneof"
+	ErrKeyFieldOneof                                 = "field_oneof"
+	ErrKeyRelationOneof                              = "relation_oneof"
+	ErrKeyCardinalityOneof                           = "cardinality_oneof"
+	ErrKeyInvalidAccountParent                       = "invalidAccountParent"
+	ErrKeyAccountRelationshipRuleAccountInvalid      = "accountRelationshipRuleAccountInvalid"
 	ErrKeyBalanceSheetDateIsTodayOrLater             = "balanceSheetDateIsTodayOrLater"
+	ErrKeyBalanceSheetCompareDateIsDuplicated        = "balanceSheetCompareDateIsDuplicated"
 	ErrKeyTransactionDateDatetime                    = "transactionDate_datetime"
 	ErrKeyProcessingDateDatetime                     = "processingDate_datetime"
 	ErrKeyClosedPeriodNotFound                       = "closedPeriodNotFound"
@@ -206,14 +275,47 @@ var (
 	errFailedJsonUnmarshal                                                                                                                                               = errors.New("failed json unmarshal")
 	errFailedJsonMarshal                                                                                                                                                 = errors.New("failed json marshal")
 	errInvalidIgateMethod                                                                                                                                                = errors.New("invalid igate method")
+	errInvalidFxRateSource                                                                                                                                               = errors.New("invalid fx rate source")
+	errInvalidFxRateFile                                                                                                                                                 = errors.New("invalid fx rate file")
+	errFxRevaluationDateMustBeTheEndOfAMonth                                                                                                                             = errors.New("fx revaluation date must be the end of a month")
 	errAccountNumberCannotBeADifferentEntity                                                                                                                             = errors.New("account number cannot be a different entity")
 	errTransactionIdsIsNotInSync                                                                                                                                         = errors.New("transaction ids is not in sync")
+	errTotalDebitAndTotalCreditAreNotBalanced                                                                                                                            = errors.New("total debit and total credit are not balanced")
+	errTotalDebitAndTotalCreditOfTheReversedJournalLinesAreNotBalanced                                                                                                   = errors.New("total debit and total credit of the reversed journal lines are not balanced")
+	errTransactionDateIsInAClosedPeriod                                                                                                                                  = errors.New("transaction date is in a closed period")
+	errApproverIsRequiredToPostIntoAClosedPeriod                                                                                                                         = errors.New("approver is required to post into a closed period")
+	errNoOpenPeriodToPostTheTransactionInto                                                                                                                              = errors.New("no open period to post the transaction into")
+	errAccountCurrencyIsDifferentFromTransactionCurrency                                                                                                                 = errors.New("account currency is different from transaction currency")
+	errFiscalYearHasNotEndedYet                                                                                                                                          = errors.New("fiscal year has not ended yet")
+	errFiscalYearAlreadyClosed                                                                                                                                           = errors.New("fiscal year already closed")
+	errRecurringJournalScheduleIsInvalid                                                                                                                                 = errors.New("recurring journal schedule is invalid")
+	errJournalDraftStatusDoesNotAllowTheAction                                                                                                                           = errors.New("journal draft status does not allow the action")
+	errOnlyTheMakerCanSubmitTheJournalDraft                                                                                                                              = errors.New("only the maker can submit the journal draft")
+	errMakerCannotApproveOrRejectOwnJournalDraft                                                                                                                         = errors.New("maker cannot approve or reject own journal draft")
+	errBankStatementFileIsInvalid                                                                                                                                        = errors.New("bank statement file is invalid")
+	errJournalDoesNotMatchTheBankStatementLine                                                                                                                           = errors.New("journal does not match the bank statement line")
+	errBankStatementLineIsNotMatched                                                                                                                                     = errors.New("bank statement line is not matched")
+	errSuspenseClearingRuleIsInvalid                                                                                                                                     = errors.New("suspense clearing rule is invalid")
+	errAccountNumbersExceedTheBalanceBatchLimit                                                                                                                          = errors.New("account numbers exceed the balance batch limit")
+	errAccountsExceedTheBulkUpdateLimit                                                                                                                                  = errors.New("accounts exceed the bulk update limit")
+	errAccountNumberIsDuplicatedInTheBulkUpdate                                                                                                                          = errors.New("account number is duplicated in the bulk update")
+	errBulkUpdateAccountFileIsInvalid                                                                                                                                    = errors.New("bulk update account file is invalid")
+	errStatusMustBeOneOfActiveFrozenCreditOnlyFrozenDebitOnlyDormantClosed                                                                                               = errors.New("status must be one of active frozen_credit_only frozen_debit_only dormant closed")
+	errAccountStatusTransitionIsNotAllowed                                                                                                                               = errors.New("account status transition is not allowed")
+	errAccountBalanceMustBeZeroToCloseTheAccount                                                                                                                         = errors.New("account balance must be zero to close the account")
+	errAccountStatusDoesNotAllowThePosting                                                                                                                               = errors.New("account status does not allow the posting")
 	errAccountNumberNotFound                                                                                                                                             = errors.New("account number not found")
 	errLegacyIdNotFound                                                                                                                                                  = errors.New("legacy id not found")
 	errAccountTypeNotValid                                                                                                                                               = errors.New("account type not valid")
 	errCategoryCodeNotFound                                                                                                                                              = errors.New("category code not found")
 	errCoaTypeCodeNotFound                                                                                                                                               = errors.New("coa type code not found")
 	errCurrencyNotFound                                                                                                                                                  = errors.New("currency not found")
+	errFxRateNotFound                                                                                                                                                    = errors.New("fx rate not found")
+	errFxRevaluationAccountIsNotConfigured                                                                                                                               = errors.New("fx revaluation account is not configured")
+	errRetainedEarningsAccountIsNotConfigured                                                                                                                            = errors.New("retained earnings account is not configured")
+	errCashSubCategoryIsNotConfigured                                                                                                                                    = errors.New("cash sub category is not configured")
+	errCashFlowActivityIsInvalid                                                                                                                                         = errors.New("cash flow activity is invalid")
+	errIntercompanyAccountIsNotConfigured                                                                                                                                = errors.New("intercompany account is not configured")
 	errDataNotFound                                                                                                                                                      = errors.New("data not found")
 	errEntityCodeNotFound                                                                                                                                                = errors.New("entity code not found")
 	errProductTypeCodeNotFound                                                                                                                                           = errors.New("product type code not found")
@@ -222,6 +324,13 @@ var (
 	errTransactionIdNotFound                                                                                                                                             = errors.New("transaction id not found")
 	errLoanPartnerAccountNotFound                                                                                                                                        = errors.New("loan partner account not found")
 	errEntityNotFound                                                                                                                                                    = errors.New("entity not found")
+	errJournalIdNotFound                                                                                                                                                 = errors.New("journal id not found")
+	errRecurringJournalNotFound                                                                                                                                          = errors.New("recurring journal not found")
+	errJournalDraftNotFound                                                                                                                                              = errors.New("journal draft not found")
+	errUploadJobNotFound                                                                                                                                                 = errors.New("upload job not found")
+	errBankStatementLineNotFound                                                                                                                                         = errors.New("bank statement line not found")
+	errAccountRelationshipRuleNotFound                                                                                                                                   = errors.New("account relationship rule not found")
+	errParentAccountOfTheAccountNotFound                                                                                                                                 = errors.New("parent account of the account not found")
 	errProductTypeCodeIsExist                                                                                                                                            = errors.New("product type code is exist")
 	errAccountTypeIsExist                                                                                                                                                = errors.New("account type is exist")
 	errAlternateIdIsExist                                                                                                                                                = errors.New("alternate id is exist")
@@ -235,6 +344,13 @@ var (
 	errTheResourceCouldNotBeUpdatedBecauseTheLegacyIdAlreadyExists                                                                                                       = errors.New("the resource could not be updated because the legacy id already exists")
 	errUnableToChangeTheEntityBecauseTheAccountHasATransactions                                                                                                          = errors.New("unable to change the entity because the account has a transactions")
 	errAccountNumberIsExist                                                                                                                                              = errors.New("account number is exist")
+	errJournalAlreadyReversed                                                                                                                                            = errors.New("journal already reversed")
+	errPendingJournalDraftWithTheTransactionIdIsExist                                                                                                                    = errors.New("pending journal draft with the transaction id is exist")
+	errBankStatementLineIsAlreadyMatched                                                                                                                                 = errors.New("bank statement line is already matched")
+	errBankStatementIsAlreadyImported                                                                                                                                    = errors.New("bank statement is already imported")
+	errJournalIsAlreadyMatchedToABankStatementLine                                                                                                                       = errors.New("journal is already matched to a bank statement line")
+	errAccountRelationshipRuleOfTheSourceSubCategoryAndRelationIsExist                                                                                                   = errors.New("account relationship rule of the source sub category and relation is exist")
+	errAccountAlreadyHasAParentAccount                                                                                                                                   = errors.New("account already has a parent account")
 	errStartDateOrEndDateMustBeFilledInIfEitherIsFilledIn                                                                                                                = errors.New("start date or end date must be filled in if either is filled in")
 	errRequiredFieldsAtLeastOwnerId                                                                                                                                      = errors.New("required fields at least ownerId")
 	errRequiredFieldsAtLeastAltId                                                                                                                                        = errors.New("required fields at least altId")
@@ -251,6 +367,8 @@ var (
 	errFieldCanHaveAMaximumLengthOf3Characters                                                                                                                           = errors.New("field can have a maximum length of 3 characters")
 	errFieldCanHaveAMaximumLengthOf50Characters                                                                                                                          = errors.New("field can have a maximum length of 50 characters")
 	errFieldMustBeAtLeast1Characters                                                                                                                                     = errors.New("field must be at least 1 characters")
+	errFieldMustHaveAtLeast1Item                                                                                                                                         = errors.New("field must have at least 1 item")
+	errFieldCanHaveAMaximumLengthOf255Characters                                                                                                                         = errors.New("field can have a maximum length of 255 characters")
 	errOnlyAcceptAlphanumericWithDashAndUnderscore                                                                                                                       = errors.New("only accept alphanumeric with dash (-) and underscore (_)")
 	errFieldCanOnlyContainAlphaValues                                                                                                                                    = errors.New("field can only contain alpha values")
 	errDateRangeMax31Days                                                                                                                                                = errors.New("date range max 31 days")
@@ -274,7 +392,13 @@ var (
 	errOneOfAccountNumberOrAltIdOrOwnerId                                                                                                                                = errors.New("one of accountNumber or altId or ownerId")
 	errInvalidJobName                                                                                                                                                    = errors.New("invalid job name")
 	errOneOfCashInTransitDisburseCashInTransitRepaymentInternalAccountsRevenueAmarthaInternalAccountsAdminFeeAmarthaInternalAccountsPphAmarthaInternalAccountsPpnAmartha = errors.New("one of CASH_IN_TRANSIT_DISBURSE CASH_IN_TRANSIT_REPAYMENT INTERNAL_ACCOUNTS_REVENUE_AMARTHA INTERNAL_ACCOUNTS_ADMIN_FEE_AMARTHA INTERNAL_ACCOUNTS_PPH_AMARTHA INTERNAL_ACCOUNTS_PPN_AMARTHA")
+	errOneOfNameOwnerIdAltIdLegacyIdEntityCodeStatusMetadata                                                                                                             = errors.New("one of name ownerId altId legacyId entityCode status metadata")
+	errOneOfInvestedReceivablesLoanAdvancePayment                                                                                                                        = errors.New("one of invested receivables loanAdvancePayment")
+	errOneOfOneToOneManyToOne                                                                                                                                            = errors.New("one of oneToOne manyToOne")
+	errParentAccountMustNotBeTheAccountOrADescendantOfTheAccount                                                                                                         = errors.New("parent account must not be the account or a descendant of the account")
+	errAccountIsNotInTheSubCategoryOfTheRelationshipRule                                                                                                                 = errors.New("account is not in the sub category of the relationship rule")
 	errBalanceSheetDateCannotBeTodayOrLaterThanToday                                                                                                                     = errors.New("balance sheet date cannot be today or later than today")
+	errCompareDateCannotBeTheBalanceSheetDateOrRepeated                                                                                                                  = errors.New("compare date cannot be the balance sheet date or repeated")
 	errFormatMustBe20060102150405                                                                                                                                        = errors.New("format must be 2006-01-02 15:04:05")
 	errClosedPeriodNotFound                                                                                                                                              = errors.New("closed period not found")
 	errPeriodAlreadyClosed                                                                                                                                               = errors.New("period already closed")
@@ -321,6 +445,14 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeInvalidLength,
 		ErrorMessage: errFieldCanHaveAMaximumLengthOf100Characters,
 	},
+	ErrKeyBulkUpdateAccountNameMax: ErrorDetail{
+		Code:         ErrCodeInvalidLength,
+		ErrorMessage: errFieldCanHaveAMaximumLengthOf100Characters,
+	},
+	ErrKeyBulkUpdateAccountAltIdMax: ErrorDetail{
+		Code:         ErrCodeInvalidLength,
+		ErrorMessage: errFieldCanHaveAMaximumLengthOf100Characters,
+	},
 	ErrKeyCreateProductTypeRequestCodeMax: ErrorDetail{
 		Code:         ErrCodeInvalidLength,
 		ErrorMessage: errFieldCanHaveAMaximumLengthOf5Characters,
@@ -393,6 +525,18 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeInvalidValues,
 		ErrorMessage: errInvalidIgateMethod,
 	},
+	ErrKeyInvalidFxRateSource: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errInvalidFxRateSource,
+	},
+	ErrKeyInvalidFxRateFile: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errInvalidFxRateFile,
+	},
+	ErrKeyInvalidFxRevaluationDate: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errFxRevaluationDateMustBeTheEndOfAMonth,
+	},
 	ErrKeyAccountNumberDifferentEntity: ErrorDetail{
 		Code:         ErrCodeInvalidValues,
 		ErrorMessage: errAccountNumberCannotBeADifferentEntity,
@@ -401,6 +545,102 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeInvalidValues,
 		ErrorMessage: errTransactionIdsIsNotInSync,
 	},
+	ErrKeyJournalNotBalanced: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errTotalDebitAndTotalCreditAreNotBalanced,
+	},
+	ErrKeyJournalReversalNotBalanced: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errTotalDebitAndTotalCreditOfTheReversedJournalLinesAreNotBalanced,
+	},
+	ErrKeyPeriodClosed: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errTransactionDateIsInAClosedPeriod,
+	},
+	ErrKeyClosedPeriodApproverRequired: ErrorDetail{
+		Code:         ErrCodeMissingField,
+		ErrorMessage: errApproverIsRequiredToPostIntoAClosedPeriod,
+	},
+	ErrKeyOpenPeriodNotFound: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errNoOpenPeriodToPostTheTransactionInto,
+	},
+	ErrKeyAccountCurrencyMismatch: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errAccountCurrencyIsDifferentFromTransactionCurrency,
+	},
+	ErrKeyFiscalYearNotEnded: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errFiscalYearHasNotEndedYet,
+	},
+	ErrKeyFiscalYearAlreadyClosed: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errFiscalYearAlreadyClosed,
+	},
+	ErrKeyInvalidRecurringJournalSchedule: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errRecurringJournalScheduleIsInvalid,
+	},
+	ErrKeyInvalidJournalDraftStatus: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errJournalDraftStatusDoesNotAllowTheAction,
+	},
+	ErrKeyJournalDraftMakerOnly: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errOnlyTheMakerCanSubmitTheJournalDraft,
+	},
+	ErrKeyJournalDraftSelfReview: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errMakerCannotApproveOrRejectOwnJournalDraft,
+	},
+	ErrKeyInvalidBankStatement: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errBankStatementFileIsInvalid,
+	},
+	ErrKeyBankStatementLineMismatch: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errJournalDoesNotMatchTheBankStatementLine,
+	},
+	ErrKeyBankStatementLineNotMatched: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errBankStatementLineIsNotMatched,
+	},
+	ErrKeyInvalidSuspenseClearingRule: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errSuspenseClearingRuleIsInvalid,
+	},
+	ErrKeyAccountBalanceBatchExceeded: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errAccountNumbersExceedTheBalanceBatchLimit,
+	},
+	ErrKeyAccountBulkUpdateExceeded: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errAccountsExceedTheBulkUpdateLimit,
+	},
+	ErrKeyAccountBulkUpdateDuplicated: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errAccountNumberIsDuplicatedInTheBulkUpdate,
+	},
+	ErrKeyInvalidAccountBulkUpdateFile: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errBulkUpdateAccountFileIsInvalid,
+	},
+	ErrKeyInvalidAccountStatus: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errStatusMustBeOneOfActiveFrozenCreditOnlyFrozenDebitOnlyDormantClosed,
+	},
+	ErrKeyAccountStatusTransitionNotAllowed: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errAccountStatusTransitionIsNotAllowed,
+	},
+	ErrKeyAccountBalanceNotZero: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errAccountBalanceMustBeZeroToCloseTheAccount,
+	},
+	ErrKeyAccountStatusPostingNotAllowed: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errAccountStatusDoesNotAllowThePosting,
+	},
 	ErrKeyAccountNumberNotFound: ErrorDetail{
 		Code:         ErrCodeDataNotFound,
 		ErrorMessage: errAccountNumberNotFound,
@@ -425,6 +665,30 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeDataNotFound,
 		ErrorMessage: errCurrencyNotFound,
 	},
+	ErrKeyFxRateNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errFxRateNotFound,
+	},
+	ErrKeyFxRevaluationAccountNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errFxRevaluationAccountIsNotConfigured,
+	},
+	ErrKeyRetainedEarningsAccountNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errRetainedEarningsAccountIsNotConfigured,
+	},
+	ErrKeyCashSubCategoryNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errCashSubCategoryIsNotConfigured,
+	},
+	ErrKeyInvalidCashFlowActivity: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errCashFlowActivityIsInvalid,
+	},
+	ErrKeyIntercompanyAccountNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errIntercompanyAccountIsNotConfigured,
+	},
 	ErrKeyDataNotFound: ErrorDetail{
 		Code:         ErrCodeDataNotFound,
 		ErrorMessage: errDataNotFound,
@@ -457,6 +721,34 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeDataNotFound,
 		ErrorMessage: errEntityNotFound,
 	},
+	ErrKeyJournalIdNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errJournalIdNotFound,
+	},
+	ErrKeyRecurringJournalNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errRecurringJournalNotFound,
+	},
+	ErrKeyJournalDraftNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errJournalDraftNotFound,
+	},
+	ErrKeyUploadJobNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errUploadJobNotFound,
+	},
+	ErrKeyBankStatementLineNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errBankStatementLineNotFound,
+	},
+	ErrKeyAccountRelationshipRuleNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errAccountRelationshipRuleNotFound,
+	},
+	ErrKeyAccountParentNotFound: ErrorDetail{
+		Code:         ErrCodeDataNotFound,
+		ErrorMessage: errParentAccountOfTheAccountNotFound,
+	},
 	ErrKeyProductTypeCodeIsExist: ErrorDetail{
 		Code:         ErrCodeDataIsExist,
 		ErrorMessage: errProductTypeCodeIsExist,
@@ -513,10 +805,42 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeDataIsExist,
 		ErrorMessage: errAccountNumberIsExist,
 	},
+	ErrKeyJournalAlreadyReversed: ErrorDetail{
+		Code:         ErrCodeDataIsExist,
+		ErrorMessage: errJournalAlreadyReversed,
+	},
+	ErrKeyJournalDraftIsExist: ErrorDetail{
+		Code:         ErrCodeDataIsExist,
+		ErrorMessage: errPendingJournalDraftWithTheTransactionIdIsExist,
+	},
+	ErrKeyBankStatementLineIsMatched: ErrorDetail{
+		Code:         ErrCodeDataIsExist,
+		ErrorMessage: errBankStatementLineIsAlreadyMatched,
+	},
+	ErrKeyBankStatementIsImported: ErrorDetail{
+		Code:         ErrCodeDataIsExist,
+		ErrorMessage: errBankStatementIsAlreadyImported,
+	},
+	ErrKeyJournalIsReconciled: ErrorDetail{
+		Code:         ErrCodeDataIsExist,
+		ErrorMessage: errJournalIsAlreadyMatchedToABankStatementLine,
+	},
+	ErrKeyAccountRelationshipRuleIsExist: ErrorDetail{
+		Code:         ErrCodeDataIsExist,
+		ErrorMessage: errAccountRelationshipRuleOfTheSourceSubCategoryAndRelationIsExist,
+	},
+	ErrKeyAccountParentIsExist: ErrorDetail{
+		Code:         ErrCodeDataIsExist,
+		ErrorMessage: errAccountAlreadyHasAParentAccount,
+	},
 	ErrKeyAccountNumberRequired: ErrorDetail{
 		Code:         ErrCodeMissingField,
 		ErrorMessage: errFieldIsMissing,
 	},
+	ErrKeyAccountsRequired: ErrorDetail{
+		Code:         ErrCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
 	ErrKeyAccountTypeRequired: ErrorDetail{
 		Code:         ErrCodeMissingField,
 		ErrorMessage: errFieldIsMissing,
@@ -669,6 +993,38 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeMissingField,
 		ErrorMessage: errFieldIsMissing,
 	},
+	ErrKeyApprovedByRequired: ErrorDetail{
+		Code:         ErrCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeySubmittedByRequired: ErrorDetail{
+		Code:         ErrCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeyRejectedByRequired: ErrorDetail{
+		Code:         ErrCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeySourceSubCategoryCodeRequired: ErrorDetail{
+		Code:         ErrCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeyTargetSubCategoryCodeRequired: ErrorDetail{
+		Code:         ErrCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeyRelationRequired: ErrorDetail{
+		Code:         ErrCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeyCardinalityRequired: ErrorDetail{
+		Code:         ErrCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeyParentAccountNumberRequired: ErrorDetail{
+		Code:         ErrCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
 	ErrKeyOwnerIdRequiredWithoutAll: ErrorDetail{
 		Code:         ErrCodeMissingField,
 		ErrorMessage: errRequiredFieldsAtLeastOwnerId,
@@ -773,6 +1129,10 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeInvalidLength,
 		ErrorMessage: errFieldMustBeAtLeast1Characters,
 	},
+	ErrKeyAccountsMin: ErrorDetail{
+		Code:         ErrCodeInvalidLength,
+		ErrorMessage: errFieldMustHaveAtLeast1Item,
+	},
 	ErrKeyProductTypeCodeMax: ErrorDetail{
 		Code:         ErrCodeInvalidLength,
 		ErrorMessage: errFieldCanHaveAMaximumLengthOf5Characters,
@@ -789,6 +1149,14 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeInvalidLength,
 		ErrorMessage: errFieldMustBeAtLeast5Characters,
 	},
+	ErrKeyReasonMax: ErrorDetail{
+		Code:         ErrCodeInvalidLength,
+		ErrorMessage: errFieldCanHaveAMaximumLengthOf255Characters,
+	},
+	ErrKeyNameTemplateMax: ErrorDetail{
+		Code:         ErrCodeInvalidLength,
+		ErrorMessage: errFieldCanHaveAMaximumLengthOf100Characters,
+	},
 	ErrKeyAltIdAlphanumDashUscore: ErrorDetail{
 		Code:         ErrCodeInvalidValues,
 		ErrorMessage: errOnlyAcceptAlphanumericWithDashAndUnderscore,
@@ -881,6 +1249,14 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeInvalidValues,
 		ErrorMessage: errFieldCanOnlyContainNumericValues,
 	},
+	ErrKeySourceAccountNumberNumeric: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errFieldCanOnlyContainNumericValues,
+	},
+	ErrKeyTargetAccountNumberNumeric: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errFieldCanOnlyContainNumericValues,
+	},
 	ErrKeyTransactionsEmpty: ErrorDetail{
 		Code:         ErrCodeInvalidValues,
 		ErrorMessage: errTransactionDataIsEmpty,
@@ -905,10 +1281,34 @@ var MapErrors = MapErrs{
 		Code:         ErrCodeInvalidValues,
 		ErrorMessage: errOneOfCashInTransitDisburseCashInTransitRepaymentInternalAccountsRevenueAmarthaInternalAccountsAdminFeeAmarthaInternalAccountsPphAmarthaInternalAccountsPpnAmartha,
 	},
+	ErrKeyFieldOneof: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errOneOfNameOwnerIdAltIdLegacyIdEntityCodeStatusMetadata,
+	},
+	ErrKeyRelationOneof: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errOneOfInvestedReceivablesLoanAdvancePayment,
+	},
+	ErrKeyCardinalityOneof: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errOneOfOneToOneManyToOne,
+	},
+	ErrKeyInvalidAccountParent: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errParentAccountMustNotBeTheAccountOrADescendantOfTheAccount,
+	},
+	ErrKeyAccountRelationshipRuleAccountInvalid: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errAccountIsNotInTheSubCategoryOfTheRelationshipRule,
+	},
 	ErrKeyBalanceSheetDateIsTodayOrLater: ErrorDetail{
 		Code:         ErrCodeInvalidValues,
 		ErrorMessage: errBalanceSheetDateCannotBeTodayOrLaterThanToday,
 	},
+	ErrKeyBalanceSheetCompareDateIsDuplicated: ErrorDetail{
+		Code:         ErrCodeInvalidValues,
+		ErrorMessage: errCompareDateCannotBeTheBalanceSheetDateOrRepeated,
+	},
 	ErrKeyTransactionDateDatetime: ErrorDetail{
 		Code:         ErrCodeInvalidValues,
 		ErrorMessage: errFormatMustBe20060102150405,
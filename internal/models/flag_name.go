@@ -13,6 +13,7 @@ const (
 	FlagGetTrialBalanceGCS
 	FlagTrialBalanceAutoAdjustment
 	FlagGetOpeningBalanceFromPreviousMonth
+	FlagJournalDirectPosting
 )
 
 func (f FlagEnum) String() string {
@@ -37,6 +38,8 @@ func (f FlagEnum) String() string {
 		return "PAS-BE-flag-trial-balance-auto-adjustment"
 	case FlagGetOpeningBalanceFromPreviousMonth:
 		return "PAS-BE-get-opening-balance-from-previous-month"
+	case FlagJournalDirectPosting:
+		return "PAS-BE-flag-journal-direct-posting"
 	default:
 		return "UNKNOWN"
 	}
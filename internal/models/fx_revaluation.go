@@ -0,0 +1,24 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// source of the fx rate file used by the revaluation job
+const (
+	FXRateSourceGCS   = "gcs"
+	FXRateSourceLocal = "local"
+)
+
+// metadata keys of a fx revaluation journal
+const (
+	MetadataKeyFXCurrency     = "fxCurrency"
+	MetadataKeyFXRate         = "fxRate"
+	MetadataKeyFXPreviousRate = "fxPreviousRate"
+	MetadataKeyFXPosition     = "fxPosition"
+)
+
+// FXRevaluationPosition is the net foreign currency position of an entity, debit normal balance is positive and credit normal balance is negative.
+type FXRevaluationPosition struct {
+	EntityCode string
+	Currency   string
+	Position   decimal.Decimal
+}
@@ -36,4 +36,6 @@ const (
 	TrialBalanceDetailDir DirectoryName = "trial_balance_detail"
 	SubLedgerDir          DirectoryName = "sub_ledger"
 	TrialBalanceDir       DirectoryName = "trial_balances"
+	UploadJobDir          DirectoryName = "upload_jobs"
+	LedgerIntegrityDir    DirectoryName = "ledger_integrity"
 )
@@ -8,6 +8,25 @@ import (
 
 const KindJournal = "journal"
 
+// metadata keys of a reversal journal
+const (
+	MetadataKeyReversalOf     = "reversalOf"
+	MetadataKeyReversalReason = "reversalReason"
+)
+
+// metadata keys of a journal posted into a closed period with the override, so the override can be queried from the journal
+const (
+	MetadataKeyClosedPeriodOverride           = "closedPeriodOverride"
+	MetadataKeyClosedPeriodOverrideApprovedBy = "closedPeriodOverrideApprovedBy"
+	MetadataKeyClosedPeriodOverrideReason     = "closedPeriodOverrideReason"
+)
+
+// policy applied when the transaction date falls into a closed trial balance period
+const (
+	ClosedPeriodPolicyReject   = "reject"
+	ClosedPeriodPolicyRedirect = "redirect"
+)
+
 type (
 	JournalRequest struct {
 		ReferenceNumber string        `json:"referenceNumber" validate:"required" example:"123456"`
@@ -18,6 +37,13 @@ type (
 		Currency        string        `json:"currency" validate:"required" example:"IDR"`
 		Transactions    []Transaction `json:"transactions" validate:"required,dive,required"`
 		Metadata        *Metadata     `json:"metadata" swaggertype:"object,string" example:"t24AccountNumber:1234567890,t24ArrangementId:1234567890"`
+
+		AllowClosedPeriod *ClosedPeriodOverride `json:"allowClosedPeriod,omitempty"`
+	}
+	// ClosedPeriodOverride allows a journal to be posted into a closed period, it must be approved by someone.
+	ClosedPeriodOverride struct {
+		ApprovedBy string `json:"approvedBy" validate:"required" example:"tono@amartha.com"`
+		Reason     string `json:"reason" example:"late disbursement correction"`
 	}
 	Transaction struct {
 		TransactionType     string          `json:"transactionType" validate:"required" example:"DSBAB"`
@@ -26,6 +52,15 @@ type (
 		Narrative           string          `json:"narrative" validate:"omitempty,required" example:"Credit"`
 		Amount              decimal.Decimal `json:"amount" validate:"required" example:"5000001"`
 		IsDebit             bool            `json:"isDebit" validate:"omitempty,required" example:"false"`
+
+		// ReversedJournalId is only filled by the reversal, it links the line to the reversed journal line.
+		ReversedJournalId string `json:"-"`
+	}
+
+	ReverseJournalRequest struct {
+		TransactionId string   `param:"transactionId" json:"-" validate:"required"`
+		JournalIds    []string `json:"journalIds" validate:"omitempty,dive,required" example:"2024060601093678"`
+		Reason        string   `json:"reason" validate:"omitempty,max=255" example:"wrong account"`
 	}
 
 	JournalError struct {
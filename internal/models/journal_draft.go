@@ -0,0 +1,150 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	KindJournalDraft = "journalDraft"
+)
+
+// status of a journal draft, a draft is only posted when it is approved by a checker
+const (
+	JournalDraftStatusDraft     = "draft"
+	JournalDraftStatusSubmitted = "submitted"
+	JournalDraftStatusApproved  = "approved"
+	JournalDraftStatusRejected  = "rejected"
+)
+
+// metadata keys of a journal posted from a draft
+const (
+	MetadataKeyJournalDraftId = "journalDraftId"
+	MetadataKeyMaker          = "maker"
+	MetadataKeyChecker        = "checker"
+)
+
+type CreateJournalDraftRequest struct {
+	JournalRequest
+	CreatedBy string `json:"-" validate:"required"` // the authenticated user
+}
+
+func (req CreateJournalDraftRequest) ToJournalDraft() JournalDraft {
+	return JournalDraft{
+		TransactionId: req.TransactionId,
+		Journal:       JournalDraftPayload(req.JournalRequest),
+		Status:        JournalDraftStatusDraft,
+		CreatedBy:     req.CreatedBy,
+	}
+}
+
+type SubmitJournalDraftRequest struct {
+	ID          int    `param:"id" json:"-" validate:"required"`
+	SubmittedBy string `json:"-" validate:"required"` // the authenticated user
+}
+
+type ApproveJournalDraftRequest struct {
+	ID         int    `param:"id" json:"-" validate:"required"`
+	ApprovedBy string `json:"-" validate:"required"` // the authenticated user
+}
+
+type RejectJournalDraftRequest struct {
+	ID         int    `param:"id" json:"-" validate:"required"`
+	RejectedBy string `json:"-" validate:"required"` // the authenticated user
+	Reason     string `json:"reason" validate:"required,max=255" example:"wrong account"`
+}
+
+type GetJournalDraftRequest struct {
+	Status        string `query:"status" json:"status" validate:"omitempty,oneof=draft submitted approved rejected" example:"submitted"`
+	CreatedBy     string `query:"createdBy" json:"createdBy" example:"tono@amartha.com"`
+	TransactionId string `query:"transactionId" json:"transactionId" example:"6de11650-dbee-4f67-9ade-ececc7a02571"`
+}
+
+type JournalDraftFilterOptions struct {
+	Status        string
+	CreatedBy     string
+	TransactionId string
+}
+
+func (req GetJournalDraftRequest) ToFilterOpts() JournalDraftFilterOptions {
+	return JournalDraftFilterOptions{
+		Status:        req.Status,
+		CreatedBy:     req.CreatedBy,
+		TransactionId: req.TransactionId,
+	}
+}
+
+type JournalDraftResponse struct {
+	Kind            string         `json:"kind" example:"journalDraft"`
+	ID              int            `json:"id" example:"1"`
+	TransactionId   string         `json:"transactionId" example:"6de11650-dbee-4f67-9ade-ececc7a02571"`
+	Journal         JournalRequest `json:"journal"`
+	Status          string         `json:"status" example:"submitted"`
+	CreatedBy       string         `json:"createdBy" example:"tono@amartha.com"`
+	SubmittedAt     *time.Time     `json:"submittedAt,omitempty"`
+	ReviewedBy      string         `json:"reviewedBy,omitempty" example:"budi@amartha.com"`
+	ReviewedAt      *time.Time     `json:"reviewedAt,omitempty"`
+	RejectionReason string         `json:"rejectionReason,omitempty" example:"wrong account"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+}
+
+// JournalDraft is a manual journal waiting for the four-eyes review, the journal is stored as json
+// and only posted to transactions & splits when the draft is approved.
+// CreatedBy is the maker, ReviewedBy is the checker who approved or rejected the draft.
+type JournalDraft struct {
+	ID              int
+	TransactionId   string
+	Journal         JournalDraftPayload
+	Status          string
+	CreatedBy       string
+	SubmittedAt     *time.Time
+	ReviewedBy      string
+	ReviewedAt      *time.Time
+	RejectionReason string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type JournalDraftPayload JournalRequest
+
+func (p *JournalDraftPayload) Value() (driver.Value, error) {
+	jsonValue, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return jsonValue, nil
+}
+
+func (p *JournalDraftPayload) Scan(value interface{}) error {
+	jsonValue, ok := value.([]byte)
+	if !ok {
+		return errors.New("invalid JSON data")
+	}
+
+	return json.Unmarshal(jsonValue, p)
+}
+
+func (jd *JournalDraft) ToResponse() JournalDraftResponse {
+	return JournalDraftResponse{
+		Kind:            KindJournalDraft,
+		ID:              jd.ID,
+		TransactionId:   jd.TransactionId,
+		Journal:         JournalRequest(jd.Journal),
+		Status:          jd.Status,
+		CreatedBy:       jd.CreatedBy,
+		SubmittedAt:     jd.SubmittedAt,
+		ReviewedBy:      jd.ReviewedBy,
+		ReviewedAt:      jd.ReviewedAt,
+		RejectionReason: jd.RejectionReason,
+		CreatedAt:       jd.CreatedAt,
+		UpdatedAt:       jd.UpdatedAt,
+	}
+}
+
+// IsPending returns true when the draft is not approved nor rejected yet.
+func (jd *JournalDraft) IsPending() bool {
+	return jd.Status == JournalDraftStatusDraft || jd.Status == JournalDraftStatusSubmitted
+}
@@ -0,0 +1,128 @@
+package models
+
+import "strings"
+
+const (
+	KindUploadJournalReport = "uploadJournalReport"
+)
+
+// format of the upload journal validation report
+const (
+	UploadJournalReportFormatJSON = "json"
+	UploadJournalReportFormatCSV  = "csv"
+)
+
+// status of an upload journal row in the validation report
+const (
+	UploadJournalRowStatusValid   = "valid"
+	UploadJournalRowStatusInvalid = "invalid"
+)
+
+// extension of the upload journal file
+const (
+	UploadJournalExtCSV  = ".csv"
+	UploadJournalExtXLSX = ".xlsx"
+)
+
+// column of the upload journal template, the columns are matched by the header name so the order of the columns is free.
+// The journal columns are only required on the first row of a transaction id, the next rows with the same transaction id
+// only add the lines to the journal.
+const (
+	UploadJournalColumnReferenceNumber = "reference_number"
+	UploadJournalColumnTransactionId   = "transaction_id"
+	UploadJournalColumnOrderType       = "order_type"
+	UploadJournalColumnTransactionDate = "transaction_date"
+	UploadJournalColumnProcessingDate  = "processing_date"
+	UploadJournalColumnCurrency        = "currency"
+	UploadJournalColumnMetadata        = "metadata"
+
+	// a single line per row, either the debit or the credit amount is filled
+	UploadJournalColumnTransactionType     = "transaction_type"
+	UploadJournalColumnTransactionTypeName = "transaction_type_name"
+	UploadJournalColumnAccount             = "account"
+	UploadJournalColumnNarrative           = "narrative"
+	UploadJournalColumnDebit               = "debit"
+	UploadJournalColumnCredit              = "credit"
+
+	// a numbered pair of debit & credit lines per row, e.g. account_debit1 & account_credit1 with amount1,
+	// the transaction type, transaction type name & narrative columns are numbered as well.
+	UploadJournalColumnAccountDebit  = "account_debit"
+	UploadJournalColumnAccountCredit = "account_credit"
+	UploadJournalColumnAmount        = "amount"
+)
+
+// UploadJournalRequest only validates the file without publishing any journal when validateOnly is true.
+type UploadJournalRequest struct {
+	ValidateOnly string `query:"validateOnly" json:"validateOnly" validate:"omitempty,boolean" example:"true"`
+	Format       string `query:"format" json:"format" validate:"omitempty,oneof=json csv" example:"csv"`
+}
+
+func (req UploadJournalRequest) IsValidateOnly() bool {
+	return req.ValidateOnly == "true" || req.ValidateOnly == "1"
+}
+
+type UploadJournalReport struct {
+	Kind        string                   `json:"kind" example:"uploadJournalReport"`
+	Filename    string                   `json:"filename" example:"journals.csv"`
+	TotalRows   int                      `json:"totalRows" example:"2"`
+	ValidRows   int                      `json:"validRows" example:"1"`
+	InvalidRows int                      `json:"invalidRows" example:"1"`
+	Rows        []UploadJournalRowReport `json:"rows"`
+
+	// Header is the header of the uploaded file, it is written back to the csv report.
+	Header []string `json:"-"`
+}
+
+// UploadJournalRowReport is the validation result of a row, the row number counts the header as the first row.
+type UploadJournalRowReport struct {
+	Row           int      `json:"row" example:"2"`
+	TransactionId string   `json:"transactionId" example:"6de11650-dbee-4f67-9ade-ececc7a02571"`
+	Status        string   `json:"status" example:"invalid"`
+	Errors        []string `json:"errors" example:"account number not found caused by 142001000000001"`
+
+	Record []string `json:"-"`
+}
+
+func (r *UploadJournalRowReport) AddError(err error) {
+	if errMap, ok := IsErrMap(err); ok {
+		r.Errors = append(r.Errors, errMap.Message)
+		return
+	}
+	r.Errors = append(r.Errors, err.Error())
+}
+
+func (report *UploadJournalReport) AddRow(row UploadJournalRowReport) {
+	row.Status = UploadJournalRowStatusValid
+	if len(row.Errors) > 0 {
+		row.Status = UploadJournalRowStatusInvalid
+		report.InvalidRows++
+	} else {
+		row.Errors = []string{}
+		report.ValidRows++
+	}
+	report.TotalRows++
+	report.Rows = append(report.Rows, row)
+}
+
+// ToCSV returns the uploaded rows annotated with the validation status & errors, so the file can be fixed & uploaded again.
+// The rows are padded to the widest row, so the annotation is always in the last two columns.
+func (report *UploadJournalReport) ToCSV() (header []string, body [][]string) {
+	width := len(report.Header)
+	for _, v := range report.Rows {
+		if len(v.Record) > width {
+			width = len(v.Record)
+		}
+	}
+	pad := func(record []string) []string {
+		padded := make([]string, width, width+2)
+		copy(padded, record)
+		return padded
+	}
+
+	header = append(pad(report.Header), "validation_status", "validation_errors")
+	for _, v := range report.Rows {
+		body = append(body, append(pad(v.Record), v.Status, strings.Join(v.Errors, "; ")))
+	}
+
+	return
+}
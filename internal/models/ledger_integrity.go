@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+)
+
+// check of a ledger integrity discrepancy
+const (
+	LedgerIntegrityCheckDailyBalance = "dailyBalance"
+	LedgerIntegrityCheckTrialBalance = "trialBalance"
+	LedgerIntegrityCheckTransaction  = "transaction"
+)
+
+// LedgerIntegrityReportHeader is the header of the discrepancy report, one row per discrepancy.
+var LedgerIntegrityReportHeader = []string{
+	"check",
+	"date",
+	"entity_code",
+	"sub_category_code",
+	"account_number",
+	"transaction_id",
+	"currency",
+	"expected",
+	"actual",
+	"difference",
+}
+
+// LedgerIntegrityRequest checks the ledger of the entity for every date from the start date until the end date.
+type LedgerIntegrityRequest struct {
+	EntityCode string
+	StartDate  time.Time
+	EndDate    time.Time
+}
+
+// LedgerAccountMovement is the debit & credit movement of an account summed from the splits.
+type LedgerAccountMovement struct {
+	AccountNumber   string
+	EntityCode      string
+	CategoryCode    string
+	SubCategoryCode string
+	Currency        string
+	DebitMovement   decimal.Decimal
+	CreditMovement  decimal.Decimal
+}
+
+// UnbalancedTransaction is a transaction whose debit & credit splits of the currency do not net to zero.
+type UnbalancedTransaction struct {
+	TransactionId   string
+	TransactionDate time.Time
+	Currency        string
+	Debit           decimal.Decimal
+	Credit          decimal.Decimal
+}
+
+// LedgerIntegrityDiscrepancy is a stored balance which is different from the balance recomputed from the splits,
+// the expected is recomputed & the actual is stored. The expected of an unbalanced transaction is the debit & the actual is the credit.
+type LedgerIntegrityDiscrepancy struct {
+	Check           string
+	Date            time.Time
+	EntityCode      string
+	SubCategoryCode string
+	AccountNumber   string
+	TransactionId   string
+	Currency        string
+	Expected        decimal.Decimal
+	Actual          decimal.Decimal
+}
+
+func (d *LedgerIntegrityDiscrepancy) Difference() decimal.Decimal {
+	return d.Actual.Sub(d.Expected)
+}
+
+func (d *LedgerIntegrityDiscrepancy) ToRecord() []string {
+	return []string{
+		d.Check,
+		d.Date.Format(atime.DateFormatYYYYMMDD),
+		d.EntityCode,
+		d.SubCategoryCode,
+		d.AccountNumber,
+		d.TransactionId,
+		d.Currency,
+		d.Expected.String(),
+		d.Actual.String(),
+		d.Difference().String(),
+	}
+}
+
+// LedgerIntegrityReport is the result of a ledger integrity check, the discrepancies are written into the file path.
+type LedgerIntegrityReport struct {
+	EntityCode    string
+	StartDate     time.Time
+	EndDate       time.Time
+	Accounts      int
+	Discrepancies []LedgerIntegrityDiscrepancy
+	FilePath      string
+}
+
+// Count returns the discrepancies of the check.
+func (r *LedgerIntegrityReport) Count(check string) (count int) {
+	for _, v := range r.Discrepancies {
+		if v.Check == check {
+			count++
+		}
+	}
+	return
+}
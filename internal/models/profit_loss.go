@@ -0,0 +1,138 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	KindProfitLoss = "profitLoss"
+)
+
+type GetProfitLossRequest struct {
+	EntityCode string `json:"entityCode" query:"entityCode" example:"001"`
+	StartDate  string `json:"startDate" query:"startDate" example:"2023-01-01"`
+	EndDate    string `json:"endDate" query:"endDate" example:"2023-01-31"`
+	Period     string `json:"period" query:"period" example:"2023-01"`
+	Currency   string `json:"currency" query:"currency" validate:"omitempty,alpha,min=3,max=3" example:"IDR"`
+}
+
+type ProfitLossFilterOptions struct {
+	EntityCode string
+	StartDate  time.Time
+	EndDate    time.Time
+	Currency   string
+
+	// ClosingOrderType is the order type of the year end closing journals, their movements are excluded
+	// so the income & expense of a closed fiscal year are not zeroed by the closing.
+	ClosingOrderType string
+}
+
+func (req GetProfitLossRequest) ToFilterOpts() (opts *ProfitLossFilterOptions, err error) {
+	opts = &ProfitLossFilterOptions{
+		EntityCode: req.EntityCode,
+		Currency:   reportCurrency(req.Currency),
+	}
+
+	if req.EntityCode == "" {
+		opts.EntityCode = EntityCodeAMF
+	}
+
+	opts.StartDate, opts.EndDate, err = parsePeriodOrDateRange(req.Period, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// parsePeriodOrDateRange returns the date range of a report from either a period (YYYY-MM) or a start & end date,
+// the previous month is used when both are empty.
+func parsePeriodOrDateRange(period, startDate, endDate string) (start, end time.Time, err error) {
+	if period != "" && (startDate != "" || endDate != "") {
+		return start, end, fmt.Errorf("cannot use both 'period' and 'startDate/endDate' in the same request")
+	}
+
+	switch {
+	case period != "":
+		periodDate, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMM, period)
+		if err != nil {
+			return start, end, GetErrMap(ErrKeyInvalidFormatDate, fmt.Sprintf("period %s format must be YYYY-MM", period))
+		}
+		start = atime.BeginningOfMonth(periodDate)
+		end = atime.ToZeroTime(atime.EndOfMonth(periodDate))
+	case startDate == "" && endDate == "":
+		// default is previous month
+		start, end = atime.PrevMonth(atime.Now())
+	case startDate == "" || endDate == "":
+		return start, end, GetErrMap(ErrKeyStartDateAndEndDateRequiredIfOneIsFilled)
+	default:
+		start, err = atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, startDate)
+		if err != nil {
+			return start, end, GetErrMap(ErrKeyInvalidFormatDate, fmt.Sprintf("date %s format must be YYYY-MM-DD", startDate))
+		}
+		end, err = atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, endDate)
+		if err != nil {
+			return start, end, GetErrMap(ErrKeyInvalidFormatDate, fmt.Sprintf("date %s format must be YYYY-MM-DD", endDate))
+		}
+		if start.After(end) {
+			return start, end, GetErrMap(ErrKeyStartDateIsAfterEndDate)
+		}
+	}
+
+	// trial balance of today is not generated yet
+	if atime.DateEqualToday(end) || atime.Now().Before(end) {
+		return start, end, GetErrMap(ErrKeyEndDateIsAfterToday)
+	}
+
+	return start, end, nil
+}
+
+type GetProfitLossResponse struct {
+	Kind       string `json:"kind" example:"profitLoss"`
+	EntityCode string `json:"entityCode" example:"001"`
+	EntityName string `json:"entityName" example:"AMF"`
+	EntityDesc string `json:"entityDesc" example:"PT. Amartha Mikro Fintek"`
+	StartDate  string `json:"startDate" example:"2023-01-01"`
+	EndDate    string `json:"endDate" example:"2023-01-31"`
+
+	Revenues        []ProfitLossCategory `json:"revenues"`
+	TotalRevenue    string               `json:"totalRevenue" example:"1000000"`
+	DecTotalRevenue decimal.Decimal      `json:"-"`
+
+	Expenses        []ProfitLossCategory `json:"expenses"`
+	TotalExpense    string               `json:"totalExpense" example:"1000000"`
+	DecTotalExpense decimal.Decimal      `json:"-"`
+
+	NetIncome    string          `json:"netIncome" example:"1000000"`
+	DecNetIncome decimal.Decimal `json:"-"`
+}
+
+type ProfitLossCategory struct {
+	CategoryCode  string                  `json:"categoryCode" example:"411"`
+	CategoryName  string                  `json:"categoryName" example:"Interest Income"`
+	Amount        string                  `json:"amount" example:"1000000"`
+	DecAmount     decimal.Decimal         `json:"-"`
+	SubCategories []ProfitLossSubCategory `json:"subCategories"`
+}
+
+type ProfitLossSubCategory struct {
+	SubCategoryCode string          `json:"subCategoryCode" example:"41101"`
+	SubCategoryName string          `json:"subCategoryName" example:"Interest Income Group Loan"`
+	Amount          string          `json:"amount" example:"1000000"`
+	DecAmount       decimal.Decimal `json:"-"`
+}
+
+type ProfitLossPerSubCategoryOut struct {
+	COAType         string
+	CategoryCode    string
+	CategoryName    string
+	SubCategoryCode string
+	SubCategoryName string
+	DebitMovement   decimal.Decimal
+	CreditMovement  decimal.Decimal
+}
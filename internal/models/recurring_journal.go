@@ -0,0 +1,236 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+)
+
+const (
+	KindRecurringJournal = "recurringJournal"
+)
+
+// schedule of a recurring journal
+const (
+	RecurringJournalFrequencyDaily      = "daily"
+	RecurringJournalFrequencyMonthly    = "monthly"
+	RecurringJournalFrequencyEndOfMonth = "endOfMonth"
+)
+
+// status of a recurring journal, a completed recurring journal is not posted anymore
+const (
+	RecurringJournalStatusActive    = "active"
+	RecurringJournalStatusCompleted = "completed"
+)
+
+// metadata keys of a recurring journal instance
+const (
+	MetadataKeyRecurringJournalId = "recurringJournalId"
+	MetadataKeyRunDate            = "runDate"
+)
+
+type CreateRecurringJournalRequest struct {
+	Name           string        `json:"name" validate:"required,max=100" example:"Prepaid insurance amortisation"`
+	OrderType      string        `json:"orderType" validate:"required" example:"AMR"`
+	Currency       string        `json:"currency" validate:"required" example:"IDR"`
+	Transactions   []Transaction `json:"transactions" validate:"required,min=2,dive,required"`
+	Frequency      string        `json:"frequency" validate:"required,oneof=daily monthly endOfMonth" example:"monthly"`
+	StartDate      string        `json:"startDate" validate:"required,date" example:"2024-01-31"`
+	EndDate        string        `json:"endDate" validate:"omitempty,date" example:"2024-12-31"`
+	RemainingCount *int          `json:"remainingCount" validate:"omitempty,min=1" example:"12"`
+	CreatedBy      string        `json:"createdBy" validate:"required" example:"tono@amartha.com"`
+}
+
+func (req CreateRecurringJournalRequest) ToRecurringJournal() (*RecurringJournal, error) {
+	startDate, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, req.StartDate)
+	if err != nil {
+		return nil, GetErrMap(ErrKeyInvalidFormatDate, "startDate format must be YYYY-MM-DD")
+	}
+
+	var endDate *time.Time
+	if req.EndDate != "" {
+		end, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, req.EndDate)
+		if err != nil {
+			return nil, GetErrMap(ErrKeyInvalidFormatDate, "endDate format must be YYYY-MM-DD")
+		}
+		if end.Before(startDate) {
+			return nil, GetErrMap(ErrKeyInvalidRecurringJournalSchedule, "endDate must be greater than startDate")
+		}
+		endDate = &end
+	}
+
+	rj := &RecurringJournal{
+		Name:           req.Name,
+		OrderType:      req.OrderType,
+		Currency:       req.Currency,
+		Transactions:   req.Transactions,
+		Frequency:      req.Frequency,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		RemainingCount: req.RemainingCount,
+		Status:         RecurringJournalStatusActive,
+		CreatedBy:      req.CreatedBy,
+	}
+	// the first run of the end of month schedule is the end of the start month
+	rj.NextRunDate = rj.runDate(0)
+
+	return rj, nil
+}
+
+type GetRecurringJournalRequest struct {
+	Status string `query:"status" json:"status" validate:"omitempty,oneof=active completed" example:"active"`
+}
+
+// RecurringJournalFilterOptions only returns the recurring journals whose next run is on or before the due date when it is set.
+type RecurringJournalFilterOptions struct {
+	Status  string
+	DueDate time.Time
+}
+
+func (req GetRecurringJournalRequest) ToFilterOpts() RecurringJournalFilterOptions {
+	return RecurringJournalFilterOptions{
+		Status: req.Status,
+	}
+}
+
+type RecurringJournalResponse struct {
+	Kind           string        `json:"kind" example:"recurringJournal"`
+	ID             int           `json:"id" example:"1"`
+	Name           string        `json:"name" example:"Prepaid insurance amortisation"`
+	OrderType      string        `json:"orderType" example:"AMR"`
+	Currency       string        `json:"currency" example:"IDR"`
+	Transactions   []Transaction `json:"transactions"`
+	Frequency      string        `json:"frequency" example:"monthly"`
+	StartDate      string        `json:"startDate" example:"2024-01-31"`
+	EndDate        string        `json:"endDate,omitempty" example:"2024-12-31"`
+	RemainingCount *int          `json:"remainingCount,omitempty" example:"12"`
+	NextRunDate    string        `json:"nextRunDate" example:"2024-02-29"`
+	Status         string        `json:"status" example:"active"`
+	CreatedBy      string        `json:"createdBy" example:"tono@amartha.com"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+}
+
+// RunRecurringJournalResult is the summary of a recurring journal run, a skipped instance was already posted by a previous run.
+type RunRecurringJournalResult struct {
+	Posted  int
+	Skipped int
+}
+
+// RecurringJournal is a journal template posted on schedule, the transactions are stored as json.
+// RemainingCount & EndDate are optional, the recurring journal runs until one of them is reached.
+type RecurringJournal struct {
+	ID             int
+	Name           string
+	OrderType      string
+	Currency       string
+	Transactions   RecurringJournalTransactions
+	Frequency      string
+	StartDate      time.Time
+	EndDate        *time.Time
+	RemainingCount *int
+	RunCount       int
+	NextRunDate    time.Time
+	Status         string
+	CreatedBy      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type RecurringJournalTransactions []Transaction
+
+func (t *RecurringJournalTransactions) Value() (driver.Value, error) {
+	jsonValue, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return jsonValue, nil
+}
+
+func (t *RecurringJournalTransactions) Scan(value interface{}) error {
+	jsonValue, ok := value.([]byte)
+	if !ok {
+		return errors.New("invalid JSON data")
+	}
+
+	return json.Unmarshal(jsonValue, t)
+}
+
+func (rj *RecurringJournal) ToResponse() RecurringJournalResponse {
+	resp := RecurringJournalResponse{
+		Kind:           KindRecurringJournal,
+		ID:             rj.ID,
+		Name:           rj.Name,
+		OrderType:      rj.OrderType,
+		Currency:       rj.Currency,
+		Transactions:   rj.Transactions,
+		Frequency:      rj.Frequency,
+		StartDate:      rj.StartDate.Format(atime.DateFormatYYYYMMDD),
+		RemainingCount: rj.RemainingCount,
+		NextRunDate:    rj.NextRunDate.Format(atime.DateFormatYYYYMMDD),
+		Status:         rj.Status,
+		CreatedBy:      rj.CreatedBy,
+		CreatedAt:      rj.CreatedAt,
+		UpdatedAt:      rj.UpdatedAt,
+	}
+	if rj.EndDate != nil {
+		resp.EndDate = rj.EndDate.Format(atime.DateFormatYYYYMMDD)
+	}
+
+	return resp
+}
+
+// IsDue returns true when the next run of the recurring journal is on or before the date
+// and neither the end date nor the remaining count is reached.
+func (rj *RecurringJournal) IsDue(date time.Time) bool {
+	if rj.Status != RecurringJournalStatusActive || rj.IsExhausted() {
+		return false
+	}
+
+	return !rj.NextRunDate.After(atime.ToZeroTime(date))
+}
+
+// IsExhausted returns true when the recurring journal has no run left.
+func (rj *RecurringJournal) IsExhausted() bool {
+	if rj.RemainingCount != nil && *rj.RemainingCount <= 0 {
+		return true
+	}
+
+	return rj.EndDate != nil && rj.NextRunDate.After(*rj.EndDate)
+}
+
+// Advance moves the recurring journal to its next run, it is completed when no run is left.
+func (rj *RecurringJournal) Advance() {
+	rj.RunCount++
+	rj.NextRunDate = rj.runDate(rj.RunCount)
+	if rj.RemainingCount != nil {
+		remaining := *rj.RemainingCount - 1
+		rj.RemainingCount = &remaining
+	}
+	if rj.IsExhausted() {
+		rj.Status = RecurringJournalStatusCompleted
+	}
+}
+
+// runDate returns the date of the nth run counted from the start date,
+// a monthly run on a day that does not exist in the month is moved to the end of the month.
+func (rj *RecurringJournal) runDate(n int) time.Time {
+	start := atime.ToZeroTime(rj.StartDate)
+	switch rj.Frequency {
+	case RecurringJournalFrequencyDaily:
+		return start.AddDate(0, 0, n)
+	case RecurringJournalFrequencyEndOfMonth:
+		month := time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, start.Location())
+		return atime.ToZeroTime(atime.EndOfMonth(month))
+	default:
+		month := time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, start.Location())
+		endOfMonth := atime.ToZeroTime(atime.EndOfMonth(month))
+		if start.Day() > endOfMonth.Day() {
+			return endOfMonth
+		}
+		return time.Date(month.Year(), month.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	}
+}
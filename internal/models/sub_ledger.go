@@ -134,6 +134,9 @@ type GetSubLedgerOut struct {
 	Credit              decimal.Decimal
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
+
+	// JournalID is the split id of the line
+	JournalID string
 }
 
 func (l GetSubLedgerOut) ToModelResponse() GetSubLedgerResponse {
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	KindSuspenseAgeing     = "suspenseAgeing"
+	KindSuspenseAgeingItem = "suspenseAgeingItem"
+)
+
+// key of a suspense clearing rule, offsetting entries with the same key are paired
+const (
+	SuspenseClearingMatchByReferenceNumber = "referenceNumber"
+	SuspenseClearingMatchByOrderType       = "orderType"
+	SuspenseClearingMatchByMetadata        = "metadata"
+)
+
+// metadata keys of a suspense clearing journal
+const (
+	MetadataKeySuspenseClearingRule    = "suspenseClearingRule"
+	MetadataKeySuspenseDebitJournalId  = "suspenseDebitJournalId"
+	MetadataKeySuspenseCreditJournalId = "suspenseCreditJournalId"
+)
+
+type GetSuspenseAgeingRequest struct {
+	Date       string `query:"date" json:"date" validate:"required" example:"2024-04-30"`
+	MinAgeDays int    `query:"minAgeDays" json:"minAgeDays" validate:"omitempty,min=1" example:"30"`
+}
+
+// SuspenseAgeingFilterOptions returns the open suspense entries whose age at the date is at least the min age days,
+// the ageing threshold of the config is used when it is not set.
+type SuspenseAgeingFilterOptions struct {
+	Date       time.Time
+	MinAgeDays int
+}
+
+func (req GetSuspenseAgeingRequest) ToFilterOpts() (*SuspenseAgeingFilterOptions, error) {
+	date, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, req.Date)
+	if err != nil {
+		return nil, GetErrMap(ErrKeyInvalidFormatDate, "date format must be YYYY-MM-DD")
+	}
+
+	return &SuspenseAgeingFilterOptions{
+		Date:       date,
+		MinAgeDays: req.MinAgeDays,
+	}, nil
+}
+
+type SuspenseAgeingResponse struct {
+	Kind        string                       `json:"kind" example:"suspenseAgeing"`
+	Date        string                       `json:"date" example:"2024-04-30"`
+	MinAgeDays  int                          `json:"minAgeDays" example:"30"`
+	TotalDebit  decimal.Decimal              `json:"totalDebit" example:"100000"`
+	TotalCredit decimal.Decimal              `json:"totalCredit" example:"0"`
+	Items       []SuspenseAgeingItemResponse `json:"items"`
+}
+
+type SuspenseAgeingItemResponse struct {
+	Kind            string          `json:"kind" example:"suspenseAgeingItem"`
+	AccountNumber   string          `json:"accountNumber" example:"219001000000001"`
+	EntityCode      string          `json:"entityCode" example:"001"`
+	SubCategoryCode string          `json:"subCategoryCode" example:"21901"`
+	Currency        string          `json:"currency" example:"IDR"`
+	TransactionId   string          `json:"transactionId" example:"6de11650-dbee-4f67-9ade-ececc7a02571"`
+	JournalId       string          `json:"journalId" example:"0e8d8c3a-4d3f-4b4f-9a59-1e6f2a2b6e11"`
+	ReferenceNumber string          `json:"referenceNumber" example:"DSB_1465621"`
+	OrderType       string          `json:"orderType" example:"DSB"`
+	TransactionType string          `json:"transactionType" example:"DSBRJ"`
+	TransactionDate string          `json:"transactionDate" example:"2024-03-20 09:20:00"`
+	Narrative       string          `json:"narrative" example:"Rejected disbursement"`
+	Debit           decimal.Decimal `json:"debit" example:"100000"`
+	Credit          decimal.Decimal `json:"credit" example:"0"`
+	AgeDays         int             `json:"ageDays" example:"41"`
+}
+
+// SuspenseEntry is a split of a suspense account which is not paired by any clearing yet.
+type SuspenseEntry struct {
+	AccountNumber   string
+	EntityCode      string
+	SubCategoryCode string
+	Currency        string
+	Split           GetSubLedgerOut
+}
+
+// IsDebit returns the side of the entry in the suspense account.
+func (e *SuspenseEntry) IsDebit() bool {
+	return e.Split.Debit.IsPositive()
+}
+
+func (e *SuspenseEntry) Amount() decimal.Decimal {
+	if e.IsDebit() {
+		return e.Split.Debit
+	}
+	return e.Split.Credit
+}
+
+// AgeDays returns the days from the transaction date of the entry to the date.
+func (e *SuspenseEntry) AgeDays(date time.Time) int {
+	transactionDate := atime.ToZeroTime(e.Split.TransactionDate.In(date.Location()))
+	return int(atime.ToZeroTime(date).Sub(transactionDate).Hours() / 24)
+}
+
+// SuspenseClearing pairs a debit & a credit entry of the suspense accounts. The entries of different accounts
+// are cleared by the journal of the transaction id, the entries of the same account are already net so no journal is posted.
+type SuspenseClearing struct {
+	ID                  int
+	RuleName            string
+	DebitJournalId      string
+	CreditJournalId     string
+	DebitAccountNumber  string
+	CreditAccountNumber string
+	Amount              decimal.Decimal
+	Currency            string
+	TransactionId       string
+	CreatedAt           time.Time
+}
+
+// ClearSuspenseAccountsResult is the summary of a suspense clearing run, a posted pair is cleared by a journal
+// & an aged entry is left open past the ageing threshold.
+type ClearSuspenseAccountsResult struct {
+	Cleared int
+	Posted  int
+	Aged    int
+}
+
+// SuspenseAgeing is the open suspense entries at the date whose age is at least the min age days.
+type SuspenseAgeing struct {
+	Date       time.Time
+	MinAgeDays int
+	Entries    []SuspenseEntry
+}
+
+func (sa *SuspenseAgeing) ToResponse() SuspenseAgeingResponse {
+	res := SuspenseAgeingResponse{
+		Kind:        KindSuspenseAgeing,
+		Date:        sa.Date.Format(atime.DateFormatYYYYMMDD),
+		MinAgeDays:  sa.MinAgeDays,
+		TotalDebit:  decimal.Zero,
+		TotalCredit: decimal.Zero,
+		Items:       []SuspenseAgeingItemResponse{},
+	}
+	for _, v := range sa.Entries {
+		res.TotalDebit = res.TotalDebit.Add(v.Split.Debit)
+		res.TotalCredit = res.TotalCredit.Add(v.Split.Credit)
+		res.Items = append(res.Items, SuspenseAgeingItemResponse{
+			Kind:            KindSuspenseAgeingItem,
+			AccountNumber:   v.AccountNumber,
+			EntityCode:      v.EntityCode,
+			SubCategoryCode: v.SubCategoryCode,
+			Currency:        v.Currency,
+			TransactionId:   v.Split.TransactionID,
+			JournalId:       v.Split.JournalID,
+			ReferenceNumber: v.Split.ReferenceNumber,
+			OrderType:       v.Split.OrderType,
+			TransactionType: v.Split.TransactionType,
+			TransactionDate: v.Split.TransactionDate.In(atime.GetLocation()).Format(atime.DateFormatYYYYMMDDWithTime),
+			Narrative:       v.Split.Narrative,
+			Debit:           v.Split.Debit,
+			Credit:          v.Split.Credit,
+			AgeDays:         v.AgeDays(sa.Date),
+		})
+	}
+
+	return res
+}
@@ -23,6 +23,7 @@ type DoGetTrialBalanceRequest struct {
 	EndDate    string `json:"endDate" query:"endDate" example:"2023-01-07"`
 	Email      string `json:"email" query:"email" validate:"omitempty,email" example:"tono@amartha.com"`
 	Period     string `json:"period" query:"period" validate:"omitempty" example:"2023-01-07"`
+	Currency   string `json:"currency" query:"currency" validate:"omitempty,alpha,min=3,max=3" example:"IDR"`
 }
 
 type TrialBalanceFilterOptions struct {
@@ -32,6 +33,7 @@ type TrialBalanceFilterOptions struct {
 	EndDate         time.Time
 	Email           string
 	Period          time.Time
+	Currency        string
 }
 
 func serializeRangeDate(inputStartDate, inputEndDate string) (startDate time.Time, endDate time.Time, err error) {
@@ -75,6 +77,7 @@ func (req DoGetTrialBalanceRequest) ToFilterOpts() (*TrialBalanceFilterOptions,
 	opts := &TrialBalanceFilterOptions{
 		EntityCode: req.EntityCode,
 		Email:      req.Email,
+		Currency:   reportCurrency(req.Currency),
 	}
 
 	if req.Period != "" && (req.StartDate != "" || req.EndDate != "") {
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	KindConsolidatedTrialBalance = "consolidatedTrialBalance"
+)
+
+type DoGetConsolidatedTrialBalanceRequest struct {
+	EntityCodes string `json:"entityCodes" query:"entityCodes" example:"001,002"`
+	StartDate   string `json:"startDate" query:"startDate" example:"2023-01-01"`
+	EndDate     string `json:"endDate" query:"endDate" example:"2023-01-07"`
+	Period      string `json:"period" query:"period" validate:"omitempty" example:"2023-01"`
+	Currency    string `json:"currency" query:"currency" validate:"omitempty,alpha,min=3,max=3" example:"IDR"`
+}
+
+// ConsolidatedTrialBalanceFilterOptions consolidates every active entity when the entity codes are empty.
+type ConsolidatedTrialBalanceFilterOptions struct {
+	EntityCodes []string
+	StartDate   time.Time
+	EndDate     time.Time
+	Period      time.Time
+	Currency    string
+}
+
+func (req DoGetConsolidatedTrialBalanceRequest) ToFilterOpts() (*ConsolidatedTrialBalanceFilterOptions, error) {
+	// the date range rules are the same as the trial balance of an entity
+	tbOpts, err := DoGetTrialBalanceRequest{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Period:    req.Period,
+		Currency:  req.Currency,
+	}.ToFilterOpts()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &ConsolidatedTrialBalanceFilterOptions{
+		StartDate: tbOpts.StartDate,
+		EndDate:   tbOpts.EndDate,
+		Period:    tbOpts.Period,
+		Currency:  tbOpts.Currency,
+	}
+	if req.EntityCodes != "" {
+		for _, v := range strings.Split(req.EntityCodes, ",") {
+			opts.EntityCodes = append(opts.EntityCodes, strings.TrimSpace(v))
+		}
+	}
+
+	return opts, nil
+}
+
+func (opts ConsolidatedTrialBalanceFilterOptions) ToTrialBalanceFilterOptions(entityCode string) TrialBalanceFilterOptions {
+	return TrialBalanceFilterOptions{
+		EntityCode: entityCode,
+		StartDate:  opts.StartDate,
+		EndDate:    opts.EndDate,
+		Period:     opts.Period,
+		Currency:   opts.Currency,
+	}
+}
+
+type GetConsolidatedTrialBalanceResponse struct {
+	Kind         string                    `json:"kind" example:"consolidatedTrialBalance"`
+	EntityCodes  []string                  `json:"entityCodes" example:"001,002"`
+	ClosingDate  string                    `json:"closingDate" example:"2023-10-31"`
+	TrialBalance GetTrialBalanceResponses  `json:"trialBalance"`
+	Eliminations []TrialBalanceElimination `json:"eliminations"`
+	Mismatches   []IntercompanyMismatch    `json:"mismatches"`
+}
+
+// TrialBalanceElimination is the intercompany sub category balance removed from the consolidated trial balance.
+type TrialBalanceElimination struct {
+	SubCategoryCode string `json:"subCategoryId" example:"13101"`
+	SubCategoryName string `json:"subCategoryName" example:"Intercompany Receivable"`
+
+	IDRFormatOpeningBalance string `json:"openingBalance" example:"100000"`
+	IDRFormatDebitMovement  string `json:"debitMovement" example:"20000"`
+	IDRFormatCreditMovement string `json:"creditMovement" example:"20000"`
+	IDRFormatClosingBalance string `json:"closingBalance" example:"100000"`
+
+	OpeningBalance decimal.Decimal `json:"-"`
+	DebitMovement  decimal.Decimal `json:"-"`
+	CreditMovement decimal.Decimal `json:"-"`
+	ClosingBalance decimal.Decimal `json:"-"`
+}
+
+// IntercompanyMismatch is an intercompany pair whose receivable & payable closing balances are not equal.
+type IntercompanyMismatch struct {
+	ReceivableSubCategoryCode string `json:"receivableSubCategoryId" example:"13101"`
+	PayableSubCategoryCode    string `json:"payableSubCategoryId" example:"23101"`
+
+	IDRFormatReceivableBalance string `json:"receivableBalance" example:"100000"`
+	IDRFormatPayableBalance    string `json:"payableBalance" example:"90000"`
+	IDRFormatDifference        string `json:"difference" example:"10000"`
+
+	ReceivableBalance decimal.Decimal `json:"-"`
+	PayableBalance    decimal.Decimal `json:"-"`
+	Difference        decimal.Decimal `json:"-"`
+}
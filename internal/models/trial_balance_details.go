@@ -21,6 +21,7 @@ type DoGetTrialBalanceDetailsRequest struct {
 	NextCursor      string `query:"nextCursor" example:"abc"`
 	PrevCursor      string `query:"prevCursor" example:"cba"`
 	Email           string `json:"email" query:"email" validate:"omitempty,email" example:"tono@amartha.com"`
+	Currency        string `json:"currency" query:"currency" validate:"omitempty,alpha,min=3,max=3" example:"IDR"`
 }
 
 // to support trial balance details v2
@@ -42,6 +43,7 @@ type TrialBalanceDetailsFilterOptions struct {
 	EntityDesc      string
 	Month           string
 	Year            string
+	Currency        string
 
 	// CursorValue as accountNumber(string)
 	CursorValue *string
@@ -55,6 +57,7 @@ func (req DoGetTrialBalanceDetailsRequest) ToFilterOpts() (*TrialBalanceDetailsF
 		SubCategoryCode: req.SubCategoryCode,
 		Search:          req.Search,
 		Limit:           req.Limit,
+		Currency:        reportCurrency(req.Currency),
 	}
 
 	if req.Limit < 0 {
@@ -190,6 +193,7 @@ type DownloadTrialBalanceDetailsRequest struct {
 	EndDate         string `json:"endDate" query:"endDate" example:"2023-01-07"`
 	Email           string `json:"email" query:"email" validate:"required,email" example:"tono@amartha.com"`
 	Period          string `json:"period" query:"period"`
+	Currency        string `json:"currency" query:"currency" validate:"omitempty,alpha,min=3,max=3" example:"IDR"`
 }
 
 func (req DownloadTrialBalanceDetailsRequest) ToFilterOpts() (*TrialBalanceDetailsFilterOptions, error) {
@@ -197,6 +201,7 @@ func (req DownloadTrialBalanceDetailsRequest) ToFilterOpts() (*TrialBalanceDetai
 		EntityCode:      req.EntityCode,
 		SubCategoryCode: req.SubCategoryCode,
 		Email:           req.Email,
+		Currency:        reportCurrency(req.Currency),
 	}
 
 	if req.StartDate == "" && req.EndDate == "" {
@@ -15,6 +15,7 @@ type AccountTrialBalance struct {
 	CreditMovement  decimal.Decimal
 	OpeningBalance  decimal.Decimal
 	ClosingBalance  decimal.Decimal
+	Currency        string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
@@ -12,12 +12,14 @@ type DoGetTrialBalanceBySubCategoryRequest struct {
 	EntityCode      string `json:"entityCode" query:"entityCode" example:"001"`
 	StartDate       string `json:"startDate" query:"startDate" example:"2023-01-01"`
 	EndDate         string `json:"endDate" query:"endDate" example:"2023-01-07"`
+	Currency        string `json:"currency" query:"currency" validate:"omitempty,alpha,min=3,max=3" example:"IDR"`
 }
 
 func (req DoGetTrialBalanceBySubCategoryRequest) ToFilterOpts() (*TrialBalanceFilterOptions, error) {
 	opts := &TrialBalanceFilterOptions{
 		EntityCode:      req.EntityCode,
 		SubCategoryCode: req.SubCategoryCode,
+		Currency:        reportCurrency(req.Currency),
 	}
 
 	if req.StartDate == "" && req.EndDate == "" {
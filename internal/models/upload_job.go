@@ -0,0 +1,172 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"time"
+)
+
+const (
+	KindUploadJob = "uploadJob"
+)
+
+// type of the uploaded file, it decides how every row of the file is processed
+const (
+	UploadJobTypeJournal           = "journal"
+	UploadJobTypeAccount           = "account"
+	UploadJobTypePublisher         = "publisher"
+	UploadJobTypeAccountBulkUpdate = "accountBulkUpdate"
+)
+
+// status of an upload job
+const (
+	UploadJobStatusPending    = "pending"
+	UploadJobStatusProcessing = "processing"
+	UploadJobStatusCompleted  = "completed"
+	UploadJobStatusFailed     = "failed"
+)
+
+type (
+	CreateUploadJobRequest struct {
+		Type  string
+		Topic string
+		File  *multipart.FileHeader
+
+		// CreatedBy & Reason are written to the account change logs of an account bulk update job
+		CreatedBy string
+		Reason    string
+	}
+
+	// UploadJobMessage is published to the upload job stream, the worker processes the job by the id.
+	UploadJobMessage struct {
+		ID int `json:"id"`
+	}
+
+	UploadJobResponse struct {
+		Kind          string             `json:"kind" example:"uploadJob"`
+		ID            int                `json:"id" example:"1"`
+		Type          string             `json:"type" example:"journal"`
+		Filename      string             `json:"filename" example:"journals.csv"`
+		Topic         string             `json:"topic,omitempty" example:"journal_stream"`
+		CreatedBy     string             `json:"createdBy,omitempty" example:"tono@amartha.com"`
+		Reason        string             `json:"reason,omitempty" example:"lender accounts migration to the new entity"`
+		Status        string             `json:"status" example:"processing"`
+		TotalRows     int                `json:"totalRows" example:"1000"`
+		ProcessedRows int                `json:"processedRows" example:"500"`
+		SuccessRows   int                `json:"successRows" example:"499"`
+		FailedRows    int                `json:"failedRows" example:"1"`
+		Failures      []UploadJobFailure `json:"failures"`
+		ErrorMessage  string             `json:"errorMessage,omitempty" example:"record on line 2: wrong number of fields"`
+		ErrorFileURL  string             `json:"errorFileUrl,omitempty" example:"https://storage.googleapis.com/bucket/upload_jobs/journal/1_errors.csv"`
+		StartedAt     *time.Time         `json:"startedAt" example:"2025-01-01T00:00:00Z"`
+		FinishedAt    *time.Time         `json:"finishedAt" example:"2025-01-01T00:00:00Z"`
+		CreatedAt     time.Time          `json:"createdAt" example:"2025-01-01T00:00:00Z"`
+		UpdatedAt     time.Time          `json:"updatedAt" example:"2025-01-01T00:00:00Z"`
+	}
+)
+
+type UploadJob struct {
+	ID            int
+	Type          string
+	Filename      string
+	FilePath      string
+	Topic         string
+	CreatedBy     string
+	Reason        string
+	Status        string
+	Attempt       int // increased on every claim, the updates of the previous attempts are rejected
+	TotalRows     int
+	ProcessedRows int
+	SuccessRows   int
+	FailedRows    int
+	Failures      UploadJobFailures
+	ErrorMessage  string
+	ErrorFilePath string
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	// ErrorFileURL is the signed url of the error file, it is not stored.
+	ErrorFileURL string
+}
+
+// UploadJobFailure is a failed row of the uploaded file, the row number counts the header as the first row.
+type UploadJobFailure struct {
+	Row   int    `json:"row" example:"2"`
+	Error string `json:"error" example:"transaction id is exist"`
+
+	Record []string `json:"-"`
+}
+
+type UploadJobFailures []UploadJobFailure
+
+func (f *UploadJobFailures) Value() (driver.Value, error) {
+	jsonValue, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return jsonValue, nil
+}
+
+func (f *UploadJobFailures) Scan(value interface{}) error {
+	jsonValue, ok := value.([]byte)
+	if !ok {
+		return errors.New("invalid JSON data")
+	}
+
+	return json.Unmarshal(jsonValue, f)
+}
+
+func (job *UploadJob) ToResponse() UploadJobResponse {
+	failures := []UploadJobFailure(job.Failures)
+	if failures == nil {
+		failures = []UploadJobFailure{}
+	}
+
+	return UploadJobResponse{
+		Kind:          KindUploadJob,
+		ID:            job.ID,
+		Type:          job.Type,
+		Filename:      job.Filename,
+		Topic:         job.Topic,
+		CreatedBy:     job.CreatedBy,
+		Reason:        job.Reason,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		SuccessRows:   job.SuccessRows,
+		FailedRows:    job.FailedRows,
+		Failures:      failures,
+		ErrorMessage:  job.ErrorMessage,
+		ErrorFileURL:  job.ErrorFileURL,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+	}
+}
+
+// AddFailure counts the row as processed & failed.
+func (job *UploadJob) AddFailure(row int, record []string, err error) {
+	message := err.Error()
+	if errMap, ok := IsErrMap(err); ok {
+		message = errMap.Message
+	}
+
+	job.Failures = append(job.Failures, UploadJobFailure{
+		Row:    row,
+		Error:  message,
+		Record: record,
+	})
+	job.ProcessedRows++
+	job.FailedRows++
+}
+
+// AddSuccess counts the row as processed & succeeded.
+func (job *UploadJob) AddSuccess() {
+	job.ProcessedRows++
+	job.SuccessRows++
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/money"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	KindYearEndClosing = "yearEndClosing"
+)
+
+// metadata keys of a year end closing journal
+const (
+	MetadataKeyFiscalYear = "fiscalYear"
+)
+
+type YearEndClosingRequest struct {
+	FiscalYear string `param:"fiscalYear" json:"fiscalYear" validate:"required,len=4,numeric" example:"2024"`
+	EntityCode string `json:"entityCode" validate:"required,min=3,max=5,numeric" example:"001"`
+	ClosedBy   string `json:"closedBy" validate:"required" example:"tono@amartha.com"`
+}
+
+type YearEndClosingResponse struct {
+	Kind                    string    `json:"kind" example:"yearEndClosing"`
+	FiscalYear              string    `json:"fiscalYear" example:"2024"`
+	EntityCode              string    `json:"entityCode" example:"001"`
+	RetainedEarningsAccount string    `json:"retainedEarningsAccount" example:"311001000000001"`
+	NetIncome               string    `json:"netIncome" example:"1.000.000,00"`
+	ClosedBy                string    `json:"closedBy" example:"tono@amartha.com"`
+	ClosedAt                time.Time `json:"closedAt"`
+}
+
+// YearEndClosing is the record of a closed fiscal year, the net income is in the minor unit of IDR.
+type YearEndClosing struct {
+	ID                      int
+	FiscalYear              string
+	EntityCode              string
+	RetainedEarningsAccount string
+	NetIncome               decimal.Decimal
+	ClosedBy                string
+	CreatedAt               time.Time
+}
+
+func (c *YearEndClosing) ToYearEndClosingResponse() YearEndClosingResponse {
+	return YearEndClosingResponse{
+		Kind:                    KindYearEndClosing,
+		FiscalYear:              c.FiscalYear,
+		EntityCode:              c.EntityCode,
+		RetainedEarningsAccount: c.RetainedEarningsAccount,
+		NetIncome:               money.FormatAmountToIDRFromDecimal(c.NetIncome),
+		ClosedBy:                c.ClosedBy,
+		ClosedAt:                c.CreatedAt,
+	}
+}
+
+// YearEndClosingBalance is the balance of an income or expense account to be closed, the movements are in the minor unit.
+type YearEndClosingBalance struct {
+	AccountNumber   string
+	SubCategoryCode string
+	COAType         string
+	DebitMovement   decimal.Decimal
+	CreditMovement  decimal.Decimal
+}
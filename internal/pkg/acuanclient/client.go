@@ -13,6 +13,7 @@ import (
 
 type AcuanClient interface {
 	PublishAccount(ctx context.Context, data PublishAccountData)
+	PublishAccountsOneByOne(ctx context.Context, data []PublishAccountData)
 }
 
 type client struct {
@@ -50,6 +51,27 @@ func New(cfg *config.Configuration) (AcuanClient, error) {
 }
 
 func (c *client) PublishAccount(ctx context.Context, data PublishAccountData) {
+	if err := c.publishAccount(data); err != nil {
+		xlog.Error(ctx, "[PUBLISH-ACCOUNT]", xlog.String("status", "fail"), xlog.Any("message", data), xlog.Err(err))
+		return
+	}
+	xlog.Info(ctx, "[PUBLISH-ACCOUNT]", xlog.String("status", "success"), xlog.Any("message", data))
+}
+
+// PublishAccountsOneByOne publishes the accounts with a message per account, acuan lib has no batch publish,
+// so it costs the same as calling PublishAccount for every account. A failed account is logged without stopping the next accounts.
+func (c *client) PublishAccountsOneByOne(ctx context.Context, data []PublishAccountData) {
+	var failed int
+	for _, v := range data {
+		if err := c.publishAccount(v); err != nil {
+			failed++
+			xlog.Error(ctx, "[PUBLISH-ACCOUNTS]", xlog.String("status", "fail"), xlog.Any("message", v), xlog.Err(err))
+		}
+	}
+	xlog.Info(ctx, "[PUBLISH-ACCOUNTS]", xlog.Int("accounts", len(data)), xlog.Int("failed", failed))
+}
+
+func (c *client) publishAccount(data PublishAccountData) error {
 	var legacyId *goAcuanLibModel.AccountLegacyId
 
 	if data.LegacyId != nil {
@@ -57,7 +79,7 @@ func (c *client) PublishAccount(ctx context.Context, data PublishAccountData) {
 		legacyId = &acuanLegacy
 	}
 
-	err := c.acuanClient.Accounting.Publish(goAcuanLibModel.Account{
+	return c.acuanClient.Accounting.Publish(goAcuanLibModel.Account{
 		Type:            data.Type,
 		AccountNumber:   data.AccountNumber,
 		Name:            data.Name,
@@ -72,9 +94,4 @@ func (c *client) PublishAccount(ctx context.Context, data PublishAccountData) {
 		LegacyId:        legacyId,
 		Metadata:        data.Metadata,
 	})
-	if err != nil {
-		xlog.Error(ctx, "[PUBLISH-ACCOUNT]", xlog.String("status", "fail"), xlog.Any("message", data), xlog.Err(err))
-		return
-	}
-	xlog.Info(ctx, "[PUBLISH-ACCOUNT]", xlog.String("status", "success"), xlog.Any("message", data))
 }
@@ -52,3 +52,15 @@ func (mr *MockAcuanClientMockRecorder) PublishAccount(ctx, data any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishAccount", reflect.TypeOf((*MockAcuanClient)(nil).PublishAccount), ctx, data)
 }
+
+// PublishAccountsOneByOne mocks base method.
+func (m *MockAcuanClient) PublishAccountsOneByOne(ctx context.Context, data []acuanclient.PublishAccountData) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishAccountsOneByOne", ctx, data)
+}
+
+// PublishAccountsOneByOne indicates an expected call of PublishAccountsOneByOne.
+func (mr *MockAcuanClientMockRecorder) PublishAccountsOneByOne(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishAccountsOneByOne", reflect.TypeOf((*MockAcuanClient)(nil).PublishAccountsOneByOne), ctx, data)
+}
@@ -20,6 +20,8 @@ type IOFile interface {
 	CSVWriteAll(ctx context.Context, all [][]string) (err error)
 	CSVProcessWrite(ctx context.Context) (err error)
 	CSVReadAll(fs io.Reader) (records [][]string, err error)
+
+	XLSXReadAll(fs io.Reader) (records [][]string, err error)
 }
 
 type ioFile struct {
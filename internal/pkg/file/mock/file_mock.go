@@ -200,3 +200,18 @@ func (mr *MockIOFileMockRecorder) RemoveFile(path any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFile", reflect.TypeOf((*MockIOFile)(nil).RemoveFile), path)
 }
+
+// XLSXReadAll mocks base method.
+func (m *MockIOFile) XLSXReadAll(fs io.Reader) ([][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "XLSXReadAll", fs)
+	ret0, _ := ret[0].([][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// XLSXReadAll indicates an expected call of XLSXReadAll.
+func (mr *MockIOFileMockRecorder) XLSXReadAll(fs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "XLSXReadAll", reflect.TypeOf((*MockIOFile)(nil).XLSXReadAll), fs)
+}
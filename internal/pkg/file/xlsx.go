@@ -0,0 +1,241 @@
+package file
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	xlsxWorkbookPath      = "xl/workbook.xml"
+	xlsxWorkbookRelsPath  = "xl/_rels/workbook.xml.rels"
+	xlsxSharedStringsPath = "xl/sharedStrings.xml"
+
+	// xlsxMaxColumns is the maximum columns of an excel sheet, column XFD.
+	xlsxMaxColumns = 16384
+	// xlsxMaxRows is the maximum rows of an excel sheet.
+	xlsxMaxRows = 1048576
+
+	// xlsxMaxFileSize caps the zipped file & xlsxMaxPartSize caps every unzipped xml part,
+	// so a zip bomb is rejected before it is inflated into the memory.
+	xlsxMaxFileSize = 50 << 20
+	xlsxMaxPartSize = 200 << 20
+)
+
+var (
+	ErrXLSXNoSheet  = errors.New("xlsx file has no sheet")
+	ErrXLSXTooLarge = errors.New("xlsx file is too large")
+)
+
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+
+	// xlsxText is a shared or inline string, a rich text string is split into runs.
+	xlsxText struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	}
+
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+
+	xlsxWorksheet struct {
+		Rows []struct {
+			Ref   string `xml:"r,attr"`
+			Cells []struct {
+				Ref       string    `xml:"r,attr"`
+				Type      string    `xml:"t,attr"`
+				Value     string    `xml:"v"`
+				InlineStr *xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+
+	var sb strings.Builder
+	for _, v := range t.Runs {
+		sb.WriteString(v.T)
+	}
+	return sb.String()
+}
+
+// XLSXReadAll reads every row of the first sheet as the csv records, the cells are returned as the raw values,
+// e.g. a date cell is returned as the excel serial number. Excel omits the empty rows & cells,
+// they are filled so the rows & the columns keep their position.
+func (c *ioFile) XLSXReadAll(fs io.Reader) (records [][]string, err error) {
+	data, err := io.ReadAll(io.LimitReader(fs, xlsxMaxFileSize+1))
+	if err != nil {
+		return
+	}
+	if len(data) > xlsxMaxFileSize {
+		return nil, ErrXLSXTooLarge
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := xlsxFirstSheetPath(files)
+	if err != nil {
+		return
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if f, ok := files[xlsxSharedStringsPath]; ok {
+		if err = xlsxDecode(f, &sharedStrings); err != nil {
+			return
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("xlsx sheet %s is not found", sheetPath)
+	}
+	var sheet xlsxWorksheet
+	if err = xlsxDecode(f, &sheet); err != nil {
+		return
+	}
+
+	records = make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		rowNumber := len(records) + 1
+		if row.Ref != "" {
+			rowNumber, err = strconv.Atoi(row.Ref)
+			if err != nil || rowNumber <= len(records) || rowNumber > xlsxMaxRows {
+				return nil, fmt.Errorf("xlsx row %s is invalid", row.Ref)
+			}
+		}
+		for len(records) < rowNumber-1 {
+			records = append(records, []string{})
+		}
+
+		record := []string{}
+		for _, cell := range row.Cells {
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				i, errIdx := strconv.Atoi(value)
+				if errIdx != nil || i < 0 || i >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("xlsx cell %s has invalid shared string %s", cell.Ref, value)
+				}
+				value = sharedStrings.Items[i].String()
+			case "inlineStr":
+				if cell.InlineStr != nil {
+					value = cell.InlineStr.String()
+				}
+			}
+
+			col := len(record)
+			if cell.Ref != "" {
+				if col, err = xlsxColumnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			for len(record) < col {
+				record = append(record, "")
+			}
+			record = append(record, value)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// xlsxFirstSheetPath resolves the file of the first sheet of the workbook through the workbook relationships.
+func xlsxFirstSheetPath(files map[string]*zip.File) (string, error) {
+	f, ok := files[xlsxWorkbookPath]
+	if !ok {
+		return "", errors.New("xlsx workbook is not found")
+	}
+	var workbook xlsxWorkbook
+	if err := xlsxDecode(f, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", ErrXLSXNoSheet
+	}
+
+	f, ok = files[xlsxWorkbookRelsPath]
+	if !ok {
+		return "", errors.New("xlsx workbook relationships is not found")
+	}
+	var rels xlsxRelationships
+	if err := xlsxDecode(f, &rels); err != nil {
+		return "", err
+	}
+	for _, v := range rels.Relationships {
+		if v.ID != workbook.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(v.Target, "/") {
+			return strings.TrimPrefix(v.Target, "/"), nil
+		}
+		return path.Join(path.Dir(xlsxWorkbookPath), v.Target), nil
+	}
+
+	return "", ErrXLSXNoSheet
+}
+
+// xlsxDecode checks the declared size before inflating the part & caps the inflated bytes in case the size is forged.
+func xlsxDecode(f *zip.File, v interface{}) error {
+	if f.UncompressedSize64 > xlsxMaxPartSize {
+		return ErrXLSXTooLarge
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(io.LimitReader(rc, xlsxMaxPartSize)).Decode(v)
+}
+
+// xlsxColumnIndex returns the zero based column of a cell reference, e.g. 0 for A1 & 27 for AB3.
+func xlsxColumnIndex(ref string) (int, error) {
+	col := 0
+	for i, r := range ref {
+		if r >= 'A' && r <= 'Z' {
+			col = col*26 + int(r-'A'+1)
+			continue
+		}
+		if i == 0 || col > xlsxMaxColumns {
+			break
+		}
+		return col - 1, nil
+	}
+
+	return 0, fmt.Errorf("xlsx cell reference %s is invalid", ref)
+}
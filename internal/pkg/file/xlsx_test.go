@@ -0,0 +1,161 @@
+package file
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newXLSX(t *testing.T, files map[string]string) *bytes.Buffer {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	return buf
+}
+
+func TestXLSXReadAll(t *testing.T) {
+	workbook := `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="journals" sheetId="1" r:id="rId2"/></sheets></workbook>`
+	rels := `<Relationships><Relationship Id="rId1" Target="worksheets/sheet2.xml"/><Relationship Id="rId2" Target="worksheets/sheet1.xml"/></Relationships>`
+	sharedStrings := `<sst><si><t>transaction_id</t></si><si><t>amount</t></si><si><r><t>trx-</t></r><r><t>1</t></r></si></sst>`
+	sheet := `<worksheet><sheetData>
+		<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>
+		<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2" t="inlineStr"><is><t>note</t></is></c><c r="C2"><v>1000.5</v></c></row>
+	</sheetData></worksheet>`
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name: "success case",
+			files: map[string]string{
+				xlsxWorkbookPath:           workbook,
+				xlsxWorkbookRelsPath:       rels,
+				xlsxSharedStringsPath:      sharedStrings,
+				"xl/worksheets/sheet1.xml": sheet,
+			},
+			want: [][]string{
+				{"transaction_id", "", "amount"},
+				{"trx-1", "note", "1000.5"},
+			},
+		},
+		{
+			name: "success case - empty rows are omitted",
+			files: map[string]string{
+				xlsxWorkbookPath:      workbook,
+				xlsxWorkbookRelsPath:  rels,
+				xlsxSharedStringsPath: sharedStrings,
+				"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+					<row r="1"><c r="A1" t="s"><v>0</v></c></row>
+					<row r="4"><c r="A4" t="s"><v>2</v></c></row>
+				</sheetData></worksheet>`,
+			},
+			want: [][]string{
+				{"transaction_id"},
+				{},
+				{},
+				{"trx-1"},
+			},
+		},
+		{
+			name: "error case - row is not in order",
+			files: map[string]string{
+				xlsxWorkbookPath:      workbook,
+				xlsxWorkbookRelsPath:  rels,
+				xlsxSharedStringsPath: sharedStrings,
+				"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+					<row r="2"><c r="A2" t="s"><v>0</v></c></row>
+					<row r="1"><c r="A1" t="s"><v>2</v></c></row>
+				</sheetData></worksheet>`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - no workbook",
+			files: map[string]string{
+				"xl/worksheets/sheet1.xml": sheet,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - invalid shared string",
+			files: map[string]string{
+				xlsxWorkbookPath:           workbook,
+				xlsxWorkbookRelsPath:       rels,
+				"xl/worksheets/sheet1.xml": sheet,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New().XLSXReadAll(newXLSX(t, tt.files))
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	t.Run("error case - sheet is too large", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		zw := zip.NewWriter(buf)
+		for name, content := range map[string]string{xlsxWorkbookPath: workbook, xlsxWorkbookRelsPath: rels} {
+			w, err := zw.Create(name)
+			require.NoError(t, err)
+			_, err = w.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		w, err := zw.Create("xl/worksheets/sheet1.xml")
+		require.NoError(t, err)
+		chunk := bytes.Repeat([]byte(" "), 1<<20)
+		for i := 0; i <= xlsxMaxPartSize>>20; i++ {
+			_, err = w.Write(chunk)
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+
+		_, err = New().XLSXReadAll(buf)
+		assert.ErrorIs(t, err, ErrXLSXTooLarge)
+	})
+
+	t.Run("error case - not a zip file", func(t *testing.T) {
+		_, err := New().XLSXReadAll(bytes.NewBufferString("a,b,c"))
+		assert.Error(t, err)
+	})
+}
+
+func TestXLSXColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    int
+		wantErr bool
+	}{
+		{ref: "A1", want: 0},
+		{ref: "Z10", want: 25},
+		{ref: "AB3", want: 27},
+		{ref: "1A", wantErr: true},
+		{ref: "AB", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := xlsxColumnIndex(tt.ref)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -13,6 +13,14 @@ import (
 
 var CurrencyIDR = godbledger.CurrencyIDR
 
+// filterCurrency returns the currency of a report filter, IDR when the filter has no currency.
+func filterCurrency(currency string) string {
+	if currency == "" {
+		return CurrencyIDR.Name
+	}
+	return currency
+}
+
 const logMessageDatabase = "[DATABASE]"
 
 func logSQL(ctx context.Context, err error, times ...time.Time) {
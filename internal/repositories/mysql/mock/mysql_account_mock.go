@@ -156,6 +156,34 @@ func (mr *MockAccountRepositoryMockRecorder) CreateLoanAccount(ctx, in any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoanAccount", reflect.TypeOf((*MockAccountRepository)(nil).CreateLoanAccount), ctx, in)
 }
 
+// DeleteParent mocks base method.
+func (m *MockAccountRepository) DeleteParent(ctx context.Context, accountNumber string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteParent", ctx, accountNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteParent indicates an expected call of DeleteParent.
+func (mr *MockAccountRepositoryMockRecorder) DeleteParent(ctx, accountNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteParent", reflect.TypeOf((*MockAccountRepository)(nil).DeleteParent), ctx, accountNumber)
+}
+
+// DeleteRelationshipRule mocks base method.
+func (m *MockAccountRepository) DeleteRelationshipRule(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRelationshipRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRelationshipRule indicates an expected call of DeleteRelationshipRule.
+func (mr *MockAccountRepositoryMockRecorder) DeleteRelationshipRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRelationshipRule", reflect.TypeOf((*MockAccountRepository)(nil).DeleteRelationshipRule), ctx, id)
+}
+
 // GetAccountList mocks base method.
 func (m *MockAccountRepository) GetAccountList(ctx context.Context, opts models.AccountFilterOptions) ([]models.GetAccountOut, error) {
 	m.ctrl.T.Helper()
@@ -230,34 +258,34 @@ func (mr *MockAccountRepositoryMockRecorder) GetAllAccountNumbersByParam(ctx, pa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAccountNumbersByParam", reflect.TypeOf((*MockAccountRepository)(nil).GetAllAccountNumbersByParam), ctx, params)
 }
 
-// GetLenderAccountByCIHAccountNumber mocks base method.
-func (m *MockAccountRepository) GetLenderAccountByCIHAccountNumber(ctx context.Context, accountNumber string) (models.AccountLender, error) {
+// GetByAccountNumbers mocks base method.
+func (m *MockAccountRepository) GetByAccountNumbers(ctx context.Context, accountNumbers []string) ([]models.GetAccountOut, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLenderAccountByCIHAccountNumber", ctx, accountNumber)
-	ret0, _ := ret[0].(models.AccountLender)
+	ret := m.ctrl.Call(m, "GetByAccountNumbers", ctx, accountNumbers)
+	ret0, _ := ret[0].([]models.GetAccountOut)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLenderAccountByCIHAccountNumber indicates an expected call of GetLenderAccountByCIHAccountNumber.
-func (mr *MockAccountRepositoryMockRecorder) GetLenderAccountByCIHAccountNumber(ctx, accountNumber any) *gomock.Call {
+// GetByAccountNumbers indicates an expected call of GetByAccountNumbers.
+func (mr *MockAccountRepositoryMockRecorder) GetByAccountNumbers(ctx, accountNumbers any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLenderAccountByCIHAccountNumber", reflect.TypeOf((*MockAccountRepository)(nil).GetLenderAccountByCIHAccountNumber), ctx, accountNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAccountNumbers", reflect.TypeOf((*MockAccountRepository)(nil).GetByAccountNumbers), ctx, accountNumbers)
 }
 
-// GetLoanAdvanceAccountByLoanAccount mocks base method.
-func (m *MockAccountRepository) GetLoanAdvanceAccountByLoanAccount(ctx context.Context, loanAccountNumber string) (models.AccountLoan, error) {
+// GetChangeLogs mocks base method.
+func (m *MockAccountRepository) GetChangeLogs(ctx context.Context, opts models.AccountChangeLogFilterOptions) ([]models.AccountChangeLog, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLoanAdvanceAccountByLoanAccount", ctx, loanAccountNumber)
-	ret0, _ := ret[0].(models.AccountLoan)
+	ret := m.ctrl.Call(m, "GetChangeLogs", ctx, opts)
+	ret0, _ := ret[0].([]models.AccountChangeLog)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLoanAdvanceAccountByLoanAccount indicates an expected call of GetLoanAdvanceAccountByLoanAccount.
-func (mr *MockAccountRepositoryMockRecorder) GetLoanAdvanceAccountByLoanAccount(ctx, loanAccountNumber any) *gomock.Call {
+// GetChangeLogs indicates an expected call of GetChangeLogs.
+func (mr *MockAccountRepositoryMockRecorder) GetChangeLogs(ctx, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanAdvanceAccountByLoanAccount", reflect.TypeOf((*MockAccountRepository)(nil).GetLoanAdvanceAccountByLoanAccount), ctx, loanAccountNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeLogs", reflect.TypeOf((*MockAccountRepository)(nil).GetChangeLogs), ctx, opts)
 }
 
 // GetOneByAccountNumber mocks base method.
@@ -290,6 +318,94 @@ func (mr *MockAccountRepositoryMockRecorder) GetOneByLegacyID(ctx, legacyID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneByLegacyID", reflect.TypeOf((*MockAccountRepository)(nil).GetOneByLegacyID), ctx, legacyID)
 }
 
+// GetRelations mocks base method.
+func (m *MockAccountRepository) GetRelations(ctx context.Context, opts models.AccountRelationFilterOptions) ([]models.AccountRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelations", ctx, opts)
+	ret0, _ := ret[0].([]models.AccountRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelations indicates an expected call of GetRelations.
+func (mr *MockAccountRepositoryMockRecorder) GetRelations(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelations", reflect.TypeOf((*MockAccountRepository)(nil).GetRelations), ctx, opts)
+}
+
+// GetRelationshipRuleById mocks base method.
+func (m *MockAccountRepository) GetRelationshipRuleById(ctx context.Context, id int) (*models.AccountRelationshipRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelationshipRuleById", ctx, id)
+	ret0, _ := ret[0].(*models.AccountRelationshipRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelationshipRuleById indicates an expected call of GetRelationshipRuleById.
+func (mr *MockAccountRepositoryMockRecorder) GetRelationshipRuleById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelationshipRuleById", reflect.TypeOf((*MockAccountRepository)(nil).GetRelationshipRuleById), ctx, id)
+}
+
+// GetRelationshipRules mocks base method.
+func (m *MockAccountRepository) GetRelationshipRules(ctx context.Context, opts models.AccountRelationshipRuleFilterOptions) ([]models.AccountRelationshipRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelationshipRules", ctx, opts)
+	ret0, _ := ret[0].([]models.AccountRelationshipRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelationshipRules indicates an expected call of GetRelationshipRules.
+func (mr *MockAccountRepositoryMockRecorder) GetRelationshipRules(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelationshipRules", reflect.TypeOf((*MockAccountRepository)(nil).GetRelationshipRules), ctx, opts)
+}
+
+// InsertChangeLogs mocks base method.
+func (m *MockAccountRepository) InsertChangeLogs(ctx context.Context, in []models.CreateAccountChangeLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertChangeLogs", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertChangeLogs indicates an expected call of InsertChangeLogs.
+func (mr *MockAccountRepositoryMockRecorder) InsertChangeLogs(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertChangeLogs", reflect.TypeOf((*MockAccountRepository)(nil).InsertChangeLogs), ctx, in)
+}
+
+// InsertParent mocks base method.
+func (m *MockAccountRepository) InsertParent(ctx context.Context, in models.AccountRelation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertParent", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertParent indicates an expected call of InsertParent.
+func (mr *MockAccountRepositoryMockRecorder) InsertParent(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertParent", reflect.TypeOf((*MockAccountRepository)(nil).InsertParent), ctx, in)
+}
+
+// InsertRelationshipRule mocks base method.
+func (m *MockAccountRepository) InsertRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRelationshipRule", ctx, in)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertRelationshipRule indicates an expected call of InsertRelationshipRule.
+func (mr *MockAccountRepositoryMockRecorder) InsertRelationshipRule(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRelationshipRule", reflect.TypeOf((*MockAccountRepository)(nil).InsertRelationshipRule), ctx, in)
+}
+
 // Update mocks base method.
 func (m *MockAccountRepository) Update(ctx context.Context, in models.UpdateAccount) error {
 	m.ctrl.T.Helper()
@@ -359,3 +475,31 @@ func (mr *MockAccountRepositoryMockRecorder) UpdateLegacyId(ctx, in any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLegacyId", reflect.TypeOf((*MockAccountRepository)(nil).UpdateLegacyId), ctx, in)
 }
+
+// UpdateRelationshipRule mocks base method.
+func (m *MockAccountRepository) UpdateRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRelationshipRule", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRelationshipRule indicates an expected call of UpdateRelationshipRule.
+func (mr *MockAccountRepositoryMockRecorder) UpdateRelationshipRule(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRelationshipRule", reflect.TypeOf((*MockAccountRepository)(nil).UpdateRelationshipRule), ctx, in)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockAccountRepository) UpdateStatus(ctx context.Context, in models.UpdateAccountStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockAccountRepositoryMockRecorder) UpdateStatus(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockAccountRepository)(nil).UpdateStatus), ctx, in)
+}
@@ -103,6 +103,21 @@ func (mr *MockAccountingRepositoryMockRecorder) CalculateOpeningClosingBalanceFr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateOpeningClosingBalanceFromAccountBalance", reflect.TypeOf((*MockAccountingRepository)(nil).CalculateOpeningClosingBalanceFromAccountBalance), ctx, in)
 }
 
+// CheckBankStatementIsExist mocks base method.
+func (m *MockAccountingRepository) CheckBankStatementIsExist(ctx context.Context, accountNumber, checksum string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckBankStatementIsExist", ctx, accountNumber, checksum)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckBankStatementIsExist indicates an expected call of CheckBankStatementIsExist.
+func (mr *MockAccountingRepositoryMockRecorder) CheckBankStatementIsExist(ctx, accountNumber, checksum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckBankStatementIsExist", reflect.TypeOf((*MockAccountingRepository)(nil).CheckBankStatementIsExist), ctx, accountNumber, checksum)
+}
+
 // CheckTransactionIdIsExist mocks base method.
 func (m *MockAccountingRepository) CheckTransactionIdIsExist(ctx context.Context, transactionId string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +133,20 @@ func (mr *MockAccountingRepositoryMockRecorder) CheckTransactionIdIsExist(ctx, t
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTransactionIdIsExist", reflect.TypeOf((*MockAccountingRepository)(nil).CheckTransactionIdIsExist), ctx, transactionId)
 }
 
+// ClaimUploadJob mocks base method.
+func (m *MockAccountingRepository) ClaimUploadJob(ctx context.Context, in models.UploadJob, staleBefore time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUploadJob", ctx, in, staleBefore)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimUploadJob indicates an expected call of ClaimUploadJob.
+func (mr *MockAccountingRepositoryMockRecorder) ClaimUploadJob(ctx, in, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUploadJob", reflect.TypeOf((*MockAccountingRepository)(nil).ClaimUploadJob), ctx, in, staleBefore)
+}
+
 // GetAccountBalancePeriodStart mocks base method.
 func (m *MockAccountingRepository) GetAccountBalancePeriodStart(ctx context.Context, accountNumber string, date time.Time) (decimal.Decimal, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +162,36 @@ func (mr *MockAccountingRepositoryMockRecorder) GetAccountBalancePeriodStart(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalancePeriodStart", reflect.TypeOf((*MockAccountingRepository)(nil).GetAccountBalancePeriodStart), ctx, accountNumber, date)
 }
 
+// GetAccountDailyBalanceByEntity mocks base method.
+func (m *MockAccountingRepository) GetAccountDailyBalanceByEntity(ctx context.Context, entityCode string, date time.Time) ([]models.AccountBalanceDaily, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountDailyBalanceByEntity", ctx, entityCode, date)
+	ret0, _ := ret[0].([]models.AccountBalanceDaily)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountDailyBalanceByEntity indicates an expected call of GetAccountDailyBalanceByEntity.
+func (mr *MockAccountingRepositoryMockRecorder) GetAccountDailyBalanceByEntity(ctx, entityCode, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountDailyBalanceByEntity", reflect.TypeOf((*MockAccountingRepository)(nil).GetAccountDailyBalanceByEntity), ctx, entityCode, date)
+}
+
+// GetAccountMovementsAfterDailyBalance mocks base method.
+func (m *MockAccountingRepository) GetAccountMovementsAfterDailyBalance(ctx context.Context, dailyBalances []models.AccountBalanceDaily, asOf time.Time) ([]models.LedgerAccountMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountMovementsAfterDailyBalance", ctx, dailyBalances, asOf)
+	ret0, _ := ret[0].([]models.LedgerAccountMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountMovementsAfterDailyBalance indicates an expected call of GetAccountMovementsAfterDailyBalance.
+func (mr *MockAccountingRepositoryMockRecorder) GetAccountMovementsAfterDailyBalance(ctx, dailyBalances, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountMovementsAfterDailyBalance", reflect.TypeOf((*MockAccountingRepository)(nil).GetAccountMovementsAfterDailyBalance), ctx, dailyBalances, asOf)
+}
+
 // GetAccountTransactionByDate mocks base method.
 func (m *MockAccountingRepository) GetAccountTransactionByDate(ctx context.Context, entities []string, date time.Time) <-chan models.StreamResult[models.AccountTransation] {
 	m.ctrl.T.Helper()
@@ -147,6 +206,21 @@ func (mr *MockAccountingRepositoryMockRecorder) GetAccountTransactionByDate(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactionByDate", reflect.TypeOf((*MockAccountingRepository)(nil).GetAccountTransactionByDate), ctx, entities, date)
 }
 
+// GetAccountTrialBalanceByEntity mocks base method.
+func (m *MockAccountingRepository) GetAccountTrialBalanceByEntity(ctx context.Context, entityCode string, date time.Time) ([]models.AccountTrialBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountTrialBalanceByEntity", ctx, entityCode, date)
+	ret0, _ := ret[0].([]models.AccountTrialBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountTrialBalanceByEntity indicates an expected call of GetAccountTrialBalanceByEntity.
+func (mr *MockAccountingRepositoryMockRecorder) GetAccountTrialBalanceByEntity(ctx, entityCode, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTrialBalanceByEntity", reflect.TypeOf((*MockAccountingRepository)(nil).GetAccountTrialBalanceByEntity), ctx, entityCode, date)
+}
+
 // GetAllAccountDailyBalance mocks base method.
 func (m *MockAccountingRepository) GetAllAccountDailyBalance(ctx context.Context, entities []string, subCategories *[]models.SubCategory, date time.Time) <-chan models.StreamResult[models.AccountBalanceDaily] {
 	m.ctrl.T.Helper()
@@ -176,6 +250,111 @@ func (mr *MockAccountingRepositoryMockRecorder) GetBalanceSheet(ctx, opts any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceSheet", reflect.TypeOf((*MockAccountingRepository)(nil).GetBalanceSheet), ctx, opts)
 }
 
+// GetBalanceSheetPerSubCategory mocks base method.
+func (m *MockAccountingRepository) GetBalanceSheetPerSubCategory(ctx context.Context, entityCode, currency string, dates []time.Time) ([]models.BalancePerSubCategoryOut, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceSheetPerSubCategory", ctx, entityCode, currency, dates)
+	ret0, _ := ret[0].([]models.BalancePerSubCategoryOut)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceSheetPerSubCategory indicates an expected call of GetBalanceSheetPerSubCategory.
+func (mr *MockAccountingRepositoryMockRecorder) GetBalanceSheetPerSubCategory(ctx, entityCode, currency, dates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceSheetPerSubCategory", reflect.TypeOf((*MockAccountingRepository)(nil).GetBalanceSheetPerSubCategory), ctx, entityCode, currency, dates)
+}
+
+// GetBankStatementLineById mocks base method.
+func (m *MockAccountingRepository) GetBankStatementLineById(ctx context.Context, id int) (*models.BankStatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankStatementLineById", ctx, id)
+	ret0, _ := ret[0].(*models.BankStatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankStatementLineById indicates an expected call of GetBankStatementLineById.
+func (mr *MockAccountingRepositoryMockRecorder) GetBankStatementLineById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankStatementLineById", reflect.TypeOf((*MockAccountingRepository)(nil).GetBankStatementLineById), ctx, id)
+}
+
+// GetBankStatementLines mocks base method.
+func (m *MockAccountingRepository) GetBankStatementLines(ctx context.Context, opts models.BankStatementLineFilterOptions) ([]models.BankStatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankStatementLines", ctx, opts)
+	ret0, _ := ret[0].([]models.BankStatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankStatementLines indicates an expected call of GetBankStatementLines.
+func (mr *MockAccountingRepositoryMockRecorder) GetBankStatementLines(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankStatementLines", reflect.TypeOf((*MockAccountingRepository)(nil).GetBankStatementLines), ctx, opts)
+}
+
+// GetBankStatementLinesByJournalIds mocks base method.
+func (m *MockAccountingRepository) GetBankStatementLinesByJournalIds(ctx context.Context, journalIds []string) ([]models.BankStatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankStatementLinesByJournalIds", ctx, journalIds)
+	ret0, _ := ret[0].([]models.BankStatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankStatementLinesByJournalIds indicates an expected call of GetBankStatementLinesByJournalIds.
+func (mr *MockAccountingRepositoryMockRecorder) GetBankStatementLinesByJournalIds(ctx, journalIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankStatementLinesByJournalIds", reflect.TypeOf((*MockAccountingRepository)(nil).GetBankStatementLinesByJournalIds), ctx, journalIds)
+}
+
+// GetCashBalance mocks base method.
+func (m *MockAccountingRepository) GetCashBalance(ctx context.Context, entityCode, currency string, cashSubCategories []string, before time.Time) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCashBalance", ctx, entityCode, currency, cashSubCategories, before)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCashBalance indicates an expected call of GetCashBalance.
+func (mr *MockAccountingRepositoryMockRecorder) GetCashBalance(ctx, entityCode, currency, cashSubCategories, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCashBalance", reflect.TypeOf((*MockAccountingRepository)(nil).GetCashBalance), ctx, entityCode, currency, cashSubCategories, before)
+}
+
+// GetCashFlowMovement mocks base method.
+func (m *MockAccountingRepository) GetCashFlowMovement(ctx context.Context, opts models.CashFlowFilterOptions, cashSubCategories []string) ([]models.CashFlowMovementOut, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCashFlowMovement", ctx, opts, cashSubCategories)
+	ret0, _ := ret[0].([]models.CashFlowMovementOut)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCashFlowMovement indicates an expected call of GetCashFlowMovement.
+func (mr *MockAccountingRepositoryMockRecorder) GetCashFlowMovement(ctx, opts, cashSubCategories any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCashFlowMovement", reflect.TypeOf((*MockAccountingRepository)(nil).GetCashFlowMovement), ctx, opts, cashSubCategories)
+}
+
+// GetForeignCurrencyClosingBalance mocks base method.
+func (m *MockAccountingRepository) GetForeignCurrencyClosingBalance(ctx context.Context, date time.Time, baseCurrency string) ([]models.AccountBalanceDaily, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForeignCurrencyClosingBalance", ctx, date, baseCurrency)
+	ret0, _ := ret[0].([]models.AccountBalanceDaily)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForeignCurrencyClosingBalance indicates an expected call of GetForeignCurrencyClosingBalance.
+func (mr *MockAccountingRepositoryMockRecorder) GetForeignCurrencyClosingBalance(ctx, date, baseCurrency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForeignCurrencyClosingBalance", reflect.TypeOf((*MockAccountingRepository)(nil).GetForeignCurrencyClosingBalance), ctx, date, baseCurrency)
+}
+
 // GetJournalDetailByTransactionId mocks base method.
 func (m *MockAccountingRepository) GetJournalDetailByTransactionId(ctx context.Context, transactionId string) ([]models.GetJournalDetailOut, error) {
 	m.ctrl.T.Helper()
@@ -191,6 +370,36 @@ func (mr *MockAccountingRepositoryMockRecorder) GetJournalDetailByTransactionId(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalDetailByTransactionId", reflect.TypeOf((*MockAccountingRepository)(nil).GetJournalDetailByTransactionId), ctx, transactionId)
 }
 
+// GetJournalDraftById mocks base method.
+func (m *MockAccountingRepository) GetJournalDraftById(ctx context.Context, id int) (*models.JournalDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournalDraftById", ctx, id)
+	ret0, _ := ret[0].(*models.JournalDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournalDraftById indicates an expected call of GetJournalDraftById.
+func (mr *MockAccountingRepositoryMockRecorder) GetJournalDraftById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalDraftById", reflect.TypeOf((*MockAccountingRepository)(nil).GetJournalDraftById), ctx, id)
+}
+
+// GetJournalDrafts mocks base method.
+func (m *MockAccountingRepository) GetJournalDrafts(ctx context.Context, opts models.JournalDraftFilterOptions) ([]models.JournalDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournalDrafts", ctx, opts)
+	ret0, _ := ret[0].([]models.JournalDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournalDrafts indicates an expected call of GetJournalDrafts.
+func (mr *MockAccountingRepositoryMockRecorder) GetJournalDrafts(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalDrafts", reflect.TypeOf((*MockAccountingRepository)(nil).GetJournalDrafts), ctx, opts)
+}
+
 // GetLastOpeningBalance mocks base method.
 func (m *MockAccountingRepository) GetLastOpeningBalance(ctx context.Context, accountNumber string, date time.Time) (decimal.Decimal, error) {
 	m.ctrl.T.Helper()
@@ -206,6 +415,36 @@ func (mr *MockAccountingRepositoryMockRecorder) GetLastOpeningBalance(ctx, accou
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastOpeningBalance", reflect.TypeOf((*MockAccountingRepository)(nil).GetLastOpeningBalance), ctx, accountNumber, date)
 }
 
+// GetLatestAccountDailyBalances mocks base method.
+func (m *MockAccountingRepository) GetLatestAccountDailyBalances(ctx context.Context, accountNumbers []string, beforeDate time.Time) ([]models.AccountBalanceDaily, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestAccountDailyBalances", ctx, accountNumbers, beforeDate)
+	ret0, _ := ret[0].([]models.AccountBalanceDaily)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestAccountDailyBalances indicates an expected call of GetLatestAccountDailyBalances.
+func (mr *MockAccountingRepositoryMockRecorder) GetLatestAccountDailyBalances(ctx, accountNumbers, beforeDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestAccountDailyBalances", reflect.TypeOf((*MockAccountingRepository)(nil).GetLatestAccountDailyBalances), ctx, accountNumbers, beforeDate)
+}
+
+// GetLedgerAccountMovements mocks base method.
+func (m *MockAccountingRepository) GetLedgerAccountMovements(ctx context.Context, entityCode string, startDate, endDate time.Time) ([]models.LedgerAccountMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedgerAccountMovements", ctx, entityCode, startDate, endDate)
+	ret0, _ := ret[0].([]models.LedgerAccountMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLedgerAccountMovements indicates an expected call of GetLedgerAccountMovements.
+func (mr *MockAccountingRepositoryMockRecorder) GetLedgerAccountMovements(ctx, entityCode, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerAccountMovements", reflect.TypeOf((*MockAccountingRepository)(nil).GetLedgerAccountMovements), ctx, entityCode, startDate, endDate)
+}
+
 // GetOneAccountBalanceDaily mocks base method.
 func (m *MockAccountingRepository) GetOneAccountBalanceDaily(ctx context.Context, accountNumber string, date time.Time) (models.AccountBalanceDaily, error) {
 	m.ctrl.T.Helper()
@@ -236,6 +475,21 @@ func (mr *MockAccountingRepositoryMockRecorder) GetOneSplitAccount(ctx, accountN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneSplitAccount", reflect.TypeOf((*MockAccountingRepository)(nil).GetOneSplitAccount), ctx, accountNumber)
 }
 
+// GetOpenSuspenseSplits mocks base method.
+func (m *MockAccountingRepository) GetOpenSuspenseSplits(ctx context.Context, accountNumber string, endDate time.Time, clearingOrderType string) ([]models.GetSubLedgerOut, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenSuspenseSplits", ctx, accountNumber, endDate, clearingOrderType)
+	ret0, _ := ret[0].([]models.GetSubLedgerOut)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenSuspenseSplits indicates an expected call of GetOpenSuspenseSplits.
+func (mr *MockAccountingRepositoryMockRecorder) GetOpenSuspenseSplits(ctx, accountNumber, endDate, clearingOrderType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenSuspenseSplits", reflect.TypeOf((*MockAccountingRepository)(nil).GetOpenSuspenseSplits), ctx, accountNumber, endDate, clearingOrderType)
+}
+
 // GetOpeningBalanceByDate mocks base method.
 func (m *MockAccountingRepository) GetOpeningBalanceByDate(ctx context.Context, accountNumber string, date time.Time) (decimal.Decimal, error) {
 	m.ctrl.T.Helper()
@@ -266,6 +520,66 @@ func (mr *MockAccountingRepositoryMockRecorder) GetOpeningBalanceFromAccountTria
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpeningBalanceFromAccountTrialBalance", reflect.TypeOf((*MockAccountingRepository)(nil).GetOpeningBalanceFromAccountTrialBalance), ctx, in)
 }
 
+// GetProfitLoss mocks base method.
+func (m *MockAccountingRepository) GetProfitLoss(ctx context.Context, opts models.ProfitLossFilterOptions) ([]models.ProfitLossPerSubCategoryOut, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfitLoss", ctx, opts)
+	ret0, _ := ret[0].([]models.ProfitLossPerSubCategoryOut)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfitLoss indicates an expected call of GetProfitLoss.
+func (mr *MockAccountingRepositoryMockRecorder) GetProfitLoss(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfitLoss", reflect.TypeOf((*MockAccountingRepository)(nil).GetProfitLoss), ctx, opts)
+}
+
+// GetRecurringJournalById mocks base method.
+func (m *MockAccountingRepository) GetRecurringJournalById(ctx context.Context, id int) (*models.RecurringJournal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringJournalById", ctx, id)
+	ret0, _ := ret[0].(*models.RecurringJournal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringJournalById indicates an expected call of GetRecurringJournalById.
+func (mr *MockAccountingRepositoryMockRecorder) GetRecurringJournalById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringJournalById", reflect.TypeOf((*MockAccountingRepository)(nil).GetRecurringJournalById), ctx, id)
+}
+
+// GetRecurringJournals mocks base method.
+func (m *MockAccountingRepository) GetRecurringJournals(ctx context.Context, opts models.RecurringJournalFilterOptions) ([]models.RecurringJournal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringJournals", ctx, opts)
+	ret0, _ := ret[0].([]models.RecurringJournal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringJournals indicates an expected call of GetRecurringJournals.
+func (mr *MockAccountingRepositoryMockRecorder) GetRecurringJournals(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringJournals", reflect.TypeOf((*MockAccountingRepository)(nil).GetRecurringJournals), ctx, opts)
+}
+
+// GetReversedJournalIds mocks base method.
+func (m *MockAccountingRepository) GetReversedJournalIds(ctx context.Context, journalIds []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReversedJournalIds", ctx, journalIds)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReversedJournalIds indicates an expected call of GetReversedJournalIds.
+func (mr *MockAccountingRepositoryMockRecorder) GetReversedJournalIds(ctx, journalIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReversedJournalIds", reflect.TypeOf((*MockAccountingRepository)(nil).GetReversedJournalIds), ctx, journalIds)
+}
+
 // GetSubLedger mocks base method.
 func (m *MockAccountingRepository) GetSubLedger(ctx context.Context, opts models.SubLedgerFilterOptions) ([]models.GetSubLedgerOut, error) {
 	m.ctrl.T.Helper()
@@ -355,6 +669,21 @@ func (mr *MockAccountingRepositoryMockRecorder) GetSubLedgerStream(ctx, opts any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubLedgerStream", reflect.TypeOf((*MockAccountingRepository)(nil).GetSubLedgerStream), ctx, opts)
 }
 
+// GetSuspenseClosingBalance mocks base method.
+func (m *MockAccountingRepository) GetSuspenseClosingBalance(ctx context.Context, date time.Time, subCategories []string) ([]models.AccountBalanceDaily, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuspenseClosingBalance", ctx, date, subCategories)
+	ret0, _ := ret[0].([]models.AccountBalanceDaily)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuspenseClosingBalance indicates an expected call of GetSuspenseClosingBalance.
+func (mr *MockAccountingRepositoryMockRecorder) GetSuspenseClosingBalance(ctx, date, subCategories any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuspenseClosingBalance", reflect.TypeOf((*MockAccountingRepository)(nil).GetSuspenseClosingBalance), ctx, date, subCategories)
+}
+
 // GetTransactionsToday mocks base method.
 func (m *MockAccountingRepository) GetTransactionsToday(ctx context.Context, transactionDate time.Time) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -432,6 +761,51 @@ func (mr *MockAccountingRepositoryMockRecorder) GetTrialBalanceV2(ctx, opts any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalanceV2", reflect.TypeOf((*MockAccountingRepository)(nil).GetTrialBalanceV2), ctx, opts)
 }
 
+// GetUnbalancedTransactions mocks base method.
+func (m *MockAccountingRepository) GetUnbalancedTransactions(ctx context.Context, entityCode string, startDate, endDate time.Time) ([]models.UnbalancedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnbalancedTransactions", ctx, entityCode, startDate, endDate)
+	ret0, _ := ret[0].([]models.UnbalancedTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnbalancedTransactions indicates an expected call of GetUnbalancedTransactions.
+func (mr *MockAccountingRepositoryMockRecorder) GetUnbalancedTransactions(ctx, entityCode, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnbalancedTransactions", reflect.TypeOf((*MockAccountingRepository)(nil).GetUnbalancedTransactions), ctx, entityCode, startDate, endDate)
+}
+
+// GetUploadJobById mocks base method.
+func (m *MockAccountingRepository) GetUploadJobById(ctx context.Context, id int) (*models.UploadJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUploadJobById", ctx, id)
+	ret0, _ := ret[0].(*models.UploadJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUploadJobById indicates an expected call of GetUploadJobById.
+func (mr *MockAccountingRepositoryMockRecorder) GetUploadJobById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUploadJobById", reflect.TypeOf((*MockAccountingRepository)(nil).GetUploadJobById), ctx, id)
+}
+
+// GetYearEndClosingBalance mocks base method.
+func (m *MockAccountingRepository) GetYearEndClosingBalance(ctx context.Context, entityCode, currency, closingOrderType string, startDate, endDate time.Time) ([]models.YearEndClosingBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetYearEndClosingBalance", ctx, entityCode, currency, closingOrderType, startDate, endDate)
+	ret0, _ := ret[0].([]models.YearEndClosingBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetYearEndClosingBalance indicates an expected call of GetYearEndClosingBalance.
+func (mr *MockAccountingRepositoryMockRecorder) GetYearEndClosingBalance(ctx, entityCode, currency, closingOrderType, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYearEndClosingBalance", reflect.TypeOf((*MockAccountingRepository)(nil).GetYearEndClosingBalance), ctx, entityCode, currency, closingOrderType, startDate, endDate)
+}
+
 // InsertAccountBalanceDaily mocks base method.
 func (m *MockAccountingRepository) InsertAccountBalanceDaily(ctx context.Context, in []models.AccountBalanceDaily) error {
 	m.ctrl.T.Helper()
@@ -460,6 +834,35 @@ func (mr *MockAccountingRepositoryMockRecorder) InsertAccountTrialBalance(ctx, i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccountTrialBalance", reflect.TypeOf((*MockAccountingRepository)(nil).InsertAccountTrialBalance), ctx, in)
 }
 
+// InsertBankStatement mocks base method.
+func (m *MockAccountingRepository) InsertBankStatement(ctx context.Context, in models.BankStatement) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBankStatement", ctx, in)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertBankStatement indicates an expected call of InsertBankStatement.
+func (mr *MockAccountingRepositoryMockRecorder) InsertBankStatement(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBankStatement", reflect.TypeOf((*MockAccountingRepository)(nil).InsertBankStatement), ctx, in)
+}
+
+// InsertBankStatementLines mocks base method.
+func (m *MockAccountingRepository) InsertBankStatementLines(ctx context.Context, in []models.BankStatementLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBankStatementLines", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertBankStatementLines indicates an expected call of InsertBankStatementLines.
+func (mr *MockAccountingRepositoryMockRecorder) InsertBankStatementLines(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBankStatementLines", reflect.TypeOf((*MockAccountingRepository)(nil).InsertBankStatementLines), ctx, in)
+}
+
 // InsertJournalDetail mocks base method.
 func (m *MockAccountingRepository) InsertJournalDetail(ctx context.Context, in []models.CreateJournalDetail) error {
 	m.ctrl.T.Helper()
@@ -474,6 +877,36 @@ func (mr *MockAccountingRepositoryMockRecorder) InsertJournalDetail(ctx, in any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertJournalDetail", reflect.TypeOf((*MockAccountingRepository)(nil).InsertJournalDetail), ctx, in)
 }
 
+// InsertJournalDraft mocks base method.
+func (m *MockAccountingRepository) InsertJournalDraft(ctx context.Context, in models.JournalDraft) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertJournalDraft", ctx, in)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertJournalDraft indicates an expected call of InsertJournalDraft.
+func (mr *MockAccountingRepositoryMockRecorder) InsertJournalDraft(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertJournalDraft", reflect.TypeOf((*MockAccountingRepository)(nil).InsertJournalDraft), ctx, in)
+}
+
+// InsertRecurringJournal mocks base method.
+func (m *MockAccountingRepository) InsertRecurringJournal(ctx context.Context, in models.RecurringJournal) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRecurringJournal", ctx, in)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertRecurringJournal indicates an expected call of InsertRecurringJournal.
+func (mr *MockAccountingRepositoryMockRecorder) InsertRecurringJournal(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRecurringJournal", reflect.TypeOf((*MockAccountingRepository)(nil).InsertRecurringJournal), ctx, in)
+}
+
 // InsertSplit mocks base method.
 func (m *MockAccountingRepository) InsertSplit(ctx context.Context, in []models.CreateSplit) error {
 	m.ctrl.T.Helper()
@@ -502,6 +935,20 @@ func (mr *MockAccountingRepositoryMockRecorder) InsertSplitAccount(ctx, in any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSplitAccount", reflect.TypeOf((*MockAccountingRepository)(nil).InsertSplitAccount), ctx, in)
 }
 
+// InsertSuspenseClearing mocks base method.
+func (m *MockAccountingRepository) InsertSuspenseClearing(ctx context.Context, in models.SuspenseClearing) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSuspenseClearing", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertSuspenseClearing indicates an expected call of InsertSuspenseClearing.
+func (mr *MockAccountingRepositoryMockRecorder) InsertSuspenseClearing(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSuspenseClearing", reflect.TypeOf((*MockAccountingRepository)(nil).InsertSuspenseClearing), ctx, in)
+}
+
 // InsertTransaction mocks base method.
 func (m *MockAccountingRepository) InsertTransaction(ctx context.Context, in []models.CreateTransaction) error {
 	m.ctrl.T.Helper()
@@ -516,6 +963,21 @@ func (mr *MockAccountingRepositoryMockRecorder) InsertTransaction(ctx, in any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTransaction", reflect.TypeOf((*MockAccountingRepository)(nil).InsertTransaction), ctx, in)
 }
 
+// InsertUploadJob mocks base method.
+func (m *MockAccountingRepository) InsertUploadJob(ctx context.Context, in models.UploadJob) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUploadJob", ctx, in)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertUploadJob indicates an expected call of InsertUploadJob.
+func (mr *MockAccountingRepositoryMockRecorder) InsertUploadJob(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUploadJob", reflect.TypeOf((*MockAccountingRepository)(nil).InsertUploadJob), ctx, in)
+}
+
 // ToggleForeignKeyChecks mocks base method.
 func (m *MockAccountingRepository) ToggleForeignKeyChecks(ctx context.Context, isEnable bool) error {
 	m.ctrl.T.Helper()
@@ -529,3 +991,59 @@ func (mr *MockAccountingRepositoryMockRecorder) ToggleForeignKeyChecks(ctx, isEn
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleForeignKeyChecks", reflect.TypeOf((*MockAccountingRepository)(nil).ToggleForeignKeyChecks), ctx, isEnable)
 }
+
+// UpdateBankStatementLineMatch mocks base method.
+func (m *MockAccountingRepository) UpdateBankStatementLineMatch(ctx context.Context, in models.BankStatementLine, fromStatus string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBankStatementLineMatch", ctx, in, fromStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBankStatementLineMatch indicates an expected call of UpdateBankStatementLineMatch.
+func (mr *MockAccountingRepositoryMockRecorder) UpdateBankStatementLineMatch(ctx, in, fromStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBankStatementLineMatch", reflect.TypeOf((*MockAccountingRepository)(nil).UpdateBankStatementLineMatch), ctx, in, fromStatus)
+}
+
+// UpdateJournalDraftStatus mocks base method.
+func (m *MockAccountingRepository) UpdateJournalDraftStatus(ctx context.Context, in models.JournalDraft, fromStatus string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJournalDraftStatus", ctx, in, fromStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJournalDraftStatus indicates an expected call of UpdateJournalDraftStatus.
+func (mr *MockAccountingRepositoryMockRecorder) UpdateJournalDraftStatus(ctx, in, fromStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJournalDraftStatus", reflect.TypeOf((*MockAccountingRepository)(nil).UpdateJournalDraftStatus), ctx, in, fromStatus)
+}
+
+// UpdateRecurringJournalSchedule mocks base method.
+func (m *MockAccountingRepository) UpdateRecurringJournalSchedule(ctx context.Context, in models.RecurringJournal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurringJournalSchedule", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecurringJournalSchedule indicates an expected call of UpdateRecurringJournalSchedule.
+func (mr *MockAccountingRepositoryMockRecorder) UpdateRecurringJournalSchedule(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurringJournalSchedule", reflect.TypeOf((*MockAccountingRepository)(nil).UpdateRecurringJournalSchedule), ctx, in)
+}
+
+// UpdateUploadJob mocks base method.
+func (m *MockAccountingRepository) UpdateUploadJob(ctx context.Context, in models.UploadJob, fromStatus string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUploadJob", ctx, in, fromStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUploadJob indicates an expected call of UpdateUploadJob.
+func (mr *MockAccountingRepositoryMockRecorder) UpdateUploadJob(ctx, in, fromStatus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUploadJob", reflect.TypeOf((*MockAccountingRepository)(nil).UpdateUploadJob), ctx, in, fromStatus)
+}
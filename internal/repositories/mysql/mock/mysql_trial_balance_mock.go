@@ -69,6 +69,20 @@ func (mr *MockTrialBalanceRepositoryMockRecorder) Close(ctx, in any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockTrialBalanceRepository)(nil).Close), ctx, in)
 }
 
+// CloseByFiscalYear mocks base method.
+func (m *MockTrialBalanceRepository) CloseByFiscalYear(ctx context.Context, fiscalYear, entityCode, closedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseByFiscalYear", ctx, fiscalYear, entityCode, closedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseByFiscalYear indicates an expected call of CloseByFiscalYear.
+func (mr *MockTrialBalanceRepositoryMockRecorder) CloseByFiscalYear(ctx, fiscalYear, entityCode, closedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseByFiscalYear", reflect.TypeOf((*MockTrialBalanceRepository)(nil).CloseByFiscalYear), ctx, fiscalYear, entityCode, closedBy)
+}
+
 // GetByPeriod mocks base method.
 func (m *MockTrialBalanceRepository) GetByPeriod(ctx context.Context, period, entity_code string) (*models.TrialBalancePeriod, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +128,50 @@ func (mr *MockTrialBalanceRepositoryMockRecorder) GetFirstPeriodByStatus(ctx, st
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirstPeriodByStatus", reflect.TypeOf((*MockTrialBalanceRepository)(nil).GetFirstPeriodByStatus), ctx, status)
 }
 
+// GetFirstPeriodByStatusAndEntity mocks base method.
+func (m *MockTrialBalanceRepository) GetFirstPeriodByStatusAndEntity(ctx context.Context, status, entityCode string) (*models.TrialBalancePeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirstPeriodByStatusAndEntity", ctx, status, entityCode)
+	ret0, _ := ret[0].(*models.TrialBalancePeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirstPeriodByStatusAndEntity indicates an expected call of GetFirstPeriodByStatusAndEntity.
+func (mr *MockTrialBalanceRepositoryMockRecorder) GetFirstPeriodByStatusAndEntity(ctx, status, entityCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirstPeriodByStatusAndEntity", reflect.TypeOf((*MockTrialBalanceRepository)(nil).GetFirstPeriodByStatusAndEntity), ctx, status, entityCode)
+}
+
+// GetYearEndClosing mocks base method.
+func (m *MockTrialBalanceRepository) GetYearEndClosing(ctx context.Context, fiscalYear, entityCode string) (*models.YearEndClosing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetYearEndClosing", ctx, fiscalYear, entityCode)
+	ret0, _ := ret[0].(*models.YearEndClosing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetYearEndClosing indicates an expected call of GetYearEndClosing.
+func (mr *MockTrialBalanceRepositoryMockRecorder) GetYearEndClosing(ctx, fiscalYear, entityCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYearEndClosing", reflect.TypeOf((*MockTrialBalanceRepository)(nil).GetYearEndClosing), ctx, fiscalYear, entityCode)
+}
+
+// InsertYearEndClosing mocks base method.
+func (m *MockTrialBalanceRepository) InsertYearEndClosing(ctx context.Context, in models.YearEndClosing) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertYearEndClosing", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertYearEndClosing indicates an expected call of InsertYearEndClosing.
+func (mr *MockTrialBalanceRepositoryMockRecorder) InsertYearEndClosing(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertYearEndClosing", reflect.TypeOf((*MockTrialBalanceRepository)(nil).InsertYearEndClosing), ctx, in)
+}
+
 // UpdateTrialBalanceAdjustment mocks base method.
 func (m *MockTrialBalanceRepository) UpdateTrialBalanceAdjustment(ctx context.Context, in models.CloseTrialBalanceRequest) error {
 	m.ctrl.T.Helper()
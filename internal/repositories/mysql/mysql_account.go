@@ -16,24 +16,40 @@ type AccountRepository interface {
 	CreateLenderAccount(ctx context.Context, in models.CreateLenderAccount) (err error)
 	Update(ctx context.Context, in models.UpdateAccount) (err error)
 	UpdateEntity(ctx context.Context, in models.UpdateAccountEntity) (err error)
+	UpdateStatus(ctx context.Context, in models.UpdateAccountStatus) (err error)
 	UpdateLegacyId(ctx context.Context, in models.UpdateLegacyId) (err error)
 	UpdateAltId(ctx context.Context, in models.UpdateAltId) (err error)
 	UpdateBySubCategory(ctx context.Context, in models.UpdateBySubCategory) (err error)
 	GetOneByAccountNumber(ctx context.Context, accountNumber string) (out models.GetAccountOut, err error)
 	GetOneByLegacyID(ctx context.Context, legacyID string) (out models.GetAccountOut, err error)
+	GetByAccountNumbers(ctx context.Context, accountNumbers []string) (out []models.GetAccountOut, err error)
 	GetAccountList(ctx context.Context, opts models.AccountFilterOptions) ([]models.GetAccountOut, error)
 	GetAccountListCount(ctx context.Context, opts models.AccountFilterOptions) (total int, err error)
 	CheckExistByParam(ctx context.Context, param models.AccountFilterOptions) (exist bool, err error)
 	BulkInsertAccount(ctx context.Context, in []models.CreateAccount) (err error)
 	BulkInsertAcctAccount(ctx context.Context, in []models.CreateAccount) (err error)
 	GetAllAccountNumbersByParam(ctx context.Context, params models.GetAllAccountNumbersByParamIn) (out []models.GetAllAccountNumbersByParamOut, err error)
-	GetLenderAccountByCIHAccountNumber(ctx context.Context, accountNumber string) (out models.AccountLender, err error)
 	CreateLoanAccount(ctx context.Context, in models.CreateLoanAccount) (err error)
 	CheckLegacyIdIsExist(ctx context.Context, legacyId string) (exist bool, err error)
 	CheckAccountNumberIsExist(ctx context.Context, accountNumber string) (out *models.CheckAccountNumberIsExist, err error)
 	GetAccountNumberByLegacyId(ctx context.Context, t24AccountNumber string) (accountNumber string, err error)
-	GetLoanAdvanceAccountByLoanAccount(ctx context.Context, loanAccountNumber string) (out models.AccountLoan, err error)
 	GetAllAccountNumber(ctx context.Context, entities []string, subCategories *[]models.SubCategory) <-chan models.StreamResult[models.GetAccountOut]
+
+	// account change log
+	InsertChangeLogs(ctx context.Context, in []models.CreateAccountChangeLog) (err error)
+	GetChangeLogs(ctx context.Context, opts models.AccountChangeLogFilterOptions) (out []models.AccountChangeLog, err error)
+
+	// account relationship rule
+	InsertRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (id int, err error)
+	GetRelationshipRuleById(ctx context.Context, id int) (out *models.AccountRelationshipRule, err error)
+	GetRelationshipRules(ctx context.Context, opts models.AccountRelationshipRuleFilterOptions) (out []models.AccountRelationshipRule, err error)
+	UpdateRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (err error)
+	DeleteRelationshipRule(ctx context.Context, id int) (err error)
+
+	// account hierarchy
+	InsertParent(ctx context.Context, in models.AccountRelation) (err error)
+	DeleteParent(ctx context.Context, accountNumber string) (err error)
+	GetRelations(ctx context.Context, opts models.AccountRelationFilterOptions) (out []models.AccountRelation, err error)
 }
 
 type accountRepository sqlRepo
@@ -125,11 +141,18 @@ func (ar *accountRepository) UpdateEntity(ctx context.Context, in models.UpdateA
 	}()
 
 	db := ar.r.extractTx(ctx)
-	args, err := getFieldValues(in)
-	if err != nil {
-		return
-	}
-	_, err = db.ExecContext(ctx, queryUpdateAccountEntity, args...)
+	_, err = db.ExecContext(ctx, queryUpdateAccountEntity, in.EntityCode, in.AccountNumber)
+
+	return
+}
+
+func (ar *accountRepository) UpdateStatus(ctx context.Context, in models.UpdateAccountStatus) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	_, err = db.ExecContext(ctx, queryUpdateAccountStatus, in.Status, in.AccountNumber)
 
 	return
 }
@@ -234,6 +257,68 @@ func (ar *accountRepository) GetOneByLegacyID(ctx context.Context, legacyID stri
 	return out, err
 }
 
+// GetByAccountNumbers returns the accounts of the account numbers in one query, a missing account is not returned.
+func (ar *accountRepository) GetByAccountNumbers(ctx context.Context, accountNumbers []string) (out []models.GetAccountOut, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	if len(accountNumbers) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := buildGetAccountsByAccountNumbersQuery(accountNumbers)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.GetAccountOut
+		if err = rows.Scan(
+			&v.AccountNumber,
+			&v.AccountName,
+			&v.OwnerID,
+			&v.CategoryCode,
+			&v.CategoryName,
+			&v.CoaTypeCode,
+			&v.CoaTypeName,
+			&v.SubCategoryCode,
+			&v.SubCategoryName,
+			&v.EntityCode,
+			&v.EntityName,
+			&v.ProductTypeCode,
+			&v.ProductTypeName,
+			&v.Currency,
+			&v.Status,
+			&v.AltID,
+			&v.CreatedAt,
+			&v.UpdatedAt,
+			&v.LegacyId,
+			&v.Metadata,
+			&v.AccountType,
+		); err != nil {
+			err = databaseError(err)
+			return
+		}
+		out = append(out, v)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return out, nil
+}
+
 func (ar *accountRepository) GetAccountList(ctx context.Context, opts models.AccountFilterOptions) ([]models.GetAccountOut, error) {
 	var (
 		start = atime.Now()
@@ -491,25 +576,6 @@ func (ar *accountRepository) GetAllAccountNumbersByParam(ctx context.Context, pa
 	return result, nil
 }
 
-func (ar *accountRepository) GetLenderAccountByCIHAccountNumber(ctx context.Context, accountNumber string) (out models.AccountLender, err error) {
-	defer func() {
-		logSQL(ctx, err)
-	}()
-
-	db := ar.r.extractTx(ctx)
-
-	err = db.QueryRowContext(ctx, queryGetLenderAccountByCIHAccountNumber, accountNumber).Scan(
-		&out.CIHAccountNumber,
-		&out.InvestedAccountNumber,
-		&out.ReceivablesAccountNumber,
-	)
-	if err != nil {
-		return
-	}
-
-	return out, err
-}
-
 func (ar *accountRepository) CreateLoanAccount(ctx context.Context, in models.CreateLoanAccount) (err error) {
 	defer func() {
 		logSQL(ctx, err)
@@ -634,24 +700,6 @@ func (ar *accountRepository) UpdateBySubCategory(ctx context.Context, in models.
 	return
 }
 
-func (ar *accountRepository) GetLoanAdvanceAccountByLoanAccount(ctx context.Context, loanAccountNumber string) (out models.AccountLoan, err error) {
-	defer func() {
-		logSQL(ctx, err)
-	}()
-
-	db := ar.r.extractTx(ctx)
-
-	err = db.QueryRowContext(ctx, queryGetLoanAdvanceAccountByLoanAccount, loanAccountNumber).Scan(
-		&out.LoanAccountNumber,
-		&out.LoanAdvancePaymentAccountNumber,
-	)
-	if err != nil {
-		return
-	}
-
-	return out, err
-}
-
 func (ar *accountRepository) GetAllAccountNumber(ctx context.Context, entities []string, subCategories *[]models.SubCategory) <-chan models.StreamResult[models.GetAccountOut] {
 	db := ar.r.extractTx(ctx)
 	ch := make(chan models.StreamResult[models.GetAccountOut], 64)
@@ -687,6 +735,7 @@ func (ar *accountRepository) GetAllAccountNumber(ctx context.Context, entities [
 						&v.EntityCode,
 						&v.CategoryCode,
 						&v.SubCategoryCode,
+						&v.Currency,
 					); err != nil {
 						ch <- models.StreamResult[models.GetAccountOut]{
 							Err: fmt.Errorf("scan error for subCategoryCode %s: %w", subCategoryCode, err),
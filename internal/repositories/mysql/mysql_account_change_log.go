@@ -0,0 +1,82 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+)
+
+func (ar *accountRepository) InsertChangeLogs(ctx context.Context, in []models.CreateAccountChangeLog) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	if len(in) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, 0, len(in))
+	valueArgs := make([]interface{}, 0, len(in)*6)
+	for _, v := range in {
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?)")
+		valueArgs = append(valueArgs,
+			v.AccountNumber,
+			v.Field,
+			v.BeforeValue,
+			v.AfterValue,
+			v.ChangedBy,
+			v.Reason,
+		)
+	}
+
+	db := ar.r.extractTx(ctx)
+	query := fmt.Sprintf(queryBulkInsertAccountChangeLog, strings.Join(valueStrings, ","))
+	if _, err = db.ExecContext(ctx, query, valueArgs...); err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return nil
+}
+
+func (ar *accountRepository) GetChangeLogs(ctx context.Context, opts models.AccountChangeLogFilterOptions) (out []models.AccountChangeLog, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetAccountChangeLogsQuery(opts)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.AccountChangeLog
+		if err = rows.Scan(
+			&v.ID,
+			&v.AccountNumber,
+			&v.Field,
+			&v.BeforeValue,
+			&v.AfterValue,
+			&v.ChangedBy,
+			&v.Reason,
+			&v.CreatedAt,
+		); err != nil {
+			err = databaseError(err)
+			return
+		}
+		out = append(out, v)
+	}
+
+	return out, nil
+}
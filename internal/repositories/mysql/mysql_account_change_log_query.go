@@ -0,0 +1,43 @@
+package mysql
+
+import (
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// query to acct_account_change_log table, the table is append-only
+var (
+	queryBulkInsertAccountChangeLog = `
+		INSERT INTO acct_account_change_log(
+			account_number,
+			field,
+			before_value,
+			after_value,
+			changed_by,
+			reason
+		) VALUES %s`
+)
+
+func buildGetAccountChangeLogsQuery(opts models.AccountChangeLogFilterOptions) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(
+		`id`,
+		`account_number`,
+		`field`,
+		`coalesce(before_value, '') before_value`,
+		`coalesce(after_value, '') after_value`,
+		`changed_by`,
+		`coalesce(reason, '') reason`,
+		`created_at`,
+	).
+		From("acct_account_change_log").
+		Where(sq.Eq{`account_number`: opts.AccountNumber}).
+		OrderBy(`created_at DESC`, `id DESC`)
+
+	if opts.Field != "" {
+		query = query.Where(sq.Eq{`field`: opts.Field})
+	}
+
+	return query.ToSql()
+}
@@ -0,0 +1,150 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountTestSuite) TestRepository_InsertChangeLogs() {
+	req := []models.CreateAccountChangeLog{
+		{
+			AccountNumber: "22200100000001",
+			Field:         models.AccountChangeLogFieldName,
+			BeforeValue:   "Lender Yang Baik",
+			AfterValue:    "Lender Yang Sangat Baik",
+			ChangedBy:     "tono@amartha.com",
+			Reason:        "customer name correction",
+		},
+		{
+			AccountNumber: "22200100000001",
+			Field:         models.AccountChangeLogFieldAltID,
+			BeforeValue:   "",
+			AfterValue:    "534534534555353523523423423",
+			ChangedBy:     "tono@amartha.com",
+			Reason:        "customer name correction",
+		},
+	}
+	query := fmt.Sprintf(queryBulkInsertAccountChangeLog, "(?, ?, ?, ?, ?, ?),(?, ?, ?, ?, ?, ?)")
+
+	testCases := []struct {
+		name       string
+		req        []models.CreateAccountChangeLog
+		setupMocks func()
+		wantErr    bool
+	}{
+		{
+			name: "success case",
+			req:  req,
+			setupMocks: func() {
+				suite.mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(
+						"22200100000001", "name", "Lender Yang Baik", "Lender Yang Sangat Baik", "tono@amartha.com", "customer name correction",
+						"22200100000001", "altId", "", "534534534555353523523423423", "tono@amartha.com", "customer name correction",
+					).
+					WillReturnResult(sqlmock.NewResult(2, 2))
+			},
+		},
+		{
+			name:       "success case - empty change logs",
+			setupMocks: func() {},
+		},
+		{
+			name: "error case - database error",
+			req:  req,
+			setupMocks: func() {
+				suite.mock.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := suite.repo.InsertChangeLogs(context.TODO(), tt.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountTestSuite) TestRepository_GetChangeLogs() {
+	opts := models.AccountChangeLogFilterOptions{
+		AccountNumber: "22200100000001",
+		Field:         models.AccountChangeLogFieldName,
+	}
+	query, _, _ := buildGetAccountChangeLogsQuery(opts)
+	columns := []string{"id", "account_number", "field", "before_value", "after_value", "changed_by", "reason", "created_at"}
+	createdAt := time.Date(2024, 4, 30, 13, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		setupMocks func()
+		want       []models.AccountChangeLog
+		wantErr    bool
+	}{
+		{
+			name: "success case",
+			setupMocks: func() {
+				suite.mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("22200100000001", "name").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "22200100000001", "name", "Lender Yang Baik", "Lender Yang Sangat Baik", "tono@amartha.com", "customer name correction", createdAt))
+			},
+			want: []models.AccountChangeLog{{
+				ID:            1,
+				AccountNumber: "22200100000001",
+				Field:         "name",
+				BeforeValue:   "Lender Yang Baik",
+				AfterValue:    "Lender Yang Sangat Baik",
+				ChangedBy:     "tono@amartha.com",
+				Reason:        "customer name correction",
+				CreatedAt:     createdAt,
+			}},
+		},
+		{
+			name: "error case - database error",
+			setupMocks: func() {
+				suite.mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - row scan",
+			setupMocks: func() {
+				suite.mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("invalid", "22200100000001", "name", "", "", "", "", createdAt))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			got, err := suite.repo.GetChangeLogs(context.TODO(), opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+)
+
+func (ar *accountRepository) InsertParent(ctx context.Context, in models.AccountRelation) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	if _, err = db.ExecContext(ctx, queryInsertAccountParent, in.AccountNumber, in.ParentAccountNumber); err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return nil
+}
+
+func (ar *accountRepository) DeleteParent(ctx context.Context, accountNumber string) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryDeleteAccountParent, accountNumber)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	if affectedRows == 0 {
+		err = databaseError(models.ErrNoRowsAffected)
+		return
+	}
+
+	return nil
+}
+
+// GetRelations returns the parent & child accounts of every relation, the lender & loan accounts included.
+func (ar *accountRepository) GetRelations(ctx context.Context, opts models.AccountRelationFilterOptions) (out []models.AccountRelation, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetAccountRelationsQuery(opts)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.AccountRelation
+		if err = rows.Scan(
+			&v.ParentAccountNumber,
+			&v.AccountNumber,
+			&v.Relation,
+		); err != nil {
+			err = databaseError(err)
+			return
+		}
+		out = append(out, v)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// query to acct_account_hierarchy table, an account has at most one parent account
+var (
+	queryInsertAccountParent = `
+		INSERT INTO acct_account_hierarchy(
+			account_number, parent_account_number
+		)
+		VALUES(
+			?, ?
+		);`
+
+	queryDeleteAccountParent = `DELETE FROM acct_account_hierarchy WHERE account_number = ?;`
+)
+
+// accountRelationTables are the parent & child accounts of every relation, the lender & loan accounts
+// written by the account relationship rules are children of the cih & loan account.
+// a lender or loan account without the child account of the relation has an empty account number.
+var accountRelationTables = []struct {
+	relation      string
+	table         string
+	parentColumn  string
+	accountColumn string
+}{
+	{models.AccountRelationChild, "acct_account_hierarchy", "parent_account_number", "account_number"},
+	{models.AccountRelationInvested, "acct_lender_account", "cih_account_number", "invested_account_number"},
+	{models.AccountRelationReceivables, "acct_lender_account", "cih_account_number", "receivables_account_number"},
+	{models.AccountRelationLoanAdvancePayment, "acct_loan_account", "loan_account_number", "loan_advance_payment_account_number"},
+}
+
+// buildGetAccountRelationsQuery unions the tables of the relations, the filters are applied to every table
+// so the indexes of the account numbers are used instead of scanning the union.
+func buildGetAccountRelationsQuery(opts models.AccountRelationFilterOptions) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+
+	branches := []string{}
+	for _, v := range accountRelationTables {
+		if len(opts.Relations) > 0 && !slices.Contains(opts.Relations, v.relation) {
+			continue
+		}
+
+		branch := psql.Select(
+			v.parentColumn+` parent_account_number`,
+			`coalesce(`+v.accountColumn+`, '') account_number`,
+			`'`+v.relation+`' relation`,
+		).From(v.table)
+		if len(opts.ParentAccountNumbers) > 0 {
+			branch = branch.Where(sq.Eq{v.parentColumn: opts.ParentAccountNumbers})
+		}
+		if len(opts.AccountNumbers) > 0 {
+			branch = branch.Where(sq.Eq{v.accountColumn: opts.AccountNumbers})
+		}
+		if !opts.IncludeUnlinked {
+			branch = branch.Where(sq.NotEq{v.accountColumn: ""})
+		}
+
+		branchSql, branchArgs, errBranch := branch.ToSql()
+		if errBranch != nil {
+			return "", nil, errBranch
+		}
+		branches = append(branches, branchSql)
+		args = append(args, branchArgs...)
+	}
+	if len(branches) == 0 {
+		return "", nil, fmt.Errorf("relations %v are not supported", opts.Relations)
+	}
+
+	sql, _, err = psql.Select(
+		`ar.parent_account_number`,
+		`ar.account_number`,
+		`ar.relation`,
+	).
+		From("("+strings.Join(branches, " UNION ALL ")+") ar").
+		OrderBy(`ar.parent_account_number ASC`, `ar.account_number ASC`).
+		ToSql()
+
+	return sql, args, err
+}
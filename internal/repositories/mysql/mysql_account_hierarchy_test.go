@@ -0,0 +1,174 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountTestSuite) TestRepository_InsertParent() {
+	in := models.AccountRelation{
+		ParentAccountNumber: "211001000000001",
+		AccountNumber:       "211001000381110",
+		Relation:            models.AccountRelationChild,
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertAccountParent)).
+					WithArgs("211001000381110", "211001000000001").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertAccountParent)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.InsertParent(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountTestSuite) TestRepository_DeleteParent() {
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryDeleteAccountParent)).
+					WithArgs("211001000381110").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error no rows affected",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryDeleteAccountParent)).
+					WithArgs("211001000381110").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryDeleteAccountParent)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.DeleteParent(context.TODO(), "211001000381110")
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountTestSuite) TestRepository_GetRelations() {
+	opts := models.AccountRelationFilterOptions{
+		ParentAccountNumbers: []string{"211001000381110"},
+		Relations:            []string{models.AccountRelationInvested, models.AccountRelationReceivables},
+	}
+	query, _, _ := buildGetAccountRelationsQuery(opts)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("211001000381110", "", "211001000381110", "").
+					WillReturnRows(sqlmock.NewRows([]string{"parent_account_number", "account_number", "relation"}).
+						AddRow("211001000381110", "142001000000001", "receivables").
+						AddRow("211001000381110", "212001000000001", "invested"))
+			},
+			wantLen: 2,
+			wantErr: false,
+		},
+		{
+			name: "error scan row",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetRelations(context.TODO(), opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -58,6 +58,13 @@ var (
 		updated_at = CURRENT_TIMESTAMP(6)
 	WHERE account_number = ?;`
 
+	queryUpdateAccountStatus = `
+	UPDATE acct_account
+	SET
+		status = ?,
+		updated_at = CURRENT_TIMESTAMP(6)
+	WHERE account_number = ?;`
+
 	queryAccountUpdateLegacyId = `
 	UPDATE acct_account
 	SET
@@ -159,13 +166,6 @@ var (
 	JOIN acct_sub_category asc2 ON asc2.code = aa.sub_category_code
 	WHERE aa.owner_id = ?`
 
-	queryGetLenderAccountByCIHAccountNumber = `
-	SELECT acl.cih_account_number, 
-		coalesce(acl.invested_account_number, ""),
-		coalesce(acl.receivables_account_number, "")
-	FROM acct_lender_account acl
-	WHERE acl.cih_account_number = ?;`
-
 	queryCreateLoanAccount = `
 		INSERT INTO acct_loan_account(
 			loan_account_number, loan_advance_payment_account_number
@@ -192,18 +192,13 @@ var (
 	FROM acct_account 
 	WHERE (cast(legacy_id->>"$.t24AccountNumber" as char(255))) = ?;`
 
-	queryGetLoanAdvanceAccountByLoanAccount = `
-	SELECT ala.loan_account_number, 
-		coalesce(ala.loan_advance_payment_account_number, "")
-	FROM acct_loan_account ala
-	WHERE ala.loan_account_number = ?;`
-
 	queryGetAllAccountNumber = `
 	SELECT 
 		aa.account_number,
 		aa.entity_code,
 		aa.category_code,
-		aa.sub_category_code
+		aa.sub_category_code,
+		coalesce(aa.currency, "") currency
 		FROM acct_account aa 
 		WHERE aa.sub_category_code = ? AND aa.entity_code IN (%s)`
 )
@@ -228,6 +223,51 @@ func buildUpdateAccountQuery(input models.UpdateAccount) (sql string, args []int
 		query = query.Set("legacy_id", input.LegacyId)
 	}
 
+	if input.EntityCode != "" {
+		query = query.Set("entity_code", input.EntityCode)
+	}
+
+	if input.Metadata != nil {
+		query = query.Set("metadata", input.Metadata)
+	}
+
+	return query.ToSql()
+}
+
+// buildGetAccountsByAccountNumbersQuery returns the same columns as queryAccountByNumber of every account number.
+func buildGetAccountsByAccountNumbersQuery(accountNumbers []string) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(
+		`aa.account_number`,
+		`coalesce(aa.name,"") account_name`,
+		`coalesce(aa.owner_id,"") owner_id`,
+		`coalesce(aa.category_code,"") category_code`,
+		`coalesce(ac.name,"") category_name`,
+		`coalesce(act.code,"") coa_type_code`,
+		`coalesce(act.coa_type_name,"") coa_type_name`,
+		`coalesce(aa.sub_category_code,"") sub_category_code`,
+		`coalesce(asuc.name,"") sub_category_name`,
+		`coalesce(aa.entity_code,"") entity_code`,
+		`coalesce(ae.name,"") entity_name`,
+		`coalesce(aa.product_type_code, "") product_type_code`,
+		`coalesce(apt.name, "") product_type_name`,
+		`coalesce(aa.currency,"") currency`,
+		`coalesce(aa.status,"") status`,
+		`coalesce(aa.alt_id, "") alt_id`,
+		`aa.created_at`,
+		`aa.updated_at`,
+		`coalesce(aa.legacy_id, "{}") legacy_id`,
+		`coalesce(aa.metadata, "{}") metadata`,
+		`coalesce(asuc.account_type, "") account_type`,
+	).
+		From("acct_account aa").
+		LeftJoin("acct_category ac ON aa.category_code = ac.code").
+		LeftJoin("acct_coa_type act ON ac.coa_type_code = act.code").
+		LeftJoin("acct_sub_category asuc ON aa.sub_category_code = asuc.code").
+		LeftJoin("acct_entity ae ON aa.entity_code = ae.code").
+		LeftJoin("acct_product_type apt on apt.code = aa.product_type_code").
+		Where(sq.Eq{`aa.account_number`: accountNumbers})
+
 	return query.ToSql()
 }
 
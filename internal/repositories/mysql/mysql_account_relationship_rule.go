@@ -0,0 +1,166 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+)
+
+func (ar *accountRepository) InsertRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (id int, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryInsertAccountRelationshipRule,
+		in.SourceSubCategoryCode,
+		in.SourceAccountNumber,
+		in.TargetSubCategoryCode,
+		in.TargetAccountNumber,
+		in.Relation,
+		in.Cardinality,
+		in.NameTemplate,
+		in.Status,
+	)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	lastInsertId, err := res.LastInsertId()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return int(lastInsertId), nil
+}
+
+func (ar *accountRepository) GetRelationshipRuleById(ctx context.Context, id int) (out *models.AccountRelationshipRule, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetAccountRelationshipRuleByIdQuery(id)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rule, err := scanAccountRelationshipRule(db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == models.ErrNoRows {
+			err = nil
+			return nil, nil
+		}
+		err = databaseError(err)
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (ar *accountRepository) GetRelationshipRules(ctx context.Context, opts models.AccountRelationshipRuleFilterOptions) (out []models.AccountRelationshipRule, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetAccountRelationshipRulesQuery(opts)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rule, errScan := scanAccountRelationshipRule(rows)
+		if errScan != nil {
+			err = databaseError(errScan)
+			return
+		}
+		out = append(out, rule)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
+
+func (ar *accountRepository) UpdateRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	if _, err = db.ExecContext(ctx, queryUpdateAccountRelationshipRule,
+		in.TargetSubCategoryCode,
+		in.TargetAccountNumber,
+		in.Cardinality,
+		in.NameTemplate,
+		in.Status,
+		in.ID,
+	); err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return nil
+}
+
+func (ar *accountRepository) DeleteRelationshipRule(ctx context.Context, id int) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryDeleteAccountRelationshipRule, id)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	if affectedRows == 0 {
+		err = databaseError(models.ErrNoRowsAffected)
+		return
+	}
+
+	return nil
+}
+
+type accountRelationshipRuleScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAccountRelationshipRule(row accountRelationshipRuleScanner) (out models.AccountRelationshipRule, err error) {
+	err = row.Scan(
+		&out.ID,
+		&out.SourceSubCategoryCode,
+		&out.SourceAccountNumber,
+		&out.TargetSubCategoryCode,
+		&out.TargetAccountNumber,
+		&out.Relation,
+		&out.Cardinality,
+		&out.NameTemplate,
+		&out.Status,
+		&out.CreatedAt,
+		&out.UpdatedAt,
+	)
+
+	return
+}
@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// query to acct_account_relationship_rule table
+var (
+	queryInsertAccountRelationshipRule = `
+		INSERT INTO acct_account_relationship_rule(
+			source_sub_category_code,
+			source_account_number,
+			target_sub_category_code,
+			target_account_number,
+			relation,
+			cardinality,
+			name_template,
+			status
+		) VALUES (?, NULLIF(?, ''), ?, NULLIF(?, ''), ?, ?, ?, ?)`
+
+	queryUpdateAccountRelationshipRule = `
+		UPDATE
+			acct_account_relationship_rule
+		SET
+			target_sub_category_code = ?,
+			target_account_number = NULLIF(?, ''),
+			cardinality = ?,
+			name_template = ?,
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP(6)
+		WHERE
+			id = ?`
+
+	queryDeleteAccountRelationshipRule = `
+		DELETE FROM acct_account_relationship_rule WHERE id = ?`
+)
+
+var accountRelationshipRuleColumns = []string{
+	`id`,
+	`source_sub_category_code`,
+	`coalesce(source_account_number, '') source_account_number`,
+	`target_sub_category_code`,
+	`coalesce(target_account_number, '') target_account_number`,
+	`relation`,
+	`cardinality`,
+	`coalesce(name_template, '') name_template`,
+	`status`,
+	`created_at`,
+	`updated_at`,
+}
+
+func buildGetAccountRelationshipRuleByIdQuery(id int) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(accountRelationshipRuleColumns...).
+		From("acct_account_relationship_rule").
+		Where(sq.Eq{`id`: id})
+
+	return query.ToSql()
+}
+
+func buildGetAccountRelationshipRulesQuery(opts models.AccountRelationshipRuleFilterOptions) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(accountRelationshipRuleColumns...).From("acct_account_relationship_rule")
+
+	if opts.SourceSubCategoryCode != "" {
+		query = query.Where(sq.Eq{`source_sub_category_code`: opts.SourceSubCategoryCode})
+	}
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{`status`: opts.Status})
+	}
+
+	query = query.OrderBy(`id ASC`)
+
+	return query.ToSql()
+}
@@ -0,0 +1,292 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountTestSuite) TestRepository_InsertRelationshipRule() {
+	in := models.AccountRelationshipRule{
+		SourceSubCategoryCode: "21101",
+		TargetSubCategoryCode: "21201",
+		Relation:              models.AccountRelationInvested,
+		Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+		NameTemplate:          "{name} - Invested",
+		Status:                models.StatusActive,
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantId  int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertAccountRelationshipRule)).
+					WithArgs("21101", "", "21201", "", "invested", "oneToOne", "{name} - Invested", "active").
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			wantId:  3,
+			wantErr: false,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertAccountRelationshipRule)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			id, err := suite.repo.InsertRelationshipRule(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantId, id)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountTestSuite) TestRepository_GetRelationshipRuleById() {
+	query, _, _ := buildGetAccountRelationshipRuleByIdQuery(1)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				now := time.Now()
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(accountRelationshipRuleColumns).
+						AddRow(1, "21101", "", "21201", "", "invested", "oneToOne", "", "active", now, now))
+			},
+			wantErr: false,
+		},
+		{
+			name: "success not found",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnError(models.ErrNoRows)
+			},
+			wantNil: true,
+			wantErr: false,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnError(assert.AnError)
+			},
+			wantNil: true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetRelationshipRuleById(context.TODO(), 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNil, out == nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountTestSuite) TestRepository_GetRelationshipRules() {
+	opts := models.AccountRelationshipRuleFilterOptions{
+		SourceSubCategoryCode: "21102",
+		Status:                models.StatusActive,
+	}
+	query, _, _ := buildGetAccountRelationshipRulesQuery(opts)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				now := time.Now()
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("21102", "active").
+					WillReturnRows(sqlmock.NewRows(accountRelationshipRuleColumns).
+						AddRow(1, "21102", "", "21202", "", "invested", "oneToOne", "", "active", now, now).
+						AddRow(2, "21102", "", "14201", "", "receivables", "manyToOne", "{name} - Receivables", "active", now, now))
+			},
+			wantLen: 2,
+			wantErr: false,
+		},
+		{
+			name: "error scan row",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetRelationshipRules(context.TODO(), opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountTestSuite) TestRepository_UpdateRelationshipRule() {
+	in := models.AccountRelationshipRule{
+		ID:                    1,
+		TargetSubCategoryCode: "21201",
+		Cardinality:           models.AccountRelationshipCardinalityManyToOne,
+		Status:                "inactive",
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateAccountRelationshipRule)).
+					WithArgs("21201", "", "manyToOne", "", "inactive", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateAccountRelationshipRule)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.UpdateRelationshipRule(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountTestSuite) TestRepository_DeleteRelationshipRule() {
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryDeleteAccountRelationshipRule)).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error no rows affected",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryDeleteAccountRelationshipRule)).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryDeleteAccountRelationshipRule)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.DeleteRelationshipRule(context.TODO(), 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
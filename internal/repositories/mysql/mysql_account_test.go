@@ -145,6 +145,22 @@ func (suite *accountTestSuite) TestRepository_Update() {
 			},
 			wantErr: false,
 		},
+		{
+			name: "test success with entity code & metadata",
+			args: args{ctx: context.TODO(),
+				req: models.UpdateAccount{
+					OwnerID:       "12345",
+					AccountNumber: "22200100000001",
+					EntityCode:    "003",
+					Metadata:      &models.Metadata{"segment": "retail"},
+				},
+				setupMocks: func(a args) {
+					listQuery, _, _ := buildUpdateAccountQuery(a.req)
+					suite.mock.ExpectExec(regexp.QuoteMeta(listQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "test error db",
 			args: args{
@@ -232,6 +248,62 @@ func (suite *accountTestSuite) TestRepository_UpdateEntity() {
 	}
 }
 
+func (suite *accountTestSuite) TestRepository_UpdateStatus() {
+	type args struct {
+		ctx        context.Context
+		req        models.UpdateAccountStatus
+		setupMocks func()
+	}
+	testCases := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "test success",
+			args: args{ctx: context.TODO(),
+				req: models.UpdateAccountStatus{
+					AccountNumber: "22200100000001",
+					Status:        models.AccountStatusFrozenCreditOnly,
+				},
+				setupMocks: func() {
+					suite.mock.ExpectExec(regexp.QuoteMeta(queryUpdateAccountStatus)).
+						WithArgs(models.AccountStatusFrozenCreditOnly, "22200100000001").
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "test error db",
+			args: args{
+				ctx: context.TODO(),
+				req: models.UpdateAccountStatus{
+					AccountNumber: "22200100000001",
+					Status:        models.AccountStatusClosed,
+				},
+				setupMocks: func() {
+					suite.mock.ExpectExec(regexp.QuoteMeta(queryUpdateAccountStatus)).WillReturnError(assert.AnError)
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.args.setupMocks()
+
+			err := suite.repo.UpdateStatus(tt.args.ctx, tt.args.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func (suite *accountTestSuite) TestRepository_UpdateLegacyId() {
 	type args struct {
 		ctx        context.Context
@@ -398,6 +470,73 @@ func (suite *accountTestSuite) TestRepository_UpdateBySubCategory() {
 	}
 }
 
+func (suite *accountTestSuite) TestRepository_GetByAccountNumbers() {
+	accountNumbers := []string{"21100100000001", "21200100000001"}
+	query, _, _ := buildGetAccountsByAccountNumbersQuery(accountNumbers)
+	columns := []string{"account_number", "account_name", "owner_id", "category_code", "category_name",
+		"coa_type_code", "coa_type_name", "sub_category_code", "sub_category_name", "entity_code", "entity_name", "product_type_code", "product_type_name",
+		"currency", "status", "alt_id", "created_at", "updated_at", "legacy_id", "metadata", "account_type"}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				now := time.Now()
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("21100100000001", "21200100000001").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("21100100000001", "Lender Yang Baik", "121212", "211", "Marketplace Payable (Lender Balance)",
+							"LIA", "Liability", "21101", "Lender Balance - Individual Non RDL", "001", "PT. Amartha Mikro Fintek (AMF)", "", "",
+							"IDR", "active", "", now, now, []byte("{}"), []byte("{}"), "INDIVIDU").
+						AddRow("21200100000001", "Lender Yang Baik", "121212", "212", "Invested",
+							"LIA", "Liability", "21201", "Invested - Individual Non RDL", "001", "PT. Amartha Mikro Fintek (AMF)", "", "",
+							"IDR", "active", "", now, now, []byte("{}"), []byte("{}"), "INDIVIDU"))
+			},
+			wantLen: 2,
+			wantErr: false,
+		},
+		{
+			name: "error scan row",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetByAccountNumbers(context.TODO(), accountNumbers)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func (suite *accountTestSuite) TestRepository_GetOneByAccountNumber() {
 	var emptyJson = new(*map[string]interface{})
 	type args struct {
@@ -1280,55 +1419,6 @@ func (suite *accountTestSuite) TestRepository_GetAllAccountNumbersByParam() {
 	}
 }
 
-func (suite *accountTestSuite) TestRepository_GetInvestedAccountNumberByCIHAccountNumber() {
-	type args struct {
-		ctx           context.Context
-		accountNumber string
-		setupMocks    func()
-	}
-
-	testCases := []struct {
-		name    string
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "success case",
-			args: args{ctx: context.TODO(),
-				accountNumber: "211001000381110",
-				setupMocks: func() {
-					rows := sqlmock.NewRows([]string{"cih_account_number", "invested_account_number", "receivables_account_number"}).
-						AddRow("211001000381110", "212001000000001", "142001000000001")
-					suite.mock.ExpectQuery(regexp.QuoteMeta(queryGetLenderAccountByCIHAccountNumber)).WillReturnRows(rows)
-				},
-			},
-			wantErr: false,
-		},
-		{
-			name: "error case - error result",
-			args: args{
-				ctx: context.TODO(),
-				setupMocks: func() {
-					suite.mock.ExpectQuery(regexp.QuoteMeta(queryGetLenderAccountByCIHAccountNumber)).WillReturnError(assert.AnError)
-				},
-			},
-			wantErr: true,
-		},
-	}
-	for _, tt := range testCases {
-		suite.t.Run(tt.name, func(t *testing.T) {
-			tt.args.setupMocks()
-
-			_, err := suite.repo.GetLenderAccountByCIHAccountNumber(tt.args.ctx, tt.args.accountNumber)
-			assert.Equal(t, tt.wantErr, err != nil)
-
-			if err = suite.mock.ExpectationsWereMet(); err != nil {
-				t.Errorf("there were unfulfilled expectations: %s", err)
-			}
-		})
-	}
-}
-
 func (suite *accountTestSuite) TestRepository_CreateLoanAccount() {
 	type args struct {
 		ctx        context.Context
@@ -1494,62 +1584,3 @@ func (suite *accountTestSuite) TestRepository_CheckAccountNumberIsExist() {
 		})
 	}
 }
-
-func (suite *accountTestSuite) TestRepository_GetLoanAdvanceAccountByLoanAccount() {
-	type args struct {
-		ctx               context.Context
-		loanAccountNumber string
-		setupMocks        func()
-	}
-
-	testCases := []struct {
-		name    string
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "test success",
-			args: args{ctx: context.TODO(),
-				loanAccountNumber: "21100100000001",
-				setupMocks: func() {
-					rows := sqlmock.NewRows([]string{"loan_account_number", "loan_advance_payment_account_number"}).
-						AddRow("21100100000001", "21100100000002")
-					suite.mock.ExpectQuery(regexp.QuoteMeta(queryGetLoanAdvanceAccountByLoanAccount)).WillReturnRows(rows)
-				},
-			},
-			wantErr: false,
-		},
-		{
-			name: "test data not found",
-			args: args{
-				ctx: context.TODO(),
-				setupMocks: func() {
-					suite.mock.ExpectQuery(regexp.QuoteMeta(queryGetLoanAdvanceAccountByLoanAccount)).WillReturnError(sql.ErrNoRows)
-				},
-			},
-			wantErr: true,
-		},
-		{
-			name: "test error result",
-			args: args{
-				ctx: context.TODO(),
-				setupMocks: func() {
-					suite.mock.ExpectQuery(regexp.QuoteMeta(queryGetLoanAdvanceAccountByLoanAccount)).WillReturnError(assert.AnError)
-				},
-			},
-			wantErr: true,
-		},
-	}
-	for _, tt := range testCases {
-		suite.t.Run(tt.name, func(t *testing.T) {
-			tt.args.setupMocks()
-
-			_, err := suite.repo.GetLoanAdvanceAccountByLoanAccount(tt.args.ctx, tt.args.loanAccountNumber)
-			assert.Equal(t, tt.wantErr, err != nil)
-
-			if err = suite.mock.ExpectationsWereMet(); err != nil {
-				t.Errorf("there were unfulfilled expectations: %s", err)
-			}
-		})
-	}
-}
@@ -31,6 +31,7 @@ type AccountingRepository interface {
 	// journal
 	GetJournalDetailByTransactionId(ctx context.Context, transactionId string) (result []models.GetJournalDetailOut, err error)
 	InsertJournalDetail(ctx context.Context, in []models.CreateJournalDetail) (err error)
+	GetReversedJournalIds(ctx context.Context, journalIds []string) (result []string, err error)
 
 	// trial-balance
 	CalculateFromAccountBalanceDaily(ctx context.Context, in models.CalculateTrialBalance) (out models.AccountTrialBalance, err error)
@@ -61,9 +62,63 @@ type AccountingRepository interface {
 	GetAccountTransactionByDate(ctx context.Context, entities []string, date time.Time) <-chan models.StreamResult[models.AccountTransation]
 	GetAllAccountDailyBalance(ctx context.Context, entities []string, subCategories *[]models.SubCategory, date time.Time) <-chan models.StreamResult[models.AccountBalanceDaily]
 	InsertAccountBalanceDaily(ctx context.Context, in []models.AccountBalanceDaily) (err error)
+	GetForeignCurrencyClosingBalance(ctx context.Context, date time.Time, baseCurrency string) (out []models.AccountBalanceDaily, err error)
 
 	// balance sheet
 	GetBalanceSheet(ctx context.Context, opts models.BalanceSheetFilterOptions) (out models.BalanceSheetOut, err error)
+	GetBalanceSheetPerSubCategory(ctx context.Context, entityCode, currency string, dates []time.Time) (out []models.BalancePerSubCategoryOut, err error)
+
+	// profit loss
+	GetProfitLoss(ctx context.Context, opts models.ProfitLossFilterOptions) (out []models.ProfitLossPerSubCategoryOut, err error)
+
+	// cash flow
+	GetCashFlowMovement(ctx context.Context, opts models.CashFlowFilterOptions, cashSubCategories []string) (out []models.CashFlowMovementOut, err error)
+	GetCashBalance(ctx context.Context, entityCode, currency string, cashSubCategories []string, before time.Time) (balance decimal.Decimal, err error)
+
+	// year end closing
+	GetYearEndClosingBalance(ctx context.Context, entityCode, currency, closingOrderType string, startDate, endDate time.Time) (out []models.YearEndClosingBalance, err error)
+
+	// recurring journal
+	InsertRecurringJournal(ctx context.Context, in models.RecurringJournal) (id int, err error)
+	GetRecurringJournalById(ctx context.Context, id int) (out *models.RecurringJournal, err error)
+	GetRecurringJournals(ctx context.Context, opts models.RecurringJournalFilterOptions) (out []models.RecurringJournal, err error)
+	UpdateRecurringJournalSchedule(ctx context.Context, in models.RecurringJournal) (err error)
+
+	// journal draft
+	InsertJournalDraft(ctx context.Context, in models.JournalDraft) (id int, err error)
+	GetJournalDraftById(ctx context.Context, id int) (out *models.JournalDraft, err error)
+	GetJournalDrafts(ctx context.Context, opts models.JournalDraftFilterOptions) (out []models.JournalDraft, err error)
+	UpdateJournalDraftStatus(ctx context.Context, in models.JournalDraft, fromStatus string) (err error)
+
+	// upload job
+	InsertUploadJob(ctx context.Context, in models.UploadJob) (id int, err error)
+	GetUploadJobById(ctx context.Context, id int) (out *models.UploadJob, err error)
+	UpdateUploadJob(ctx context.Context, in models.UploadJob, fromStatus string) (err error)
+	ClaimUploadJob(ctx context.Context, in models.UploadJob, staleBefore time.Time) (err error)
+
+	// bank reconciliation
+	InsertBankStatement(ctx context.Context, in models.BankStatement) (id int, err error)
+	CheckBankStatementIsExist(ctx context.Context, accountNumber, checksum string) (isExist bool, err error)
+	InsertBankStatementLines(ctx context.Context, in []models.BankStatementLine) (err error)
+	GetBankStatementLineById(ctx context.Context, id int) (out *models.BankStatementLine, err error)
+	GetBankStatementLines(ctx context.Context, opts models.BankStatementLineFilterOptions) (out []models.BankStatementLine, err error)
+	GetBankStatementLinesByJournalIds(ctx context.Context, journalIds []string) (out []models.BankStatementLine, err error)
+	UpdateBankStatementLineMatch(ctx context.Context, in models.BankStatementLine, fromStatus string) (err error)
+
+	// suspense clearing
+	GetSuspenseClosingBalance(ctx context.Context, date time.Time, subCategories []string) (out []models.AccountBalanceDaily, err error)
+	InsertSuspenseClearing(ctx context.Context, in models.SuspenseClearing) (err error)
+	GetOpenSuspenseSplits(ctx context.Context, accountNumber string, endDate time.Time, clearingOrderType string) (out []models.GetSubLedgerOut, err error)
+
+	// ledger integrity
+	GetLedgerAccountMovements(ctx context.Context, entityCode string, startDate, endDate time.Time) (out []models.LedgerAccountMovement, err error)
+	GetAccountDailyBalanceByEntity(ctx context.Context, entityCode string, date time.Time) (out []models.AccountBalanceDaily, err error)
+	GetAccountTrialBalanceByEntity(ctx context.Context, entityCode string, date time.Time) (out []models.AccountTrialBalance, err error)
+	GetUnbalancedTransactions(ctx context.Context, entityCode string, startDate, endDate time.Time) (out []models.UnbalancedTransaction, err error)
+
+	// account balance
+	GetLatestAccountDailyBalances(ctx context.Context, accountNumbers []string, beforeDate time.Time) (out []models.AccountBalanceDaily, err error)
+	GetAccountMovementsAfterDailyBalance(ctx context.Context, dailyBalances []models.AccountBalanceDaily, asOf time.Time) (out []models.LedgerAccountMovement, err error)
 
 	GetTransactionsToday(ctx context.Context, transactionDate time.Time) (transactions []string, err error)
 }
@@ -166,8 +221,8 @@ func (ar *accountingRepository) InsertJournalDetail(ctx context.Context, in []mo
 	valueStrings := []string{}
 	valueArgs := []interface{}{}
 	for _, req := range in {
-		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP(6), CURRENT_TIMESTAMP(6), ?)")
-		valueArgs = append(valueArgs, req.JournalId, req.ReferenceNumber, req.OrderType, req.TransactionType, req.TransactionTypeName, req.TransactionDate, req.IsDebit, req.Metadata)
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP(6), CURRENT_TIMESTAMP(6), ?, NULLIF(?, ''))")
+		valueArgs = append(valueArgs, req.JournalId, req.ReferenceNumber, req.OrderType, req.TransactionType, req.TransactionTypeName, req.TransactionDate, req.IsDebit, req.Metadata, req.ReversedJournalId)
 	}
 
 	query := fmt.Sprintf(queryInsertJournalDetail, strings.Join(valueStrings, ","))
@@ -214,7 +269,10 @@ func (ar *accountingRepository) GetSubLedger(ctx context.Context, opts models.Su
 	defer rows.Close()
 
 	for rows.Next() {
-		var out = models.GetSubLedgerOut{}
+		var (
+			out      = models.GetSubLedgerOut{}
+			decimals int
+		)
 		var err = rows.Scan(
 			&out.TransactionID,
 			&out.ReferenceNumber,
@@ -227,12 +285,14 @@ func (ar *accountingRepository) GetSubLedger(ctx context.Context, opts models.Su
 			&out.Credit,
 			&out.CreatedAt,
 			&out.UpdatedAt,
+			&out.JournalID,
+			&decimals,
 		)
 		if err != nil {
 			return nil, err
 		}
-		out.Debit = money.FormatBigIntToAmount(out.Debit, CurrencyIDR.Decimals)
-		out.Credit = money.FormatBigIntToAmount(out.Credit, CurrencyIDR.Decimals)
+		out.Debit = money.FormatBigIntToAmount(out.Debit, decimals)
+		out.Credit = money.FormatBigIntToAmount(out.Credit, decimals)
 		result = append(result, out)
 	}
 	if rows.Err() != nil {
@@ -272,7 +332,10 @@ func (ar *accountingRepository) GetSubLedgerStream(ctx context.Context, opts mod
 				case <-ctx.Done():
 					return
 				default:
-					var out models.GetSubLedgerOut
+					var (
+						out      models.GetSubLedgerOut
+						decimals int
+					)
 					err := rows.Scan(
 						&out.TransactionID,
 						&out.ReferenceNumber,
@@ -285,13 +348,15 @@ func (ar *accountingRepository) GetSubLedgerStream(ctx context.Context, opts mod
 						&out.Credit,
 						&out.CreatedAt,
 						&out.UpdatedAt,
+						&out.JournalID,
+						&decimals,
 					)
 					if err != nil {
 						ch <- models.StreamResult[models.GetSubLedgerOut]{Err: err}
 						return
 					}
-					out.Debit = money.FormatBigIntToAmount(out.Debit, CurrencyIDR.Decimals)
-					out.Credit = money.FormatBigIntToAmount(out.Credit, CurrencyIDR.Decimals)
+					out.Debit = money.FormatBigIntToAmount(out.Debit, decimals)
+					out.Credit = money.FormatBigIntToAmount(out.Credit, decimals)
 					ch <- models.StreamResult[models.GetSubLedgerOut]{Data: out}
 					rowsProcessed = true
 				}
@@ -379,7 +444,10 @@ func (ar *accountingRepository) GetJournalDetailByTransactionId(ctx context.Cont
 	defer rows.Close()
 
 	for rows.Next() {
-		var out = models.GetJournalDetailOut{}
+		var (
+			out      = models.GetJournalDetailOut{}
+			decimals int
+		)
 		var err = rows.Scan(
 			&out.TransactionId,
 			&out.JournalId,
@@ -395,12 +463,17 @@ func (ar *accountingRepository) GetJournalDetailByTransactionId(ctx context.Cont
 			&out.TransactionDate,
 			&out.Narrative,
 			&out.IsDebit,
+			&out.ReferenceNumber,
+			&out.OrderType,
+			&out.ReversedJournalId,
+			&out.Currency,
+			&decimals,
 		)
 		if err != nil {
 			err = databaseError(err)
 			return nil, err
 		}
-		out.Amount = money.FormatBigIntToAmount(out.Amount, CurrencyIDR.Decimals)
+		out.Amount = money.FormatBigIntToAmount(out.Amount, decimals)
 		result = append(result, out)
 	}
 	if rows.Err() != nil {
@@ -411,6 +484,42 @@ func (ar *accountingRepository) GetJournalDetailByTransactionId(ctx context.Cont
 	return
 }
 
+func (ar *accountingRepository) GetReversedJournalIds(ctx context.Context, journalIds []string) (result []string, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+
+	query, args, err := getReversedJournalIdsQuery(journalIds)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var journalId string
+		if err = rows.Scan(&journalId); err != nil {
+			err = databaseError(err)
+			return nil, err
+		}
+		result = append(result, journalId)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return result, err
+	}
+
+	return
+}
+
 func (ar *accountingRepository) GetSubLedgerAccounts(ctx context.Context, opts models.SubLedgerAccountsFilterOptions) (result []models.GetSubLedgerAccountsOut, err error) {
 	start := atime.Now()
 
@@ -535,7 +644,10 @@ func (ar *accountingRepository) GetTrialBalanceDetails(ctx context.Context, opts
 	defer rows.Close()
 
 	for rows.Next() {
-		var out models.TrialBalanceDetailOut
+		var (
+			out      models.TrialBalanceDetailOut
+			decimals int
+		)
 		err = rows.Scan(
 			&out.AccountNumber,
 			&out.AccountName,
@@ -543,15 +655,16 @@ func (ar *accountingRepository) GetTrialBalanceDetails(ctx context.Context, opts
 			&out.ClosingBalance,
 			&out.DebitMovement,
 			&out.CreditMovement,
+			&decimals,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		out.OpeningBalance = money.FormatBigIntToAmount(out.OpeningBalance, CurrencyIDR.Decimals)
-		out.ClosingBalance = money.FormatBigIntToAmount(out.ClosingBalance, CurrencyIDR.Decimals)
-		out.CreditMovement = money.FormatBigIntToAmount(out.CreditMovement, CurrencyIDR.Decimals)
-		out.DebitMovement = money.FormatBigIntToAmount(out.DebitMovement, CurrencyIDR.Decimals)
+		out.OpeningBalance = money.FormatBigIntToAmount(out.OpeningBalance, decimals)
+		out.ClosingBalance = money.FormatBigIntToAmount(out.ClosingBalance, decimals)
+		out.CreditMovement = money.FormatBigIntToAmount(out.CreditMovement, decimals)
+		out.DebitMovement = money.FormatBigIntToAmount(out.DebitMovement, decimals)
 
 		tba = append(tba, out)
 	}
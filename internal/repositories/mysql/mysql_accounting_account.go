@@ -114,6 +114,45 @@ func (ar *accountingRepository) GetOneAccountBalanceDaily(ctx context.Context, a
 	return
 }
 
+// GetForeignCurrencyClosingBalance returns the closing balance of accounts not in the base currency,
+// summed per entity, sub category and currency.
+func (ar *accountingRepository) GetForeignCurrencyClosingBalance(ctx context.Context, date time.Time, baseCurrency string) (out []models.AccountBalanceDaily, err error) {
+	start := atime.Now()
+	defer func() {
+		logSQL(ctx, err, start)
+	}()
+
+	db := ar.r.extractTx(ctx)
+
+	rows, err := db.QueryContext(ctx, queryGetForeignCurrencyClosingBalance, date, baseCurrency)
+	if err != nil {
+		err = databaseError(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := models.AccountBalanceDaily{BalanceDate: date}
+		if err = rows.Scan(
+			&v.EntityCode,
+			&v.CategoryCode,
+			&v.SubCategoryCode,
+			&v.Currency,
+			&v.ClosingBalance,
+		); err != nil {
+			err = databaseError(err)
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return out, err
+	}
+
+	return
+}
+
 func (ar *accountingRepository) GetAccountTransactionByDate(ctx context.Context, entities []string, date time.Time) <-chan models.StreamResult[models.AccountTransation] {
 	db := ar.r.extractTx(ctx)
 	startDate, _ := atime.StartDateEndDate(date, date)
@@ -258,7 +297,7 @@ func (ar *accountingRepository) InsertAccountBalanceDaily(ctx context.Context, i
 	valueStrings := []string{}
 	valueArgs := []interface{}{}
 	for _, req := range in {
-		valueStrings = append(valueStrings, `(?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		valueStrings = append(valueStrings, `(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 		valueArgs = append(valueArgs, req.BalanceDate)
 		valueArgs = append(valueArgs, req.AccountNumber)
 		valueArgs = append(valueArgs, req.EntityCode)
@@ -268,6 +307,7 @@ func (ar *accountingRepository) InsertAccountBalanceDaily(ctx context.Context, i
 		valueArgs = append(valueArgs, req.CreditMovement)
 		valueArgs = append(valueArgs, req.OpeningBalance)
 		valueArgs = append(valueArgs, req.ClosingBalance)
+		valueArgs = append(valueArgs, req.Currency)
 	}
 
 	query := fmt.Sprintf(queryInsertAccountBalanceDailyOld, strings.Join(valueStrings, ","))
@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+)
+
+// GetLatestAccountDailyBalances returns the last daily balance of the accounts before the date, an account without
+// daily balance is not returned.
+func (ar *accountingRepository) GetLatestAccountDailyBalances(ctx context.Context, accountNumbers []string, beforeDate time.Time) (out []models.AccountBalanceDaily, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	if len(accountNumbers) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := buildGetLatestAccountDailyBalancesQuery(accountNumbers, beforeDate)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.AccountBalanceDaily
+		if err = rows.Scan(
+			&v.AccountNumber,
+			&v.BalanceDate,
+			&v.EntityCode,
+			&v.CategoryCode,
+			&v.SubCategoryCode,
+			&v.Currency,
+			&v.ClosingBalance,
+		); err != nil {
+			err = databaseError(err)
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
+
+// GetAccountMovementsAfterDailyBalance returns the debit & credit movements of the accounts after the date
+// of the daily balances until the as of time, the balance date of an account without daily balance is zero.
+func (ar *accountingRepository) GetAccountMovementsAfterDailyBalance(ctx context.Context, dailyBalances []models.AccountBalanceDaily, asOf time.Time) (out []models.LedgerAccountMovement, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	if len(dailyBalances) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := buildGetAccountMovementsAfterDailyBalanceQuery(dailyBalances, asOf)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.LedgerAccountMovement
+		if err = rows.Scan(
+			&v.AccountNumber,
+			&v.DebitMovement,
+			&v.CreditMovement,
+		); err != nil {
+			err = databaseError(err)
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
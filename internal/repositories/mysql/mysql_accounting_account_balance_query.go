@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// buildGetLatestAccountDailyBalancesQuery returns the last daily balance of every account before the date.
+func buildGetLatestAccountDailyBalancesQuery(accountNumbers []string, beforeDate time.Time) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	latest := psql.Select(`account_number`, `MAX(balance_date) AS balance_date`).
+		From("acct_account_daily_balance").
+		Where(sq.Eq{`account_number`: accountNumbers}).
+		Where(sq.Lt{`balance_date`: beforeDate}).
+		GroupBy(`account_number`)
+
+	query := psql.Select(
+		`aadb.account_number`,
+		`aadb.balance_date`,
+		`aadb.entity_code`,
+		`aadb.category_code`,
+		`aadb.sub_category_code`,
+		`aadb.currency`,
+		`aadb.closing_balance`,
+	).
+		From("acct_account_daily_balance aadb").
+		JoinClause(latest.Prefix("JOIN (").Suffix(") latest ON latest.account_number = aadb.account_number AND latest.balance_date = aadb.balance_date")).
+		OrderBy(`aadb.account_number ASC`)
+
+	return query.ToSql()
+}
+
+// buildGetAccountMovementsAfterDailyBalanceQuery sums the splits of every account after the date of its daily balance
+// until the as of time, every split until the as of time is summed when the account has no daily balance.
+func buildGetAccountMovementsAfterDailyBalanceQuery(dailyBalances []models.AccountBalanceDaily, asOf time.Time) (sql string, args []interface{}, err error) {
+	accounts := sq.Or{}
+	for _, v := range dailyBalances {
+		if v.BalanceDate.IsZero() {
+			accounts = append(accounts, sq.Eq{`sa.account_id`: v.AccountNumber})
+			continue
+		}
+		accounts = append(accounts, sq.And{
+			sq.Eq{`sa.account_id`: v.AccountNumber},
+			sq.GtOrEq{`ajd.transaction_date`: v.BalanceDate.AddDate(0, 0, 1)},
+		})
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(
+		`sa.account_id`,
+		`SUM(CASE WHEN ajd.is_debit = 1 THEN s.amount ELSE 0 END) AS debit`,
+		`SUM(CASE WHEN ajd.is_debit = 0 THEN s.amount ELSE 0 END) AS credit`,
+	).
+		From("splits s").
+		Join("split_accounts sa ON sa.split_id = s.split_id").
+		Join("acct_journal_detail ajd ON ajd.journal_id = s.split_id").
+		Where(sq.LtOrEq{`ajd.transaction_date`: asOf}).
+		Where(accounts).
+		GroupBy(`sa.account_id`).
+		OrderBy(`sa.account_id ASC`)
+
+	return query.ToSql()
+}
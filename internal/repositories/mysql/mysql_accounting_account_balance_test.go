@@ -0,0 +1,156 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountingTestSuite) TestRepository_GetLatestAccountDailyBalances() {
+	date := time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC)
+	accountNumbers := []string{"142001000000001", "212001000000001"}
+	query, _, _ := buildGetLatestAccountDailyBalancesQuery(accountNumbers, date)
+	columns := []string{"account_number", "balance_date", "entity_code", "category_code", "sub_category_code", "currency", "closing_balance"}
+
+	testCases := []struct {
+		name           string
+		accountNumbers []string
+		doMock         func()
+		wantLen        int
+		wantErr        bool
+	}{
+		{
+			name:           "success",
+			accountNumbers: accountNumbers,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("142001000000001", "212001000000001", date).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("142001000000001", date.AddDate(0, 0, -1), "001", "142", "14201", "IDR", "100000"))
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
+		{
+			name:    "success without account numbers",
+			doMock:  func() {},
+			wantErr: false,
+		},
+		{
+			name:           "error database",
+			accountNumbers: accountNumbers,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:           "error row scan",
+			accountNumbers: accountNumbers,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"account_number"}).AddRow("142001000000001"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetLatestAccountDailyBalances(context.TODO(), tt.accountNumbers, date)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetAccountMovementsAfterDailyBalance() {
+	balanceDate := time.Date(2024, time.April, 29, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2024, time.April, 30, 13, 0, 0, 0, time.UTC)
+	dailyBalances := []models.AccountBalanceDaily{
+		{AccountNumber: "142001000000001", BalanceDate: balanceDate},
+		{AccountNumber: "212001000000001"},
+	}
+	query, _, _ := buildGetAccountMovementsAfterDailyBalanceQuery(dailyBalances, asOf)
+	columns := []string{"account_id", "debit", "credit"}
+
+	testCases := []struct {
+		name          string
+		dailyBalances []models.AccountBalanceDaily
+		doMock        func()
+		wantLen       int
+		wantErr       bool
+	}{
+		{
+			name:          "success",
+			dailyBalances: dailyBalances,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(asOf, "142001000000001", balanceDate.AddDate(0, 0, 1), "212001000000001").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("142001000000001", "50000", "0").
+						AddRow("212001000000001", "0", "50000"))
+			},
+			wantLen: 2,
+			wantErr: false,
+		},
+		{
+			name:    "success without daily balances",
+			doMock:  func() {},
+			wantErr: false,
+		},
+		{
+			name:          "error database",
+			dailyBalances: dailyBalances,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:          "error row scan",
+			dailyBalances: dailyBalances,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"account_id"}).AddRow("142001000000001"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetAccountMovementsAfterDailyBalance(context.TODO(), tt.dailyBalances, asOf)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
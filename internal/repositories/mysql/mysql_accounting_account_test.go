@@ -491,12 +491,116 @@ func (suite *accountingTestSuite) TestRepository_GetOneAccountBalanceDaily() {
 	}
 }
 
+func (suite *accountingTestSuite) TestRepository_GetForeignCurrencyClosingBalance() {
+	date, _ := atime.NowZeroTime()
+	type args struct {
+		ctx          context.Context
+		date         time.Time
+		baseCurrency string
+	}
+	testCases := []struct {
+		name       string
+		args       args
+		setupMocks func(a args)
+		wantErr    bool
+	}{
+		{
+			name: "success case",
+			args: args{
+				ctx:          context.TODO(),
+				date:         date,
+				baseCurrency: "IDR",
+			},
+			setupMocks: func(a args) {
+				rows := sqlmock.
+					NewRows([]string{
+						"aadb.entity_code",
+						"aadb.category_code",
+						"aadb.sub_category_code",
+						"aadb.currency",
+						"closing_balance",
+					}).
+					AddRow("001", "114", "11403", "USD", 10000)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryGetForeignCurrencyClosingBalance)).
+					WithArgs(a.date, a.baseCurrency).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - QueryContext",
+			args: args{
+				ctx:          context.TODO(),
+				date:         date,
+				baseCurrency: "IDR",
+			},
+			setupMocks: func(a args) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryGetForeignCurrencyClosingBalance)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - row scan",
+			args: args{
+				ctx:          context.TODO(),
+				date:         date,
+				baseCurrency: "IDR",
+			},
+			setupMocks: func(a args) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryGetForeignCurrencyClosingBalance)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - row error",
+			args: args{
+				ctx:          context.TODO(),
+				date:         date,
+				baseCurrency: "IDR",
+			},
+			setupMocks: func(a args) {
+				rows := sqlmock.
+					NewRows([]string{
+						"aadb.entity_code",
+						"aadb.category_code",
+						"aadb.sub_category_code",
+						"aadb.currency",
+						"closing_balance",
+					}).
+					AddRow("001", "114", "11403", "USD", 10000).RowError(0, assert.AnError)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryGetForeignCurrencyClosingBalance)).
+					WillReturnRows(rows)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks(tt.args)
+
+			_, err := suite.repo.GetForeignCurrencyClosingBalance(tt.args.ctx, tt.args.date, tt.args.baseCurrency)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func (suite *accountingTestSuite) TestRepository_InsertAccountBalanceDaily() {
 	type args struct {
 		ctx context.Context
 		req []models.AccountBalanceDaily
 	}
-	valueStrings := "(?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	valueStrings := "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
 	query := fmt.Sprintf(queryInsertAccountBalanceDailyOld, valueStrings)
 
 	testCases := []struct {
@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/money"
@@ -61,3 +62,47 @@ func (ar *accountingRepository) GetBalanceSheet(ctx context.Context, opts models
 
 	return
 }
+
+func (ar *accountingRepository) GetBalanceSheetPerSubCategory(ctx context.Context, entityCode, currency string, dates []time.Time) (out []models.BalancePerSubCategoryOut, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetBalanceSheetPerSubCategoryQuery(entityCode, currency, dates)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data = models.BalancePerSubCategoryOut{}
+		var errScan = rows.Scan(
+			&data.ClosingDate,
+			&data.COAType,
+			&data.CategoryCode,
+			&data.CategoryName,
+			&data.SubCategoryCode,
+			&data.SubCategoryName,
+			&data.SumClosingBalance,
+		)
+		if errScan != nil {
+			err = databaseError(errScan)
+			return
+		}
+		out = append(out, data)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
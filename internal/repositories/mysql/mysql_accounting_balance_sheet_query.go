@@ -1,6 +1,8 @@
 package mysql
 
 import (
+	"time"
+
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
 
@@ -20,9 +22,40 @@ func buildGetBalanceSheetQuery(opts models.BalanceSheetFilterOptions) (sql strin
 	}...).From("acct_account_trial_balance aatb").
 		Join("acct_category ac on aatb.category_code = ac.code").
 		Where(sq.Eq{"aatb.entity_code": opts.EntityCode}).
+		Where(sq.Eq{"aatb.currency": opts.Currency}).
 		Where(sq.Eq{"aatb.closing_date": date}).
 		Where(`EXISTS (SELECT 1 FROM acct_account aa WHERE aa.entity_code = aatb.entity_code AND aa.sub_category_code = aatb.sub_category_code)`).
 		GroupBy("aatb.category_code")
 
 	return query.ToSql()
 }
+
+// buildGetBalanceSheetPerSubCategoryQuery sums the closing balance per sub category of every date to be compared.
+func buildGetBalanceSheetPerSubCategoryQuery(entityCode, currency string, dates []time.Time) (sql string, args []interface{}, err error) {
+	closingDates := make([]time.Time, 0, len(dates))
+	for _, v := range dates {
+		closingDates = append(closingDates, atime.ToZeroTime(v))
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select([]string{
+		"aatb.closing_date",
+		"ac.coa_type_code",
+		"aatb.category_code",
+		"ac.name",
+		"aatb.sub_category_code",
+		"asc2.name",
+		"sum(aatb.closing_balance)",
+	}...).From("acct_account_trial_balance aatb").
+		Join("acct_category ac on aatb.category_code = ac.code").
+		Join("acct_sub_category asc2 on aatb.sub_category_code = asc2.code").
+		Where(sq.Eq{"aatb.entity_code": entityCode}).
+		Where(sq.Eq{"aatb.currency": currency}).
+		Where(sq.Eq{"aatb.closing_date": closingDates}).
+		Where(sq.Eq{"ac.coa_type_code": []string{models.COATypeAsset, models.COATypeLiability}}).
+		Where(`EXISTS (SELECT 1 FROM acct_account aa WHERE aa.entity_code = aatb.entity_code AND aa.sub_category_code = aatb.sub_category_code)`).
+		GroupBy("aatb.closing_date", "ac.coa_type_code", "aatb.category_code", "ac.name", "aatb.sub_category_code", "asc2.name").
+		OrderBy("aatb.category_code", "aatb.sub_category_code")
+
+	return query.ToSql()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"regexp"
 	"testing"
+	"time"
 
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
@@ -98,3 +99,67 @@ func (suite *accountingTestSuite) TestRepository_GetBalanceSheet() {
 		})
 	}
 }
+
+func (suite *accountingTestSuite) TestRepository_GetBalanceSheetPerSubCategory() {
+	balanceSheetDate, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-01-31")
+	compareDate, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2023-12-31")
+	dates := []time.Time{balanceSheetDate, compareDate}
+	query, _, _ := buildGetBalanceSheetPerSubCategoryQuery("001", models.CurrencyIDR, dates)
+
+	testCases := []struct {
+		name       string
+		setupMocks func()
+		wantErr    bool
+	}{
+		{
+			name: "success case - get balance sheet per sub category",
+			setupMocks: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{
+						"aatb.closing_date",
+						"ac.coa_type_code",
+						"aatb.category_code",
+						"ac.name",
+						"aatb.sub_category_code",
+						"asc2.name",
+						"sum(aatb.closing_balance)",
+					}).
+						AddRow(balanceSheetDate, "AST", "111", "Cash", "11101", "Cash in Bank", "150000").
+						AddRow(compareDate, "AST", "111", "Cash", "11101", "Cash in Bank", "100000"))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - error scan row",
+			setupMocks: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - error database",
+			setupMocks: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.setupMocks()
+
+			_, err := suite.repo.GetBalanceSheetPerSubCategory(context.TODO(), "001", models.CurrencyIDR, dates)
+			assert.Equal(t, tc.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+)
+
+func (ar *accountingRepository) InsertBankStatement(ctx context.Context, in models.BankStatement) (id int, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryInsertBankStatement,
+		in.AccountNumber,
+		in.Format,
+		in.Filename,
+		in.ImportedBy,
+		in.Checksum,
+	)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	lastInsertId, err := res.LastInsertId()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return int(lastInsertId), nil
+}
+
+// CheckBankStatementIsExist checks whether the statement file with the checksum is already imported to the account.
+func (ar *accountingRepository) CheckBankStatementIsExist(ctx context.Context, accountNumber, checksum string) (isExist bool, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	if err = db.QueryRowContext(ctx, queryCheckBankStatementIsExist, accountNumber, checksum).Scan(&isExist); err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return
+}
+
+func (ar *accountingRepository) InsertBankStatementLines(ctx context.Context, in []models.BankStatementLine) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+
+	valueStrings := []string{}
+	valueArgs := []interface{}{}
+	for _, req := range in {
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		valueArgs = append(valueArgs,
+			req.StatementID,
+			req.AccountNumber,
+			req.LineDate,
+			req.Amount,
+			req.IsCredit,
+			req.ReferenceNumber,
+			req.Description,
+			req.Status,
+			sql.NullString{String: req.TransactionId, Valid: req.TransactionId != ""},
+			sql.NullString{String: req.JournalId, Valid: req.JournalId != ""},
+			sql.NullString{String: req.MatchType, Valid: req.MatchType != ""},
+			sql.NullString{String: req.MatchedBy, Valid: req.MatchedBy != ""},
+			req.MatchedAt,
+		)
+	}
+
+	query := fmt.Sprintf(queryInsertBankStatementLines, strings.Join(valueStrings, ","))
+	res, err := db.ExecContext(ctx, query, valueArgs...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	if affectedRows == 0 {
+		err = databaseError(models.ErrNoRowsAffected)
+		return
+	}
+
+	return nil
+}
+
+func (ar *accountingRepository) GetBankStatementLineById(ctx context.Context, id int) (out *models.BankStatementLine, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetBankStatementLineByIdQuery(id)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	line, err := scanBankStatementLine(db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == models.ErrNoRows {
+			err = nil
+			return nil, nil
+		}
+		err = databaseError(err)
+		return nil, err
+	}
+
+	return &line, nil
+}
+
+func (ar *accountingRepository) GetBankStatementLines(ctx context.Context, opts models.BankStatementLineFilterOptions) (out []models.BankStatementLine, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetBankStatementLinesQuery(opts)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	return ar.queryBankStatementLines(ctx, query, args)
+}
+
+// GetBankStatementLinesByJournalIds returns the lines matched with the splits, a split is matched with one line at most.
+func (ar *accountingRepository) GetBankStatementLinesByJournalIds(ctx context.Context, journalIds []string) (out []models.BankStatementLine, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	if len(journalIds) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := buildGetBankStatementLinesByJournalIdsQuery(journalIds)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	return ar.queryBankStatementLines(ctx, query, args)
+}
+
+// UpdateBankStatementLineMatch matches or unmatches the line from the given status, models.ErrNoRowsAffected is returned
+// when the line is already moved by another request.
+func (ar *accountingRepository) UpdateBankStatementLineMatch(ctx context.Context, in models.BankStatementLine, fromStatus string) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryUpdateBankStatementLineMatch,
+		in.Status,
+		sql.NullString{String: in.TransactionId, Valid: in.TransactionId != ""},
+		sql.NullString{String: in.JournalId, Valid: in.JournalId != ""},
+		sql.NullString{String: in.MatchType, Valid: in.MatchType != ""},
+		sql.NullString{String: in.MatchedBy, Valid: in.MatchedBy != ""},
+		in.MatchedAt,
+		in.ID,
+		fromStatus,
+	)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	if affectedRows == 0 {
+		err = models.ErrNoRowsAffected
+		return
+	}
+
+	return nil
+}
+
+func (ar *accountingRepository) queryBankStatementLines(ctx context.Context, query string, args []interface{}) (out []models.BankStatementLine, err error) {
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		line, errScan := scanBankStatementLine(rows)
+		if errScan != nil {
+			err = databaseError(errScan)
+			return
+		}
+		out = append(out, line)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
+
+type bankStatementLineScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBankStatementLine(row bankStatementLineScanner) (out models.BankStatementLine, err error) {
+	var (
+		transactionId sql.NullString
+		journalId     sql.NullString
+		matchType     sql.NullString
+		matchedBy     sql.NullString
+		matchedAt     sql.NullTime
+	)
+	err = row.Scan(
+		&out.ID,
+		&out.StatementID,
+		&out.AccountNumber,
+		&out.LineDate,
+		&out.Amount,
+		&out.IsCredit,
+		&out.ReferenceNumber,
+		&out.Description,
+		&out.Status,
+		&transactionId,
+		&journalId,
+		&matchType,
+		&matchedBy,
+		&matchedAt,
+		&out.CreatedAt,
+		&out.UpdatedAt,
+	)
+	if err != nil {
+		return
+	}
+
+	out.TransactionId = transactionId.String
+	out.JournalId = journalId.String
+	out.MatchType = matchType.String
+	out.MatchedBy = matchedBy.String
+	if matchedAt.Valid {
+		out.MatchedAt = &matchedAt.Time
+	}
+
+	return
+}
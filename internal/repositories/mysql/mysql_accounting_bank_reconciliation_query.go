@@ -0,0 +1,119 @@
+package mysql
+
+import (
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// query to acct_bank_statements & acct_bank_statement_lines table
+var (
+	queryInsertBankStatement = `
+		INSERT INTO acct_bank_statements(
+			account_number,
+			format,
+			filename,
+			imported_by,
+			checksum
+		) VALUES (?, ?, ?, ?, ?)`
+
+	queryCheckBankStatementIsExist = `
+	SELECT EXISTS (
+		SELECT 1
+		FROM acct_bank_statements
+		WHERE account_number = ? AND checksum = ? LIMIT 1
+	) AS is_exist;`
+
+	queryInsertBankStatementLines = `
+		INSERT INTO acct_bank_statement_lines(
+			statement_id,
+			account_number,
+			line_date,
+			amount,
+			is_credit,
+			reference_number,
+			description,
+			status,
+			transaction_id,
+			journal_id,
+			match_type,
+			matched_by,
+			matched_at
+		) VALUES %s`
+
+	queryUpdateBankStatementLineMatch = `
+		UPDATE
+			acct_bank_statement_lines
+		SET
+			status = ?,
+			transaction_id = ?,
+			journal_id = ?,
+			match_type = ?,
+			matched_by = ?,
+			matched_at = ?,
+			updated_at = CURRENT_TIMESTAMP(6)
+		WHERE
+			id = ? AND status = ?`
+)
+
+var bankStatementLineColumns = []string{
+	`id`,
+	`statement_id`,
+	`account_number`,
+	`line_date`,
+	`amount`,
+	`is_credit`,
+	`reference_number`,
+	`description`,
+	`status`,
+	`transaction_id`,
+	`journal_id`,
+	`match_type`,
+	`matched_by`,
+	`matched_at`,
+	`created_at`,
+	`updated_at`,
+}
+
+func buildGetBankStatementLineByIdQuery(id int) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(bankStatementLineColumns...).
+		From("acct_bank_statement_lines").
+		Where(sq.Eq{`id`: id})
+
+	return query.ToSql()
+}
+
+func buildGetBankStatementLinesQuery(opts models.BankStatementLineFilterOptions) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(bankStatementLineColumns...).From("acct_bank_statement_lines")
+
+	if opts.StatementID != 0 {
+		query = query.Where(sq.Eq{`statement_id`: opts.StatementID})
+	}
+	if opts.AccountNumber != "" {
+		query = query.Where(sq.Eq{`account_number`: opts.AccountNumber})
+	}
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{`status`: opts.Status})
+	}
+	if !opts.StartDate.IsZero() {
+		query = query.Where(sq.GtOrEq{`line_date`: opts.StartDate})
+	}
+	if !opts.EndDate.IsZero() {
+		query = query.Where(sq.LtOrEq{`line_date`: opts.EndDate})
+	}
+
+	query = query.OrderBy(`line_date ASC`, `id ASC`)
+
+	return query.ToSql()
+}
+
+func buildGetBankStatementLinesByJournalIdsQuery(journalIds []string) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(bankStatementLineColumns...).
+		From("acct_bank_statement_lines").
+		Where(sq.Eq{`journal_id`: journalIds})
+
+	return query.ToSql()
+}
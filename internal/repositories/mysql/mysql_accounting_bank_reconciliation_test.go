@@ -0,0 +1,455 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountingTestSuite) TestRepository_InsertBankStatement() {
+	in := models.BankStatement{
+		AccountNumber: "142001000000001",
+		Format:        models.BankStatementFormatMT940,
+		Filename:      "statement.sta",
+		ImportedBy:    "tono@amartha.com",
+		Checksum:      "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantId  int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertBankStatement)).
+					WithArgs(in.AccountNumber, in.Format, in.Filename, in.ImportedBy, in.Checksum).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+			},
+			wantId:  2,
+			wantErr: false,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertBankStatement)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			id, err := suite.repo.InsertBankStatement(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantId, id)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_CheckBankStatementIsExist() {
+	const (
+		accountNumber = "142001000000001"
+		checksum      = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryCheckBankStatementIsExist)).
+					WithArgs(accountNumber, checksum).
+					WillReturnRows(sqlmock.NewRows([]string{"is_exist"}).AddRow(true))
+			},
+			want: true,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryCheckBankStatementIsExist)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			got, err := suite.repo.CheckBankStatementIsExist(context.TODO(), accountNumber, checksum)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_InsertBankStatementLines() {
+	lineDate := time.Date(2024, time.April, 23, 0, 0, 0, 0, time.UTC)
+	matchedAt := time.Date(2024, time.April, 24, 10, 0, 0, 0, time.UTC)
+	in := []models.BankStatementLine{
+		{
+			StatementID:     2,
+			AccountNumber:   "142001000000001",
+			LineDate:        lineDate,
+			Amount:          decimal.NewFromInt(100000),
+			IsCredit:        true,
+			ReferenceNumber: "ref-1",
+			Description:     "VA repayment",
+			Status:          models.BankStatementLineStatusMatched,
+			TransactionId:   "trx-1",
+			JournalId:       "journal-1",
+			MatchType:       models.BankStatementMatchTypeAuto,
+			MatchedAt:       &matchedAt,
+		},
+		{
+			StatementID:   2,
+			AccountNumber: "142001000000001",
+			LineDate:      lineDate,
+			Amount:        decimal.NewFromInt(5000),
+			Description:   "bank charge",
+			Status:        models.BankStatementLineStatusUnmatched,
+		},
+	}
+	query := fmt.Sprintf(queryInsertBankStatementLines, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?),(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(
+						2, "142001000000001", lineDate, in[0].Amount, true, "ref-1", "VA repayment", "matched", "trx-1", "journal-1", "auto", nil, &matchedAt,
+						2, "142001000000001", lineDate, in[1].Amount, false, "", "bank charge", "unmatched", nil, nil, nil, nil, nil,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error no rows affected",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(query)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.InsertBankStatementLines(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetBankStatementLineById() {
+	query, _, _ := buildGetBankStatementLineByIdQuery(1)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		check   func(t *testing.T, out *models.BankStatementLine)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				now := time.Now()
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(bankStatementLineColumns).
+						AddRow(1, 2, "142001000000001", now, "100000", true, "ref-1", "VA repayment", "matched", "trx-1", "journal-1", "manual", "tono@amartha.com", now, now, now))
+			},
+			check: func(t *testing.T, out *models.BankStatementLine) {
+				assert.Equal(t, "journal-1", out.JournalId)
+				assert.Equal(t, "tono@amartha.com", out.MatchedBy)
+				assert.NotNil(t, out.MatchedAt)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success not found",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnError(models.ErrNoRows)
+			},
+			check: func(t *testing.T, out *models.BankStatementLine) {
+				assert.Nil(t, out)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnError(assert.AnError)
+			},
+			check: func(t *testing.T, out *models.BankStatementLine) {
+				assert.Nil(t, out)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetBankStatementLineById(context.TODO(), 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			tt.check(t, out)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetBankStatementLines() {
+	startDate := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC)
+	opts := models.BankStatementLineFilterOptions{
+		AccountNumber: "142001000000001",
+		Status:        models.BankStatementLineStatusUnmatched,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	}
+	query, _, _ := buildGetBankStatementLinesQuery(opts)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				now := time.Now()
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(opts.AccountNumber, opts.Status, startDate, endDate).
+					WillReturnRows(sqlmock.NewRows(bankStatementLineColumns).
+						AddRow(1, 2, "142001000000001", now, "100000", true, "ref-1", "VA repayment", "unmatched", nil, nil, nil, nil, nil, now, now).
+						AddRow(2, 2, "142001000000001", now, "5000", false, "", "bank charge", "unmatched", nil, nil, nil, nil, nil, now, now))
+			},
+			wantLen: 2,
+			wantErr: false,
+		},
+		{
+			name: "error scan row",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetBankStatementLines(context.TODO(), opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetBankStatementLinesByJournalIds() {
+	journalIds := []string{"journal-1", "journal-2"}
+	query, _, _ := buildGetBankStatementLinesByJournalIdsQuery(journalIds)
+
+	testCases := []struct {
+		name       string
+		journalIds []string
+		doMock     func()
+		wantLen    int
+		wantErr    bool
+	}{
+		{
+			name:       "success",
+			journalIds: journalIds,
+			doMock: func() {
+				now := time.Now()
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("journal-1", "journal-2").
+					WillReturnRows(sqlmock.NewRows(bankStatementLineColumns).
+						AddRow(1, 2, "142001000000001", now, "100000", true, "ref-1", "VA repayment", "matched", "trx-1", "journal-1", "auto", nil, now, now, now))
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
+		{
+			name:    "success without journal ids",
+			doMock:  func() {},
+			wantErr: false,
+		},
+		{
+			name:       "error database",
+			journalIds: journalIds,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetBankStatementLinesByJournalIds(context.TODO(), tt.journalIds)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_UpdateBankStatementLineMatch() {
+	matchedAt := time.Date(2024, time.April, 24, 10, 0, 0, 0, time.UTC)
+	in := models.BankStatementLine{
+		ID:            1,
+		Status:        models.BankStatementLineStatusMatched,
+		TransactionId: "trx-1",
+		JournalId:     "journal-1",
+		MatchType:     models.BankStatementMatchTypeManual,
+		MatchedBy:     "tono@amartha.com",
+		MatchedAt:     &matchedAt,
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateBankStatementLineMatch)).
+					WithArgs(in.Status, in.TransactionId, in.JournalId, in.MatchType, in.MatchedBy, in.MatchedAt, in.ID, models.BankStatementLineStatusUnmatched).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "error no rows affected",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateBankStatementLineMatch)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: models.ErrNoRowsAffected,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateBankStatementLineMatch)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyDatabaseError, assert.AnError.Error()),
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.UpdateBankStatementLineMatch(context.TODO(), in, models.BankStatementLineStatusUnmatched)
+			assert.Equal(t, tt.wantErr, err)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
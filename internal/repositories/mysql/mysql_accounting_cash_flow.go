@@ -0,0 +1,76 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+)
+
+func (ar *accountingRepository) GetCashFlowMovement(ctx context.Context, opts models.CashFlowFilterOptions, cashSubCategories []string) (out []models.CashFlowMovementOut, err error) {
+	start := atime.Now()
+
+	defer func() {
+		logSQL(ctx, err, start)
+	}()
+
+	query, args, err := buildGetCashFlowMovementQuery(opts, cashSubCategories)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data = models.CashFlowMovementOut{}
+		var errScan = rows.Scan(
+			&data.OrderType,
+			&data.TransactionType,
+			&data.TransactionTypeName,
+			&data.DebitMovement,
+			&data.CreditMovement,
+		)
+		if errScan != nil {
+			err = databaseError(errScan)
+			return
+		}
+		out = append(out, data)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
+
+func (ar *accountingRepository) GetCashBalance(ctx context.Context, entityCode, currency string, cashSubCategories []string, before time.Time) (balance decimal.Decimal, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetCashBalanceQuery(entityCode, currency, cashSubCategories, before)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&balance); err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,56 @@
+package mysql
+
+import (
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func buildGetCashFlowMovementQuery(opts models.CashFlowFilterOptions, cashSubCategories []string) (sql string, args []interface{}, err error) {
+	startDate := atime.ToZeroTime(opts.StartDate)
+	// postdate has a time, so the end date is exclusive on the next day
+	endDate := atime.ToZeroTime(opts.EndDate).AddDate(0, 0, 1)
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select([]string{
+		"ajd.order_type",
+		"ajd.transaction_type",
+		"ajd.transaction_type_name",
+		"SUM(CASE WHEN ajd.is_debit = 1 THEN s.amount ELSE 0 END) AS debit",
+		"SUM(CASE WHEN ajd.is_debit = 0 THEN s.amount ELSE 0 END) AS credit",
+	}...).From("acct_account aa").
+		Join("split_accounts sa ON sa.account_id = aa.account_number").
+		Join("splits s ON s.split_id = sa.split_id").
+		Join("acct_journal_detail ajd ON ajd.journal_id = s.split_id").
+		Join("transactions t ON t.transaction_id = s.transaction_id").
+		Where(sq.Eq{"aa.entity_code": opts.EntityCode}).
+		Where(sq.Eq{"aa.sub_category_code": cashSubCategories}).
+		Where(sq.Eq{"s.currency": opts.Currency}).
+		Where(sq.GtOrEq{"t.postdate": startDate}).
+		Where(sq.Lt{"t.postdate": endDate}).
+		GroupBy("ajd.order_type", "ajd.transaction_type", "ajd.transaction_type_name").
+		OrderBy("ajd.order_type", "ajd.transaction_type")
+
+	return query.ToSql()
+}
+
+// buildGetCashBalanceQuery sums the balance of the cash accounts before the given date.
+func buildGetCashBalanceQuery(entityCode, currency string, cashSubCategories []string, before time.Time) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(
+		"COALESCE(SUM(CASE WHEN ajd.is_debit = 1 THEN s.amount ELSE -s.amount END), 0) AS balance",
+	).From("acct_account aa").
+		Join("split_accounts sa ON sa.account_id = aa.account_number").
+		Join("splits s ON s.split_id = sa.split_id").
+		Join("acct_journal_detail ajd ON ajd.journal_id = s.split_id").
+		Join("transactions t ON t.transaction_id = s.transaction_id").
+		Where(sq.Eq{"aa.entity_code": entityCode}).
+		Where(sq.Eq{"aa.sub_category_code": cashSubCategories}).
+		Where(sq.Eq{"s.currency": currency}).
+		Where(sq.Lt{"t.postdate": atime.ToZeroTime(before)})
+
+	return query.ToSql()
+}
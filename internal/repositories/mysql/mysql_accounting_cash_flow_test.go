@@ -0,0 +1,143 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountingTestSuite) TestRepository_GetCashFlowMovement() {
+	startDate, endDate := atime.PrevMonth(atime.Now())
+	cashSubCategories := []string{"11101", "11102"}
+	opts := models.CashFlowFilterOptions{
+		EntityCode: "001",
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Currency:   models.CurrencyIDR,
+	}
+
+	type args struct {
+		ctx  context.Context
+		opts models.CashFlowFilterOptions
+	}
+	testCases := []struct {
+		name       string
+		args       args
+		setupMocks func(a args)
+		wantErr    bool
+	}{
+		{
+			name: "success case - get profit loss",
+			args: args{
+				ctx:  context.TODO(),
+				opts: opts,
+			},
+			setupMocks: func(a args) {
+				query, _, _ := buildGetCashFlowMovementQuery(a.opts, cashSubCategories)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"order_type", "transaction_type", "transaction_type_name", "debit", "credit"}).
+						AddRow("TUP", "TUPVA", "Topup VA", "100000", "0").
+						AddRow("DSB", "DSBAA", "Disbursement", "0", "40000"))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - error scan row",
+			args: args{
+				ctx:  context.TODO(),
+				opts: opts,
+			},
+			setupMocks: func(a args) {
+				query, _, _ := buildGetCashFlowMovementQuery(a.opts, cashSubCategories)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - error database",
+			args: args{
+				ctx:  context.TODO(),
+				opts: opts,
+			},
+			setupMocks: func(a args) {
+				query, _, _ := buildGetCashFlowMovementQuery(a.opts, cashSubCategories)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.setupMocks(tc.args)
+
+			_, err := suite.repo.GetCashFlowMovement(tc.args.ctx, tc.args.opts, cashSubCategories)
+			assert.Equal(t, tc.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetCashBalance() {
+	cashSubCategories := []string{"11101"}
+	startDate, _ := atime.PrevMonth(atime.Now())
+	query, _, _ := buildGetCashBalanceQuery("001", models.CurrencyIDR, cashSubCategories, startDate)
+
+	testCases := []struct {
+		name       string
+		setupMocks func()
+		want       decimal.Decimal
+		wantErr    bool
+	}{
+		{
+			name: "success case - get cash balance",
+			setupMocks: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow("150000"))
+			},
+			want:    decimal.NewFromInt(150000),
+			wantErr: false,
+		},
+		{
+			name: "error case - error database",
+			setupMocks: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.setupMocks()
+
+			got, err := suite.repo.GetCashBalance(context.TODO(), "001", models.CurrencyIDR, cashSubCategories, startDate)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.True(t, tc.want.Equal(got))
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+)
+
+func (ar *accountingRepository) InsertJournalDraft(ctx context.Context, in models.JournalDraft) (id int, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryInsertJournalDraft,
+		in.TransactionId,
+		&in.Journal,
+		in.Status,
+		in.CreatedBy,
+	)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	lastInsertId, err := res.LastInsertId()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return int(lastInsertId), nil
+}
+
+func (ar *accountingRepository) GetJournalDraftById(ctx context.Context, id int) (out *models.JournalDraft, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetJournalDraftByIdQuery(id)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	jd, err := scanJournalDraft(db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == models.ErrNoRows {
+			err = nil
+			return nil, nil
+		}
+		err = databaseError(err)
+		return nil, err
+	}
+
+	return &jd, nil
+}
+
+func (ar *accountingRepository) GetJournalDrafts(ctx context.Context, opts models.JournalDraftFilterOptions) (out []models.JournalDraft, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetJournalDraftsQuery(opts)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		jd, errScan := scanJournalDraft(rows)
+		if errScan != nil {
+			err = databaseError(errScan)
+			return
+		}
+		out = append(out, jd)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
+
+// UpdateJournalDraftStatus moves the draft from the given status, models.ErrNoRowsAffected is returned
+// when the draft is already moved by another request.
+func (ar *accountingRepository) UpdateJournalDraftStatus(ctx context.Context, in models.JournalDraft, fromStatus string) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryUpdateJournalDraftStatus,
+		in.Status,
+		in.SubmittedAt,
+		sql.NullString{String: in.ReviewedBy, Valid: in.ReviewedBy != ""},
+		in.ReviewedAt,
+		sql.NullString{String: in.RejectionReason, Valid: in.RejectionReason != ""},
+		in.ID,
+		fromStatus,
+	)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	if affectedRows == 0 {
+		err = models.ErrNoRowsAffected
+		return
+	}
+
+	return nil
+}
+
+type journalDraftScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanJournalDraft(row journalDraftScanner) (out models.JournalDraft, err error) {
+	var (
+		submittedAt     sql.NullTime
+		reviewedBy      sql.NullString
+		reviewedAt      sql.NullTime
+		rejectionReason sql.NullString
+	)
+	err = row.Scan(
+		&out.ID,
+		&out.TransactionId,
+		&out.Journal,
+		&out.Status,
+		&out.CreatedBy,
+		&submittedAt,
+		&reviewedBy,
+		&reviewedAt,
+		&rejectionReason,
+		&out.CreatedAt,
+		&out.UpdatedAt,
+	)
+	if err != nil {
+		return
+	}
+
+	if submittedAt.Valid {
+		out.SubmittedAt = &submittedAt.Time
+	}
+	if reviewedAt.Valid {
+		out.ReviewedAt = &reviewedAt.Time
+	}
+	out.ReviewedBy = reviewedBy.String
+	out.RejectionReason = rejectionReason.String
+
+	return
+}
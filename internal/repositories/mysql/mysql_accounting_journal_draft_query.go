@@ -0,0 +1,73 @@
+package mysql
+
+import (
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// query to acct_journal_drafts table
+var (
+	queryInsertJournalDraft = `
+		INSERT INTO acct_journal_drafts(
+			transaction_id,
+			journal,
+			status,
+			created_by
+		) VALUES (?, ?, ?, ?)`
+
+	queryUpdateJournalDraftStatus = `
+		UPDATE
+			acct_journal_drafts
+		SET
+			status = ?,
+			submitted_at = ?,
+			reviewed_by = ?,
+			reviewed_at = ?,
+			rejection_reason = ?,
+			updated_at = CURRENT_TIMESTAMP(6)
+		WHERE
+			id = ? AND status = ?`
+)
+
+var journalDraftColumns = []string{
+	`id`,
+	`transaction_id`,
+	`journal`,
+	`status`,
+	`created_by`,
+	`submitted_at`,
+	`reviewed_by`,
+	`reviewed_at`,
+	`rejection_reason`,
+	`created_at`,
+	`updated_at`,
+}
+
+func buildGetJournalDraftByIdQuery(id int) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(journalDraftColumns...).
+		From("acct_journal_drafts").
+		Where(sq.Eq{`id`: id})
+
+	return query.ToSql()
+}
+
+func buildGetJournalDraftsQuery(opts models.JournalDraftFilterOptions) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(journalDraftColumns...).From("acct_journal_drafts")
+
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{`status`: opts.Status})
+	}
+	if opts.CreatedBy != "" {
+		query = query.Where(sq.Eq{`created_by`: opts.CreatedBy})
+	}
+	if opts.TransactionId != "" {
+		query = query.Where(sq.Eq{`transaction_id`: opts.TransactionId})
+	}
+
+	query = query.OrderBy(`id DESC`)
+
+	return query.ToSql()
+}
@@ -0,0 +1,263 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountingTestSuite) TestRepository_InsertJournalDraft() {
+	in := models.JournalDraft{
+		TransactionId: "6de11650-dbee-4f67-9ade-ececc7a02571",
+		Journal: models.JournalDraftPayload{
+			TransactionId: "6de11650-dbee-4f67-9ade-ececc7a02571",
+			OrderType:     "ADJ",
+			Currency:      "IDR",
+		},
+		Status:    models.JournalDraftStatusDraft,
+		CreatedBy: "tono@amartha.com",
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantId  int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertJournalDraft)).
+					WithArgs(in.TransactionId, sqlmock.AnyArg(), in.Status, in.CreatedBy).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			wantId:  3,
+			wantErr: false,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertJournalDraft)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			id, err := suite.repo.InsertJournalDraft(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantId, id)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetJournalDrafts() {
+	now := time.Now()
+	opts := models.JournalDraftFilterOptions{
+		Status:    models.JournalDraftStatusSubmitted,
+		CreatedBy: "tono@amartha.com",
+	}
+	query, _, _ := buildGetJournalDraftsQuery(opts)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		check   func(t *testing.T, out []models.JournalDraft)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(opts.Status, opts.CreatedBy).
+					WillReturnRows(sqlmock.NewRows(journalDraftColumns).
+						AddRow(2, "trx-2", []byte(`{"transactionId":"trx-2","currency":"IDR","transactions":[{"account":"111001000000001","amount":"1000","isDebit":true}]}`),
+							"rejected", "tono@amartha.com", now, "budi@amartha.com", now, "wrong account", now, now).
+						AddRow(1, "trx-1", []byte(`{"transactionId":"trx-1"}`),
+							"draft", "tono@amartha.com", nil, nil, nil, nil, now, now))
+			},
+			check: func(t *testing.T, out []models.JournalDraft) {
+				assert.Len(t, out, 2)
+				assert.Len(t, out[0].Journal.Transactions, 1)
+				assert.Equal(t, "budi@amartha.com", out[0].ReviewedBy)
+				assert.Equal(t, "wrong account", out[0].RejectionReason)
+				assert.NotNil(t, out[0].ReviewedAt)
+				assert.Nil(t, out[1].SubmittedAt)
+				assert.Empty(t, out[1].ReviewedBy)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error scan row",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetJournalDrafts(context.TODO(), opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.check != nil {
+				tt.check(t, out)
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetJournalDraftById() {
+	query, _, _ := buildGetJournalDraftByIdQuery(1)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				now := time.Now()
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(journalDraftColumns).
+						AddRow(1, "trx-1", []byte(`{}`), "submitted", "tono@amartha.com", now, nil, nil, nil, now, now))
+			},
+			wantErr: false,
+		},
+		{
+			name: "success not found",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnError(models.ErrNoRows)
+			},
+			wantNil: true,
+			wantErr: false,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnError(assert.AnError)
+			},
+			wantNil: true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetJournalDraftById(context.TODO(), 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNil, out == nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_UpdateJournalDraftStatus() {
+	reviewedAt := time.Date(2024, time.February, 1, 10, 0, 0, 0, time.UTC)
+	in := models.JournalDraft{
+		ID:         1,
+		Status:     models.JournalDraftStatusApproved,
+		ReviewedBy: "budi@amartha.com",
+		ReviewedAt: &reviewedAt,
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateJournalDraftStatus)).
+					WithArgs(in.Status, nil, in.ReviewedBy, in.ReviewedAt, nil, in.ID, models.JournalDraftStatusSubmitted).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "error no rows affected",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateJournalDraftStatus)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: models.ErrNoRowsAffected,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateJournalDraftStatus)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyDatabaseError, assert.AnError.Error()),
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.UpdateJournalDraftStatus(context.TODO(), in, models.JournalDraftStatusSubmitted)
+			assert.Equal(t, tt.wantErr, err)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+)
+
+// GetLedgerAccountMovements returns the debit & credit movements of the entity accounts from the splits
+// whose transaction date is at or after the start date & before the end date.
+func (ar *accountingRepository) GetLedgerAccountMovements(ctx context.Context, entityCode string, startDate, endDate time.Time) (out []models.LedgerAccountMovement, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetLedgerAccountMovementsQuery(entityCode, startDate, endDate)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.LedgerAccountMovement
+		if err = rows.Scan(
+			&v.AccountNumber,
+			&v.EntityCode,
+			&v.CategoryCode,
+			&v.SubCategoryCode,
+			&v.Currency,
+			&v.DebitMovement,
+			&v.CreditMovement,
+		); err != nil {
+			err = databaseError(err)
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
+
+func (ar *accountingRepository) GetAccountDailyBalanceByEntity(ctx context.Context, entityCode string, date time.Time) (out []models.AccountBalanceDaily, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetAccountDailyBalanceByEntityQuery(entityCode, date)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := models.AccountBalanceDaily{BalanceDate: date}
+		if err = rows.Scan(
+			&v.AccountNumber,
+			&v.EntityCode,
+			&v.CategoryCode,
+			&v.SubCategoryCode,
+			&v.Currency,
+			&v.DebitMovement,
+			&v.CreditMovement,
+			&v.OpeningBalance,
+			&v.ClosingBalance,
+		); err != nil {
+			err = databaseError(err)
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
+
+func (ar *accountingRepository) GetAccountTrialBalanceByEntity(ctx context.Context, entityCode string, date time.Time) (out []models.AccountTrialBalance, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetAccountTrialBalanceByEntityQuery(entityCode, date)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := models.AccountTrialBalance{ClosingDate: date}
+		if err = rows.Scan(
+			&v.EntityCode,
+			&v.CategoryCode,
+			&v.SubCategoryCode,
+			&v.Currency,
+			&v.DebitMovement,
+			&v.CreditMovement,
+			&v.OpeningBalance,
+			&v.ClosingBalance,
+		); err != nil {
+			err = databaseError(err)
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
+
+// GetUnbalancedTransactions returns the transactions of the entity within the dates whose debit & credit are not equal.
+func (ar *accountingRepository) GetUnbalancedTransactions(ctx context.Context, entityCode string, startDate, endDate time.Time) (out []models.UnbalancedTransaction, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetUnbalancedTransactionsQuery(entityCode, startDate, endDate)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v models.UnbalancedTransaction
+		if err = rows.Scan(
+			&v.TransactionId,
+			&v.TransactionDate,
+			&v.Currency,
+			&v.Debit,
+			&v.Credit,
+		); err != nil {
+			err = databaseError(err)
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
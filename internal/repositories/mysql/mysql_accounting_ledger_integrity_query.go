@@ -0,0 +1,111 @@
+package mysql
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// buildGetLedgerAccountMovementsQuery sums the splits of the entity accounts whose transaction date is within the dates,
+// every split before the end date is summed when the start date is not set.
+func buildGetLedgerAccountMovementsQuery(entityCode string, startDate, endDate time.Time) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(
+		`aa.account_number`,
+		`aa.entity_code`,
+		`aa.category_code`,
+		`aa.sub_category_code`,
+		`s.currency`,
+		`SUM(CASE WHEN ajd.is_debit = 1 THEN s.amount ELSE 0 END) AS debit`,
+		`SUM(CASE WHEN ajd.is_debit = 0 THEN s.amount ELSE 0 END) AS credit`,
+	).
+		From("acct_account aa").
+		Join("split_accounts sa ON sa.account_id = aa.account_number").
+		Join("splits s ON s.split_id = sa.split_id").
+		Join("acct_journal_detail ajd ON ajd.journal_id = s.split_id").
+		Where(sq.Eq{`aa.entity_code`: entityCode}).
+		Where(sq.Lt{`ajd.transaction_date`: endDate})
+	if !startDate.IsZero() {
+		query = query.Where(sq.GtOrEq{`ajd.transaction_date`: startDate})
+	}
+	query = query.
+		GroupBy(`aa.account_number`, `aa.entity_code`, `aa.category_code`, `aa.sub_category_code`, `s.currency`).
+		OrderBy(`aa.account_number ASC`)
+
+	return query.ToSql()
+}
+
+func buildGetAccountDailyBalanceByEntityQuery(entityCode string, date time.Time) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(
+		`aadb.account_number`,
+		`aadb.entity_code`,
+		`aadb.category_code`,
+		`aadb.sub_category_code`,
+		`aadb.currency`,
+		`aadb.debit_movement`,
+		`aadb.credit_movement`,
+		`aadb.opening_balance`,
+		`aadb.closing_balance`,
+	).
+		From("acct_account_daily_balance aadb").
+		Where(sq.Eq{`aadb.entity_code`: entityCode}).
+		Where(sq.Eq{`aadb.balance_date`: date}).
+		OrderBy(`aadb.account_number ASC`)
+
+	return query.ToSql()
+}
+
+func buildGetAccountTrialBalanceByEntityQuery(entityCode string, date time.Time) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(
+		`aatb.entity_code`,
+		`aatb.category_code`,
+		`aatb.sub_category_code`,
+		`aatb.currency`,
+		`aatb.debit_movement`,
+		`aatb.credit_movement`,
+		`aatb.opening_balance`,
+		`aatb.closing_balance`,
+	).
+		From("acct_account_trial_balance aatb").
+		Where(sq.Eq{`aatb.entity_code`: entityCode}).
+		Where(sq.Eq{`aatb.closing_date`: date}).
+		OrderBy(`aatb.sub_category_code ASC`, `aatb.currency ASC`)
+
+	return query.ToSql()
+}
+
+// buildGetUnbalancedTransactionsQuery nets every split of the transactions within the dates which have a split
+// in the entity, so an inter-entity transaction is checked as a whole.
+func buildGetUnbalancedTransactionsQuery(entityCode string, startDate, endDate time.Time) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	entityTransactions := psql.Select(`s2.transaction_id`).
+		From("splits s2").
+		Join("split_accounts sa2 ON sa2.split_id = s2.split_id").
+		Join("acct_account aa2 ON aa2.account_number = sa2.account_id").
+		Where(sq.Eq{`aa2.entity_code`: entityCode})
+	entitySql, entityArgs, err := entityTransactions.ToSql()
+	if err != nil {
+		return
+	}
+
+	query := psql.Select(
+		`t.transaction_id`,
+		`MIN(ajd.transaction_date) AS transaction_date`,
+		`s.currency`,
+		`SUM(CASE WHEN ajd.is_debit = 1 THEN s.amount ELSE 0 END) AS debit`,
+		`SUM(CASE WHEN ajd.is_debit = 0 THEN s.amount ELSE 0 END) AS credit`,
+	).
+		From("transactions t").
+		Join("splits s ON s.transaction_id = t.transaction_id").
+		Join("acct_journal_detail ajd ON ajd.journal_id = s.split_id").
+		Where(sq.GtOrEq{`ajd.transaction_date`: startDate}).
+		Where(sq.Lt{`ajd.transaction_date`: endDate}).
+		Where("t.transaction_id IN ("+entitySql+")", entityArgs...).
+		GroupBy(`t.transaction_id`, `s.currency`).
+		Having("debit <> credit").
+		OrderBy(`transaction_date ASC`, `t.transaction_id ASC`)
+
+	return query.ToSql()
+}
@@ -0,0 +1,266 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountingTestSuite) TestRepository_GetLedgerAccountMovements() {
+	startDate := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 0, 1)
+	columns := []string{"account_number", "entity_code", "category_code", "sub_category_code", "currency", "debit", "credit"}
+
+	testCases := []struct {
+		name      string
+		startDate time.Time
+		doMock    func(query string)
+		wantLen   int
+		wantErr   bool
+	}{
+		{
+			name:      "success",
+			startDate: startDate,
+			doMock: func(query string) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("001", endDate, startDate).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("142001000000001", "001", "142", "14201", "IDR", "100000", "0"))
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
+		{
+			name: "success without start date",
+			doMock: func(query string) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("001", endDate).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("142001000000001", "001", "142", "14201", "IDR", "100000", "0").
+						AddRow("212001000000001", "001", "212", "21201", "IDR", "0", "100000"))
+			},
+			wantLen: 2,
+			wantErr: false,
+		},
+		{
+			name:      "error database",
+			startDate: startDate,
+			doMock: func(query string) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "error row scan",
+			startDate: startDate,
+			doMock: func(query string) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"account_number"}).AddRow("142001000000001"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			query, _, _ := buildGetLedgerAccountMovementsQuery("001", tt.startDate, endDate)
+			tt.doMock(query)
+
+			out, err := suite.repo.GetLedgerAccountMovements(context.TODO(), "001", tt.startDate, endDate)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetAccountDailyBalanceByEntity() {
+	date := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	query, _, _ := buildGetAccountDailyBalanceByEntityQuery("001", date)
+	columns := []string{"account_number", "entity_code", "category_code", "sub_category_code", "currency", "debit_movement", "credit_movement", "opening_balance", "closing_balance"}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("001", date).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("142001000000001", "001", "142", "14201", "IDR", "100000", "0", "0", "100000"))
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error row scan",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"account_number"}).AddRow("142001000000001"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetAccountDailyBalanceByEntity(context.TODO(), "001", date)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetAccountTrialBalanceByEntity() {
+	date := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	query, _, _ := buildGetAccountTrialBalanceByEntityQuery("001", date)
+	columns := []string{"entity_code", "category_code", "sub_category_code", "currency", "debit_movement", "credit_movement", "opening_balance", "closing_balance"}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("001", date).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("001", "142", "14201", "IDR", "100000", "0", "0", "100000"))
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error row scan",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"entity_code"}).AddRow("001"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetAccountTrialBalanceByEntity(context.TODO(), "001", date)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetUnbalancedTransactions() {
+	startDate := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 0, 1)
+	query, _, _ := buildGetUnbalancedTransactionsQuery("001", startDate, endDate)
+	columns := []string{"transaction_id", "transaction_date", "currency", "debit", "credit"}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(startDate, endDate, "001").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("6de11650-dbee-4f67-9ade-ececc7a02571", startDate, "IDR", "100000", "90000"))
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error row scan",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}).AddRow("6de11650-dbee-4f67-9ade-ececc7a02571"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetUnbalancedTransactions(context.TODO(), "001", startDate, endDate)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+)
+
+func (ar *accountingRepository) GetProfitLoss(ctx context.Context, opts models.ProfitLossFilterOptions) (out []models.ProfitLossPerSubCategoryOut, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetProfitLossQuery(opts)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data = models.ProfitLossPerSubCategoryOut{}
+		var errScan = rows.Scan(
+			&data.COAType,
+			&data.CategoryCode,
+			&data.CategoryName,
+			&data.SubCategoryCode,
+			&data.SubCategoryName,
+			&data.DebitMovement,
+			&data.CreditMovement,
+		)
+		if errScan != nil {
+			err = databaseError(errScan)
+			return
+		}
+		out = append(out, data)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
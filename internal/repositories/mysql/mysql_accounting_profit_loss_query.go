@@ -0,0 +1,76 @@
+package mysql
+
+import (
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// buildGetProfitLossQuery sums the movements of the income & expense sub categories from acct_account_trial_balance,
+// the year end closing journals are included in the trial balance so their movements are subtracted from the splits.
+func buildGetProfitLossQuery(opts models.ProfitLossFilterOptions) (sql string, args []interface{}, err error) {
+	startDate := atime.ToZeroTime(opts.StartDate)
+	endDate := atime.ToZeroTime(opts.EndDate)
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	movements := psql.Select([]string{
+		"aatb.category_code",
+		"aatb.sub_category_code",
+		"aatb.debit_movement",
+		"aatb.credit_movement",
+	}...).From("acct_account_trial_balance aatb").
+		Where(sq.Eq{"aatb.entity_code": opts.EntityCode}).
+		Where(sq.Eq{"aatb.currency": opts.Currency}).
+		Where(sq.GtOrEq{"aatb.closing_date": startDate}).
+		Where(sq.LtOrEq{"aatb.closing_date": endDate})
+	if opts.ClosingOrderType != "" {
+		closingSql, closingArgs, errClosing := buildGetProfitLossClosingMovementsQuery(opts, startDate, endDate.AddDate(0, 0, 1))
+		if errClosing != nil {
+			return "", nil, errClosing
+		}
+		movements = movements.Suffix("UNION ALL "+closingSql, closingArgs...)
+	}
+
+	query := psql.Select([]string{
+		"ac.coa_type_code",
+		"m.category_code",
+		"ac.name",
+		"m.sub_category_code",
+		"asc2.name",
+		"sum(m.debit_movement)",
+		"sum(m.credit_movement)",
+	}...).FromSelect(movements, "m").
+		Join("acct_category ac on m.category_code = ac.code").
+		Join("acct_sub_category asc2 on m.sub_category_code = asc2.code").
+		Where(sq.Eq{"ac.coa_type_code": []string{models.COATypeIncome, models.COATypeExpense}}).
+		GroupBy("ac.coa_type_code", "m.category_code", "ac.name", "m.sub_category_code", "asc2.name").
+		OrderBy("m.category_code", "m.sub_category_code")
+
+	return query.ToSql()
+}
+
+// buildGetProfitLossClosingMovementsQuery returns the negated movements of the year end closing journals within the dates,
+// the end date is exclusive.
+func buildGetProfitLossClosingMovementsQuery(opts models.ProfitLossFilterOptions, startDate, endDate time.Time) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select([]string{
+		"aa.category_code",
+		"aa.sub_category_code",
+		"-SUM(CASE WHEN ajd.is_debit = 1 THEN s.amount ELSE 0 END)",
+		"-SUM(CASE WHEN ajd.is_debit = 0 THEN s.amount ELSE 0 END)",
+	}...).From("acct_account aa").
+		Join("split_accounts sa ON sa.account_id = aa.account_number").
+		Join("splits s ON s.split_id = sa.split_id").
+		Join("acct_journal_detail ajd ON ajd.journal_id = s.split_id").
+		Where(sq.Eq{"aa.entity_code": opts.EntityCode}).
+		Where(sq.Eq{"s.currency": opts.Currency}).
+		Where(sq.Eq{"ajd.order_type": opts.ClosingOrderType}).
+		Where(sq.GtOrEq{"ajd.transaction_date": startDate}).
+		Where(sq.Lt{"ajd.transaction_date": endDate}).
+		GroupBy("aa.category_code", "aa.sub_category_code")
+
+	return query.ToSql()
+}
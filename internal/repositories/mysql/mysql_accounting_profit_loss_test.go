@@ -0,0 +1,132 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountingTestSuite) TestRepository_GetProfitLoss() {
+	startDate, endDate := atime.PrevMonth(atime.Now())
+	opts := models.ProfitLossFilterOptions{
+		EntityCode: "001",
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Currency:   models.CurrencyIDR,
+	}
+
+	type args struct {
+		ctx  context.Context
+		opts models.ProfitLossFilterOptions
+	}
+	testCases := []struct {
+		name       string
+		args       args
+		setupMocks func(a args)
+		wantErr    bool
+	}{
+		{
+			name: "success case - get profit loss",
+			args: args{
+				ctx:  context.TODO(),
+				opts: opts,
+			},
+			setupMocks: func(a args) {
+				query, _, _ := buildGetProfitLossQuery(a.opts)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{
+						"ac.coa_type_code",
+						"aatb.category_code",
+						"ac.name",
+						"aatb.sub_category_code",
+						"asc2.name",
+						"sum(aatb.debit_movement)",
+						"sum(aatb.credit_movement)",
+					}).
+						AddRow("INC", "411", "Interest Income", "41101", "Interest Income Group Loan", "0", "100000").
+						AddRow("EXP", "511", "Operational Expense", "51101", "Salary", "40000", "0"))
+			},
+			wantErr: false,
+		},
+		{
+			name: "success case - year end closing journals are excluded",
+			args: args{
+				ctx: context.TODO(),
+				opts: models.ProfitLossFilterOptions{
+					EntityCode:       "001",
+					StartDate:        startDate,
+					EndDate:          endDate,
+					Currency:         models.CurrencyIDR,
+					ClosingOrderType: "YEC",
+				},
+			},
+			setupMocks: func(a args) {
+				query, _, _ := buildGetProfitLossQuery(a.opts)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("001", models.CurrencyIDR, startDate, atime.ToZeroTime(endDate),
+						"001", models.CurrencyIDR, "YEC", startDate, atime.ToZeroTime(endDate).AddDate(0, 0, 1),
+						models.COATypeIncome, models.COATypeExpense).
+					WillReturnRows(sqlmock.NewRows([]string{
+						"ac.coa_type_code",
+						"m.category_code",
+						"ac.name",
+						"m.sub_category_code",
+						"asc2.name",
+						"sum(m.debit_movement)",
+						"sum(m.credit_movement)",
+					}).
+						AddRow("INC", "411", "Interest Income", "41101", "Interest Income Group Loan", "0", "100000"))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - error scan row",
+			args: args{
+				ctx:  context.TODO(),
+				opts: opts,
+			},
+			setupMocks: func(a args) {
+				query, _, _ := buildGetProfitLossQuery(a.opts)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - error database",
+			args: args{
+				ctx:  context.TODO(),
+				opts: opts,
+			},
+			setupMocks: func(a args) {
+				query, _, _ := buildGetProfitLossQuery(a.opts)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.setupMocks(tc.args)
+
+			_, err := suite.repo.GetProfitLoss(tc.args.ctx, tc.args.opts)
+			assert.Equal(t, tc.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -19,7 +19,7 @@ var (
 	) AS is_exist;`
 
 	queryInsertJournalDetail = `
-		INSERT INTO acct_journal_detail(journal_id, reference_number, order_type, transaction_type, transaction_type_name, transaction_date, is_debit, created_at, updated_at, metadata) VALUES %s`
+		INSERT INTO acct_journal_detail(journal_id, reference_number, order_type, transaction_type, transaction_type_name, transaction_date, is_debit, created_at, updated_at, metadata, reversed_journal_id) VALUES %s`
 
 	queryInsertTransaction = `
 		INSERT INTO transactions(transaction_id, postdate, poster_user_id) VALUES %s`
@@ -77,12 +77,14 @@ var (
     debit_movement,
     credit_movement,
     opening_balance,
-    closing_balance
+    closing_balance,
+    currency
 ) VALUES %s AS new_data
 ON DUPLICATE KEY UPDATE
     entity_code = new_data.entity_code,
 	category_code = new_data.category_code,
     sub_category_code = new_data.sub_category_code,
+    currency = new_data.currency,
     debit_movement = new_data.debit_movement,
     credit_movement = new_data.credit_movement,
     opening_balance = new_data.opening_balance,
@@ -182,7 +184,8 @@ ON DUPLICATE KEY UPDATE
         debit_movement,
         credit_movement,
         opening_balance,
-        closing_balance) VALUES %s 
+        closing_balance,
+        currency) VALUES %s 
 		AS new_data
         ON DUPLICATE KEY UPDATE 
             closing_date = new_data.closing_date,
@@ -208,6 +211,18 @@ ON DUPLICATE KEY UPDATE
         aadb.closing_balance
     FROM acct_account_daily_balance aadb
     WHERE aadb.entity_code IN (%s) AND aadb.sub_category_code = ? AND aadb.balance_date = ?
+    `
+
+	queryGetForeignCurrencyClosingBalance = `
+    SELECT
+		aadb.entity_code,
+		aadb.category_code,
+		aadb.sub_category_code,
+		aadb.currency,
+		SUM(aadb.closing_balance) closing_balance
+    FROM acct_account_daily_balance aadb
+    WHERE aadb.balance_date = ? AND aadb.currency <> ?
+    GROUP BY aadb.entity_code, aadb.category_code, aadb.sub_category_code, aadb.currency
     `
 
 	queryGetOneSplitAccount = `
@@ -276,10 +291,10 @@ func buildFilteredTrialBalanceQuery(cols []string, opts models.TrialBalanceFilte
 		opts.StartDate, opts.EndDate)
 
 	query = query.Column(
-		`SUM(CASE WHEN t.postdate < ? and (dtl.is_debit=1 and act.code = 'AST' or dtl.is_debit=0 and act.code = 'LIA') then s.amount ELSE 0 END) - SUM(CASE WHEN t.postdate < ? and (dtl.is_debit=0 and act.code = 'AST' or dtl.is_debit=1 and act.code = 'LIA') then s.amount ELSE 0 END) as opening`,
+		`SUM(CASE WHEN t.postdate < ? and (dtl.is_debit=1 and act.code IN ('AST', 'EXP') or dtl.is_debit=0 and act.code IN ('LIA', 'INC')) then s.amount ELSE 0 END) - SUM(CASE WHEN t.postdate < ? and (dtl.is_debit=0 and act.code IN ('AST', 'EXP') or dtl.is_debit=1 and act.code IN ('LIA', 'INC')) then s.amount ELSE 0 END) as opening`,
 		opts.StartDate, opts.StartDate)
 	query = query.Column(
-		`SUM(CASE WHEN t.postdate <= ? and (dtl.is_debit=1 and act.code = 'AST' or dtl.is_debit=0 and act.code = 'LIA') then s.amount ELSE 0 END) - SUM(CASE WHEN t.postdate <= ? and (dtl.is_debit=0 and act.code = 'AST' or dtl.is_debit=1 and act.code = 'LIA') then s.amount ELSE 0 END) as closing`,
+		`SUM(CASE WHEN t.postdate <= ? and (dtl.is_debit=1 and act.code IN ('AST', 'EXP') or dtl.is_debit=0 and act.code IN ('LIA', 'INC')) then s.amount ELSE 0 END) - SUM(CASE WHEN t.postdate <= ? and (dtl.is_debit=0 and act.code IN ('AST', 'EXP') or dtl.is_debit=1 and act.code IN ('LIA', 'INC')) then s.amount ELSE 0 END) as closing`,
 		opts.EndDate, opts.EndDate)
 
 	query = query.Where(sq.Eq{`aa.entity_code`: opts.EntityCode})
@@ -326,8 +341,11 @@ func buildSubLedgerQuery(opts models.SubLedgerFilterOptions) (sql string, args [
 		`case when ajd.is_debit = FALSE then s.amount else 0 end as credit`,
 		`ajd.created_at`,
 		`ajd.updated_at`,
+		`s.split_id`,
+		`c.decimals`,
 	}
 	query := buildFilteredSubLedgerQuery(columns, opts)
+	query = query.Join("currencies c ON c.name = s.currency")
 
 	if opts.AfterCreatedAt != nil {
 		query = query.Where(sq.Lt{`ajd.transaction_date`: opts.AfterCreatedAt})
@@ -407,8 +425,8 @@ func getAccountBalancePeriodStart(accountNumber string, date time.Time) (sql str
 	query = query.LeftJoin("transactions t ON t.transaction_id = s.transaction_id")
 	query = query.LeftJoin("acct_journal_detail ajd ON ajd.journal_id = s.split_id")
 	query = query.Column(`
-		SUM(CASE WHEN t.postdate < ? and (ajd.is_debit=1 and ac.coa_type_code = 'AST' or ajd.is_debit=0 and ac.coa_type_code = 'LIA') then s.amount ELSE 0 END) - 
-		SUM(CASE WHEN t.postdate < ? and (ajd.is_debit=0 and ac.coa_type_code = 'AST' or ajd.is_debit=1 and ac.coa_type_code = 'LIA') then s.amount ELSE 0 END) as balance_period_start`,
+		SUM(CASE WHEN t.postdate < ? and (ajd.is_debit=1 and ac.coa_type_code IN ('AST', 'EXP') or ajd.is_debit=0 and ac.coa_type_code IN ('LIA', 'INC')) then s.amount ELSE 0 END) - 
+		SUM(CASE WHEN t.postdate < ? and (ajd.is_debit=0 and ac.coa_type_code IN ('AST', 'EXP') or ajd.is_debit=1 and ac.coa_type_code IN ('LIA', 'INC')) then s.amount ELSE 0 END) as balance_period_start`,
 		date,
 		date,
 	)
@@ -446,9 +464,15 @@ func getJournalDetailQuery(transactionId string) (sql string, args []interface{}
 		`ajd.transaction_date`,
 		`COALESCE(s.description, '') AS narrative`,
 		`ajd.is_debit`,
+		`ajd.reference_number`,
+		`ajd.order_type`,
+		`COALESCE(ajd.reversed_journal_id, '') reversed_journal_id`,
+		`s.currency`,
+		`c.decimals`,
 	}...).FromSelect(subQuery, "aa")
 	query = query.Join("split_accounts sa ON sa.account_id = aa.account_number")
 	query = query.Join("splits s ON s.split_id = sa.split_id")
+	query = query.Join("currencies c ON c.name = s.currency")
 	query = query.Join("acct_journal_detail ajd ON ajd.journal_id = s.split_id")
 	query = query.Join("transactions t ON t.transaction_id = s.transaction_id")
 	query = query.Where(sq.Eq{`t.transaction_id `: transactionId})
@@ -457,6 +481,15 @@ func getJournalDetailQuery(transactionId string) (sql string, args []interface{}
 	return query.ToSql()
 }
 
+func getReversedJournalIdsQuery(journalIds []string) (sql string, args []interface{}, err error) {
+	query := sq.StatementBuilder.PlaceholderFormat(sq.Question).
+		Select(`ajd.reversed_journal_id`).
+		From("acct_journal_detail ajd").
+		Where(sq.Eq{`ajd.reversed_journal_id`: journalIds})
+
+	return query.ToSql()
+}
+
 func buildSubLedgerAccountsQuery(opts models.SubLedgerAccountsFilterOptions) (sql string, args []interface{}, err error) {
 	query := sq.StatementBuilder.PlaceholderFormat(sq.Question).
 		Select([]string{
@@ -557,10 +590,10 @@ func buildCalculateOpeningClosingBalance(accountNumber string, date time.Time) (
 	query = query.GroupBy(`ac.account_number`)
 
 	query = query.Column(
-		`SUM(CASE WHEN t.postdate < ? AND (ajd.is_debit=1 AND ac.coa_type_code = 'AST' OR ajd.is_debit=0 AND ac.coa_type_code = 'LIA') THEN s.amount ELSE 0 END) - SUM(CASE WHEN t.postdate < ? AND (ajd.is_debit=0 AND ac.coa_type_code = 'AST' OR ajd.is_debit=1 AND ac.coa_type_code = 'LIA') THEN s.amount ELSE 0 END) opening_balance`,
+		`SUM(CASE WHEN t.postdate < ? AND (ajd.is_debit=1 AND ac.coa_type_code IN ('AST', 'EXP') OR ajd.is_debit=0 AND ac.coa_type_code IN ('LIA', 'INC')) THEN s.amount ELSE 0 END) - SUM(CASE WHEN t.postdate < ? AND (ajd.is_debit=0 AND ac.coa_type_code IN ('AST', 'EXP') OR ajd.is_debit=1 AND ac.coa_type_code IN ('LIA', 'INC')) THEN s.amount ELSE 0 END) opening_balance`,
 		end, end)
 	query = query.Column(
-		`SUM(CASE WHEN t.postdate <= ? AND (ajd.is_debit=1 AND ac.coa_type_code = 'AST' OR ajd.is_debit=0 AND ac.coa_type_code = 'LIA') THEN s.amount ELSE 0 END) - SUM(CASE WHEN t.postdate <= ? AND (ajd.is_debit=0 AND ac.coa_type_code = 'AST' OR ajd.is_debit=1 AND ac.coa_type_code = 'LIA') THEN s.amount ELSE 0 END) opening_balance`,
+		`SUM(CASE WHEN t.postdate <= ? AND (ajd.is_debit=1 AND ac.coa_type_code IN ('AST', 'EXP') OR ajd.is_debit=0 AND ac.coa_type_code IN ('LIA', 'INC')) THEN s.amount ELSE 0 END) - SUM(CASE WHEN t.postdate <= ? AND (ajd.is_debit=0 AND ac.coa_type_code IN ('AST', 'EXP') OR ajd.is_debit=1 AND ac.coa_type_code IN ('LIA', 'INC')) THEN s.amount ELSE 0 END) opening_balance`,
 		end, end)
 
 	return query.ToSql()
@@ -712,16 +745,20 @@ func buildGetListTrialBalanceDetailQuery(opts models.TrialBalanceDetailsFilterOp
 		"substring_index(group_concat(cast(coalesce(closing_balance, 0) as CHAR) order by balance_date desc), ',', 1 ) as last_closing_balance",
 		"sum(coalesce(debit_movement, 0)) as debit_movement",
 		"sum(coalesce(credit_movement, 0)) as credit_movement",
+		"c.decimals",
 	}
 
+	currency := filterCurrency(opts.Currency)
 	query := psql.Select(aggregatedCols...).
 		Prefix(fmt.Sprintf("with tb_account as (%s) ", cteQuery), cteArgs...).
 		From("tb_account").
 		LeftJoin("acct_account_daily_balance balance_daily "+
 			"on tb_account.account_number = balance_daily.account_number and "+
+			"balance_daily.currency = ? and "+
 			"balance_date >= ? and "+
-			"balance_date <= ?", start, end).
-		GroupBy("tb_account.account_number", "tb_account.name")
+			"balance_date <= ?", currency, start, end).
+		Join("currencies c ON c.name = ?", currency).
+		GroupBy("tb_account.account_number", "tb_account.name", "c.decimals")
 
 	if opts.CursorValue != nil && opts.IsBackward {
 		query = query.OrderBy("tb_account.account_number DESC")
@@ -752,6 +789,7 @@ func buildGetTrialBalanceV2Query(opts models.TrialBalanceFilterOptions) (sql str
 		end)
 
 	query = query.Where(sq.Eq{`aatb.entity_code`: opts.EntityCode})
+	query = query.Where(sq.Eq{`aatb.currency`: filterCurrency(opts.Currency)})
 	query = query.Where(sq.GtOrEq{`aatb.closing_date`: start})
 	query = query.Where(sq.LtOrEq{`aatb.closing_date`: end})
 	query = query.Where(`EXISTS (SELECT 1 FROM acct_account aa WHERE aa.sub_category_code = aatb.sub_category_code AND aa.entity_code = aatb.entity_code)`)
@@ -775,28 +813,33 @@ func buildGetTrialBalanceSubCategory(opts models.TrialBalanceFilterOptions) (sql
 		).
 		Column(`SUM(CASE WHEN aatb.closing_date = ? THEN aatb.opening_balance ELSE 0 END) opening_balance`, start).
 		Column(`SUM(CASE WHEN aatb.closing_date = ? THEN aatb.closing_balance ELSE 0 END) closing_balance`, end).
+		Column(`c.decimals`).
 		From("acct_sub_category sub_category")
 
+	currency := filterCurrency(opts.Currency)
 	if opts.EntityCode != "" {
 		query = query.
 			LeftJoin("acct_account_trial_balance aatb on "+
 				"sub_category.code = aatb.sub_category_code and "+
 				"aatb.entity_code = ? and "+
+				"aatb.currency = ? and "+
 				"aatb.closing_date >= ? and "+
-				"aatb.closing_date <= ?", opts.EntityCode, start, end)
+				"aatb.closing_date <= ?", opts.EntityCode, currency, start, end)
 	} else {
 		query = query.
 			LeftJoin("acct_account_trial_balance aatb on "+
 				"sub_category.code = aatb.sub_category_code and "+
+				"aatb.currency = ? and "+
 				"aatb.closing_date >= ? and "+
-				"aatb.closing_date <= ?", start, end)
+				"aatb.closing_date <= ?", currency, start, end)
 	}
+	query = query.Join("currencies c ON c.name = ?", currency)
 
 	if opts.SubCategoryCode != "" {
 		query = query.Where(sq.Eq{`sub_category.code`: opts.SubCategoryCode})
 	}
 
-	query = query.GroupBy("sub_category.code", "sub_category.name")
+	query = query.GroupBy("sub_category.code", "sub_category.name", "c.decimals")
 
 	return query.ToSql()
 }
@@ -0,0 +1,175 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+)
+
+func (ar *accountingRepository) InsertRecurringJournal(ctx context.Context, in models.RecurringJournal) (id int, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryInsertRecurringJournal,
+		in.Name,
+		in.OrderType,
+		in.Currency,
+		&in.Transactions,
+		in.Frequency,
+		in.StartDate,
+		in.EndDate,
+		in.RemainingCount,
+		in.RunCount,
+		in.NextRunDate,
+		in.Status,
+		in.CreatedBy,
+	)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	lastInsertId, err := res.LastInsertId()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return int(lastInsertId), nil
+}
+
+func (ar *accountingRepository) GetRecurringJournalById(ctx context.Context, id int) (out *models.RecurringJournal, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetRecurringJournalByIdQuery(id)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rj, err := scanRecurringJournal(db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == models.ErrNoRows {
+			err = nil
+			return nil, nil
+		}
+		err = databaseError(err)
+		return nil, err
+	}
+
+	return &rj, nil
+}
+
+func (ar *accountingRepository) GetRecurringJournals(ctx context.Context, opts models.RecurringJournalFilterOptions) (out []models.RecurringJournal, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetRecurringJournalsQuery(opts)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rj, errScan := scanRecurringJournal(rows)
+		if errScan != nil {
+			err = databaseError(errScan)
+			return
+		}
+		out = append(out, rj)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
+
+func (ar *accountingRepository) UpdateRecurringJournalSchedule(ctx context.Context, in models.RecurringJournal) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryUpdateRecurringJournalSchedule,
+		in.RemainingCount,
+		in.RunCount,
+		in.NextRunDate,
+		in.Status,
+		in.ID,
+	)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	if affectedRows == 0 {
+		err = databaseError(models.ErrNoRowsAffected)
+		return
+	}
+
+	return nil
+}
+
+type recurringJournalScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRecurringJournal(row recurringJournalScanner) (out models.RecurringJournal, err error) {
+	var (
+		endDate        sql.NullTime
+		remainingCount sql.NullInt64
+	)
+	err = row.Scan(
+		&out.ID,
+		&out.Name,
+		&out.OrderType,
+		&out.Currency,
+		&out.Transactions,
+		&out.Frequency,
+		&out.StartDate,
+		&endDate,
+		&remainingCount,
+		&out.RunCount,
+		&out.NextRunDate,
+		&out.Status,
+		&out.CreatedBy,
+		&out.CreatedAt,
+		&out.UpdatedAt,
+	)
+	if err != nil {
+		return
+	}
+
+	if endDate.Valid {
+		out.EndDate = &endDate.Time
+	}
+	if remainingCount.Valid {
+		count := int(remainingCount.Int64)
+		out.RemainingCount = &count
+	}
+
+	return
+}
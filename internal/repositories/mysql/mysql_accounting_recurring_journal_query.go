@@ -0,0 +1,81 @@
+package mysql
+
+import (
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// query to acct_recurring_journals table
+var (
+	queryInsertRecurringJournal = `
+		INSERT INTO acct_recurring_journals(
+			name,
+			order_type,
+			currency,
+			transactions,
+			frequency,
+			start_date,
+			end_date,
+			remaining_count,
+			run_count,
+			next_run_date,
+			status,
+			created_by
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	queryUpdateRecurringJournalSchedule = `
+		UPDATE 
+			acct_recurring_journals
+		SET 
+			remaining_count = ?,
+			run_count = ?,
+			next_run_date = ?,
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP(6)
+		WHERE 
+			id = ?`
+)
+
+var recurringJournalColumns = []string{
+	`id`,
+	`name`,
+	`order_type`,
+	`currency`,
+	`transactions`,
+	`frequency`,
+	`start_date`,
+	`end_date`,
+	`remaining_count`,
+	`run_count`,
+	`next_run_date`,
+	`status`,
+	`created_by`,
+	`created_at`,
+	`updated_at`,
+}
+
+func buildGetRecurringJournalByIdQuery(id int) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(recurringJournalColumns...).
+		From("acct_recurring_journals").
+		Where(sq.Eq{`id`: id})
+
+	return query.ToSql()
+}
+
+func buildGetRecurringJournalsQuery(opts models.RecurringJournalFilterOptions) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(recurringJournalColumns...).From("acct_recurring_journals")
+
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{`status`: opts.Status})
+	}
+	if !opts.DueDate.IsZero() {
+		query = query.Where(sq.LtOrEq{`next_run_date`: opts.DueDate})
+	}
+
+	query = query.OrderBy(`id ASC`)
+
+	return query.ToSql()
+}
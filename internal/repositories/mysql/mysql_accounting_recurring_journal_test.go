@@ -0,0 +1,262 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountingTestSuite) TestRepository_InsertRecurringJournal() {
+	in := models.RecurringJournal{
+		Name:        "Monthly fee",
+		OrderType:   "AMR",
+		Currency:    "IDR",
+		Frequency:   models.RecurringJournalFrequencyMonthly,
+		StartDate:   time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+		NextRunDate: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+		Status:      models.RecurringJournalStatusActive,
+		CreatedBy:   "tono@amartha.com",
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantId  int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertRecurringJournal)).
+					WillReturnResult(sqlmock.NewResult(7, 1))
+			},
+			wantId:  7,
+			wantErr: false,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertRecurringJournal)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			id, err := suite.repo.InsertRecurringJournal(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantId, id)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetRecurringJournals() {
+	now := time.Now()
+	opts := models.RecurringJournalFilterOptions{
+		Status:  models.RecurringJournalStatusActive,
+		DueDate: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+	}
+	query, _, _ := buildGetRecurringJournalsQuery(opts)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		check   func(t *testing.T, out []models.RecurringJournal)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows(recurringJournalColumns).
+						AddRow(1, "Monthly fee", "AMR", "IDR", []byte(`[{"transactionType":"AMRIN","account":"511001000000001","amount":"1000","isDebit":true}]`),
+							"monthly", now, nil, 2, 0, now, "active", "tono@amartha.com", now, now).
+						AddRow(2, "Daily accrual", "ACR", "IDR", []byte(`[]`),
+							"daily", now, now, nil, 3, now, "active", "tono@amartha.com", now, now))
+			},
+			check: func(t *testing.T, out []models.RecurringJournal) {
+				assert.Len(t, out, 2)
+				assert.Len(t, out[0].Transactions, 1)
+				assert.Nil(t, out[0].EndDate)
+				assert.Equal(t, 2, *out[0].RemainingCount)
+				assert.NotNil(t, out[1].EndDate)
+				assert.Nil(t, out[1].RemainingCount)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error scan row",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetRecurringJournals(context.TODO(), opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.check != nil {
+				tt.check(t, out)
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetRecurringJournalById() {
+	query, _, _ := buildGetRecurringJournalByIdQuery(1)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				now := time.Now()
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(recurringJournalColumns).
+						AddRow(1, "Monthly fee", "AMR", "IDR", []byte(`[]`), "monthly", now, nil, nil, 0, now, "active", "tono@amartha.com", now, now))
+			},
+			wantErr: false,
+		},
+		{
+			name: "success not found",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnError(models.ErrNoRows)
+			},
+			wantNil: true,
+			wantErr: false,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnError(assert.AnError)
+			},
+			wantNil: true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetRecurringJournalById(context.TODO(), 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantNil, out == nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_UpdateRecurringJournalSchedule() {
+	remainingCount := 1
+	in := models.RecurringJournal{
+		ID:             1,
+		RemainingCount: &remainingCount,
+		RunCount:       1,
+		NextRunDate:    time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		Status:         models.RecurringJournalStatusActive,
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateRecurringJournalSchedule)).
+					WithArgs(in.RemainingCount, in.RunCount, in.NextRunDate, in.Status, in.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error no rows affected",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateRecurringJournalSchedule)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateRecurringJournalSchedule)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.UpdateRecurringJournalSchedule(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/money"
+)
+
+// GetSuspenseClosingBalance returns the accounts of the suspense sub categories whose closing balance of the date is not zero.
+func (ar *accountingRepository) GetSuspenseClosingBalance(ctx context.Context, date time.Time, subCategories []string) (out []models.AccountBalanceDaily, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	if len(subCategories) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := buildGetSuspenseClosingBalanceQuery(date, subCategories)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := models.AccountBalanceDaily{BalanceDate: date}
+		if err = rows.Scan(
+			&v.AccountNumber,
+			&v.EntityCode,
+			&v.CategoryCode,
+			&v.SubCategoryCode,
+			&v.Currency,
+			&v.ClosingBalance,
+		); err != nil {
+			err = databaseError(err)
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
+
+func (ar *accountingRepository) InsertSuspenseClearing(ctx context.Context, in models.SuspenseClearing) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	if _, err = db.ExecContext(ctx, queryInsertSuspenseClearing,
+		in.RuleName,
+		in.DebitJournalId,
+		in.CreditJournalId,
+		in.DebitAccountNumber,
+		in.CreditAccountNumber,
+		in.Amount,
+		in.Currency,
+		sql.NullString{String: in.TransactionId, Valid: in.TransactionId != ""},
+	); err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return nil
+}
+
+// GetOpenSuspenseSplits returns the splits of the suspense account until the end date which are not paired by any clearing,
+// the clearing journals of the order type are excluded. the splits are sorted from the oldest.
+func (ar *accountingRepository) GetOpenSuspenseSplits(ctx context.Context, accountNumber string, endDate time.Time, clearingOrderType string) (out []models.GetSubLedgerOut, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetOpenSuspenseSplitsQuery(accountNumber, endDate, clearingOrderType)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			v        models.GetSubLedgerOut
+			decimals int
+		)
+		if err = rows.Scan(
+			&v.TransactionID,
+			&v.ReferenceNumber,
+			&v.TransactionDate,
+			&v.OrderType,
+			&v.TransactionType,
+			&v.Narrative,
+			&v.Metadata,
+			&v.Debit,
+			&v.Credit,
+			&v.CreatedAt,
+			&v.UpdatedAt,
+			&v.JournalID,
+			&decimals,
+		); err != nil {
+			err = databaseError(err)
+			return nil, err
+		}
+		v.Debit = money.FormatBigIntToAmount(v.Debit, decimals)
+		v.Credit = money.FormatBigIntToAmount(v.Credit, decimals)
+		out = append(out, v)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
@@ -0,0 +1,73 @@
+package mysql
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// query to acct_suspense_clearings table
+var (
+	queryInsertSuspenseClearing = `
+		INSERT INTO acct_suspense_clearings(
+			rule_name,
+			debit_journal_id,
+			credit_journal_id,
+			debit_account_number,
+			credit_account_number,
+			amount,
+			currency,
+			transaction_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+)
+
+func buildGetSuspenseClosingBalanceQuery(date time.Time, subCategories []string) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(
+		`aadb.account_number`,
+		`aadb.entity_code`,
+		`aadb.category_code`,
+		`aadb.sub_category_code`,
+		`aadb.currency`,
+		`aadb.closing_balance`,
+	).
+		From("acct_account_daily_balance aadb").
+		Where(sq.Eq{`aadb.balance_date`: date}).
+		Where(sq.Eq{`aadb.sub_category_code`: subCategories}).
+		Where(sq.NotEq{`aadb.closing_balance`: 0}).
+		OrderBy(`aadb.account_number ASC`)
+
+	return query.ToSql()
+}
+
+func buildGetOpenSuspenseSplitsQuery(accountNumber string, endDate time.Time, clearingOrderType string) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(
+		`COALESCE(t.transaction_id, '')`,
+		`COALESCE(ajd.reference_number, '')`,
+		`ajd.transaction_date`,
+		`ajd.order_type`,
+		`ajd.transaction_type`,
+		`COALESCE(s.description, '') narrative`,
+		`COALESCE(ajd.metadata, '{}') metadata`,
+		`case when ajd.is_debit = TRUE then s.amount else 0 end as debit`,
+		`case when ajd.is_debit = FALSE then s.amount else 0 end as credit`,
+		`ajd.created_at`,
+		`ajd.updated_at`,
+		`s.split_id`,
+		`c.decimals`,
+	).
+		From("splits s").
+		Join("split_accounts sa ON sa.split_id = s.split_id").
+		Join("acct_journal_detail ajd ON ajd.journal_id = s.split_id").
+		Join("currencies c ON c.name = s.currency").
+		LeftJoin("transactions t ON t.transaction_id = s.transaction_id").
+		Where(sq.Eq{`sa.account_id`: accountNumber}).
+		Where(sq.LtOrEq{`ajd.transaction_date`: endDate}).
+		Where(sq.NotEq{`ajd.order_type`: clearingOrderType}).
+		Where(`NOT EXISTS (SELECT 1 FROM acct_suspense_clearings sc WHERE sc.debit_journal_id = s.split_id)`).
+		Where(`NOT EXISTS (SELECT 1 FROM acct_suspense_clearings sc WHERE sc.credit_journal_id = s.split_id)`).
+		OrderBy(`ajd.transaction_date ASC`)
+
+	return query.ToSql()
+}
@@ -0,0 +1,208 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountingTestSuite) TestRepository_GetSuspenseClosingBalance() {
+	date := time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC)
+	subCategories := []string{"21901", "21902"}
+	query, _, _ := buildGetSuspenseClosingBalanceQuery(date, subCategories)
+	columns := []string{"account_number", "entity_code", "category_code", "sub_category_code", "currency", "closing_balance"}
+
+	testCases := []struct {
+		name          string
+		subCategories []string
+		doMock        func()
+		wantLen       int
+		wantErr       bool
+	}{
+		{
+			name:          "success",
+			subCategories: subCategories,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(date, "21901", "21902", 0).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("219001000000001", "001", "219", "21901", "IDR", "10000000"))
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
+		{
+			name:    "success without sub categories",
+			doMock:  func() {},
+			wantErr: false,
+		},
+		{
+			name:          "error database",
+			subCategories: subCategories,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:          "error row scan",
+			subCategories: subCategories,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"account_number"}).AddRow("219001000000001"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetSuspenseClosingBalance(context.TODO(), date, tt.subCategories)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_InsertSuspenseClearing() {
+	in := models.SuspenseClearing{
+		RuleName:            "rejected disbursement",
+		DebitJournalId:      "journal-1",
+		CreditJournalId:     "journal-2",
+		DebitAccountNumber:  "219001000000001",
+		CreditAccountNumber: "219001000000001",
+		Amount:              decimal.NewFromInt(100000),
+		Currency:            "IDR",
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success without clearing journal",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertSuspenseClearing)).
+					WithArgs(in.RuleName, "journal-1", "journal-2", "219001000000001", "219001000000001", in.Amount, "IDR", nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertSuspenseClearing)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.InsertSuspenseClearing(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetOpenSuspenseSplits() {
+	endDate := time.Date(2024, time.April, 30, 23, 59, 59, 0, time.Local)
+	query, _, _ := buildGetOpenSuspenseSplitsQuery("219001000000001", endDate, "SCL")
+	columns := []string{
+		`COALESCE(t.transaction_id, '')`,
+		`COALESCE(ajd.reference_number, '')`,
+		`ajd.transaction_date`,
+		`ajd.order_type`,
+		`ajd.transaction_type`,
+		`narrative`,
+		`metadata`,
+		`debit`,
+		`credit`,
+		`ajd.created_at`,
+		`ajd.updated_at`,
+		`s.split_id`,
+		`c.decimals`,
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("219001000000001", endDate, "SCL").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow("trx-1", "DSB_1", time.Now(), "DSB", "DSBTR", "", []byte("{}"), "10000000", "0", time.Now(), time.Now(), "journal-1", 2))
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
+		{
+			name: "error scan row",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := suite.repo.GetOpenSuspenseSplits(context.TODO(), "219001000000001", endDate, "SCL")
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, out, tt.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
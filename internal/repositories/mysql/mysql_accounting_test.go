@@ -339,8 +339,10 @@ func (suite *accountingTestSuite) TestRepository_GetSubLedger() {
 						`case when ajd.is_debit = FALSE then s.amount else 0 end as credit`,
 						`ajd.created_at`,
 						`ajd.updated_at`,
+						`s.split_id`,
+						`c.decimals`,
 					}).
-					AddRow("", "", time.Time{}, "", "", "", nil, 0, 0, time.Time{}, time.Time{})
+					AddRow("", "", time.Time{}, "", "", "", nil, 0, 0, time.Time{}, time.Time{}, "", 2)
 				suite.mock.
 					ExpectQuery(regexp.QuoteMeta(query)).
 					WillReturnRows(rows)
@@ -386,8 +388,10 @@ func (suite *accountingTestSuite) TestRepository_GetSubLedger() {
 						`case when ajd.is_debit = FALSE then s.amount else 0 end as credit`,
 						`ajd.created_at`,
 						`ajd.updated_at`,
+						`s.split_id`,
+						`c.decimals`,
 					}).
-					AddRow("", "", time.Time{}, "", "", "", nil, 0, 0, time.Time{}, time.Time{}).RowError(0, assert.AnError)
+					AddRow("", "", time.Time{}, "", "", "", nil, 0, 0, time.Time{}, time.Time{}, "", 2).RowError(0, assert.AnError)
 				suite.mock.
 					ExpectQuery(regexp.QuoteMeta(query)).
 					WillReturnRows(rows)
@@ -695,8 +699,13 @@ func (suite *accountingTestSuite) TestRepository_GetJournalDetailByTransactionId
 						`ajd.transaction_date`,
 						`COALESCE(s.description, '') AS narrative`,
 						`ajd.is_debit`,
+						`ajd.reference_number`,
+						`ajd.order_type`,
+						`COALESCE(ajd.reversed_journal_id, '') reversed_journal_id`,
+						`s.currency`,
+						`c.decimals`,
 					}).
-					AddRow("", "", "", "", "", "", "", "", "", "", defaultDecimal, time.Time{}, "", true)
+					AddRow("", "", "", "", "", "", "", "", "", "", defaultDecimal, time.Time{}, "", true, "", "", "", "IDR", 2)
 				suite.mock.
 					ExpectQuery(regexp.QuoteMeta(query)).
 					WillReturnRows(rows)
@@ -727,8 +736,13 @@ func (suite *accountingTestSuite) TestRepository_GetJournalDetailByTransactionId
 						`ajd.transaction_date`,
 						`COALESCE(s.description, '') AS narrative`,
 						`ajd.is_debit`,
+						`ajd.reference_number`,
+						`ajd.order_type`,
+						`COALESCE(ajd.reversed_journal_id, '') reversed_journal_id`,
+						`s.currency`,
+						`c.decimals`,
 					}).
-					AddRow("", "", "", "", "", "", "", "", "", "", defaultDecimal, time.Time{}, "", true).RowError(0, assert.AnError)
+					AddRow("", "", "", "", "", "", "", "", "", "", defaultDecimal, time.Time{}, "", true, "", "", "", "IDR", 2).RowError(0, assert.AnError)
 				suite.mock.
 					ExpectQuery(regexp.QuoteMeta(query)).
 					WillReturnRows(rows)
@@ -1230,6 +1244,7 @@ func (suite *accountingTestSuite) TestRepository_GetTrialBalanceDetails() {
 		"closing_balance",
 		"debit_movement",
 		"credit_movement",
+		"decimals",
 	}
 
 	defaultDecimal := decimal.NewNullDecimal(decimal.NewFromInt(100_000))
@@ -1262,6 +1277,7 @@ func (suite *accountingTestSuite) TestRepository_GetTrialBalanceDetails() {
 						defaultDecimal,
 						defaultDecimal,
 						defaultDecimal,
+						2,
 					).
 					AddRow(
 						"001",
@@ -1270,6 +1286,7 @@ func (suite *accountingTestSuite) TestRepository_GetTrialBalanceDetails() {
 						defaultDecimal,
 						defaultDecimal,
 						defaultDecimal,
+						2,
 					)
 				suite.mock.
 					ExpectQuery(regexp.QuoteMeta(listQuery)).
@@ -1298,6 +1315,7 @@ func (suite *accountingTestSuite) TestRepository_GetTrialBalanceDetails() {
 						defaultDecimal,
 						defaultDecimal,
 						defaultDecimal,
+						2,
 					).RowError(0, assert.AnError)
 				suite.mock.
 					ExpectQuery(regexp.QuoteMeta(listQuery)).
@@ -1682,3 +1700,76 @@ func (suite *accountingTestSuite) TestRepository_CheckTransactionIdIsExist() {
 		})
 	}
 }
+
+func (suite *accountingTestSuite) TestRepository_GetReversedJournalIds() {
+	type args struct {
+		ctx        context.Context
+		journalIds []string
+	}
+	testCases := []struct {
+		name       string
+		args       args
+		setupMocks func(a args)
+		wantErr    bool
+		expected   []string
+	}{
+		{
+			name: "success get reversed journal ids",
+			args: args{
+				ctx:        context.TODO(),
+				journalIds: []string{"2024060601093678", "2024060601093679"},
+			},
+			setupMocks: func(a args) {
+				query, _, _ := getReversedJournalIdsQuery(a.journalIds)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("2024060601093678", "2024060601093679").
+					WillReturnRows(sqlmock.NewRows([]string{`ajd.reversed_journal_id`}).AddRow("2024060601093678"))
+			},
+			expected: []string{"2024060601093678"},
+			wantErr:  false,
+		},
+		{
+			name: "error case - scan row",
+			args: args{
+				ctx:        context.TODO(),
+				journalIds: []string{"2024060601093678"},
+			},
+			setupMocks: func(a args) {
+				query, _, _ := getReversedJournalIdsQuery(a.journalIds)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{`ajd.reversed_journal_id`}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - QueryContext",
+			args: args{
+				ctx:        context.TODO(),
+				journalIds: []string{"2024060601093678"},
+			},
+			setupMocks: func(a args) {
+				query, _, _ := getReversedJournalIdsQuery(a.journalIds)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks(tt.args)
+
+			got, err := suite.repo.GetReversedJournalIds(tt.args.ctx, tt.args.journalIds)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.expected, got)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -77,7 +77,7 @@ func (ar *accountingRepository) InsertAccountTrialBalance(ctx context.Context, i
 	valueStrings := []string{}
 	valueArgs := []interface{}{}
 	for _, req := range in {
-		valueStrings = append(valueStrings, `(?, ?, ?, ?, ?, ?, ?, ?)`)
+		valueStrings = append(valueStrings, `(?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 		valueArgs = append(valueArgs, req.ClosingDate)
 		valueArgs = append(valueArgs, req.EntityCode)
 		valueArgs = append(valueArgs, req.CategoryCode)
@@ -86,6 +86,7 @@ func (ar *accountingRepository) InsertAccountTrialBalance(ctx context.Context, i
 		valueArgs = append(valueArgs, req.CreditMovement)
 		valueArgs = append(valueArgs, req.OpeningBalance)
 		valueArgs = append(valueArgs, req.ClosingBalance)
+		valueArgs = append(valueArgs, req.Currency)
 	}
 
 	query := fmt.Sprintf(queryInsertAccountTrialBalance, strings.Join(valueStrings, ","))
@@ -223,6 +224,7 @@ func (ar *accountingRepository) GetTrialBalanceSubCategory(ctx context.Context,
 		return
 	}
 
+	var decimals int
 	err = db.
 		QueryRowContext(ctx, query, args...).
 		Scan(
@@ -232,15 +234,16 @@ func (ar *accountingRepository) GetTrialBalanceSubCategory(ctx context.Context,
 			&out.CreditMovement,
 			&out.OpeningBalance,
 			&out.ClosingBalance,
+			&decimals,
 		)
 	if err != nil {
 		return
 	}
 
-	out.OpeningBalance = money.FormatBigIntToAmount(out.OpeningBalance, CurrencyIDR.Decimals)
-	out.ClosingBalance = money.FormatBigIntToAmount(out.ClosingBalance, CurrencyIDR.Decimals)
-	out.CreditMovement = money.FormatBigIntToAmount(out.CreditMovement, CurrencyIDR.Decimals)
-	out.DebitMovement = money.FormatBigIntToAmount(out.DebitMovement, CurrencyIDR.Decimals)
+	out.OpeningBalance = money.FormatBigIntToAmount(out.OpeningBalance, decimals)
+	out.ClosingBalance = money.FormatBigIntToAmount(out.ClosingBalance, decimals)
+	out.CreditMovement = money.FormatBigIntToAmount(out.CreditMovement, decimals)
+	out.DebitMovement = money.FormatBigIntToAmount(out.DebitMovement, decimals)
 
 	return
 }
@@ -309,7 +309,7 @@ func (suite *accountingTestSuite) TestRepository_InsertAccountTrialBalance() {
 		ctx context.Context
 		req []models.AccountTrialBalance
 	}
-	valueStrings := "(?, ?, ?, ?, ?, ?, ?, ?)"
+	valueStrings := "(?, ?, ?, ?, ?, ?, ?, ?, ?)"
 	queryInsertAccountTrialBalance = fmt.Sprintf(queryInsertAccountTrialBalance, valueStrings)
 
 	testCases := []struct {
@@ -583,6 +583,7 @@ func (suite *accountingTestSuite) TestRepository_GetTrialBalanceSubCategory() {
 		"closing_balance",
 		"debit_movement",
 		"credit_movement",
+		"decimals",
 	}
 
 	defaultDecimal := decimal.NewNullDecimal(decimal.NewFromInt(100_000))
@@ -615,6 +616,7 @@ func (suite *accountingTestSuite) TestRepository_GetTrialBalanceSubCategory() {
 						defaultDecimal,
 						defaultDecimal,
 						defaultDecimal,
+						2,
 					)
 				suite.mock.
 					ExpectQuery(regexp.QuoteMeta(listQuery)).
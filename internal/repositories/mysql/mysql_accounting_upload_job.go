@@ -0,0 +1,192 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+)
+
+func (ar *accountingRepository) InsertUploadJob(ctx context.Context, in models.UploadJob) (id int, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryInsertUploadJob,
+		in.Type,
+		in.Filename,
+		in.FilePath,
+		sql.NullString{String: in.Topic, Valid: in.Topic != ""},
+		sql.NullString{String: in.CreatedBy, Valid: in.CreatedBy != ""},
+		sql.NullString{String: in.Reason, Valid: in.Reason != ""},
+		in.Status,
+	)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	lastInsertId, err := res.LastInsertId()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return int(lastInsertId), nil
+}
+
+func (ar *accountingRepository) GetUploadJobById(ctx context.Context, id int) (out *models.UploadJob, err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	query, args, err := buildGetUploadJobByIdQuery(id)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	var (
+		job           models.UploadJob
+		topic         sql.NullString
+		createdBy     sql.NullString
+		reason        sql.NullString
+		failures      []byte
+		errorMessage  sql.NullString
+		errorFilePath sql.NullString
+		startedAt     sql.NullTime
+		finishedAt    sql.NullTime
+	)
+	db := ar.r.extractTx(ctx)
+	err = db.QueryRowContext(ctx, query, args...).Scan(
+		&job.ID,
+		&job.Type,
+		&job.Filename,
+		&job.FilePath,
+		&topic,
+		&createdBy,
+		&reason,
+		&job.Status,
+		&job.Attempt,
+		&job.TotalRows,
+		&job.ProcessedRows,
+		&job.SuccessRows,
+		&job.FailedRows,
+		&failures,
+		&errorMessage,
+		&errorFilePath,
+		&startedAt,
+		&finishedAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		if err == models.ErrNoRows {
+			err = nil
+			return nil, nil
+		}
+		err = databaseError(err)
+		return nil, err
+	}
+
+	if len(failures) > 0 {
+		if err = job.Failures.Scan(failures); err != nil {
+			err = models.GetErrMap(models.ErrKeyFailedUnmarshal, err.Error())
+			return nil, err
+		}
+	}
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	job.Topic = topic.String
+	job.CreatedBy = createdBy.String
+	job.Reason = reason.String
+	job.ErrorMessage = errorMessage.String
+	job.ErrorFilePath = errorFilePath.String
+
+	return &job, nil
+}
+
+// UpdateUploadJob updates the progress of the job in the given status & attempt, models.ErrNoRowsAffected is returned
+// when the job is already moved or claimed by another worker.
+func (ar *accountingRepository) UpdateUploadJob(ctx context.Context, in models.UploadJob, fromStatus string) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryUpdateUploadJob,
+		in.Status,
+		in.TotalRows,
+		in.ProcessedRows,
+		in.SuccessRows,
+		in.FailedRows,
+		&in.Failures,
+		sql.NullString{String: in.ErrorMessage, Valid: in.ErrorMessage != ""},
+		sql.NullString{String: in.ErrorFilePath, Valid: in.ErrorFilePath != ""},
+		in.StartedAt,
+		in.FinishedAt,
+		in.ID,
+		fromStatus,
+		in.Attempt,
+	)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	if affectedRows == 0 {
+		err = models.ErrNoRowsAffected
+		return
+	}
+
+	return nil
+}
+
+// ClaimUploadJob starts a pending job or restarts a processing job whose progress is not updated since the stale time,
+// the attempt is increased so the updates of the previous worker are rejected.
+// models.ErrNoRowsAffected is returned when the job is updated or claimed by another worker since it is read.
+func (ar *accountingRepository) ClaimUploadJob(ctx context.Context, in models.UploadJob, staleBefore time.Time) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := ar.r.extractTx(ctx)
+	res, err := db.ExecContext(ctx, queryClaimUploadJob,
+		models.UploadJobStatusProcessing,
+		&in.Failures,
+		in.StartedAt,
+		in.ID,
+		in.Attempt,
+		models.UploadJobStatusPending,
+		models.UploadJobStatusProcessing,
+		staleBefore,
+	)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	affectedRows, err := res.RowsAffected()
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	if affectedRows == 0 {
+		err = models.ErrNoRowsAffected
+		return
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+// query to acct_upload_jobs table
+var (
+	queryInsertUploadJob = `
+		INSERT INTO acct_upload_jobs(
+			type,
+			filename,
+			file_path,
+			topic,
+			created_by,
+			reason,
+			status
+		) VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	queryUpdateUploadJob = `
+		UPDATE
+			acct_upload_jobs
+		SET
+			status = ?,
+			total_rows = ?,
+			processed_rows = ?,
+			success_rows = ?,
+			failed_rows = ?,
+			failures = ?,
+			error_message = ?,
+			error_file_path = ?,
+			started_at = ?,
+			finished_at = ?,
+			updated_at = CURRENT_TIMESTAMP(6)
+		WHERE
+			id = ? AND status = ? AND attempt = ?`
+
+	queryClaimUploadJob = `
+		UPDATE
+			acct_upload_jobs
+		SET
+			status = ?,
+			attempt = attempt + 1,
+			total_rows = 0,
+			processed_rows = 0,
+			success_rows = 0,
+			failed_rows = 0,
+			failures = ?,
+			error_message = NULL,
+			error_file_path = NULL,
+			started_at = ?,
+			finished_at = NULL,
+			updated_at = CURRENT_TIMESTAMP(6)
+		WHERE
+			id = ? AND attempt = ? AND (status = ? OR (status = ? AND updated_at < ?))`
+)
+
+var uploadJobColumns = []string{
+	`id`,
+	`type`,
+	`filename`,
+	`file_path`,
+	`topic`,
+	`created_by`,
+	`reason`,
+	`status`,
+	`attempt`,
+	`total_rows`,
+	`processed_rows`,
+	`success_rows`,
+	`failed_rows`,
+	`failures`,
+	`error_message`,
+	`error_file_path`,
+	`started_at`,
+	`finished_at`,
+	`created_at`,
+	`updated_at`,
+}
+
+func buildGetUploadJobByIdQuery(id int) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select(uploadJobColumns...).
+		From("acct_upload_jobs").
+		Where(sq.Eq{`id`: id})
+
+	return query.ToSql()
+}
@@ -0,0 +1,268 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountingTestSuite) TestRepository_InsertUploadJob() {
+	in := models.UploadJob{
+		Type:     models.UploadJobTypeJournal,
+		Filename: "journals.csv",
+		FilePath: "upload_jobs/journal/20250101000000_journals.csv",
+		Status:   models.UploadJobStatusPending,
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantId  int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertUploadJob)).
+					WithArgs(in.Type, in.Filename, in.FilePath, nil, nil, nil, in.Status).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			wantId:  5,
+			wantErr: false,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertUploadJob)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			id, err := suite.repo.InsertUploadJob(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantId, id)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_GetUploadJobById() {
+	now := time.Now()
+	query, _, _ := buildGetUploadJobByIdQuery(1)
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		want    *models.UploadJob
+		wantErr bool
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(uploadJobColumns).
+						AddRow(1, "publisher", "messages.json", "upload_jobs/publisher/messages.json", "journal_stream", "tono@amartha.com", "republish", "completed", 1,
+							2, 2, 1, 1, []byte(`[{"row":2,"error":"failed publish"}]`), nil, "upload_jobs/publisher/1_errors.csv", now, now, now, now))
+			},
+			want: &models.UploadJob{
+				ID:            1,
+				Type:          models.UploadJobTypePublisher,
+				Filename:      "messages.json",
+				FilePath:      "upload_jobs/publisher/messages.json",
+				Topic:         "journal_stream",
+				CreatedBy:     "tono@amartha.com",
+				Reason:        "republish",
+				Status:        models.UploadJobStatusCompleted,
+				Attempt:       1,
+				TotalRows:     2,
+				ProcessedRows: 2,
+				SuccessRows:   1,
+				FailedRows:    1,
+				Failures:      models.UploadJobFailures{{Row: 2, Error: "failed publish"}},
+				ErrorFilePath: "upload_jobs/publisher/1_errors.csv",
+				StartedAt:     &now,
+				FinishedAt:    &now,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success not found",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnError(models.ErrNoRows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			got, err := suite.repo.GetUploadJobById(context.TODO(), 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_UpdateUploadJob() {
+	startedAt := time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC)
+	in := models.UploadJob{
+		ID:            1,
+		Status:        models.UploadJobStatusProcessing,
+		TotalRows:     10,
+		ProcessedRows: 5,
+		SuccessRows:   5,
+		Attempt:       2,
+		StartedAt:     &startedAt,
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateUploadJob)).
+					WithArgs(in.Status, in.TotalRows, in.ProcessedRows, in.SuccessRows, in.FailedRows, sqlmock.AnyArg(),
+						nil, nil, in.StartedAt, nil, in.ID, models.UploadJobStatusProcessing, in.Attempt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "error no rows affected",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateUploadJob)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: models.ErrNoRowsAffected,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateUploadJob)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyDatabaseError, assert.AnError.Error()),
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.UpdateUploadJob(context.TODO(), in, models.UploadJobStatusProcessing)
+			assert.Equal(t, tt.wantErr, err)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *accountingTestSuite) TestRepository_ClaimUploadJob() {
+	startedAt := time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC)
+	staleBefore := startedAt.Add(-30 * time.Minute)
+	in := models.UploadJob{
+		ID:        1,
+		Status:    models.UploadJobStatusProcessing,
+		Attempt:   1,
+		StartedAt: &startedAt,
+	}
+
+	testCases := []struct {
+		name    string
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryClaimUploadJob)).
+					WithArgs(models.UploadJobStatusProcessing, sqlmock.AnyArg(), in.StartedAt, in.ID, in.Attempt,
+						models.UploadJobStatusPending, models.UploadJobStatusProcessing, staleBefore).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "error no rows affected",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryClaimUploadJob)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: models.ErrNoRowsAffected,
+		},
+		{
+			name: "error database",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryClaimUploadJob)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyDatabaseError, assert.AnError.Error()),
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.ClaimUploadJob(context.TODO(), in, staleBefore)
+			assert.Equal(t, tt.wantErr, err)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+)
+
+func (ar *accountingRepository) GetYearEndClosingBalance(ctx context.Context, entityCode, currency, closingOrderType string, startDate, endDate time.Time) (out []models.YearEndClosingBalance, err error) {
+	start := atime.Now()
+
+	defer func() {
+		logSQL(ctx, err, start)
+	}()
+
+	query, args, err := buildGetYearEndClosingBalanceQuery(entityCode, currency, closingOrderType, startDate, endDate)
+	if err != nil {
+		err = fmt.Errorf("failed to build query: %w", err)
+		return
+	}
+
+	db := ar.r.extractTx(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		err = databaseError(err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data = models.YearEndClosingBalance{}
+		var errScan = rows.Scan(
+			&data.AccountNumber,
+			&data.SubCategoryCode,
+			&data.COAType,
+			&data.DebitMovement,
+			&data.CreditMovement,
+		)
+		if errScan != nil {
+			err = databaseError(errScan)
+			return
+		}
+		out = append(out, data)
+	}
+	if rows.Err() != nil {
+		err = databaseError(rows.Err())
+		return
+	}
+
+	return
+}
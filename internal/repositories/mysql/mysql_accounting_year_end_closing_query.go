@@ -0,0 +1,41 @@
+package mysql
+
+import (
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// buildGetYearEndClosingBalanceQuery sums the movements of the income & expense accounts up to the end of the fiscal year,
+// the closing journals of the fiscal year itself are excluded so the balances stay the same when the closing is resumed.
+func buildGetYearEndClosingBalanceQuery(entityCode, currency, closingOrderType string, startDate, endDate time.Time) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Question)
+	query := psql.Select([]string{
+		"aa.account_number",
+		"aa.sub_category_code",
+		"ac.coa_type_code",
+		"SUM(CASE WHEN ajd.is_debit = 1 THEN s.amount ELSE 0 END) AS debit",
+		"SUM(CASE WHEN ajd.is_debit = 0 THEN s.amount ELSE 0 END) AS credit",
+	}...).From("acct_account aa").
+		Join("acct_sub_category asc2 ON asc2.code = aa.sub_category_code").
+		Join("acct_category ac ON ac.code = asc2.category_code").
+		Join("split_accounts sa ON sa.account_id = aa.account_number").
+		Join("splits s ON s.split_id = sa.split_id").
+		Join("acct_journal_detail ajd ON ajd.journal_id = s.split_id").
+		Join("transactions t ON t.transaction_id = s.transaction_id").
+		Where(sq.Eq{"aa.entity_code": entityCode}).
+		Where(sq.Eq{"s.currency": currency}).
+		Where(sq.Eq{"ac.coa_type_code": []string{models.COATypeIncome, models.COATypeExpense}}).
+		Where(sq.LtOrEq{"t.postdate": endDate}).
+		Where(sq.Or{
+			sq.NotEq{"ajd.order_type": closingOrderType},
+			sq.Lt{"t.postdate": startDate},
+		}).
+		GroupBy("aa.account_number", "aa.sub_category_code", "ac.coa_type_code").
+		Having("debit <> credit").
+		OrderBy("aa.sub_category_code", "aa.account_number")
+
+	return query.ToSql()
+}
@@ -0,0 +1,101 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *accountingTestSuite) TestRepository_GetYearEndClosingBalance() {
+	startDate, _ := atime.ParseStringToDatetime(atime.DateFormatYYYY, "2024")
+	endDate := time.Date(2024, time.December, 31, 23, 59, 59, 0, startDate.Location())
+
+	type args struct {
+		ctx        context.Context
+		entityCode string
+		currency   string
+		orderType  string
+	}
+	testCases := []struct {
+		name       string
+		args       args
+		setupMocks func(a args)
+		wantErr    bool
+	}{
+		{
+			name: "success case - get year end closing balance",
+			args: args{
+				ctx:        context.TODO(),
+				entityCode: "001",
+				currency:   "IDR",
+				orderType:  "YEC",
+			},
+			setupMocks: func(a args) {
+				query, _, _ := buildGetYearEndClosingBalanceQuery(a.entityCode, a.currency, a.orderType, startDate, endDate)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{
+						"aa.account_number",
+						"aa.sub_category_code",
+						"ac.coa_type_code",
+						"debit",
+						"credit",
+					}).
+						AddRow("411001000000001", "41101", "INC", "0", "100000").
+						AddRow("511001000000001", "51101", "EXP", "40000", "0"))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - error scan row",
+			args: args{
+				ctx:        context.TODO(),
+				entityCode: "001",
+				currency:   "IDR",
+				orderType:  "YEC",
+			},
+			setupMocks: func(a args) {
+				query, _, _ := buildGetYearEndClosingBalanceQuery(a.entityCode, a.currency, a.orderType, startDate, endDate)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"InvalidColumn"}).AddRow(nil))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - error database",
+			args: args{
+				ctx:        context.TODO(),
+				entityCode: "001",
+				currency:   "IDR",
+				orderType:  "YEC",
+			},
+			setupMocks: func(a args) {
+				query, _, _ := buildGetYearEndClosingBalanceQuery(a.entityCode, a.currency, a.orderType, startDate, endDate)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.setupMocks(tc.args)
+
+			_, err := suite.repo.GetYearEndClosingBalance(tc.args.ctx, tc.args.entityCode, tc.args.currency, tc.args.orderType, startDate, endDate)
+			assert.Equal(t, tc.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -13,8 +13,14 @@ type TrialBalanceRepository interface {
 	Close(ctx context.Context, in models.CloseTrialBalanceRequest) error
 	GetByPeriod(ctx context.Context, period, entity_code string) (*models.TrialBalancePeriod, error)
 	GetFirstPeriodByStatus(ctx context.Context, status string) (*models.TrialBalancePeriod, error)
+	GetFirstPeriodByStatusAndEntity(ctx context.Context, status, entityCode string) (*models.TrialBalancePeriod, error)
 	GetByPeriodStatus(ctx context.Context, period, status string) ([]models.TrialBalancePeriod, error)
 	UpdateTrialBalanceAdjustment(ctx context.Context, in models.CloseTrialBalanceRequest) (err error)
+
+	// year end closing
+	CloseByFiscalYear(ctx context.Context, fiscalYear, entityCode, closedBy string) (err error)
+	GetYearEndClosing(ctx context.Context, fiscalYear, entityCode string) (*models.YearEndClosing, error)
+	InsertYearEndClosing(ctx context.Context, in models.YearEndClosing) (err error)
 }
 
 type trialBalanceRepository sqlRepo
@@ -137,6 +143,36 @@ func (tr *trialBalanceRepository) GetFirstPeriodByStatus(ctx context.Context, st
 	return &out, nil
 }
 
+func (tr *trialBalanceRepository) GetFirstPeriodByStatusAndEntity(ctx context.Context, status, entityCode string) (*models.TrialBalancePeriod, error) {
+	var err error
+
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := tr.r.extractTx(ctx)
+
+	var out models.TrialBalancePeriod
+	if err = db.QueryRowContext(ctx, queryGetFirstPeriodByStatusAndEntity, status, entityCode).Scan(
+		&out.ID,
+		&out.Period,
+		&out.EntityCode,
+		&out.TBFilePath,
+		&out.Status,
+		&out.ClosedBy,
+		&out.IsAdjustment,
+		&out.CreatedAt,
+		&out.UpdatedAt,
+	); err != nil {
+		if err == models.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &out, nil
+}
+
 func (tr *trialBalanceRepository) UpdateTrialBalanceAdjustment(ctx context.Context, in models.CloseTrialBalanceRequest) (err error) {
 	defer func() {
 		logSQL(ctx, err)
@@ -202,3 +238,68 @@ func (tr *trialBalanceRepository) GetByPeriodStatus(ctx context.Context, period,
 
 	return result, nil
 }
+
+// CloseByFiscalYear closes every period (YYYY-MM) of the fiscal year of the entity.
+func (tr *trialBalanceRepository) CloseByFiscalYear(ctx context.Context, fiscalYear, entityCode, closedBy string) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := tr.r.extractTx(ctx)
+	if _, err = db.ExecContext(ctx, queryTrialBalanceCloseByFiscalYear, models.TrialBalanceStatusClosed, closedBy, fiscalYear+"-%", entityCode); err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return nil
+}
+
+func (tr *trialBalanceRepository) GetYearEndClosing(ctx context.Context, fiscalYear, entityCode string) (*models.YearEndClosing, error) {
+	var err error
+
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := tr.r.extractTx(ctx)
+
+	var out models.YearEndClosing
+	if err = db.QueryRowContext(ctx, queryGetYearEndClosing, fiscalYear, entityCode).Scan(
+		&out.ID,
+		&out.FiscalYear,
+		&out.EntityCode,
+		&out.RetainedEarningsAccount,
+		&out.NetIncome,
+		&out.ClosedBy,
+		&out.CreatedAt,
+	); err != nil {
+		if err == models.ErrNoRows {
+			err = nil
+			return nil, nil
+		}
+		err = databaseError(err)
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (tr *trialBalanceRepository) InsertYearEndClosing(ctx context.Context, in models.YearEndClosing) (err error) {
+	defer func() {
+		logSQL(ctx, err)
+	}()
+
+	db := tr.r.extractTx(ctx)
+	if _, err = db.ExecContext(ctx, queryInsertYearEndClosing,
+		in.FiscalYear,
+		in.EntityCode,
+		in.RetainedEarningsAccount,
+		in.NetIncome,
+		in.ClosedBy,
+	); err != nil {
+		err = databaseError(err)
+		return
+	}
+
+	return nil
+}
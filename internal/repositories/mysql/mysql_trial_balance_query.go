@@ -62,6 +62,24 @@ var (
 			ORDER BY period ASC
 			LIMIT 1`
 
+	queryGetFirstPeriodByStatusAndEntity = `
+		SELECT 
+			id,
+			period,
+			entity_code,
+			COALESCE(tb_file_path, '') as tb_file_path,
+			status,
+			COALESCE(closed_by, '') as closed_by,
+			is_adjustment,
+			created_at,
+			updated_at
+		FROM 
+			acct_trial_balance_periods
+		WHERE 
+			status = ? AND entity_code = ?
+			ORDER BY period ASC
+			LIMIT 1`
+
 	queryGetByPeriodStatus = `
 		SELECT 
 			period,
@@ -77,4 +95,40 @@ var (
 		WHERE 
 			period = ? AND status = ?
 			`
+
+	queryTrialBalanceCloseByFiscalYear = `
+		UPDATE 
+			acct_trial_balance_periods
+		SET 
+			status = ?,
+			closed_by = ?,
+			updated_at = CURRENT_TIMESTAMP(6)
+		WHERE 
+			period LIKE ? AND entity_code = ?`
+)
+
+// query to acct_year_end_closings table
+var (
+	queryInsertYearEndClosing = `
+		INSERT INTO acct_year_end_closings(
+			fiscal_year,
+			entity_code,
+			retained_earnings_account,
+			net_income,
+			closed_by
+		) VALUES (?, ?, ?, ?, ?)`
+
+	queryGetYearEndClosing = `
+		SELECT 
+			id,
+			fiscal_year,
+			entity_code,
+			retained_earnings_account,
+			net_income,
+			closed_by,
+			created_at
+		FROM 
+			acct_year_end_closings
+		WHERE 
+			fiscal_year = ? AND entity_code = ?`
 )
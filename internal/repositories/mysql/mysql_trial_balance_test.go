@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"regexp"
 	"testing"
+	"time"
 
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -100,3 +102,295 @@ func (suite *trialBalanceTestSuite) TestRepository_Close() {
 		})
 	}
 }
+
+func (suite *trialBalanceTestSuite) TestRepository_GetFirstPeriodByStatusAndEntity() {
+	type args struct {
+		ctx        context.Context
+		status     string
+		entityCode string
+	}
+
+	columns := []string{"id", "period", "entity_code", "tb_file_path", "status", "closed_by", "is_adjustment", "created_at", "updated_at"}
+	testCases := []struct {
+		name    string
+		args    args
+		want    *models.TrialBalancePeriod
+		wantErr bool
+		doMock  func(args args)
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:        context.TODO(),
+				status:     models.TrialBalanceStatusOpen,
+				entityCode: "001",
+			},
+			doMock: func(args args) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryGetFirstPeriodByStatusAndEntity)).
+					WithArgs(args.status, args.entityCode).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "2023-11", "001", "", models.TrialBalanceStatusOpen, "", false, time.Time{}, time.Time{}))
+			},
+			want: &models.TrialBalancePeriod{
+				ID:         1,
+				Period:     "2023-11",
+				EntityCode: "001",
+				Status:     models.TrialBalanceStatusOpen,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - no open period",
+			args: args{
+				ctx:        context.TODO(),
+				status:     models.TrialBalanceStatusOpen,
+				entityCode: "001",
+			},
+			doMock: func(args args) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryGetFirstPeriodByStatusAndEntity)).
+					WithArgs(args.status, args.entityCode).
+					WillReturnError(sql.ErrNoRows)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "error",
+			args: args{
+				ctx:        context.TODO(),
+				status:     models.TrialBalanceStatusOpen,
+				entityCode: "001",
+			},
+			doMock: func(args args) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryGetFirstPeriodByStatusAndEntity)).
+					WithArgs(args.status, args.entityCode).
+					WillReturnError(assert.AnError)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock(tt.args)
+
+			got, err := suite.repo.GetFirstPeriodByStatusAndEntity(tt.args.ctx, tt.args.status, tt.args.entityCode)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *trialBalanceTestSuite) TestRepository_CloseByFiscalYear() {
+	type args struct {
+		ctx        context.Context
+		fiscalYear string
+		entityCode string
+		closedBy   string
+	}
+
+	testCases := []struct {
+		name    string
+		args    args
+		wantErr bool
+		doMock  func(args args)
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:        context.TODO(),
+				fiscalYear: "2024",
+				entityCode: "001",
+				closedBy:   "test",
+			},
+			doMock: func(args args) {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryTrialBalanceCloseByFiscalYear)).
+					WithArgs(models.TrialBalanceStatusClosed, args.closedBy, "2024-%", args.entityCode).
+					WillReturnResult(sqlmock.NewResult(0, 12))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error",
+			args: args{
+				ctx:        context.TODO(),
+				fiscalYear: "2024",
+				entityCode: "001",
+				closedBy:   "test",
+			},
+			doMock: func(args args) {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryTrialBalanceCloseByFiscalYear)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock(tt.args)
+
+			err := suite.repo.CloseByFiscalYear(tt.args.ctx, tt.args.fiscalYear, tt.args.entityCode, tt.args.closedBy)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *trialBalanceTestSuite) TestRepository_GetYearEndClosing() {
+	type args struct {
+		ctx        context.Context
+		fiscalYear string
+		entityCode string
+	}
+
+	columns := []string{"id", "fiscal_year", "entity_code", "retained_earnings_account", "net_income", "closed_by", "created_at"}
+	testCases := []struct {
+		name    string
+		args    args
+		want    *models.YearEndClosing
+		wantErr bool
+		doMock  func(args args)
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:        context.TODO(),
+				fiscalYear: "2024",
+				entityCode: "001",
+			},
+			doMock: func(args args) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryGetYearEndClosing)).
+					WithArgs(args.fiscalYear, args.entityCode).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "2024", "001", "311001000000001", "100000", "test", time.Time{}))
+			},
+			want: &models.YearEndClosing{
+				ID:                      1,
+				FiscalYear:              "2024",
+				EntityCode:              "001",
+				RetainedEarningsAccount: "311001000000001",
+				NetIncome:               decimal.NewFromInt(100000),
+				ClosedBy:                "test",
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - not closed yet",
+			args: args{
+				ctx:        context.TODO(),
+				fiscalYear: "2024",
+				entityCode: "001",
+			},
+			doMock: func(args args) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryGetYearEndClosing)).
+					WithArgs(args.fiscalYear, args.entityCode).
+					WillReturnError(sql.ErrNoRows)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "error",
+			args: args{
+				ctx:        context.TODO(),
+				fiscalYear: "2024",
+				entityCode: "001",
+			},
+			doMock: func(args args) {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryGetYearEndClosing)).
+					WithArgs(args.fiscalYear, args.entityCode).
+					WillReturnError(assert.AnError)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock(tt.args)
+
+			got, err := suite.repo.GetYearEndClosing(tt.args.ctx, tt.args.fiscalYear, tt.args.entityCode)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.want != nil {
+				assert.True(t, tt.want.NetIncome.Equal(got.NetIncome))
+				tt.want.NetIncome = got.NetIncome
+			}
+			assert.Equal(t, tt.want, got)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *trialBalanceTestSuite) TestRepository_InsertYearEndClosing() {
+	in := models.YearEndClosing{
+		FiscalYear:              "2024",
+		EntityCode:              "001",
+		RetainedEarningsAccount: "311001000000001",
+		NetIncome:               decimal.NewFromInt(100000),
+		ClosedBy:                "test",
+	}
+
+	testCases := []struct {
+		name    string
+		wantErr bool
+		doMock  func()
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertYearEndClosing)).
+					WithArgs(in.FiscalYear, in.EntityCode, in.RetainedEarningsAccount, in.NetIncome, in.ClosedBy).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryInsertYearEndClosing)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := suite.repo.InsertYearEndClosing(context.TODO(), in)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
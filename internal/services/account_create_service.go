@@ -17,12 +17,11 @@ import (
 1. check create account with account type or not and validate request
 2. get last sequence by category code
 3. generate account number
-4. if create account with account type
-- check invested and receivables account
-- get last sequence by category code
-- generate account number
+4. evaluate the relationship rules of the sub category
+- use the existing target account of a many to one rule
+- get last sequence by category code & generate the target account number
 5. bulk Insert into accounts and acct_account
-6. if account have invested and receivables account insert into acct_lender_account
+6. if account have invested and receivables account insert into acct_lender_account, if account have loan advance payment account insert into acct_loan_account
 7. publish account to kafka
 - if create account with account type and request metadata not empty and legacy id is empty then publish it to account_stream_t24 after that account will publish to acuan
 - if create account with account type and request legacy id is not empty then publish it to acuan
@@ -34,10 +33,8 @@ var loanAccountType = "PARTNERSHIP_LOAN"
 
 func (as *account) Create(ctx context.Context, in models.CreateAccount) (out models.CreateAccount, err error) {
 	var (
-		accounts      = make([]models.CreateAccount, 0, 3)
-		lenderAccount models.CreateLenderAccount
-		loanAccount   models.CreateLoanAccount
-		legacyID      models.LegacyID
+		accounts = make([]models.CreateAccount, 0, 3)
+		legacyID models.LegacyID
 	)
 
 	defer func() {
@@ -130,51 +127,12 @@ func (as *account) Create(ctx context.Context, in models.CreateAccount) (out mod
 		return
 	}
 
-	// generate account number if have invested account
-	invested, ok := as.srv.conf.AccountConfig.InvestedAccountNumber[in.SubCategoryCode]
-	if ok {
-		xlog.Info(ctx, "invested", xlog.String("sub-category-code", invested))
-		var investedAccount models.CreateAccount
-		investedAccount, err = as.generateOtherAccount(ctx, in, invested)
-		if err != nil {
-			return out, err
-		}
-		lenderAccount.CIHAccountNumber = in.AccountNumber
-		lenderAccount.InvestedAccountNumber = investedAccount.AccountNumber
-		accounts = append(accounts, investedAccount)
-	}
-
-	// generate account number if have receivables account
-	receivables, ok := as.srv.conf.AccountConfig.ReceivablesAccountNumber[in.SubCategoryCode]
-	if ok {
-		xlog.Info(ctx, "receivables", xlog.String("sub-category-code", receivables))
-		var receivablesAccount models.CreateAccount
-		receivablesAccount, err = as.generateOtherAccount(ctx, in, receivables)
-		if err != nil {
-			return out, err
-		}
-		lenderAccount.CIHAccountNumber = in.AccountNumber
-		lenderAccount.ReceivablesAccountNumber = receivablesAccount.AccountNumber
-		accounts = append(accounts, receivablesAccount)
-	}
-
-	// generate account number if have loan account normal
-	loanAdvancePayment, ok := as.srv.conf.AccountConfig.MultiLoanAccount[in.SubCategoryCode]
-	if ok {
-		xlog.Info(ctx, "loanAdvancePayment", xlog.String("sub-category-code", loanAdvancePayment))
-		var loanAdvancePaymentAccount models.CreateAccount
-		loanAdvancePaymentAccount, err = as.generateOtherAccount(ctx, in, loanAdvancePayment)
-		if err != nil {
-			return out, err
-		}
-		loanAdvancePaymentAccount.AltId = in.AccountNumber
-		if in.AltId != "" {
-			loanAdvancePaymentAccount.AltId = fmt.Sprintf("%s-%s", in.AccountNumber, in.AltId)
-		}
-		loanAccount.LoanAccountNumber = in.AccountNumber
-		loanAccount.LoanAdvancePaymentAccountNumber = loanAdvancePaymentAccount.AccountNumber
-		accounts = append(accounts, loanAdvancePaymentAccount)
+	// generate or link the accounts of the relationship rules
+	related, err := as.resolveRelatedAccounts(ctx, in, nil, as.generateRelatedAccount)
+	if err != nil {
+		return out, err
 	}
+	accounts = append(accounts, related.newAccounts...)
 
 	if err = as.srv.mySqlRepo.Atomic(ctx, func(actx context.Context, r mysql.SQLRepository) (err error) {
 		if err = r.GetAccountRepository().BulkInsertAcctAccount(actx, accounts); err != nil {
@@ -185,14 +143,14 @@ func (as *account) Create(ctx context.Context, in models.CreateAccount) (out mod
 			return
 		}
 
-		if lenderAccount.CIHAccountNumber != "" {
-			if err = r.GetAccountRepository().CreateLenderAccount(actx, lenderAccount); err != nil {
+		if related.lenderAccount.CIHAccountNumber != "" {
+			if err = r.GetAccountRepository().CreateLenderAccount(actx, related.lenderAccount); err != nil {
 				return
 			}
 		}
 
-		if loanAccount.LoanAccountNumber != "" {
-			if err = r.GetAccountRepository().CreateLoanAccount(actx, loanAccount); err != nil {
+		if related.loanAccount.LoanAccountNumber != "" {
+			if err = r.GetAccountRepository().CreateLoanAccount(actx, related.loanAccount); err != nil {
 				return
 			}
 		}
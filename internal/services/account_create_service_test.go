@@ -37,6 +37,29 @@ func Test_account_Create(t *testing.T) {
 		"partnerId": "123456789",
 	}
 
+	lenderRules := []models.AccountRelationshipRule{
+		{
+			SourceSubCategoryCode: "21102",
+			TargetSubCategoryCode: "21202",
+			Relation:              models.AccountRelationInvested,
+			Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+		},
+		{
+			SourceSubCategoryCode: "21102",
+			TargetSubCategoryCode: "14201",
+			Relation:              models.AccountRelationReceivables,
+			Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+		},
+	}
+	loanRules := []models.AccountRelationshipRule{
+		{
+			SourceSubCategoryCode: "13101",
+			TargetSubCategoryCode: "21303",
+			Relation:              models.AccountRelationLoanAdvancePayment,
+			Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+		},
+	}
+
 	tests := []struct {
 		name    string
 		req     models.CreateAccount
@@ -396,6 +419,9 @@ func Test_account_Create(t *testing.T) {
 				testHelper.mockAccRepository.EXPECT().
 					GetOneByAccountNumber(ctx, gomock.Any()).
 					Return(models.GetAccountOut{}, models.ErrNoRows)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{SourceSubCategoryCode: req.SubCategoryCode, Status: models.StatusActive}).
+					Return(lenderRules, nil)
 				testHelper.mockSubCategoryRepository.EXPECT().
 					GetByCode(ctx, gomock.Any()).
 					Return(&models.SubCategory{}, nil)
@@ -461,6 +487,9 @@ func Test_account_Create(t *testing.T) {
 				testHelper.mockAccRepository.EXPECT().
 					GetOneByAccountNumber(ctx, gomock.Any()).
 					Return(models.GetAccountOut{}, models.ErrNoRows)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, gomock.Any()).
+					Return(nil, nil)
 
 				testHelper.mockMySQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).DoAndReturn(
 					func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
@@ -502,6 +531,9 @@ func Test_account_Create(t *testing.T) {
 				testHelper.mockAccRepository.EXPECT().
 					GetOneByAccountNumber(ctx, gomock.Any()).
 					Return(models.GetAccountOut{}, models.ErrNoRows)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{SourceSubCategoryCode: req.SubCategoryCode, Status: models.StatusActive}).
+					Return(loanRules, nil)
 				testHelper.mockSubCategoryRepository.EXPECT().
 					GetByCode(ctx, gomock.Any()).
 					Return(&models.SubCategory{}, nil).AnyTimes()
@@ -544,6 +576,9 @@ func Test_account_Create(t *testing.T) {
 				testHelper.mockAccRepository.EXPECT().
 					GetOneByAccountNumber(ctx, gomock.Any()).
 					Return(models.GetAccountOut{}, models.ErrNoRows)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{SourceSubCategoryCode: req.SubCategoryCode, Status: models.StatusActive}).
+					Return(loanRules, nil)
 				testHelper.mockSubCategoryRepository.EXPECT().
 					GetByCode(ctx, gomock.Any()).
 					Return(&models.SubCategory{}, nil).AnyTimes()
@@ -585,6 +620,9 @@ func Test_account_Create(t *testing.T) {
 				testHelper.mockAccRepository.EXPECT().
 					GetOneByAccountNumber(ctx, gomock.Any()).
 					Return(models.GetAccountOut{}, models.ErrNoRows)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{SourceSubCategoryCode: req.SubCategoryCode, Status: models.StatusActive}).
+					Return(lenderRules, nil)
 				testHelper.mockSubCategoryRepository.EXPECT().
 					GetByCode(ctx, gomock.Any()).
 					Return(&models.SubCategory{}, nil).AnyTimes()
@@ -624,6 +662,9 @@ func Test_account_Create(t *testing.T) {
 				testHelper.mockAccRepository.EXPECT().
 					GetOneByAccountNumber(ctx, gomock.Any()).
 					Return(models.GetAccountOut{}, models.ErrNoRows)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{SourceSubCategoryCode: req.SubCategoryCode, Status: models.StatusActive}).
+					Return(lenderRules, nil)
 				testHelper.mockSubCategoryRepository.EXPECT().
 					GetByCode(ctx, gomock.Any()).
 					Return(&models.SubCategory{}, nil).AnyTimes()
@@ -664,6 +705,9 @@ func Test_account_Create(t *testing.T) {
 				testHelper.mockAccRepository.EXPECT().
 					GetOneByAccountNumber(ctx, gomock.Any()).
 					Return(models.GetAccountOut{}, models.ErrNoRows)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{SourceSubCategoryCode: req.SubCategoryCode, Status: models.StatusActive}).
+					Return(lenderRules, nil)
 				testHelper.mockSubCategoryRepository.EXPECT().
 					GetByCode(ctx, gomock.Any()).
 					Return(&models.SubCategory{}, nil).AnyTimes()
@@ -12,11 +12,9 @@ import (
 1. check legacy id if exist skipped
 2. check account number if exist do update
 3. validate request
-4. generate account number if have invested account
-4. generate account number if have receivables account
-5. generate account number if have loan account normal
-6. insert to database
-8. publish account to acuan
+4. generate or link the accounts of the relationship rules
+5. insert to database
+6. publish account to acuan
 */
 func (as *account) ConsumerCreateAccountMigration(ctx context.Context, in models.CreateAccount) (err error) {
 	_, err = as.createAccountMigration(ctx, in)
@@ -37,11 +35,7 @@ func (as *account) publishToAccountMigrationStreamDLQ(ctx context.Context, data
 }
 
 func (as *account) createAccountMigration(ctx context.Context, in models.CreateAccount) (out models.CreateAccount, err error) {
-	var (
-		accounts      = make([]models.CreateAccount, 0, 3)
-		lenderAccount models.CreateLenderAccount
-		loanAccount   models.CreateLoanAccount
-	)
+	accounts := make([]models.CreateAccount, 0, 3)
 
 	defer func() {
 		logService(ctx, err)
@@ -64,14 +58,7 @@ func (as *account) createAccountMigration(ctx context.Context, in models.CreateA
 	}
 
 	if isExistAccountNumber != nil {
-		if err = as.srv.mySqlRepo.GetAccountRepository().Update(ctx, models.UpdateAccount{
-			Name:          in.Name,
-			OwnerID:       in.OwnerID,
-			AltID:         in.AltId,
-			LegacyId:      in.LegacyId,
-			AccountNumber: in.AccountNumber,
-		}); err != nil {
-			err = checkDatabaseError(err)
+		if err = as.updateAccountMigration(ctx, in); err != nil {
 			return
 		}
 		xlog.Info(ctx, "[ACCOUNT-MIGRATION]", xlog.String("status", "account number is exist do update"), xlog.Any("message", in))
@@ -83,56 +70,16 @@ func (as *account) createAccountMigration(ctx context.Context, in models.CreateA
 		return out, err
 	}
 
-	accountNumber := in.AccountNumber
 	in.Status = models.MapAccountStatus[models.ACCOUNT_STATUS_ACTIVE]
 	accounts = append(accounts, in)
 	out = in
 
-	// generate account number if have invested account
-	invested, ok := as.srv.conf.AccountConfig.InvestedAccountNumber[in.SubCategoryCode]
-	if ok {
-		lenderAccount.CIHAccountNumber = accountNumber
-		account, err := as.generateOtherAccount(ctx, in, invested)
-		if err != nil {
-			return out, err
-		}
-		accounts = append(accounts, account)
-		lenderAccount.InvestedAccountNumber = account.AccountNumber
-	}
-
-	// generate account number if have receivables account
-	receivables, ok := as.srv.conf.AccountConfig.ReceivablesAccountNumber[in.SubCategoryCode]
-	if ok {
-		if in.SubCategoryCode == "21103" {
-			lenderAccount.CIHAccountNumber = accountNumber
-			account, err := as.generateOtherAccountReceivables(ctx, in, receivables)
-			if err != nil {
-				return out, err
-			}
-			accounts = append(accounts, account)
-			lenderAccount.ReceivablesAccountNumber = account.AccountNumber
-		} else {
-			lenderAccount.CIHAccountNumber = accountNumber
-			account, err := as.generateOtherAccount(ctx, in, receivables)
-			if err != nil {
-				return out, err
-			}
-			accounts = append(accounts, account)
-			lenderAccount.ReceivablesAccountNumber = account.AccountNumber
-		}
-	}
-
-	// generate account number if have loan account normal
-	loanAdvancePayment, ok := as.srv.conf.AccountConfig.MultiLoanAccount[in.SubCategoryCode]
-	if ok {
-		loanAccount.LoanAccountNumber = accountNumber
-		account, err := as.generateOtherAccount(ctx, in, loanAdvancePayment)
-		if err != nil {
-			return out, err
-		}
-		accounts = append(accounts, account)
-		loanAccount.LoanAdvancePaymentAccountNumber = account.AccountNumber
+	// generate the accounts of the relationship rules
+	related, err := as.resolveRelatedAccounts(ctx, in, nil, as.generateRelatedAccount)
+	if err != nil {
+		return out, err
 	}
+	accounts = append(accounts, related.newAccounts...)
 
 	if err = as.srv.mySqlRepo.Atomic(ctx, func(actx context.Context, r mysql.SQLRepository) (err error) {
 		if err = r.GetAccountRepository().BulkInsertAcctAccount(actx, accounts); err != nil {
@@ -143,14 +90,14 @@ func (as *account) createAccountMigration(ctx context.Context, in models.CreateA
 			return
 		}
 
-		if lenderAccount.CIHAccountNumber != "" {
-			if err = r.GetAccountRepository().CreateLenderAccount(actx, lenderAccount); err != nil {
+		if related.lenderAccount.CIHAccountNumber != "" {
+			if err = r.GetAccountRepository().CreateLenderAccount(actx, related.lenderAccount); err != nil {
 				return
 			}
 		}
 
-		if loanAccount.LoanAccountNumber != "" {
-			if err = r.GetAccountRepository().CreateLoanAccount(actx, loanAccount); err != nil {
+		if related.loanAccount.LoanAccountNumber != "" {
+			if err = r.GetAccountRepository().CreateLoanAccount(actx, related.loanAccount); err != nil {
 				return
 			}
 		}
@@ -170,53 +117,37 @@ func (as *account) createAccountMigration(ctx context.Context, in models.CreateA
 	return
 }
 
-func (as *account) generateOtherAccountReceivables(ctx context.Context, in models.CreateAccount, subCategoryCode string) (account models.CreateAccount, err error) {
-	var lastSequence int64
-	subCategory, err := as.srv.mySqlRepo.GetSubCategoryRepository().GetByCode(ctx, subCategoryCode)
+// updateAccountMigration updates the existing account from the migrated account & writes the change logs by the migration.
+func (as *account) updateAccountMigration(ctx context.Context, in models.CreateAccount) (err error) {
+	act, err := as.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, in.AccountNumber)
 	if err != nil {
-		return
-	}
-	if subCategory == nil {
-		err = models.GetErrMap(models.ErrKeySubCategoryCodeNotFound)
-		return
+		return checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
 	}
 
-	accountNumber, ok := as.srv.conf.AccountConfig.LenderInstiReceivablesAccount[in.AccountNumber]
-	if !ok {
-		// get last sequence
-		lastSequence, err = as.getCategoryCodeSeq(ctx, subCategory.CategoryCode)
-		if err != nil {
-			return account, err
-		}
-
-		// generate account number
-		accountNumber, err = generateAccountNumber(
-			subCategory.CategoryCode,
-			in.EntityCode,
-			as.srv.conf.AccountConfig.AccountNumberPadWidth,
-			lastSequence,
-		)
-		if err != nil {
-			return account, err
-		}
+	update := models.UpdateAccount{
+		Name:          in.Name,
+		OwnerID:       in.OwnerID,
+		AltID:         in.AltId,
+		LegacyId:      in.LegacyId,
+		AccountNumber: in.AccountNumber,
 	}
 
-	currency := models.CurrencyIDR
-	if subCategory.Currency != "" {
-		currency = subCategory.Currency
+	// an empty name & alt id and a nil legacy id are not updated
+	changes := []models.AccountFieldChange{
+		{Field: models.AccountChangeLogFieldOwnerID, Before: act.OwnerID, After: update.OwnerID},
+	}
+	if update.Name != "" {
+		changes = append(changes, models.AccountFieldChange{Field: models.AccountChangeLogFieldName, Before: act.AccountName, After: update.Name})
 	}
-	account = models.CreateAccount{
-		AccountNumber:   accountNumber,
-		OwnerID:         in.OwnerID,
-		AccountType:     subCategory.AccountType,
-		ProductTypeCode: subCategory.ProductTypeCode,
-		EntityCode:      in.EntityCode,
-		CategoryCode:    subCategory.CategoryCode,
-		SubCategoryCode: subCategory.Code,
-		Currency:        currency,
-		Status:          models.MapAccountStatus[models.ACCOUNT_STATUS_ACTIVE],
-		Name:            in.Name,
+	if update.AltID != "" {
+		changes = append(changes, models.AccountFieldChange{Field: models.AccountChangeLogFieldAltID, Before: act.AltID, After: update.AltID})
 	}
+	if update.LegacyId != nil {
+		changes = append(changes, models.AccountFieldChange{Field: models.AccountChangeLogFieldLegacyID, Before: act.LegacyId.ChangeLogValue(), After: update.LegacyId.ChangeLogValue()})
+	}
+	logs := models.NewAccountChangeLogs(update.AccountNumber, models.AccountChangeLogActorMigration, "t24 account migration", changes...)
 
-	return
+	return as.updateWithChangeLogs(ctx, logs, func(ctx context.Context, r mysql.AccountRepository) error {
+		return r.Update(ctx, update)
+	})
 }
@@ -0,0 +1,90 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_account_ConsumerCreateAccountMigration(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+	in := models.CreateAccount{
+		AccountNumber: "21100100000001",
+		OwnerID:       "12345",
+		Name:          "Lender Yang Sangat Baik",
+		LegacyId:      &models.AccountLegacyId{"t24AccountNumber": "111000035909"},
+	}
+
+	tests := []struct {
+		name    string
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success case - update the existing account with the change logs",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().CheckLegacyIdIsExist(ctx, in.AccountNumber).Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().CheckAccountNumberIsExist(ctx, in.AccountNumber).Return(&models.CheckAccountNumberIsExist{AccountNumber: in.AccountNumber}, nil)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, in.AccountNumber).Return(models.GetAccountOut{
+					AccountNumber: in.AccountNumber,
+					OwnerID:       in.OwnerID,
+					AccountName:   "Lender Yang Baik",
+				}, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().Update(ctx, models.UpdateAccount{
+							Name:          in.Name,
+							OwnerID:       in.OwnerID,
+							LegacyId:      in.LegacyId,
+							AccountNumber: in.AccountNumber,
+						}).Return(nil)
+						testHelper.mockAccRepository.EXPECT().
+							InsertChangeLogs(ctx, gomock.Any()).
+							DoAndReturn(func(ctx context.Context, logs []models.CreateAccountChangeLog) error {
+								assert.Len(t, logs, 2)
+								for _, v := range logs {
+									assert.Equal(t, models.AccountChangeLogActorMigration, v.ChangedBy)
+								}
+								return nil
+							})
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - update is published to the dlq",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().CheckLegacyIdIsExist(ctx, in.AccountNumber).Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().CheckAccountNumberIsExist(ctx, in.AccountNumber).Return(&models.CheckAccountNumberIsExist{AccountNumber: in.AccountNumber}, nil)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, in.AccountNumber).Return(models.GetAccountOut{AccountNumber: in.AccountNumber}, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().Update(ctx, gomock.Any()).Return(assert.AnError)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(ctx, gomock.Any(), gomock.Any(), in.AccountNumber, gomock.Any()).
+					Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := testHelper.accountService.ConsumerCreateAccountMigration(ctx, in)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -2,9 +2,11 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"sort"
+	"time"
 
+	"bitbucket.org/Amartha/go-accounting/internal/config"
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
 	xlog "bitbucket.org/Amartha/go-x/log"
@@ -35,184 +37,189 @@ func (as *account) ConsumerCreateAccountRelationship(ctx context.Context, in mod
 	return
 }
 
+// CreateAccountRelationship links the existing account to the target accounts of the relationship rules of its sub category,
+// the target account is generated when the owner has no target account for the account.
 func (as *account) CreateAccountRelationship(ctx context.Context, in models.CreateAccount) (err error) {
-	var (
-		newAccounts   = make([]models.CreateAccount, 0, 3)
-		lenderAccount models.CreateLenderAccount
-		loanAccount   models.CreateLoanAccount
-	)
-
-	accountNumber := in.AccountNumber
-
-	account, err := as.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, accountNumber)
+	account, err := as.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, in.AccountNumber)
 	if err != nil {
 		err = checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
 		return
 	}
 
-	// generate account number if have invested account
-	invested, ok := as.srv.conf.AccountConfig.InvestedAccountNumber[in.SubCategoryCode]
-	if ok {
-		result, err := as.srv.mySqlRepo.GetAccountRepository().GetAllAccountNumbersByParam(ctx, models.GetAllAccountNumbersByParamIn{
-			OwnerId:         in.OwnerID,
-			SubCategoryCode: invested,
-		})
-		if err != nil {
-			err = checkDatabaseError(err)
-			return err
-		}
-		accounts := result
-
-		var investedAccount models.CreateAccount
-		lenderAccount.CIHAccountNumber = accountNumber
-		if len(accounts) == 0 {
-			investedAccount, err = as.generateOtherAccount(ctx, in, invested)
-			if err != nil {
-				return err
-			}
-			lenderAccount.InvestedAccountNumber = investedAccount.AccountNumber
-			newAccounts = append(newAccounts, investedAccount)
-		} else if len(accounts) == 1 {
-			lenderAccount.InvestedAccountNumber = accounts[0].AccountNumber
-		} else {
-			date := account.CreatedAt
-			for _, v := range accounts {
-				if date.Before(v.CreatedAt) || date.Equal(v.CreatedAt) {
-					lenderAccount.InvestedAccountNumber = v.AccountNumber
-					break
-				}
-			}
-			if lenderAccount.InvestedAccountNumber == "" {
-				investedAccount, err = as.generateOtherAccount(ctx, in, invested)
-				if err != nil {
-					return err
-				}
-				lenderAccount.InvestedAccountNumber = investedAccount.AccountNumber
-				newAccounts = append(newAccounts, investedAccount)
-			}
-		}
+	related, err := as.resolveRelatedAccounts(ctx, in, &account.CreatedAt, as.generateRelatedAccount)
+	if err != nil {
+		return
 	}
 
-	// generate account number if have receivables account
-	receivables, ok := as.srv.conf.AccountConfig.ReceivablesAccountNumber[in.SubCategoryCode]
-	if ok {
-		result, err := as.srv.mySqlRepo.GetAccountRepository().GetAllAccountNumbersByParam(ctx, models.GetAllAccountNumbersByParamIn{
-			OwnerId:         in.OwnerID,
-			SubCategoryCode: receivables,
-		})
-		if err != nil {
-			err = checkDatabaseError(err, models.ErrKeyOwnerIdNotFound)
-			return err
-		}
-		accounts := result
-
-		var receivablesAccount models.CreateAccount
-		lenderAccount.CIHAccountNumber = accountNumber
-		if len(accounts) == 0 {
-			receivablesAccount, err = as.generateOtherAccount(ctx, in, receivables)
-			if err != nil {
-				return err
-			}
-			lenderAccount.ReceivablesAccountNumber = receivablesAccount.AccountNumber
-			newAccounts = append(newAccounts, receivablesAccount)
-		} else if len(accounts) == 1 {
-			lenderAccount.ReceivablesAccountNumber = accounts[0].AccountNumber
-		} else {
-			date := account.CreatedAt
-			for _, v := range accounts {
-				if date.Before(v.CreatedAt) || date.Equal(v.CreatedAt) {
-					lenderAccount.ReceivablesAccountNumber = v.AccountNumber
-					break
-				}
-			}
-			if lenderAccount.ReceivablesAccountNumber == "" {
-				receivablesAccount, err = as.generateOtherAccount(ctx, in, receivables)
-				if err != nil {
-					return err
-				}
-				lenderAccount.ReceivablesAccountNumber = receivablesAccount.AccountNumber
-				newAccounts = append(newAccounts, receivablesAccount)
+	if err = as.srv.mySqlRepo.Atomic(ctx, func(actx context.Context, r mysql.SQLRepository) (err error) {
+		if related.lenderAccount.CIHAccountNumber != "" {
+			if err = r.GetAccountRepository().CreateLenderAccount(actx, related.lenderAccount); err != nil {
+				return
 			}
 		}
-	}
 
-	// generate account number if have loan account normal
-	loanAdvancePayment, ok := as.srv.conf.AccountConfig.MultiLoanAccount[in.SubCategoryCode]
-	if ok {
-		result, err := as.srv.mySqlRepo.GetAccountRepository().GetAllAccountNumbersByParam(ctx, models.GetAllAccountNumbersByParamIn{
-			OwnerId:         in.OwnerID,
-			SubCategoryCode: loanAdvancePayment,
-		})
-		if err != nil {
-			err = checkDatabaseError(err, models.ErrKeyOwnerIdNotFound)
-			return err
+		if related.loanAccount.LoanAccountNumber != "" {
+			if err = r.GetAccountRepository().CreateLoanAccount(actx, related.loanAccount); err != nil {
+				return
+			}
 		}
-		accounts := result
 
-		var loanAdvancePaymentAccount models.CreateAccount
-		loanAccount.LoanAccountNumber = accountNumber
-		if len(accounts) == 0 {
-			loanAdvancePaymentAccount, err = as.generateOtherAccount(ctx, in, loanAdvancePayment)
-			if err != nil {
-				return err
-			}
-			loanAdvancePaymentAccount.AltId = fmt.Sprintf("%s-%s", accountNumber, in.AltId)
-			loanAccount.LoanAdvancePaymentAccountNumber = loanAdvancePaymentAccount.AccountNumber
-			newAccounts = append(newAccounts, loanAdvancePaymentAccount)
-		} else if len(accounts) == 1 {
-			loanAccount.LoanAdvancePaymentAccountNumber = accounts[0].AccountNumber
-		} else {
-			date := account.CreatedAt
-			for _, v := range accounts {
-				if date.Before(v.CreatedAt) || date.Equal(v.CreatedAt) {
-					loanAccount.LoanAdvancePaymentAccountNumber = v.AccountNumber
-					break
-				}
+		if len(related.newAccounts) > 0 {
+			if err = r.GetAccountRepository().BulkInsertAcctAccount(actx, related.newAccounts); err != nil {
+				return
 			}
-			if loanAccount.LoanAdvancePaymentAccountNumber == "" {
-				loanAdvancePaymentAccount, err = as.generateOtherAccount(ctx, in, loanAdvancePayment)
-				if err != nil {
-					return err
-				}
-				loanAdvancePaymentAccount.AltId = fmt.Sprintf("%s-%s", accountNumber, in.AltId)
-				loanAccount.LoanAdvancePaymentAccountNumber = loanAdvancePaymentAccount.AccountNumber
-				newAccounts = append(newAccounts, loanAdvancePaymentAccount)
+
+			if err = r.GetAccountRepository().BulkInsertAccount(actx, related.newAccounts); err != nil {
+				return
 			}
 		}
+
+		return
+	}); err != nil {
+		return
 	}
 
-	if err = as.srv.mySqlRepo.Atomic(ctx, func(actx context.Context, r mysql.SQLRepository) (err error) {
-		if lenderAccount.CIHAccountNumber != "" {
-			if err = r.GetAccountRepository().CreateLenderAccount(actx, lenderAccount); err != nil {
+	if len(related.newAccounts) > 0 {
+		// publish account creation
+		as.publishAccount(ctx, related.newAccounts)
+	}
+	return
+}
+
+// relatedAccounts are the target accounts of the relationship rules of a source account.
+type relatedAccounts struct {
+	newAccounts   []models.CreateAccount
+	lenderAccount models.CreateLenderAccount
+	loanAccount   models.CreateLoanAccount
+}
+
+// link stores the target account to the lender or loan account of the source account by the relation of the rule.
+func (ra *relatedAccounts) link(rule models.AccountRelationshipRule, sourceAccountNumber, targetAccountNumber string) {
+	switch rule.Relation {
+	case models.AccountRelationInvested:
+		ra.lenderAccount.CIHAccountNumber = sourceAccountNumber
+		ra.lenderAccount.InvestedAccountNumber = targetAccountNumber
+	case models.AccountRelationReceivables:
+		ra.lenderAccount.CIHAccountNumber = sourceAccountNumber
+		ra.lenderAccount.ReceivablesAccountNumber = targetAccountNumber
+	case models.AccountRelationLoanAdvancePayment:
+		ra.loanAccount.LoanAccountNumber = sourceAccountNumber
+		ra.loanAccount.LoanAdvancePaymentAccountNumber = targetAccountNumber
+	}
+}
+
+type generateRelatedAccountFunc func(ctx context.Context, in models.CreateAccount, rule models.AccountRelationshipRule) (models.CreateAccount, error)
+
+/*
+resolveRelatedAccounts evaluates the active relationship rules of the sub category of the source account,
+createdAt is the creation time of an existing source account & nil for a new source account.
+1. a rule with the target account number links the fixed target account
+2. a many to one rule uses the existing target account of the owner
+3. a one to one rule generates the target account of a new source account,
+an existing source account uses the target account of the owner created together with or after it
+4. the target account is generated when there is no target account to use
+5. the rules of the deprecated config are the fallback of the relations without a stored rule
+*/
+func (as *account) resolveRelatedAccounts(ctx context.Context, in models.CreateAccount, createdAt *time.Time, generate generateRelatedAccountFunc) (out relatedAccounts, err error) {
+	rules, err := as.srv.mySqlRepo.GetAccountRepository().GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{
+		SourceSubCategoryCode: in.SubCategoryCode,
+		Status:                models.StatusActive,
+	})
+	if err != nil {
+		err = checkDatabaseError(err)
+		return
+	}
+	rules = append(rules, legacyRelationshipRules(as.srv.conf.AccountConfig, in.SubCategoryCode, in.AccountNumber)...)
+
+	for _, rule := range applicableRelationshipRules(rules, in.AccountNumber) {
+		targetAccountNumber := rule.TargetAccountNumber
+		if targetAccountNumber == "" && (rule.Cardinality == models.AccountRelationshipCardinalityManyToOne || createdAt != nil) {
+			existing, errExisting := as.srv.mySqlRepo.GetAccountRepository().GetAllAccountNumbersByParam(ctx, models.GetAllAccountNumbersByParamIn{
+				OwnerId:         in.OwnerID,
+				SubCategoryCode: rule.TargetSubCategoryCode,
+			})
+			if errExisting != nil {
+				err = checkDatabaseError(errExisting, models.ErrKeyOwnerIdNotFound)
 				return
 			}
+			targetAccountNumber = selectRelatedAccountNumber(rule, existing, createdAt)
 		}
 
-		if loanAccount.LoanAccountNumber != "" {
-			if err = r.GetAccountRepository().CreateLoanAccount(actx, loanAccount); err != nil {
+		if targetAccountNumber == "" {
+			xlog.Info(ctx, "[ACCOUNT-RELATIONSHIPS]", xlog.String("relation", rule.Relation), xlog.String("sub-category-code", rule.TargetSubCategoryCode))
+			target, errGenerate := generate(ctx, in, rule)
+			if errGenerate != nil {
+				err = errGenerate
 				return
 			}
+			targetAccountNumber = target.AccountNumber
+			out.newAccounts = append(out.newAccounts, target)
 		}
 
-		if len(newAccounts) > 0 {
-			if err = r.GetAccountRepository().BulkInsertAcctAccount(actx, newAccounts); err != nil {
-				return
-			}
+		out.link(rule, in.AccountNumber, targetAccountNumber)
+	}
 
-			if err = r.GetAccountRepository().BulkInsertAccount(actx, newAccounts); err != nil {
-				return
-			}
+	return
+}
+
+// applicableRelationshipRules returns a rule per relation for the source account,
+// the rule of the source account number takes precedence over the rule of the sub category & the first rule wins otherwise.
+func applicableRelationshipRules(rules []models.AccountRelationshipRule, accountNumber string) []models.AccountRelationshipRule {
+	mapRule := map[string]int{}
+	out := []models.AccountRelationshipRule{}
+	for _, rule := range rules {
+		if rule.SourceAccountNumber != "" && rule.SourceAccountNumber != accountNumber {
+			continue
 		}
 
-		return
-	}); err != nil {
+		i, ok := mapRule[rule.Relation]
+		if !ok {
+			mapRule[rule.Relation] = len(out)
+			out = append(out, rule)
+			continue
+		}
+		if rule.SourceAccountNumber != "" && out[i].SourceAccountNumber == "" {
+			out[i] = rule
+		}
+	}
+
+	return out
+}
+
+// selectRelatedAccountNumber returns the target account number to use from the existing target accounts of the owner,
+// an empty account number means the target account must be generated.
+func selectRelatedAccountNumber(rule models.AccountRelationshipRule, existing []models.GetAllAccountNumbersByParamOut, createdAt *time.Time) string {
+	if len(existing) == 0 {
+		return ""
+	}
+	if rule.Cardinality == models.AccountRelationshipCardinalityManyToOne || len(existing) == 1 {
+		return existing[0].AccountNumber
+	}
+
+	for _, v := range existing {
+		if !v.CreatedAt.Before(*createdAt) {
+			return v.AccountNumber
+		}
+	}
+
+	return ""
+}
+
+// generateRelatedAccount generates the target account of the rule, the name follows the naming template of the rule.
+func (as *account) generateRelatedAccount(ctx context.Context, in models.CreateAccount, rule models.AccountRelationshipRule) (account models.CreateAccount, err error) {
+	account, err = as.generateOtherAccount(ctx, in, rule.TargetSubCategoryCode)
+	if err != nil {
 		return
 	}
 
-	if len(newAccounts) > 0 {
-		// publish account creation
-		as.publishAccount(ctx, newAccounts)
+	account.Name = rule.AccountName(in)
+	if rule.Relation == models.AccountRelationLoanAdvancePayment {
+		account.AltId = in.AccountNumber
+		if in.AltId != "" {
+			account.AltId = fmt.Sprintf("%s-%s", in.AccountNumber, in.AltId)
+		}
 	}
+
 	return
 }
 
@@ -228,12 +235,15 @@ func (as *account) CreateLoanAdvanceAccount(ctx context.Context, in models.Creat
 		return
 	}
 
-	_, err = as.srv.mySqlRepo.GetAccountRepository().GetLoanAdvanceAccountByLoanAccount(ctx, account.AccountNumber)
-	if err != nil && !errors.Is(err, models.ErrNoRows) {
+	advanceAccounts, err := as.srv.mySqlRepo.GetAccountRepository().GetRelations(ctx, models.AccountRelationFilterOptions{
+		ParentAccountNumbers: []string{account.AccountNumber},
+		Relations:            []string{models.AccountRelationLoanAdvancePayment},
+	})
+	if err != nil {
 		return
 	}
 
-	if errors.Is(err, models.ErrNoRows) {
+	if len(advanceAccounts) == 0 {
 		in.Name = account.AccountName
 		in.AccountType = account.AccountType
 		in.EntityCode = account.EntityCode
@@ -282,3 +292,59 @@ func (as *account) CreateLoanAdvanceAccount(ctx context.Context, in models.Creat
 
 	return
 }
+
+// legacyRelationshipRules returns the rules of the deprecated config for the source account,
+// they are evaluated after the stored rules so a stored rule of the same relation wins.
+func legacyRelationshipRules(conf config.AccountConfig, subCategoryCode, accountNumber string) []models.AccountRelationshipRule {
+	out := []models.AccountRelationshipRule{}
+	for _, rule := range legacySubCategoryRelationshipRules(conf) {
+		if rule.SourceSubCategoryCode == subCategoryCode {
+			out = append(out, rule)
+		}
+	}
+
+	if target, ok := conf.LenderInstiReceivablesAccount[accountNumber]; ok {
+		out = append(out, models.AccountRelationshipRule{
+			SourceSubCategoryCode: subCategoryCode,
+			SourceAccountNumber:   accountNumber,
+			TargetSubCategoryCode: conf.ReceivablesAccountNumber[subCategoryCode],
+			TargetAccountNumber:   target,
+			Relation:              models.AccountRelationReceivables,
+			Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+			Status:                models.StatusActive,
+		})
+	}
+
+	return out
+}
+
+// legacySubCategoryRelationshipRules returns the rules of the sub categories of the deprecated config sorted by the source sub category.
+func legacySubCategoryRelationshipRules(conf config.AccountConfig) []models.AccountRelationshipRule {
+	out := []models.AccountRelationshipRule{}
+	for _, v := range []struct {
+		relation string
+		targets  map[string]string
+	}{
+		{models.AccountRelationInvested, conf.InvestedAccountNumber},
+		{models.AccountRelationReceivables, conf.ReceivablesAccountNumber},
+		{models.AccountRelationLoanAdvancePayment, conf.MultiLoanAccount},
+	} {
+		sources := make([]string, 0, len(v.targets))
+		for source := range v.targets {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+
+		for _, source := range sources {
+			out = append(out, models.AccountRelationshipRule{
+				SourceSubCategoryCode: source,
+				TargetSubCategoryCode: v.targets[source],
+				Relation:              v.relation,
+				Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+				Status:                models.StatusActive,
+			})
+		}
+	}
+
+	return out
+}
@@ -9,16 +9,17 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/acuanclient"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/gocustomer"
 	"bitbucket.org/Amartha/go-accounting/internal/repositories/cache"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
 
 	xlog "bitbucket.org/Amartha/go-x/log"
 
-	"github.com/google/uuid"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -29,6 +30,8 @@ type AccountService interface {
 	CreateBranchPointAccount(ctx context.Context, in models.CreateAccount) (err error)
 	Update(ctx context.Context, in models.UpdateAccount) (out models.UpdateAccount, err error)
 	UpdateAccountEntity(ctx context.Context, in models.UpdateAccountEntity) (out models.UpdateAccountEntity, err error)
+	UpdateAccountStatus(ctx context.Context, in models.UpdateAccountStatus) (out models.UpdateAccountStatus, err error)
+	GetAccountHistory(ctx context.Context, opts models.AccountChangeLogFilterOptions) (out []models.AccountChangeLog, err error)
 	GetOneByAccountNumber(ctx context.Context, accountNumber string) (out models.GetAccountOut, err error)
 	GetOneByLegacyID(ctx context.Context, legacyID string) (out models.GetAccountOut, err error)
 	GetAccountList(ctx context.Context, opts models.AccountFilterOptions) (accounts []models.GetAccountOut, total int, err error)
@@ -43,6 +46,18 @@ type AccountService interface {
 	ConsumerCreateAccountMigration(ctx context.Context, in models.CreateAccount) (err error)
 	GetLoanAdvanceAccountByLoanAccount(ctx context.Context, loanAccountNumber string) (out models.AccountLoan, err error)
 	UpdateAccountByCustomerData(ctx context.Context, in gocustomer.CustomerEventPayload) (err error)
+	GetAccountBalance(ctx context.Context, accountNumber string, asOf time.Time) (out models.AccountBalance, err error)
+	GetAccountBalances(ctx context.Context, opts models.AccountBalanceFilterOptions) (out models.AccountBalances, err error)
+	CreateAccountRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (out *models.AccountRelationshipRule, err error)
+	GetAccountRelationshipRules(ctx context.Context, opts models.AccountRelationshipRuleFilterOptions) (out []models.AccountRelationshipRule, err error)
+	GetAccountRelationshipRuleById(ctx context.Context, id int) (out *models.AccountRelationshipRule, err error)
+	UpdateAccountRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (out *models.AccountRelationshipRule, err error)
+	DeleteAccountRelationshipRule(ctx context.Context, id int) (err error)
+	SeedAccountRelationshipRules(ctx context.Context) (out []models.AccountRelationshipRule, err error)
+	SetAccountParent(ctx context.Context, in models.AccountRelation) (out *models.AccountRelation, err error)
+	RemoveAccountParent(ctx context.Context, accountNumber string) (err error)
+	GetAccountHierarchy(ctx context.Context, accountNumber string, balanceDate time.Time) (out models.AccountHierarchy, err error)
+	BulkUpdateAccounts(ctx context.Context, in models.BulkUpdateAccountsIn) (out models.AccountBulkUpdateReport, err error)
 
 	CreateLoanPartnerAccount(ctx context.Context, in models.CreateAccountLoanPartner) (out models.AccountsLoanPartner, err error)
 }
@@ -67,18 +82,11 @@ func (as *account) Update(ctx context.Context, in models.UpdateAccount) (out mod
 		return
 	}
 
-	/*
-	 1. If in.LegacyId is nil, replace value for in.LegacyId with act.LegacyId.
-	 2. If in.LegacyId has the same value as act.LegacyId, you can choose which value to use.
-	    So, for points 1 & 2, the legacy Id value in database will never change
-
-	 3. If in.LegacyId does not have the same value as act.LegacyId:
-	    first check the database whether this value has become another account's legacy ID or not.
-	    if true, you are not allowed to update this account
-	    if false, you are allowed to update this account
-	*/
-	if in.LegacyId == nil || reflect.DeepEqual(in.LegacyId, act.LegacyId) {
-		in.LegacyId = act.LegacyId
+	in.LegacyId, err = as.resolveUpdateLegacyId(ctx, in.LegacyId, act.LegacyId)
+	if err != nil {
+		return
+	}
+	if in.LegacyId == act.LegacyId {
 		legacy, legacyErr := in.LegacyId.Value()
 		if legacyErr != nil {
 			err = models.GetErrMap(models.ErrKeyFailedMarshal, legacyErr.Error())
@@ -88,26 +96,26 @@ func (as *account) Update(ctx context.Context, in models.UpdateAccount) (out mod
 			err = models.GetErrMap(models.ErrKeyFailedUnmarshal, err.Error())
 			return out, err
 		}
-	} else {
-		value, ok := (*in.LegacyId)["t24AccountNumber"]
-		if ok {
-			isExistLegacyId, errGetLegacyID := as.srv.mySqlRepo.GetAccountRepository().CheckLegacyIdIsExist(ctx, value.(string))
-			if errGetLegacyID != nil {
-				err = checkDatabaseError(errGetLegacyID, models.ErrKeyDatabaseError)
-				return
-			}
-			if isExistLegacyId {
-				xlog.Info(ctx, "[UPDATE-ACCOUNT]", xlog.String("status", "legacy id is exist, you are not allowed to update this account"), xlog.Any("message", in))
-				err = models.GetErrMap(models.ErrKeyLegacyIdalreadyExists)
-				return
-			}
-		}
 	}
 
 	in.Name = removeSpecialChars(in.Name)
 
-	if err = as.srv.mySqlRepo.GetAccountRepository().Update(ctx, in); err != nil {
-		err = checkDatabaseError(err)
+	// an empty name & alt id are not updated
+	changes := []models.AccountFieldChange{
+		{Field: models.AccountChangeLogFieldOwnerID, Before: act.OwnerID, After: in.OwnerID},
+		{Field: models.AccountChangeLogFieldLegacyID, Before: act.LegacyId.ChangeLogValue(), After: in.LegacyId.ChangeLogValue()},
+	}
+	if in.Name != "" {
+		changes = append(changes, models.AccountFieldChange{Field: models.AccountChangeLogFieldName, Before: act.AccountName, After: in.Name})
+	}
+	if in.AltID != "" {
+		changes = append(changes, models.AccountFieldChange{Field: models.AccountChangeLogFieldAltID, Before: act.AltID, After: in.AltID})
+	}
+	logs := models.NewAccountChangeLogs(in.AccountNumber, in.ChangedBy, in.Reason, changes...)
+
+	if err = as.updateWithChangeLogs(ctx, logs, func(ctx context.Context, r mysql.AccountRepository) error {
+		return r.Update(ctx, in)
+	}); err != nil {
 		return
 	}
 
@@ -139,6 +147,32 @@ func (as *account) Update(ctx context.Context, in models.UpdateAccount) (out mod
 	return in, err
 }
 
+/*
+resolveUpdateLegacyId returns the legacy id written by an account update,
+1. the current legacy id is kept when in is nil or has the same value, so the legacy id in database never changes
+2. otherwise in is only allowed when its t24 account number is not the legacy id of another account
+*/
+func (as *account) resolveUpdateLegacyId(ctx context.Context, in, current *models.AccountLegacyId) (out *models.AccountLegacyId, err error) {
+	if in == nil || reflect.DeepEqual(in, current) {
+		return current, nil
+	}
+
+	if value, ok := (*in)["t24AccountNumber"].(string); ok {
+		isExistLegacyId, errGetLegacyID := as.srv.mySqlRepo.GetAccountRepository().CheckLegacyIdIsExist(ctx, value)
+		if errGetLegacyID != nil {
+			err = checkDatabaseError(errGetLegacyID, models.ErrKeyDatabaseError)
+			return
+		}
+		if isExistLegacyId {
+			xlog.Info(ctx, "[UPDATE-ACCOUNT]", xlog.String("status", "legacy id is exist, you are not allowed to update this account"), xlog.Any("message", in))
+			err = models.GetErrMap(models.ErrKeyLegacyIdalreadyExists)
+			return
+		}
+	}
+
+	return in, nil
+}
+
 func (as *account) GetOneByAccountNumber(ctx context.Context, accountNumber string) (out models.GetAccountOut, err error) {
 	var errs *multierror.Error
 
@@ -366,35 +400,14 @@ func (as *account) ProcessUploadAccounts(ctx context.Context, file *multipart.Fi
 		return
 	}
 
-	for _, r := range records[1:] {
-		account := models.CreateAccount{
-			Name:            strings.TrimSpace(r[1]),
-			OwnerID:         strings.TrimSpace(r[2]),
-			ProductTypeCode: strings.TrimSpace(r[3]),
-			EntityCode:      strings.TrimSpace(r[4]),
-			CategoryCode:    strings.TrimSpace(r[5]),
-			SubCategoryCode: strings.TrimSpace(r[6]),
-			Currency:        strings.TrimSpace(r[7]),
-			AltId:           strings.TrimSpace(r[8]),
-			Metadata: &models.Metadata{
-				"remarks":    "manual upload",
-				"uploadDate": atime.Now().String(),
-			},
-		}
-
-		legacyId := strings.TrimSpace(r[0])
-		if legacyId != "" {
-			account.LegacyId = &models.AccountLegacyId{
-				"t24AccountNumber": legacyId,
-			}
+	for i, r := range records[1:] {
+		account, errParse := parseUploadAccount(r)
+		if errParse != nil {
+			err = fmt.Errorf("error process data %s caused by - %v ", strings.Join(r, ","), errParse)
+			return
 		}
 
-		if err = as.srv.publisher.PublishSyncWithKeyAndLog(ctx,
-			"publish account to pas_account_stream",
-			as.srv.conf.Kafka.Publishers.PASAccountStream.Topic,
-			uuid.New().String(),
-			account,
-		); err != nil {
+		if err = as.publishUploadAccount(ctx, fmt.Sprintf("%s-row-%d", file.Filename, i+2), account); err != nil {
 			err = fmt.Errorf("error process data %s caused by - %v ", strings.Join(r, ","), err)
 			return
 		}
@@ -403,6 +416,51 @@ func (as *account) ProcessUploadAccounts(ctx context.Context, file *multipart.Fi
 	return
 }
 
+// uploadAccountColumns is the number of columns of the upload account csv.
+const uploadAccountColumns = 9
+
+// parseUploadAccount transforms an upload account csv record to an account to be created by the pas account stream.
+func parseUploadAccount(r []string) (account models.CreateAccount, err error) {
+	if len(r) < uploadAccountColumns {
+		err = fmt.Errorf("record has %d columns, expected %d columns", len(r), uploadAccountColumns)
+		return
+	}
+
+	account = models.CreateAccount{
+		Name:            strings.TrimSpace(r[1]),
+		OwnerID:         strings.TrimSpace(r[2]),
+		ProductTypeCode: strings.TrimSpace(r[3]),
+		EntityCode:      strings.TrimSpace(r[4]),
+		CategoryCode:    strings.TrimSpace(r[5]),
+		SubCategoryCode: strings.TrimSpace(r[6]),
+		Currency:        strings.TrimSpace(r[7]),
+		AltId:           strings.TrimSpace(r[8]),
+		Metadata: &models.Metadata{
+			"remarks":    "manual upload",
+			"uploadDate": atime.Now().String(),
+		},
+	}
+
+	legacyId := strings.TrimSpace(r[0])
+	if legacyId != "" {
+		account.LegacyId = &models.AccountLegacyId{
+			"t24AccountNumber": legacyId,
+		}
+	}
+
+	return account, nil
+}
+
+// publishUploadAccount publishes an uploaded account with the key of its row, so a row published again by a retry has the same key.
+func (as *account) publishUploadAccount(ctx context.Context, key string, account models.CreateAccount) error {
+	return as.srv.publisher.PublishSyncWithKeyAndLog(ctx,
+		"publish account to pas_account_stream",
+		as.srv.conf.Kafka.Publishers.PASAccountStream.Topic,
+		key,
+		account,
+	)
+}
+
 func (as *account) GetAllAccountNumbersByParam(ctx context.Context, in models.GetAllAccountNumbersByParamIn) (out []models.GetAllAccountNumbersByParamOut, err error) {
 	defer func() {
 		logService(ctx, err)
@@ -453,11 +511,16 @@ func (as *account) GetLenderAccountByCIHAccountNumber(ctx context.Context, accou
 			}
 		}
 
-		out, err = as.srv.mySqlRepo.GetAccountRepository().GetLenderAccountByCIHAccountNumber(ctx, accountNumber)
+		var related map[string]string
+		related, err = as.getRelatedAccountNumbers(ctx, accountNumber, models.AccountRelationInvested, models.AccountRelationReceivables)
 		if err != nil {
-			err = checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
 			return
 		}
+		out = models.AccountLender{
+			CIHAccountNumber:         accountNumber,
+			InvestedAccountNumber:    related[models.AccountRelationInvested],
+			ReceivablesAccountNumber: related[models.AccountRelationReceivables],
+		}
 
 		data, errMarshal := json.Marshal(out)
 		if errMarshal != nil {
@@ -512,11 +575,15 @@ func (as *account) GetLoanAdvanceAccountByLoanAccount(ctx context.Context, loanA
 			}
 		}
 
-		out, err = as.srv.mySqlRepo.GetAccountRepository().GetLoanAdvanceAccountByLoanAccount(ctx, loanAccountNumber)
+		var related map[string]string
+		related, err = as.getRelatedAccountNumbers(ctx, loanAccountNumber, models.AccountRelationLoanAdvancePayment)
 		if err != nil {
-			err = checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
 			return
 		}
+		out = models.AccountLoan{
+			LoanAccountNumber:               loanAccountNumber,
+			LoanAdvancePaymentAccountNumber: related[models.AccountRelationLoanAdvancePayment],
+		}
 
 		data, errMarshal := json.Marshal(out)
 		if errMarshal != nil {
@@ -595,15 +662,19 @@ func (as *account) UpdateAccountByCustomerData(ctx context.Context, in gocustome
 
 	for _, act := range accounts {
 		if act.AccountName != in.Name {
-			err = as.srv.mySqlRepo.GetAccountRepository().Update(ctx, models.UpdateAccount{
+			update := models.UpdateAccount{
 				AccountNumber: act.AccountNumber,
 				OwnerID:       act.OwnerID,
 				AltID:         act.AltID,
 				LegacyId:      act.LegacyId,
 				Name:          in.Name,
-			})
-			if err != nil {
-				err = checkDatabaseError(err)
+			}
+			logs := models.NewAccountChangeLogs(act.AccountNumber, models.AccountChangeLogActorGoCustomer, "customer data updated",
+				models.AccountFieldChange{Field: models.AccountChangeLogFieldName, Before: act.AccountName, After: in.Name},
+			)
+			if err = as.updateWithChangeLogs(ctx, logs, func(ctx context.Context, r mysql.AccountRepository) error {
+				return r.Update(ctx, update)
+			}); err != nil {
 				return
 			}
 
@@ -633,11 +704,7 @@ func (as *account) UpdateAccountEntity(ctx context.Context, in models.UpdateAcco
 		logService(ctx, err)
 	}()
 
-	var (
-		keys    []string
-		entity  *models.Entity
-		isExist bool
-	)
+	var keys []string
 
 	act, err := as.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, in.AccountNumber)
 	if err != nil {
@@ -645,29 +712,18 @@ func (as *account) UpdateAccountEntity(ctx context.Context, in models.UpdateAcco
 		return
 	}
 
-	entity, err = as.srv.mySqlRepo.GetEntityRepository().GetByCode(ctx, in.EntityCode)
-	if err != nil {
-		return
-	}
-	if entity == nil {
-		err = models.GetErrMap(models.ErrKeyEntityCodeNotFound)
-		return
-	}
-
-	isExist, err = as.srv.mySqlRepo.GetAccountingRepository().GetOneSplitAccount(ctx, in.AccountNumber)
-	if err != nil {
-		err = checkDatabaseError(err)
-		return
-	}
-	if isExist {
-		err = models.GetErrMap(models.ErrKeyJournalAccountIsExist)
+	if err = as.checkUpdateAccountEntity(ctx, in.AccountNumber, in.EntityCode); err != nil {
 		return
 	}
 
+	logs := models.NewAccountChangeLogs(in.AccountNumber, in.ChangedBy, in.Reason,
+		models.AccountFieldChange{Field: models.AccountChangeLogFieldEntityCode, Before: act.EntityCode, After: in.EntityCode},
+	)
 	act.EntityCode = in.EntityCode
 
-	if err = as.srv.mySqlRepo.GetAccountRepository().UpdateEntity(ctx, in); err != nil {
-		err = checkDatabaseError(err)
+	if err = as.updateWithChangeLogs(ctx, logs, func(ctx context.Context, r mysql.AccountRepository) error {
+		return r.UpdateEntity(ctx, in)
+	}); err != nil {
 		return
 	}
 
@@ -694,3 +750,25 @@ func (as *account) UpdateAccountEntity(ctx context.Context, in models.UpdateAcco
 
 	return in, err
 }
+
+// checkUpdateAccountEntity checks the entity exists & the account has no transactions,
+// the entity of an account with transactions is never changed.
+func (as *account) checkUpdateAccountEntity(ctx context.Context, accountNumber, entityCode string) error {
+	entity, err := as.srv.mySqlRepo.GetEntityRepository().GetByCode(ctx, entityCode)
+	if err != nil {
+		return err
+	}
+	if entity == nil {
+		return models.GetErrMap(models.ErrKeyEntityCodeNotFound)
+	}
+
+	isExist, err := as.srv.mySqlRepo.GetAccountingRepository().GetOneSplitAccount(ctx, accountNumber)
+	if err != nil {
+		return checkDatabaseError(err)
+	}
+	if isExist {
+		return models.GetErrMap(models.ErrKeyJournalAccountIsExist)
+	}
+
+	return nil
+}
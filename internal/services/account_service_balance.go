@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+)
+
+const defaultMaxBatchAccountBalance = 100
+
+// GetAccountBalance returns the balance of the account at the as of time.
+func (as *account) GetAccountBalance(ctx context.Context, accountNumber string, asOf time.Time) (out models.AccountBalance, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	balances, err := as.GetAccountBalances(ctx, models.AccountBalanceFilterOptions{
+		AccountNumbers: []string{accountNumber},
+		AsOf:           asOf,
+	})
+	if err != nil {
+		return
+	}
+	if len(balances.Balances) == 0 {
+		err = models.GetErrMap(models.ErrKeyAccountNumberNotFound)
+		return
+	}
+
+	return balances.Balances[0], nil
+}
+
+/*
+GetAccountBalances returns the balance of the accounts at the as of time without waiting for the daily balance job,
+1. get the accounts, an unknown account is returned in the not found account numbers
+2. get the last daily balance of every account before the as of date from acct_account_daily_balance
+3. sum the splits after the daily balance date until the as of time, every split is summed when the account has no daily balance
+4. the balance is the closing balance of the daily balance plus the movements by the normal balance of the coa type
+*/
+func (as *account) GetAccountBalances(ctx context.Context, opts models.AccountBalanceFilterOptions) (out models.AccountBalances, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	maxBatch := as.srv.conf.AccountConfig.MaxBatchAccountBalance
+	if maxBatch <= 0 {
+		maxBatch = defaultMaxBatchAccountBalance
+	}
+
+	seen := make(map[string]struct{}, len(opts.AccountNumbers))
+	accountNumbers := make([]string, 0, len(opts.AccountNumbers))
+	for _, v := range opts.AccountNumbers {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		accountNumbers = append(accountNumbers, v)
+	}
+	if len(accountNumbers) > maxBatch {
+		err = models.GetErrMap(models.ErrKeyAccountBalanceBatchExceeded, fmt.Sprintf("max account numbers is %d", maxBatch))
+		return
+	}
+
+	out = models.AccountBalances{
+		AsOf:                   opts.AsOf,
+		Balances:               []models.AccountBalance{},
+		NotFoundAccountNumbers: []string{},
+	}
+
+	accounts := make([]models.GetAccountOut, 0, len(accountNumbers))
+	for _, v := range accountNumbers {
+		account, errGet := as.GetOneByAccountNumber(ctx, v)
+		if errGet != nil {
+			if errors.Is(errGet, models.GetErrMap(models.ErrKeyAccountNumberNotFound)) {
+				out.NotFoundAccountNumbers = append(out.NotFoundAccountNumbers, v)
+				continue
+			}
+			err = errGet
+			return
+		}
+		accounts = append(accounts, account)
+	}
+	if len(accounts) == 0 {
+		return out, nil
+	}
+
+	found := make([]string, 0, len(accounts))
+	for _, v := range accounts {
+		found = append(found, v.AccountNumber)
+	}
+
+	act := as.srv.mySqlRepo.GetAccountingRepository()
+	latest, err := act.GetLatestAccountDailyBalances(ctx, found, atime.ToZeroTime(opts.AsOf))
+	if err != nil {
+		err = checkDatabaseError(err)
+		return
+	}
+	dailyBalances := make(map[string]models.AccountBalanceDaily, len(latest))
+	for _, v := range latest {
+		dailyBalances[v.AccountNumber] = v
+	}
+
+	periods := make([]models.AccountBalanceDaily, 0, len(found))
+	for _, v := range found {
+		periods = append(periods, models.AccountBalanceDaily{
+			AccountNumber: v,
+			BalanceDate:   dailyBalances[v].BalanceDate,
+		})
+	}
+	movements, err := act.GetAccountMovementsAfterDailyBalance(ctx, periods, opts.AsOf)
+	if err != nil {
+		err = checkDatabaseError(err)
+		return
+	}
+	mapMovements := make(map[string]models.LedgerAccountMovement, len(movements))
+	for _, v := range movements {
+		mapMovements[v.AccountNumber] = v
+	}
+
+	for _, v := range accounts {
+		balance := models.AccountBalance{
+			AccountNumber:   v.AccountNumber,
+			EntityCode:      v.EntityCode,
+			SubCategoryCode: v.SubCategoryCode,
+			Currency:        v.Currency,
+			AsOf:            opts.AsOf,
+			DebitMovement:   mapMovements[v.AccountNumber].DebitMovement,
+			CreditMovement:  mapMovements[v.AccountNumber].CreditMovement,
+		}
+		if balance.Currency == "" {
+			balance.Currency = models.CurrencyIDR
+		}
+		if dailyBalance, ok := dailyBalances[v.AccountNumber]; ok {
+			balanceDate := dailyBalance.BalanceDate
+			balance.DailyBalanceDate = &balanceDate
+			balance.DailyBalance = dailyBalance.ClosingBalance
+		}
+
+		balance.Balance = balance.DailyBalance.Add(balance.DebitMovement).Sub(balance.CreditMovement)
+		if models.IsCreditNormalBalance(v.CoaTypeCode) {
+			balance.Balance = balance.DailyBalance.Add(balance.CreditMovement).Sub(balance.DebitMovement)
+		}
+		out.Balances = append(out.Balances, balance)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,280 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_account_GetAccountBalances(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	asOf, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDDWithTime, "2024-04-30 13:00:00")
+	balanceDate, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-04-28")
+
+	assetAccount := models.GetAccountOut{
+		AccountNumber:   "142001000000001",
+		EntityCode:      "001",
+		SubCategoryCode: "14201",
+		CoaTypeCode:     models.COATypeAsset,
+		Currency:        "IDR",
+	}
+	liabilityAccount := models.GetAccountOut{
+		AccountNumber:   "212001000000001",
+		EntityCode:      "001",
+		SubCategoryCode: "21201",
+		CoaTypeCode:     models.COATypeLiability,
+	}
+	mockGetAccount := func(ctx context.Context, account models.GetAccountOut) {
+		val, _ := json.Marshal(account)
+		testHelper.mockCacheRepository.EXPECT().
+			Get(ctx, fmt.Sprintf("%s_%s", "pas_account_key", account.AccountNumber)).
+			Return(string(val), nil)
+	}
+
+	type args struct {
+		ctx  context.Context
+		opts models.AccountBalanceFilterOptions
+	}
+	tests := []struct {
+		name     string
+		args     args
+		maxBatch int
+		doMock   func(args args)
+		want     models.AccountBalances
+		wantErr  bool
+	}{
+		{
+			name: "success case",
+			args: args{
+				ctx: context.Background(),
+				opts: models.AccountBalanceFilterOptions{
+					AccountNumbers: []string{"142001000000001", "212001000000001", "142001000000001", "999001000000001"},
+					AsOf:           asOf,
+				},
+			},
+			doMock: func(args args) {
+				mockGetAccount(args.ctx, assetAccount)
+				mockGetAccount(args.ctx, liabilityAccount)
+				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, "pas_account_key_999001000000001").Return("", assert.AnError)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(args.ctx, "999001000000001").
+					Return(models.GetAccountOut{}, models.ErrNoRows)
+				testHelper.mockAcctRepository.EXPECT().
+					GetLatestAccountDailyBalances(args.ctx, []string{"142001000000001", "212001000000001"}, atime.ToZeroTime(asOf)).
+					Return([]models.AccountBalanceDaily{{
+						AccountNumber:  "142001000000001",
+						BalanceDate:    balanceDate,
+						ClosingBalance: decimal.NewFromInt(100000),
+					}}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountMovementsAfterDailyBalance(args.ctx, []models.AccountBalanceDaily{
+						{AccountNumber: "142001000000001", BalanceDate: balanceDate},
+						{AccountNumber: "212001000000001"},
+					}, asOf).
+					Return([]models.LedgerAccountMovement{
+						{AccountNumber: "142001000000001", DebitMovement: decimal.NewFromInt(50000), CreditMovement: decimal.NewFromInt(20000)},
+						{AccountNumber: "212001000000001", DebitMovement: decimal.NewFromInt(10000), CreditMovement: decimal.NewFromInt(75000)},
+					}, nil)
+			},
+			want: models.AccountBalances{
+				AsOf: asOf,
+				Balances: []models.AccountBalance{
+					{
+						AccountNumber:    "142001000000001",
+						EntityCode:       "001",
+						SubCategoryCode:  "14201",
+						Currency:         "IDR",
+						AsOf:             asOf,
+						DailyBalanceDate: &balanceDate,
+						DailyBalance:     decimal.NewFromInt(100000),
+						DebitMovement:    decimal.NewFromInt(50000),
+						CreditMovement:   decimal.NewFromInt(20000),
+						Balance:          decimal.NewFromInt(130000),
+					},
+					{
+						AccountNumber:   "212001000000001",
+						EntityCode:      "001",
+						SubCategoryCode: "21201",
+						Currency:        models.CurrencyIDR,
+						AsOf:            asOf,
+						DebitMovement:   decimal.NewFromInt(10000),
+						CreditMovement:  decimal.NewFromInt(75000),
+						Balance:         decimal.NewFromInt(65000),
+					},
+				},
+				NotFoundAccountNumbers: []string{"999001000000001"},
+			},
+		},
+		{
+			name: "success case - every account not found",
+			args: args{
+				ctx: context.Background(),
+				opts: models.AccountBalanceFilterOptions{
+					AccountNumbers: []string{"999001000000001"},
+					AsOf:           asOf,
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, "pas_account_key_999001000000001").Return("", assert.AnError)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(args.ctx, "999001000000001").
+					Return(models.GetAccountOut{}, models.ErrNoRows)
+			},
+			want: models.AccountBalances{
+				AsOf:                   asOf,
+				Balances:               []models.AccountBalance{},
+				NotFoundAccountNumbers: []string{"999001000000001"},
+			},
+		},
+		{
+			name: "error case - exceed batch limit",
+			args: args{
+				ctx: context.Background(),
+				opts: models.AccountBalanceFilterOptions{
+					AccountNumbers: []string{"142001000000001", "212001000000001"},
+					AsOf:           asOf,
+				},
+			},
+			maxBatch: 1,
+			wantErr:  true,
+		},
+		{
+			name: "error case - database error get account",
+			args: args{
+				ctx: context.Background(),
+				opts: models.AccountBalanceFilterOptions{
+					AccountNumbers: []string{"142001000000001"},
+					AsOf:           asOf,
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, "pas_account_key_142001000000001").Return("", assert.AnError)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(args.ctx, "142001000000001").
+					Return(models.GetAccountOut{}, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - database error get latest daily balances",
+			args: args{
+				ctx: context.Background(),
+				opts: models.AccountBalanceFilterOptions{
+					AccountNumbers: []string{"142001000000001"},
+					AsOf:           asOf,
+				},
+			},
+			doMock: func(args args) {
+				mockGetAccount(args.ctx, assetAccount)
+				testHelper.mockAcctRepository.EXPECT().
+					GetLatestAccountDailyBalances(args.ctx, gomock.Any(), gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - database error get movements",
+			args: args{
+				ctx: context.Background(),
+				opts: models.AccountBalanceFilterOptions{
+					AccountNumbers: []string{"142001000000001"},
+					AsOf:           asOf,
+				},
+			},
+			doMock: func(args args) {
+				mockGetAccount(args.ctx, assetAccount)
+				testHelper.mockAcctRepository.EXPECT().
+					GetLatestAccountDailyBalances(args.ctx, gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountMovementsAfterDailyBalance(args.ctx, gomock.Any(), asOf).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			testHelper.config.AccountConfig.MaxBatchAccountBalance = tt.maxBatch
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			got, err := testHelper.accountService.GetAccountBalances(tt.args.ctx, tt.args.opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_account_GetAccountBalance(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	asOf, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDDWithTime, "2024-04-30 13:00:00")
+
+	type args struct {
+		ctx           context.Context
+		accountNumber string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success case",
+			args: args{
+				ctx:           context.Background(),
+				accountNumber: "142001000000001",
+			},
+			doMock: func(args args) {
+				val, _ := json.Marshal(models.GetAccountOut{AccountNumber: args.accountNumber, CoaTypeCode: models.COATypeAsset})
+				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, "pas_account_key_142001000000001").Return(string(val), nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetLatestAccountDailyBalances(args.ctx, []string{args.accountNumber}, atime.ToZeroTime(asOf)).
+					Return(nil, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountMovementsAfterDailyBalance(args.ctx, gomock.Any(), asOf).
+					Return(nil, nil)
+			},
+		},
+		{
+			name: "error case - account number not found",
+			args: args{
+				ctx:           context.Background(),
+				accountNumber: "999001000000001",
+			},
+			doMock: func(args args) {
+				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, "pas_account_key_999001000000001").Return("", assert.AnError)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(args.ctx, args.accountNumber).
+					Return(models.GetAccountOut{}, models.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			_, err := testHelper.accountService.GetAccountBalance(tt.args.ctx, tt.args.accountNumber, asOf)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
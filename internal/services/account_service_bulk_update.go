@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/acuanclient"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/validation"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	defaultMaxBulkUpdateAccounts = 100
+
+	// bulkUpdateAccountsFlushRows is the number of rows after which the updated accounts are invalidated & published
+	bulkUpdateAccountsFlushRows = 100
+)
+
+// parseBulkUpdateAccountColumns returns the index of every column of the bulk update account csv by the header name.
+func parseBulkUpdateAccountColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, v := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(v, "\ufeff")))
+		if name == "" {
+			continue
+		}
+		if _, ok := columns[name]; ok {
+			return nil, models.GetErrMap(models.ErrKeyInvalidAccountBulkUpdateFile, fmt.Sprintf("column %s is duplicated", name))
+		}
+		columns[name] = i
+	}
+	if _, ok := columns[models.AccountBulkUpdateColumnAccountNumber]; !ok {
+		return nil, models.GetErrMap(models.ErrKeyInvalidAccountBulkUpdateFile, fmt.Sprintf("column %s is required", models.AccountBulkUpdateColumnAccountNumber))
+	}
+
+	return columns, nil
+}
+
+// parseBulkUpdateAccount transforms a bulk update account csv record to a row of the bulk update.
+func parseBulkUpdateAccount(columns map[string]int, r []string, row int) models.BulkUpdateAccount {
+	value := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(r) {
+			return ""
+		}
+		return strings.TrimSpace(r[i])
+	}
+
+	account := models.BulkUpdateAccount{
+		Row:           row,
+		AccountNumber: value(models.AccountBulkUpdateColumnAccountNumber),
+		Name:          value(models.AccountBulkUpdateColumnName),
+		OwnerID:       value(models.AccountBulkUpdateColumnOwnerID),
+		AltID:         value(models.AccountBulkUpdateColumnAltID),
+		EntityCode:    value(models.AccountBulkUpdateColumnEntityCode),
+	}
+
+	if t24AccountNumber := value(models.AccountBulkUpdateColumnT24AccountNumber); t24AccountNumber != "" {
+		account.LegacyId = &models.AccountLegacyId{
+			"t24AccountNumber": t24AccountNumber,
+		}
+	}
+
+	if metadata := value(models.AccountBulkUpdateColumnMetadata); metadata != "" {
+		account.Metadata = &models.Metadata{}
+		if err := json.Unmarshal([]byte(metadata), account.Metadata); err != nil {
+			account.Err = fmt.Errorf("%s must be a json object", models.AccountBulkUpdateColumnMetadata)
+		}
+	}
+
+	return account
+}
+
+/*
+BulkUpdateAccounts updates every row of the bulk update with partial success,
+1. every row is updated in its own transaction with the change logs, a failed row does not stop the next rows
+2. a row goes through the same legacy id & entity checks as the single account update
+3. the caches of the updated accounts are invalidated & the accounts are published to acuan every bulkUpdateAccountsFlushRows rows
+
+the rows are updated inside the request, so the accounts are limited to MaxBulkUpdateAccounts,
+a larger update is uploaded as a csv & processed by the account bulk update upload job.
+*/
+func (as *account) BulkUpdateAccounts(ctx context.Context, in models.BulkUpdateAccountsIn) (out models.AccountBulkUpdateReport, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	maxAccounts := as.srv.conf.AccountConfig.MaxBulkUpdateAccounts
+	if maxAccounts <= 0 {
+		maxAccounts = defaultMaxBulkUpdateAccounts
+	}
+	if len(in.Accounts) > maxAccounts {
+		err = models.GetErrMap(models.ErrKeyAccountBulkUpdateExceeded, fmt.Sprintf("max accounts is %d, upload a csv file for more accounts", maxAccounts))
+		return
+	}
+
+	out = models.AccountBulkUpdateReport{
+		Kind: models.KindAccountBulkUpdateReport,
+		Rows: []models.AccountBulkUpdateRowReport{},
+	}
+
+	updater := as.newAccountBulkUpdater(in.ChangedBy, in.Reason)
+	for _, v := range in.Accounts {
+		row := models.AccountBulkUpdateRowReport{
+			Row:           v.Row,
+			AccountNumber: v.AccountNumber,
+		}
+		if errUpdate := updater.update(ctx, v); errUpdate != nil {
+			row.AddError(errUpdate)
+		}
+		out.AddRow(row)
+	}
+	updater.flush(ctx)
+
+	xlog.Info(ctx, "[BULK-UPDATE-ACCOUNTS]",
+		xlog.Int("total-rows", out.TotalRows),
+		xlog.Int("success-rows", out.SuccessRows),
+		xlog.Int("failed-rows", out.FailedRows),
+		xlog.Int("updated-accounts", updater.updated),
+	)
+
+	return out, nil
+}
+
+// accountBulkUpdater updates the rows of a bulk update one by one, it is shared by the bulk update request & the bulk update upload job.
+// The updated accounts are invalidated & published every bulkUpdateAccountsFlushRows rows,
+// so the updated accounts are not stale until the last row of a large file.
+type accountBulkUpdater struct {
+	as        *account
+	changedBy string
+	reason    string
+
+	rows               int
+	rowByAccountNumber map[string]int
+	keys               []string
+	publishes          []acuanclient.PublishAccountData
+	updated            int
+}
+
+func (as *account) newAccountBulkUpdater(changedBy, reason string) *accountBulkUpdater {
+	return &accountBulkUpdater{
+		as:                 as,
+		changedBy:          changedBy,
+		reason:             reason,
+		rowByAccountNumber: make(map[string]int),
+	}
+}
+
+// update updates the account of a row, an account number of a previous row is failed as duplicated.
+func (u *accountBulkUpdater) update(ctx context.Context, in models.BulkUpdateAccount) error {
+	if u.rows > 0 && u.rows%bulkUpdateAccountsFlushRows == 0 {
+		u.flush(ctx)
+	}
+	u.rows++
+
+	if first, ok := u.rowByAccountNumber[in.AccountNumber]; ok && in.AccountNumber != "" {
+		return models.GetErrMap(models.ErrKeyAccountBulkUpdateDuplicated, fmt.Sprintf("row %d", first))
+	}
+	u.rowByAccountNumber[in.AccountNumber] = in.Row
+
+	data, keys, err := u.as.bulkUpdateAccount(ctx, in, u.changedBy, u.reason)
+	if err != nil {
+		return err
+	}
+	if data != nil {
+		u.keys = append(u.keys, keys...)
+		u.publishes = append(u.publishes, *data)
+	}
+
+	return nil
+}
+
+// flush invalidates the caches of the accounts updated since the previous flush & publishes them to acuan.
+func (u *accountBulkUpdater) flush(ctx context.Context) {
+	if len(u.keys) > 0 {
+		u.as.deleteCaching(ctx, u.keys)
+	}
+	if len(u.publishes) > 0 {
+		u.as.srv.acuanClient.PublishAccountsOneByOne(ctx, u.publishes)
+	}
+	u.updated += len(u.publishes)
+	u.keys, u.publishes = nil, nil
+}
+
+// bulkUpdateAccount updates an account of the bulk update & returns the account to be published with the cache keys to be deleted,
+// nothing is returned when the row does not change the account.
+func (as *account) bulkUpdateAccount(ctx context.Context, in models.BulkUpdateAccount, changedBy, reason string) (data *acuanclient.PublishAccountData, keys []string, err error) {
+	if in.Err != nil {
+		return nil, nil, in.Err
+	}
+
+	if err = validation.ValidateStruct(in); err != nil {
+		var errs *multierror.Error
+		if errors.As(err, &errs) {
+			for i, v := range errs.Errors {
+				var errValidate validation.ErrorValidateResponse
+				if errors.As(v, &errValidate) {
+					errs.Errors[i] = fmt.Errorf("%s %s", errValidate.Field, errValidate.Message)
+				}
+			}
+		}
+		return nil, nil, err
+	}
+
+	act, err := as.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, in.AccountNumber)
+	if err != nil {
+		err = checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
+		return
+	}
+
+	update := models.UpdateAccount{
+		AccountNumber: in.AccountNumber,
+		Name:          removeSpecialChars(in.Name),
+		OwnerID:       in.OwnerID,
+		AltID:         in.AltID,
+		ChangedBy:     changedBy,
+		Reason:        reason,
+	}
+	if update.OwnerID == "" {
+		update.OwnerID = act.OwnerID
+	}
+
+	update.LegacyId, err = as.resolveUpdateLegacyId(ctx, in.LegacyId, act.LegacyId)
+	if err != nil {
+		return
+	}
+
+	if in.EntityCode != "" && in.EntityCode != act.EntityCode {
+		if err = as.checkUpdateAccountEntity(ctx, in.AccountNumber, in.EntityCode); err != nil {
+			return
+		}
+		update.EntityCode = in.EntityCode
+	}
+
+	if in.Metadata != nil {
+		update.Metadata = mergeAccountMetadata(act.Metadata, *in.Metadata)
+	}
+
+	// an empty name, alt id & entity code and a nil metadata are not updated
+	changes := []models.AccountFieldChange{
+		{Field: models.AccountChangeLogFieldOwnerID, Before: act.OwnerID, After: update.OwnerID},
+		{Field: models.AccountChangeLogFieldLegacyID, Before: act.LegacyId.ChangeLogValue(), After: update.LegacyId.ChangeLogValue()},
+	}
+	if update.Name != "" {
+		changes = append(changes, models.AccountFieldChange{Field: models.AccountChangeLogFieldName, Before: act.AccountName, After: update.Name})
+	}
+	if update.AltID != "" {
+		changes = append(changes, models.AccountFieldChange{Field: models.AccountChangeLogFieldAltID, Before: act.AltID, After: update.AltID})
+	}
+	if update.EntityCode != "" {
+		changes = append(changes, models.AccountFieldChange{Field: models.AccountChangeLogFieldEntityCode, Before: act.EntityCode, After: update.EntityCode})
+	}
+	if update.Metadata != nil {
+		changes = append(changes, models.AccountFieldChange{Field: models.AccountChangeLogFieldMetadata, Before: act.Metadata.ChangeLogValue(), After: update.Metadata.ChangeLogValue()})
+	}
+	logs := models.NewAccountChangeLogs(update.AccountNumber, changedBy, reason, changes...)
+	if len(logs) == 0 {
+		return nil, nil, nil
+	}
+
+	if err = as.updateWithChangeLogs(ctx, logs, func(ctx context.Context, r mysql.AccountRepository) error {
+		return r.Update(ctx, update)
+	}); err != nil {
+		return
+	}
+
+	data = &acuanclient.PublishAccountData{
+		Type: models.TypeAccountUpdated,
+
+		AccountNumber:   act.AccountNumber,
+		Name:            act.AccountName,
+		ProductTypeName: act.ProductTypeName,
+		OwnerId:         update.OwnerID,
+		CategoryCode:    act.CategoryCode,
+		SubCategoryCode: act.SubCategoryCode,
+		EntityCode:      act.EntityCode,
+		Currency:        act.Currency,
+		AltId:           act.AltID,
+		Status:          act.Status,
+		LegacyId:        update.LegacyId,
+		Metadata:        act.Metadata,
+	}
+	if update.Name != "" {
+		data.Name = update.Name
+	}
+	if update.AltID != "" {
+		data.AltId = update.AltID
+	}
+	if update.EntityCode != "" {
+		data.EntityCode = update.EntityCode
+	}
+	if update.Metadata != nil {
+		data.Metadata = update.Metadata
+	}
+
+	// the legacy keys of the previous & the new t24 account number are both stale
+	legacyIds := []*models.AccountLegacyId{act.LegacyId}
+	if update.LegacyId != act.LegacyId {
+		legacyIds = append(legacyIds, update.LegacyId)
+	}
+	keys = []string{pasAccountKey(in.AccountNumber)}
+	for _, v := range legacyIds {
+		if v == nil {
+			continue
+		}
+		if t24AccountNumber, ok := (*v)["t24AccountNumber"].(string); ok && t24AccountNumber != "" && t24AccountNumber != "0" {
+			keys = append(keys, pasAccountLegacyKey(t24AccountNumber))
+		}
+	}
+
+	return data, keys, nil
+}
+
+// mergeAccountMetadata returns the current metadata with the keys of the update, a key with a null value is removed.
+func mergeAccountMetadata(current *models.Metadata, update models.Metadata) *models.Metadata {
+	merged := models.Metadata{}
+	if current != nil {
+		for k, v := range *current {
+			merged[k] = v
+		}
+	}
+	for k, v := range update {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = v
+	}
+
+	return &merged
+}
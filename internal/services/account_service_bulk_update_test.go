@@ -0,0 +1,174 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/acuanclient"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_account_BulkUpdateAccounts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success case - partial success", func(t *testing.T) {
+		testHelper := serviceTestHelper(t)
+
+		in := models.BulkUpdateAccountsIn{
+			Accounts: []models.BulkUpdateAccount{
+				{Row: 1, AccountNumber: "21100100000001", Name: "Lender Yang Baik!", EntityCode: "003", Metadata: &models.Metadata{"segment": "retail", "remarks": nil}},
+				{Row: 2, AccountNumber: "21100100000002", Name: "Lender Not Found"},
+				{Row: 3, AccountNumber: "21100100000001", Name: "Lender Duplicated"},
+				{Row: 4, AccountNumber: "21100100000003", OwnerID: "owner-id"},
+				{Row: 5, AccountNumber: "21100100000004", LegacyId: &models.AccountLegacyId{"t24AccountNumber": "111000035910"}},
+				{Row: 6, AccountNumber: "21100100000005", Err: errors.New("metadata must be a json object")},
+			},
+			ChangedBy: "tono@amartha.com",
+			Reason:    "lender accounts migration",
+		}
+
+		act := models.GetAccountOut{
+			AccountNumber: "21100100000001",
+			AccountName:   "Lender",
+			OwnerID:       "12345",
+			EntityCode:    "001",
+			LegacyId:      &models.AccountLegacyId{"t24AccountNumber": "111000035909"},
+			Metadata:      &models.Metadata{"remarks": "manual upload"},
+		}
+		update := models.UpdateAccount{
+			AccountNumber: "21100100000001",
+			Name:          "Lender Yang Baik",
+			OwnerID:       "12345",
+			LegacyId:      act.LegacyId,
+			EntityCode:    "003",
+			Metadata:      &models.Metadata{"segment": "retail"},
+			ChangedBy:     "tono@amartha.com",
+			Reason:        "lender accounts migration",
+		}
+
+		testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), "21100100000001").Return(act, nil)
+		testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "003").Return(&models.Entity{}, nil)
+		testHelper.mockAcctRepository.EXPECT().GetOneSplitAccount(gomock.Any(), "21100100000001").Return(false, nil)
+		testHelper.mockMySQLRepository.EXPECT().
+			Atomic(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+				testHelper.mockAccRepository.EXPECT().Update(ctx, update).Return(nil)
+				testHelper.mockAccRepository.EXPECT().InsertChangeLogs(ctx, gomock.Len(3)).Return(nil)
+				return steps(ctx, testHelper.mockMySQLRepository)
+			})
+		testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), "21100100000002").Return(models.GetAccountOut{}, models.ErrNoRows)
+		testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), "21100100000004").Return(models.GetAccountOut{AccountNumber: "21100100000004", OwnerID: "12346"}, nil)
+		testHelper.mockAccRepository.EXPECT().CheckLegacyIdIsExist(gomock.Any(), "111000035910").Return(true, nil)
+
+		testHelper.mockCacheRepository.EXPECT().Del(gomock.Any(), "pas_account_key_21100100000001", "pas_account_legacy_key_111000035909").Return(nil)
+		testHelper.mockAcuanClient.EXPECT().PublishAccountsOneByOne(gomock.Any(), []acuanclient.PublishAccountData{{
+			Type:          models.TypeAccountUpdated,
+			AccountNumber: "21100100000001",
+			Name:          "Lender Yang Baik",
+			OwnerId:       "12345",
+			EntityCode:    "003",
+			LegacyId:      act.LegacyId,
+			Metadata:      update.Metadata,
+		}})
+
+		got, err := testHelper.accountService.BulkUpdateAccounts(ctx, in)
+		assert.NoError(t, err)
+		assert.Equal(t, models.AccountBulkUpdateReport{
+			Kind:        models.KindAccountBulkUpdateReport,
+			TotalRows:   6,
+			SuccessRows: 1,
+			FailedRows:  5,
+			Rows: []models.AccountBulkUpdateRowReport{
+				{Row: 1, AccountNumber: "21100100000001", Status: models.AccountBulkUpdateRowStatusSuccess, Errors: []string{}},
+				{Row: 2, AccountNumber: "21100100000002", Status: models.AccountBulkUpdateRowStatusFailed, Errors: []string{"account number not found"}},
+				{Row: 3, AccountNumber: "21100100000001", Status: models.AccountBulkUpdateRowStatusFailed, Errors: []string{"account number is duplicated in the bulk update caused by row 1"}},
+				{Row: 4, AccountNumber: "21100100000003", Status: models.AccountBulkUpdateRowStatusFailed, Errors: []string{"ownerId field can only contain alphanumeric values"}},
+				{Row: 5, AccountNumber: "21100100000004", Status: models.AccountBulkUpdateRowStatusFailed, Errors: []string{"the resource could not be updated because the legacy id already exists"}},
+				{Row: 6, AccountNumber: "21100100000005", Status: models.AccountBulkUpdateRowStatusFailed, Errors: []string{"metadata must be a json object"}},
+			},
+		}, got)
+	})
+
+	t.Run("success case - unchanged account is not updated", func(t *testing.T) {
+		testHelper := serviceTestHelper(t)
+
+		testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), "21100100000001").Return(models.GetAccountOut{
+			AccountNumber: "21100100000001",
+			AccountName:   "Lender Yang Baik",
+			OwnerID:       "12345",
+			EntityCode:    "001",
+		}, nil)
+
+		got, err := testHelper.accountService.BulkUpdateAccounts(ctx, models.BulkUpdateAccountsIn{
+			Accounts: []models.BulkUpdateAccount{{Row: 1, AccountNumber: "21100100000001", Name: "Lender Yang Baik", EntityCode: "001"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.SuccessRows)
+	})
+
+	t.Run("success case - updated accounts are flushed every 100 rows", func(t *testing.T) {
+		testHelper := serviceTestHelper(t)
+		testHelper.config.AccountConfig.MaxBulkUpdateAccounts = 200
+
+		accounts := make([]models.BulkUpdateAccount, 101)
+		for i := range accounts {
+			accountNumber := fmt.Sprintf("211001%08d", i+1)
+			accounts[i] = models.BulkUpdateAccount{Row: i + 1, AccountNumber: accountNumber, OwnerID: "12346"}
+			testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), accountNumber).Return(models.GetAccountOut{
+				AccountNumber: accountNumber,
+				OwnerID:       "12345",
+			}, nil)
+		}
+		testHelper.mockMySQLRepository.EXPECT().
+			Atomic(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+				testHelper.mockAccRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+				testHelper.mockAccRepository.EXPECT().InsertChangeLogs(ctx, gomock.Len(1)).Return(nil)
+				return steps(ctx, testHelper.mockMySQLRepository)
+			}).
+			Times(101)
+
+		gomock.InOrder(
+			testHelper.mockCacheRepository.EXPECT().Del(gomock.Any(), gomock.Any()).Return(nil),
+			testHelper.mockAcuanClient.EXPECT().PublishAccountsOneByOne(gomock.Any(), gomock.Len(100)),
+			testHelper.mockCacheRepository.EXPECT().Del(gomock.Any(), "pas_account_key_21100100000101").Return(nil),
+			testHelper.mockAcuanClient.EXPECT().PublishAccountsOneByOne(gomock.Any(), gomock.Len(1)),
+		)
+
+		got, err := testHelper.accountService.BulkUpdateAccounts(ctx, models.BulkUpdateAccountsIn{Accounts: accounts})
+		assert.NoError(t, err)
+		assert.Equal(t, 101, got.SuccessRows)
+	})
+
+	t.Run("error case - entity of an account with transactions", func(t *testing.T) {
+		testHelper := serviceTestHelper(t)
+
+		testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), "21100100000001").Return(models.GetAccountOut{
+			AccountNumber: "21100100000001",
+			EntityCode:    "001",
+		}, nil)
+		testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "003").Return(&models.Entity{}, nil)
+		testHelper.mockAcctRepository.EXPECT().GetOneSplitAccount(gomock.Any(), "21100100000001").Return(true, nil)
+
+		got, err := testHelper.accountService.BulkUpdateAccounts(ctx, models.BulkUpdateAccountsIn{
+			Accounts: []models.BulkUpdateAccount{{Row: 1, AccountNumber: "21100100000001", EntityCode: "003"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"unable to change the entity because the account has a transactions"}, got.Rows[0].Errors)
+	})
+
+	t.Run("error case - accounts exceed the limit", func(t *testing.T) {
+		testHelper := serviceTestHelper(t)
+
+		_, err := testHelper.accountService.BulkUpdateAccounts(ctx, models.BulkUpdateAccountsIn{
+			Accounts: make([]models.BulkUpdateAccount, 101),
+		})
+		assert.Equal(t, models.GetErrMap(models.ErrKeyAccountBulkUpdateExceeded, "max accounts is 100, upload a csv file for more accounts"), err)
+	})
+}
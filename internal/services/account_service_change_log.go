@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+)
+
+// GetAccountHistory returns the change log of the account, the latest change first.
+func (as *account) GetAccountHistory(ctx context.Context, opts models.AccountChangeLogFilterOptions) (out []models.AccountChangeLog, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	acc := as.srv.mySqlRepo.GetAccountRepository()
+	if _, err = acc.GetOneByAccountNumber(ctx, opts.AccountNumber); err != nil {
+		err = checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
+		return
+	}
+
+	out, err = acc.GetChangeLogs(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	return out, nil
+}
+
+// updateWithChangeLogs runs the update of the account & writes the change logs in the same transaction,
+// the account is never updated without its change logs.
+func (as *account) updateWithChangeLogs(
+	ctx context.Context,
+	logs []models.CreateAccountChangeLog,
+	update func(ctx context.Context, r mysql.AccountRepository) error,
+) error {
+	return as.srv.mySqlRepo.Atomic(ctx, func(actx context.Context, r mysql.SQLRepository) error {
+		if err := update(actx, r.GetAccountRepository()); err != nil {
+			return checkDatabaseError(err)
+		}
+
+		return r.GetAccountRepository().InsertChangeLogs(actx, logs)
+	})
+}
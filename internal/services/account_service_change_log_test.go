@@ -0,0 +1,83 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_account_GetAccountHistory(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	opts := models.AccountChangeLogFilterOptions{
+		AccountNumber: "22200100000001",
+		Field:         models.AccountChangeLogFieldName,
+	}
+	changeLogs := []models.AccountChangeLog{{
+		ID:            1,
+		AccountNumber: "22200100000001",
+		Field:         models.AccountChangeLogFieldName,
+		BeforeValue:   "Lender Yang Baik",
+		AfterValue:    "Lender Yang Sangat Baik",
+		ChangedBy:     "tono@amartha.com",
+		Reason:        "customer name correction",
+		CreatedAt:     time.Date(2024, 4, 30, 13, 0, 0, 0, time.UTC),
+	}}
+
+	type args struct {
+		ctx  context.Context
+		opts models.AccountChangeLogFilterOptions
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		want    []models.AccountChangeLog
+		wantErr bool
+	}{
+		{
+			name: "success case",
+			args: args{ctx: context.Background(), opts: opts},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.opts.AccountNumber).Return(models.GetAccountOut{}, nil)
+				testHelper.mockAccRepository.EXPECT().GetChangeLogs(args.ctx, args.opts).Return(changeLogs, nil)
+			},
+			want: changeLogs,
+		},
+		{
+			name: "error case - account number not found",
+			args: args{ctx: context.Background(), opts: opts},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.opts.AccountNumber).Return(models.GetAccountOut{}, models.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - database error get change logs",
+			args: args{ctx: context.Background(), opts: opts},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.opts.AccountNumber).Return(models.GetAccountOut{}, nil)
+				testHelper.mockAccRepository.EXPECT().GetChangeLogs(args.ctx, args.opts).Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			got, err := testHelper.accountService.GetAccountHistory(tt.args.ctx, tt.args.opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxAccountHierarchyDepth limits the levels of the subtree & the ancestors walked from an account.
+const maxAccountHierarchyDepth = 10
+
+/*
+SetAccountParent links the account to the parent account,
+1. the account & the parent account must exist
+2. the account has no parent account yet, including the cih & loan account of a lender & loan advance account
+3. the parent account must not be the account or a descendant of the account
+*/
+func (as *account) SetAccountParent(ctx context.Context, in models.AccountRelation) (out *models.AccountRelation, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	if in.AccountNumber == in.ParentAccountNumber {
+		err = models.GetErrMap(models.ErrKeyInvalidAccountParent, in.ParentAccountNumber)
+		return nil, err
+	}
+
+	acc := as.srv.mySqlRepo.GetAccountRepository()
+	for _, v := range []string{in.AccountNumber, in.ParentAccountNumber} {
+		if _, err = acc.GetOneByAccountNumber(ctx, v); err != nil {
+			err = checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
+			return nil, err
+		}
+	}
+
+	parents, err := acc.GetRelations(ctx, models.AccountRelationFilterOptions{
+		AccountNumbers: []string{in.AccountNumber},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(parents) > 0 {
+		err = models.GetErrMap(models.ErrKeyAccountParentIsExist, parents[0].ParentAccountNumber)
+		return nil, err
+	}
+
+	ancestor := in.ParentAccountNumber
+	for depth := 0; depth < maxAccountHierarchyDepth; depth++ {
+		parents, err = acc.GetRelations(ctx, models.AccountRelationFilterOptions{
+			AccountNumbers: []string{ancestor},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(parents) == 0 {
+			break
+		}
+		ancestor = parents[0].ParentAccountNumber
+		if ancestor == in.AccountNumber {
+			err = models.GetErrMap(models.ErrKeyInvalidAccountParent, in.ParentAccountNumber)
+			return nil, err
+		}
+	}
+
+	if err = acc.InsertParent(ctx, in); err != nil {
+		return nil, err
+	}
+
+	return &in, nil
+}
+
+// RemoveAccountParent unlinks the account from the parent account set by SetAccountParent,
+// the lender & loan advance accounts are never unlinked.
+func (as *account) RemoveAccountParent(ctx context.Context, accountNumber string) (err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	acc := as.srv.mySqlRepo.GetAccountRepository()
+	parents, err := acc.GetRelations(ctx, models.AccountRelationFilterOptions{
+		AccountNumbers: []string{accountNumber},
+		Relations:      []string{models.AccountRelationChild},
+	})
+	if err != nil {
+		return err
+	}
+	if len(parents) == 0 {
+		err = models.GetErrMap(models.ErrKeyAccountParentNotFound, accountNumber)
+		return err
+	}
+
+	return acc.DeleteParent(ctx, accountNumber)
+}
+
+/*
+GetAccountHierarchy returns the subtree of the account with the rolled up balances at the end of the balance date,
+1. get the children of every level of the subtree, at most maxAccountHierarchyDepth levels
+2. get the accounts of the subtree in one query
+3. get the closing balance of the last daily balance of every account until the balance date from acct_account_daily_balance
+4. the rolled up balance of an account is its balance plus the rolled up balance of the children of the same coa type & currency,
+the balances of different coa types have different normal balances so they are not added
+*/
+func (as *account) GetAccountHierarchy(ctx context.Context, accountNumber string, balanceDate time.Time) (out models.AccountHierarchy, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	root, err := as.GetOneByAccountNumber(ctx, accountNumber)
+	if err != nil {
+		return
+	}
+
+	acc := as.srv.mySqlRepo.GetAccountRepository()
+	children := make(map[string][]models.AccountRelation)
+	visited := map[string]struct{}{accountNumber: {}}
+	accountNumbers := []string{accountNumber}
+	level := []string{accountNumber}
+	for depth := 0; depth < maxAccountHierarchyDepth && len(level) > 0; depth++ {
+		relations, errGet := acc.GetRelations(ctx, models.AccountRelationFilterOptions{
+			ParentAccountNumbers: level,
+		})
+		if errGet != nil {
+			err = checkDatabaseError(errGet)
+			return
+		}
+
+		level = nil
+		for _, v := range relations {
+			if _, ok := visited[v.AccountNumber]; ok {
+				continue
+			}
+			visited[v.AccountNumber] = struct{}{}
+			children[v.ParentAccountNumber] = append(children[v.ParentAccountNumber], v)
+			accountNumbers = append(accountNumbers, v.AccountNumber)
+			level = append(level, v.AccountNumber)
+		}
+	}
+
+	accounts := map[string]models.GetAccountOut{accountNumber: root}
+	descendants, err := acc.GetByAccountNumbers(ctx, accountNumbers[1:])
+	if err != nil {
+		err = checkDatabaseError(err)
+		return
+	}
+	for _, v := range descendants {
+		accounts[v.AccountNumber] = v
+	}
+	for _, v := range accountNumbers[1:] {
+		if _, ok := accounts[v]; !ok {
+			err = models.GetErrMap(models.ErrKeyAccountNumberNotFound, v)
+			return
+		}
+	}
+
+	dailyBalances, err := as.srv.mySqlRepo.GetAccountingRepository().GetLatestAccountDailyBalances(ctx, accountNumbers, atime.ToZeroTime(balanceDate).AddDate(0, 0, 1))
+	if err != nil {
+		err = checkDatabaseError(err)
+		return
+	}
+	balances := make(map[string]decimal.Decimal, len(dailyBalances))
+	for _, v := range dailyBalances {
+		balances[v.AccountNumber] = v.ClosingBalance
+	}
+
+	var build func(account models.GetAccountOut, relation string) models.AccountHierarchy
+	build = func(account models.GetAccountOut, relation string) models.AccountHierarchy {
+		node := models.AccountHierarchy{
+			AccountNumber:   account.AccountNumber,
+			AccountName:     account.AccountName,
+			CoaTypeCode:     account.CoaTypeCode,
+			SubCategoryCode: account.SubCategoryCode,
+			Currency:        account.Currency,
+			Relation:        relation,
+			BalanceDate:     balanceDate,
+			Balance:         balances[account.AccountNumber],
+			Children:        []models.AccountHierarchy{},
+		}
+		if node.Currency == "" {
+			node.Currency = models.CurrencyIDR
+		}
+
+		node.RolledUpBalance = node.Balance
+		for _, v := range children[account.AccountNumber] {
+			child := build(accounts[v.AccountNumber], v.Relation)
+			if child.CoaTypeCode == node.CoaTypeCode && child.Currency == node.Currency {
+				node.RolledUpBalance = node.RolledUpBalance.Add(child.RolledUpBalance)
+			}
+			node.Children = append(node.Children, child)
+		}
+
+		return node
+	}
+
+	return build(root, ""), nil
+}
+
+// getRelatedAccountNumbers returns the child account number of every relation of the parent account,
+// the account number is empty when the lender or loan account has no child account of the relation.
+// the account is not found when it is not a lender or loan account of the relations.
+func (as *account) getRelatedAccountNumbers(ctx context.Context, parentAccountNumber string, relations ...string) (out map[string]string, err error) {
+	children, err := as.srv.mySqlRepo.GetAccountRepository().GetRelations(ctx, models.AccountRelationFilterOptions{
+		ParentAccountNumbers: []string{parentAccountNumber},
+		Relations:            relations,
+		IncludeUnlinked:      true,
+	})
+	if err != nil {
+		return nil, checkDatabaseError(err)
+	}
+	if len(children) == 0 {
+		return nil, models.GetErrMap(models.ErrKeyAccountNumberNotFound)
+	}
+
+	out = make(map[string]string, len(children))
+	for _, v := range children {
+		out[v.Relation] = v.AccountNumber
+	}
+
+	return out, nil
+}
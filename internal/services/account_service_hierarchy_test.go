@@ -0,0 +1,340 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_account_SetAccountParent(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	in := models.AccountRelation{
+		ParentAccountNumber: "211001000000001",
+		AccountNumber:       "211001000381110",
+		Relation:            models.AccountRelationChild,
+	}
+
+	type args struct {
+		ctx context.Context
+		in  models.AccountRelation
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr error
+	}{
+		{
+			name: "success case",
+			args: args{ctx: context.Background(), in: in},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, in.AccountNumber).Return(models.GetAccountOut{}, nil)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, in.ParentAccountNumber).Return(models.GetAccountOut{}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{AccountNumbers: []string{in.AccountNumber}}).
+					Return(nil, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{AccountNumbers: []string{in.ParentAccountNumber}}).
+					Return([]models.AccountRelation{{ParentAccountNumber: "311001000000001", AccountNumber: in.ParentAccountNumber, Relation: models.AccountRelationChild}}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{AccountNumbers: []string{"311001000000001"}}).
+					Return(nil, nil)
+				testHelper.mockAccRepository.EXPECT().InsertParent(args.ctx, in).Return(nil)
+			},
+		},
+		{
+			name: "error case - parent is the account",
+			args: args{ctx: context.Background(), in: models.AccountRelation{
+				ParentAccountNumber: in.AccountNumber,
+				AccountNumber:       in.AccountNumber,
+			}},
+			wantErr: models.GetErrMap(models.ErrKeyInvalidAccountParent, in.AccountNumber),
+		},
+		{
+			name: "error case - parent account not found",
+			args: args{ctx: context.Background(), in: in},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, in.AccountNumber).Return(models.GetAccountOut{}, nil)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, in.ParentAccountNumber).Return(models.GetAccountOut{}, models.ErrNoRows)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyAccountNumberNotFound),
+		},
+		{
+			name: "error case - account has a parent account",
+			args: args{ctx: context.Background(), in: in},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, gomock.Any()).Return(models.GetAccountOut{}, nil).Times(2)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{AccountNumbers: []string{in.AccountNumber}}).
+					Return([]models.AccountRelation{{ParentAccountNumber: "311001000000001", AccountNumber: in.AccountNumber, Relation: models.AccountRelationChild}}, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyAccountParentIsExist, "311001000000001"),
+		},
+		{
+			name: "error case - parent account is a descendant of the account",
+			args: args{ctx: context.Background(), in: in},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, gomock.Any()).Return(models.GetAccountOut{}, nil).Times(2)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{AccountNumbers: []string{in.AccountNumber}}).
+					Return(nil, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{AccountNumbers: []string{in.ParentAccountNumber}}).
+					Return([]models.AccountRelation{{ParentAccountNumber: in.AccountNumber, AccountNumber: in.ParentAccountNumber, Relation: models.AccountRelationInvested}}, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyInvalidAccountParent, in.ParentAccountNumber),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			out, err := testHelper.accountService.SetAccountParent(tt.args.ctx, tt.args.in)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, out)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &tt.args.in, out)
+		})
+	}
+}
+
+func Test_account_RemoveAccountParent(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	accountNumber := "211001000381110"
+	opts := models.AccountRelationFilterOptions{
+		AccountNumbers: []string{accountNumber},
+		Relations:      []string{models.AccountRelationChild},
+	}
+
+	tests := []struct {
+		name    string
+		doMock  func(ctx context.Context)
+		wantErr error
+	}{
+		{
+			name: "success case",
+			doMock: func(ctx context.Context) {
+				testHelper.mockAccRepository.EXPECT().GetRelations(ctx, opts).
+					Return([]models.AccountRelation{{ParentAccountNumber: "211001000000001", AccountNumber: accountNumber, Relation: models.AccountRelationChild}}, nil)
+				testHelper.mockAccRepository.EXPECT().DeleteParent(ctx, accountNumber).Return(nil)
+			},
+		},
+		{
+			name: "error case - account has no parent account",
+			doMock: func(ctx context.Context) {
+				testHelper.mockAccRepository.EXPECT().GetRelations(ctx, opts).Return(nil, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyAccountParentNotFound, accountNumber),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			tt.doMock(ctx)
+
+			err := testHelper.accountService.RemoveAccountParent(ctx, accountNumber)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_account_GetAccountHierarchy(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	balanceDate, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-04-30")
+
+	master := models.GetAccountOut{AccountNumber: "211001000000001", AccountName: "Lender Master", CoaTypeCode: "LIA", SubCategoryCode: "21101", Currency: "IDR"}
+	cih := models.GetAccountOut{AccountNumber: "211001000381110", AccountName: "Lender CIH", CoaTypeCode: "LIA", SubCategoryCode: "21102"}
+	invested := models.GetAccountOut{AccountNumber: "212001000000001", AccountName: "Lender Invested", CoaTypeCode: "LIA", SubCategoryCode: "21202", Currency: "IDR"}
+	receivables := models.GetAccountOut{AccountNumber: "142001000000001", AccountName: "Lender Receivables", CoaTypeCode: "AST", SubCategoryCode: "14201", Currency: "IDR"}
+	usd := models.GetAccountOut{AccountNumber: "211001000381111", AccountName: "Lender CIH USD", CoaTypeCode: "LIA", SubCategoryCode: "21102", Currency: "USD"}
+	mockGetAccount := func(ctx context.Context, account models.GetAccountOut) {
+		val, _ := json.Marshal(account)
+		testHelper.mockCacheRepository.EXPECT().
+			Get(ctx, fmt.Sprintf("%s_%s", "pas_account_key", account.AccountNumber)).
+			Return(string(val), nil)
+	}
+
+	type args struct {
+		ctx           context.Context
+		accountNumber string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		want    models.AccountHierarchy
+		wantErr bool
+	}{
+		{
+			name: "success case",
+			args: args{ctx: context.Background(), accountNumber: master.AccountNumber},
+			doMock: func(args args) {
+				mockGetAccount(args.ctx, master)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{ParentAccountNumbers: []string{master.AccountNumber}}).
+					Return([]models.AccountRelation{
+						{ParentAccountNumber: master.AccountNumber, AccountNumber: cih.AccountNumber, Relation: models.AccountRelationChild},
+						{ParentAccountNumber: master.AccountNumber, AccountNumber: usd.AccountNumber, Relation: models.AccountRelationChild},
+					}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{ParentAccountNumbers: []string{cih.AccountNumber, usd.AccountNumber}}).
+					Return([]models.AccountRelation{
+						{ParentAccountNumber: cih.AccountNumber, AccountNumber: invested.AccountNumber, Relation: models.AccountRelationInvested},
+						{ParentAccountNumber: cih.AccountNumber, AccountNumber: receivables.AccountNumber, Relation: models.AccountRelationReceivables},
+					}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{ParentAccountNumbers: []string{invested.AccountNumber, receivables.AccountNumber}}).
+					Return(nil, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetByAccountNumbers(args.ctx, []string{cih.AccountNumber, usd.AccountNumber, invested.AccountNumber, receivables.AccountNumber}).
+					Return([]models.GetAccountOut{cih, usd, invested, receivables}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetLatestAccountDailyBalances(args.ctx,
+						[]string{master.AccountNumber, cih.AccountNumber, usd.AccountNumber, invested.AccountNumber, receivables.AccountNumber},
+						balanceDate.AddDate(0, 0, 1)).
+					Return([]models.AccountBalanceDaily{
+						{AccountNumber: cih.AccountNumber, ClosingBalance: decimal.NewFromInt(100000)},
+						{AccountNumber: usd.AccountNumber, ClosingBalance: decimal.NewFromInt(10)},
+						{AccountNumber: invested.AccountNumber, ClosingBalance: decimal.NewFromInt(50000)},
+						{AccountNumber: receivables.AccountNumber, ClosingBalance: decimal.NewFromInt(70000)},
+					}, nil)
+			},
+			want: models.AccountHierarchy{
+				AccountNumber:   master.AccountNumber,
+				AccountName:     master.AccountName,
+				CoaTypeCode:     "LIA",
+				SubCategoryCode: master.SubCategoryCode,
+				Currency:        "IDR",
+				BalanceDate:     balanceDate,
+				RolledUpBalance: decimal.NewFromInt(150000),
+				Children: []models.AccountHierarchy{
+					{
+						AccountNumber:   cih.AccountNumber,
+						AccountName:     cih.AccountName,
+						CoaTypeCode:     "LIA",
+						SubCategoryCode: cih.SubCategoryCode,
+						Currency:        models.CurrencyIDR,
+						Relation:        models.AccountRelationChild,
+						BalanceDate:     balanceDate,
+						Balance:         decimal.NewFromInt(100000),
+						RolledUpBalance: decimal.NewFromInt(150000),
+						Children: []models.AccountHierarchy{
+							{
+								AccountNumber:   invested.AccountNumber,
+								AccountName:     invested.AccountName,
+								CoaTypeCode:     "LIA",
+								SubCategoryCode: invested.SubCategoryCode,
+								Currency:        "IDR",
+								Relation:        models.AccountRelationInvested,
+								BalanceDate:     balanceDate,
+								Balance:         decimal.NewFromInt(50000),
+								RolledUpBalance: decimal.NewFromInt(50000),
+								Children:        []models.AccountHierarchy{},
+							},
+							// an asset is not rolled up into the liability
+							{
+								AccountNumber:   receivables.AccountNumber,
+								AccountName:     receivables.AccountName,
+								CoaTypeCode:     "AST",
+								SubCategoryCode: receivables.SubCategoryCode,
+								Currency:        "IDR",
+								Relation:        models.AccountRelationReceivables,
+								BalanceDate:     balanceDate,
+								Balance:         decimal.NewFromInt(70000),
+								RolledUpBalance: decimal.NewFromInt(70000),
+								Children:        []models.AccountHierarchy{},
+							},
+						},
+					},
+					{
+						AccountNumber:   usd.AccountNumber,
+						AccountName:     usd.AccountName,
+						CoaTypeCode:     "LIA",
+						SubCategoryCode: usd.SubCategoryCode,
+						Currency:        "USD",
+						Relation:        models.AccountRelationChild,
+						BalanceDate:     balanceDate,
+						Balance:         decimal.NewFromInt(10),
+						RolledUpBalance: decimal.NewFromInt(10),
+						Children:        []models.AccountHierarchy{},
+					},
+				},
+			},
+		},
+		{
+			name: "error case - account not found",
+			args: args{ctx: context.Background(), accountNumber: "999001000000001"},
+			doMock: func(args args) {
+				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, "pas_account_key_999001000000001").Return("", assert.AnError)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(args.ctx, "999001000000001").
+					Return(models.GetAccountOut{}, models.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - descendant account not found",
+			args: args{ctx: context.Background(), accountNumber: master.AccountNumber},
+			doMock: func(args args) {
+				mockGetAccount(args.ctx, master)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{ParentAccountNumbers: []string{master.AccountNumber}}).
+					Return([]models.AccountRelation{
+						{ParentAccountNumber: master.AccountNumber, AccountNumber: cih.AccountNumber, Relation: models.AccountRelationChild},
+					}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelations(args.ctx, models.AccountRelationFilterOptions{ParentAccountNumbers: []string{cih.AccountNumber}}).
+					Return(nil, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetByAccountNumbers(args.ctx, []string{cih.AccountNumber}).
+					Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - database error",
+			args: args{ctx: context.Background(), accountNumber: master.AccountNumber},
+			doMock: func(args args) {
+				mockGetAccount(args.ctx, master)
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			out, err := testHelper.accountService.GetAccountHierarchy(tt.args.ctx, tt.args.accountNumber, balanceDate)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+/*
+CreateAccountRelationshipRule stores the rule evaluated when an account of the source sub category is created,
+1. the source & target sub category must exist
+2. the source & target account number must be in the source & target sub category when they are set
+3. a source sub category has at most one rule per relation & source account number
+*/
+func (as *account) CreateAccountRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (out *models.AccountRelationshipRule, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	for _, code := range []string{in.SourceSubCategoryCode, in.TargetSubCategoryCode} {
+		if err = as.checkSubCategoryExist(ctx, code); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = as.checkRelationshipRuleAccount(ctx, in.SourceAccountNumber, in.SourceSubCategoryCode); err != nil {
+		return nil, err
+	}
+	if err = as.checkRelationshipRuleAccount(ctx, in.TargetAccountNumber, in.TargetSubCategoryCode); err != nil {
+		return nil, err
+	}
+
+	rules, err := as.srv.mySqlRepo.GetAccountRepository().GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{
+		SourceSubCategoryCode: in.SourceSubCategoryCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range rules {
+		if v.Relation == in.Relation && v.SourceAccountNumber == in.SourceAccountNumber {
+			err = models.GetErrMap(models.ErrKeyAccountRelationshipRuleIsExist, strings.TrimSpace(fmt.Sprintf("%s %s %s", in.SourceSubCategoryCode, in.Relation, in.SourceAccountNumber)))
+			return nil, err
+		}
+	}
+
+	in.ID, err = as.srv.mySqlRepo.GetAccountRepository().InsertRelationshipRule(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return &in, nil
+}
+
+func (as *account) GetAccountRelationshipRules(ctx context.Context, opts models.AccountRelationshipRuleFilterOptions) (out []models.AccountRelationshipRule, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	return as.srv.mySqlRepo.GetAccountRepository().GetRelationshipRules(ctx, opts)
+}
+
+func (as *account) GetAccountRelationshipRuleById(ctx context.Context, id int) (out *models.AccountRelationshipRule, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = as.srv.mySqlRepo.GetAccountRepository().GetRelationshipRuleById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		err = models.GetErrMap(models.ErrKeyAccountRelationshipRuleNotFound, strconv.Itoa(id))
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// UpdateAccountRelationshipRule updates the target, cardinality, naming template & status of the rule,
+// the source sub category, source account number & relation identify the rule and are never updated.
+func (as *account) UpdateAccountRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (out *models.AccountRelationshipRule, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = as.GetAccountRelationshipRuleById(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = as.checkSubCategoryExist(ctx, in.TargetSubCategoryCode); err != nil {
+		return nil, err
+	}
+	if err = as.checkRelationshipRuleAccount(ctx, in.TargetAccountNumber, in.TargetSubCategoryCode); err != nil {
+		return nil, err
+	}
+
+	out.TargetSubCategoryCode = in.TargetSubCategoryCode
+	out.TargetAccountNumber = in.TargetAccountNumber
+	out.Cardinality = in.Cardinality
+	out.NameTemplate = in.NameTemplate
+	out.Status = in.Status
+	if err = as.srv.mySqlRepo.GetAccountRepository().UpdateRelationshipRule(ctx, *out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (as *account) DeleteAccountRelationshipRule(ctx context.Context, id int) (err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	if _, err = as.GetAccountRelationshipRuleById(ctx, id); err != nil {
+		return err
+	}
+
+	return as.srv.mySqlRepo.GetAccountRepository().DeleteRelationshipRule(ctx, id)
+}
+
+/*
+SeedAccountRelationshipRules creates the relationship rules of the deprecated config of the environment,
+go run cmd/job/main.go run -v=v1 -n=SeedAccountRelationshipRules
+
+1. a rule of the sub category per entry of invested_account_number, receivables_account_number & multi_loan_account
+2. a rule of the lender account per entry of lender_insti_receivables_account, the source & target sub category are the sub category of the accounts
+3. a rule of the same source sub category, relation & source account number is skipped so the job can be re-run
+4. a failed rule does not stop the others, the errors are returned together
+*/
+func (as *account) SeedAccountRelationshipRules(ctx context.Context) (out []models.AccountRelationshipRule, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	existing, err := as.srv.mySqlRepo.GetAccountRepository().GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{})
+	if err != nil {
+		return nil, checkDatabaseError(err)
+	}
+	ruleKey := func(rule models.AccountRelationshipRule) string {
+		return strings.TrimSpace(fmt.Sprintf("%s %s %s", rule.SourceSubCategoryCode, rule.Relation, rule.SourceAccountNumber))
+	}
+	isExist := make(map[string]bool, len(existing))
+	for _, v := range existing {
+		isExist[ruleKey(v)] = true
+	}
+
+	var errs *multierror.Error
+	conf := as.srv.conf.AccountConfig
+	rules := legacySubCategoryRelationshipRules(conf)
+
+	lenderAccounts := make([]string, 0, len(conf.LenderInstiReceivablesAccount))
+	for v := range conf.LenderInstiReceivablesAccount {
+		lenderAccounts = append(lenderAccounts, v)
+	}
+	sort.Strings(lenderAccounts)
+	for _, v := range lenderAccounts {
+		source, errSource := as.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, v)
+		if errSource != nil {
+			errs = multierror.Append(errs, fmt.Errorf("lender account %s: %w", v, checkDatabaseError(errSource, models.ErrKeyAccountNumberNotFound)))
+			continue
+		}
+		target, errTarget := as.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, conf.LenderInstiReceivablesAccount[v])
+		if errTarget != nil {
+			errs = multierror.Append(errs, fmt.Errorf("lender account %s: %w", v, checkDatabaseError(errTarget, models.ErrKeyAccountNumberNotFound)))
+			continue
+		}
+
+		rules = append(rules, models.AccountRelationshipRule{
+			SourceSubCategoryCode: source.SubCategoryCode,
+			SourceAccountNumber:   source.AccountNumber,
+			TargetSubCategoryCode: target.SubCategoryCode,
+			TargetAccountNumber:   target.AccountNumber,
+			Relation:              models.AccountRelationReceivables,
+			Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+			Status:                models.StatusActive,
+		})
+	}
+
+	for _, rule := range rules {
+		key := ruleKey(rule)
+		if isExist[key] {
+			continue
+		}
+
+		created, errCreate := as.CreateAccountRelationshipRule(ctx, rule)
+		if errCreate != nil {
+			errs = multierror.Append(errs, fmt.Errorf("relationship rule %s: %w", key, errCreate))
+			continue
+		}
+		isExist[key] = true
+		out = append(out, *created)
+	}
+
+	return out, errs.ErrorOrNil()
+}
+
+func (as *account) checkSubCategoryExist(ctx context.Context, code string) error {
+	subCategory, err := as.srv.mySqlRepo.GetSubCategoryRepository().GetByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if subCategory == nil {
+		return models.GetErrMap(models.ErrKeySubCategoryCodeNotFound, code)
+	}
+
+	return nil
+}
+
+// checkRelationshipRuleAccount checks the account number of the rule is in the sub category, an empty account number is skipped.
+func (as *account) checkRelationshipRuleAccount(ctx context.Context, accountNumber, subCategoryCode string) error {
+	if accountNumber == "" {
+		return nil
+	}
+
+	act, err := as.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, accountNumber)
+	if err != nil {
+		return checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
+	}
+	if act.SubCategoryCode != subCategoryCode {
+		return models.GetErrMap(models.ErrKeyAccountRelationshipRuleAccountInvalid, fmt.Sprintf("%s %s", accountNumber, subCategoryCode))
+	}
+
+	return nil
+}
@@ -0,0 +1,557 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/config"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_account_CreateAccountRelationshipRule(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	ctx := context.Background()
+	req := models.AccountRelationshipRule{
+		SourceSubCategoryCode: "21101",
+		TargetSubCategoryCode: "21201",
+		Relation:              models.AccountRelationInvested,
+		Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+		NameTemplate:          "{name} - Invested",
+		Status:                models.StatusActive,
+	}
+	mockSubCategories := func() {
+		testHelper.mockSubCategoryRepository.EXPECT().GetByCode(ctx, "21101").Return(&models.SubCategory{Code: "21101"}, nil)
+		testHelper.mockSubCategoryRepository.EXPECT().GetByCode(ctx, "21201").Return(&models.SubCategory{Code: "21201"}, nil)
+	}
+
+	tests := []struct {
+		name    string
+		req     models.AccountRelationshipRule
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			req:  req,
+			doMock: func() {
+				mockSubCategories()
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{SourceSubCategoryCode: "21101"}).
+					Return([]models.AccountRelationshipRule{{Relation: models.AccountRelationReceivables}}, nil)
+				testHelper.mockAccRepository.EXPECT().InsertRelationshipRule(ctx, req).Return(1, nil)
+			},
+		},
+		{
+			name: "error source sub category not found",
+			req:  req,
+			doMock: func() {
+				testHelper.mockSubCategoryRepository.EXPECT().GetByCode(ctx, "21101").Return(nil, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeySubCategoryCodeNotFound, "21101"),
+		},
+		{
+			name: "error rule of the relation is exist",
+			req:  req,
+			doMock: func() {
+				mockSubCategories()
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, gomock.Any()).
+					Return([]models.AccountRelationshipRule{{Relation: models.AccountRelationInvested}}, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyAccountRelationshipRuleIsExist, "21101 invested"),
+		},
+		{
+			name: "success rule of the source account number with the fixed target account",
+			req: func() models.AccountRelationshipRule {
+				req := req
+				req.SourceAccountNumber = "21100100000001"
+				req.TargetAccountNumber = "21200100000001"
+				return req
+			}(),
+			doMock: func() {
+				mockSubCategories()
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, "21100100000001").Return(models.GetAccountOut{SubCategoryCode: "21101"}, nil)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, "21200100000001").Return(models.GetAccountOut{SubCategoryCode: "21201"}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, gomock.Any()).
+					Return([]models.AccountRelationshipRule{{Relation: models.AccountRelationInvested}}, nil)
+				testHelper.mockAccRepository.EXPECT().InsertRelationshipRule(ctx, gomock.Any()).Return(1, nil)
+			},
+		},
+		{
+			name: "error target account is not in the target sub category",
+			req: func() models.AccountRelationshipRule {
+				req := req
+				req.TargetAccountNumber = "14200100000001"
+				return req
+			}(),
+			doMock: func() {
+				mockSubCategories()
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, "14200100000001").Return(models.GetAccountOut{SubCategoryCode: "14201"}, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyAccountRelationshipRuleAccountInvalid, "14200100000001 21201"),
+		},
+		{
+			name: "error database insert",
+			req:  req,
+			doMock: func() {
+				mockSubCategories()
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRules(ctx, gomock.Any()).Return(nil, nil)
+				testHelper.mockAccRepository.EXPECT().InsertRelationshipRule(ctx, req).Return(0, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := testHelper.accountService.CreateAccountRelationshipRule(ctx, tt.req)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, 1, out.ID)
+			}
+		})
+	}
+}
+
+func Test_account_UpdateAccountRelationshipRule(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	ctx := context.Background()
+	rule := models.AccountRelationshipRule{
+		ID:                    1,
+		SourceSubCategoryCode: "21101",
+		TargetSubCategoryCode: "21201",
+		Relation:              models.AccountRelationInvested,
+		Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+		Status:                models.StatusActive,
+	}
+	req := models.AccountRelationshipRule{
+		ID:                    1,
+		TargetSubCategoryCode: "21202",
+		Cardinality:           models.AccountRelationshipCardinalityManyToOne,
+		NameTemplate:          "{name} - Invested",
+		Status:                "inactive",
+	}
+
+	tests := []struct {
+		name    string
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRuleById(ctx, 1).Return(&rule, nil)
+				testHelper.mockSubCategoryRepository.EXPECT().GetByCode(ctx, "21202").Return(&models.SubCategory{Code: "21202"}, nil)
+				testHelper.mockAccRepository.EXPECT().UpdateRelationshipRule(ctx, models.AccountRelationshipRule{
+					ID:                    1,
+					SourceSubCategoryCode: "21101",
+					TargetSubCategoryCode: "21202",
+					Relation:              models.AccountRelationInvested,
+					Cardinality:           models.AccountRelationshipCardinalityManyToOne,
+					NameTemplate:          "{name} - Invested",
+					Status:                "inactive",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "error rule not found",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRuleById(ctx, 1).Return(nil, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyAccountRelationshipRuleNotFound, "1"),
+		},
+		{
+			name: "error target sub category not found",
+			doMock: func() {
+				rule := rule
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRuleById(ctx, 1).Return(&rule, nil)
+				testHelper.mockSubCategoryRepository.EXPECT().GetByCode(ctx, "21202").Return(nil, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeySubCategoryCodeNotFound, "21202"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			_, err := testHelper.accountService.UpdateAccountRelationshipRule(ctx, req)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_account_DeleteAccountRelationshipRule(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRuleById(ctx, 1).Return(&models.AccountRelationshipRule{ID: 1}, nil)
+				testHelper.mockAccRepository.EXPECT().DeleteRelationshipRule(ctx, 1).Return(nil)
+			},
+		},
+		{
+			name: "error rule not found",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRuleById(ctx, 1).Return(nil, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyAccountRelationshipRuleNotFound, "1"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			err := testHelper.accountService.DeleteAccountRelationshipRule(ctx, 1)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_account_CreateAccountRelationship(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	ctx := context.Background()
+	createdAt := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	req := models.CreateAccount{
+		AccountNumber:   "21100100000001",
+		OwnerID:         "12345",
+		EntityCode:      "001",
+		SubCategoryCode: "21101",
+		Name:            "Lender Yang Baik",
+		AltId:           "LND-1",
+	}
+	ruleFilter := models.AccountRelationshipRuleFilterOptions{SourceSubCategoryCode: "21101", Status: models.StatusActive}
+
+	// CreateAccountRelationship is called by the account relationship consumer only
+	accountService, ok := testHelper.accountService.(interface {
+		CreateAccountRelationship(ctx context.Context, in models.CreateAccount) error
+	})
+	assert.True(t, ok)
+
+	accountConfig := testHelper.config.AccountConfig
+
+	tests := []struct {
+		name         string
+		legacyConfig func(conf *config.AccountConfig)
+		doMock       func()
+		wantErr      bool
+	}{
+		{
+			name: "success use the shared target account of a many to one rule",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, req.AccountNumber).Return(models.GetAccountOut{CreatedAt: createdAt}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRules(ctx, ruleFilter).Return([]models.AccountRelationshipRule{{
+					TargetSubCategoryCode: "14201",
+					Relation:              models.AccountRelationReceivables,
+					Cardinality:           models.AccountRelationshipCardinalityManyToOne,
+				}}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetAllAccountNumbersByParam(ctx, models.GetAllAccountNumbersByParamIn{OwnerId: "12345", SubCategoryCode: "14201"}).
+					Return([]models.GetAllAccountNumbersByParamOut{
+						{AccountNumber: "14200100000001", CreatedAt: createdAt.AddDate(0, 0, -10)},
+						{AccountNumber: "14200100000002", CreatedAt: createdAt.AddDate(0, 0, 10)},
+					}, nil)
+				testHelper.mockMySQLRepository.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().CreateLenderAccount(ctx, models.CreateLenderAccount{
+							CIHAccountNumber:         req.AccountNumber,
+							ReceivablesAccountNumber: "14200100000001",
+						}).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+			},
+		},
+		{
+			name: "success use the target account created after the source account of a one to one rule",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, req.AccountNumber).Return(models.GetAccountOut{CreatedAt: createdAt}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRules(ctx, ruleFilter).Return([]models.AccountRelationshipRule{{
+					TargetSubCategoryCode: "21201",
+					Relation:              models.AccountRelationInvested,
+					Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+				}}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetAllAccountNumbersByParam(ctx, models.GetAllAccountNumbersByParamIn{OwnerId: "12345", SubCategoryCode: "21201"}).
+					Return([]models.GetAllAccountNumbersByParamOut{
+						{AccountNumber: "21200100000001", CreatedAt: createdAt.AddDate(0, 0, -10)},
+						{AccountNumber: "21200100000002", CreatedAt: createdAt},
+					}, nil)
+				testHelper.mockMySQLRepository.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().CreateLenderAccount(ctx, models.CreateLenderAccount{
+							CIHAccountNumber:      req.AccountNumber,
+							InvestedAccountNumber: "21200100000002",
+						}).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+			},
+		},
+		{
+			name: "success link the fixed target account of the rule of the source account number",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, req.AccountNumber).Return(models.GetAccountOut{CreatedAt: createdAt}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRules(ctx, ruleFilter).Return([]models.AccountRelationshipRule{
+					{
+						TargetSubCategoryCode: "14201",
+						Relation:              models.AccountRelationReceivables,
+						Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+					},
+					{
+						SourceAccountNumber:   "21100100000099",
+						TargetSubCategoryCode: "14201",
+						TargetAccountNumber:   "14200100000099",
+						Relation:              models.AccountRelationReceivables,
+						Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+					},
+					{
+						SourceAccountNumber:   req.AccountNumber,
+						TargetSubCategoryCode: "14201",
+						TargetAccountNumber:   "14200100000007",
+						Relation:              models.AccountRelationReceivables,
+						Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+					},
+				}, nil)
+				testHelper.mockMySQLRepository.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().CreateLenderAccount(ctx, models.CreateLenderAccount{
+							CIHAccountNumber:         req.AccountNumber,
+							ReceivablesAccountNumber: "14200100000007",
+						}).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+			},
+		},
+		{
+			name: "success generate the target account with the naming template",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, req.AccountNumber).Return(models.GetAccountOut{CreatedAt: createdAt}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRules(ctx, ruleFilter).Return([]models.AccountRelationshipRule{{
+					TargetSubCategoryCode: "21303",
+					Relation:              models.AccountRelationLoanAdvancePayment,
+					Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+					NameTemplate:          "{name} - Advance {altId}",
+				}}, nil)
+				testHelper.mockAccRepository.EXPECT().GetAllAccountNumbersByParam(ctx, gomock.Any()).Return(nil, nil)
+				testHelper.mockSubCategoryRepository.EXPECT().GetByCode(ctx, "21303").Return(&models.SubCategory{Code: "21303", CategoryCode: "213"}, nil)
+				testHelper.mockCacheRepository.EXPECT().GetIncrement(ctx, "category_code_213_seq").Return(int64(1), nil)
+				newAccount := models.CreateAccount{
+					AccountNumber:   "21300100000001",
+					OwnerID:         "12345",
+					EntityCode:      "001",
+					CategoryCode:    "213",
+					SubCategoryCode: "21303",
+					Currency:        models.CurrencyIDR,
+					Status:          models.AccountStatusActive,
+					Name:            "Lender Yang Baik - Advance LND-1",
+					AltId:           "21100100000001-LND-1",
+				}
+				testHelper.mockMySQLRepository.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().CreateLoanAccount(ctx, models.CreateLoanAccount{
+							LoanAccountNumber:               req.AccountNumber,
+							LoanAdvancePaymentAccountNumber: "21300100000001",
+						}).Return(nil)
+						testHelper.mockAccRepository.EXPECT().BulkInsertAcctAccount(ctx, []models.CreateAccount{newAccount}).Return(nil)
+						testHelper.mockAccRepository.EXPECT().BulkInsertAccount(ctx, []models.CreateAccount{newAccount}).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockAcuanClient.EXPECT().PublishAccount(ctx, gomock.Any())
+			},
+		},
+		{
+			name: "success fall back to the rules of the deprecated config",
+			legacyConfig: func(conf *config.AccountConfig) {
+				conf.InvestedAccountNumber = map[string]string{"21101": "21201"}
+				conf.ReceivablesAccountNumber = map[string]string{"21101": "14201"}
+				conf.LenderInstiReceivablesAccount = map[string]string{req.AccountNumber: "14200100000003"}
+			},
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, req.AccountNumber).Return(models.GetAccountOut{CreatedAt: createdAt}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRules(ctx, ruleFilter).Return(nil, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetAllAccountNumbersByParam(ctx, models.GetAllAccountNumbersByParamIn{OwnerId: "12345", SubCategoryCode: "21201"}).
+					Return([]models.GetAllAccountNumbersByParamOut{{AccountNumber: "21200100000001", CreatedAt: createdAt}}, nil)
+				testHelper.mockMySQLRepository.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().CreateLenderAccount(ctx, models.CreateLenderAccount{
+							CIHAccountNumber:         req.AccountNumber,
+							InvestedAccountNumber:    "21200100000001",
+							ReceivablesAccountNumber: "14200100000003",
+						}).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+			},
+		},
+		{
+			name: "success the stored rule wins over the rule of the deprecated config",
+			legacyConfig: func(conf *config.AccountConfig) {
+				conf.LenderInstiReceivablesAccount = map[string]string{req.AccountNumber: "14200100000003"}
+			},
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, req.AccountNumber).Return(models.GetAccountOut{CreatedAt: createdAt}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRules(ctx, ruleFilter).Return([]models.AccountRelationshipRule{{
+					SourceAccountNumber:   req.AccountNumber,
+					TargetSubCategoryCode: "14201",
+					TargetAccountNumber:   "14200100000007",
+					Relation:              models.AccountRelationReceivables,
+					Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+				}}, nil)
+				testHelper.mockMySQLRepository.EXPECT().Atomic(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().CreateLenderAccount(ctx, models.CreateLenderAccount{
+							CIHAccountNumber:         req.AccountNumber,
+							ReceivablesAccountNumber: "14200100000007",
+						}).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+			},
+		},
+		{
+			name: "error account number not found",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, req.AccountNumber).Return(models.GetAccountOut{}, models.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database get relationship rules",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, req.AccountNumber).Return(models.GetAccountOut{CreatedAt: createdAt}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelationshipRules(ctx, ruleFilter).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			conf := accountConfig
+			if tt.legacyConfig != nil {
+				tt.legacyConfig(&conf)
+			}
+			testHelper.config.AccountConfig = conf
+			tt.doMock()
+
+			err := accountService.CreateAccountRelationship(ctx, req)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_account_SeedAccountRelationshipRules(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	ctx := context.Background()
+	testHelper.config.AccountConfig.InvestedAccountNumber = map[string]string{"21101": "21201", "21103": "21203"}
+	testHelper.config.AccountConfig.LenderInstiReceivablesAccount = map[string]string{"21100300000001": "14200100000001"}
+
+	tests := []struct {
+		name    string
+		doMock  func()
+		wantIds []int
+		wantErr bool
+	}{
+		{
+			name: "success skip the existing rule & create the rules of the config",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{}).
+					Return([]models.AccountRelationshipRule{{SourceSubCategoryCode: "21101", Relation: models.AccountRelationInvested}}, nil)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, "21100300000001").
+					Return(models.GetAccountOut{AccountNumber: "21100300000001", SubCategoryCode: "21103"}, nil).Times(2)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, "14200100000001").
+					Return(models.GetAccountOut{AccountNumber: "14200100000001", SubCategoryCode: "14201"}, nil).Times(2)
+				for _, code := range []string{"21103", "21203", "21103", "14201"} {
+					testHelper.mockSubCategoryRepository.EXPECT().GetByCode(ctx, code).Return(&models.SubCategory{Code: code}, nil)
+				}
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{SourceSubCategoryCode: "21103"}).
+					Return(nil, nil).Times(2)
+				gomock.InOrder(
+					testHelper.mockAccRepository.EXPECT().
+						InsertRelationshipRule(ctx, models.AccountRelationshipRule{
+							SourceSubCategoryCode: "21103",
+							TargetSubCategoryCode: "21203",
+							Relation:              models.AccountRelationInvested,
+							Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+							Status:                models.StatusActive,
+						}).
+						Return(2, nil),
+					testHelper.mockAccRepository.EXPECT().
+						InsertRelationshipRule(ctx, models.AccountRelationshipRule{
+							SourceSubCategoryCode: "21103",
+							SourceAccountNumber:   "21100300000001",
+							TargetSubCategoryCode: "14201",
+							TargetAccountNumber:   "14200100000001",
+							Relation:              models.AccountRelationReceivables,
+							Cardinality:           models.AccountRelationshipCardinalityOneToOne,
+							Status:                models.StatusActive,
+						}).
+						Return(3, nil),
+				)
+			},
+			wantIds: []int{2, 3},
+		},
+		{
+			name: "error lender account not found does not stop the others",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{}).
+					Return([]models.AccountRelationshipRule{
+						{SourceSubCategoryCode: "21101", Relation: models.AccountRelationInvested},
+						{SourceSubCategoryCode: "21103", Relation: models.AccountRelationInvested},
+					}, nil)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(ctx, "21100300000001").
+					Return(models.GetAccountOut{}, models.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error database get relationship rules",
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().
+					GetRelationshipRules(ctx, models.AccountRelationshipRuleFilterOptions{}).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := testHelper.accountService.SeedAccountRelationshipRules(ctx)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			ids := []int{}
+			for _, v := range out {
+				ids = append(ids, v.ID)
+			}
+			if tt.wantIds == nil {
+				tt.wantIds = []int{}
+			}
+			assert.Equal(t, tt.wantIds, ids)
+		})
+	}
+}
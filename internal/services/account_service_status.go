@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/acuanclient"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+)
+
+/*
+UpdateAccountStatus moves the account to the next status of the lifecycle,
+1. the status must be a lifecycle status & allowed from the current status of the account
+2. closing the account requires the current balance of the account to be zero
+3. update the status with the change log, publish the account to acuan & delete the account caching
+*/
+func (as *account) UpdateAccountStatus(ctx context.Context, in models.UpdateAccountStatus) (out models.UpdateAccountStatus, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	if !models.IsValidAccountStatus(in.Status) {
+		err = models.GetErrMap(models.ErrKeyInvalidAccountStatus)
+		return
+	}
+
+	act, err := as.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, in.AccountNumber)
+	if err != nil {
+		err = checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
+		return
+	}
+
+	if !models.CanTransitionAccountStatus(act.Status, in.Status) {
+		err = models.GetErrMap(models.ErrKeyAccountStatusTransitionNotAllowed, fmt.Sprintf("from %s to %s", act.Status, in.Status))
+		return
+	}
+
+	if in.Status == models.AccountStatusClosed {
+		balance, errBalance := as.GetAccountBalance(ctx, in.AccountNumber, atime.Now())
+		if errBalance != nil {
+			err = errBalance
+			return
+		}
+		if !balance.Balance.IsZero() {
+			err = models.GetErrMap(models.ErrKeyAccountBalanceNotZero, fmt.Sprintf("balance %s", balance.Balance.String()))
+			return
+		}
+	}
+
+	logs := models.NewAccountChangeLogs(in.AccountNumber, in.ChangedBy, in.Reason,
+		models.AccountFieldChange{Field: models.AccountChangeLogFieldStatus, Before: act.Status, After: in.Status},
+	)
+	if err = as.updateWithChangeLogs(ctx, logs, func(ctx context.Context, r mysql.AccountRepository) error {
+		return r.UpdateStatus(ctx, in)
+	}); err != nil {
+		return
+	}
+
+	as.srv.acuanClient.PublishAccount(ctx, acuanclient.PublishAccountData{
+		Type: models.TypeAccountUpdated,
+		// updated fields
+		Status: in.Status,
+
+		AccountNumber:   act.AccountNumber,
+		Name:            act.AccountName,
+		ProductTypeName: act.ProductTypeName,
+		OwnerId:         act.OwnerID,
+		CategoryCode:    act.CategoryCode,
+		SubCategoryCode: act.SubCategoryCode,
+		EntityCode:      act.EntityCode,
+		Currency:        act.Currency,
+		AltId:           act.AltID,
+		LegacyId:        act.LegacyId,
+		Metadata:        act.Metadata,
+	})
+
+	keys := []string{pasAccountKey(in.AccountNumber)}
+	if act.LegacyId != nil {
+		if t24AccountNumber, ok := (*act.LegacyId)["t24AccountNumber"].(string); ok && t24AccountNumber != "" && t24AccountNumber != "0" {
+			keys = append(keys, pasAccountLegacyKey(t24AccountNumber))
+		}
+	}
+	as.deleteCaching(ctx, keys)
+
+	return in, nil
+}
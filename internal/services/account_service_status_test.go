@@ -0,0 +1,205 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_account_UpdateAccountStatus(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	account := models.GetAccountOut{
+		AccountNumber: "22200100000001",
+		CoaTypeCode:   models.COATypeLiability,
+		EntityCode:    "001",
+		Status:        models.AccountStatusActive,
+		LegacyId: &models.AccountLegacyId{
+			"t24AccountNumber": "111000035909",
+		},
+	}
+	mockGetAccountBalance := func(ctx context.Context, credit decimal.Decimal) {
+		val, _ := json.Marshal(account)
+		testHelper.mockCacheRepository.EXPECT().Get(ctx, "pas_account_key_22200100000001").Return(string(val), nil)
+		testHelper.mockAcctRepository.EXPECT().
+			GetLatestAccountDailyBalances(ctx, []string{account.AccountNumber}, gomock.Any()).
+			Return(nil, nil)
+		testHelper.mockAcctRepository.EXPECT().
+			GetAccountMovementsAfterDailyBalance(ctx, gomock.Any(), gomock.Any()).
+			Return([]models.LedgerAccountMovement{{
+				AccountNumber:  account.AccountNumber,
+				DebitMovement:  decimal.NewFromInt(50000),
+				CreditMovement: credit,
+			}}, nil)
+	}
+
+	type args struct {
+		ctx context.Context
+		req models.UpdateAccountStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success case - freeze account",
+			args: args{
+				ctx: context.Background(),
+				req: models.UpdateAccountStatus{
+					AccountNumber: account.AccountNumber,
+					Status:        models.AccountStatusFrozenCreditOnly,
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.req.AccountNumber).Return(account, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().UpdateStatus(ctx, args.req).Return(nil)
+						testHelper.mockAccRepository.EXPECT().InsertChangeLogs(ctx, []models.CreateAccountChangeLog{{
+							AccountNumber: args.req.AccountNumber,
+							Field:         models.AccountChangeLogFieldStatus,
+							BeforeValue:   models.AccountStatusActive,
+							AfterValue:    args.req.Status,
+							ChangedBy:     models.AccountChangeLogActorSystem,
+						}}).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockAcuanClient.EXPECT().PublishAccount(args.ctx, gomock.Any())
+				testHelper.mockCacheRepository.EXPECT().
+					Del(args.ctx, "pas_account_key_22200100000001", "pas_account_legacy_key_111000035909").
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success case - close account with zero balance",
+			args: args{
+				ctx: context.Background(),
+				req: models.UpdateAccountStatus{
+					AccountNumber: account.AccountNumber,
+					Status:        models.AccountStatusClosed,
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.req.AccountNumber).Return(account, nil)
+				mockGetAccountBalance(args.ctx, decimal.NewFromInt(50000))
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().UpdateStatus(ctx, args.req).Return(nil)
+						testHelper.mockAccRepository.EXPECT().InsertChangeLogs(ctx, []models.CreateAccountChangeLog{{
+							AccountNumber: args.req.AccountNumber,
+							Field:         models.AccountChangeLogFieldStatus,
+							BeforeValue:   models.AccountStatusActive,
+							AfterValue:    args.req.Status,
+							ChangedBy:     models.AccountChangeLogActorSystem,
+						}}).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockAcuanClient.EXPECT().PublishAccount(args.ctx, gomock.Any())
+				testHelper.mockCacheRepository.EXPECT().Del(args.ctx, gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - invalid status",
+			args: args{
+				ctx: context.Background(),
+				req: models.UpdateAccountStatus{
+					AccountNumber: account.AccountNumber,
+					Status:        models.AccountStatusInActive,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - account number not found",
+			args: args{
+				ctx: context.Background(),
+				req: models.UpdateAccountStatus{
+					AccountNumber: account.AccountNumber,
+					Status:        models.AccountStatusDormant,
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.req.AccountNumber).Return(models.GetAccountOut{}, models.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - transition not allowed",
+			args: args{
+				ctx: context.Background(),
+				req: models.UpdateAccountStatus{
+					AccountNumber: account.AccountNumber,
+					Status:        models.AccountStatusActive,
+				},
+			},
+			doMock: func(args args) {
+				closed := account
+				closed.Status = models.AccountStatusClosed
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.req.AccountNumber).Return(closed, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - close account with balance",
+			args: args{
+				ctx: context.Background(),
+				req: models.UpdateAccountStatus{
+					AccountNumber: account.AccountNumber,
+					Status:        models.AccountStatusClosed,
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.req.AccountNumber).Return(account, nil)
+				mockGetAccountBalance(args.ctx, decimal.NewFromInt(75000))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - database error update status",
+			args: args{
+				ctx: context.Background(),
+				req: models.UpdateAccountStatus{
+					AccountNumber: account.AccountNumber,
+					Status:        models.AccountStatusDormant,
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.req.AccountNumber).Return(account, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().UpdateStatus(ctx, args.req).Return(assert.AnError)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			got, err := testHelper.accountService.UpdateAccountStatus(tt.args.ctx, tt.args.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.args.req, got)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/gocustomer"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -98,7 +99,13 @@ func Test_account_Update(t *testing.T) {
 			},
 			doMock: func(args args, mockData mockData) {
 				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.req.AccountNumber).Return(models.GetAccountOut{}, nil)
-				testHelper.mockAccRepository.EXPECT().Update(args.ctx, args.req).Return(nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().Update(ctx, args.req).Return(nil)
+						testHelper.mockAccRepository.EXPECT().InsertChangeLogs(ctx, gomock.Any()).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
 				testHelper.mockAcuanClient.EXPECT().PublishAccount(args.ctx, gomock.Any())
 				testHelper.mockCacheRepository.EXPECT().Del(args.ctx, gomock.Any()).Return(nil)
 			},
@@ -133,7 +140,12 @@ func Test_account_Update(t *testing.T) {
 			},
 			doMock: func(args args, mockData mockData) {
 				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.req.AccountNumber).Return(models.GetAccountOut{}, nil)
-				testHelper.mockAccRepository.EXPECT().Update(args.ctx, args.req).Return(models.GetErrMap(models.ErrKeyDatabaseError))
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().Update(ctx, args.req).Return(models.GetErrMap(models.ErrKeyDatabaseError))
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
 			},
 			wantErr: true,
 		},
@@ -185,7 +197,13 @@ func Test_account_UpdateEntity(t *testing.T) {
 				}, nil)
 				testHelper.mockEntityRepository.EXPECT().GetByCode(args.ctx, args.req.EntityCode).Return(&models.Entity{}, nil)
 				testHelper.mockAcctRepository.EXPECT().GetOneSplitAccount(args.ctx, args.req.AccountNumber).Return(false, nil)
-				testHelper.mockAccRepository.EXPECT().UpdateEntity(args.ctx, args.req).Return(nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().UpdateEntity(ctx, args.req).Return(nil)
+						testHelper.mockAccRepository.EXPECT().InsertChangeLogs(ctx, gomock.Any()).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
 				testHelper.mockAcuanClient.EXPECT().PublishAccount(args.ctx, gomock.Any())
 				testHelper.mockCacheRepository.EXPECT().Del(args.ctx, gomock.Any()).Return(nil)
 			},
@@ -262,7 +280,12 @@ func Test_account_UpdateEntity(t *testing.T) {
 				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(args.ctx, args.req.AccountNumber).Return(models.GetAccountOut{}, nil)
 				testHelper.mockEntityRepository.EXPECT().GetByCode(args.ctx, args.req.EntityCode).Return(&models.Entity{}, nil)
 				testHelper.mockAcctRepository.EXPECT().GetOneSplitAccount(args.ctx, args.req.AccountNumber).Return(false, nil)
-				testHelper.mockAccRepository.EXPECT().UpdateEntity(args.ctx, args.req).Return(models.GetErrMap(models.ErrKeyDatabaseError))
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().UpdateEntity(ctx, args.req).Return(models.GetErrMap(models.ErrKeyDatabaseError))
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
 			},
 			wantErr: true,
 		},
@@ -1026,7 +1049,33 @@ func Test_account_GetInvestedAccountNumberByCIHAccountNumber(t *testing.T) {
 			},
 			doMock: func(args args) {
 				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, args.accountNumber).Return("", nil)
-				testHelper.mockAccRepository.EXPECT().GetLenderAccountByCIHAccountNumber(args.ctx, args.accountNumber).Return(models.AccountLender{}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, models.AccountRelationFilterOptions{
+					ParentAccountNumbers: []string{args.accountNumber},
+					Relations:            []string{models.AccountRelationInvested, models.AccountRelationReceivables},
+					IncludeUnlinked:      true,
+				}).Return([]models.AccountRelation{
+					{ParentAccountNumber: args.accountNumber, AccountNumber: "212001000000330", Relation: models.AccountRelationInvested},
+				}, nil)
+				testHelper.mockCacheRepository.EXPECT().Set(args.ctx, args.accountNumber, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success case - lender account without invested & receivables account",
+			args: args{
+				ctx:           context.Background(),
+				accountNumber: CIHAccountNumber,
+			},
+			doMock: func(args args) {
+				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, args.accountNumber).Return("", nil)
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, models.AccountRelationFilterOptions{
+					ParentAccountNumbers: []string{args.accountNumber},
+					Relations:            []string{models.AccountRelationInvested, models.AccountRelationReceivables},
+					IncludeUnlinked:      true,
+				}).Return([]models.AccountRelation{
+					{ParentAccountNumber: args.accountNumber, AccountNumber: "", Relation: models.AccountRelationInvested},
+					{ParentAccountNumber: args.accountNumber, AccountNumber: "", Relation: models.AccountRelationReceivables},
+				}, nil)
 				testHelper.mockCacheRepository.EXPECT().Set(args.ctx, args.accountNumber, gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
@@ -1050,7 +1099,13 @@ func Test_account_GetInvestedAccountNumberByCIHAccountNumber(t *testing.T) {
 			},
 			doMock: func(args args) {
 				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, args.accountNumber).Return("", models.GetErrMap(models.ErrKeyFailedSetToCache))
-				testHelper.mockAccRepository.EXPECT().GetLenderAccountByCIHAccountNumber(args.ctx, args.accountNumber).Return(models.AccountLender{}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, models.AccountRelationFilterOptions{
+					ParentAccountNumbers: []string{args.accountNumber},
+					Relations:            []string{models.AccountRelationInvested, models.AccountRelationReceivables},
+					IncludeUnlinked:      true,
+				}).Return([]models.AccountRelation{
+					{ParentAccountNumber: args.accountNumber, AccountNumber: "212001000000330", Relation: models.AccountRelationInvested},
+				}, nil)
 				testHelper.mockCacheRepository.EXPECT().Set(args.ctx, args.accountNumber, gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
@@ -1063,11 +1118,27 @@ func Test_account_GetInvestedAccountNumberByCIHAccountNumber(t *testing.T) {
 			},
 			doMock: func(args args) {
 				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, args.accountNumber).Return("", nil)
-				testHelper.mockAccRepository.EXPECT().GetLenderAccountByCIHAccountNumber(args.ctx, args.accountNumber).Return(models.AccountLender{}, models.GetErrMap(models.ErrKeyDatabaseError))
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, models.AccountRelationFilterOptions{
+					ParentAccountNumbers: []string{args.accountNumber},
+					Relations:            []string{models.AccountRelationInvested, models.AccountRelationReceivables},
+					IncludeUnlinked:      true,
+				}).Return(nil, assert.AnError)
 				testHelper.mockCacheRepository.EXPECT().Set(args.ctx, args.accountNumber, gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: true,
 		},
+		{
+			name: "error case - not found",
+			args: args{
+				ctx:           context.Background(),
+				accountNumber: CIHAccountNumber,
+			},
+			doMock: func(args args) {
+				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, args.accountNumber).Return("", nil)
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, gomock.Any()).Return(nil, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "error case - failed set to cache",
 			args: args{
@@ -1076,7 +1147,13 @@ func Test_account_GetInvestedAccountNumberByCIHAccountNumber(t *testing.T) {
 			},
 			doMock: func(args args) {
 				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, args.accountNumber).Return("", nil)
-				testHelper.mockAccRepository.EXPECT().GetLenderAccountByCIHAccountNumber(args.ctx, args.accountNumber).Return(models.AccountLender{}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, models.AccountRelationFilterOptions{
+					ParentAccountNumbers: []string{args.accountNumber},
+					Relations:            []string{models.AccountRelationInvested, models.AccountRelationReceivables},
+					IncludeUnlinked:      true,
+				}).Return([]models.AccountRelation{
+					{ParentAccountNumber: args.accountNumber, AccountNumber: "212001000000330", Relation: models.AccountRelationInvested},
+				}, nil)
 				testHelper.mockCacheRepository.EXPECT().Set(args.ctx, args.accountNumber, gomock.Any(), gomock.Any()).Return(models.GetErrMap(models.ErrKeyFailedSetToCache))
 			},
 			wantErr: false,
@@ -1121,7 +1198,13 @@ func Test_account_GetLoanAdvanceAccountByLoanAccount(t *testing.T) {
 			},
 			doMock: func(args args) {
 				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, cacheKey).Return("", nil)
-				testHelper.mockAccRepository.EXPECT().GetLoanAdvanceAccountByLoanAccount(args.ctx, args.loanAccountNumber).Return(models.AccountLoan{}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, models.AccountRelationFilterOptions{
+					ParentAccountNumbers: []string{args.loanAccountNumber},
+					Relations:            []string{models.AccountRelationLoanAdvancePayment},
+					IncludeUnlinked:      true,
+				}).Return([]models.AccountRelation{
+					{ParentAccountNumber: args.loanAccountNumber, AccountNumber: account.LoanAdvancePaymentAccountNumber, Relation: models.AccountRelationLoanAdvancePayment},
+				}, nil)
 				testHelper.mockCacheRepository.EXPECT().Set(args.ctx, cacheKey, gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
@@ -1145,7 +1228,13 @@ func Test_account_GetLoanAdvanceAccountByLoanAccount(t *testing.T) {
 			},
 			doMock: func(args args) {
 				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, cacheKey).Return("", models.GetErrMap(models.ErrKeyFailedSetToCache))
-				testHelper.mockAccRepository.EXPECT().GetLoanAdvanceAccountByLoanAccount(args.ctx, args.loanAccountNumber).Return(models.AccountLoan{}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, models.AccountRelationFilterOptions{
+					ParentAccountNumbers: []string{args.loanAccountNumber},
+					Relations:            []string{models.AccountRelationLoanAdvancePayment},
+					IncludeUnlinked:      true,
+				}).Return([]models.AccountRelation{
+					{ParentAccountNumber: args.loanAccountNumber, AccountNumber: account.LoanAdvancePaymentAccountNumber, Relation: models.AccountRelationLoanAdvancePayment},
+				}, nil)
 				testHelper.mockCacheRepository.EXPECT().Set(args.ctx, cacheKey, gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
@@ -1158,7 +1247,11 @@ func Test_account_GetLoanAdvanceAccountByLoanAccount(t *testing.T) {
 			},
 			doMock: func(args args) {
 				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, cacheKey).Return("", nil)
-				testHelper.mockAccRepository.EXPECT().GetLoanAdvanceAccountByLoanAccount(args.ctx, args.loanAccountNumber).Return(models.AccountLoan{}, models.GetErrMap(models.ErrKeyDatabaseError))
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, models.AccountRelationFilterOptions{
+					ParentAccountNumbers: []string{args.loanAccountNumber},
+					Relations:            []string{models.AccountRelationLoanAdvancePayment},
+					IncludeUnlinked:      true,
+				}).Return(nil, assert.AnError)
 				testHelper.mockCacheRepository.EXPECT().Set(args.ctx, cacheKey, gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: true,
@@ -1171,7 +1264,13 @@ func Test_account_GetLoanAdvanceAccountByLoanAccount(t *testing.T) {
 			},
 			doMock: func(args args) {
 				testHelper.mockCacheRepository.EXPECT().Get(args.ctx, cacheKey).Return("", nil)
-				testHelper.mockAccRepository.EXPECT().GetLoanAdvanceAccountByLoanAccount(args.ctx, args.loanAccountNumber).Return(models.AccountLoan{}, nil)
+				testHelper.mockAccRepository.EXPECT().GetRelations(args.ctx, models.AccountRelationFilterOptions{
+					ParentAccountNumbers: []string{args.loanAccountNumber},
+					Relations:            []string{models.AccountRelationLoanAdvancePayment},
+					IncludeUnlinked:      true,
+				}).Return([]models.AccountRelation{
+					{ParentAccountNumber: args.loanAccountNumber, AccountNumber: account.LoanAdvancePaymentAccountNumber, Relation: models.AccountRelationLoanAdvancePayment},
+				}, nil)
 				testHelper.mockCacheRepository.EXPECT().Set(args.ctx, cacheKey, gomock.Any(), gomock.Any()).Return(models.GetErrMap(models.ErrKeyFailedSetToCache))
 			},
 			wantErr: false,
@@ -1228,9 +1327,13 @@ func Test_account_UpdateAccountByCustomerData(t *testing.T) {
 					}).
 					Return(defaultResultAccounts, nil)
 
-				testHelper.mockAccRepository.EXPECT().
-					Update(args.ctx, gomock.Any()).
-					Return(nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+						testHelper.mockAccRepository.EXPECT().InsertChangeLogs(ctx, gomock.Any()).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
 
 				testHelper.mockAcuanClient.
 					EXPECT().
@@ -1293,9 +1396,12 @@ func Test_account_UpdateAccountByCustomerData(t *testing.T) {
 					}).
 					Return(defaultResultAccounts, nil)
 
-				testHelper.mockAccRepository.EXPECT().
-					Update(args.ctx, gomock.Any()).
-					Return(assert.AnError)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().Update(ctx, gomock.Any()).Return(assert.AnError)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
 			},
 			wantErr: true,
 		},
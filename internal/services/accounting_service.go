@@ -27,6 +27,28 @@ import (
 
 var CurrencyIDR = godbledger.CurrencyIDR
 
+// getReportCurrency returns the currency of a report, the report is in IDR when the currency is empty.
+// The amounts of the ledger are in the minor unit of the currency, so they are scaled by its decimals.
+func (as *accounting) getReportCurrency(ctx context.Context, name string) (godbledger.Currency, error) {
+	if name == "" {
+		name = models.CurrencyIDR
+	}
+	currency, err := as.srv.goDBLedger.GetCurrency(ctx, name)
+	if err != nil {
+		return nil, checkDatabaseError(err, models.ErrKeyCurrencyNotFound)
+	}
+
+	return currency, nil
+}
+
+// currencyLabel returns the label of the amount column of a report.
+func currencyLabel(currency godbledger.Currency) string {
+	if currency.Name == models.CurrencyIDR {
+		return "Rp"
+	}
+	return currency.Name
+}
+
 type AccountingService interface {
 	// Trial Balance
 	GetTrialBalance(ctx context.Context, opts models.TrialBalanceFilterOptions) (resp models.GetTrialBalanceResponses, err error)
@@ -51,12 +73,40 @@ type AccountingService interface {
 	GetBalanceSheet(ctx context.Context, opts models.BalanceSheetFilterOptions) (resp models.GetBalanceSheetResponse, err error)
 	DownloadCSVGetBalanceSheet(ctx context.Context, opts models.BalanceSheetFilterOptions, resp models.GetBalanceSheetResponse) (b *bytes.Buffer, filename string, err error)
 
+	// Profit Loss
+	GetProfitLoss(ctx context.Context, opts models.ProfitLossFilterOptions) (resp models.GetProfitLossResponse, err error)
+	DownloadCSVGetProfitLoss(ctx context.Context, opts models.ProfitLossFilterOptions, resp models.GetProfitLossResponse) (b *bytes.Buffer, filename string, err error)
+
+	// Cash Flow
+	GetCashFlow(ctx context.Context, opts models.CashFlowFilterOptions) (resp models.GetCashFlowResponse, err error)
+	DownloadCSVGetCashFlow(ctx context.Context, opts models.CashFlowFilterOptions, resp models.GetCashFlowResponse) (b *bytes.Buffer, filename string, err error)
+
+	// Consolidated Trial Balance
+	GetConsolidatedTrialBalance(ctx context.Context, opts models.ConsolidatedTrialBalanceFilterOptions) (resp models.GetConsolidatedTrialBalanceResponse, err error)
+
+	// Year End Closing
+	CloseFiscalYear(ctx context.Context, in models.YearEndClosingRequest) (out models.YearEndClosing, err error)
+
+	// Recurring Journal
+	CreateRecurringJournal(ctx context.Context, req models.CreateRecurringJournalRequest) (out *models.RecurringJournal, err error)
+	GetRecurringJournals(ctx context.Context, opts models.RecurringJournalFilterOptions) (out []models.RecurringJournal, err error)
+	GetRecurringJournalById(ctx context.Context, id int) (out *models.RecurringJournal, err error)
+	RunRecurringJournals(ctx context.Context, date time.Time) (out models.RunRecurringJournalResult, err error)
+
+	// Suspense Clearing
+	ClearSuspenseAccounts(ctx context.Context, date time.Time) (out models.ClearSuspenseAccountsResult, err error)
+	GetSuspenseAgeing(ctx context.Context, opts models.SuspenseAgeingFilterOptions) (out *models.SuspenseAgeing, err error)
+
+	// Ledger Integrity
+	CheckLedgerIntegrity(ctx context.Context, in models.LedgerIntegrityRequest) (out models.LedgerIntegrityReport, err error)
+
 	// Job
 	GenerateTrialBalanceBigQuery(ctx context.Context, date time.Time, isAdjustment bool) (err error)
 	GenerateAdjustmentTrialBalanceBigQuery(ctx context.Context, in models.AdjustmentTrialBalanceFilter) (err error)
 
 	GenerateAccountDailyBalance(ctx context.Context, date time.Time) (err error)
 	GenerateAccountTrialBalanceDaily(ctx context.Context, date time.Time) (err error)
+	RevalueForeignCurrencyBalances(ctx context.Context, date time.Time) (err error)
 }
 
 type accounting service
@@ -97,14 +147,7 @@ func (as *accounting) GetTrialBalance(ctx context.Context, opts models.TrialBala
 		if opts.Period.IsZero() {
 			return resp, fmt.Errorf("period is required for the new trial balance flow")
 		}
-		payload := models.CloudStoragePayload{
-			Filename: fmt.Sprintf("%s/%s/summaries/%s.csv",
-				opts.Period.Format("2006"),
-				opts.EntityCode,
-				opts.Period.Format("01"),
-			),
-			Path: fmt.Sprintf("%s", models.TrialBalanceDir),
-		}
+		payload := trialBalanceSummaryPayload(opts.Period, opts.EntityCode)
 
 		reader, errSigned := as.srv.cloudStorageRepo.NewReader(ctx, &payload)
 		if errSigned != nil {
@@ -160,6 +203,18 @@ func (as *accounting) GetTrialBalance(ctx context.Context, opts models.TrialBala
 	return
 }
 
+// trialBalanceSummaryPayload is the gcs summary csv of the trial balance of an entity in a period.
+func trialBalanceSummaryPayload(period time.Time, entityCode string) models.CloudStoragePayload {
+	return models.CloudStoragePayload{
+		Filename: fmt.Sprintf("%s/%s/summaries/%s.csv",
+			period.Format("2006"),
+			entityCode,
+			period.Format("01"),
+		),
+		Path: fmt.Sprintf("%s", models.TrialBalanceDir),
+	}
+}
+
 func (as *accounting) parseTrialBalanceCSV(ctx context.Context, reader io.ReadCloser) ([]models.TrialBalanceCSV, error) {
 
 	var results []models.TrialBalanceCSV
@@ -459,6 +514,11 @@ func (as *accounting) trialBalanceCSVProcessAndSendEmail(ctx context.Context, in
 		return
 	}
 
+	currency, err := as.getReportCurrency(ctx, in.Opts.Currency)
+	if err != nil {
+		return
+	}
+
 	b := &bytes.Buffer{}
 	as.srv.file.NewCSVWriter(b)
 
@@ -505,10 +565,10 @@ func (as *accounting) trialBalanceCSVProcessAndSendEmail(ctx context.Context, in
 			if err = as.srv.file.CSVWriteBody(ctx, []string{
 				fmt.Sprintf("%s.%s", c.CategoryCode, c.CategoryName),
 				"",
-				money.FormatBigIntToAmount(c.TotalOpeningBalance, CurrencyIDR.Decimals).String(),
-				money.FormatBigIntToAmount(c.TotalDebitMovement, CurrencyIDR.Decimals).String(),
-				money.FormatBigIntToAmount(c.TotalCreditMovement, CurrencyIDR.Decimals).String(),
-				money.FormatBigIntToAmount(c.TotalClosingBalance, CurrencyIDR.Decimals).String(),
+				money.FormatBigIntToAmount(c.TotalOpeningBalance, currency.Decimals).String(),
+				money.FormatBigIntToAmount(c.TotalDebitMovement, currency.Decimals).String(),
+				money.FormatBigIntToAmount(c.TotalCreditMovement, currency.Decimals).String(),
+				money.FormatBigIntToAmount(c.TotalClosingBalance, currency.Decimals).String(),
 			}); err != nil {
 				err = fmt.Errorf("failed to write body: %w", err)
 				return
@@ -519,10 +579,10 @@ func (as *accounting) trialBalanceCSVProcessAndSendEmail(ctx context.Context, in
 				if err = as.srv.file.CSVWriteBody(ctx, []string{
 					"",
 					fmt.Sprintf("%s.%s", sc.SubCategoryCode, sc.SubCategoryName),
-					money.FormatBigIntToAmount(sc.OpeningBalance, CurrencyIDR.Decimals).String(),
-					money.FormatBigIntToAmount(sc.DebitMovement, CurrencyIDR.Decimals).String(),
-					money.FormatBigIntToAmount(sc.CreditMovement, CurrencyIDR.Decimals).String(),
-					money.FormatBigIntToAmount(sc.ClosingBalance, CurrencyIDR.Decimals).String(),
+					money.FormatBigIntToAmount(sc.OpeningBalance, currency.Decimals).String(),
+					money.FormatBigIntToAmount(sc.DebitMovement, currency.Decimals).String(),
+					money.FormatBigIntToAmount(sc.CreditMovement, currency.Decimals).String(),
+					money.FormatBigIntToAmount(sc.ClosingBalance, currency.Decimals).String(),
 				}); err != nil {
 					err = fmt.Errorf("failed to write body: %w", err)
 					return
@@ -533,10 +593,10 @@ func (as *accounting) trialBalanceCSVProcessAndSendEmail(ctx context.Context, in
 		if err = as.srv.file.CSVWriteBody(ctx, []string{
 			t.CoaTypeCode,
 			fmt.Sprintf("Total %s", t.CoaTypeName),
-			money.FormatBigIntToAmount(t.TotalOpeningBalance, CurrencyIDR.Decimals).String(),
-			money.FormatBigIntToAmount(t.TotalDebitMovement, CurrencyIDR.Decimals).String(),
-			money.FormatBigIntToAmount(t.TotalCreditMovement, CurrencyIDR.Decimals).String(),
-			money.FormatBigIntToAmount(t.TotalClosingBalance, CurrencyIDR.Decimals).String(),
+			money.FormatBigIntToAmount(t.TotalOpeningBalance, currency.Decimals).String(),
+			money.FormatBigIntToAmount(t.TotalDebitMovement, currency.Decimals).String(),
+			money.FormatBigIntToAmount(t.TotalCreditMovement, currency.Decimals).String(),
+			money.FormatBigIntToAmount(t.TotalClosingBalance, currency.Decimals).String(),
 		}); err != nil {
 			err = fmt.Errorf("failed to write body: %w", err)
 			return
@@ -552,10 +612,10 @@ func (as *accounting) trialBalanceCSVProcessAndSendEmail(ctx context.Context, in
 	if err = as.srv.file.CSVWriteBody(ctx, []string{
 		"",
 		"Catch All",
-		money.FormatBigIntToAmount(res.CatchAll.CatchAllOpeningBalance, CurrencyIDR.Decimals).String(),
-		money.FormatBigIntToAmount(res.CatchAll.CatchAllDebitMovement, CurrencyIDR.Decimals).String(),
-		money.FormatBigIntToAmount(res.CatchAll.CatchAllCreditMovement, CurrencyIDR.Decimals).String(),
-		money.FormatBigIntToAmount(res.CatchAll.CatchAllClosingBalance, CurrencyIDR.Decimals).String(),
+		money.FormatBigIntToAmount(res.CatchAll.CatchAllOpeningBalance, currency.Decimals).String(),
+		money.FormatBigIntToAmount(res.CatchAll.CatchAllDebitMovement, currency.Decimals).String(),
+		money.FormatBigIntToAmount(res.CatchAll.CatchAllCreditMovement, currency.Decimals).String(),
+		money.FormatBigIntToAmount(res.CatchAll.CatchAllClosingBalance, currency.Decimals).String(),
 	}); err != nil {
 		err = fmt.Errorf("failed to write body: %w", err)
 		return
@@ -5,11 +5,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/money"
 	"github.com/hashicorp/go-multierror"
+	"github.com/shopspring/decimal"
 )
 
 func (as *accounting) GetBalanceSheet(ctx context.Context, opts models.BalanceSheetFilterOptions) (resp models.GetBalanceSheetResponse, err error) {
@@ -53,10 +56,157 @@ func (as *accounting) GetBalanceSheet(ctx context.Context, opts models.BalanceSh
 		},
 	}
 
+	if len(opts.CompareDates) > 0 {
+		resp.Comparison, err = as.getBalanceSheetComparison(ctx, opts)
+		if err != nil {
+			return
+		}
+	}
+
 	return
 }
 
+/*
+1. get the closing balance per sub category of the balance sheet date & every compare date
+2. group per coa type, category & sub category, the amounts are in the order of the dates
+3. variance = balance sheet date amount - compare date amount
+4. percentage = variance / |compare date amount| * 100, it is empty when the compare date amount is zero
+*/
+func (as *accounting) getBalanceSheetComparison(ctx context.Context, opts models.BalanceSheetFilterOptions) (*models.BalanceSheetComparison, error) {
+	dates := append([]time.Time{opts.BalanceSheetDate}, opts.CompareDates...)
+	out, err := as.srv.mySqlRepo.GetAccountingRepository().GetBalanceSheetPerSubCategory(ctx, opts.EntityCode, opts.Currency, dates)
+	if err != nil {
+		return nil, err
+	}
+
+	formattedDates := make([]string, len(dates))
+	dateIndex := map[string]int{}
+	for i, v := range dates {
+		formattedDates[i] = v.Format(atime.DateFormatYYYYMMDD)
+		dateIndex[formattedDates[i]] = i
+	}
+
+	type subCategoryAmounts struct {
+		code, name string
+		amounts    []decimal.Decimal
+	}
+	type categoryAmounts struct {
+		code, name    string
+		amounts       []decimal.Decimal
+		subCategories []*subCategoryAmounts
+	}
+	newAmounts := func() []decimal.Decimal {
+		amounts := make([]decimal.Decimal, len(dates))
+		for i := range amounts {
+			amounts[i] = decimal.Zero
+		}
+		return amounts
+	}
+
+	// rows are ordered by category & sub category, the dates of a sub category are next to each other
+	categories := map[string][]*categoryAmounts{}
+	totals := map[string][]decimal.Decimal{
+		models.COATypeAsset:     newAmounts(),
+		models.COATypeLiability: newAmounts(),
+	}
+	for _, v := range out {
+		i, ok := dateIndex[v.ClosingDate.Format(atime.DateFormatYYYYMMDD)]
+		if !ok {
+			continue
+		}
+
+		coaCategories := categories[v.COAType]
+		if len(coaCategories) == 0 || coaCategories[len(coaCategories)-1].code != v.CategoryCode {
+			coaCategories = append(coaCategories, &categoryAmounts{code: v.CategoryCode, name: v.CategoryName, amounts: newAmounts()})
+			categories[v.COAType] = coaCategories
+		}
+		category := coaCategories[len(coaCategories)-1]
+		if len(category.subCategories) == 0 || category.subCategories[len(category.subCategories)-1].code != v.SubCategoryCode {
+			category.subCategories = append(category.subCategories, &subCategoryAmounts{code: v.SubCategoryCode, name: v.SubCategoryName, amounts: newAmounts()})
+		}
+		subCategory := category.subCategories[len(category.subCategories)-1]
+
+		subCategory.amounts[i] = subCategory.amounts[i].Add(v.SumClosingBalance)
+		category.amounts[i] = category.amounts[i].Add(v.SumClosingBalance)
+		totals[v.COAType][i] = totals[v.COAType][i].Add(v.SumClosingBalance)
+	}
+
+	toComparativeCategories := func(coaType string) []models.ComparativeBalanceCategory {
+		res := []models.ComparativeBalanceCategory{}
+		for _, c := range categories[coaType] {
+			category := models.ComparativeBalanceCategory{
+				CategoryCode:  c.code,
+				CategoryName:  c.name,
+				Balance:       newComparativeBalance(formattedDates, c.amounts),
+				SubCategories: []models.ComparativeBalanceSubCategory{},
+			}
+			for _, sc := range c.subCategories {
+				category.SubCategories = append(category.SubCategories, models.ComparativeBalanceSubCategory{
+					SubCategoryCode: sc.code,
+					SubCategoryName: sc.name,
+					Balance:         newComparativeBalance(formattedDates, sc.amounts),
+				})
+			}
+			res = append(res, category)
+		}
+		return res
+	}
+
+	catchAll := newAmounts()
+	for i := range catchAll {
+		catchAll[i] = totals[models.COATypeAsset][i].Sub(totals[models.COATypeLiability][i])
+	}
+
+	return &models.BalanceSheetComparison{
+		Kind:           models.KindBalanceSheetComparison,
+		Dates:          formattedDates,
+		Assets:         toComparativeCategories(models.COATypeAsset),
+		TotalAsset:     newComparativeBalance(formattedDates, totals[models.COATypeAsset]),
+		Liabilities:    toComparativeCategories(models.COATypeLiability),
+		TotalLiability: newComparativeBalance(formattedDates, totals[models.COATypeLiability]),
+		CatchAll:       newComparativeBalance(formattedDates, catchAll),
+	}, nil
+}
+
+// newComparativeBalance calculates the variances of the first amount against the other amounts.
+func newComparativeBalance(dates []string, amounts []decimal.Decimal) models.ComparativeBalance {
+	res := models.ComparativeBalance{
+		Amounts:    make([]string, len(amounts)),
+		DecAmounts: amounts,
+		Variances:  make([]models.BalanceVariance, 0, len(amounts)-1),
+	}
+	for i, v := range amounts {
+		res.Amounts[i] = money.FormatAmountToIDRFromDecimal(v)
+		if i == 0 {
+			continue
+		}
+
+		variance := models.BalanceVariance{
+			CompareDate: dates[i],
+			DecAmount:   amounts[0].Sub(v),
+		}
+		variance.Amount = money.FormatAmountToIDRFromDecimal(variance.DecAmount)
+		if !v.IsZero() {
+			percentage := variance.DecAmount.Div(v.Abs()).Mul(decimal.NewFromInt(100)).Round(2)
+			variance.DecPercentage = &percentage
+			variance.Percentage = percentage.StringFixed(2)
+		}
+		res.Variances = append(res.Variances, variance)
+	}
+
+	return res
+}
+
 func (as *accounting) DownloadCSVGetBalanceSheet(ctx context.Context, opts models.BalanceSheetFilterOptions, resp models.GetBalanceSheetResponse) (b *bytes.Buffer, filename string, err error) {
+	currency, err := as.getReportCurrency(ctx, opts.Currency)
+	if err != nil {
+		return
+	}
+
+	if resp.Comparison != nil {
+		return as.downloadCSVBalanceSheetComparison(ctx, opts, resp, currency)
+	}
+
 	b = &bytes.Buffer{}
 	as.srv.file.NewCSVWriter(b)
 
@@ -79,14 +229,14 @@ func (as *accounting) DownloadCSVGetBalanceSheet(ctx context.Context, opts model
 	var errs *multierror.Error
 	if err = as.srv.file.CSVWriteBody(ctx, []string{
 		"ASSETS",
-		"Rp",
+		currencyLabel(currency),
 	}); err != nil {
 		errs = multierror.Append(errs, err)
 	}
 	for _, t := range resp.BalanceSheet.Assets {
 		if err = as.srv.file.CSVWriteBody(ctx, []string{
 			fmt.Sprintf("A.%s %s", t.CategoryCode, t.CategoryName),
-			money.FormatBigIntToAmount(t.DecAmount, CurrencyIDR.Decimals).String(),
+			money.FormatBigIntToAmount(t.DecAmount, currency.Decimals).String(),
 		}); err != nil {
 			errs = multierror.Append(errs, err)
 		}
@@ -96,7 +246,7 @@ func (as *accounting) DownloadCSVGetBalanceSheet(ctx context.Context, opts model
 		return
 	}
 
-	if err = as.srv.file.CSVWriteBody(ctx, []string{"TOTAL ASSETS", money.FormatBigIntToAmount(resp.BalanceSheet.DecTotalAsset, CurrencyIDR.Decimals).String()}); err != nil {
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"TOTAL ASSETS", money.FormatBigIntToAmount(resp.BalanceSheet.DecTotalAsset, currency.Decimals).String()}); err != nil {
 		err = fmt.Errorf("failed to write body: %w", err)
 		return
 	}
@@ -108,14 +258,14 @@ func (as *accounting) DownloadCSVGetBalanceSheet(ctx context.Context, opts model
 
 	if err = as.srv.file.CSVWriteBody(ctx, []string{
 		"LIABILITIES",
-		"Rp",
+		currencyLabel(currency),
 	}); err != nil {
 		errs = multierror.Append(errs, err)
 	}
 	for _, t := range resp.BalanceSheet.Liabilities {
 		if err = as.srv.file.CSVWriteBody(ctx, []string{
 			fmt.Sprintf("B.%s %s", t.CategoryCode, t.CategoryName),
-			money.FormatBigIntToAmount(t.DecAmount, CurrencyIDR.Decimals).String(),
+			money.FormatBigIntToAmount(t.DecAmount, currency.Decimals).String(),
 		}); err != nil {
 			errs = multierror.Append(errs, err)
 		}
@@ -125,7 +275,7 @@ func (as *accounting) DownloadCSVGetBalanceSheet(ctx context.Context, opts model
 		return
 	}
 
-	if err = as.srv.file.CSVWriteBody(ctx, []string{"TOTAL LIABILITIES", money.FormatBigIntToAmount(resp.BalanceSheet.DecTotalLiability, CurrencyIDR.Decimals).String()}); err != nil {
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"TOTAL LIABILITIES", money.FormatBigIntToAmount(resp.BalanceSheet.DecTotalLiability, currency.Decimals).String()}); err != nil {
 		err = fmt.Errorf("failed to write body: %w", err)
 		return
 	}
@@ -135,7 +285,7 @@ func (as *accounting) DownloadCSVGetBalanceSheet(ctx context.Context, opts model
 		return
 	}
 
-	if err = as.srv.file.CSVWriteBody(ctx, []string{"Catch all (Balancing)", money.FormatBigIntToAmount(resp.BalanceSheet.DecCatchAll, CurrencyIDR.Decimals).String()}); err != nil {
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"Catch all (Balancing)", money.FormatBigIntToAmount(resp.BalanceSheet.DecCatchAll, currency.Decimals).String()}); err != nil {
 		err = fmt.Errorf("failed to write body: %w", err)
 		return
 	}
@@ -148,3 +298,113 @@ func (as *accounting) DownloadCSVGetBalanceSheet(ctx context.Context, opts model
 
 	return
 }
+
+func (as *accounting) downloadCSVBalanceSheetComparison(ctx context.Context, opts models.BalanceSheetFilterOptions, resp models.GetBalanceSheetResponse, currency godbledger.Currency) (b *bytes.Buffer, filename string, err error) {
+	b = &bytes.Buffer{}
+	as.srv.file.NewCSVWriter(b)
+
+	comparison := resp.Comparison
+	compareDates := make([]string, 0, len(opts.CompareDates))
+	for _, v := range opts.CompareDates {
+		compareDates = append(compareDates, strings.ToUpper(v.Format(atime.DateFormatDDMMMYYYYWithSpace)))
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{resp.EntityDesc}); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"COMPARATIVE BALANCE SHEET REPORT"}); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{fmt.Sprintf("AS AT CLOSE OF %s COMPARED TO %s",
+		strings.ToUpper(opts.BalanceSheetDate.Format(atime.DateFormatDDMMMYYYYWithSpace)),
+		strings.Join(compareDates, ", "),
+	)}); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{}); err != nil {
+		return
+	}
+
+	if err = as.writeCSVBalanceSheetComparisonSection(ctx, "ASSETS", "A", comparison.Dates, comparison.Assets, currency); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, comparativeBalanceRow("TOTAL ASSETS", comparison.TotalAsset, currency.Decimals)); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.writeCSVBalanceSheetComparisonSection(ctx, "LIABILITIES", "B", comparison.Dates, comparison.Liabilities, currency); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, comparativeBalanceRow("TOTAL LIABILITIES", comparison.TotalLiability, currency.Decimals)); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, comparativeBalanceRow("Catch all (Balancing)", comparison.CatchAll, currency.Decimals)); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVProcessWrite(ctx); err != nil {
+		return
+	}
+
+	filename = fmt.Sprintf("Balance-Sheets-Comparison-%s-%s.csv", resp.EntityName, opts.BalanceSheetDate.Format(atime.DateFormatYYYYMMDDWithoutDash))
+
+	return
+}
+
+func (as *accounting) writeCSVBalanceSheetComparisonSection(ctx context.Context, title, prefix string, dates []string, categories []models.ComparativeBalanceCategory, currency godbledger.Currency) error {
+	var errs *multierror.Error
+	header := []string{title}
+	for _, v := range dates {
+		header = append(header, fmt.Sprintf("%s %s", currencyLabel(currency), v))
+	}
+	for _, v := range dates[1:] {
+		header = append(header, fmt.Sprintf("Variance %s", v), fmt.Sprintf("Variance %% %s", v))
+	}
+	if err := as.srv.file.CSVWriteBody(ctx, header); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	for _, c := range categories {
+		if err := as.srv.file.CSVWriteBody(ctx, comparativeBalanceRow(fmt.Sprintf("%s.%s %s", prefix, c.CategoryCode, c.CategoryName), c.Balance, currency.Decimals)); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		for _, sc := range c.SubCategories {
+			if err := as.srv.file.CSVWriteBody(ctx, comparativeBalanceRow(fmt.Sprintf("    %s %s", sc.SubCategoryCode, sc.SubCategoryName), sc.Balance, currency.Decimals)); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func comparativeBalanceRow(title string, balance models.ComparativeBalance, decimals int) []string {
+	row := []string{title}
+	for _, v := range balance.DecAmounts {
+		row = append(row, money.FormatBigIntToAmount(v, decimals).String())
+	}
+	for _, v := range balance.Variances {
+		row = append(row, money.FormatBigIntToAmount(v.DecAmount, decimals).String(), v.Percentage)
+	}
+
+	return row
+}
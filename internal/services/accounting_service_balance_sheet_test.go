@@ -3,9 +3,11 @@ package services_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
 	"go.uber.org/mock/gomock"
 
 	"github.com/shopspring/decimal"
@@ -106,6 +108,10 @@ func Test_accounting_GetBalanceSheet(t *testing.T) {
 
 func Test_accounting_DownloadCSVGetBalanceSheet(t *testing.T) {
 	testHelper := serviceTestHelper(t)
+	testHelper.mockGoDbLedger.EXPECT().
+		GetCurrency(gomock.Any(), models.CurrencyIDR).
+		Return(godbledger.CurrencyIDR, nil).
+		AnyTimes()
 	res := models.GetBalanceSheetResponse{
 		EntityCode:       "001",
 		BalanceSheetDate: "2024-01-01",
@@ -164,3 +170,56 @@ func Test_accounting_DownloadCSVGetBalanceSheet(t *testing.T) {
 		})
 	}
 }
+
+func Test_accounting_GetBalanceSheet_Comparison(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	testHelper.mockGoDbLedger.EXPECT().
+		GetCurrency(gomock.Any(), models.CurrencyIDR).
+		Return(godbledger.CurrencyIDR, nil).
+		AnyTimes()
+	balanceSheetDate := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	compareDate := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	opts := models.BalanceSheetFilterOptions{
+		EntityCode:       "001",
+		BalanceSheetDate: balanceSheetDate,
+		CompareDates:     []time.Time{compareDate},
+		Currency:         models.CurrencyIDR,
+	}
+	out := []models.BalancePerSubCategoryOut{
+		{ClosingDate: balanceSheetDate, COAType: models.COATypeAsset, CategoryCode: "111", CategoryName: "Cash", SubCategoryCode: "11101", SubCategoryName: "Cash in Bank", SumClosingBalance: decimal.NewFromInt(150000)},
+		{ClosingDate: compareDate, COAType: models.COATypeAsset, CategoryCode: "111", CategoryName: "Cash", SubCategoryCode: "11101", SubCategoryName: "Cash in Bank", SumClosingBalance: decimal.NewFromInt(100000)},
+		{ClosingDate: balanceSheetDate, COAType: models.COATypeAsset, CategoryCode: "111", CategoryName: "Cash", SubCategoryCode: "11102", SubCategoryName: "Petty Cash", SumClosingBalance: decimal.NewFromInt(50000)},
+		{ClosingDate: balanceSheetDate, COAType: models.COATypeLiability, CategoryCode: "211", CategoryName: "Lender Balance", SubCategoryCode: "21101", SubCategoryName: "Lender Cash", SumClosingBalance: decimal.NewFromInt(200000)},
+		{ClosingDate: compareDate, COAType: models.COATypeLiability, CategoryCode: "211", CategoryName: "Lender Balance", SubCategoryCode: "21101", SubCategoryName: "Lender Cash", SumClosingBalance: decimal.NewFromInt(100000)},
+	}
+
+	testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "001").Return(&models.Entity{Name: "AMF"}, nil)
+	testHelper.mockAcctRepository.EXPECT().GetBalanceSheet(gomock.Any(), opts).Return(models.BalanceSheetOut{}, nil)
+	testHelper.mockAcctRepository.EXPECT().GetBalanceSheetPerSubCategory(gomock.Any(), "001", models.CurrencyIDR, []time.Time{balanceSheetDate, compareDate}).Return(out, nil)
+
+	got, err := testHelper.accountingService.GetBalanceSheet(context.Background(), opts)
+	assert.NoError(t, err)
+	assert.NotNil(t, got.Comparison)
+	assert.Equal(t, []string{"2024-01-31", "2023-12-31"}, got.Comparison.Dates)
+
+	cash := got.Comparison.Assets[0]
+	assert.Equal(t, []string{"2.000,00", "1.000,00"}, cash.Balance.Amounts)
+	assert.Equal(t, "1.000,00", cash.Balance.Variances[0].Amount)
+	assert.Equal(t, "100.00", cash.Balance.Variances[0].Percentage)
+	assert.Len(t, cash.SubCategories, 2)
+	assert.Equal(t, "50.00", cash.SubCategories[0].Balance.Variances[0].Percentage)
+	// petty cash has no balance at the compare date
+	assert.Equal(t, "", cash.SubCategories[1].Balance.Variances[0].Percentage)
+	assert.Nil(t, cash.SubCategories[1].Balance.Variances[0].DecPercentage)
+
+	assert.Equal(t, []string{"2.000,00", "1.000,00"}, got.Comparison.TotalLiability.Amounts)
+	assert.Equal(t, []string{"0,00", "0,00"}, got.Comparison.CatchAll.Amounts)
+
+	testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+	testHelper.mockFile.EXPECT().CSVWriteBody(gomock.Any(), gomock.Any()).AnyTimes()
+	testHelper.mockFile.EXPECT().CSVProcessWrite(gomock.Any())
+
+	_, filename, err := testHelper.accountingService.DownloadCSVGetBalanceSheet(context.Background(), opts, got)
+	assert.NoError(t, err)
+	assert.Equal(t, "Balance-Sheets-Comparison-AMF-20240131.csv", filename)
+}
@@ -18,7 +18,6 @@ func (as *accounting) GenerateTrialBalanceBigQuery(ctx context.Context, date tim
 	}()
 
 	jobStart := atime.Now()
-	periodStartEnd := atime.BeginningOfMonth(date).Format(atime.DateFormatYYYYMMDD)
 	start, end := atime.PrevMonth(date)
 	startDate, endDate := start.Format(atime.DateFormatYYYYMMDD), end.Format(atime.DateFormatYYYYMMDD)
 	baseDate := end.Format(atime.DateFormatYYYYMMWithUnderscore)
@@ -60,6 +59,32 @@ func (as *accounting) GenerateTrialBalanceBigQuery(ctx context.Context, date tim
 		entityCodes = adjustmentEntityCodes
 	}
 
+	trialBalancePeriods, err = as.runTrialBalanceBigQuerySteps(ctx, logPrefix, date, entityCodes)
+	if err != nil {
+		return err
+	}
+
+	if !isAdjustment {
+		if err = as.srv.mySqlRepo.GetTrialBalanceRepository().BulkInsert(ctx, trialBalancePeriods); err != nil {
+			return err
+		}
+	}
+
+	xlog.Info(ctx, logPrefix,
+		xlog.String("description", "job complete"),
+		xlog.Duration("total-duration", time.Since(jobStart)),
+	)
+
+	return nil
+}
+
+// runTrialBalanceBigQuerySteps generates the trial balance of the month before the date in big query and exports it to gcs.
+func (as *accounting) runTrialBalanceBigQuerySteps(ctx context.Context, logPrefix string, date time.Time, entityCodes []string) (trialBalancePeriods []models.CreateTrialBalancePeriod, err error) {
+	periodStartEnd := atime.BeginningOfMonth(date).Format(atime.DateFormatYYYYMMDD)
+	start, end := atime.PrevMonth(date)
+	startDate, endDate := start.Format(atime.DateFormatYYYYMMDD), end.Format(atime.DateFormatYYYYMMDD)
+	baseDate := end.Format(atime.DateFormatYYYYMMWithUnderscore)
+
 	previousMonth := ""
 	if as.srv.flagger.IsEnabled(models.FlagGetOpeningBalanceFromPreviousMonth.String()) {
 		previousMonth = start.AddDate(0, -1, 0).Format(atime.DateFormatYYYYMMWithUnderscore)
@@ -105,7 +130,7 @@ func (as *accounting) GenerateTrialBalanceBigQuery(ctx context.Context, date tim
 				xlog.Duration("elapsed-time", time.Since(stepStart)))
 
 			err = models.GetErrMap(models.ErrKeyBigQueryError, err.Error())
-			return err
+			return nil, err
 		}
 
 		xlog.Info(ctx, logPrefix,
@@ -114,18 +139,7 @@ func (as *accounting) GenerateTrialBalanceBigQuery(ctx context.Context, date tim
 			xlog.Duration("elapsed-time", time.Since(stepStart)))
 	}
 
-	if !isAdjustment {
-		if err = as.srv.mySqlRepo.GetTrialBalanceRepository().BulkInsert(ctx, trialBalancePeriods); err != nil {
-			return err
-		}
-	}
-
-	xlog.Info(ctx, logPrefix,
-		xlog.String("description", "job complete"),
-		xlog.Duration("total-duration", time.Since(jobStart)),
-	)
-
-	return nil
+	return trialBalancePeriods, nil
 }
 
 func (as *accounting) GenerateAdjustmentTrialBalanceBigQuery(ctx context.Context, in models.AdjustmentTrialBalanceFilter) (err error) {
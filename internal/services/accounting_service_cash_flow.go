@@ -0,0 +1,228 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/money"
+	"github.com/hashicorp/go-multierror"
+	"github.com/shopspring/decimal"
+)
+
+/*
+1. validate entity code, the configured cash sub categories & activities
+2. get the balance of the cash accounts before the start date as the opening balance
+3. sum debit & credit movement of the cash accounts per order type & transaction type within the date range
+4. classify each transaction type into operating, investing or financing activity from the config,
+the transaction type mapping is used first, then the order type mapping, otherwise it is an operating activity
+5. closing balance = opening balance + net cash flow
+*/
+func (as *accounting) GetCashFlow(ctx context.Context, opts models.CashFlowFilterOptions) (resp models.GetCashFlowResponse, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	entity, err := as.srv.mySqlRepo.GetEntityRepository().GetByCode(ctx, opts.EntityCode)
+	if err != nil {
+		return
+	}
+
+	if entity == nil {
+		err = models.GetErrMap(models.ErrKeyEntityCodeNotFound)
+		return
+	}
+
+	cashSubCategories := as.srv.conf.CashFlow.CashSubCategories
+	if len(cashSubCategories) == 0 {
+		err = models.GetErrMap(models.ErrKeyCashSubCategoryNotFound)
+		return
+	}
+
+	if err = validateCashFlowActivities(as.srv.conf.CashFlow.Activities); err != nil {
+		return
+	}
+
+	openingBalance, err := as.srv.mySqlRepo.GetAccountingRepository().GetCashBalance(ctx, opts.EntityCode, opts.Currency, cashSubCategories, opts.StartDate)
+	if err != nil {
+		return
+	}
+
+	out, err := as.srv.mySqlRepo.GetAccountingRepository().GetCashFlowMovement(ctx, opts, cashSubCategories)
+	if err != nil {
+		return
+	}
+
+	activities := make([]models.CashFlowActivity, len(models.CashFlowActivities))
+	activityIndex := map[string]int{}
+	for i, v := range models.CashFlowActivities {
+		activities[i] = models.CashFlowActivity{Activity: v, Items: []models.CashFlowItem{}}
+		activityIndex[v] = i
+	}
+
+	netCashFlow := decimal.Zero
+	for _, v := range out {
+		// cash is an asset, debit is cash in & credit is cash out
+		amount := v.DebitMovement.Sub(v.CreditMovement)
+		activity := &activities[activityIndex[as.getCashFlowActivity(v.OrderType, v.TransactionType)]]
+		activity.DecAmount = activity.DecAmount.Add(amount)
+		activity.Items = append(activity.Items, models.CashFlowItem{
+			OrderType:           v.OrderType,
+			TransactionType:     v.TransactionType,
+			TransactionTypeName: v.TransactionTypeName,
+			Amount:              money.FormatAmountToIDRFromDecimal(amount),
+			DecAmount:           amount,
+		})
+		netCashFlow = netCashFlow.Add(amount)
+	}
+	for i := range activities {
+		activities[i].Amount = money.FormatAmountToIDRFromDecimal(activities[i].DecAmount)
+	}
+	closingBalance := openingBalance.Add(netCashFlow)
+
+	resp = models.GetCashFlowResponse{
+		Kind:       models.KindCashFlow,
+		EntityCode: opts.EntityCode,
+		EntityName: entity.Name,
+		EntityDesc: entity.Description,
+		StartDate:  opts.StartDate.Format(atime.DateFormatYYYYMMDD),
+		EndDate:    opts.EndDate.Format(atime.DateFormatYYYYMMDD),
+
+		OpeningBalance:    money.FormatAmountToIDRFromDecimal(openingBalance),
+		DecOpeningBalance: openingBalance,
+
+		Activities: activities,
+
+		NetCashFlow:    money.FormatAmountToIDRFromDecimal(netCashFlow),
+		DecNetCashFlow: netCashFlow,
+
+		ClosingBalance:    money.FormatAmountToIDRFromDecimal(closingBalance),
+		DecClosingBalance: closingBalance,
+	}
+
+	return
+}
+
+// getCashFlowActivity returns the activity of a transaction type, unmapped transaction types are operating activities.
+func (as *accounting) getCashFlowActivity(orderType, transactionType string) string {
+	mapping := as.srv.conf.CashFlow.Activities
+	if activity, ok := mapping[fmt.Sprintf("%s:%s", orderType, transactionType)]; ok {
+		return activity
+	}
+	if activity, ok := mapping[orderType]; ok {
+		return activity
+	}
+
+	return models.CashFlowActivityOperating
+}
+
+func validateCashFlowActivities(activities map[string]string) error {
+	for key, activity := range activities {
+		if !slices.Contains(models.CashFlowActivities, activity) {
+			return models.GetErrMap(models.ErrKeyInvalidCashFlowActivity, fmt.Sprintf("%s activity %s is not supported", key, activity))
+		}
+	}
+
+	return nil
+}
+
+func (as *accounting) DownloadCSVGetCashFlow(ctx context.Context, opts models.CashFlowFilterOptions, resp models.GetCashFlowResponse) (b *bytes.Buffer, filename string, err error) {
+	currency, err := as.getReportCurrency(ctx, opts.Currency)
+	if err != nil {
+		return
+	}
+
+	b = &bytes.Buffer{}
+	as.srv.file.NewCSVWriter(b)
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{resp.EntityDesc}); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"CASH FLOW STATEMENT"}); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{fmt.Sprintf("FOR THE PERIOD %s TO %s",
+		strings.ToUpper(opts.StartDate.Format(atime.DateFormatDDMMMYYYYWithSpace)),
+		strings.ToUpper(opts.EndDate.Format(atime.DateFormatDDMMMYYYYWithSpace)),
+	)}); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{}); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"OPENING CASH BALANCE", money.FormatBigIntToAmount(resp.DecOpeningBalance, currency.Decimals).String()}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	for _, v := range resp.Activities {
+		if err = as.srv.file.CSVWriteBody(ctx, []string{}); err != nil {
+			err = fmt.Errorf("failed to write body: %w", err)
+			return
+		}
+
+		if err = as.writeCSVCashFlowActivity(ctx, v, currency); err != nil {
+			return
+		}
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"NET CASH FLOW", money.FormatBigIntToAmount(resp.DecNetCashFlow, currency.Decimals).String()}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"CLOSING CASH BALANCE", money.FormatBigIntToAmount(resp.DecClosingBalance, currency.Decimals).String()}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVProcessWrite(ctx); err != nil {
+		return
+	}
+
+	filename = fmt.Sprintf("Cash-Flow-%s-%s-%s.csv",
+		resp.EntityName,
+		opts.StartDate.Format(atime.DateFormatYYYYMMDDWithoutDash),
+		opts.EndDate.Format(atime.DateFormatYYYYMMDDWithoutDash),
+	)
+
+	return
+}
+
+func (as *accounting) writeCSVCashFlowActivity(ctx context.Context, activity models.CashFlowActivity, currency godbledger.Currency) error {
+	var errs *multierror.Error
+	title := strings.ToUpper(fmt.Sprintf("CASH FLOW FROM %s ACTIVITIES", activity.Activity))
+	if err := as.srv.file.CSVWriteBody(ctx, []string{title, currencyLabel(currency)}); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	for _, v := range activity.Items {
+		if err := as.srv.file.CSVWriteBody(ctx, []string{
+			fmt.Sprintf("    %s %s", v.TransactionType, v.TransactionTypeName),
+			money.FormatBigIntToAmount(v.DecAmount, currency.Decimals).String(),
+		}); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if err := as.srv.file.CSVWriteBody(ctx, []string{
+		strings.ToUpper(fmt.Sprintf("NET CASH FROM %s ACTIVITIES", activity.Activity)),
+		money.FormatBigIntToAmount(activity.DecAmount, currency.Decimals).String(),
+	}); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,183 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/config"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
+	"go.uber.org/mock/gomock"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_accounting_GetCashFlow(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	testHelper.config.CashFlow = config.CashFlowConfig{
+		CashSubCategories: []string{"11101"},
+		Activities: map[string]string{
+			"LOA":       models.CashFlowActivityInvesting,
+			"EQT:EQTIN": models.CashFlowActivityFinancing,
+		},
+	}
+	startDate, endDate := atime.PrevMonth(atime.Now())
+	opts := models.CashFlowFilterOptions{
+		EntityCode: "001",
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Currency:   models.CurrencyIDR,
+	}
+	defaultData := []models.CashFlowMovementOut{
+		{OrderType: "EQT", TransactionType: "EQTIN", TransactionTypeName: "Equity Injection", DebitMovement: decimal.NewFromInt(1000000)},
+		{OrderType: "EQT", TransactionType: "EQTDV", TransactionTypeName: "Dividend", CreditMovement: decimal.NewFromInt(100000)},
+		{OrderType: "LOA", TransactionType: "LOADB", TransactionTypeName: "Loan Disbursement", CreditMovement: decimal.NewFromInt(300000)},
+	}
+
+	testCases := []struct {
+		name    string
+		opts    models.CashFlowFilterOptions
+		doMock  func()
+		want    models.GetCashFlowResponse
+		wantErr bool
+	}{
+		{
+			name: "success case - get cash flow",
+			opts: opts,
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "001").Return(&models.Entity{Name: "AMF"}, nil)
+				testHelper.mockAcctRepository.EXPECT().GetCashBalance(gomock.Any(), "001", models.CurrencyIDR, []string{"11101"}, startDate).Return(decimal.NewFromInt(500000), nil)
+				testHelper.mockAcctRepository.EXPECT().GetCashFlowMovement(gomock.Any(), opts, []string{"11101"}).Return(defaultData, nil)
+			},
+			want: models.GetCashFlowResponse{
+				Kind:              models.KindCashFlow,
+				EntityCode:        "001",
+				EntityName:        "AMF",
+				StartDate:         startDate.Format(atime.DateFormatYYYYMMDD),
+				EndDate:           endDate.Format(atime.DateFormatYYYYMMDD),
+				OpeningBalance:    "5.000,00",
+				DecOpeningBalance: decimal.NewFromInt(500000),
+				Activities: []models.CashFlowActivity{
+					{
+						Activity: models.CashFlowActivityOperating,
+						Items: []models.CashFlowItem{
+							{OrderType: "EQT", TransactionType: "EQTDV", TransactionTypeName: "Dividend", Amount: "-1.000,00", DecAmount: decimal.NewFromInt(-100000)},
+						},
+						Amount:    "-1.000,00",
+						DecAmount: decimal.NewFromInt(-100000),
+					},
+					{
+						Activity: models.CashFlowActivityInvesting,
+						Items: []models.CashFlowItem{
+							{OrderType: "LOA", TransactionType: "LOADB", TransactionTypeName: "Loan Disbursement", Amount: "-3.000,00", DecAmount: decimal.NewFromInt(-300000)},
+						},
+						Amount:    "-3.000,00",
+						DecAmount: decimal.NewFromInt(-300000),
+					},
+					{
+						Activity: models.CashFlowActivityFinancing,
+						Items: []models.CashFlowItem{
+							{OrderType: "EQT", TransactionType: "EQTIN", TransactionTypeName: "Equity Injection", Amount: "10.000,00", DecAmount: decimal.NewFromInt(1000000)},
+						},
+						Amount:    "10.000,00",
+						DecAmount: decimal.NewFromInt(1000000),
+					},
+				},
+				NetCashFlow:       "6.000,00",
+				DecNetCashFlow:    decimal.NewFromInt(600000),
+				ClosingBalance:    "11.000,00",
+				DecClosingBalance: decimal.NewFromInt(1100000),
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - get cash flow movement error",
+			opts: opts,
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "001").Return(&models.Entity{}, nil)
+				testHelper.mockAcctRepository.EXPECT().GetCashBalance(gomock.Any(), "001", models.CurrencyIDR, []string{"11101"}, startDate).Return(decimal.Zero, nil)
+				testHelper.mockAcctRepository.EXPECT().GetCashFlowMovement(gomock.Any(), opts, []string{"11101"}).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - get cash balance error",
+			opts: opts,
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "001").Return(&models.Entity{}, nil)
+				testHelper.mockAcctRepository.EXPECT().GetCashBalance(gomock.Any(), "001", models.CurrencyIDR, []string{"11101"}, startDate).Return(decimal.Zero, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - entity code not found",
+			opts: models.CashFlowFilterOptions{EntityCode: "123"},
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "123").Return(nil, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			got, err := testHelper.accountingService.GetCashFlow(context.Background(), tc.opts)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_accounting_GetCashFlow_InvalidActivity(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	testHelper.config.CashFlow = config.CashFlowConfig{
+		CashSubCategories: []string{"11101"},
+		Activities:        map[string]string{"LOA": "lending"},
+	}
+
+	testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "001").Return(&models.Entity{Name: "AMF"}, nil)
+
+	_, err := testHelper.accountingService.GetCashFlow(context.Background(), models.CashFlowFilterOptions{EntityCode: "001"})
+	assert.Equal(t, models.GetErrMap(models.ErrKeyInvalidCashFlowActivity, "LOA activity lending is not supported"), err)
+}
+
+func Test_accounting_DownloadCSVGetCashFlow(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	testHelper.mockGoDbLedger.EXPECT().
+		GetCurrency(gomock.Any(), models.CurrencyIDR).
+		Return(godbledger.CurrencyIDR, nil).
+		AnyTimes()
+	res := models.GetCashFlowResponse{
+		EntityCode:     "001",
+		StartDate:      "2024-01-01",
+		EndDate:        "2024-01-31",
+		OpeningBalance: "0,00",
+		Activities: []models.CashFlowActivity{
+			{
+				Activity: models.CashFlowActivityOperating,
+				Items: []models.CashFlowItem{
+					{OrderType: "TUP", TransactionType: "TUPVA", TransactionTypeName: "Topup VA", Amount: "0,00"},
+				},
+				Amount: "0,00",
+			},
+		},
+		NetCashFlow:    "0,00",
+		ClosingBalance: "0,00",
+	}
+
+	testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+	testHelper.mockFile.EXPECT().CSVWriteBody(gomock.Any(), gomock.Any()).AnyTimes()
+	testHelper.mockFile.EXPECT().CSVProcessWrite(gomock.Any())
+
+	_, filename, err := testHelper.accountingService.DownloadCSVGetCashFlow(context.TODO(), models.CashFlowFilterOptions{}, res)
+	assert.NoError(t, err)
+	assert.Contains(t, filename, "Cash-Flow-")
+}
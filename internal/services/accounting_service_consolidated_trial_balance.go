@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/money"
+
+	"github.com/shopspring/decimal"
+)
+
+type consolidatedSubCategory struct {
+	key         models.TBCOACategory
+	subCategory models.TBSubCategory
+}
+
+/*
+1. validate the entity codes, every active entity is consolidated when the entity codes are empty
+2. get the trial balance of every entity, from the gcs summary csv when the gcs flag is enabled otherwise from mysql
+3. sum the balances & movements per sub category
+4. eliminate the configured intercompany sub categories & report the pairs whose receivable & payable are not equal
+5. transform the remaining sub categories to the trial balance response
+*/
+func (as *accounting) GetConsolidatedTrialBalance(ctx context.Context, opts models.ConsolidatedTrialBalanceFilterOptions) (resp models.GetConsolidatedTrialBalanceResponse, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	entityCodes, err := as.getConsolidatedEntityCodes(ctx, opts.EntityCodes)
+	if err != nil {
+		return
+	}
+
+	useGCS := as.srv.flagger.IsEnabled(models.FlagGetTrialBalanceGCS.String())
+	formatAmount := money.FormatAmountToIDRFromDecimal
+	var mapSubCategory map[string]models.CategorySubCategoryCOAType
+	if useGCS {
+		if opts.Period.IsZero() {
+			return resp, fmt.Errorf("period is required for the new trial balance flow")
+		}
+		formatAmount = money.FormatAmountToIDRFromDecimalGCS
+
+		_, _, mapSubCategory, err = as.srv.mySqlRepo.GetAllCategorySubCategoryCOAType(ctx)
+		if err != nil {
+			return resp, fmt.Errorf("failed to get coa mapping: %w", err)
+		}
+	}
+
+	consolidated := map[string]*consolidatedSubCategory{}
+	for _, entityCode := range entityCodes {
+		tbOpts := opts.ToTrialBalanceFilterOptions(entityCode)
+		var coaSubCategories map[models.TBCOACategory][]models.TBSubCategory
+		if useGCS {
+			coaSubCategories, err = as.getTrialBalanceFromSummaryCSV(ctx, tbOpts, mapSubCategory)
+		} else {
+			coaSubCategories, err = as.getTrialBalanceFromMySQL(ctx, tbOpts)
+		}
+		if err != nil {
+			return
+		}
+
+		for key, subCategories := range coaSubCategories {
+			for _, v := range subCategories {
+				c, ok := consolidated[v.SubCategoryCode]
+				if !ok {
+					c = &consolidatedSubCategory{
+						key: key,
+						subCategory: models.TBSubCategory{
+							Kind:            models.KindSubCategory,
+							SubCategoryCode: v.SubCategoryCode,
+							SubCategoryName: v.SubCategoryName,
+						},
+					}
+					consolidated[v.SubCategoryCode] = c
+				}
+				c.subCategory.OpeningBalance = c.subCategory.OpeningBalance.Add(v.OpeningBalance)
+				c.subCategory.DebitMovement = c.subCategory.DebitMovement.Add(v.DebitMovement)
+				c.subCategory.CreditMovement = c.subCategory.CreditMovement.Add(v.CreditMovement)
+				c.subCategory.ClosingBalance = c.subCategory.ClosingBalance.Add(v.ClosingBalance)
+			}
+		}
+	}
+
+	resp.Eliminations, resp.Mismatches = as.eliminateIntercompany(consolidated, formatAmount)
+
+	subCategoryCodes := make([]string, 0, len(consolidated))
+	for k := range consolidated {
+		subCategoryCodes = append(subCategoryCodes, k)
+	}
+	sort.Strings(subCategoryCodes)
+
+	coaCategories := make(map[string][]models.TBCOACategory)
+	coaSubCategories := make(map[models.TBCOACategory][]models.TBSubCategory)
+	for _, code := range subCategoryCodes {
+		c := consolidated[code]
+		sc := c.subCategory
+		sc.IDRFormatOpeningBalance = formatAmount(sc.OpeningBalance)
+		sc.IDRFormatDebitMovement = formatAmount(sc.DebitMovement)
+		sc.IDRFormatCreditMovement = formatAmount(sc.CreditMovement)
+		sc.IDRFormatClosingBalance = formatAmount(sc.ClosingBalance)
+		coaSubCategories[c.key] = append(coaSubCategories[c.key], sc)
+
+		coaCategories[c.key.CategoryCode] = append(coaCategories[c.key.CategoryCode], models.TBCOACategory{
+			Type:                c.key.CoaTypeName,
+			CategoryCode:        c.key.CategoryCode,
+			CategoryName:        c.key.CategoryName,
+			TotalOpeningBalance: sc.OpeningBalance,
+			TotalDebitMovement:  sc.DebitMovement,
+			TotalCreditMovement: sc.CreditMovement,
+			TotalClosingBalance: sc.ClosingBalance,
+		})
+	}
+
+	if useGCS {
+		resp.TrialBalance = as.transformToTrialBalanceGCS(coaCategories, coaSubCategories)
+	} else {
+		resp.TrialBalance = as.transformToTrialBalance(coaCategories, coaSubCategories)
+	}
+
+	closingDate := opts.EndDate.Format(atime.DateFormatYYYYMMDD)
+	if useGCS {
+		closingDate = opts.Period.Format(atime.DateFormatYYYYMM)
+	}
+	resp.Kind = models.KindConsolidatedTrialBalance
+	resp.EntityCodes = entityCodes
+	resp.ClosingDate = closingDate
+	resp.TrialBalance.EntityCode = strings.Join(entityCodes, ",")
+	resp.TrialBalance.ClosingDate = closingDate
+
+	return
+}
+
+func (as *accounting) getConsolidatedEntityCodes(ctx context.Context, entityCodes []string) ([]string, error) {
+	if len(entityCodes) == 0 {
+		entities, err := as.srv.mySqlRepo.GetEntityRepository().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if entities == nil || len(*entities) == 0 {
+			return nil, models.GetErrMap(models.ErrKeyEntityCodeNotFound)
+		}
+
+		for _, v := range *entities {
+			entityCodes = append(entityCodes, v.Code)
+		}
+		return entityCodes, nil
+	}
+
+	for _, v := range entityCodes {
+		entity, err := as.srv.mySqlRepo.GetEntityRepository().GetByCode(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		if entity == nil {
+			return nil, models.GetErrMap(models.ErrKeyEntityCodeNotFound, v)
+		}
+	}
+
+	return entityCodes, nil
+}
+
+func (as *accounting) getTrialBalanceFromMySQL(ctx context.Context, opts models.TrialBalanceFilterOptions) (coaSubCategories map[models.TBCOACategory][]models.TBSubCategory, err error) {
+	if as.srv.flagger.IsEnabled(models.FlagGetTrialBalanceV2.String()) {
+		_, coaSubCategories, err = as.srv.mySqlRepo.GetAccountingRepository().GetTrialBalanceV2(ctx, opts)
+		return
+	}
+
+	_, coaSubCategories, err = as.srv.mySqlRepo.GetAccountingRepository().GetTrialBalance(ctx, opts)
+	return
+}
+
+func (as *accounting) getTrialBalanceFromSummaryCSV(ctx context.Context, opts models.TrialBalanceFilterOptions, mapSubCategory map[string]models.CategorySubCategoryCOAType) (map[models.TBCOACategory][]models.TBSubCategory, error) {
+	payload := trialBalanceSummaryPayload(opts.Period, opts.EntityCode)
+	reader, err := as.srv.cloudStorageRepo.NewReader(ctx, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", payload.Filename, err)
+	}
+	defer reader.Close()
+
+	csvData, err := as.parseTrialBalanceCSV(ctx, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	_, coaSubCategories := aggregateTrialBalance(csvData, mapSubCategory)
+	return coaSubCategories, nil
+}
+
+// eliminateIntercompany removes the configured intercompany sub categories from the consolidated sub categories,
+// a pair is reported as a mismatch when its receivable & payable closing balances are not equal.
+func (as *accounting) eliminateIntercompany(consolidated map[string]*consolidatedSubCategory, formatAmount func(decimal.Decimal) string) ([]models.TrialBalanceElimination, []models.IntercompanyMismatch) {
+	eliminations := []models.TrialBalanceElimination{}
+	mismatches := []models.IntercompanyMismatch{}
+
+	eliminate := func(subCategoryCode string) decimal.Decimal {
+		c, ok := consolidated[subCategoryCode]
+		if !ok {
+			return decimal.Zero
+		}
+		delete(consolidated, subCategoryCode)
+
+		sc := c.subCategory
+		eliminations = append(eliminations, models.TrialBalanceElimination{
+			SubCategoryCode:         sc.SubCategoryCode,
+			SubCategoryName:         sc.SubCategoryName,
+			IDRFormatOpeningBalance: formatAmount(sc.OpeningBalance),
+			IDRFormatDebitMovement:  formatAmount(sc.DebitMovement),
+			IDRFormatCreditMovement: formatAmount(sc.CreditMovement),
+			IDRFormatClosingBalance: formatAmount(sc.ClosingBalance),
+			OpeningBalance:          sc.OpeningBalance,
+			DebitMovement:           sc.DebitMovement,
+			CreditMovement:          sc.CreditMovement,
+			ClosingBalance:          sc.ClosingBalance,
+		})
+		return sc.ClosingBalance
+	}
+
+	for _, pair := range as.srv.conf.Consolidation.IntercompanyPairs {
+		receivable := eliminate(pair.ReceivableSubCategory)
+		payable := eliminate(pair.PayableSubCategory)
+		if receivable.Equal(payable) {
+			continue
+		}
+
+		difference := receivable.Sub(payable)
+		mismatches = append(mismatches, models.IntercompanyMismatch{
+			ReceivableSubCategoryCode:  pair.ReceivableSubCategory,
+			PayableSubCategoryCode:     pair.PayableSubCategory,
+			IDRFormatReceivableBalance: formatAmount(receivable),
+			IDRFormatPayableBalance:    formatAmount(payable),
+			IDRFormatDifference:        formatAmount(difference),
+			ReceivableBalance:          receivable,
+			PayableBalance:             payable,
+			Difference:                 difference,
+		})
+	}
+
+	sort.Slice(eliminations, func(i, j int) bool {
+		return eliminations[i].SubCategoryCode < eliminations[j].SubCategoryCode
+	})
+
+	return eliminations, mismatches
+}
@@ -0,0 +1,151 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/config"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_accounting_GetConsolidatedTrialBalance(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	testHelper.config.Consolidation = config.ConsolidationConfig{
+		IntercompanyPairs: []config.IntercompanyPairConfig{
+			{ReceivableSubCategory: "13101", PayableSubCategory: "23101"},
+		},
+	}
+	startDate := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	opts := models.ConsolidatedTrialBalanceFilterOptions{
+		EntityCodes: []string{"001", "002"},
+		StartDate:   startDate,
+		EndDate:     endDate,
+	}
+
+	cashKey := models.TBCOACategory{Type: "asset", CoaTypeCode: "AST", CoaTypeName: "Asset", CategoryCode: "111", CategoryName: "Cash"}
+	receivableKey := models.TBCOACategory{Type: "asset", CoaTypeCode: "AST", CoaTypeName: "Asset", CategoryCode: "131", CategoryName: "Intercompany Receivable"}
+	payableKey := models.TBCOACategory{Type: "liability", CoaTypeCode: "LIA", CoaTypeName: "Liability", CategoryCode: "231", CategoryName: "Intercompany Payable"}
+	subCategory := func(code string, closing int64) models.TBSubCategory {
+		return models.TBSubCategory{SubCategoryCode: code, ClosingBalance: decimal.NewFromInt(closing)}
+	}
+	entity001 := map[models.TBCOACategory][]models.TBSubCategory{
+		cashKey:       {subCategory("11101", 100000)},
+		receivableKey: {subCategory("13101", 50000)},
+	}
+	entity002 := map[models.TBCOACategory][]models.TBSubCategory{
+		cashKey:    {subCategory("11101", 20000)},
+		payableKey: {subCategory("23101", 40000)},
+	}
+
+	tests := []struct {
+		name    string
+		opts    models.ConsolidatedTrialBalanceFilterOptions
+		doMock  func()
+		check   func(t *testing.T, resp models.GetConsolidatedTrialBalanceResponse)
+		wantErr bool
+	}{
+		{
+			name: "success case - consolidate from mysql & eliminate intercompany",
+			opts: opts,
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "001").Return(&models.Entity{Code: "001"}, nil)
+				testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "002").Return(&models.Entity{Code: "002"}, nil)
+				testHelper.mockFlag.EXPECT().IsEnabled(models.FlagGetTrialBalanceGCS.String()).Return(false)
+				testHelper.mockFlag.EXPECT().IsEnabled(models.FlagGetTrialBalanceV2.String()).Return(false).Times(2)
+				testHelper.mockAcctRepository.EXPECT().
+					GetTrialBalance(gomock.Any(), opts.ToTrialBalanceFilterOptions("001")).
+					Return(nil, entity001, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetTrialBalance(gomock.Any(), opts.ToTrialBalanceFilterOptions("002")).
+					Return(nil, entity002, nil)
+			},
+			check: func(t *testing.T, resp models.GetConsolidatedTrialBalanceResponse) {
+				assert.Equal(t, models.KindConsolidatedTrialBalance, resp.Kind)
+				assert.Equal(t, "2023-12-31", resp.ClosingDate)
+				assert.Len(t, resp.Eliminations, 2)
+				assert.Len(t, resp.Mismatches, 1)
+				assert.True(t, decimal.NewFromInt(10000).Equal(resp.Mismatches[0].Difference))
+
+				asset := resp.TrialBalance.COATypes[0]
+				assert.Len(t, asset.Categories, 1)
+				assert.True(t, decimal.NewFromInt(120000).Equal(asset.TotalClosingBalance))
+				assert.Empty(t, resp.TrialBalance.COATypes[1].Categories)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success case - consolidate every entity from gcs summary",
+			opts: models.ConsolidatedTrialBalanceFilterOptions{Period: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().List(gomock.Any()).Return(&[]models.Entity{{Code: "001"}}, nil)
+				testHelper.mockFlag.EXPECT().IsEnabled(models.FlagGetTrialBalanceGCS.String()).Return(true)
+				testHelper.mockMySQLRepository.EXPECT().
+					GetAllCategorySubCategoryCOAType(gomock.Any()).
+					Return(nil, nil, map[string]models.CategorySubCategoryCOAType{
+						"11101": {CoaTypeCode: "AST", CoaTypeName: "Asset", CategoryName: "Cash", SubCategoryName: "Cash in Bank"},
+					}, nil)
+				csvContent := "entity_code,category_code,sub_category_code,debit,credit,opening,closing\n001,111,11101,1000,0,0,1000"
+				testHelper.mockCloudStorageRepository.EXPECT().
+					NewReader(gomock.Any(), &models.CloudStoragePayload{Filename: "2023/001/summaries/12.csv", Path: string(models.TrialBalanceDir)}).
+					Return(io.NopCloser(bytes.NewReader([]byte(csvContent))), nil)
+			},
+			check: func(t *testing.T, resp models.GetConsolidatedTrialBalanceResponse) {
+				assert.Equal(t, []string{"001"}, resp.EntityCodes)
+				assert.Equal(t, "2023-12", resp.ClosingDate)
+				assert.Empty(t, resp.Mismatches)
+				assert.True(t, decimal.NewFromInt(1000).Equal(resp.TrialBalance.COATypes[0].TotalClosingBalance))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - entity code not found",
+			opts: opts,
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "001").Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - period is required for gcs",
+			opts: models.ConsolidatedTrialBalanceFilterOptions{EntityCodes: []string{"001"}},
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "001").Return(&models.Entity{Code: "001"}, nil)
+				testHelper.mockFlag.EXPECT().IsEnabled(models.FlagGetTrialBalanceGCS.String()).Return(true)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - get trial balance error",
+			opts: models.ConsolidatedTrialBalanceFilterOptions{EntityCodes: []string{"001"}, StartDate: startDate, EndDate: endDate},
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(gomock.Any(), "001").Return(&models.Entity{Code: "001"}, nil)
+				testHelper.mockFlag.EXPECT().IsEnabled(models.FlagGetTrialBalanceGCS.String()).Return(false)
+				testHelper.mockFlag.EXPECT().IsEnabled(models.FlagGetTrialBalanceV2.String()).Return(false)
+				testHelper.mockAcctRepository.EXPECT().GetTrialBalance(gomock.Any(), gomock.Any()).Return(nil, nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			resp, err := testHelper.accountingService.GetConsolidatedTrialBalance(context.Background(), tt.opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.check != nil {
+				tt.check(t, resp)
+			}
+		})
+	}
+}
@@ -155,7 +155,7 @@ func (as *accounting) GenerateAccountTrialBalanceDaily(ctx context.Context, date
 			accountsTrialBalance.CategoryCode = categoryCode
 			accountsTrialBalance.SubCategoryCode = subCategoryCode
 			accountsTrialBalance.ClosingBalance = accountsTrialBalance.OpeningBalance.Add(accountsTrialBalance.DebitMovement).Sub(accountsTrialBalance.CreditMovement)
-			if coaTypeCode == models.COATypeLiability {
+			if models.IsCreditNormalBalance(coaTypeCode) {
 				accountsTrialBalance.ClosingBalance = accountsTrialBalance.OpeningBalance.Add(accountsTrialBalance.CreditMovement).Sub(accountsTrialBalance.DebitMovement)
 			}
 			trialBalances = append(trialBalances, accountsTrialBalance)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -117,6 +118,8 @@ func (as *accounting) GenerateAccountDailyBalance(ctx context.Context, date time
 		accounts       []models.AccountBalanceDaily
 		errKeyNotFound = localstorage.ErrKeyNotFound
 		act            = as.srv.mySqlRepo.GetAccountingRepository()
+		// currencies found per entity and sub category, trial balance is generated per currency
+		tbCurrencies = map[string]map[string]struct{}{}
 	)
 
 	if err = storageAccounts.ForEach(func(accountNumber string, value models.GetAccountOut) error {
@@ -133,6 +136,10 @@ func (as *accounting) GenerateAccountDailyBalance(ctx context.Context, date time
 			EntityCode:      value.EntityCode,
 			CategoryCode:    value.CategoryCode,
 			SubCategoryCode: value.SubCategoryCode,
+			Currency:        value.Currency,
+		}
+		if accountBalanceDaily.Currency == "" {
+			accountBalanceDaily.Currency = models.CurrencyIDR
 		}
 		switch {
 		case errors.Is(errAdb, errKeyNotFound) &&
@@ -170,7 +177,13 @@ func (as *accounting) GenerateAccountDailyBalance(ctx context.Context, date time
 			return err
 		}
 
-		key := fmt.Sprintf("%s_%s", accountBalanceDaily.EntityCode, accountBalanceDaily.SubCategoryCode)
+		tbKey := fmt.Sprintf("%s_%s", accountBalanceDaily.EntityCode, accountBalanceDaily.SubCategoryCode)
+		if _, ok := tbCurrencies[tbKey]; !ok {
+			tbCurrencies[tbKey] = map[string]struct{}{}
+		}
+		tbCurrencies[tbKey][accountBalanceDaily.Currency] = struct{}{}
+
+		key := fmt.Sprintf("%s_%s", tbKey, accountBalanceDaily.Currency)
 		atb, err := storageAccountTrialBalance.Get(key)
 		if err != nil && !errors.Is(err, errKeyNotFound) {
 			return err
@@ -184,6 +197,7 @@ func (as *accounting) GenerateAccountDailyBalance(ctx context.Context, date time
 			CreditMovement:  accountBalanceDaily.CreditMovement.Add(atb.CreditMovement),
 			OpeningBalance:  accountBalanceDaily.OpeningBalance.Add(atb.OpeningBalance),
 			ClosingBalance:  accountBalanceDaily.ClosingBalance.Add(atb.ClosingBalance),
+			Currency:        accountBalanceDaily.Currency,
 		}
 		if err = storageAccountTrialBalance.Set(key, accountTrialBalance); err != nil {
 			return err
@@ -257,30 +271,48 @@ func (as *accounting) GenerateAccountDailyBalance(ctx context.Context, date time
 			coaTypeCode := mapSubCategory[subCategoryCode].CoaTypeCode
 			categoryCode := mapSubCategory[subCategoryCode].CategoryCode
 
-			key := fmt.Sprintf("%s_%s", entityCode, subCategoryCode)
-			accountsTrialBalance, err := storageAccountTrialBalance.Get(key)
-			if err != nil && !errors.Is(err, errKeyNotFound) {
-				return err
+			tbKey := fmt.Sprintf("%s_%s", entityCode, subCategoryCode)
+			currencies := make([]string, 0, len(tbCurrencies[tbKey]))
+			for currency := range tbCurrencies[tbKey] {
+				currencies = append(currencies, currency)
+			}
+			sort.Strings(currencies)
+			if len(currencies) == 0 {
+				// no account balance, still generate an empty row in the sub category currency
+				currency := v.Currency
+				if currency == "" {
+					currency = models.CurrencyIDR
+				}
+				currencies = append(currencies, currency)
 			}
-			// if errors.Is(err, errKeyNotFound) {
-			// 	xlog.Warn(ctx, "storageAccountTrialBalance",
-			// 		xlog.Time("date", date),
-			// 		xlog.String("category-code", categoryCode),
-			// 		xlog.String("sub-category-code", subCategoryCode),
-			// 		xlog.String("coa-type-code", coaTypeCode),
-			// 		xlog.Err(err),
-			// 	)
-			// }
-
-			accountsTrialBalance.ClosingDate = date
-			accountsTrialBalance.EntityCode = entityCode
-			accountsTrialBalance.CategoryCode = categoryCode
-			accountsTrialBalance.SubCategoryCode = subCategoryCode
-			accountsTrialBalance.ClosingBalance = accountsTrialBalance.OpeningBalance.Add(accountsTrialBalance.DebitMovement).Sub(accountsTrialBalance.CreditMovement)
-			if coaTypeCode == models.COATypeLiability {
-				accountsTrialBalance.ClosingBalance = accountsTrialBalance.OpeningBalance.Add(accountsTrialBalance.CreditMovement).Sub(accountsTrialBalance.DebitMovement)
+
+			for _, currency := range currencies {
+				key := fmt.Sprintf("%s_%s", tbKey, currency)
+				accountsTrialBalance, err := storageAccountTrialBalance.Get(key)
+				if err != nil && !errors.Is(err, errKeyNotFound) {
+					return err
+				}
+				// if errors.Is(err, errKeyNotFound) {
+				// 	xlog.Warn(ctx, "storageAccountTrialBalance",
+				// 		xlog.Time("date", date),
+				// 		xlog.String("category-code", categoryCode),
+				// 		xlog.String("sub-category-code", subCategoryCode),
+				// 		xlog.String("coa-type-code", coaTypeCode),
+				// 		xlog.Err(err),
+				// 	)
+				// }
+
+				accountsTrialBalance.ClosingDate = date
+				accountsTrialBalance.EntityCode = entityCode
+				accountsTrialBalance.CategoryCode = categoryCode
+				accountsTrialBalance.SubCategoryCode = subCategoryCode
+				accountsTrialBalance.Currency = currency
+				accountsTrialBalance.ClosingBalance = accountsTrialBalance.OpeningBalance.Add(accountsTrialBalance.DebitMovement).Sub(accountsTrialBalance.CreditMovement)
+				if models.IsCreditNormalBalance(coaTypeCode) {
+					accountsTrialBalance.ClosingBalance = accountsTrialBalance.OpeningBalance.Add(accountsTrialBalance.CreditMovement).Sub(accountsTrialBalance.DebitMovement)
+				}
+				trialBalances = append(trialBalances, accountsTrialBalance)
 			}
-			trialBalances = append(trialBalances, accountsTrialBalance)
 		}
 	}
 
@@ -468,7 +500,7 @@ func (as *accounting) processGetAndSetData(ctx context.Context, date time.Time,
 func (as *accounting) calculateClosingBalance(mapSubCategory map[string]models.CategorySubCategoryCOAType, accountBalanceDaily models.AccountBalanceDaily) models.AccountBalanceDaily {
 	coaTypeCode := mapSubCategory[accountBalanceDaily.SubCategoryCode].CoaTypeCode
 	accountBalanceDaily.ClosingBalance = accountBalanceDaily.OpeningBalance.Add(accountBalanceDaily.DebitMovement).Sub(accountBalanceDaily.CreditMovement)
-	if coaTypeCode == models.COATypeLiability {
+	if models.IsCreditNormalBalance(coaTypeCode) {
 		accountBalanceDaily.ClosingBalance = accountBalanceDaily.OpeningBalance.Add(accountBalanceDaily.CreditMovement).Sub(accountBalanceDaily.DebitMovement)
 	}
 	return accountBalanceDaily
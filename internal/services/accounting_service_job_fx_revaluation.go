@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/money"
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+/*
+go run cmd/job/main.go run -v=v1 -n=RevalueForeignCurrencyBalances -d=2024-01-31
+
+the date must be the end of a month, the revaluation is the difference to the rate of the previous month end
+so a revaluation in the middle of the month would be posted again by the month end run.
+
+1. get closing balance of non IDR accounts from acct_account_daily_balance, summed per entity, sub category & currency
+2. net the position per entity & currency, debit normal balance is positive and credit normal balance is negative
+3. get the rates of the date and of the previous month end from the rate source
+4. revaluation amount = position * (rate - previous rate), in IDR
+5. post the revaluation journal per entity & currency to the configured accounts through journal service,
+the transaction id is generated from entity, currency & date so the job can be re-run safely
+*/
+func (as *accounting) RevalueForeignCurrencyBalances(ctx context.Context, date time.Time) (err error) {
+	process := atime.Now()
+	date = atime.ToZeroTime(date)
+
+	logMessage := "[JOB-RevalueForeignCurrencyBalances]"
+	message := fmt.Sprintf("Job Revalue Foreign Currency Balances - %s", date.Format(atime.DateFormatYYYYMMDD))
+	defer func() {
+		logService(ctx, err)
+		elapsed := time.Since(process)
+		if err != nil {
+			as.sendMessageToSlack(ctx, message, err.Error())
+			xlog.Error(ctx, logMessage, xlog.String("description", message), xlog.Duration("elapsed-time", elapsed), xlog.Err(err))
+			return
+		}
+		as.sendMessageToSlack(ctx, message, fmt.Sprintf("Finished, Elapsed Time: %v", elapsed))
+		xlog.Info(ctx, logMessage, xlog.String("description", message), xlog.Duration("elapsed-time", elapsed))
+	}()
+
+	xlog.Info(ctx, logMessage, xlog.Time("date", date), xlog.Time("execution-date", process))
+	as.sendMessageToSlack(ctx, message, fmt.Sprintf("Starting with the execution date - %v", process))
+
+	if !atime.ToZeroTime(atime.EndOfMonth(date)).Equal(date) {
+		err = models.GetErrMap(models.ErrKeyInvalidFxRevaluationDate, date.Format(atime.DateFormatYYYYMMDD))
+		return
+	}
+
+	rateSource, err := as.newFXRateSource()
+	if err != nil {
+		return
+	}
+
+	balances, err := as.srv.mySqlRepo.GetAccountingRepository().GetForeignCurrencyClosingBalance(ctx, date, models.CurrencyIDR)
+	if err != nil {
+		err = checkDatabaseError(err)
+		return
+	}
+	if len(balances) == 0 {
+		xlog.Info(ctx, logMessage, xlog.String("description", "no foreign currency balance to revalue"))
+		return
+	}
+
+	_, _, mapSubCategory, err := as.srv.mySqlRepo.GetAllCategorySubCategoryCOAType(ctx)
+	if err != nil {
+		err = checkDatabaseError(err)
+		return
+	}
+
+	positions, err := as.netFXPositions(ctx, balances, mapSubCategory)
+	if err != nil {
+		return
+	}
+
+	rates, err := rateSource.GetRates(ctx, date)
+	if err != nil {
+		return
+	}
+	// the previous revaluation is done at the end of the previous month
+	previousDate := date.AddDate(0, 0, -date.Day())
+	previousRates, err := rateSource.GetRates(ctx, previousDate)
+	if err != nil {
+		return
+	}
+
+	for _, position := range positions {
+		if err = as.postFXRevaluation(ctx, date, position, rates, previousRates); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// netFXPositions sums the closing balances per entity & currency, the balances are converted from the minor unit of the currency.
+func (as *accounting) netFXPositions(ctx context.Context, balances []models.AccountBalanceDaily, mapSubCategory map[string]models.CategorySubCategoryCOAType) ([]models.FXRevaluationPosition, error) {
+	decimals := map[string]int{}
+	mapPosition := map[string]*models.FXRevaluationPosition{}
+	for _, v := range balances {
+		if _, ok := decimals[v.Currency]; !ok {
+			currency, err := as.srv.goDBLedger.GetCurrency(ctx, v.Currency)
+			if err != nil {
+				return nil, checkDatabaseError(err, models.ErrKeyCurrencyNotFound)
+			}
+			decimals[v.Currency] = currency.Decimals
+		}
+
+		amount := money.FormatBigIntToAmount(v.ClosingBalance, decimals[v.Currency])
+		if models.IsCreditNormalBalance(mapSubCategory[v.SubCategoryCode].CoaTypeCode) {
+			amount = amount.Neg()
+		}
+
+		key := fmt.Sprintf("%s_%s", v.EntityCode, v.Currency)
+		if _, ok := mapPosition[key]; !ok {
+			mapPosition[key] = &models.FXRevaluationPosition{
+				EntityCode: v.EntityCode,
+				Currency:   v.Currency,
+			}
+		}
+		mapPosition[key].Position = mapPosition[key].Position.Add(amount)
+	}
+
+	keys := make([]string, 0, len(mapPosition))
+	for key := range mapPosition {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	positions := make([]models.FXRevaluationPosition, 0, len(keys))
+	for _, key := range keys {
+		positions = append(positions, *mapPosition[key])
+	}
+
+	return positions, nil
+}
+
+func (as *accounting) postFXRevaluation(ctx context.Context, date time.Time, position models.FXRevaluationPosition, rates, previousRates map[string]decimal.Decimal) error {
+	rate, ok := rates[position.Currency]
+	if !ok {
+		return models.GetErrMap(models.ErrKeyFxRateNotFound, fmt.Sprintf("currency %s date %s", position.Currency, date.Format(atime.DateFormatYYYYMMDD)))
+	}
+	previousRate, ok := previousRates[position.Currency]
+	if !ok {
+		return models.GetErrMap(models.ErrKeyFxRateNotFound, fmt.Sprintf("currency %s date %s", position.Currency, date.AddDate(0, 0, -date.Day()).Format(atime.DateFormatYYYYMMDD)))
+	}
+
+	amount := position.Position.Mul(rate.Sub(previousRate)).Round(int32(CurrencyIDR.Decimals))
+	if amount.IsZero() {
+		return nil
+	}
+
+	accounts, ok := as.srv.conf.FXRevaluation.Accounts[position.EntityCode]
+	if !ok {
+		return models.GetErrMap(models.ErrKeyFxRevaluationAccountNotFound, fmt.Sprintf("entity %s", position.EntityCode))
+	}
+
+	transactionId := uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("fx-revaluation:%s:%s:%s", position.EntityCode, position.Currency, date.Format(atime.DateFormatYYYYMMDD)))).String()
+	isExist, err := as.srv.mySqlRepo.GetAccountingRepository().CheckTransactionIdIsExist(ctx, transactionId)
+	if err != nil {
+		return checkDatabaseError(err)
+	}
+	if isExist {
+		xlog.Info(ctx, "[FX-REVALUATION]", xlog.String("description", "already posted"), xlog.String("transaction-id", transactionId))
+		return nil
+	}
+
+	// gain debits the revaluation account, loss credits it
+	debitAccount, creditAccount := accounts.RevaluationAccount, accounts.GainAccount
+	if amount.IsNegative() {
+		debitAccount, creditAccount = accounts.LossAccount, accounts.RevaluationAccount
+		amount = amount.Abs()
+	}
+
+	narrative := fmt.Sprintf("FX Revaluation %s %s", position.Currency, date.Format(atime.DateFormatYYYYMMDD))
+	transactionType := as.srv.conf.FXRevaluation.TransactionType
+	req := models.JournalRequest{
+		ReferenceNumber: transactionId,
+		TransactionId:   transactionId,
+		OrderType:       as.srv.conf.FXRevaluation.OrderType,
+		TransactionDate: time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location()).Format(atime.DateFormatYYYYMMDDWithTime),
+		ProcessingDate:  atime.Now().Format(atime.DateFormatYYYYMMDDWithTime),
+		Currency:        CurrencyIDR.Name,
+		Transactions: []models.Transaction{
+			{
+				TransactionType: transactionType,
+				Account:         debitAccount,
+				Narrative:       narrative,
+				Amount:          amount,
+				IsDebit:         true,
+			},
+			{
+				TransactionType: transactionType,
+				Account:         creditAccount,
+				Narrative:       narrative,
+				Amount:          amount,
+				IsDebit:         false,
+			},
+		},
+		Metadata: &models.Metadata{
+			models.MetadataKeyFXCurrency:     position.Currency,
+			models.MetadataKeyFXRate:         rate.String(),
+			models.MetadataKeyFXPreviousRate: previousRate.String(),
+			models.MetadataKeyFXPosition:     position.Position.String(),
+		},
+	}
+
+	return as.srv.Journal.postJournalTransaction(ctx, req)
+}
+
+// fxRateSource provides the rates of foreign currencies in IDR at a date.
+type fxRateSource interface {
+	GetRates(ctx context.Context, date time.Time) (map[string]decimal.Decimal, error)
+}
+
+func (as *accounting) newFXRateSource() (fxRateSource, error) {
+	switch as.srv.conf.FXRevaluation.RateSource {
+	case models.FXRateSourceGCS:
+		return &gcsFXRateSource{srv: as.srv}, nil
+	case models.FXRateSourceLocal:
+		return &localFXRateSource{srv: as.srv}, nil
+	default:
+		return nil, models.GetErrMap(models.ErrKeyInvalidFxRateSource, as.srv.conf.FXRevaluation.RateSource)
+	}
+}
+
+// gcsFXRateSource reads the rate file from the cloud storage bucket.
+type gcsFXRateSource struct {
+	srv *Services
+}
+
+func (s *gcsFXRateSource) GetRates(ctx context.Context, date time.Time) (map[string]decimal.Decimal, error) {
+	payload := models.NewCloudStoragePayload(fxRateFilePath(s.srv.conf.FXRevaluation.RateFilePath, date))
+	reader, err := s.srv.cloudStorageRepo.NewReader(ctx, &payload)
+	if err != nil {
+		return nil, models.GetErrMap(models.ErrKeyFxRateNotFound, err.Error())
+	}
+	defer reader.Close()
+
+	return parseFXRates(s.srv, reader)
+}
+
+// localFXRateSource reads the rate file from the local file system.
+type localFXRateSource struct {
+	srv *Services
+}
+
+func (s *localFXRateSource) GetRates(ctx context.Context, date time.Time) (map[string]decimal.Decimal, error) {
+	file, err := s.srv.file.OpenFile(fxRateFilePath(s.srv.conf.FXRevaluation.RateFilePath, date))
+	if err != nil {
+		return nil, models.GetErrMap(models.ErrKeyFxRateNotFound, err.Error())
+	}
+	defer file.Close()
+
+	return parseFXRates(s.srv, file)
+}
+
+func fxRateFilePath(dir string, date time.Time) string {
+	return path.Join(dir, date.Format(atime.DateFormatYYYYMMDD)+".csv")
+}
+
+// parseFXRates reads a csv with header currency,rate where rate is the IDR value of one unit of the currency.
+func parseFXRates(srv *Services, reader io.Reader) (map[string]decimal.Decimal, error) {
+	records, err := srv.file.CSVReadAll(reader)
+	if err != nil {
+		return nil, models.GetErrMap(models.ErrKeyInvalidFxRateFile, err.Error())
+	}
+
+	rates := map[string]decimal.Decimal{}
+	for i, row := range records {
+		if i == 0 {
+			continue // skip header
+		}
+		if len(row) < 2 {
+			return nil, models.GetErrMap(models.ErrKeyInvalidFxRateFile, fmt.Sprintf("row %d must have currency and rate", i+1))
+		}
+
+		rate, err := decimal.NewFromString(strings.TrimSpace(row[1]))
+		if err != nil || !rate.IsPositive() {
+			return nil, models.GetErrMap(models.ErrKeyInvalidFxRateFile, fmt.Sprintf("row %d invalid rate %s", i+1, row[1]))
+		}
+		rates[strings.ToUpper(strings.TrimSpace(row[0]))] = rate
+	}
+
+	return rates, nil
+}
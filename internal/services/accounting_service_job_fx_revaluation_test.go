@@ -0,0 +1,244 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/config"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_accounting_RevalueForeignCurrencyBalances(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+	date, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-01-31")
+	_, _, mapSubCateg := dummyResponseGetAllCategorySubCategoryCOAType()
+
+	fxConfig := config.FXRevaluationConfig{
+		RateSource:      models.FXRateSourceGCS,
+		RateFilePath:    "fx_rates",
+		OrderType:       "FXR",
+		TransactionType: "FXREV",
+		Accounts: map[string]config.FXRevaluationAccountConfig{
+			"001": {
+				RevaluationAccount: "119001000000001",
+				GainAccount:        "411001000000001",
+				LossAccount:        "511001000000001",
+			},
+		},
+	}
+	balances := []models.AccountBalanceDaily{
+		{
+			EntityCode:      "001",
+			CategoryCode:    "114",
+			SubCategoryCode: "11403",
+			Currency:        "USD",
+			ClosingBalance:  decimal.NewFromInt(150000), // 1,500.00 USD
+		},
+		{
+			EntityCode:      "001",
+			CategoryCode:    "211",
+			SubCategoryCode: "21108",
+			Currency:        "USD",
+			ClosingBalance:  decimal.NewFromInt(50000), // 500.00 USD
+		},
+	}
+	mockRates := func(rate, previousRate string) {
+		testHelper.mockCloudStorageRepository.EXPECT().
+			NewReader(gomock.Any(), &models.CloudStoragePayload{Path: "fx_rates", Filename: "2024-01-31.csv"}).
+			Return(io.NopCloser(strings.NewReader("")), nil)
+		testHelper.mockCloudStorageRepository.EXPECT().
+			NewReader(gomock.Any(), &models.CloudStoragePayload{Path: "fx_rates", Filename: "2023-12-31.csv"}).
+			Return(io.NopCloser(strings.NewReader("")), nil)
+		gomock.InOrder(
+			testHelper.mockFile.EXPECT().
+				CSVReadAll(gomock.Any()).
+				Return([][]string{{"currency", "rate"}, {"USD", rate}}, nil),
+			testHelper.mockFile.EXPECT().
+				CSVReadAll(gomock.Any()).
+				Return([][]string{{"currency", "rate"}, {"USD", previousRate}}, nil),
+		)
+	}
+
+	tests := []struct {
+		name     string
+		date     string
+		fxConfig config.FXRevaluationConfig
+		doMock   func()
+		wantErr  bool
+	}{
+		{
+			name:     "success case - post gain to revaluation account",
+			fxConfig: fxConfig,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetForeignCurrencyClosingBalance(gomock.Any(), date, models.CurrencyIDR).
+					Return(balances, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					GetAllCategorySubCategoryCOAType(gomock.Any()).
+					Return(nil, nil, mapSubCateg, nil)
+				testHelper.mockGoDbLedger.EXPECT().
+					GetCurrency(gomock.Any(), "USD").
+					Return(godbledger.CurrencyUSD, nil)
+				mockRates("15600", "15500")
+
+				// position 1,000.00 USD * 100 = 100,000.00 IDR gain
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil).Times(2)
+				testHelper.mockGoDbLedger.EXPECT().
+					GetCurrency(gomock.Any(), models.CurrencyIDR).
+					Return(godbledger.CurrencyIDR, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "119001000000001").
+					Return(models.GetAccountOut{AccountNumber: "119001000000001", EntityCode: "001"}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "411001000000001").
+					Return(models.GetAccountOut{AccountNumber: "411001000000001", EntityCode: "001"}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2024-01", "001").
+					Return(&models.TrialBalancePeriod{Period: "2024-01", Status: models.TrialBalanceStatusOpen}, nil)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).Times(2)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().
+							InsertSplit(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, splits []models.CreateSplit) error {
+								assert.Equal(t, int64(10000000), splits[0].Amount)
+								return nil
+							})
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertJournalDetail(gomock.Any(), gomock.Any()).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(2)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "success case - already posted",
+			fxConfig: fxConfig,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetForeignCurrencyClosingBalance(gomock.Any(), date, models.CurrencyIDR).
+					Return(balances, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					GetAllCategorySubCategoryCOAType(gomock.Any()).
+					Return(nil, nil, mapSubCateg, nil)
+				testHelper.mockGoDbLedger.EXPECT().
+					GetCurrency(gomock.Any(), "USD").
+					Return(godbledger.CurrencyUSD, nil)
+				mockRates("15400", "15500")
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(true, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "success case - no foreign currency balance",
+			fxConfig: fxConfig,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetForeignCurrencyClosingBalance(gomock.Any(), date, models.CurrencyIDR).
+					Return(nil, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:     "error case - date is not the end of a month",
+			date:     "2024-01-15",
+			fxConfig: fxConfig,
+			wantErr:  true,
+		},
+		{
+			name:     "error case - invalid rate source",
+			fxConfig: config.FXRevaluationConfig{RateSource: "ftp"},
+			wantErr:  true,
+		},
+		{
+			name:     "error case - database error get closing balance",
+			fxConfig: fxConfig,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetForeignCurrencyClosingBalance(gomock.Any(), date, models.CurrencyIDR).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "error case - rate not found",
+			fxConfig: fxConfig,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetForeignCurrencyClosingBalance(gomock.Any(), date, models.CurrencyIDR).
+					Return(balances, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					GetAllCategorySubCategoryCOAType(gomock.Any()).
+					Return(nil, nil, mapSubCateg, nil)
+				testHelper.mockGoDbLedger.EXPECT().
+					GetCurrency(gomock.Any(), "USD").
+					Return(godbledger.CurrencyUSD, nil)
+				testHelper.mockCloudStorageRepository.EXPECT().
+					NewReader(gomock.Any(), gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - revaluation account not configured",
+			fxConfig: config.FXRevaluationConfig{
+				RateSource:   models.FXRateSourceGCS,
+				RateFilePath: "fx_rates",
+			},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetForeignCurrencyClosingBalance(gomock.Any(), date, models.CurrencyIDR).
+					Return(balances, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					GetAllCategorySubCategoryCOAType(gomock.Any()).
+					Return(nil, nil, mapSubCateg, nil)
+				testHelper.mockGoDbLedger.EXPECT().
+					GetCurrency(gomock.Any(), "USD").
+					Return(godbledger.CurrencyUSD, nil)
+				mockRates("15600", "15500")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			testHelper.config.FXRevaluation = tt.fxConfig
+			testHelper.mockDDDNotification.EXPECT().
+				SendMessageToSlack(gomock.Any(), gomock.Any()).
+				Return(nil).AnyTimes()
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			runDate := date
+			if tt.date != "" {
+				runDate, _ = atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, tt.date)
+			}
+
+			err := testHelper.accountingService.RevalueForeignCurrencyBalances(ctx, runDate)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/shopspring/decimal"
+)
+
+/*
+ENTITY_CODE=001 DATE_END=2024-01-31 go run cmd/job/main.go run -v=v1 -n=CheckLedgerIntegrity -d=2024-01-01
+
+1. recompute the closing balance of every account of the entity from the splits before the start date
+2. for every date, add the splits of the date into the closing balances then compare them with acct_account_daily_balance,
+the closing balances are also summed per sub category & currency then compared with acct_account_trial_balance
+3. check every transaction of the dates nets to zero per currency
+4. write the discrepancies into a csv report in gcs & send the summary to slack
+*/
+func (as *accounting) CheckLedgerIntegrity(ctx context.Context, in models.LedgerIntegrityRequest) (out models.LedgerIntegrityReport, err error) {
+	process := atime.Now()
+	startDate, endDate := atime.ToZeroTime(in.StartDate), atime.ToZeroTime(in.EndDate)
+
+	logMessage := "[JOB-CheckLedgerIntegrity]"
+	message := fmt.Sprintf("Job Check Ledger Integrity %s - %s to %s", in.EntityCode, startDate.Format(atime.DateFormatYYYYMMDD), endDate.Format(atime.DateFormatYYYYMMDD))
+	defer func() {
+		logService(ctx, err)
+		elapsed := time.Since(process)
+		if err != nil {
+			as.sendMessageToSlack(ctx, message, err.Error())
+			xlog.Error(ctx, logMessage, xlog.String("description", message), xlog.Duration("elapsed-time", elapsed), xlog.Err(err))
+			return
+		}
+		as.sendMessageToSlack(ctx, message, fmt.Sprintf("Finished, Accounts: %d, Daily Balance: %d, Trial Balance: %d, Transaction: %d, Report: %s, Elapsed Time: %v",
+			out.Accounts,
+			out.Count(models.LedgerIntegrityCheckDailyBalance),
+			out.Count(models.LedgerIntegrityCheckTrialBalance),
+			out.Count(models.LedgerIntegrityCheckTransaction),
+			out.FilePath,
+			elapsed,
+		))
+		xlog.Info(ctx, logMessage, xlog.String("description", message), xlog.Int("discrepancies", len(out.Discrepancies)), xlog.Duration("elapsed-time", elapsed))
+	}()
+
+	if in.EntityCode == "" {
+		err = models.GetErrMap(models.ErrKeyEntityCodeRequired)
+		return
+	}
+	dates, errDates := atime.GenerateNextDate(startDate, endDate)
+	if errDates != nil {
+		err = models.GetErrMap(models.ErrKeyStartDateIsAfterEndDate)
+		return
+	}
+
+	out = models.LedgerIntegrityReport{
+		EntityCode: in.EntityCode,
+		StartDate:  startDate,
+		EndDate:    endDate,
+	}
+
+	_, _, mapSubCategory, err := as.srv.mySqlRepo.GetAllCategorySubCategoryCOAType(ctx)
+	if err != nil {
+		return
+	}
+
+	act := as.srv.mySqlRepo.GetAccountingRepository()
+	movements, err := act.GetLedgerAccountMovements(ctx, in.EntityCode, time.Time{}, startDate)
+	if err != nil {
+		return
+	}
+	balances := map[string]models.AccountBalanceDaily{}
+	as.applyLedgerMovements(mapSubCategory, balances, movements)
+
+	for _, date := range dates {
+		movements, err = act.GetLedgerAccountMovements(ctx, in.EntityCode, date, date.AddDate(0, 0, 1))
+		if err != nil {
+			return
+		}
+		as.applyLedgerMovements(mapSubCategory, balances, movements)
+
+		dailyBalances, errGet := act.GetAccountDailyBalanceByEntity(ctx, in.EntityCode, date)
+		if errGet != nil {
+			err = errGet
+			return
+		}
+		out.Discrepancies = append(out.Discrepancies, compareLedgerDailyBalances(in.EntityCode, date, balances, dailyBalances)...)
+
+		trialBalances, errGet := act.GetAccountTrialBalanceByEntity(ctx, in.EntityCode, date)
+		if errGet != nil {
+			err = errGet
+			return
+		}
+		out.Discrepancies = append(out.Discrepancies, compareLedgerTrialBalances(in.EntityCode, date, balances, trialBalances)...)
+	}
+	out.Accounts = len(balances)
+
+	transactions, err := act.GetUnbalancedTransactions(ctx, in.EntityCode, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return
+	}
+	for _, v := range transactions {
+		out.Discrepancies = append(out.Discrepancies, models.LedgerIntegrityDiscrepancy{
+			Check:         models.LedgerIntegrityCheckTransaction,
+			Date:          atime.ToZeroTime(v.TransactionDate),
+			EntityCode:    in.EntityCode,
+			TransactionId: v.TransactionId,
+			Currency:      v.Currency,
+			Expected:      v.Debit,
+			Actual:        v.Credit,
+		})
+	}
+
+	out.FilePath, err = as.writeLedgerIntegrityReport(ctx, out)
+	if err != nil {
+		return
+	}
+
+	return out, nil
+}
+
+// applyLedgerMovements adds the movements into the closing balance of the accounts, the closing balance of
+// an account without movement stays the same.
+func (as *accounting) applyLedgerMovements(mapSubCategory map[string]models.CategorySubCategoryCOAType, balances map[string]models.AccountBalanceDaily, movements []models.LedgerAccountMovement) {
+	for _, v := range movements {
+		balance := balances[v.AccountNumber]
+		balance.AccountNumber = v.AccountNumber
+		balance.EntityCode = v.EntityCode
+		balance.CategoryCode = v.CategoryCode
+		balance.SubCategoryCode = v.SubCategoryCode
+		balance.Currency = v.Currency
+		if balance.Currency == "" {
+			balance.Currency = models.CurrencyIDR
+		}
+		balance.OpeningBalance = balance.ClosingBalance
+		balance.DebitMovement = v.DebitMovement
+		balance.CreditMovement = v.CreditMovement
+		balances[v.AccountNumber] = as.calculateClosingBalance(mapSubCategory, balance)
+	}
+}
+
+// compareLedgerDailyBalances returns the accounts whose stored closing balance is different from the recomputed one,
+// a missing stored balance is zero.
+func compareLedgerDailyBalances(entityCode string, date time.Time, balances map[string]models.AccountBalanceDaily, dailyBalances []models.AccountBalanceDaily) (out []models.LedgerIntegrityDiscrepancy) {
+	stored := make(map[string]models.AccountBalanceDaily, len(dailyBalances))
+	for _, v := range dailyBalances {
+		stored[v.AccountNumber] = v
+	}
+
+	accountNumbers := make([]string, 0, len(balances))
+	for k := range balances {
+		accountNumbers = append(accountNumbers, k)
+	}
+	for k := range stored {
+		if _, ok := balances[k]; !ok {
+			accountNumbers = append(accountNumbers, k)
+		}
+	}
+	sort.Strings(accountNumbers)
+
+	for _, accountNumber := range accountNumbers {
+		expected, actual := balances[accountNumber], stored[accountNumber]
+		if expected.ClosingBalance.Equal(actual.ClosingBalance) {
+			continue
+		}
+
+		account := expected
+		if account.AccountNumber == "" {
+			account = actual
+		}
+		out = append(out, models.LedgerIntegrityDiscrepancy{
+			Check:           models.LedgerIntegrityCheckDailyBalance,
+			Date:            date,
+			EntityCode:      entityCode,
+			SubCategoryCode: account.SubCategoryCode,
+			AccountNumber:   accountNumber,
+			Currency:        account.Currency,
+			Expected:        expected.ClosingBalance,
+			Actual:          actual.ClosingBalance,
+		})
+	}
+
+	return out
+}
+
+// compareLedgerTrialBalances sums the recomputed closing balances per sub category & currency then returns
+// the ones which are different from the stored trial balance.
+func compareLedgerTrialBalances(entityCode string, date time.Time, balances map[string]models.AccountBalanceDaily, trialBalances []models.AccountTrialBalance) (out []models.LedgerIntegrityDiscrepancy) {
+	type trialBalanceKey struct {
+		subCategoryCode string
+		currency        string
+	}
+
+	expected := map[trialBalanceKey]decimal.Decimal{}
+	for _, v := range balances {
+		key := trialBalanceKey{v.SubCategoryCode, v.Currency}
+		expected[key] = expected[key].Add(v.ClosingBalance)
+	}
+	actual := map[trialBalanceKey]decimal.Decimal{}
+	for _, v := range trialBalances {
+		currency := v.Currency
+		if currency == "" {
+			currency = models.CurrencyIDR
+		}
+		key := trialBalanceKey{v.SubCategoryCode, currency}
+		actual[key] = actual[key].Add(v.ClosingBalance)
+	}
+
+	keys := make([]trialBalanceKey, 0, len(expected))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	for k := range actual {
+		if _, ok := expected[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].subCategoryCode == keys[j].subCategoryCode {
+			return keys[i].currency < keys[j].currency
+		}
+		return keys[i].subCategoryCode < keys[j].subCategoryCode
+	})
+
+	for _, k := range keys {
+		if expected[k].Equal(actual[k]) {
+			continue
+		}
+		out = append(out, models.LedgerIntegrityDiscrepancy{
+			Check:           models.LedgerIntegrityCheckTrialBalance,
+			Date:            date,
+			EntityCode:      entityCode,
+			SubCategoryCode: k.subCategoryCode,
+			Currency:        k.currency,
+			Expected:        expected[k],
+			Actual:          actual[k],
+		})
+	}
+
+	return out
+}
+
+// writeLedgerIntegrityReport writes every discrepancy of the report into gcs, the file is written even without
+// discrepancy so every run has a report.
+func (as *accounting) writeLedgerIntegrityReport(ctx context.Context, report models.LedgerIntegrityReport) (filePath string, err error) {
+	fp := models.CloudStoragePayload{
+		Filename: fmt.Sprintf("ledgerIntegrity-%s-%s-%s.csv",
+			report.EntityCode,
+			report.StartDate.Format(atime.DateFormatYYYYMMDD),
+			report.EndDate.Format(atime.DateFormatYYYYMMDD),
+		),
+		Path: string(models.LedgerIntegrityDir),
+	}
+
+	body := make([][]string, 0, len(report.Discrepancies))
+	for _, v := range report.Discrepancies {
+		body = append(body, v.ToRecord())
+	}
+
+	w := as.srv.cloudStorageRepo.NewWriter(ctx, &fp)
+	as.srv.file.NewCSVWriter(w)
+	if err = as.srv.file.CSVWriteHeader(ctx, models.LedgerIntegrityReportHeader); err != nil {
+		w.Close()
+		return "", fmt.Errorf("failed to write header: %w", err)
+	}
+	if err = as.srv.file.CSVWriteAll(ctx, body); err != nil {
+		w.Close()
+		return "", fmt.Errorf("failed to write body: %w", err)
+	}
+	if err = as.srv.file.CSVProcessWrite(ctx); err != nil {
+		w.Close()
+		return "", err
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+
+	return fp.GetFilePath(), nil
+}
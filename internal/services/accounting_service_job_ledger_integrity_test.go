@@ -0,0 +1,226 @@
+package services_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_accounting_CheckLedgerIntegrity(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+	startDate := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 0, 1)
+	_, _, mapSubCateg := dummyResponseGetAllCategorySubCategoryCOAType()
+	testHelper.mockDDDNotification.EXPECT().SendMessageToSlack(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	movement := func(accountNumber, subCategoryCode string, debit, credit int64) models.LedgerAccountMovement {
+		return models.LedgerAccountMovement{
+			AccountNumber:   accountNumber,
+			EntityCode:      "001",
+			SubCategoryCode: subCategoryCode,
+			Currency:        models.CurrencyIDR,
+			DebitMovement:   decimal.NewFromInt(debit),
+			CreditMovement:  decimal.NewFromInt(credit),
+		}
+	}
+	dailyBalance := func(accountNumber, subCategoryCode string, closingBalance int64) models.AccountBalanceDaily {
+		return models.AccountBalanceDaily{
+			AccountNumber:   accountNumber,
+			EntityCode:      "001",
+			SubCategoryCode: subCategoryCode,
+			Currency:        models.CurrencyIDR,
+			ClosingBalance:  decimal.NewFromInt(closingBalance),
+		}
+	}
+	trialBalance := func(subCategoryCode string, closingBalance int64) models.AccountTrialBalance {
+		return models.AccountTrialBalance{
+			EntityCode:      "001",
+			SubCategoryCode: subCategoryCode,
+			Currency:        models.CurrencyIDR,
+			ClosingBalance:  decimal.NewFromInt(closingBalance),
+		}
+	}
+	writeReport := func(check func(body [][]string)) {
+		tempFile, _ := os.CreateTemp("", "test_mock_gcs")
+		testHelper.mockCloudStorageRepository.EXPECT().
+			NewWriter(gomock.Any(), &models.CloudStoragePayload{Path: "ledger_integrity", Filename: "ledgerIntegrity-001-2024-04-01-2024-04-02.csv"}).
+			Return(tempFile)
+		testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+		testHelper.mockFile.EXPECT().CSVWriteHeader(gomock.Any(), models.LedgerIntegrityReportHeader).Return(nil)
+		testHelper.mockFile.EXPECT().CSVWriteAll(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, body [][]string) error {
+				check(body)
+				return nil
+			})
+		testHelper.mockFile.EXPECT().CSVProcessWrite(gomock.Any()).Return(nil)
+	}
+
+	tests := []struct {
+		name    string
+		in      models.LedgerIntegrityRequest
+		doMock  func()
+		check   func(t *testing.T, out models.LedgerIntegrityReport)
+		wantErr bool
+	}{
+		{
+			name: "success case - report the discrepancies of the daily balance, the trial balance & the transaction",
+			in:   models.LedgerIntegrityRequest{EntityCode: "001", StartDate: startDate, EndDate: endDate},
+			doMock: func() {
+				testHelper.mockMySQLRepository.EXPECT().
+					GetAllCategorySubCategoryCOAType(gomock.Any()).
+					Return(nil, nil, mapSubCateg, nil)
+				gomock.InOrder(
+					testHelper.mockAcctRepository.EXPECT().
+						GetLedgerAccountMovements(gomock.Any(), "001", time.Time{}, startDate).
+						Return([]models.LedgerAccountMovement{
+							movement("114030000000001", "11403", 100000, 0),
+							movement("211080000000001", "21108", 0, 100000),
+						}, nil),
+					testHelper.mockAcctRepository.EXPECT().
+						GetLedgerAccountMovements(gomock.Any(), "001", startDate, endDate).
+						Return([]models.LedgerAccountMovement{
+							movement("114030000000001", "11403", 50000, 0),
+							movement("211080000000001", "21108", 0, 50000),
+						}, nil),
+					testHelper.mockAcctRepository.EXPECT().
+						GetLedgerAccountMovements(gomock.Any(), "001", endDate, endDate.AddDate(0, 0, 1)).
+						Return(nil, nil),
+				)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountDailyBalanceByEntity(gomock.Any(), "001", startDate).
+					Return([]models.AccountBalanceDaily{
+						dailyBalance("114030000000001", "11403", 150000),
+						dailyBalance("211080000000001", "21108", 150000),
+					}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountDailyBalanceByEntity(gomock.Any(), "001", endDate).
+					Return([]models.AccountBalanceDaily{
+						dailyBalance("114030000000001", "11403", 150000),
+						dailyBalance("114030000000002", "11403", 1000),
+						dailyBalance("211080000000001", "21108", 140000),
+					}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountTrialBalanceByEntity(gomock.Any(), "001", startDate).
+					Return([]models.AccountTrialBalance{trialBalance("11403", 150000), trialBalance("21108", 150000)}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountTrialBalanceByEntity(gomock.Any(), "001", endDate).
+					Return([]models.AccountTrialBalance{trialBalance("11403", 151000), trialBalance("21108", 150000)}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetUnbalancedTransactions(gomock.Any(), "001", startDate, endDate.AddDate(0, 0, 1)).
+					Return([]models.UnbalancedTransaction{{
+						TransactionId:   "6de11650-dbee-4f67-9ade-ececc7a02571",
+						TransactionDate: time.Date(2024, time.April, 2, 9, 0, 0, 0, time.Local),
+						Currency:        models.CurrencyIDR,
+						Debit:           decimal.NewFromInt(100000),
+						Credit:          decimal.NewFromInt(90000),
+					}}, nil)
+				writeReport(func(body [][]string) {
+					assert.Equal(t, [][]string{
+						{"dailyBalance", "2024-04-02", "001", "11403", "114030000000002", "", "IDR", "0", "1000", "1000"},
+						{"dailyBalance", "2024-04-02", "001", "21108", "211080000000001", "", "IDR", "150000", "140000", "-10000"},
+						{"trialBalance", "2024-04-02", "001", "11403", "", "", "IDR", "150000", "151000", "1000"},
+						{"transaction", "2024-04-02", "001", "", "", "6de11650-dbee-4f67-9ade-ececc7a02571", "IDR", "100000", "90000", "-10000"},
+					}, body)
+				})
+			},
+			check: func(t *testing.T, out models.LedgerIntegrityReport) {
+				assert.Equal(t, 2, out.Accounts)
+				assert.Equal(t, 2, out.Count(models.LedgerIntegrityCheckDailyBalance))
+				assert.Equal(t, 1, out.Count(models.LedgerIntegrityCheckTrialBalance))
+				assert.Equal(t, 1, out.Count(models.LedgerIntegrityCheckTransaction))
+				assert.Equal(t, "ledger_integrity/ledgerIntegrity-001-2024-04-01-2024-04-02.csv", out.FilePath)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "error case - entity code is required",
+			in:      models.LedgerIntegrityRequest{StartDate: startDate, EndDate: endDate},
+			wantErr: true,
+		},
+		{
+			name:    "error case - start date is after end date",
+			in:      models.LedgerIntegrityRequest{EntityCode: "001", StartDate: endDate, EndDate: startDate},
+			wantErr: true,
+		},
+		{
+			name: "error case - get ledger account movements",
+			in:   models.LedgerIntegrityRequest{EntityCode: "001", StartDate: startDate, EndDate: endDate},
+			doMock: func() {
+				testHelper.mockMySQLRepository.EXPECT().
+					GetAllCategorySubCategoryCOAType(gomock.Any()).
+					Return(nil, nil, mapSubCateg, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetLedgerAccountMovements(gomock.Any(), "001", time.Time{}, startDate).
+					Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - get account trial balance",
+			in:   models.LedgerIntegrityRequest{EntityCode: "001", StartDate: startDate, EndDate: startDate},
+			doMock: func() {
+				testHelper.mockMySQLRepository.EXPECT().
+					GetAllCategorySubCategoryCOAType(gomock.Any()).
+					Return(nil, nil, mapSubCateg, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetLedgerAccountMovements(gomock.Any(), "001", gomock.Any(), gomock.Any()).
+					Return(nil, nil).Times(2)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountDailyBalanceByEntity(gomock.Any(), "001", startDate).
+					Return(nil, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountTrialBalanceByEntity(gomock.Any(), "001", startDate).
+					Return(nil, models.GetErrMap(models.ErrKeyDatabaseError))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - write report",
+			in:   models.LedgerIntegrityRequest{EntityCode: "001", StartDate: startDate, EndDate: endDate},
+			doMock: func() {
+				testHelper.mockMySQLRepository.EXPECT().
+					GetAllCategorySubCategoryCOAType(gomock.Any()).
+					Return(nil, nil, mapSubCateg, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetLedgerAccountMovements(gomock.Any(), "001", gomock.Any(), gomock.Any()).
+					Return(nil, nil).Times(3)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountDailyBalanceByEntity(gomock.Any(), "001", gomock.Any()).
+					Return(nil, nil).Times(2)
+				testHelper.mockAcctRepository.EXPECT().
+					GetAccountTrialBalanceByEntity(gomock.Any(), "001", gomock.Any()).
+					Return(nil, nil).Times(2)
+				testHelper.mockAcctRepository.EXPECT().
+					GetUnbalancedTransactions(gomock.Any(), "001", startDate, endDate.AddDate(0, 0, 1)).
+					Return(nil, nil)
+				tempFile, _ := os.CreateTemp("", "test_mock_gcs")
+				testHelper.mockCloudStorageRepository.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(tempFile)
+				testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+				testHelper.mockFile.EXPECT().CSVWriteHeader(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			out, err := testHelper.accountingService.CheckLedgerIntegrity(ctx, tt.in)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.check != nil {
+				tt.check(t, out)
+			}
+		})
+	}
+}
@@ -231,6 +231,8 @@ func (as *accounting) csvProcessSubLedger(ctx context.Context,
 	modCalculate := make(map[string]calculateMethod)
 	modCalculate[models.COATypeAsset] = calculateClosingBalanceAsset{}
 	modCalculate[models.COATypeLiability] = calculateClosingBalanceLiability{}
+	modCalculate[models.COATypeIncome] = calculateClosingBalanceLiability{}
+	modCalculate[models.COATypeExpense] = calculateClosingBalanceAsset{}
 
 	mapOrderTypeCodeName, mapTrxTypeCodeName, err := as.getAllMapOrderTrxType(ctx)
 	if err != nil {
@@ -327,6 +329,8 @@ func (as *accounting) csvProcessAndSendEmail(ctx context.Context,
 	modCalculate := make(map[string]calculateMethod)
 	modCalculate[models.COATypeAsset] = calculateClosingBalanceAsset{}
 	modCalculate[models.COATypeLiability] = calculateClosingBalanceLiability{}
+	modCalculate[models.COATypeIncome] = calculateClosingBalanceLiability{}
+	modCalculate[models.COATypeExpense] = calculateClosingBalanceAsset{}
 
 	mapOrderTypeCodeName, mapTrxTypeCodeName, err := as.getAllMapOrderTrxType(ctx)
 	if err != nil {
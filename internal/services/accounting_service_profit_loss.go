@@ -0,0 +1,204 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/money"
+	"github.com/hashicorp/go-multierror"
+	"github.com/shopspring/decimal"
+)
+
+/*
+1. validate entity code
+2. sum debit & credit movement of income & expense sub categories from acct_account_trial_balance within the date range,
+the movements of the year end closing journals are excluded
+3. group per category & sub category
+- revenue = credit - debit
+- expense = debit - credit
+4. net income = total revenue - total expense
+*/
+func (as *accounting) GetProfitLoss(ctx context.Context, opts models.ProfitLossFilterOptions) (resp models.GetProfitLossResponse, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	entity, err := as.srv.mySqlRepo.GetEntityRepository().GetByCode(ctx, opts.EntityCode)
+	if err != nil {
+		return
+	}
+
+	if entity == nil {
+		err = models.GetErrMap(models.ErrKeyEntityCodeNotFound)
+		return
+	}
+
+	opts.ClosingOrderType = as.srv.conf.YearEndClosing.OrderType
+	out, err := as.srv.mySqlRepo.GetAccountingRepository().GetProfitLoss(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	revenues, expenses := []models.ProfitLossCategory{}, []models.ProfitLossCategory{}
+	for _, v := range out {
+		amount := v.DebitMovement.Sub(v.CreditMovement)
+		categories := &expenses
+		if models.IsCreditNormalBalance(v.COAType) {
+			amount = v.CreditMovement.Sub(v.DebitMovement)
+			categories = &revenues
+		}
+
+		// rows are ordered by category, so the sub category belongs to the last category when the code is the same
+		if len(*categories) == 0 || (*categories)[len(*categories)-1].CategoryCode != v.CategoryCode {
+			*categories = append(*categories, models.ProfitLossCategory{
+				CategoryCode:  v.CategoryCode,
+				CategoryName:  v.CategoryName,
+				SubCategories: []models.ProfitLossSubCategory{},
+			})
+		}
+		category := &(*categories)[len(*categories)-1]
+		category.DecAmount = category.DecAmount.Add(amount)
+		category.Amount = money.FormatAmountToIDRFromDecimal(category.DecAmount)
+		category.SubCategories = append(category.SubCategories, models.ProfitLossSubCategory{
+			SubCategoryCode: v.SubCategoryCode,
+			SubCategoryName: v.SubCategoryName,
+			Amount:          money.FormatAmountToIDRFromDecimal(amount),
+			DecAmount:       amount,
+		})
+	}
+
+	totalRevenue, totalExpense := decimal.Zero, decimal.Zero
+	for _, v := range revenues {
+		totalRevenue = totalRevenue.Add(v.DecAmount)
+	}
+	for _, v := range expenses {
+		totalExpense = totalExpense.Add(v.DecAmount)
+	}
+	netIncome := totalRevenue.Sub(totalExpense)
+
+	resp = models.GetProfitLossResponse{
+		Kind:       models.KindProfitLoss,
+		EntityCode: opts.EntityCode,
+		EntityName: entity.Name,
+		EntityDesc: entity.Description,
+		StartDate:  opts.StartDate.Format(atime.DateFormatYYYYMMDD),
+		EndDate:    opts.EndDate.Format(atime.DateFormatYYYYMMDD),
+
+		Revenues:        revenues,
+		TotalRevenue:    money.FormatAmountToIDRFromDecimal(totalRevenue),
+		DecTotalRevenue: totalRevenue,
+
+		Expenses:        expenses,
+		TotalExpense:    money.FormatAmountToIDRFromDecimal(totalExpense),
+		DecTotalExpense: totalExpense,
+
+		NetIncome:    money.FormatAmountToIDRFromDecimal(netIncome),
+		DecNetIncome: netIncome,
+	}
+
+	return
+}
+
+func (as *accounting) DownloadCSVGetProfitLoss(ctx context.Context, opts models.ProfitLossFilterOptions, resp models.GetProfitLossResponse) (b *bytes.Buffer, filename string, err error) {
+	currency, err := as.getReportCurrency(ctx, opts.Currency)
+	if err != nil {
+		return
+	}
+
+	b = &bytes.Buffer{}
+	as.srv.file.NewCSVWriter(b)
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{resp.EntityDesc}); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"PROFIT AND LOSS REPORT"}); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{fmt.Sprintf("FOR THE PERIOD %s TO %s",
+		strings.ToUpper(opts.StartDate.Format(atime.DateFormatDDMMMYYYYWithSpace)),
+		strings.ToUpper(opts.EndDate.Format(atime.DateFormatDDMMMYYYYWithSpace)),
+	)}); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{}); err != nil {
+		return
+	}
+
+	if err = as.writeCSVProfitLossSection(ctx, "REVENUES", "A", resp.Revenues, currency); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"TOTAL REVENUES", money.FormatBigIntToAmount(resp.DecTotalRevenue, currency.Decimals).String()}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.writeCSVProfitLossSection(ctx, "EXPENSES", "B", resp.Expenses, currency); err != nil {
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"TOTAL EXPENSES", money.FormatBigIntToAmount(resp.DecTotalExpense, currency.Decimals).String()}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVWriteBody(ctx, []string{"NET INCOME", money.FormatBigIntToAmount(resp.DecNetIncome, currency.Decimals).String()}); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = as.srv.file.CSVProcessWrite(ctx); err != nil {
+		return
+	}
+
+	filename = fmt.Sprintf("Profit-Loss-%s-%s-%s.csv",
+		resp.EntityName,
+		opts.StartDate.Format(atime.DateFormatYYYYMMDDWithoutDash),
+		opts.EndDate.Format(atime.DateFormatYYYYMMDDWithoutDash),
+	)
+
+	return
+}
+
+func (as *accounting) writeCSVProfitLossSection(ctx context.Context, title, prefix string, categories []models.ProfitLossCategory, currency godbledger.Currency) error {
+	var errs *multierror.Error
+	if err := as.srv.file.CSVWriteBody(ctx, []string{title, currencyLabel(currency)}); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	for _, c := range categories {
+		if err := as.srv.file.CSVWriteBody(ctx, []string{
+			fmt.Sprintf("%s.%s %s", prefix, c.CategoryCode, c.CategoryName),
+			money.FormatBigIntToAmount(c.DecAmount, currency.Decimals).String(),
+		}); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		for _, sc := range c.SubCategories {
+			if err := as.srv.file.CSVWriteBody(ctx, []string{
+				fmt.Sprintf("    %s %s", sc.SubCategoryCode, sc.SubCategoryName),
+				money.FormatBigIntToAmount(sc.DecAmount, currency.Decimals).String(),
+			}); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
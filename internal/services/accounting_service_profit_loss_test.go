@@ -0,0 +1,247 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
+	"go.uber.org/mock/gomock"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_accounting_GetProfitLoss(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	startDate, endDate := atime.PrevMonth(atime.Now())
+	defaultData := []models.ProfitLossPerSubCategoryOut{
+		{
+			COAType:         models.COATypeIncome,
+			CategoryCode:    "411",
+			CategoryName:    "Interest Income",
+			SubCategoryCode: "41101",
+			SubCategoryName: "Interest Income Group Loan",
+			DebitMovement:   decimal.NewFromInt(10000),
+			CreditMovement:  decimal.NewFromInt(510000),
+		},
+		{
+			COAType:         models.COATypeIncome,
+			CategoryCode:    "411",
+			CategoryName:    "Interest Income",
+			SubCategoryCode: "41102",
+			SubCategoryName: "Interest Income Individual Loan",
+			CreditMovement:  decimal.NewFromInt(200000),
+		},
+		{
+			COAType:         models.COATypeExpense,
+			CategoryCode:    "511",
+			CategoryName:    "Operational Expense",
+			SubCategoryCode: "51101",
+			SubCategoryName: "Salary Expense",
+			DebitMovement:   decimal.NewFromInt(300000),
+		},
+	}
+
+	type args struct {
+		ctx context.Context
+		req models.ProfitLossFilterOptions
+	}
+	testCases := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		want    models.GetProfitLossResponse
+		wantErr bool
+	}{
+		{
+			name: "success case - get profit loss",
+			args: args{
+				ctx: context.Background(),
+				req: models.ProfitLossFilterOptions{
+					EntityCode: "111",
+					StartDate:  startDate,
+					EndDate:    endDate,
+					Currency:   models.CurrencyIDR,
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(args.ctx, args.req.EntityCode).Return(&models.Entity{Name: "AMF"}, nil)
+				testHelper.mockAcctRepository.EXPECT().GetProfitLoss(args.ctx, args.req).Return(defaultData, nil)
+			},
+			want: models.GetProfitLossResponse{
+				Kind:       models.KindProfitLoss,
+				EntityCode: "111",
+				EntityName: "AMF",
+				StartDate:  startDate.Format(atime.DateFormatYYYYMMDD),
+				EndDate:    endDate.Format(atime.DateFormatYYYYMMDD),
+				Revenues: []models.ProfitLossCategory{
+					{
+						CategoryCode: "411",
+						CategoryName: "Interest Income",
+						Amount:       "7.000,00",
+						DecAmount:    decimal.NewFromInt(700000),
+						SubCategories: []models.ProfitLossSubCategory{
+							{SubCategoryCode: "41101", SubCategoryName: "Interest Income Group Loan", Amount: "5.000,00", DecAmount: decimal.NewFromInt(500000)},
+							{SubCategoryCode: "41102", SubCategoryName: "Interest Income Individual Loan", Amount: "2.000,00", DecAmount: decimal.NewFromInt(200000)},
+						},
+					},
+				},
+				TotalRevenue:    "7.000,00",
+				DecTotalRevenue: decimal.NewFromInt(700000),
+				Expenses: []models.ProfitLossCategory{
+					{
+						CategoryCode: "511",
+						CategoryName: "Operational Expense",
+						Amount:       "3.000,00",
+						DecAmount:    decimal.NewFromInt(300000),
+						SubCategories: []models.ProfitLossSubCategory{
+							{SubCategoryCode: "51101", SubCategoryName: "Salary Expense", Amount: "3.000,00", DecAmount: decimal.NewFromInt(300000)},
+						},
+					},
+				},
+				TotalExpense:    "3.000,00",
+				DecTotalExpense: decimal.NewFromInt(300000),
+				NetIncome:       "4.000,00",
+				DecNetIncome:    decimal.NewFromInt(400000),
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - get profit loss error",
+			args: args{
+				ctx: context.Background(),
+				req: models.ProfitLossFilterOptions{EntityCode: "111"},
+			},
+			doMock: func(args args) {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(args.ctx, args.req.EntityCode).Return(&models.Entity{}, nil)
+				testHelper.mockAcctRepository.EXPECT().GetProfitLoss(args.ctx, args.req).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - get entity code not found",
+			args: args{
+				ctx: context.Background(),
+				req: models.ProfitLossFilterOptions{EntityCode: "123"},
+			},
+			doMock: func(args args) {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(args.ctx, args.req.EntityCode).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - get entity code error",
+			args: args{
+				ctx: context.Background(),
+				req: models.ProfitLossFilterOptions{EntityCode: "123"},
+			},
+			doMock: func(args args) {
+				testHelper.mockEntityRepository.EXPECT().GetByCode(args.ctx, args.req.EntityCode).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock(tc.args)
+			}
+
+			got, err := testHelper.accountingService.GetProfitLoss(tc.args.ctx, tc.args.req)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_accounting_DownloadCSVGetProfitLoss(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	testHelper.mockGoDbLedger.EXPECT().
+		GetCurrency(gomock.Any(), models.CurrencyIDR).
+		Return(godbledger.CurrencyIDR, nil).
+		AnyTimes()
+	res := models.GetProfitLossResponse{
+		EntityCode: "001",
+		StartDate:  "2024-01-01",
+		EndDate:    "2024-01-31",
+		Revenues: []models.ProfitLossCategory{
+			{
+				CategoryCode: "411",
+				CategoryName: "Interest Income",
+				Amount:       "0,00",
+				SubCategories: []models.ProfitLossSubCategory{
+					{SubCategoryCode: "41101", SubCategoryName: "Interest Income Group Loan", Amount: "0,00"},
+				},
+			},
+		},
+		TotalRevenue: "0,00",
+		TotalExpense: "0,00",
+		NetIncome:    "0,00",
+	}
+	type args struct {
+		opts models.ProfitLossFilterOptions
+		resp models.GetProfitLossResponse
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success case",
+			args: args{
+				opts: models.ProfitLossFilterOptions{},
+				resp: res,
+			},
+			doMock: func(args args) {
+				testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+				testHelper.mockFile.EXPECT().CSVWriteBody(gomock.Any(), gomock.Any()).AnyTimes()
+				testHelper.mockFile.EXPECT().CSVProcessWrite(gomock.Any())
+			},
+			wantErr: false,
+		},
+		{
+			name: "success case - usd amounts are scaled by the usd decimals",
+			args: args{
+				opts: models.ProfitLossFilterOptions{Currency: godbledger.CurrencyUSD.Name},
+				resp: models.GetProfitLossResponse{DecNetIncome: decimal.NewFromInt(12345)},
+			},
+			doMock: func(args args) {
+				testHelper.mockGoDbLedger.EXPECT().GetCurrency(gomock.Any(), godbledger.CurrencyUSD.Name).Return(godbledger.CurrencyUSD, nil)
+				testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+				testHelper.mockFile.EXPECT().CSVWriteBody(gomock.Any(), []string{"REVENUES", "USD"})
+				testHelper.mockFile.EXPECT().CSVWriteBody(gomock.Any(), []string{"NET INCOME", "123.45"})
+				testHelper.mockFile.EXPECT().CSVWriteBody(gomock.Any(), gomock.Any()).AnyTimes()
+				testHelper.mockFile.EXPECT().CSVProcessWrite(gomock.Any())
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - currency not found",
+			args: args{
+				opts: models.ProfitLossFilterOptions{Currency: "XYZ"},
+				resp: res,
+			},
+			doMock: func(args args) {
+				testHelper.mockGoDbLedger.EXPECT().GetCurrency(gomock.Any(), "XYZ").Return(nil, models.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+			_, _, err := testHelper.accountingService.DownloadCSVGetProfitLoss(context.TODO(), tt.args.opts, tt.args.resp)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
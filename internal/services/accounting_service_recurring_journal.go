@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+)
+
+/*
+1. build the schedule from the request, the first run is the start date (end of the start month for end of month schedule)
+2. make sure the template is balanced & every account exists
+3. store the template
+*/
+func (as *accounting) CreateRecurringJournal(ctx context.Context, req models.CreateRecurringJournalRequest) (out *models.RecurringJournal, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = req.ToRecurringJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]journalLine, 0, len(req.Transactions))
+	for i, v := range req.Transactions {
+		account, errAccount := as.srv.mySqlRepo.GetAccountRepository().CheckAccountNumberIsExist(ctx, v.Account)
+		if errAccount != nil {
+			err = checkDatabaseError(errAccount)
+			return nil, err
+		}
+		if account == nil {
+			err = models.GetErrMap(models.ErrKeyAccountNumberNotFound, v.Account)
+			return nil, err
+		}
+
+		lines = append(lines, journalLine{
+			Line:     i + 1,
+			Account:  v.Account,
+			Currency: req.Currency,
+			Amount:   v.Amount,
+			IsDebit:  v.IsDebit,
+		})
+	}
+	if err = as.srv.Journal.checkBalance(lines); err != nil {
+		return nil, err
+	}
+
+	out.ID, err = as.srv.mySqlRepo.GetAccountingRepository().InsertRecurringJournal(ctx, *out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (as *accounting) GetRecurringJournals(ctx context.Context, opts models.RecurringJournalFilterOptions) (out []models.RecurringJournal, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	return as.srv.mySqlRepo.GetAccountingRepository().GetRecurringJournals(ctx, opts)
+}
+
+func (as *accounting) GetRecurringJournalById(ctx context.Context, id int) (out *models.RecurringJournal, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = as.srv.mySqlRepo.GetAccountingRepository().GetRecurringJournalById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		err = models.GetErrMap(models.ErrKeyRecurringJournalNotFound, strconv.Itoa(id))
+		return nil, err
+	}
+
+	return out, nil
+}
+
+/*
+1. get the active recurring journals whose next run is on or before the date
+2. post every due run of a recurring journal, the runs missed by the previous jobs are caught up in order
+3. the transaction id is generated from the recurring journal id & the run date,
+a run that is already posted is skipped so the job can be re-run safely
+4. move the schedule after every run, the recurring journal is completed when the end date or the remaining count is reached
+5. a failed recurring journal does not stop the others, the errors are returned together
+*/
+func (as *accounting) RunRecurringJournals(ctx context.Context, date time.Time) (out models.RunRecurringJournalResult, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	date = atime.ToZeroTime(date)
+	recurringJournals, err := as.srv.mySqlRepo.GetAccountingRepository().GetRecurringJournals(ctx, models.RecurringJournalFilterOptions{
+		Status:  models.RecurringJournalStatusActive,
+		DueDate: date,
+	})
+	if err != nil {
+		return
+	}
+
+	var errs *multierror.Error
+	for i := range recurringJournals {
+		rj := &recurringJournals[i]
+		for rj.IsDue(date) {
+			isPosted, errPost := as.postRecurringJournal(ctx, rj)
+			if errPost != nil {
+				errs = multierror.Append(errs, fmt.Errorf("recurring journal %d run %s: %w", rj.ID, rj.NextRunDate.Format(atime.DateFormatYYYYMMDD), errPost))
+				break
+			}
+			if isPosted {
+				out.Posted++
+			} else {
+				out.Skipped++
+			}
+
+			rj.Advance()
+			if errUpdate := as.srv.mySqlRepo.GetAccountingRepository().UpdateRecurringJournalSchedule(ctx, *rj); errUpdate != nil {
+				errs = multierror.Append(errs, fmt.Errorf("recurring journal %d: %w", rj.ID, errUpdate))
+				break
+			}
+		}
+	}
+
+	xlog.Info(ctx, "[RECURRING-JOURNAL]",
+		xlog.String("date", date.Format(atime.DateFormatYYYYMMDD)),
+		xlog.Int("posted", out.Posted),
+		xlog.Int("skipped", out.Skipped),
+	)
+
+	return out, errs.ErrorOrNil()
+}
+
+// postRecurringJournal posts the next run of the recurring journal, it returns false when the run is already posted.
+func (as *accounting) postRecurringJournal(ctx context.Context, rj *models.RecurringJournal) (bool, error) {
+	runDate := rj.NextRunDate.Format(atime.DateFormatYYYYMMDD)
+	transactionId := uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("recurring-journal:%d:%s", rj.ID, runDate))).String()
+
+	isExist, err := as.srv.mySqlRepo.GetAccountingRepository().CheckTransactionIdIsExist(ctx, transactionId)
+	if err != nil {
+		return false, checkDatabaseError(err)
+	}
+	if isExist {
+		xlog.Info(ctx, "[RECURRING-JOURNAL]", xlog.String("description", "already posted"), xlog.String("transaction-id", transactionId))
+		return false, nil
+	}
+
+	req := models.JournalRequest{
+		ReferenceNumber: transactionId,
+		TransactionId:   transactionId,
+		OrderType:       rj.OrderType,
+		TransactionDate: rj.NextRunDate.Format(atime.DateFormatYYYYMMDDWithTime),
+		ProcessingDate:  atime.Now().Format(atime.DateFormatYYYYMMDDWithTime),
+		Currency:        rj.Currency,
+		Transactions:    rj.Transactions,
+		Metadata: &models.Metadata{
+			models.MetadataKeyRecurringJournalId: rj.ID,
+			models.MetadataKeyRunDate:            runDate,
+		},
+	}
+
+	if err = as.srv.Journal.postJournalTransaction(ctx, req); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,253 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_accounting_CreateRecurringJournal(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+	req := models.CreateRecurringJournalRequest{
+		Name:      "Prepaid insurance amortisation",
+		OrderType: "AMR",
+		Currency:  models.CurrencyIDR,
+		Transactions: []models.Transaction{
+			{TransactionType: "AMRIN", Account: "511001000000001", Amount: decimal.NewFromInt(100000), IsDebit: true},
+			{TransactionType: "AMRIN", Account: "141001000000001", Amount: decimal.NewFromInt(100000)},
+		},
+		Frequency: models.RecurringJournalFrequencyEndOfMonth,
+		StartDate: "2024-01-15",
+		CreatedBy: "tono@amartha.com",
+	}
+
+	tests := []struct {
+		name    string
+		req     models.CreateRecurringJournalRequest
+		doMock  func()
+		check   func(t *testing.T, out *models.RecurringJournal)
+		wantErr bool
+	}{
+		{
+			name: "success case - first run of end of month schedule",
+			req:  req,
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().
+					CheckAccountNumberIsExist(gomock.Any(), gomock.Any()).
+					Return(&models.CheckAccountNumberIsExist{}, nil).Times(2)
+				testHelper.mockAcctRepository.EXPECT().
+					InsertRecurringJournal(gomock.Any(), gomock.Any()).
+					Return(1, nil)
+			},
+			check: func(t *testing.T, out *models.RecurringJournal) {
+				assert.Equal(t, 1, out.ID)
+				assert.Equal(t, models.RecurringJournalStatusActive, out.Status)
+				assert.Equal(t, "2024-01-31", out.NextRunDate.Format("2006-01-02"))
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - end date before start date",
+			req: func() models.CreateRecurringJournalRequest {
+				r := req
+				r.EndDate = "2023-12-31"
+				return r
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "error case - account not found",
+			req:  req,
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().
+					CheckAccountNumberIsExist(gomock.Any(), "511001000000001").
+					Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - not balanced",
+			req: func() models.CreateRecurringJournalRequest {
+				r := req
+				r.Transactions = []models.Transaction{
+					{TransactionType: "AMRIN", Account: "511001000000001", Amount: decimal.NewFromInt(100000), IsDebit: true},
+					{TransactionType: "AMRIN", Account: "141001000000001", Amount: decimal.NewFromInt(90000)},
+				}
+				return r
+			}(),
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().
+					CheckAccountNumberIsExist(gomock.Any(), gomock.Any()).
+					Return(&models.CheckAccountNumberIsExist{}, nil).Times(2)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			out, err := testHelper.accountingService.CreateRecurringJournal(ctx, tt.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.check != nil {
+				tt.check(t, out)
+			}
+		})
+	}
+}
+
+func Test_accounting_GetRecurringJournalById(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+
+	testHelper.mockAcctRepository.EXPECT().GetRecurringJournalById(gomock.Any(), 1).Return(&models.RecurringJournal{ID: 1}, nil)
+	out, err := testHelper.accountingService.GetRecurringJournalById(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, out.ID)
+
+	testHelper.mockAcctRepository.EXPECT().GetRecurringJournalById(gomock.Any(), 2).Return(nil, nil)
+	_, err = testHelper.accountingService.GetRecurringJournalById(ctx, 2)
+	assert.ErrorContains(t, err, models.ErrCodeDataNotFound)
+}
+
+func Test_accounting_RunRecurringJournals(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+	date := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.Local)
+	remainingCount := 2
+	recurringJournal := models.RecurringJournal{
+		ID:        1,
+		OrderType: "AMR",
+		Currency:  models.CurrencyIDR,
+		Transactions: models.RecurringJournalTransactions{
+			{TransactionType: "AMRIN", Account: "511001000000001", Amount: decimal.NewFromInt(100000), IsDebit: true},
+			{TransactionType: "AMRIN", Account: "141001000000001", Amount: decimal.NewFromInt(100000)},
+		},
+		Frequency:      models.RecurringJournalFrequencyMonthly,
+		StartDate:      time.Date(2024, time.January, 31, 0, 0, 0, 0, time.Local),
+		RemainingCount: &remainingCount,
+		NextRunDate:    time.Date(2024, time.January, 31, 0, 0, 0, 0, time.Local),
+		Status:         models.RecurringJournalStatusActive,
+	}
+	opts := models.RecurringJournalFilterOptions{
+		Status:  models.RecurringJournalStatusActive,
+		DueDate: date,
+	}
+
+	tests := []struct {
+		name    string
+		doMock  func()
+		want    models.RunRecurringJournalResult
+		wantErr bool
+	}{
+		{
+			name: "success case - skip the posted run & catch up the missed run",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetRecurringJournals(gomock.Any(), opts).
+					Return([]models.RecurringJournal{recurringJournal}, nil)
+				// january is posted by the previous run which failed before the schedule was moved
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(true, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					UpdateRecurringJournalSchedule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in models.RecurringJournal) error {
+						assert.Equal(t, "2024-02-29", in.NextRunDate.Format("2006-01-02"))
+						assert.Equal(t, 1, *in.RemainingCount)
+						return nil
+					})
+
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil).Times(2)
+				testHelper.mockGoDbLedger.EXPECT().
+					GetCurrency(gomock.Any(), models.CurrencyIDR).
+					Return(godbledger.CurrencyIDR, nil)
+				for _, accountNumber := range []string{"511001000000001", "141001000000001"} {
+					testHelper.mockAccRepository.EXPECT().
+						GetOneByAccountNumber(gomock.Any(), accountNumber).
+						Return(models.GetAccountOut{AccountNumber: accountNumber, EntityCode: "001"}, nil)
+				}
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2024-02", "001").
+					Return(&models.TrialBalancePeriod{Period: "2024-02", Status: models.TrialBalanceStatusOpen}, nil)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).Times(2)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().
+							InsertTransaction(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, in []models.CreateTransaction) error {
+								assert.Equal(t, "2024-02-29", in[0].Postdate.Format("2006-01-02"))
+								return nil
+							})
+						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertJournalDetail(gomock.Any(), gomock.Any()).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(2)
+				testHelper.mockAcctRepository.EXPECT().
+					UpdateRecurringJournalSchedule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in models.RecurringJournal) error {
+						assert.Equal(t, "2024-03-31", in.NextRunDate.Format("2006-01-02"))
+						assert.Equal(t, 0, *in.RemainingCount)
+						assert.Equal(t, models.RecurringJournalStatusCompleted, in.Status)
+						return nil
+					})
+			},
+			want:    models.RunRecurringJournalResult{Posted: 1, Skipped: 1},
+			wantErr: false,
+		},
+		{
+			name: "error case - failed post does not move the schedule",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetRecurringJournals(gomock.Any(), opts).
+					Return([]models.RecurringJournal{recurringJournal}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - get recurring journals",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetRecurringJournals(gomock.Any(), opts).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			got, err := testHelper.accountingService.RunRecurringJournals(ctx, date)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
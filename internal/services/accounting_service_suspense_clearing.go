@@ -0,0 +1,408 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/config"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultSuspenseLookbackDays        = 180
+	defaultSuspenseAgeingThresholdDays = 30
+)
+
+// suspensePair is a debit & a credit entry paired by a clearing rule.
+type suspensePair struct {
+	rule   string
+	debit  models.SuspenseEntry
+	credit models.SuspenseEntry
+}
+
+/*
+go run cmd/job/main.go run -v=v1 -n=ClearSuspenseAccounts -d=2024-01-31
+
+1. get the suspense accounts whose closing balance of the date is not zero from acct_account_daily_balance
+2. get the open entries of the accounts, an entry is open when it is not paired by a previous run & is not a clearing journal
+3. pair a debit & a credit entry within the lookback days with the same key of the rule, entity, currency & amount,
+the rules are applied in order & the oldest entries are paired first
+4. the entries of the same account are already net so the pair is only recorded, the entries of different accounts are cleared by
+a journal which debits the account of the credit entry & credits the account of the debit entry.
+the transaction id is generated from the pair so the job can be re-run safely
+5. a failed pair does not stop the others, the errors are returned together
+6. the entries left open past the ageing threshold are reported to slack, including the entries older than the lookback days
+*/
+func (as *accounting) ClearSuspenseAccounts(ctx context.Context, date time.Time) (out models.ClearSuspenseAccountsResult, err error) {
+	process := atime.Now()
+	date = atime.ToZeroTime(date)
+
+	logMessage := "[JOB-ClearSuspenseAccounts]"
+	message := fmt.Sprintf("Job Clear Suspense Accounts - %s", date.Format(atime.DateFormatYYYYMMDD))
+	defer func() {
+		logService(ctx, err)
+		elapsed := time.Since(process)
+		if err != nil {
+			as.sendMessageToSlack(ctx, message, err.Error())
+			xlog.Error(ctx, logMessage, xlog.String("description", message), xlog.Duration("elapsed-time", elapsed), xlog.Err(err))
+			return
+		}
+		as.sendMessageToSlack(ctx, message, fmt.Sprintf("Finished, Cleared: %d, Posted: %d, Aged: %d, Elapsed Time: %v", out.Cleared, out.Posted, out.Aged, elapsed))
+		xlog.Info(ctx, logMessage, xlog.String("description", message), xlog.Duration("elapsed-time", elapsed))
+	}()
+
+	if err = validateSuspenseClearing(as.srv.conf.SuspenseClearing); err != nil {
+		return
+	}
+	rules := as.srv.conf.SuspenseClearing.Rules
+
+	entries, err := as.getOpenSuspenseEntries(ctx, date)
+	if err != nil {
+		return
+	}
+
+	// the entries older than the lookback days are not paired but they are still open
+	lookbackDate := date.AddDate(0, 0, -suspenseLookbackDays(as.srv.conf.SuspenseClearing))
+	idx := sort.Search(len(entries), func(i int) bool {
+		return !entries[i].Split.TransactionDate.Before(lookbackDate)
+	})
+	pairs, openEntries := pairSuspenseEntries(rules, entries[idx:])
+	openEntries = append(entries[:idx:idx], openEntries...)
+
+	var errs *multierror.Error
+	for _, pair := range pairs {
+		isPosted, errClear := as.clearSuspensePair(ctx, date, pair)
+		if errClear != nil {
+			errs = multierror.Append(errs, fmt.Errorf("suspense clearing %s & %s: %w", pair.debit.Split.JournalID, pair.credit.Split.JournalID, errClear))
+			continue
+		}
+		out.Cleared++
+		if isPosted {
+			out.Posted++
+		}
+	}
+
+	threshold := suspenseAgeingThresholdDays(as.srv.conf.SuspenseClearing)
+	aged := []models.SuspenseEntry{}
+	for _, v := range openEntries {
+		if v.AgeDays(date) >= threshold {
+			aged = append(aged, v)
+		}
+	}
+	out.Aged = len(aged)
+	if len(aged) > 0 {
+		as.sendMessageToSlack(ctx, fmt.Sprintf("Suspense Ageing - %s", date.Format(atime.DateFormatYYYYMMDD)), suspenseAgeingMessage(date, threshold, aged))
+	}
+
+	return out, errs.ErrorOrNil()
+}
+
+// GetSuspenseAgeing returns the open suspense entries at the date whose age is at least the min age days.
+func (as *accounting) GetSuspenseAgeing(ctx context.Context, opts models.SuspenseAgeingFilterOptions) (out *models.SuspenseAgeing, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out = &models.SuspenseAgeing{
+		Date:       atime.ToZeroTime(opts.Date),
+		MinAgeDays: opts.MinAgeDays,
+		Entries:    []models.SuspenseEntry{},
+	}
+	if out.MinAgeDays <= 0 {
+		out.MinAgeDays = suspenseAgeingThresholdDays(as.srv.conf.SuspenseClearing)
+	}
+
+	if err = validateSuspenseClearing(as.srv.conf.SuspenseClearing); err != nil {
+		return nil, err
+	}
+
+	entries, err := as.getOpenSuspenseEntries(ctx, out.Date)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range entries {
+		if v.AgeDays(out.Date) >= out.MinAgeDays {
+			out.Entries = append(out.Entries, v)
+		}
+	}
+
+	return out, nil
+}
+
+// getOpenSuspenseEntries returns all the entries of the suspense accounts until the date which are not paired yet,
+// the entries are sorted from the oldest. the lookback days is not applied so the ageing covers every open entry.
+func (as *accounting) getOpenSuspenseEntries(ctx context.Context, date time.Time) ([]models.SuspenseEntry, error) {
+	conf := as.srv.conf.SuspenseClearing
+	balances, err := as.srv.mySqlRepo.GetAccountingRepository().GetSuspenseClosingBalance(ctx, date, conf.SubCategories)
+	if err != nil {
+		return nil, checkDatabaseError(err)
+	}
+
+	_, endDate := atime.StartDateEndDate(date, date)
+
+	entries := []models.SuspenseEntry{}
+	for _, balance := range balances {
+		// the paired entries & the clearing journals are excluded by the query
+		splits, errSplits := as.srv.mySqlRepo.GetAccountingRepository().GetOpenSuspenseSplits(ctx, balance.AccountNumber, endDate, conf.OrderType)
+		if errSplits != nil {
+			return nil, checkDatabaseError(errSplits)
+		}
+
+		for _, split := range splits {
+			entries = append(entries, models.SuspenseEntry{
+				AccountNumber:   balance.AccountNumber,
+				EntityCode:      balance.EntityCode,
+				SubCategoryCode: balance.SubCategoryCode,
+				Currency:        balance.Currency,
+				Split:           split,
+			})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Split.TransactionDate.Before(entries[j].Split.TransactionDate)
+	})
+
+	return entries, nil
+}
+
+// clearSuspensePair records the pair, it returns true when a clearing journal is posted.
+func (as *accounting) clearSuspensePair(ctx context.Context, date time.Time, pair suspensePair) (isPosted bool, err error) {
+	clearing := models.SuspenseClearing{
+		RuleName:            pair.rule,
+		DebitJournalId:      pair.debit.Split.JournalID,
+		CreditJournalId:     pair.credit.Split.JournalID,
+		DebitAccountNumber:  pair.debit.AccountNumber,
+		CreditAccountNumber: pair.credit.AccountNumber,
+		Amount:              pair.debit.Amount(),
+		Currency:            pair.debit.Currency,
+	}
+
+	if clearing.DebitAccountNumber != clearing.CreditAccountNumber {
+		clearing.TransactionId = uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("suspense-clearing:%s:%s", clearing.DebitJournalId, clearing.CreditJournalId))).String()
+		isPosted, err = as.postSuspenseClearing(ctx, date, clearing)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err = as.srv.mySqlRepo.GetAccountingRepository().InsertSuspenseClearing(ctx, clearing); err != nil {
+		return false, checkDatabaseError(err)
+	}
+
+	return isPosted, nil
+}
+
+// postSuspenseClearing posts the clearing journal of the pair, it returns false when the journal is already posted by a previous run.
+func (as *accounting) postSuspenseClearing(ctx context.Context, date time.Time, clearing models.SuspenseClearing) (bool, error) {
+	isExist, err := as.srv.mySqlRepo.GetAccountingRepository().CheckTransactionIdIsExist(ctx, clearing.TransactionId)
+	if err != nil {
+		return false, checkDatabaseError(err)
+	}
+	if isExist {
+		xlog.Info(ctx, "[SUSPENSE-CLEARING]", xlog.String("description", "already posted"), xlog.String("transaction-id", clearing.TransactionId))
+		return false, nil
+	}
+
+	narrative := fmt.Sprintf("Suspense Clearing %s", clearing.RuleName)
+	transactionType := as.srv.conf.SuspenseClearing.TransactionType
+	req := models.JournalRequest{
+		ReferenceNumber: clearing.TransactionId,
+		TransactionId:   clearing.TransactionId,
+		OrderType:       as.srv.conf.SuspenseClearing.OrderType,
+		TransactionDate: time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location()).Format(atime.DateFormatYYYYMMDDWithTime),
+		ProcessingDate:  atime.Now().Format(atime.DateFormatYYYYMMDDWithTime),
+		Currency:        clearing.Currency,
+		Transactions: []models.Transaction{
+			{
+				TransactionType: transactionType,
+				Account:         clearing.CreditAccountNumber,
+				Narrative:       narrative,
+				Amount:          clearing.Amount,
+				IsDebit:         true,
+			},
+			{
+				TransactionType: transactionType,
+				Account:         clearing.DebitAccountNumber,
+				Narrative:       narrative,
+				Amount:          clearing.Amount,
+				IsDebit:         false,
+			},
+		},
+		Metadata: &models.Metadata{
+			models.MetadataKeySuspenseClearingRule:    clearing.RuleName,
+			models.MetadataKeySuspenseDebitJournalId:  clearing.DebitJournalId,
+			models.MetadataKeySuspenseCreditJournalId: clearing.CreditJournalId,
+		},
+	}
+
+	if err = as.srv.Journal.postJournalTransaction(ctx, req); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// validateSuspenseClearing requires the order type because the clearing journals are excluded from the open entries by it.
+func validateSuspenseClearing(conf config.SuspenseClearingConfig) error {
+	if conf.OrderType == "" {
+		return models.GetErrMap(models.ErrKeyInvalidSuspenseClearingRule, "order_type is required")
+	}
+	if conf.TransactionType == "" {
+		return models.GetErrMap(models.ErrKeyInvalidSuspenseClearingRule, "transaction_type is required")
+	}
+
+	for _, rule := range conf.Rules {
+		switch rule.MatchBy {
+		case models.SuspenseClearingMatchByReferenceNumber, models.SuspenseClearingMatchByOrderType:
+		case models.SuspenseClearingMatchByMetadata:
+			if rule.MetadataKey == "" {
+				return models.GetErrMap(models.ErrKeyInvalidSuspenseClearingRule, fmt.Sprintf("rule %s metadata_key is required", rule.Name))
+			}
+		default:
+			return models.GetErrMap(models.ErrKeyInvalidSuspenseClearingRule, fmt.Sprintf("rule %s match_by %s is not supported", rule.Name, rule.MatchBy))
+		}
+	}
+
+	return nil
+}
+
+/*
+pairSuspenseEntries pairs the entries by the rules in order, it returns the pairs & the entries left open.
+1. an entry without the key of the rule or not in the order types of the rule is skipped by the rule
+2. a debit & a credit entry are paired when the key, entity, currency & amount are the same, the entries are sorted from the oldest
+*/
+func pairSuspenseEntries(rules []config.SuspenseClearingRuleConfig, entries []models.SuspenseEntry) (pairs []suspensePair, openEntries []models.SuspenseEntry) {
+	paired := make([]bool, len(entries))
+	for _, rule := range rules {
+		debits, credits := map[string][]int{}, map[string][]int{}
+		keys := []string{}
+		for i, v := range entries {
+			if paired[i] {
+				continue
+			}
+			key := suspenseClearingKey(rule, v)
+			if key == "" {
+				continue
+			}
+			key = strings.Join([]string{key, v.EntityCode, v.Currency, v.Amount().String()}, "|")
+			if _, ok := debits[key]; !ok {
+				if _, ok := credits[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			if v.IsDebit() {
+				debits[key] = append(debits[key], i)
+			} else {
+				credits[key] = append(credits[key], i)
+			}
+		}
+
+		for _, key := range keys {
+			for i := 0; i < len(debits[key]) && i < len(credits[key]); i++ {
+				debit, credit := debits[key][i], credits[key][i]
+				paired[debit], paired[credit] = true, true
+				pairs = append(pairs, suspensePair{
+					rule:   rule.Name,
+					debit:  entries[debit],
+					credit: entries[credit],
+				})
+			}
+		}
+	}
+
+	for i, v := range entries {
+		if !paired[i] {
+			openEntries = append(openEntries, v)
+		}
+	}
+
+	return pairs, openEntries
+}
+
+// suspenseClearingKey returns the value of the entry which the rule pairs on, empty when the rule does not apply to the entry.
+func suspenseClearingKey(rule config.SuspenseClearingRuleConfig, entry models.SuspenseEntry) string {
+	if len(rule.OrderTypes) > 0 && !slices.Contains(rule.OrderTypes, entry.Split.OrderType) {
+		return ""
+	}
+
+	switch rule.MatchBy {
+	case models.SuspenseClearingMatchByReferenceNumber:
+		return entry.Split.ReferenceNumber
+	case models.SuspenseClearingMatchByOrderType:
+		return entry.Split.OrderType
+	case models.SuspenseClearingMatchByMetadata:
+		if entry.Split.Metadata == nil {
+			return ""
+		}
+		v, ok := (*entry.Split.Metadata)[rule.MetadataKey]
+		if !ok || v == nil {
+			return ""
+		}
+		return fmt.Sprint(v)
+	default:
+		return ""
+	}
+}
+
+func suspenseLookbackDays(conf config.SuspenseClearingConfig) int {
+	if conf.LookbackDays <= 0 {
+		return defaultSuspenseLookbackDays
+	}
+	return conf.LookbackDays
+}
+
+func suspenseAgeingThresholdDays(conf config.SuspenseClearingConfig) int {
+	if conf.AgeingThresholdDays <= 0 {
+		return defaultSuspenseAgeingThresholdDays
+	}
+	return conf.AgeingThresholdDays
+}
+
+// suspenseAgeingMessage summarizes the aged entries per account, the accounts are sorted by the account number.
+func suspenseAgeingMessage(date time.Time, threshold int, entries []models.SuspenseEntry) string {
+	type summary struct {
+		count  int
+		debit  decimal.Decimal
+		credit decimal.Decimal
+		oldest int
+	}
+
+	mapSummary := map[string]*summary{}
+	for _, v := range entries {
+		s, ok := mapSummary[v.AccountNumber]
+		if !ok {
+			s = &summary{}
+			mapSummary[v.AccountNumber] = s
+		}
+		s.count++
+		s.debit = s.debit.Add(v.Split.Debit)
+		s.credit = s.credit.Add(v.Split.Credit)
+		if age := v.AgeDays(date); age > s.oldest {
+			s.oldest = age
+		}
+	}
+
+	accounts := make([]string, 0, len(mapSummary))
+	for account := range mapSummary {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	lines := []string{fmt.Sprintf("%d entries are uncleared for more than %d days", len(entries), threshold)}
+	for _, account := range accounts {
+		s := mapSummary[account]
+		lines = append(lines, fmt.Sprintf("- %s: %d entries, debit %s, credit %s, oldest %d days", account, s.count, s.debit.String(), s.credit.String(), s.oldest))
+	}
+
+	return strings.Join(lines, "\n")
+}
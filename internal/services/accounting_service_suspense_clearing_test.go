@@ -0,0 +1,278 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/config"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func dummySuspenseClearing() (config.SuspenseClearingConfig, []models.AccountBalanceDaily, map[string][]models.GetSubLedgerOut) {
+	conf := config.SuspenseClearingConfig{
+		SubCategories:       []string{"21901"},
+		OrderType:           "SCL",
+		TransactionType:     "SCLTR",
+		AgeingThresholdDays: 30,
+		Rules: []config.SuspenseClearingRuleConfig{
+			{Name: "rejected disbursement", MatchBy: models.SuspenseClearingMatchByReferenceNumber, OrderTypes: []string{"DSB"}},
+			{Name: "loan", MatchBy: models.SuspenseClearingMatchByMetadata, MetadataKey: "loanId"},
+		},
+	}
+	balances := []models.AccountBalanceDaily{
+		{AccountNumber: "219001000000001", EntityCode: "001", SubCategoryCode: "21901", Currency: models.CurrencyIDR, ClosingBalance: decimal.NewFromInt(13000000)},
+		{AccountNumber: "219001000000002", EntityCode: "001", SubCategoryCode: "21901", Currency: models.CurrencyIDR, ClosingBalance: decimal.NewFromInt(-9000000)},
+	}
+	split := func(journalId, referenceNumber, orderType string, date time.Time, debit, credit int64, metadata *models.Metadata) models.GetSubLedgerOut {
+		return models.GetSubLedgerOut{
+			TransactionID:   "trx-" + journalId,
+			JournalID:       journalId,
+			ReferenceNumber: referenceNumber,
+			OrderType:       orderType,
+			TransactionDate: date,
+			Metadata:        metadata,
+			Debit:           decimal.NewFromInt(debit),
+			Credit:          decimal.NewFromInt(credit),
+		}
+	}
+	// the open splits, the clearing journals & the paired splits are excluded by the query
+	splits := map[string][]models.GetSubLedgerOut{
+		"219001000000001": {
+			split("journal-1", "DSB_1", "DSB", time.Date(2024, time.April, 10, 9, 0, 0, 0, time.Local), 100000, 0, nil),
+			split("journal-2", "RPY_1", "RPY", time.Date(2024, time.March, 1, 9, 0, 0, 0, time.Local), 50000, 0, &models.Metadata{"loanId": float64(7)}),
+			split("journal-6", "RPY_2", "RPY", time.Date(2024, time.April, 20, 9, 0, 0, 0, time.Local), 0, 50000, &models.Metadata{"loanId": float64(7)}),
+			// older than the lookback days, it is not paired but still aged
+			split("journal-8", "RPY_0", "RPY", time.Date(2023, time.October, 2, 9, 0, 0, 0, time.Local), 0, 50000, &models.Metadata{"loanId": float64(7)}),
+		},
+		"219001000000002": {
+			split("journal-3", "DSB_1", "DSB", time.Date(2024, time.April, 12, 9, 0, 0, 0, time.Local), 0, 100000, nil),
+			split("journal-4", "RPY_3", "RPY", time.Date(2024, time.March, 15, 9, 0, 0, 0, time.Local), 0, 20000, &models.Metadata{"loanId": float64(9)}),
+		},
+	}
+
+	return conf, balances, splits
+}
+
+func Test_accounting_ClearSuspenseAccounts(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+	date := time.Date(2024, time.April, 30, 0, 0, 0, 0, time.Local)
+	_, endDate := atime.StartDateEndDate(date, date)
+	conf, balances, splits := dummySuspenseClearing()
+
+	mockOpenEntries := func() {
+		testHelper.mockAcctRepository.EXPECT().
+			GetSuspenseClosingBalance(gomock.Any(), date, conf.SubCategories).
+			Return(balances, nil)
+		for _, v := range balances {
+			testHelper.mockAcctRepository.EXPECT().
+				GetOpenSuspenseSplits(gomock.Any(), v.AccountNumber, endDate, conf.OrderType).
+				Return(splits[v.AccountNumber], nil)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		conf    config.SuspenseClearingConfig
+		doMock  func()
+		want    models.ClearSuspenseAccountsResult
+		wantErr bool
+	}{
+		{
+			name: "success case - clear the pair of different accounts by journal & record the pair of the same account",
+			conf: conf,
+			doMock: func() {
+				mockOpenEntries()
+
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil).Times(2)
+				testHelper.mockGoDbLedger.EXPECT().
+					GetCurrency(gomock.Any(), models.CurrencyIDR).
+					Return(godbledger.CurrencyIDR, nil)
+				for _, accountNumber := range []string{"219001000000002", "219001000000001"} {
+					testHelper.mockAccRepository.EXPECT().
+						GetOneByAccountNumber(gomock.Any(), accountNumber).
+						Return(models.GetAccountOut{AccountNumber: accountNumber, EntityCode: "001"}, nil)
+				}
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2024-04", "001").
+					Return(&models.TrialBalancePeriod{Period: "2024-04", Status: models.TrialBalanceStatusOpen}, nil)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).Times(2)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertJournalDetail(gomock.Any(), gomock.Any()).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(2)
+
+				gomock.InOrder(
+					testHelper.mockAcctRepository.EXPECT().
+						InsertSuspenseClearing(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, in models.SuspenseClearing) error {
+							assert.Equal(t, "rejected disbursement", in.RuleName)
+							assert.Equal(t, "journal-1", in.DebitJournalId)
+							assert.Equal(t, "journal-3", in.CreditJournalId)
+							assert.NotEmpty(t, in.TransactionId)
+							return nil
+						}),
+					testHelper.mockAcctRepository.EXPECT().
+						InsertSuspenseClearing(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, in models.SuspenseClearing) error {
+							assert.Equal(t, "loan", in.RuleName)
+							assert.Equal(t, "journal-2", in.DebitJournalId)
+							assert.Equal(t, "journal-6", in.CreditJournalId)
+							assert.Empty(t, in.TransactionId)
+							return nil
+						}),
+				)
+			},
+			want:    models.ClearSuspenseAccountsResult{Cleared: 2, Posted: 1, Aged: 2},
+			wantErr: false,
+		},
+		{
+			name: "error case - failed pair does not stop the others",
+			conf: conf,
+			doMock: func() {
+				mockOpenEntries()
+
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, assert.AnError)
+				testHelper.mockAcctRepository.EXPECT().
+					InsertSuspenseClearing(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			want:    models.ClearSuspenseAccountsResult{Cleared: 1, Aged: 2},
+			wantErr: true,
+		},
+		{
+			name: "error case - order type is required",
+			conf: config.SuspenseClearingConfig{
+				TransactionType: "SCLTR",
+				Rules:           conf.Rules,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - invalid rule",
+			conf: config.SuspenseClearingConfig{
+				OrderType:       "SCL",
+				TransactionType: "SCLTR",
+				Rules:           []config.SuspenseClearingRuleConfig{{Name: "loan", MatchBy: models.SuspenseClearingMatchByMetadata}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - get closing balance",
+			conf: conf,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetSuspenseClosingBalance(gomock.Any(), date, conf.SubCategories).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			testHelper.config.SuspenseClearing = tt.conf
+			testHelper.mockDDDNotification.EXPECT().
+				SendMessageToSlack(gomock.Any(), gomock.Any()).
+				Return(nil).AnyTimes()
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			got, err := testHelper.accountingService.ClearSuspenseAccounts(ctx, date)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_accounting_GetSuspenseAgeing(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+	date := time.Date(2024, time.April, 30, 0, 0, 0, 0, time.Local)
+	conf, balances, splits := dummySuspenseClearing()
+	testHelper.config.SuspenseClearing = conf
+
+	tests := []struct {
+		name           string
+		opts           models.SuspenseAgeingFilterOptions
+		doMock         func()
+		wantJournalIds []string
+		wantMinAgeDays int
+		wantErr        bool
+	}{
+		{
+			name: "success case - default threshold",
+			opts: models.SuspenseAgeingFilterOptions{Date: date},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetSuspenseClosingBalance(gomock.Any(), date, conf.SubCategories).
+					Return(balances, nil)
+				for _, v := range balances {
+					testHelper.mockAcctRepository.EXPECT().
+						GetOpenSuspenseSplits(gomock.Any(), v.AccountNumber, gomock.Any(), conf.OrderType).
+						Return(splits[v.AccountNumber], nil)
+				}
+			},
+			wantJournalIds: []string{"journal-8", "journal-2", "journal-4"},
+			wantMinAgeDays: 30,
+			wantErr:        false,
+		},
+		{
+			name: "error case - get open splits",
+			opts: models.SuspenseAgeingFilterOptions{Date: date, MinAgeDays: 10},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetSuspenseClosingBalance(gomock.Any(), date, conf.SubCategories).
+					Return(balances, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetOpenSuspenseSplits(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			got, err := testHelper.accountingService.GetSuspenseAgeing(ctx, tt.opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				return
+			}
+
+			journalIds := []string{}
+			for _, v := range got.Entries {
+				journalIds = append(journalIds, v.Split.JournalID)
+			}
+			assert.Equal(t, tt.wantJournalIds, journalIds)
+			assert.Equal(t, tt.wantMinAgeDays, got.MinAgeDays)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -250,6 +251,10 @@ func Test_accounting_GetTrialBalance(t *testing.T) {
 
 func Test_accounting_DownloadCSVGetTrialBalance(t *testing.T) {
 	testHelper := serviceTestHelper(t)
+	testHelper.mockGoDbLedger.EXPECT().
+		GetCurrency(gomock.Any(), models.CurrencyIDR).
+		Return(godbledger.CurrencyIDR, nil).
+		AnyTimes()
 	resC, resSC := dummyResponse()
 	type args struct {
 		ctx context.Context
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/money"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+/*
+1. validate fiscal year, entity code & the configured retained earnings account
+2. refuse when the fiscal year is already closed
+3. get the balance of the income & expense accounts at the end of the fiscal year
+4. post a closing journal per sub category which zeroes the accounts into the retained earnings account,
+the transaction id is generated from entity, fiscal year & sub category so the closing can be resumed safely
+5. regenerate the december trial balance in big query and insert the opening balance of the next year from it
+6. close every trial balance period of the fiscal year & record the closing
+*/
+func (as *accounting) CloseFiscalYear(ctx context.Context, in models.YearEndClosingRequest) (out models.YearEndClosing, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	yearStart, err := atime.ParseStringToDatetime(atime.DateFormatYYYY, in.FiscalYear)
+	if err != nil {
+		err = models.GetErrMap(models.ErrKeyInvalidFormatDate, fmt.Sprintf("fiscal year %s format must be YYYY", in.FiscalYear))
+		return
+	}
+	yearEnd := time.Date(yearStart.Year(), time.December, 31, 23, 59, 59, 0, yearStart.Location())
+	if !atime.Now().After(yearEnd) {
+		err = models.GetErrMap(models.ErrKeyFiscalYearNotEnded, in.FiscalYear)
+		return
+	}
+
+	entity, err := as.srv.mySqlRepo.GetEntityRepository().GetByCode(ctx, in.EntityCode)
+	if err != nil {
+		return
+	}
+	if entity == nil {
+		err = models.GetErrMap(models.ErrKeyEntityCodeNotFound)
+		return
+	}
+
+	retainedEarningsAccount, ok := as.srv.conf.YearEndClosing.RetainedEarningsAccounts[in.EntityCode]
+	if !ok {
+		err = models.GetErrMap(models.ErrKeyRetainedEarningsAccountNotFound, fmt.Sprintf("entity %s", in.EntityCode))
+		return
+	}
+
+	closing, err := as.srv.mySqlRepo.GetTrialBalanceRepository().GetYearEndClosing(ctx, in.FiscalYear, in.EntityCode)
+	if err != nil {
+		return
+	}
+	if closing != nil {
+		err = models.GetErrMap(models.ErrKeyFiscalYearAlreadyClosed, fmt.Sprintf("fiscal year %s entity %s", in.FiscalYear, in.EntityCode))
+		return
+	}
+
+	balances, err := as.srv.mySqlRepo.GetAccountingRepository().GetYearEndClosingBalance(ctx, in.EntityCode, CurrencyIDR.Name, as.srv.conf.YearEndClosing.OrderType, yearStart, yearEnd)
+	if err != nil {
+		return
+	}
+
+	// balances are ordered by sub category
+	netIncome := decimal.Zero
+	transactionIds := []string{}
+	for i := 0; i < len(balances); {
+		j := i
+		for j < len(balances) && balances[j].SubCategoryCode == balances[i].SubCategoryCode {
+			j++
+		}
+
+		transactionId, subCategoryNetIncome, errPost := as.postYearEndClosingJournal(ctx, in, yearEnd, retainedEarningsAccount, balances[i:j])
+		if errPost != nil {
+			err = errPost
+			return
+		}
+		transactionIds = append(transactionIds, transactionId)
+		netIncome = netIncome.Add(subCategoryNetIncome)
+		i = j
+	}
+
+	if err = as.regenerateYearEndTrialBalance(ctx, in, yearEnd, transactionIds); err != nil {
+		return
+	}
+
+	out = models.YearEndClosing{
+		FiscalYear:              in.FiscalYear,
+		EntityCode:              in.EntityCode,
+		RetainedEarningsAccount: retainedEarningsAccount,
+		NetIncome:               netIncome,
+		ClosedBy:                in.ClosedBy,
+		CreatedAt:               atime.Now(),
+	}
+	err = as.srv.mySqlRepo.Atomic(ctx, func(actx context.Context, r mysql.SQLRepository) error {
+		if err := r.GetTrialBalanceRepository().CloseByFiscalYear(actx, in.FiscalYear, in.EntityCode, in.ClosedBy); err != nil {
+			return err
+		}
+
+		return r.GetTrialBalanceRepository().InsertYearEndClosing(actx, out)
+	})
+	if err != nil {
+		return models.YearEndClosing{}, err
+	}
+
+	return out, nil
+}
+
+// postYearEndClosingJournal zeroes the accounts of a sub category into the retained earnings account,
+// it returns the transaction id and the net income of the sub category in the minor unit.
+func (as *accounting) postYearEndClosingJournal(ctx context.Context, in models.YearEndClosingRequest, yearEnd time.Time, retainedEarningsAccount string, balances []models.YearEndClosingBalance) (string, decimal.Decimal, error) {
+	subCategoryCode := balances[0].SubCategoryCode
+	transactionId := uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("year-end-closing:%s:%s:%s", in.EntityCode, in.FiscalYear, subCategoryCode))).String()
+
+	narrative := fmt.Sprintf("Year End Closing %s %s", in.FiscalYear, subCategoryCode)
+	transactionType := as.srv.conf.YearEndClosing.TransactionType
+	// every account is closed against the retained earnings account in its own pair of lines
+	transactions := make([]models.Transaction, 0, len(balances)*2)
+	netIncome := decimal.Zero
+	for _, v := range balances {
+		balance := v.DebitMovement.Sub(v.CreditMovement)
+		netIncome = netIncome.Sub(balance)
+		amount := money.FormatBigIntToAmount(balance.Abs(), CurrencyIDR.Decimals)
+		transactions = append(transactions,
+			models.Transaction{
+				TransactionType: transactionType,
+				Account:         v.AccountNumber,
+				Narrative:       narrative,
+				Amount:          amount,
+				IsDebit:         balance.IsNegative(),
+			},
+			models.Transaction{
+				TransactionType: transactionType,
+				Account:         retainedEarningsAccount,
+				Narrative:       narrative,
+				Amount:          amount,
+				IsDebit:         balance.IsPositive(),
+			},
+		)
+	}
+
+	isExist, err := as.srv.mySqlRepo.GetAccountingRepository().CheckTransactionIdIsExist(ctx, transactionId)
+	if err != nil {
+		return "", decimal.Zero, checkDatabaseError(err)
+	}
+	if isExist {
+		xlog.Info(ctx, "[YEAR-END-CLOSING]", xlog.String("description", "already posted"), xlog.String("transaction-id", transactionId))
+		return transactionId, netIncome, nil
+	}
+
+	req := models.JournalRequest{
+		ReferenceNumber: transactionId,
+		TransactionId:   transactionId,
+		OrderType:       as.srv.conf.YearEndClosing.OrderType,
+		TransactionDate: yearEnd.Format(atime.DateFormatYYYYMMDDWithTime),
+		ProcessingDate:  atime.Now().Format(atime.DateFormatYYYYMMDDWithTime),
+		Currency:        CurrencyIDR.Name,
+		Transactions:    transactions,
+		Metadata: &models.Metadata{
+			models.MetadataKeyFiscalYear: in.FiscalYear,
+		},
+		// december is usually closed by the monthly closing before the year is closed
+		AllowClosedPeriod: &models.ClosedPeriodOverride{
+			ApprovedBy: in.ClosedBy,
+			Reason:     fmt.Sprintf("year end closing %s", in.FiscalYear),
+		},
+	}
+
+	if err = as.srv.Journal.postJournalTransaction(ctx, req); err != nil {
+		return "", decimal.Zero, err
+	}
+
+	return transactionId, netIncome, nil
+}
+
+// regenerateYearEndTrialBalance makes the december trial balance include the closing journals,
+// so the opening balance of the next year reflects the closed year.
+func (as *accounting) regenerateYearEndTrialBalance(ctx context.Context, in models.YearEndClosingRequest, yearEnd time.Time, transactionIds []string) error {
+	if len(transactionIds) > 0 {
+		bqTransactionIds, err := as.srv.bigQueryRepo.QueryGetTransactions(ctx, transactionIds)
+		if err != nil {
+			return models.GetErrMap(models.ErrKeyBigQueryError, err.Error())
+		}
+		if len(bqTransactionIds) != len(transactionIds) {
+			// the closing journals are not streamed to big query yet, the closing can be resumed later
+			return models.GetErrMap(models.ErrKeyTransactionIdsNotSync)
+		}
+	}
+
+	if _, err := as.runTrialBalanceBigQuerySteps(ctx, "[YEAR-END-CLOSING]", atime.FirstOfNextMonth(yearEnd), []string{in.EntityCode}); err != nil {
+		return err
+	}
+
+	sourceTable := fmt.Sprintf("%s_tb_detail", yearEnd.Format(atime.DateFormatYYYYMMWithUnderscore))
+	if err := as.srv.bigQueryRepo.QueryInsertOpeningBalanceCreated(ctx, sourceTable, in.EntityCode); err != nil {
+		return models.GetErrMap(models.ErrKeyBigQueryError, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/config"
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_accounting_CloseFiscalYear(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+	testHelper.config.YearEndClosing = config.YearEndClosingConfig{
+		OrderType:       "YEC",
+		TransactionType: "YECLS",
+		RetainedEarningsAccounts: map[string]string{
+			"001": "311001000000001",
+		},
+	}
+
+	req := models.YearEndClosingRequest{
+		FiscalYear: "2024",
+		EntityCode: "001",
+		ClosedBy:   "tono@amartha.com",
+	}
+	balances := []models.YearEndClosingBalance{
+		{
+			AccountNumber:   "411001000000001",
+			SubCategoryCode: "41101",
+			COAType:         models.COATypeIncome,
+			DebitMovement:   decimal.NewFromInt(10000),
+			CreditMovement:  decimal.NewFromInt(510000),
+		},
+		{
+			AccountNumber:   "411001000000002",
+			SubCategoryCode: "41101",
+			COAType:         models.COATypeIncome,
+			CreditMovement:  decimal.NewFromInt(200000),
+		},
+	}
+	mockValidate := func() {
+		testHelper.mockEntityRepository.EXPECT().
+			GetByCode(gomock.Any(), "001").
+			Return(&models.Entity{Code: "001"}, nil)
+		testHelper.mockTrialBalanceRepository.EXPECT().
+			GetYearEndClosing(gomock.Any(), "2024", "001").
+			Return(nil, nil)
+	}
+	mockRegenerate := func() {
+		testHelper.mockBigQuery.EXPECT().
+			QueryGetTransactions(gomock.Any(), gomock.Len(1)).
+			DoAndReturn(func(ctx context.Context, ids []string) ([]string, error) {
+				return ids, nil
+			})
+		testHelper.mockFlag.EXPECT().
+			IsEnabled(models.FlagGetOpeningBalanceFromPreviousMonth.String()).
+			Return(false)
+		testHelper.mockBigQuery.EXPECT().
+			QueryGenerateTrialBalanceDetail(gomock.Any(), "2024_12", "", "2025-01-01", "2024-12-01", "2024-12-31").
+			Return(nil)
+		testHelper.mockBigQuery.EXPECT().
+			QueryGenerateTrialBalanceSummary(gomock.Any(), "2024_12").
+			Return(nil)
+		testHelper.mockBigQuery.EXPECT().
+			ExportTrialBalanceSummary(gomock.Any(), []string{"001"}, "2024_12", gomock.Any()).
+			Return([]models.CreateTrialBalancePeriod{}, nil)
+		testHelper.mockSubCategoryRepository.EXPECT().
+			GetAll(gomock.Any(), models.GetAllSubCategoryParam{}).
+			Return(&[]models.SubCategory{{Code: "41101"}}, nil)
+		testHelper.mockBigQuery.EXPECT().
+			ExportTrialBalanceDetail(gomock.Any(), []string{"001"}, "2024_12", gomock.Any(), gomock.Any()).
+			Return(nil)
+		testHelper.mockBigQuery.EXPECT().
+			QueryInsertOpeningBalanceCreated(gomock.Any(), "2024_12_tb_detail", "001").
+			Return(nil)
+	}
+	mockClose := func() {
+		testHelper.mockMySQLRepository.EXPECT().
+			Atomic(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					CloseByFiscalYear(gomock.Any(), "2024", "001", "tono@amartha.com").
+					Return(nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					InsertYearEndClosing(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in models.YearEndClosing) error {
+						assert.Equal(t, "311001000000001", in.RetainedEarningsAccount)
+						assert.True(t, decimal.NewFromInt(700000).Equal(in.NetIncome))
+						return nil
+					})
+				return steps(ctx, testHelper.mockMySQLRepository)
+			})
+	}
+
+	tests := []struct {
+		name    string
+		req     models.YearEndClosingRequest
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success case - post closing journal into retained earnings",
+			req:  req,
+			doMock: func() {
+				mockValidate()
+				testHelper.mockAcctRepository.EXPECT().
+					GetYearEndClosingBalance(gomock.Any(), "001", models.CurrencyIDR, "YEC", gomock.Any(), gomock.Any()).
+					Return(balances, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil).Times(2)
+				testHelper.mockGoDbLedger.EXPECT().
+					GetCurrency(gomock.Any(), models.CurrencyIDR).
+					Return(godbledger.CurrencyIDR, nil)
+				for _, accountNumber := range []string{"411001000000001", "411001000000002"} {
+					testHelper.mockAccRepository.EXPECT().
+						GetOneByAccountNumber(gomock.Any(), accountNumber).
+						Return(models.GetAccountOut{AccountNumber: accountNumber, EntityCode: "001"}, nil)
+				}
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "311001000000001").
+					Return(models.GetAccountOut{AccountNumber: "311001000000001", EntityCode: "001"}, nil).Times(2)
+				// december is already closed by the monthly closing
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2024-12", "001").
+					Return(&models.TrialBalancePeriod{Period: "2024-12", Status: models.TrialBalanceStatusClosed}, nil)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).Times(4)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().
+							InsertSplit(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, splits []models.CreateSplit) error {
+								assert.Equal(t, int64(500000), splits[0].Amount)
+								assert.Equal(t, int64(500000), splits[1].Amount)
+								assert.Equal(t, int64(200000), splits[2].Amount)
+								assert.Equal(t, int64(200000), splits[3].Amount)
+								return nil
+							})
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().
+							InsertJournalDetail(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, journals []models.CreateJournalDetail) error {
+								assert.True(t, journals[0].IsDebit)
+								assert.False(t, journals[1].IsDebit)
+								assert.True(t, journals[2].IsDebit)
+								assert.False(t, journals[3].IsDebit)
+								return nil
+							})
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(4)
+				mockRegenerate()
+				mockClose()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success case - resume when closing journal is already posted",
+			req:  req,
+			doMock: func() {
+				mockValidate()
+				testHelper.mockAcctRepository.EXPECT().
+					GetYearEndClosingBalance(gomock.Any(), "001", models.CurrencyIDR, "YEC", gomock.Any(), gomock.Any()).
+					Return(balances, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(true, nil)
+				mockRegenerate()
+				mockClose()
+			},
+			wantErr: false,
+		},
+		{
+			name:    "error case - invalid fiscal year",
+			req:     models.YearEndClosingRequest{FiscalYear: "20x4", EntityCode: "001"},
+			wantErr: true,
+		},
+		{
+			name:    "error case - fiscal year has not ended",
+			req:     models.YearEndClosingRequest{FiscalYear: atime.Now().Format(atime.DateFormatYYYY), EntityCode: "001"},
+			wantErr: true,
+		},
+		{
+			name: "error case - entity not found",
+			req:  req,
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().
+					GetByCode(gomock.Any(), "001").
+					Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - retained earnings account not configured",
+			req:  models.YearEndClosingRequest{FiscalYear: "2024", EntityCode: "002"},
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().
+					GetByCode(gomock.Any(), "002").
+					Return(&models.Entity{Code: "002"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - fiscal year already closed",
+			req:  req,
+			doMock: func() {
+				testHelper.mockEntityRepository.EXPECT().
+					GetByCode(gomock.Any(), "001").
+					Return(&models.Entity{Code: "001"}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetYearEndClosing(gomock.Any(), "2024", "001").
+					Return(&models.YearEndClosing{FiscalYear: "2024", EntityCode: "001"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - closing journal is not synced to big query",
+			req:  req,
+			doMock: func() {
+				mockValidate()
+				testHelper.mockAcctRepository.EXPECT().
+					GetYearEndClosingBalance(gomock.Any(), "001", models.CurrencyIDR, "YEC", gomock.Any(), gomock.Any()).
+					Return(balances, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(true, nil)
+				testHelper.mockBigQuery.EXPECT().
+					QueryGetTransactions(gomock.Any(), gomock.Any()).
+					Return([]string{}, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			_, err := testHelper.accountingService.CloseFiscalYear(ctx, tt.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+
+	t.Run("success case - profit loss of the closed fiscal year excludes the closing journals", func(t *testing.T) {
+		tests[0].doMock()
+		closing, err := testHelper.accountingService.CloseFiscalYear(ctx, req)
+		assert.NoError(t, err)
+
+		startDate, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-01-01")
+		endDate, _ := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, "2024-12-31")
+		opts := models.ProfitLossFilterOptions{
+			EntityCode: "001",
+			StartDate:  startDate,
+			EndDate:    endDate,
+			Currency:   models.CurrencyIDR,
+		}
+		testHelper.mockEntityRepository.EXPECT().
+			GetByCode(gomock.Any(), "001").
+			Return(&models.Entity{Code: "001"}, nil)
+		// the movements of the closing journals are subtracted by the repository, only the movements of the year are left
+		testHelper.mockAcctRepository.EXPECT().
+			GetProfitLoss(gomock.Any(), models.ProfitLossFilterOptions{
+				EntityCode:       "001",
+				StartDate:        startDate,
+				EndDate:          endDate,
+				Currency:         models.CurrencyIDR,
+				ClosingOrderType: "YEC",
+			}).
+			Return([]models.ProfitLossPerSubCategoryOut{{
+				COAType:         models.COATypeIncome,
+				CategoryCode:    "411",
+				SubCategoryCode: "41101",
+				DebitMovement:   decimal.NewFromInt(10000),
+				CreditMovement:  decimal.NewFromInt(710000),
+			}}, nil)
+
+		got, err := testHelper.accountingService.GetProfitLoss(ctx, opts)
+		assert.NoError(t, err)
+		assert.True(t, closing.NetIncome.Equal(got.DecNetIncome))
+	})
+}
@@ -1,11 +1,13 @@
 package services
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 
 	xlog "bitbucket.org/Amartha/go-x/log"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-multierror"
 	"github.com/shopspring/decimal"
 )
@@ -27,9 +30,20 @@ type JournalService interface {
 	ConsumerInsertTransaction(ctx context.Context, req models.JournalRequest) (err error)
 	InsertJournalTransaction(ctx context.Context, request models.JournalRequest) (out []models.JournalEntryCreatedRequest, err error)
 	ProcessUploadJournal(ctx context.Context, file *multipart.FileHeader) error
+	ValidateUploadJournal(ctx context.Context, file *multipart.FileHeader) (out models.UploadJournalReport, err error)
+	DownloadCSVUploadJournalReport(ctx context.Context, report models.UploadJournalReport) (b *bytes.Buffer, filename string, err error)
 	PublishJournalTransaction(ctx context.Context, req models.JournalRequest) (err error)
 	GetJournalByTransactionId(ctx context.Context, transactionId string) (out []models.GetJournalDetailOut, err error)
 	RetryPublishToJournalEntryCreated(ctx context.Context, data models.JournalEntryCreatedRequest) (err error)
+	ReverseJournal(ctx context.Context, req models.ReverseJournalRequest) (out models.JournalRequest, err error)
+
+	// Journal Draft
+	CreateJournalDraft(ctx context.Context, req models.CreateJournalDraftRequest) (out *models.JournalDraft, err error)
+	GetJournalDrafts(ctx context.Context, opts models.JournalDraftFilterOptions) (out []models.JournalDraft, err error)
+	GetJournalDraftById(ctx context.Context, id int) (out *models.JournalDraft, err error)
+	SubmitJournalDraft(ctx context.Context, req models.SubmitJournalDraftRequest) (out *models.JournalDraft, err error)
+	ApproveJournalDraft(ctx context.Context, req models.ApproveJournalDraftRequest) (out *models.JournalDraft, err error)
+	RejectJournalDraft(ctx context.Context, req models.RejectJournalDraftRequest) (out *models.JournalDraft, err error)
 }
 
 type journalService service
@@ -83,37 +97,13 @@ func (js *journalService) InsertJournalTransaction(ctx context.Context, req mode
 		xlog.Any("data", req),
 	)
 
-	trxDate, processingDate, err := js.validateTransaction(ctx, req)
+	records, journalEntries, err := js.prepareJournalTransaction(ctx, req)
 	if err != nil {
 		return
 	}
 
-	now := atime.Now()
-	trxDate = time.Date(trxDate.Year(), trxDate.Month(), trxDate.Day(), trxDate.Hour(), trxDate.Minute(), trxDate.Second(), now.Nanosecond(), now.Location())
-
-	transactions, splits, splitAccounts, journals, journalEntries, err := js.changeTrxToSplits(ctx, req, trxDate, processingDate)
-	if err != nil {
-		return
-	}
-
-	err = js.srv.mySqlRepo.Atomic(ctx, func(actx context.Context, r mysql.SQLRepository) (err error) {
-		if err = r.GetAccountingRepository().InsertTransaction(actx, transactions); err != nil {
-			return err
-		}
-
-		if err = r.GetAccountingRepository().InsertSplit(actx, splits); err != nil {
-			return err
-		}
-
-		if err = r.GetAccountingRepository().InsertSplitAccount(actx, splitAccounts); err != nil {
-			return err
-		}
-
-		if err = r.GetAccountingRepository().InsertJournalDetail(actx, journals); err != nil {
-			return err
-		}
-
-		return nil
+	err = js.srv.mySqlRepo.Atomic(ctx, func(actx context.Context, r mysql.SQLRepository) error {
+		return insertJournalRecords(actx, r, records)
 	})
 	if err != nil {
 		return []models.JournalEntryCreatedRequest{}, err
@@ -130,6 +120,46 @@ func (js *journalService) InsertJournalTransaction(ctx context.Context, req mode
 	return journalEntries, nil
 }
 
+// journalRecords are the rows of a journal to be inserted in one transaction.
+type journalRecords struct {
+	transactions  []models.CreateTransaction
+	splits        []models.CreateSplit
+	splitAccounts []models.CreateSplitAccount
+	journals      []models.CreateJournalDetail
+}
+
+// prepareJournalTransaction validates the journal & builds the rows to be inserted with the journal entries to be published.
+func (js *journalService) prepareJournalTransaction(ctx context.Context, req models.JournalRequest) (records journalRecords, journalEntries []models.JournalEntryCreatedRequest, err error) {
+	trxDate, processingDate, err := js.validateTransaction(ctx, req)
+	if err != nil {
+		return
+	}
+
+	now := atime.Now()
+	trxDate = time.Date(trxDate.Year(), trxDate.Month(), trxDate.Day(), trxDate.Hour(), trxDate.Minute(), trxDate.Second(), now.Nanosecond(), now.Location())
+
+	records.transactions, records.splits, records.splitAccounts, records.journals, journalEntries, err = js.changeTrxToSplits(ctx, req, trxDate, processingDate)
+
+	return
+}
+
+// insertJournalRecords inserts the rows of a journal, it must be called inside an atomic.
+func insertJournalRecords(ctx context.Context, r mysql.SQLRepository, records journalRecords) error {
+	if err := r.GetAccountingRepository().InsertTransaction(ctx, records.transactions); err != nil {
+		return err
+	}
+
+	if err := r.GetAccountingRepository().InsertSplit(ctx, records.splits); err != nil {
+		return err
+	}
+
+	if err := r.GetAccountingRepository().InsertSplitAccount(ctx, records.splitAccounts); err != nil {
+		return err
+	}
+
+	return r.GetAccountingRepository().InsertJournalDetail(ctx, records.journals)
+}
+
 func (js *journalService) validateTransaction(ctx context.Context, req models.JournalRequest) (trxDate, processingDate time.Time, err error) {
 	if len(req.Transactions) == 0 {
 		err = models.GetErrMap(models.ErrKeyTransactionsEmpty)
@@ -165,6 +195,19 @@ func (js *journalService) validateTransaction(ctx context.Context, req models.Jo
 		return
 	}
 
+	// entity is not known yet at this point, so only check the balance per currency
+	lines := make([]journalLine, 0, len(req.Transactions))
+	for i, v := range req.Transactions {
+		lines = append(lines, journalLine{
+			Line:     i + 1,
+			Account:  v.Account,
+			Currency: req.Currency,
+			Amount:   v.Amount,
+			IsDebit:  v.IsDebit,
+		})
+	}
+	err = js.checkBalance(lines)
+
 	return
 }
 
@@ -173,34 +216,37 @@ func (js *journalService) changeTrxToSplits(
 	req models.JournalRequest,
 	trxDate, processingDate time.Time,
 ) ([]models.CreateTransaction, []models.CreateSplit, []models.CreateSplitAccount, []models.CreateJournalDetail, []models.JournalEntryCreatedRequest, error) {
-	len := len(req.Transactions)
+	resolved, err := js.resolveJournal(ctx, req, trxDate)
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	trxDate = resolved.postDate
+	currency := resolved.currency
+
+	metadata := req.Metadata
+	if resolved.closedPeriodOverride {
+		metadata = withClosedPeriodOverride(req.Metadata, req.AllowClosedPeriod, trxDate)
+	}
+
+	len := len(resolved.transactions)
 	transactions := make([]models.CreateTransaction, 0, len)
 	splits := make([]models.CreateSplit, 0, len)
 	splitAccounts := make([]models.CreateSplitAccount, 0, len)
 	journals := make([]models.CreateJournalDetail, 0, len)
 	journalEntries := make([]models.JournalEntryCreatedRequest, 0, len)
-	arrEntity := make([]string, 0, len)
-	currency := godbledger.CurrencyIDR
 
 	transactions = append(transactions, models.CreateTransaction{
 		TransactionID: req.TransactionId,
 		Postdate:      trxDate,
 		PosterUserID:  godbledger.UserSystem.Id,
 	})
-	for _, v := range req.Transactions {
+	for i, v := range resolved.transactions {
 		splitId, err := js.generateSplitId(ctx)
 		if err != nil {
 			return nil, nil, nil, nil, nil, err
 		}
 
-		account, err := js.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, v.Account)
-		if err != nil {
-			err = checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
-			return nil, nil, nil, nil, nil, err
-		}
-
-		arrEntity = append(arrEntity, account.EntityCode)
-
+		account := resolved.accounts[i]
 		amount := money.FormatAmountToBigInt(v.Amount, currency.Decimals)
 		splits = append(splits, models.CreateSplit{
 			SplitID:       splitId,
@@ -217,13 +263,14 @@ func (js *journalService) changeTrxToSplits(
 		})
 
 		journals = append(journals, models.CreateJournalDetail{
-			JournalId:       splitId,
-			ReferenceNumber: req.ReferenceNumber,
-			OrderType:       req.OrderType,
-			TransactionType: v.TransactionType,
-			TransactionDate: trxDate,
-			IsDebit:         v.IsDebit,
-			Metadata:        req.Metadata,
+			JournalId:         splitId,
+			ReferenceNumber:   req.ReferenceNumber,
+			OrderType:         req.OrderType,
+			TransactionType:   v.TransactionType,
+			TransactionDate:   trxDate,
+			IsDebit:           v.IsDebit,
+			Metadata:          metadata,
+			ReversedJournalId: v.ReversedJournalId,
 		})
 
 		journalEntries = append(journalEntries, models.JournalEntryCreatedRequest{
@@ -245,11 +292,225 @@ func (js *journalService) changeTrxToSplits(
 		})
 	}
 
-	if isSame := js.allSameEntity(arrEntity); !isSame {
-		return nil, nil, nil, nil, nil, models.GetErrMap(models.ErrKeyAccountNumberDifferentEntity)
+	return transactions, splits, splitAccounts, journals, journalEntries, nil
+}
+
+// resolvedJournal is a journal with the account of every line & the generated intercompany legs.
+type resolvedJournal struct {
+	transactions []models.Transaction
+	accounts     []models.GetAccountOut
+	currency     godbledger.Currency
+	postDate     time.Time
+
+	// closedPeriodOverride is true when the journal is posted into a closed period with the override
+	closedPeriodOverride bool
+}
+
+// withClosedPeriodOverride returns a copy of the metadata with the approver & reason of the closed period override,
+// the metadata is stored in every journal detail so the override can be queried afterwards.
+func withClosedPeriodOverride(metadata *models.Metadata, override *models.ClosedPeriodOverride, trxDate time.Time) *models.Metadata {
+	out := models.Metadata{}
+	if metadata != nil {
+		for k, v := range *metadata {
+			out[k] = v
+		}
 	}
+	out[models.MetadataKeyClosedPeriodOverride] = trxDate.Format(atime.DateFormatYYYYMM)
+	out[models.MetadataKeyClosedPeriodOverrideApprovedBy] = override.ApprovedBy
+	out[models.MetadataKeyClosedPeriodOverrideReason] = override.Reason
 
-	return transactions, splits, splitAccounts, journals, journalEntries, nil
+	return &out
+}
+
+// resolveJournal runs every check of posting a journal without writing anything,
+// the accounts must exist in the journal currency, every entity must be balanced & the period must be open.
+func (js *journalService) resolveJournal(ctx context.Context, req models.JournalRequest, trxDate time.Time) (out resolvedJournal, err error) {
+	len := len(req.Transactions)
+	arrEntity := make([]string, 0, len)
+	lines := make([]journalLine, 0, len)
+	accounts := make([]models.GetAccountOut, 0, len)
+
+	// split amounts are stored in the minor unit of the request currency
+	currency, err := js.srv.goDBLedger.GetCurrency(ctx, req.Currency)
+	if err != nil {
+		err = checkDatabaseError(err, models.ErrKeyCurrencyNotFound)
+		return out, err
+	}
+
+	for i, v := range req.Transactions {
+		account, err := js.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, v.Account)
+		if err != nil {
+			err = checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
+			return out, err
+		}
+		if account.Currency != "" && account.Currency != currency.Name {
+			return out, models.GetErrMap(
+				models.ErrKeyAccountCurrencyMismatch,
+				fmt.Sprintf("account %s currency %s", account.AccountNumber, account.Currency),
+			)
+		}
+		if err := checkAccountStatusPosting(account, v.IsDebit); err != nil {
+			return out, err
+		}
+
+		accounts = append(accounts, account)
+		arrEntity = append(arrEntity, account.EntityCode)
+		lines = append(lines, journalLine{
+			Line:       i + 1,
+			Account:    account.AccountNumber,
+			EntityCode: account.EntityCode,
+			Currency:   req.Currency,
+			Amount:     v.Amount,
+			IsDebit:    v.IsDebit,
+		})
+	}
+
+	// a cross entity journal is balanced per entity with the generated due to & due from legs
+	intercompany, err := js.generateIntercompanyLegs(req, lines)
+	if err != nil {
+		return out, err
+	}
+	reqTransactions := append([]models.Transaction{}, req.Transactions...)
+	for i, v := range intercompany {
+		account, err := js.srv.mySqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, v.Account)
+		if err != nil {
+			err = checkDatabaseError(err, models.ErrKeyAccountNumberNotFound)
+			return out, err
+		}
+		if account.Currency != "" && account.Currency != currency.Name {
+			return out, models.GetErrMap(
+				models.ErrKeyAccountCurrencyMismatch,
+				fmt.Sprintf("account %s currency %s", account.AccountNumber, account.Currency),
+			)
+		}
+		if account.EntityCode != v.EntityCode {
+			return out, models.GetErrMap(
+				models.ErrKeyAccountNumberDifferentEntity,
+				fmt.Sprintf("intercompany account %s entity %s", account.AccountNumber, v.EntityCode),
+			)
+		}
+		if err := checkAccountStatusPosting(account, v.IsDebit); err != nil {
+			return out, err
+		}
+
+		reqTransactions = append(reqTransactions, v.Transaction)
+		accounts = append(accounts, account)
+		arrEntity = append(arrEntity, account.EntityCode)
+		lines = append(lines, journalLine{
+			Line:       len + i + 1,
+			Account:    account.AccountNumber,
+			EntityCode: account.EntityCode,
+			Currency:   req.Currency,
+			Amount:     v.Amount,
+			IsDebit:    v.IsDebit,
+		})
+	}
+
+	if err := js.checkBalance(lines); err != nil {
+		return out, err
+	}
+
+	postDate, overridden, err := js.checkClosedPeriod(ctx, req, trxDate, arrEntity)
+	if err != nil {
+		return out, err
+	}
+
+	return resolvedJournal{
+		transactions:         reqTransactions,
+		accounts:             accounts,
+		currency:             currency,
+		postDate:             postDate,
+		closedPeriodOverride: overridden,
+	}, nil
+}
+
+// checkAccountStatusPosting rejects the posting when the status of the account does not allow a debit or credit posting.
+func checkAccountStatusPosting(account models.GetAccountOut, isDebit bool) error {
+	if models.IsPostingAllowed(account.Status, isDebit) {
+		return nil
+	}
+
+	side := "credit"
+	if isDebit {
+		side = "debit"
+	}
+
+	return models.GetErrMap(
+		models.ErrKeyAccountStatusPostingNotAllowed,
+		fmt.Sprintf("account %s status %s %s", account.AccountNumber, account.Status, side),
+	)
+}
+
+// checkClosedPeriod makes sure the journal is not posted into a closed trial balance period.
+// A journal in a closed period is rejected, or moved to the first open period when the redirect policy is configured.
+// The allowClosedPeriod override keeps the original date, it must have an approver and is written to the audit log
+// & the journal metadata, overridden is true when the override is used for any entity.
+func (js *journalService) checkClosedPeriod(ctx context.Context, req models.JournalRequest, trxDate time.Time, entities []string) (postDate time.Time, overridden bool, err error) {
+	period := trxDate.Format(atime.DateFormatYYYYMM)
+	postDate = trxDate
+	checked := make(map[string]bool, len(entities))
+	for _, entityCode := range entities {
+		if checked[entityCode] {
+			continue
+		}
+		checked[entityCode] = true
+
+		tb, err := js.srv.mySqlRepo.GetTrialBalanceRepository().GetByPeriod(ctx, period, entityCode)
+		if err != nil {
+			if errors.Is(err, models.ErrNoRows) {
+				continue
+			}
+			return trxDate, false, checkDatabaseError(err)
+		}
+		if tb.Status != models.TrialBalanceStatusClosed {
+			continue
+		}
+
+		if req.AllowClosedPeriod != nil {
+			if req.AllowClosedPeriod.ApprovedBy == "" {
+				return trxDate, false, models.GetErrMap(models.ErrKeyClosedPeriodApproverRequired)
+			}
+			xlog.Warn(ctx, "[AUDIT-CLOSED-PERIOD-OVERRIDE]",
+				xlog.String("transaction-id", req.TransactionId),
+				xlog.String("period", period),
+				xlog.String("entity-code", entityCode),
+				xlog.String("approved-by", req.AllowClosedPeriod.ApprovedBy),
+				xlog.String("reason", req.AllowClosedPeriod.Reason),
+			)
+			overridden = true
+			continue
+		}
+
+		if js.srv.conf.JournalConfig.ClosedPeriodPolicy != models.ClosedPeriodPolicyRedirect {
+			return trxDate, false, models.GetErrMap(models.ErrKeyPeriodClosed, fmt.Sprintf("period %s entity %s", period, entityCode))
+		}
+
+		open, err := js.srv.mySqlRepo.GetTrialBalanceRepository().GetFirstPeriodByStatusAndEntity(ctx, models.TrialBalanceStatusOpen, entityCode)
+		if err != nil {
+			return trxDate, false, checkDatabaseError(err)
+		}
+		if open == nil || open.Period < period {
+			return trxDate, false, models.GetErrMap(models.ErrKeyOpenPeriodNotFound, fmt.Sprintf("period %s entity %s", period, entityCode))
+		}
+
+		openDate, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMM, open.Period)
+		if err != nil {
+			return trxDate, false, err
+		}
+		openDate = time.Date(openDate.Year(), openDate.Month(), 1, trxDate.Hour(), trxDate.Minute(), trxDate.Second(), trxDate.Nanosecond(), trxDate.Location())
+		if openDate.After(postDate) {
+			postDate = openDate
+		}
+
+		xlog.Info(ctx, "[CLOSED-PERIOD-REDIRECT]",
+			xlog.String("transaction-id", req.TransactionId),
+			xlog.String("period", period),
+			xlog.String("entity-code", entityCode),
+			xlog.String("redirect-period", open.Period),
+		)
+	}
+
+	return postDate, overridden, nil
 }
 
 func (js *journalService) generateSplitId(ctx context.Context) (string, error) {
@@ -305,6 +566,28 @@ func (js *journalService) publishToJournalStream(ctx context.Context, data model
 	return js.srv.publisher.PublishSyncWithKeyAndLog(ctx, "publish transaction to journal stream", js.srv.conf.Kafka.Publishers.JournalStream.Topic, data.TransactionId, data)
 }
 
+// postJournalTransaction inserts a journal posted by the service itself & publishes its entries.
+func (js *journalService) postJournalTransaction(ctx context.Context, req models.JournalRequest) error {
+	journalEntries, err := js.InsertJournalTransaction(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	js.publishJournalEntries(ctx, journalEntries)
+
+	return nil
+}
+
+// publishJournalEntries publishes the entries of a posted journal,
+// the journal is already posted so a failed publish is sent to the dlq & retried from there instead of failing the caller.
+func (js *journalService) publishJournalEntries(ctx context.Context, journalEntries []models.JournalEntryCreatedRequest) {
+	for _, journal := range journalEntries {
+		if err := js.publishToJournalEntryCreated(ctx, journal); err != nil {
+			js.publishToJournalEntryCreatedDLQ(ctx, journal)
+		}
+	}
+}
+
 func (js *journalService) publishToJournalEntryCreated(ctx context.Context, data models.JournalEntryCreatedRequest) error {
 	return js.srv.publisher.PublishSyncWithKeyAndLog(ctx, "publish transaction to journal_entry_created", js.srv.conf.Kafka.Publishers.JournalEntryCreated.Topic, data.JournalID, data)
 }
@@ -327,111 +610,36 @@ func (js *journalService) ProcessUploadJournal(ctx context.Context, file *multip
 		logService(ctx, err)
 	}()
 
-	path := fmt.Sprintf("./%s_%s", atime.Now().Format(atime.DateFormatYYYYMMDDWithTimeWithoutDash), file.Filename)
-	src, err := file.Open()
-	if err != nil {
+	records, err := js.readUploadJournal(file)
+	if err != nil || len(records) == 0 {
 		return
 	}
-	defer src.Close()
 
-	dst, err := js.srv.file.CreateFile(path)
+	uploadJournals, err := parseUploadJournals(records[0], records[1:])
 	if err != nil {
 		return
 	}
-	defer dst.Close()
 
-	if err = js.srv.file.CopyFile(dst, src); err != nil {
-		return err
-	}
-
-	defer js.srv.file.RemoveFile(dst.Name())
-	fs, err := js.srv.file.OpenFile(dst.Name())
-	if err != nil {
-		return
-	}
-
-	records, err := js.srv.file.CSVReadAll(fs)
-	if err != nil {
-		return
-	}
-
-	fmtErr := func(data []string, err error) error {
-		return fmt.Errorf("error process data %s caused by - %v ", strings.Join(data, ","), err)
+	fmtErr := func(uj *uploadJournal, err error) error {
+		return fmt.Errorf("error process transaction %s on rows %v caused by - %v ", uj.journal.TransactionId, uj.rowNumbers(), err)
 	}
 
 	var (
 		journals []models.JournalRequest
 		errs     *multierror.Error
 	)
-	for _, r := range records[1:] {
-		amount, err := decimal.NewFromString(r[11])
-		if err != nil {
-			errs = multierror.Append(errs, fmtErr(r, err))
+	for _, uj := range uploadJournals {
+		if uj.err != nil {
+			errs = multierror.Append(errs, fmtErr(uj, uj.err))
 			continue
 		}
-		journal := models.JournalRequest{
-			ReferenceNumber: strings.TrimSpace(r[0]),
-			TransactionId:   strings.TrimSpace(r[1]),
-			OrderType:       strings.TrimSpace(r[2]),
-			TransactionDate: strings.TrimSpace(r[3]),
-			ProcessingDate:  strings.TrimSpace(r[4]),
-			Currency:        strings.TrimSpace(r[5]),
-			Transactions: []models.Transaction{
-				{
-					TransactionType:     strings.TrimSpace(r[6]),
-					TransactionTypeName: strings.TrimSpace(r[7]),
-					Account:             strings.TrimSpace(r[8]),
-					Narrative:           strings.TrimSpace(r[10]),
-					Amount:              amount,
-					IsDebit:             true,
-				},
-				{
-					TransactionType:     strings.TrimSpace(r[6]),
-					TransactionTypeName: strings.TrimSpace(r[7]),
-					Account:             strings.TrimSpace(r[9]),
-					Narrative:           strings.TrimSpace(r[10]),
-					Amount:              amount,
-					IsDebit:             false,
-				},
-			},
-		}
-
-		if r[18] != "" {
-			if err := json.Unmarshal([]byte(strings.TrimSpace(r[18])), &journal.Metadata); err != nil {
-				errs = multierror.Append(errs, fmtErr(r, err))
-				continue
-			}
-		}
 
-		if r[12] != "" && r[14] != "" && r[15] != "" && r[17] != "" {
-			amount, err := decimal.NewFromString(r[17])
-			if err != nil {
-				errs = multierror.Append(errs, fmtErr(r, err))
-				continue
-			}
-			journal.Transactions = append(journal.Transactions,
-				models.Transaction{
-					TransactionType:     strings.TrimSpace(r[12]),
-					TransactionTypeName: strings.TrimSpace(r[13]),
-					Account:             strings.TrimSpace(r[14]),
-					Narrative:           strings.TrimSpace(r[16]),
-					Amount:              amount,
-					IsDebit:             true,
-				}, models.Transaction{
-					TransactionType:     strings.TrimSpace(r[12]),
-					TransactionTypeName: strings.TrimSpace(r[13]),
-					Account:             strings.TrimSpace(r[15]),
-					Narrative:           strings.TrimSpace(r[16]),
-					Amount:              amount,
-					IsDebit:             false,
-				})
-		}
-		_, _, err = js.validateTransaction(ctx, journal)
+		_, _, err = js.validateTransaction(ctx, uj.journal)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 			continue
 		}
-		journals = append(journals, journal)
+		journals = append(journals, uj.journal)
 	}
 	if errs.ErrorOrNil() != nil {
 		err = errs
@@ -452,6 +660,101 @@ func (js *journalService) ProcessUploadJournal(ctx context.Context, file *multip
 	return
 }
 
+/*
+1. read the upload journal csv or xlsx, the file format is the same as the upload journal
+2. group the rows into journals by the transaction id, then run every check of posting each journal without publishing nor inserting anything
+- parse the journal & the lines of every row by the header
+- the transaction id must not be posted yet
+- validate format date, the dates must not be greater than today
+- the accounts must exist, a cross entity journal must have the intercompany accounts, the journal must be balanced per entity
+- the transaction date must not be in a closed period
+3. return the report of every row, every row of an invalid journal is invalid
+*/
+func (js *journalService) ValidateUploadJournal(ctx context.Context, file *multipart.FileHeader) (out models.UploadJournalReport, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	records, err := js.readUploadJournal(file)
+	if err != nil {
+		return
+	}
+
+	out = models.UploadJournalReport{
+		Kind:     models.KindUploadJournalReport,
+		Filename: file.Filename,
+		Rows:     []models.UploadJournalRowReport{},
+	}
+	if len(records) == 0 {
+		return out, nil
+	}
+	out.Header = records[0]
+
+	uploadJournals, err := parseUploadJournals(records[0], records[1:])
+	if err != nil {
+		return
+	}
+
+	rows := make([]models.UploadJournalRowReport, len(records)-1)
+	for _, uj := range uploadJournals {
+		errValidate := uj.err
+		if errValidate == nil {
+			var trxDate time.Time
+			trxDate, _, errValidate = js.validateTransaction(ctx, uj.journal)
+			if errValidate == nil {
+				_, errValidate = js.resolveJournal(ctx, uj.journal, trxDate)
+			}
+		}
+
+		for _, i := range uj.rows {
+			rows[i] = models.UploadJournalRowReport{
+				Row:           i + 2,
+				TransactionId: uj.journal.TransactionId,
+				Record:        records[i+1],
+			}
+			if errValidate != nil {
+				rows[i].AddError(errValidate)
+			}
+		}
+	}
+	for _, row := range rows {
+		out.AddRow(row)
+	}
+
+	xlog.Info(ctx, "[VALIDATE.UPLOAD.JOURNAL]",
+		xlog.String("filename", file.Filename),
+		xlog.Int("journals", len(uploadJournals)),
+		xlog.Int("valid-rows", out.ValidRows),
+		xlog.Int("invalid-rows", out.InvalidRows),
+	)
+
+	return out, nil
+}
+
+func (js *journalService) DownloadCSVUploadJournalReport(ctx context.Context, report models.UploadJournalReport) (b *bytes.Buffer, filename string, err error) {
+	b = &bytes.Buffer{}
+	js.srv.file.NewCSVWriter(b)
+
+	header, body := report.ToCSV()
+	if err = js.srv.file.CSVWriteHeader(ctx, header); err != nil {
+		err = fmt.Errorf("failed to write header: %w", err)
+		return
+	}
+
+	if err = js.srv.file.CSVWriteAll(ctx, body); err != nil {
+		err = fmt.Errorf("failed to write body: %w", err)
+		return
+	}
+
+	if err = js.srv.file.CSVProcessWrite(ctx); err != nil {
+		return
+	}
+
+	filename = fmt.Sprintf("Validation-%s", report.Filename)
+
+	return
+}
+
 func (js *journalService) GetJournalByTransactionId(ctx context.Context, transactionId string) (out []models.GetJournalDetailOut, err error) {
 	defer func() {
 		logService(ctx, err)
@@ -478,6 +781,146 @@ func (js *journalService) GetJournalByTransactionId(ctx context.Context, transac
 	return out, err
 }
 
+// ReverseJournal posts a contra journal of a transaction, every line is posted on the opposite side.
+// Only the given journal lines are reversed when journalIds is filled, the lines must be balanced per currency and entity,
+// and every line can only be reversed once.
+func (js *journalService) ReverseJournal(ctx context.Context, req models.ReverseJournalRequest) (out models.JournalRequest, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	details, err := js.GetJournalByTransactionId(ctx, req.TransactionId)
+	if err != nil {
+		return
+	}
+	if len(details) == 0 {
+		err = models.GetErrMap(models.ErrKeyTransactionIdNotFound)
+		return
+	}
+
+	selected := details
+	if len(req.JournalIds) > 0 {
+		byJournalId := make(map[string]models.GetJournalDetailOut, len(details))
+		for _, v := range details {
+			byJournalId[v.JournalId] = v
+		}
+
+		selected = make([]models.GetJournalDetailOut, 0, len(req.JournalIds))
+		seen := make(map[string]bool, len(req.JournalIds))
+		for _, journalId := range req.JournalIds {
+			if seen[journalId] {
+				continue
+			}
+			seen[journalId] = true
+
+			detail, ok := byJournalId[journalId]
+			if !ok {
+				err = models.GetErrMap(models.ErrKeyJournalIdNotFound, journalId)
+				return
+			}
+			selected = append(selected, detail)
+		}
+
+		if err = checkReversalBalance(selected); err != nil {
+			return
+		}
+	}
+
+	journalIds := make([]string, 0, len(selected))
+	for _, v := range selected {
+		journalIds = append(journalIds, v.JournalId)
+	}
+	reversed, err := js.srv.mySqlRepo.GetAccountingRepository().GetReversedJournalIds(ctx, journalIds)
+	if err != nil {
+		err = checkDatabaseError(err)
+		return
+	}
+	if len(reversed) > 0 {
+		err = models.GetErrMap(models.ErrKeyJournalAlreadyReversed, strings.Join(reversed, ","))
+		return
+	}
+
+	// the reversal transaction id is derived from the reversed journal lines,
+	// so a concurrent reversal of the same lines fails on the duplicate transaction id
+	sortedJournalIds := append([]string(nil), journalIds...)
+	sort.Strings(sortedJournalIds)
+	transactionId := uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("journal-reversal:%s:%s", req.TransactionId, strings.Join(sortedJournalIds, ",")))).String()
+
+	metadata := models.Metadata{
+		models.MetadataKeyReversalOf: req.TransactionId,
+	}
+	if req.Reason != "" {
+		metadata[models.MetadataKeyReversalReason] = req.Reason
+	}
+
+	now := atime.Now().Format(atime.DateFormatYYYYMMDDWithTime)
+	out = models.JournalRequest{
+		ReferenceNumber: selected[0].ReferenceNumber,
+		TransactionId:   transactionId,
+		OrderType:       selected[0].OrderType,
+		TransactionDate: now,
+		ProcessingDate:  now,
+		Currency:        selected[0].Currency,
+		Metadata:        &metadata,
+	}
+	for _, v := range selected {
+		out.Transactions = append(out.Transactions, models.Transaction{
+			TransactionType:   v.TransactionType,
+			Account:           v.AccountNumber,
+			Narrative:         v.Narrative,
+			Amount:            v.Amount,
+			IsDebit:           !v.IsDebit,
+			ReversedJournalId: v.JournalId,
+		})
+	}
+
+	if err = js.postJournalTransaction(ctx, out); err != nil {
+		return
+	}
+
+	return out, nil
+}
+
+// checkReversalBalance makes sure the selected journal lines of a partial reversal are balanced for every currency and entity,
+// so an unbalanced selection is rejected before the reversal is prepared.
+func checkReversalBalance(selected []models.GetJournalDetailOut) error {
+	type balanceKey struct {
+		EntityCode string
+		Currency   string
+	}
+
+	var keys []balanceKey
+	totals := make(map[balanceKey]decimal.Decimal)
+	for _, v := range selected {
+		key := balanceKey{EntityCode: v.EntityCode, Currency: v.Currency}
+		if _, ok := totals[key]; !ok {
+			keys = append(keys, key)
+		}
+		if v.IsDebit {
+			totals[key] = totals[key].Add(v.Amount)
+		} else {
+			totals[key] = totals[key].Sub(v.Amount)
+		}
+	}
+
+	var causes []string
+	for _, key := range keys {
+		if totals[key].IsZero() {
+			continue
+		}
+		scope := key.Currency
+		if key.EntityCode != "" {
+			scope = fmt.Sprintf("entity %s %s", key.EntityCode, key.Currency)
+		}
+		causes = append(causes, fmt.Sprintf("%s difference %s", scope, totals[key].String()))
+	}
+	if len(causes) > 0 {
+		return models.GetErrMap(models.ErrKeyJournalReversalNotBalanced, strings.Join(causes, "; "))
+	}
+
+	return nil
+}
+
 func (js *journalService) detectAdjustmentTransaction(ctx context.Context, req models.JournalRequest) {
 	var err error
 	logPrefix := "[Adjustment-TrialBalance]"
@@ -546,16 +989,160 @@ func (js *journalService) detectAdjustmentTransaction(ctx context.Context, req m
 	}
 }
 
-func (js *journalService) allSameEntity(arr []string) bool {
-	if len(arr)%2 != 0 || len(arr) == 0 {
-		return false
+// intercompanyLeg is a generated due to or due from transaction of the entity that owns the account.
+type intercompanyLeg struct {
+	models.Transaction
+	EntityCode string
+}
+
+// generateIntercompanyLegs returns the due to & due from transactions that balance every entity of a cross entity journal.
+// The net amount of each entity is settled against the counterparty entities in the order they appear in the journal,
+// an entity with more debit gets a credit on its due to account & the counterparty gets a debit on its due from account.
+func (js *journalService) generateIntercompanyLegs(req models.JournalRequest, lines []journalLine) ([]intercompanyLeg, error) {
+	var entities []string
+	nets := make(map[string]decimal.Decimal)
+	for _, l := range lines {
+		if _, ok := nets[l.EntityCode]; !ok {
+			entities = append(entities, l.EntityCode)
+		}
+		if l.IsDebit {
+			nets[l.EntityCode] = nets[l.EntityCode].Add(l.Amount)
+		} else {
+			nets[l.EntityCode] = nets[l.EntityCode].Sub(l.Amount)
+		}
+	}
+	if len(entities) < 2 {
+		return nil, nil
 	}
 
-	for i := 0; i < len(arr); i += 2 {
-		if arr[i] != arr[i+1] {
-			return false
+	var debtors, creditors []string
+	for _, v := range entities {
+		if nets[v].IsPositive() {
+			debtors = append(debtors, v)
+		} else if nets[v].IsNegative() {
+			creditors = append(creditors, v)
 		}
 	}
 
-	return true
+	var (
+		legs            []intercompanyLeg
+		transactionType = req.Transactions[0].TransactionType
+	)
+	for _, debtor := range debtors {
+		for _, creditor := range creditors {
+			amount := decimal.Min(nets[debtor], nets[creditor].Neg())
+			if !amount.IsPositive() {
+				continue
+			}
+
+			dueTo, ok := js.srv.conf.JournalConfig.IntercompanyAccounts[fmt.Sprintf("%s:%s", debtor, creditor)]
+			if !ok || dueTo.DueToAccount == "" {
+				return nil, models.GetErrMap(models.ErrKeyIntercompanyAccountNotFound, fmt.Sprintf("due to %s from %s", creditor, debtor))
+			}
+			dueFrom, ok := js.srv.conf.JournalConfig.IntercompanyAccounts[fmt.Sprintf("%s:%s", creditor, debtor)]
+			if !ok || dueFrom.DueFromAccount == "" {
+				return nil, models.GetErrMap(models.ErrKeyIntercompanyAccountNotFound, fmt.Sprintf("due from %s to %s", debtor, creditor))
+			}
+
+			narrative := fmt.Sprintf("Intercompany %s - %s", debtor, creditor)
+			legs = append(legs,
+				intercompanyLeg{
+					EntityCode: debtor,
+					Transaction: models.Transaction{
+						TransactionType: transactionType,
+						Account:         dueTo.DueToAccount,
+						Narrative:       narrative,
+						Amount:          amount,
+						IsDebit:         false,
+					},
+				},
+				intercompanyLeg{
+					EntityCode: creditor,
+					Transaction: models.Transaction{
+						TransactionType: transactionType,
+						Account:         dueFrom.DueFromAccount,
+						Narrative:       narrative,
+						Amount:          amount,
+						IsDebit:         true,
+					},
+				},
+			)
+			nets[debtor] = nets[debtor].Sub(amount)
+			nets[creditor] = nets[creditor].Add(amount)
+		}
+	}
+
+	return legs, nil
+}
+
+// journalLine is a single posting of a journal request, used to check the journal balance.
+type journalLine struct {
+	Line       int
+	Account    string
+	EntityCode string
+	Currency   string
+	Amount     decimal.Decimal
+	IsDebit    bool
+}
+
+// checkBalance makes sure total debit equals total credit for every currency and entity.
+// Every line of an unbalanced group is written to the error causer, so the failure can be traced from the DLQ notification.
+func (js *journalService) checkBalance(lines []journalLine) error {
+	type balanceKey struct {
+		EntityCode string
+		Currency   string
+	}
+
+	var (
+		keys    []balanceKey
+		debits  = make(map[balanceKey]decimal.Decimal)
+		credits = make(map[balanceKey]decimal.Decimal)
+		members = make(map[balanceKey][]journalLine)
+	)
+	for _, l := range lines {
+		key := balanceKey{EntityCode: l.EntityCode, Currency: l.Currency}
+		if _, ok := members[key]; !ok {
+			keys = append(keys, key)
+		}
+		members[key] = append(members[key], l)
+
+		if l.IsDebit {
+			debits[key] = debits[key].Add(l.Amount)
+		} else {
+			credits[key] = credits[key].Add(l.Amount)
+		}
+	}
+
+	var causes []string
+	for _, key := range keys {
+		if debits[key].Equal(credits[key]) {
+			continue
+		}
+
+		scope := key.Currency
+		if key.EntityCode != "" {
+			scope = fmt.Sprintf("entity %s %s", key.EntityCode, key.Currency)
+		}
+
+		details := make([]string, 0, len(members[key]))
+		for _, l := range members[key] {
+			side := "credit"
+			if l.IsDebit {
+				side = "debit"
+			}
+			details = append(details, fmt.Sprintf("line %d account %s %s %s", l.Line, l.Account, side, l.Amount.String()))
+		}
+
+		causes = append(causes, fmt.Sprintf("%s total debit %s total credit %s [%s]",
+			scope,
+			debits[key].String(),
+			credits[key].String(),
+			strings.Join(details, ", ")))
+	}
+
+	if len(causes) > 0 {
+		return models.GetErrMap(models.ErrKeyJournalNotBalanced, strings.Join(causes, "; "))
+	}
+
+	return nil
 }
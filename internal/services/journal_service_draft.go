@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/dbutil"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+)
+
+/*
+1. validate the journal the same way as posting it, the transaction id must not be posted yet & the journal must be balanced
+2. make sure every account exists
+3. make sure there is no pending draft with the same transaction id
+4. store the draft, nothing is inserted into transactions & splits until the draft is approved
+*/
+func (js *journalService) CreateJournalDraft(ctx context.Context, req models.CreateJournalDraftRequest) (out *models.JournalDraft, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	if _, _, err = js.validateTransaction(ctx, req.JournalRequest); err != nil {
+		return nil, err
+	}
+
+	for _, v := range req.Transactions {
+		account, errAccount := js.srv.mySqlRepo.GetAccountRepository().CheckAccountNumberIsExist(ctx, v.Account)
+		if errAccount != nil {
+			err = checkDatabaseError(errAccount)
+			return nil, err
+		}
+		if account == nil {
+			err = models.GetErrMap(models.ErrKeyAccountNumberNotFound, v.Account)
+			return nil, err
+		}
+	}
+
+	drafts, err := js.srv.mySqlRepo.GetAccountingRepository().GetJournalDrafts(ctx, models.JournalDraftFilterOptions{
+		TransactionId: req.TransactionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range drafts {
+		if v.IsPending() {
+			err = models.GetErrMap(models.ErrKeyJournalDraftIsExist, strconv.Itoa(v.ID))
+			return nil, err
+		}
+	}
+
+	draft := req.ToJournalDraft()
+	draft.ID, err = js.srv.mySqlRepo.GetAccountingRepository().InsertJournalDraft(ctx, draft)
+	if err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+func (js *journalService) GetJournalDrafts(ctx context.Context, opts models.JournalDraftFilterOptions) (out []models.JournalDraft, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	return js.srv.mySqlRepo.GetAccountingRepository().GetJournalDrafts(ctx, opts)
+}
+
+func (js *journalService) GetJournalDraftById(ctx context.Context, id int) (out *models.JournalDraft, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = js.srv.mySqlRepo.GetAccountingRepository().GetJournalDraftById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		err = models.GetErrMap(models.ErrKeyJournalDraftNotFound, strconv.Itoa(id))
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// SubmitJournalDraft sends the draft to the checkers, only the maker can submit the draft.
+func (js *journalService) SubmitJournalDraft(ctx context.Context, req models.SubmitJournalDraftRequest) (out *models.JournalDraft, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = js.GetJournalDraftById(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if out.Status != models.JournalDraftStatusDraft {
+		err = models.GetErrMap(models.ErrKeyInvalidJournalDraftStatus, out.Status)
+		return nil, err
+	}
+	if !strings.EqualFold(out.CreatedBy, req.SubmittedBy) {
+		err = models.GetErrMap(models.ErrKeyJournalDraftMakerOnly)
+		return nil, err
+	}
+
+	now := atime.Now()
+	out.Status = models.JournalDraftStatusSubmitted
+	out.SubmittedAt = &now
+	if err = updateJournalDraftStatus(ctx, js.srv.mySqlRepo.GetAccountingRepository(), *out, models.JournalDraftStatusDraft); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+/*
+1. the draft must be submitted & the checker must not be the maker
+2. validate the journal again because the accounts & the periods may have changed since the draft was created
+3. post the journal & record the checker on the draft in one transaction,
+a concurrent approval moves no draft row so its journal is rolled back
+4. publish the journal entries
+*/
+func (js *journalService) ApproveJournalDraft(ctx context.Context, req models.ApproveJournalDraftRequest) (out *models.JournalDraft, err error) {
+	ctx = dbutil.NewContextUsePrimaryDB(ctx)
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = js.getSubmittedJournalDraft(ctx, req.ID, req.ApprovedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	journal := models.JournalRequest(out.Journal)
+	metadata := models.Metadata{}
+	if journal.Metadata != nil {
+		for k, v := range *journal.Metadata {
+			metadata[k] = v
+		}
+	}
+	metadata[models.MetadataKeyJournalDraftId] = out.ID
+	metadata[models.MetadataKeyMaker] = out.CreatedBy
+	metadata[models.MetadataKeyChecker] = req.ApprovedBy
+	journal.Metadata = &metadata
+
+	records, journalEntries, err := js.prepareJournalTransaction(ctx, journal)
+	if err != nil {
+		return nil, err
+	}
+
+	now := atime.Now()
+	out.Status = models.JournalDraftStatusApproved
+	out.ReviewedBy = req.ApprovedBy
+	out.ReviewedAt = &now
+	err = js.srv.mySqlRepo.Atomic(ctx, func(actx context.Context, r mysql.SQLRepository) error {
+		if err := insertJournalRecords(actx, r, records); err != nil {
+			return err
+		}
+
+		return updateJournalDraftStatus(actx, r.GetAccountingRepository(), *out, models.JournalDraftStatusSubmitted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	js.publishJournalEntries(ctx, journalEntries)
+
+	return out, nil
+}
+
+// RejectJournalDraft closes the submitted draft without posting it, the maker can create a new draft with the same transaction id.
+func (js *journalService) RejectJournalDraft(ctx context.Context, req models.RejectJournalDraftRequest) (out *models.JournalDraft, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = js.getSubmittedJournalDraft(ctx, req.ID, req.RejectedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	now := atime.Now()
+	out.Status = models.JournalDraftStatusRejected
+	out.ReviewedBy = req.RejectedBy
+	out.ReviewedAt = &now
+	out.RejectionReason = req.Reason
+	if err = updateJournalDraftStatus(ctx, js.srv.mySqlRepo.GetAccountingRepository(), *out, models.JournalDraftStatusSubmitted); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// getSubmittedJournalDraft returns the draft to be reviewed, the checker must not be the maker of the draft.
+func (js *journalService) getSubmittedJournalDraft(ctx context.Context, id int, checker string) (*models.JournalDraft, error) {
+	draft, err := js.GetJournalDraftById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != models.JournalDraftStatusSubmitted {
+		return nil, models.GetErrMap(models.ErrKeyInvalidJournalDraftStatus, draft.Status)
+	}
+	if strings.EqualFold(draft.CreatedBy, checker) {
+		return nil, models.GetErrMap(models.ErrKeyJournalDraftSelfReview)
+	}
+
+	return draft, nil
+}
+
+// updateJournalDraftStatus moves the draft from the given status, no row is updated when the draft is moved by another request.
+func updateJournalDraftStatus(ctx context.Context, r mysql.AccountingRepository, draft models.JournalDraft, fromStatus string) error {
+	err := r.UpdateJournalDraftStatus(ctx, draft, fromStatus)
+	if errors.Is(err, models.ErrNoRowsAffected) {
+		return models.GetErrMap(models.ErrKeyInvalidJournalDraftStatus, fmt.Sprintf("draft is no longer %s", fromStatus))
+	}
+
+	return err
+}
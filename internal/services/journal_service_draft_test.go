@@ -0,0 +1,419 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func journalDraftRequest() models.JournalRequest {
+	now := atime.Now().Format(atime.DateFormatYYYYMMDDWithTime)
+	return models.JournalRequest{
+		ReferenceNumber: "ADJ-001",
+		TransactionId:   "6de11650-dbee-4f67-9ade-ececc7a02571",
+		OrderType:       "ADJ",
+		TransactionDate: now,
+		ProcessingDate:  now,
+		Currency:        "IDR",
+		Transactions: []models.Transaction{
+			{TransactionType: "ADJIN", Account: "511001000000001", Amount: decimal.NewFromInt(100000), IsDebit: true},
+			{TransactionType: "ADJIN", Account: "141001000000001", Amount: decimal.NewFromInt(100000)},
+		},
+		Metadata: &models.Metadata{"note": "manual adjustment"},
+	}
+}
+
+func Test_journalService_CreateJournalDraft(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	req := models.CreateJournalDraftRequest{
+		JournalRequest: journalDraftRequest(),
+		CreatedBy:      "tono@amartha.com",
+	}
+
+	tests := []struct {
+		name    string
+		req     models.CreateJournalDraftRequest
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success case - draft is stored without posting",
+			req:  req,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), req.TransactionId).Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					CheckAccountNumberIsExist(gomock.Any(), gomock.Any()).
+					Return(&models.CheckAccountNumberIsExist{}, nil).Times(2)
+				testHelper.mockAcctRepository.EXPECT().
+					GetJournalDrafts(gomock.Any(), models.JournalDraftFilterOptions{TransactionId: req.TransactionId}).
+					Return([]models.JournalDraft{{ID: 1, Status: models.JournalDraftStatusRejected}}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					InsertJournalDraft(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in models.JournalDraft) (int, error) {
+						assert.Equal(t, models.JournalDraftStatusDraft, in.Status)
+						assert.Equal(t, "tono@amartha.com", in.CreatedBy)
+						assert.Len(t, in.Journal.Transactions, 2)
+						return 2, nil
+					})
+			},
+		},
+		{
+			name: "error case - transaction id is posted",
+			req:  req,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), req.TransactionId).Return(true, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyTransactionIdIsExist),
+		},
+		{
+			name: "error case - journal not balanced",
+			req: func() models.CreateJournalDraftRequest {
+				r := req
+				r.JournalRequest = journalDraftRequest()
+				r.Transactions[1].Amount = decimal.NewFromInt(90000)
+				return r
+			}(),
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), req.TransactionId).Return(false, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyJournalNotBalanced, "IDR total debit 100000 total credit 90000 [line 1 account 511001000000001 debit 100000, line 2 account 141001000000001 credit 90000]"),
+		},
+		{
+			name: "error case - account not found",
+			req:  req,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), req.TransactionId).Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().CheckAccountNumberIsExist(gomock.Any(), "511001000000001").Return(nil, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyAccountNumberNotFound, "511001000000001"),
+		},
+		{
+			name: "error case - pending draft is exist",
+			req:  req,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), req.TransactionId).Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					CheckAccountNumberIsExist(gomock.Any(), gomock.Any()).
+					Return(&models.CheckAccountNumberIsExist{}, nil).Times(2)
+				testHelper.mockAcctRepository.EXPECT().
+					GetJournalDrafts(gomock.Any(), gomock.Any()).
+					Return([]models.JournalDraft{{ID: 1, Status: models.JournalDraftStatusSubmitted}}, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyJournalDraftIsExist, "1"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			out, err := testHelper.journalService.CreateJournalDraft(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 2, out.ID)
+		})
+	}
+}
+
+func Test_journalService_SubmitJournalDraft(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	draft := func(status string) *models.JournalDraft {
+		return &models.JournalDraft{ID: 1, Status: status, CreatedBy: "tono@amartha.com"}
+	}
+
+	tests := []struct {
+		name    string
+		req     models.SubmitJournalDraftRequest
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success case",
+			req:  models.SubmitJournalDraftRequest{ID: 1, SubmittedBy: "Tono@amartha.com"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusDraft), nil)
+				testHelper.mockAcctRepository.EXPECT().
+					UpdateJournalDraftStatus(gomock.Any(), gomock.Any(), models.JournalDraftStatusDraft).
+					DoAndReturn(func(ctx context.Context, in models.JournalDraft, fromStatus string) error {
+						assert.Equal(t, models.JournalDraftStatusSubmitted, in.Status)
+						assert.NotNil(t, in.SubmittedAt)
+						return nil
+					})
+			},
+		},
+		{
+			name: "error case - draft not found",
+			req:  models.SubmitJournalDraftRequest{ID: 1, SubmittedBy: "tono@amartha.com"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(nil, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyJournalDraftNotFound, "1"),
+		},
+		{
+			name: "error case - draft is already submitted",
+			req:  models.SubmitJournalDraftRequest{ID: 1, SubmittedBy: "tono@amartha.com"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusSubmitted), nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyInvalidJournalDraftStatus, models.JournalDraftStatusSubmitted),
+		},
+		{
+			name: "error case - submitted by other than the maker",
+			req:  models.SubmitJournalDraftRequest{ID: 1, SubmittedBy: "budi@amartha.com"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusDraft), nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyJournalDraftMakerOnly),
+		},
+		{
+			name: "error case - draft is moved by another request",
+			req:  models.SubmitJournalDraftRequest{ID: 1, SubmittedBy: "tono@amartha.com"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusDraft), nil)
+				testHelper.mockAcctRepository.EXPECT().
+					UpdateJournalDraftStatus(gomock.Any(), gomock.Any(), models.JournalDraftStatusDraft).
+					Return(models.ErrNoRowsAffected)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyInvalidJournalDraftStatus, "draft is no longer draft"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			out, err := testHelper.journalService.SubmitJournalDraft(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, models.JournalDraftStatusSubmitted, out.Status)
+		})
+	}
+}
+
+func Test_journalService_ApproveJournalDraft(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	testHelper.mockGoDbLedger.EXPECT().
+		GetCurrency(gomock.Any(), godbledger.CurrencyIDR.Name).
+		Return(godbledger.CurrencyIDR, nil).
+		AnyTimes()
+	journal := journalDraftRequest()
+	draft := func(status string) *models.JournalDraft {
+		return &models.JournalDraft{
+			ID:            1,
+			TransactionId: journal.TransactionId,
+			Journal:       models.JournalDraftPayload(journal),
+			Status:        status,
+			CreatedBy:     "tono@amartha.com",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		req     models.ApproveJournalDraftRequest
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success case - journal is posted on approval",
+			req:  models.ApproveJournalDraftRequest{ID: 1, ApprovedBy: "budi@amartha.com"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusSubmitted), nil)
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), journal.TransactionId).Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "511001000000001").
+					Return(models.GetAccountOut{AccountNumber: "511001000000001", EntityCode: "001"}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "141001000000001").
+					Return(models.GetAccountOut{AccountNumber: "141001000000001", EntityCode: "001"}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), atime.Now().Format(atime.DateFormatYYYYMM), "001").
+					Return(nil, models.ErrNoRows)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).Times(2)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().
+							InsertJournalDetail(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, in []models.CreateJournalDetail) error {
+								metadata := *in[0].Metadata
+								assert.Equal(t, 1, metadata[models.MetadataKeyJournalDraftId])
+								assert.Equal(t, "tono@amartha.com", metadata[models.MetadataKeyMaker])
+								assert.Equal(t, "budi@amartha.com", metadata[models.MetadataKeyChecker])
+								assert.Equal(t, "manual adjustment", metadata["note"])
+								return nil
+							})
+						testHelper.mockAcctRepository.EXPECT().
+							UpdateJournalDraftStatus(gomock.Any(), gomock.Any(), models.JournalDraftStatusSubmitted).
+							DoAndReturn(func(ctx context.Context, in models.JournalDraft, fromStatus string) error {
+								assert.Equal(t, models.JournalDraftStatusApproved, in.Status)
+								assert.Equal(t, "budi@amartha.com", in.ReviewedBy)
+								assert.NotNil(t, in.ReviewedAt)
+								return nil
+							})
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(2)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - draft is approved by another request, the journal is rolled back",
+			req:  models.ApproveJournalDraftRequest{ID: 1, ApprovedBy: "budi@amartha.com"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusSubmitted), nil)
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), journal.TransactionId).Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "511001000000001").
+					Return(models.GetAccountOut{AccountNumber: "511001000000001", EntityCode: "001"}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "141001000000001").
+					Return(models.GetAccountOut{AccountNumber: "141001000000001", EntityCode: "001"}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), atime.Now().Format(atime.DateFormatYYYYMM), "001").
+					Return(nil, models.ErrNoRows)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).Times(2)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertJournalDetail(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().
+							UpdateJournalDraftStatus(gomock.Any(), gomock.Any(), models.JournalDraftStatusSubmitted).
+							Return(models.ErrNoRowsAffected)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - maker approves own draft",
+			req:  models.ApproveJournalDraftRequest{ID: 1, ApprovedBy: "TONO@amartha.com"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusSubmitted), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - draft is not submitted",
+			req:  models.ApproveJournalDraftRequest{ID: 1, ApprovedBy: "budi@amartha.com"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusDraft), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - transaction id is posted since the draft was created",
+			req:  models.ApproveJournalDraftRequest{ID: 1, ApprovedBy: "budi@amartha.com"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusSubmitted), nil)
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), journal.TransactionId).Return(true, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			out, err := testHelper.journalService.ApproveJournalDraft(context.Background(), tt.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, models.JournalDraftStatusApproved, out.Status)
+			}
+		})
+	}
+}
+
+func Test_journalService_RejectJournalDraft(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	draft := func(status string) *models.JournalDraft {
+		return &models.JournalDraft{ID: 1, Status: status, CreatedBy: "tono@amartha.com"}
+	}
+
+	tests := []struct {
+		name    string
+		req     models.RejectJournalDraftRequest
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success case",
+			req:  models.RejectJournalDraftRequest{ID: 1, RejectedBy: "budi@amartha.com", Reason: "wrong account"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusSubmitted), nil)
+				testHelper.mockAcctRepository.EXPECT().
+					UpdateJournalDraftStatus(gomock.Any(), gomock.Any(), models.JournalDraftStatusSubmitted).
+					DoAndReturn(func(ctx context.Context, in models.JournalDraft, fromStatus string) error {
+						assert.Equal(t, models.JournalDraftStatusRejected, in.Status)
+						assert.Equal(t, "budi@amartha.com", in.ReviewedBy)
+						assert.Equal(t, "wrong account", in.RejectionReason)
+						return nil
+					})
+			},
+		},
+		{
+			name: "error case - maker rejects own draft",
+			req:  models.RejectJournalDraftRequest{ID: 1, RejectedBy: "tono@amartha.com", Reason: "wrong account"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusSubmitted), nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyJournalDraftSelfReview),
+		},
+		{
+			name: "error case - draft is already approved",
+			req:  models.RejectJournalDraftRequest{ID: 1, RejectedBy: "budi@amartha.com", Reason: "wrong account"},
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetJournalDraftById(gomock.Any(), 1).Return(draft(models.JournalDraftStatusApproved), nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyInvalidJournalDraftStatus, models.JournalDraftStatusApproved),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			out, err := testHelper.journalService.RejectJournalDraft(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, models.JournalDraftStatusRejected, out.Status)
+		})
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"mime/multipart"
 	"testing"
 
+	"bitbucket.org/Amartha/go-accounting/internal/config"
 	"bitbucket.org/Amartha/go-accounting/internal/models"
 	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/godbledger"
 	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
 
 	"github.com/google/uuid"
@@ -18,6 +20,10 @@ import (
 func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 	testHelper := serviceTestHelper(t)
 	ctx := context.Background()
+	testHelper.mockGoDbLedger.EXPECT().
+		GetCurrency(gomock.Any(), godbledger.CurrencyIDR.Name).
+		Return(godbledger.CurrencyIDR, nil).
+		AnyTimes()
 	req := models.JournalRequest{
 		TransactionId:   uuid.New().String(),
 		TransactionDate: atime.DateFormatYYYYMMDDWithTime,
@@ -28,6 +34,7 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 				Account:         "TEST1",
 				TransactionType: "TEST1",
 				Amount:          decimal.NewFromFloat(10000),
+				IsDebit:         true,
 			},
 			{
 				Account:         "TEST2",
@@ -36,6 +43,10 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 			},
 		},
 	}
+	intercompanyAccounts := map[string]config.IntercompanyAccountConfig{
+		"001:002": {DueFromAccount: "DUEFROM001", DueToAccount: "DUETO001"},
+		"002:001": {DueFromAccount: "DUEFROM002", DueToAccount: "DUETO002"},
+	}
 
 	tests := []struct {
 		name    string
@@ -65,6 +76,12 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 						AccountNumber: "TEST1",
 						EntityCode:    "001",
 					}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{
+						Period: "2006-01",
+						Status: models.TrialBalanceStatusOpen,
+					}, nil)
 				testHelper.mockMySQLRepository.EXPECT().
 					Atomic(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
@@ -135,6 +152,80 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error case - total debit and credit not balanced",
+			req: models.JournalRequest{
+				TransactionId:   uuid.New().String(),
+				TransactionDate: atime.DateFormatYYYYMMDDWithTime,
+				ProcessingDate:  atime.DateFormatYYYYMMDDWithTime,
+				Currency:        "IDR",
+				Transactions: []models.Transaction{
+					{
+						Account:         "TEST1",
+						TransactionType: "TEST1",
+						Amount:          decimal.NewFromFloat(10000),
+						IsDebit:         true,
+					},
+					{
+						Account:         "TEST2",
+						TransactionType: "TEST2",
+						Amount:          decimal.NewFromFloat(5000),
+					},
+				},
+			},
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - total debit and credit not balanced per entity",
+			req: models.JournalRequest{
+				TransactionId:   uuid.New().String(),
+				TransactionDate: atime.DateFormatYYYYMMDDWithTime,
+				ProcessingDate:  atime.DateFormatYYYYMMDDWithTime,
+				Currency:        "IDR",
+				Transactions: []models.Transaction{
+					{
+						Account:         "TEST1",
+						TransactionType: "TEST1",
+						Amount:          decimal.NewFromFloat(10000),
+						IsDebit:         true,
+					},
+					{
+						Account:         "TEST2",
+						TransactionType: "TEST2",
+						Amount:          decimal.NewFromFloat(10000),
+					},
+				},
+			},
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST1",
+						EntityCode:    "001",
+					}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST2",
+						EntityCode:    "002",
+					}, nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
 		{
 			name: "error case - invalid format transaction date",
 			req: models.JournalRequest{
@@ -217,6 +308,15 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 				testHelper.mockAcctRepository.EXPECT().
 					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
 					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST",
+						EntityCode:    "001",
+					}, nil).Times(2)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(nil, models.ErrNoRows)
 				testHelper.mockCacheRepository.EXPECT().
 					GetIncrement(gomock.Any(), "splitIdCounter").
 					Return(int64(1), assert.AnError)
@@ -233,9 +333,6 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 				testHelper.mockAcctRepository.EXPECT().
 					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
 					Return(false, nil)
-				testHelper.mockCacheRepository.EXPECT().
-					GetIncrement(gomock.Any(), "splitIdCounter").Return(int64(1), nil).
-					MaxTimes(2)
 				testHelper.mockAccRepository.EXPECT().
 					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
 					Return(models.GetAccountOut{}, assert.AnError)
@@ -252,9 +349,6 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 				testHelper.mockAcctRepository.EXPECT().
 					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
 					Return(false, nil)
-				testHelper.mockCacheRepository.EXPECT().
-					GetIncrement(gomock.Any(), "splitIdCounter").
-					Return(int64(1), nil).MaxTimes(2)
 				testHelper.mockAccRepository.EXPECT().
 					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
 					Return(models.GetAccountOut{}, models.ErrNoRows)
@@ -264,6 +358,115 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error case - currency not found",
+			req: models.JournalRequest{
+				TransactionId:   req.TransactionId,
+				TransactionDate: req.TransactionDate,
+				ProcessingDate:  req.ProcessingDate,
+				Currency:        "XYZ",
+				Transactions:    req.Transactions,
+			},
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockGoDbLedger.EXPECT().
+					GetCurrency(gomock.Any(), "XYZ").
+					Return(nil, models.ErrNoRows)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - account currency is different",
+			req:  req,
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST",
+						EntityCode:    "001",
+						Currency:      "USD",
+					}, nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - account is closed",
+			req:  req,
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST1",
+						EntityCode:    "001",
+						Status:        models.AccountStatusClosed,
+					}, nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - credit only account is debited",
+			req:  req,
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST1",
+						EntityCode:    "001",
+						Status:        models.AccountStatusFrozenCreditOnly,
+					}, nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - debit only account is credited",
+			req:  req,
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST1",
+						EntityCode:    "001",
+						Status:        models.AccountStatusActive,
+					}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST2",
+						EntityCode:    "001",
+						Status:        models.AccountStatusFrozenDebitOnly,
+					}, nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
 		{
 			name: "error case - account entity is diffrent",
 			req:  req,
@@ -271,9 +474,6 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 				testHelper.mockAcctRepository.EXPECT().
 					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
 					Return(false, nil)
-				testHelper.mockCacheRepository.EXPECT().
-					GetIncrement(gomock.Any(), "splitIdCounter").
-					Return(int64(1), nil).MaxTimes(2)
 				testHelper.mockAccRepository.EXPECT().
 					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
 					Return(models.GetAccountOut{
@@ -314,6 +514,12 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 						AccountNumber: "TEST1",
 						EntityCode:    "001",
 					}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{
+						Period: "2006-01",
+						Status: models.TrialBalanceStatusOpen,
+					}, nil)
 				testHelper.mockMySQLRepository.EXPECT().
 					Atomic(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
@@ -348,6 +554,12 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 						AccountNumber: "TEST1",
 						EntityCode:    "001",
 					}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{
+						Period: "2006-01",
+						Status: models.TrialBalanceStatusOpen,
+					}, nil)
 				testHelper.mockMySQLRepository.EXPECT().
 					Atomic(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
@@ -372,62 +584,344 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 					GetIncrement(gomock.Any(), "splitIdCounter").Return(int64(1), nil).
 					MaxTimes(2)
 				testHelper.mockAccRepository.EXPECT().
-					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
-					Return(models.GetAccountOut{
-						AccountNumber: "TEST",
-						EntityCode:    "001",
-					}, nil)
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST",
+						EntityCode:    "001",
+					}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST1",
+						EntityCode:    "001",
+					}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{
+						Period: "2006-01",
+						Status: models.TrialBalanceStatusOpen,
+					}, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(assert.AnError)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - failed insert journal detail",
+			req:  req,
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).MaxTimes(2)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST",
+						EntityCode:    "001",
+					}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST1",
+						EntityCode:    "001",
+					}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{
+						Period: "2006-01",
+						Status: models.TrialBalanceStatusOpen,
+					}, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertJournalDetail(gomock.Any(), gomock.Any()).Return(assert.AnError)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - period is closed",
+			req:  req,
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST",
+						EntityCode:    "001",
+					}, nil).Times(2)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{
+						Period: "2006-01",
+						Status: models.TrialBalanceStatusClosed,
+					}, nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - closed period override without approver",
+			req: func() models.JournalRequest {
+				r := req
+				r.TransactionId = uuid.New().String()
+				r.AllowClosedPeriod = &models.ClosedPeriodOverride{
+					ApprovedBy: "",
+					Reason:     "late correction",
+				}
+				return r
+			}(),
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST",
+						EntityCode:    "001",
+					}, nil).Times(2)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{
+						Period: "2006-01",
+						Status: models.TrialBalanceStatusClosed,
+					}, nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success case - closed period override with approver",
+			req: func() models.JournalRequest {
+				r := req
+				r.TransactionId = uuid.New().String()
+				r.AllowClosedPeriod = &models.ClosedPeriodOverride{
+					ApprovedBy: "tono@amartha.com",
+					Reason:     "late correction",
+				}
+				return r
+			}(),
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST",
+						EntityCode:    "001",
+					}, nil).Times(2)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{
+						Period: "2006-01",
+						Status: models.TrialBalanceStatusClosed,
+					}, nil)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).Times(2)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertJournalDetail(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, in []models.CreateJournalDetail) error {
+								for _, v := range in {
+									assert.Equal(t, "2006-01", (*v.Metadata)[models.MetadataKeyClosedPeriodOverride])
+									assert.Equal(t, "tono@amartha.com", (*v.Metadata)[models.MetadataKeyClosedPeriodOverrideApprovedBy])
+									assert.Equal(t, "late correction", (*v.Metadata)[models.MetadataKeyClosedPeriodOverrideReason])
+								}
+								return nil
+							})
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockFlag.EXPECT().
+					IsEnabled(models.FlagTrialBalanceAutoAdjustment.String()).
+					Return(false)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success case - redirect to first open period",
+			req:  req,
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.config.JournalConfig.ClosedPeriodPolicy = models.ClosedPeriodPolicyRedirect
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST",
+						EntityCode:    "001",
+					}, nil).Times(2)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{
+						Period: "2006-01",
+						Status: models.TrialBalanceStatusClosed,
+					}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetFirstPeriodByStatusAndEntity(gomock.Any(), models.TrialBalanceStatusOpen, "001").
+					Return(&models.TrialBalancePeriod{
+						Period:     "2006-02",
+						EntityCode: "001",
+						Status:     models.TrialBalanceStatusOpen,
+					}, nil)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).Times(2)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertJournalDetail(gomock.Any(), gomock.Any()).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockFlag.EXPECT().
+					IsEnabled(models.FlagTrialBalanceAutoAdjustment.String()).
+					Return(false)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - redirect without open period",
+			req:  req,
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.config.JournalConfig.ClosedPeriodPolicy = models.ClosedPeriodPolicyRedirect
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{
+						AccountNumber: "TEST",
+						EntityCode:    "001",
+					}, nil).Times(2)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{
+						Period: "2006-01",
+						Status: models.TrialBalanceStatusClosed,
+					}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetFirstPeriodByStatusAndEntity(gomock.Any(), models.TrialBalanceStatusOpen, "001").
+					Return(nil, nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success case - intercompany journal with due to & due from legs",
+			req: func() models.JournalRequest {
+				r := req
+				r.TransactionId = uuid.New().String()
+				return r
+			}(),
+			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.config.JournalConfig.IntercompanyAccounts = intercompanyAccounts
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "TEST1").
+					Return(models.GetAccountOut{AccountNumber: "TEST1", EntityCode: "001"}, nil)
 				testHelper.mockAccRepository.EXPECT().
-					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
-					Return(models.GetAccountOut{
-						AccountNumber: "TEST1",
-						EntityCode:    "001",
-					}, nil)
+					GetOneByAccountNumber(gomock.Any(), "TEST2").
+					Return(models.GetAccountOut{AccountNumber: "TEST2", EntityCode: "002"}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "DUETO001").
+					Return(models.GetAccountOut{AccountNumber: "DUETO001", EntityCode: "001"}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "DUEFROM002").
+					Return(models.GetAccountOut{AccountNumber: "DUEFROM002", EntityCode: "002"}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "001").
+					Return(&models.TrialBalancePeriod{Period: "2006-01", Status: models.TrialBalanceStatusOpen}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), "2006-01", "002").
+					Return(&models.TrialBalancePeriod{Period: "2006-01", Status: models.TrialBalanceStatusOpen}, nil)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).Times(4)
 				testHelper.mockMySQLRepository.EXPECT().
 					Atomic(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
 						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
-						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
-						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(assert.AnError)
+						testHelper.mockAcctRepository.EXPECT().
+							InsertSplit(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, splits []models.CreateSplit) error {
+								assert.Len(t, splits, 4)
+								return nil
+							})
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertJournalDetail(gomock.Any(), gomock.Any()).Return(nil)
 						return steps(ctx, testHelper.mockMySQLRepository)
 					})
+				testHelper.mockFlag.EXPECT().
+					IsEnabled(models.FlagTrialBalanceAutoAdjustment.String()).
+					Return(false)
 				testHelper.mockPublisher.EXPECT().
 					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					AnyTimes()
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "error case - failed insert journal detail",
+			name: "error case - intercompany account belongs to a different entity",
 			req:  req,
 			doMock: func(ctx context.Context, req models.JournalRequest) {
+				testHelper.config.JournalConfig.IntercompanyAccounts = intercompanyAccounts
 				testHelper.mockAcctRepository.EXPECT().
 					CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).
 					Return(false, nil)
-				testHelper.mockCacheRepository.EXPECT().
-					GetIncrement(gomock.Any(), "splitIdCounter").
-					Return(int64(1), nil).MaxTimes(2)
 				testHelper.mockAccRepository.EXPECT().
-					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
-					Return(models.GetAccountOut{
-						AccountNumber: "TEST",
-						EntityCode:    "001",
-					}, nil)
+					GetOneByAccountNumber(gomock.Any(), "TEST1").
+					Return(models.GetAccountOut{AccountNumber: "TEST1", EntityCode: "001"}, nil)
 				testHelper.mockAccRepository.EXPECT().
-					GetOneByAccountNumber(gomock.Any(), gomock.Any()).
-					Return(models.GetAccountOut{
-						AccountNumber: "TEST1",
-						EntityCode:    "001",
-					}, nil)
-				testHelper.mockMySQLRepository.EXPECT().
-					Atomic(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
-						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
-						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
-						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
-						testHelper.mockAcctRepository.EXPECT().InsertJournalDetail(gomock.Any(), gomock.Any()).Return(assert.AnError)
-						return steps(ctx, testHelper.mockMySQLRepository)
-					})
+					GetOneByAccountNumber(gomock.Any(), "TEST2").
+					Return(models.GetAccountOut{AccountNumber: "TEST2", EntityCode: "002"}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "DUETO001").
+					Return(models.GetAccountOut{AccountNumber: "DUETO001", EntityCode: "002"}, nil)
 				testHelper.mockPublisher.EXPECT().
 					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					AnyTimes()
@@ -443,6 +937,8 @@ func Test_journalService_ConsumerInsertTransaction(t *testing.T) {
 			}
 			err := testHelper.journalService.ConsumerInsertTransaction(ctx, tt.req)
 			assert.Equal(t, tt.wantErr, err != nil)
+			testHelper.config.JournalConfig.ClosedPeriodPolicy = ""
+			testHelper.config.JournalConfig.IntercompanyAccounts = nil
 		})
 	}
 }
@@ -460,6 +956,12 @@ func Test_journalService_PublishJournalTransaction(t *testing.T) {
 				Account:         "TEST",
 				TransactionType: "TEST",
 				Amount:          decimal.NewFromFloat(10000),
+				IsDebit:         true,
+			},
+			{
+				Account:         "TEST1",
+				TransactionType: "TEST",
+				Amount:          decimal.NewFromFloat(10000),
 			},
 		},
 	}
@@ -846,6 +1348,210 @@ func Test_journalService_ProcessUploadJournal(t *testing.T) {
 	}
 }
 
+func Test_journalService_ValidateUploadJournal(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	f := createMultipartFormData(t)
+	o := mustOpen("../../storages/upload_journals.csv")
+	header := []string{"reference_number", "transaction_id", "order_type", "transaction_date", "processing_date", "currency", "transaction_type1", "transaction_type_name1", "account_debit1", "account_credit1", "narrative1", "amount1", "transaction_type2", "transaction_type_name2", "account_debit2", "account_credit2", "narrative2", "amount2", "metadata"}
+	row := []string{"001-TRX-MANUAL-23042024-1", "b13583d7-adba-4aba-9261-3dee86867ec7s", "TUP", "2024-04-23 09:20:00", "2024-04-23 09:20:00", "IDR", "TUPIN", "Amartha Top Up represents Institutional Lender", "142001000000001", "211001000381110", "Amartha Top Up represents Institutional Lender", "3368495938", "", "", "", "", "", "", ""}
+	invalidRow := append([]string{}, row...)
+	invalidRow[1], invalidRow[11] = "c2a7c1f0-5b1e-4f0e-9d55-0d0c3a6b1e21", "3368495938A"
+
+	type args struct {
+		ctx  context.Context
+		file *multipart.FileHeader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		want    []models.UploadJournalRowReport
+		wantErr bool
+	}{
+		{
+			name: "success case - report every row without posting",
+			args: args{
+				ctx:  context.TODO(),
+				file: f,
+			},
+			doMock: func(args args) {
+				testHelper.mockFile.EXPECT().CreateFile(gomock.Any()).Return(o, nil)
+				testHelper.mockFile.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockFile.EXPECT().RemoveFile(gomock.Any()).Return(nil)
+				testHelper.mockFile.EXPECT().OpenFile(gomock.Any()).Return(o, nil)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{
+					header,
+					row,
+					row,
+					invalidRow,
+				}, nil)
+
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).Return(false, nil)
+				testHelper.mockGoDbLedger.EXPECT().GetCurrency(gomock.Any(), godbledger.CurrencyIDR.Name).Return(godbledger.CurrencyIDR, nil)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), gomock.Any()).Return(models.GetAccountOut{
+					AccountNumber: "142001000000001",
+					EntityCode:    "001",
+				}, nil).Times(4)
+				testHelper.mockTrialBalanceRepository.EXPECT().GetByPeriod(gomock.Any(), "2024-04", "001").Return(&models.TrialBalancePeriod{
+					Period: "2024-04",
+					Status: models.TrialBalanceStatusOpen,
+				}, nil)
+			},
+			want: []models.UploadJournalRowReport{
+				{
+					Row:           2,
+					TransactionId: "b13583d7-adba-4aba-9261-3dee86867ec7s",
+					Status:        models.UploadJournalRowStatusValid,
+					Errors:        []string{},
+					Record:        row,
+				},
+				{
+					Row:           3,
+					TransactionId: "b13583d7-adba-4aba-9261-3dee86867ec7s",
+					Status:        models.UploadJournalRowStatusValid,
+					Errors:        []string{},
+					Record:        row,
+				},
+				{
+					Row:           4,
+					TransactionId: "c2a7c1f0-5b1e-4f0e-9d55-0d0c3a6b1e21",
+					Status:        models.UploadJournalRowStatusInvalid,
+					Errors:        []string{"invalid amount1: can't convert 3368495938A to decimal"},
+					Record:        invalidRow,
+				},
+			},
+		},
+		{
+			name: "success case - account not found",
+			args: args{
+				ctx:  context.TODO(),
+				file: f,
+			},
+			doMock: func(args args) {
+				testHelper.mockFile.EXPECT().CreateFile(gomock.Any()).Return(o, nil)
+				testHelper.mockFile.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockFile.EXPECT().RemoveFile(gomock.Any()).Return(nil)
+				testHelper.mockFile.EXPECT().OpenFile(gomock.Any()).Return(o, nil)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{header, row}, nil)
+
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).Return(false, nil)
+				testHelper.mockGoDbLedger.EXPECT().GetCurrency(gomock.Any(), godbledger.CurrencyIDR.Name).Return(godbledger.CurrencyIDR, nil)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), gomock.Any()).Return(models.GetAccountOut{}, models.ErrNoRows)
+			},
+			want: []models.UploadJournalRowReport{
+				{
+					Row:           2,
+					TransactionId: "b13583d7-adba-4aba-9261-3dee86867ec7s",
+					Status:        models.UploadJournalRowStatusInvalid,
+					Errors:        []string{"account number not found"},
+					Record:        row,
+				},
+			},
+		},
+		{
+			name: "error case - csv read",
+			args: args{
+				ctx:  context.TODO(),
+				file: f,
+			},
+			doMock: func(args args) {
+				testHelper.mockFile.EXPECT().CreateFile(gomock.Any()).Return(o, nil)
+				testHelper.mockFile.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockFile.EXPECT().RemoveFile(gomock.Any()).Return(nil)
+				testHelper.mockFile.EXPECT().OpenFile(gomock.Any()).Return(o, nil)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{}, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+			got, err := testHelper.journalService.ValidateUploadJournal(tt.args.ctx, tt.args.file)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got.Rows)
+			}
+		})
+	}
+}
+
+func Test_journalService_DownloadCSVUploadJournalReport(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	report := models.UploadJournalReport{
+		Filename: "upload_journals.csv",
+		Header:   []string{"reference_number", "transaction_id"},
+	}
+	report.AddRow(models.UploadJournalRowReport{
+		Row:    2,
+		Errors: []string{"transaction id is exist"},
+		Record: []string{"001-TRX-MANUAL-23042024-1", "b13583d7-adba-4aba-9261-3dee86867ec7s"},
+	})
+
+	tests := []struct {
+		name         string
+		doMock       func()
+		wantFilename string
+		wantErr      bool
+	}{
+		{
+			name: "success case",
+			doMock: func() {
+				testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+				testHelper.mockFile.EXPECT().CSVWriteHeader(gomock.Any(), []string{"reference_number", "transaction_id", "validation_status", "validation_errors"}).Return(nil)
+				testHelper.mockFile.EXPECT().CSVWriteAll(gomock.Any(), [][]string{
+					{"001-TRX-MANUAL-23042024-1", "b13583d7-adba-4aba-9261-3dee86867ec7s", models.UploadJournalRowStatusInvalid, "transaction id is exist"},
+				}).Return(nil)
+				testHelper.mockFile.EXPECT().CSVProcessWrite(gomock.Any()).Return(nil)
+			},
+			wantFilename: "Validation-upload_journals.csv",
+		},
+		{
+			name: "error case - write header",
+			doMock: func() {
+				testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+				testHelper.mockFile.EXPECT().CSVWriteHeader(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - write body",
+			doMock: func() {
+				testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+				testHelper.mockFile.EXPECT().CSVWriteHeader(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockFile.EXPECT().CSVWriteAll(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - process write",
+			doMock: func() {
+				testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+				testHelper.mockFile.EXPECT().CSVWriteHeader(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockFile.EXPECT().CSVWriteAll(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockFile.EXPECT().CSVProcessWrite(gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			_, filename, err := testHelper.journalService.DownloadCSVUploadJournalReport(context.TODO(), report)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantFilename, filename)
+		})
+	}
+}
+
 func Test_journalService_GetJournalByTransactionId(t *testing.T) {
 	testHelper := serviceTestHelper(t)
 
@@ -1021,3 +1727,212 @@ func Test_journalService_RetryPublishToJournalEntryCreated(t *testing.T) {
 		})
 	}
 }
+
+func Test_journalService_ReverseJournal(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+	testHelper.mockGoDbLedger.EXPECT().
+		GetCurrency(gomock.Any(), godbledger.CurrencyIDR.Name).
+		Return(godbledger.CurrencyIDR, nil).
+		AnyTimes()
+
+	transactionId := "12c5692e-cfbd-4cee-a4ce-86eac1447d48"
+	reversalTransactionId := uuid.NewSHA1(uuid.NameSpaceOID, []byte("journal-reversal:"+transactionId+":2024060601093678,2024060601093679")).String()
+	details := []models.GetJournalDetailOut{
+		{
+			TransactionId:   transactionId,
+			JournalId:       "2024060601093678",
+			AccountNumber:   "121001000000008",
+			EntityCode:      "001",
+			TransactionType: "PAYGL",
+			ReferenceNumber: "123456",
+			OrderType:       "PAY",
+			Amount:          decimal.NewFromFloat(10000),
+			Narrative:       "Repayment Group Loan",
+			IsDebit:         true,
+			Currency:        "IDR",
+		},
+		{
+			TransactionId:   transactionId,
+			JournalId:       "2024060601093679",
+			AccountNumber:   "143001000000168",
+			EntityCode:      "001",
+			TransactionType: "PAYGL",
+			ReferenceNumber: "123456",
+			OrderType:       "PAY",
+			Amount:          decimal.NewFromFloat(10000),
+			Narrative:       "Repayment Group Loan",
+			IsDebit:         false,
+			Currency:        "IDR",
+		},
+	}
+	mockGetJournal := func() {
+		testHelper.mockAcctRepository.EXPECT().
+			CheckTransactionIdIsExist(gomock.Any(), transactionId).
+			Return(true, nil)
+		testHelper.mockAcctRepository.EXPECT().
+			GetJournalDetailByTransactionId(gomock.Any(), transactionId).
+			Return(details, nil)
+	}
+
+	tests := []struct {
+		name    string
+		req     models.ReverseJournalRequest
+		doMock  func(req models.ReverseJournalRequest)
+		want    []models.Transaction
+		wantErr bool
+		err     error
+	}{
+		{
+			name: "success case - reverse all journal lines",
+			req: models.ReverseJournalRequest{
+				TransactionId: transactionId,
+				Reason:        "wrong account",
+			},
+			doMock: func(req models.ReverseJournalRequest) {
+				mockGetJournal()
+				testHelper.mockAcctRepository.EXPECT().
+					GetReversedJournalIds(gomock.Any(), []string{"2024060601093678", "2024060601093679"}).
+					Return(nil, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), reversalTransactionId).
+					Return(false, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "121001000000008").
+					Return(models.GetAccountOut{AccountNumber: "121001000000008", EntityCode: "001"}, nil)
+				testHelper.mockAccRepository.EXPECT().
+					GetOneByAccountNumber(gomock.Any(), "143001000000168").
+					Return(models.GetAccountOut{AccountNumber: "143001000000168", EntityCode: "001"}, nil)
+				testHelper.mockTrialBalanceRepository.EXPECT().
+					GetByPeriod(gomock.Any(), atime.Now().Format(atime.DateFormatYYYYMM), "001").
+					Return(nil, models.ErrNoRows)
+				testHelper.mockCacheRepository.EXPECT().
+					GetIncrement(gomock.Any(), "splitIdCounter").
+					Return(int64(1), nil).Times(2)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().InsertTransaction(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplit(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().InsertSplitAccount(gomock.Any(), gomock.Any()).Return(nil)
+						testHelper.mockAcctRepository.EXPECT().
+							InsertJournalDetail(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, in []models.CreateJournalDetail) error {
+								assert.Equal(t, "2024060601093678", in[0].ReversedJournalId)
+								assert.False(t, in[0].IsDebit)
+								assert.Equal(t, "2024060601093679", in[1].ReversedJournalId)
+								assert.True(t, in[1].IsDebit)
+								return nil
+							})
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).Times(2)
+			},
+			want: []models.Transaction{
+				{
+					TransactionType:   "PAYGL",
+					Account:           "121001000000008",
+					Narrative:         "Repayment Group Loan",
+					Amount:            decimal.NewFromFloat(10000),
+					IsDebit:           false,
+					ReversedJournalId: "2024060601093678",
+				},
+				{
+					TransactionType:   "PAYGL",
+					Account:           "143001000000168",
+					Narrative:         "Repayment Group Loan",
+					Amount:            decimal.NewFromFloat(10000),
+					IsDebit:           true,
+					ReversedJournalId: "2024060601093679",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - transaction id not found",
+			req: models.ReverseJournalRequest{
+				TransactionId: transactionId,
+			},
+			doMock: func(req models.ReverseJournalRequest) {
+				testHelper.mockAcctRepository.EXPECT().
+					CheckTransactionIdIsExist(gomock.Any(), transactionId).
+					Return(false, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - journal id not found",
+			req: models.ReverseJournalRequest{
+				TransactionId: transactionId,
+				JournalIds:    []string{"2024060601093678", "2024060601093699"},
+			},
+			doMock: func(req models.ReverseJournalRequest) {
+				mockGetJournal()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - journal already reversed",
+			req: models.ReverseJournalRequest{
+				TransactionId: transactionId,
+				JournalIds:    []string{"2024060601093679", "2024060601093679"},
+			},
+			doMock: func(req models.ReverseJournalRequest) {
+				mockGetJournal()
+				testHelper.mockAcctRepository.EXPECT().
+					GetReversedJournalIds(gomock.Any(), []string{"2024060601093679"}).
+					Return([]string{"2024060601093679"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - database error get reversed journal",
+			req: models.ReverseJournalRequest{
+				TransactionId: transactionId,
+			},
+			doMock: func(req models.ReverseJournalRequest) {
+				mockGetJournal()
+				testHelper.mockAcctRepository.EXPECT().
+					GetReversedJournalIds(gomock.Any(), gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - partial reversal not balanced",
+			req: models.ReverseJournalRequest{
+				TransactionId: transactionId,
+				JournalIds:    []string{"2024060601093678"},
+			},
+			doMock: func(req models.ReverseJournalRequest) {
+				mockGetJournal()
+			},
+			wantErr: true,
+			err:     models.GetErrMap(models.ErrKeyJournalReversalNotBalanced, "entity 001 IDR difference 10000"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.req)
+			}
+			got, err := testHelper.journalService.ReverseJournal(ctx, tt.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got.Transactions)
+				assert.Equal(t, reversalTransactionId, got.TransactionId)
+				assert.Equal(t, "123456", got.ReferenceNumber)
+				assert.Equal(t, &models.Metadata{
+					models.MetadataKeyReversalOf:     transactionId,
+					models.MetadataKeyReversalReason: tt.req.Reason,
+				}, got.Metadata)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/file"
+
+	"github.com/shopspring/decimal"
+)
+
+// uploadJournalPairColumn matches the numbered account debit column of a pair of lines, e.g. account_debit1.
+var uploadJournalPairColumn = regexp.MustCompile(`^` + models.UploadJournalColumnAccountDebit + `(\d+)$`)
+
+// excelEpoch is the day zero of the excel serial date.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// uploadJournal is a journal of the upload file, the lines of a journal can be spread across the rows with the same transaction id.
+type uploadJournal struct {
+	journal models.JournalRequest
+
+	// rows are the indexes of the rows of the journal in the file, without the header.
+	rows []int
+	err  error
+}
+
+// uploadJournalTemplate maps the column names of the header to the column indexes, so a row is parsed by the column name.
+type uploadJournalTemplate struct {
+	columns map[string]int
+
+	// pairs are the numbers of the pairs of debit & credit lines, e.g. 1 & 2 for account_debit1 & account_debit2.
+	pairs []string
+}
+
+// rowNumbers returns the row numbers of the journal in the file, the header is the first row.
+func (uj *uploadJournal) rowNumbers() []int {
+	numbers := make([]int, 0, len(uj.rows))
+	for _, v := range uj.rows {
+		numbers = append(numbers, v+2)
+	}
+	return numbers
+}
+
+func newUploadJournalTemplate(header []string) (t uploadJournalTemplate, err error) {
+	t.columns = make(map[string]int, len(header))
+	for i, v := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(v, "\ufeff")))
+		if name == "" {
+			continue
+		}
+		if _, ok := t.columns[name]; ok {
+			return t, fmt.Errorf("column %s is duplicated", name)
+		}
+		t.columns[name] = i
+
+		if match := uploadJournalPairColumn.FindStringSubmatch(name); match != nil {
+			t.pairs = append(t.pairs, match[1])
+		}
+	}
+	sort.Slice(t.pairs, func(i, j int) bool {
+		a, _ := strconv.Atoi(t.pairs[i])
+		b, _ := strconv.Atoi(t.pairs[j])
+		return a < b
+	})
+
+	for _, v := range []string{
+		models.UploadJournalColumnTransactionId,
+		models.UploadJournalColumnTransactionDate,
+		models.UploadJournalColumnProcessingDate,
+		models.UploadJournalColumnCurrency,
+	} {
+		if !t.has(v) {
+			return t, fmt.Errorf("column %s is required", v)
+		}
+	}
+
+	_, hasAccount := t.columns[models.UploadJournalColumnAccount]
+	if !hasAccount && len(t.pairs) == 0 {
+		return t, fmt.Errorf("column %s or %s1 is required", models.UploadJournalColumnAccount, models.UploadJournalColumnAccountDebit)
+	}
+
+	return t, nil
+}
+
+func (t uploadJournalTemplate) has(column string) bool {
+	_, ok := t.columns[column]
+	return ok
+}
+
+// value returns the trimmed cell of the column, a missing column or cell is an empty value.
+func (t uploadJournalTemplate) value(r []string, column string) string {
+	i, ok := t.columns[column]
+	if !ok || i >= len(r) {
+		return ""
+	}
+	return strings.TrimSpace(r[i])
+}
+
+// dateValue returns the cell of a date column, an excel serial date is formatted to the journal date format.
+func (t uploadJournalTemplate) dateValue(r []string, column string) string {
+	v := t.value(r, column)
+	serial, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return v
+	}
+
+	days, fraction := math.Modf(serial)
+	date := excelEpoch.AddDate(0, 0, int(days)).Add(time.Duration(math.Round(fraction*86400)) * time.Second)
+	return date.Format(atime.DateFormatYYYYMMDDWithTime)
+}
+
+// parseJournal parses the journal columns of a row without the lines.
+func (t uploadJournalTemplate) parseJournal(r []string) (journal models.JournalRequest, err error) {
+	journal = models.JournalRequest{
+		ReferenceNumber: t.value(r, models.UploadJournalColumnReferenceNumber),
+		TransactionId:   t.value(r, models.UploadJournalColumnTransactionId),
+		OrderType:       t.value(r, models.UploadJournalColumnOrderType),
+		TransactionDate: t.dateValue(r, models.UploadJournalColumnTransactionDate),
+		ProcessingDate:  t.dateValue(r, models.UploadJournalColumnProcessingDate),
+		Currency:        t.value(r, models.UploadJournalColumnCurrency),
+	}
+
+	if metadata := t.value(r, models.UploadJournalColumnMetadata); metadata != "" {
+		if err = json.Unmarshal([]byte(metadata), &journal.Metadata); err != nil {
+			return
+		}
+	}
+
+	return journal, nil
+}
+
+// parseLines parses every line of a row, the numbered pairs of lines first then the single line.
+// A pair without the accounts & the amount is skipped, so the pairs are optional.
+func (t uploadJournalTemplate) parseLines(r []string) (lines []models.Transaction, err error) {
+	for _, n := range t.pairs {
+		debitAccount := t.value(r, models.UploadJournalColumnAccountDebit+n)
+		creditAccount := t.value(r, models.UploadJournalColumnAccountCredit+n)
+		amountValue := t.value(r, models.UploadJournalColumnAmount+n)
+		if debitAccount == "" && creditAccount == "" && amountValue == "" {
+			continue
+		}
+
+		amount, errAmount := decimal.NewFromString(amountValue)
+		if errAmount != nil {
+			return nil, fmt.Errorf("invalid %s%s: %w", models.UploadJournalColumnAmount, n, errAmount)
+		}
+
+		line := models.Transaction{
+			TransactionType:     t.value(r, models.UploadJournalColumnTransactionType+n),
+			TransactionTypeName: t.value(r, models.UploadJournalColumnTransactionTypeName+n),
+			Narrative:           t.value(r, models.UploadJournalColumnNarrative+n),
+			Amount:              amount,
+		}
+		debit, credit := line, line
+		debit.Account, debit.IsDebit = debitAccount, true
+		credit.Account, credit.IsDebit = creditAccount, false
+		lines = append(lines, debit, credit)
+	}
+
+	if account := t.value(r, models.UploadJournalColumnAccount); account != "" {
+		debit := t.value(r, models.UploadJournalColumnDebit)
+		credit := t.value(r, models.UploadJournalColumnCredit)
+		if (debit == "") == (credit == "") {
+			return nil, fmt.Errorf("either %s or %s must be filled", models.UploadJournalColumnDebit, models.UploadJournalColumnCredit)
+		}
+
+		column, amountValue := models.UploadJournalColumnDebit, debit
+		if credit != "" {
+			column, amountValue = models.UploadJournalColumnCredit, credit
+		}
+		amount, errAmount := decimal.NewFromString(amountValue)
+		if errAmount != nil {
+			return nil, fmt.Errorf("invalid %s: %w", column, errAmount)
+		}
+
+		lines = append(lines, models.Transaction{
+			TransactionType:     t.value(r, models.UploadJournalColumnTransactionType),
+			TransactionTypeName: t.value(r, models.UploadJournalColumnTransactionTypeName),
+			Account:             account,
+			Narrative:           t.value(r, models.UploadJournalColumnNarrative),
+			Amount:              amount,
+			IsDebit:             debit != "",
+		})
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("row has no journal line")
+	}
+
+	return lines, nil
+}
+
+// checkSameJournal checks the journal columns of a next row of the same transaction id, an empty column follows the first row.
+func (t uploadJournalTemplate) checkSameJournal(journal models.JournalRequest, r []string) error {
+	for _, v := range []struct{ column, got, want string }{
+		{models.UploadJournalColumnReferenceNumber, t.value(r, models.UploadJournalColumnReferenceNumber), journal.ReferenceNumber},
+		{models.UploadJournalColumnOrderType, t.value(r, models.UploadJournalColumnOrderType), journal.OrderType},
+		{models.UploadJournalColumnTransactionDate, t.dateValue(r, models.UploadJournalColumnTransactionDate), journal.TransactionDate},
+		{models.UploadJournalColumnProcessingDate, t.dateValue(r, models.UploadJournalColumnProcessingDate), journal.ProcessingDate},
+		{models.UploadJournalColumnCurrency, t.value(r, models.UploadJournalColumnCurrency), journal.Currency},
+	} {
+		if v.got != "" && v.got != v.want {
+			return fmt.Errorf("%s %s is different from the first row of the transaction id", v.column, v.got)
+		}
+	}
+
+	return nil
+}
+
+/*
+parseUploadJournals parses the rows of the upload journal file by the header, the rows with the same transaction id are
+grouped into a journal in the order of the first row of each transaction id. An invalid row fails the whole journal.
+*/
+func parseUploadJournals(header []string, rows [][]string) (journals []*uploadJournal, err error) {
+	template, err := newUploadJournalTemplate(header)
+	if err != nil {
+		return nil, err
+	}
+
+	journalByTransactionId := make(map[string]*uploadJournal, len(rows))
+	for i, r := range rows {
+		// the empty rows of an xlsx file are kept so the row numbers match the sheet
+		if strings.TrimSpace(strings.Join(r, "")) == "" {
+			continue
+		}
+
+		transactionId := template.value(r, models.UploadJournalColumnTransactionId)
+		uj, ok := journalByTransactionId[transactionId]
+		if !ok {
+			uj = &uploadJournal{}
+			uj.journal, uj.err = template.parseJournal(r)
+			journals = append(journals, uj)
+			if transactionId != "" {
+				journalByTransactionId[transactionId] = uj
+			}
+		} else if errMerge := template.checkSameJournal(uj.journal, r); errMerge != nil && uj.err == nil {
+			uj.err = errMerge
+		}
+		uj.rows = append(uj.rows, i)
+		if transactionId == "" && uj.err == nil {
+			uj.err = fmt.Errorf("%s is required", models.UploadJournalColumnTransactionId)
+		}
+
+		lines, errLines := template.parseLines(r)
+		if errLines != nil {
+			if uj.err == nil {
+				uj.err = errLines
+			}
+			continue
+		}
+		uj.journal.Transactions = append(uj.journal.Transactions, lines...)
+	}
+
+	return journals, nil
+}
+
+// readUploadRecords reads every record of a csv or xlsx file by the file extension, the first record is the header.
+func readUploadRecords(f file.IOFile, src io.Reader, filename string) ([][]string, error) {
+	if strings.HasSuffix(strings.ToLower(filename), models.UploadJournalExtXLSX) {
+		return f.XLSXReadAll(src)
+	}
+
+	return f.CSVReadAll(src)
+}
+
+// readUploadJournal copies the uploaded file to the local disk & reads every record, the first record is the header.
+func (js *journalService) readUploadJournal(file *multipart.FileHeader) (records [][]string, err error) {
+	path := fmt.Sprintf("./%s_%s", atime.Now().Format(atime.DateFormatYYYYMMDDWithTimeWithoutDash), file.Filename)
+	src, err := file.Open()
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := js.srv.file.CreateFile(path)
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	if err = js.srv.file.CopyFile(dst, src); err != nil {
+		return
+	}
+
+	defer js.srv.file.RemoveFile(dst.Name())
+	fs, err := js.srv.file.OpenFile(dst.Name())
+	if err != nil {
+		return
+	}
+
+	return readUploadRecords(js.srv.file, fs, file.Filename)
+}
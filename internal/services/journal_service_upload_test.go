@@ -0,0 +1,184 @@
+package services
+
+import (
+	"testing"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseUploadJournals(t *testing.T) {
+	lineHeader := []string{"transaction_id", "reference_number", "order_type", "transaction_date", "processing_date", "currency", "transaction_type", "transaction_type_name", "account", "narrative", "debit", "credit", "metadata"}
+	pairHeader := []string{"reference_number", "transaction_id", "order_type", "transaction_date", "processing_date", "currency", "transaction_type1", "transaction_type_name1", "account_debit1", "account_credit1", "narrative1", "amount1", "transaction_type3", "transaction_type_name3", "account_debit3", "account_credit3", "narrative3", "amount3"}
+
+	line := func(account string, amount int64, isDebit bool) models.Transaction {
+		return models.Transaction{
+			TransactionType:     "TUPIN",
+			TransactionTypeName: "Top Up",
+			Account:             account,
+			Narrative:           "top up",
+			Amount:              decimal.NewFromInt(amount),
+			IsDebit:             isDebit,
+		}
+	}
+
+	type want struct {
+		journal models.JournalRequest
+		rows    []int
+		err     string
+	}
+	tests := []struct {
+		name    string
+		header  []string
+		rows    [][]string
+		want    []want
+		wantErr string
+	}{
+		{
+			name:   "success case - n lines grouped by transaction id across rows",
+			header: lineHeader,
+			rows: [][]string{
+				{"trx-1", "ref-1", "TUP", "45405.5", "45405.5", "IDR", "TUPIN", "Top Up", "142001000000001", "top up", "300", "", `{"note":"a"}`},
+				{"trx-2", "ref-2", "TUP", "2024-04-23 09:20:00", "2024-04-23 09:20:00", "IDR", "TUPIN", "Top Up", "142001000000001", "top up", "50", ""},
+				{"trx-1", "", "", "", "", "", "TUPIN", "Top Up", "211001000381110", "top up", "", "100"},
+				{"trx-1", "ref-1", "TUP", "2024-04-23 12:00:00", "", "IDR", "TUPIN", "Top Up", "211001000381111", "top up", "", "200"},
+				{"trx-2", "", "", "", "", "", "TUPIN", "Top Up", "211001000381110", "top up", "", "50"},
+			},
+			want: []want{
+				{
+					journal: models.JournalRequest{
+						ReferenceNumber: "ref-1",
+						TransactionId:   "trx-1",
+						OrderType:       "TUP",
+						TransactionDate: "2024-04-23 12:00:00",
+						ProcessingDate:  "2024-04-23 12:00:00",
+						Currency:        "IDR",
+						Transactions: []models.Transaction{
+							line("142001000000001", 300, true),
+							line("211001000381110", 100, false),
+							line("211001000381111", 200, false),
+						},
+						Metadata: &models.Metadata{"note": "a"},
+					},
+					rows: []int{0, 2, 3},
+				},
+				{
+					journal: models.JournalRequest{
+						ReferenceNumber: "ref-2",
+						TransactionId:   "trx-2",
+						OrderType:       "TUP",
+						TransactionDate: "2024-04-23 09:20:00",
+						ProcessingDate:  "2024-04-23 09:20:00",
+						Currency:        "IDR",
+						Transactions: []models.Transaction{
+							line("142001000000001", 50, true),
+							line("211001000381110", 50, false),
+						},
+					},
+					rows: []int{1, 4},
+				},
+			},
+		},
+		{
+			name:   "success case - numbered pairs of lines, an empty pair & an empty row are skipped",
+			header: pairHeader,
+			rows: [][]string{
+				{"ref-1", "trx-1", "TUP", "2024-04-23 09:20:00", "2024-04-23 09:20:00", "IDR", "TUPIN", "Top Up", "142001000000001", "211001000381110", "top up", "100", "", "", "", "", "", ""},
+				{},
+				{"ref-2", "trx-2", "TUP", "2024-04-23 09:20:00", "2024-04-23 09:20:00", "IDR", "TUPIN", "Top Up", "142001000000001", "211001000381110", "top up", "100", "TUPIN", "Top Up", "142001000000002", "211001000381112", "top up", "20"},
+			},
+			want: []want{
+				{
+					journal: models.JournalRequest{
+						ReferenceNumber: "ref-1",
+						TransactionId:   "trx-1",
+						OrderType:       "TUP",
+						TransactionDate: "2024-04-23 09:20:00",
+						ProcessingDate:  "2024-04-23 09:20:00",
+						Currency:        "IDR",
+						Transactions: []models.Transaction{
+							line("142001000000001", 100, true),
+							line("211001000381110", 100, false),
+						},
+					},
+					rows: []int{0},
+				},
+				{
+					journal: models.JournalRequest{
+						ReferenceNumber: "ref-2",
+						TransactionId:   "trx-2",
+						OrderType:       "TUP",
+						TransactionDate: "2024-04-23 09:20:00",
+						ProcessingDate:  "2024-04-23 09:20:00",
+						Currency:        "IDR",
+						Transactions: []models.Transaction{
+							line("142001000000001", 100, true),
+							line("211001000381110", 100, false),
+							line("142001000000002", 20, true),
+							line("211001000381112", 20, false),
+						},
+					},
+					rows: []int{2},
+				},
+			},
+		},
+		{
+			name:   "success case - invalid rows fail the journal",
+			header: lineHeader,
+			rows: [][]string{
+				{"trx-1", "ref-1", "TUP", "2024-04-23 09:20:00", "2024-04-23 09:20:00", "IDR", "TUPIN", "Top Up", "142001000000001", "top up", "100", ""},
+				{"trx-1", "ref-1", "TUP", "2024-04-24 09:20:00", "", "", "TUPIN", "Top Up", "211001000381110", "top up", "", "100"},
+				{"trx-2", "ref-2", "TUP", "2024-04-23 09:20:00", "2024-04-23 09:20:00", "IDR", "TUPIN", "Top Up", "142001000000001", "top up", "100", "100"},
+				{"trx-3", "ref-3", "TUP", "2024-04-23 09:20:00", "2024-04-23 09:20:00", "IDR", "TUPIN", "Top Up", "142001000000001", "top up", "1OO", ""},
+				{"trx-4", "ref-4", "TUP", "2024-04-23 09:20:00", "2024-04-23 09:20:00", "IDR", "", "", "", "", "", ""},
+				{"", "ref-5", "TUP", "2024-04-23 09:20:00", "2024-04-23 09:20:00", "IDR", "TUPIN", "Top Up", "142001000000001", "top up", "100", ""},
+			},
+			want: []want{
+				{rows: []int{0, 1}, err: "transaction_date 2024-04-24 09:20:00 is different from the first row of the transaction id"},
+				{rows: []int{2}, err: "either debit or credit must be filled"},
+				{rows: []int{3}, err: "invalid debit: can't convert 1OO to decimal"},
+				{rows: []int{4}, err: "row has no journal line"},
+				{rows: []int{5}, err: "transaction_id is required"},
+			},
+		},
+		{
+			name:    "error case - missing required column",
+			header:  []string{"transaction_id", "transaction_date", "processing_date", "account", "debit", "credit"},
+			wantErr: "column currency is required",
+		},
+		{
+			name:    "error case - missing line columns",
+			header:  []string{"transaction_id", "transaction_date", "processing_date", "currency"},
+			wantErr: "column account or account_debit1 is required",
+		},
+		{
+			name:    "error case - duplicated column",
+			header:  append([]string{"transaction_id"}, lineHeader...),
+			wantErr: "column transaction_id is duplicated",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUploadJournals(tt.header, tt.rows)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i, w := range tt.want {
+				assert.Equal(t, w.rows, got[i].rows)
+				if w.err != "" {
+					assert.EqualError(t, got[i].err, w.err)
+					continue
+				}
+				assert.NoError(t, got[i].err)
+				assert.Equal(t, w.journal, got[i].journal)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	context "context"
 	multipart "mime/multipart"
 	reflect "reflect"
+	time "time"
 
 	models "bitbucket.org/Amartha/go-accounting/internal/models"
 	gocustomer "bitbucket.org/Amartha/go-accounting/internal/pkg/gocustomer"
@@ -44,6 +45,21 @@ func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
 	return m.recorder
 }
 
+// BulkUpdateAccounts mocks base method.
+func (m *MockAccountService) BulkUpdateAccounts(ctx context.Context, in models.BulkUpdateAccountsIn) (models.AccountBulkUpdateReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateAccounts", ctx, in)
+	ret0, _ := ret[0].(models.AccountBulkUpdateReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateAccounts indicates an expected call of BulkUpdateAccounts.
+func (mr *MockAccountServiceMockRecorder) BulkUpdateAccounts(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateAccounts", reflect.TypeOf((*MockAccountService)(nil).BulkUpdateAccounts), ctx, in)
+}
+
 // CheckAltIdIsExist mocks base method.
 func (m *MockAccountService) CheckAltIdIsExist(ctx context.Context, altId string) error {
 	m.ctrl.T.Helper()
@@ -87,6 +103,21 @@ func (mr *MockAccountServiceMockRecorder) Create(ctx, in any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccountService)(nil).Create), ctx, in)
 }
 
+// CreateAccountRelationshipRule mocks base method.
+func (m *MockAccountService) CreateAccountRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (*models.AccountRelationshipRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountRelationshipRule", ctx, in)
+	ret0, _ := ret[0].(*models.AccountRelationshipRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountRelationshipRule indicates an expected call of CreateAccountRelationshipRule.
+func (mr *MockAccountServiceMockRecorder) CreateAccountRelationshipRule(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountRelationshipRule", reflect.TypeOf((*MockAccountService)(nil).CreateAccountRelationshipRule), ctx, in)
+}
+
 // CreateBranchPointAccount mocks base method.
 func (m *MockAccountService) CreateBranchPointAccount(ctx context.Context, in models.CreateAccount) error {
 	m.ctrl.T.Helper()
@@ -158,6 +189,20 @@ func (mr *MockAccountServiceMockRecorder) CreateLoanPartnerAccount(ctx, in any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoanPartnerAccount", reflect.TypeOf((*MockAccountService)(nil).CreateLoanPartnerAccount), ctx, in)
 }
 
+// DeleteAccountRelationshipRule mocks base method.
+func (m *MockAccountService) DeleteAccountRelationshipRule(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccountRelationshipRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccountRelationshipRule indicates an expected call of DeleteAccountRelationshipRule.
+func (mr *MockAccountServiceMockRecorder) DeleteAccountRelationshipRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountRelationshipRule", reflect.TypeOf((*MockAccountService)(nil).DeleteAccountRelationshipRule), ctx, id)
+}
+
 // DownloadCSVGetAccountList mocks base method.
 func (m *MockAccountService) DownloadCSVGetAccountList(ctx context.Context, in []models.GetAccountOut) (*bytes.Buffer, string, error) {
 	m.ctrl.T.Helper()
@@ -174,6 +219,66 @@ func (mr *MockAccountServiceMockRecorder) DownloadCSVGetAccountList(ctx, in any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadCSVGetAccountList", reflect.TypeOf((*MockAccountService)(nil).DownloadCSVGetAccountList), ctx, in)
 }
 
+// GetAccountBalance mocks base method.
+func (m *MockAccountService) GetAccountBalance(ctx context.Context, accountNumber string, asOf time.Time) (models.AccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalance", ctx, accountNumber, asOf)
+	ret0, _ := ret[0].(models.AccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalance indicates an expected call of GetAccountBalance.
+func (mr *MockAccountServiceMockRecorder) GetAccountBalance(ctx, accountNumber, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalance", reflect.TypeOf((*MockAccountService)(nil).GetAccountBalance), ctx, accountNumber, asOf)
+}
+
+// GetAccountBalances mocks base method.
+func (m *MockAccountService) GetAccountBalances(ctx context.Context, opts models.AccountBalanceFilterOptions) (models.AccountBalances, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalances", ctx, opts)
+	ret0, _ := ret[0].(models.AccountBalances)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalances indicates an expected call of GetAccountBalances.
+func (mr *MockAccountServiceMockRecorder) GetAccountBalances(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalances", reflect.TypeOf((*MockAccountService)(nil).GetAccountBalances), ctx, opts)
+}
+
+// GetAccountHierarchy mocks base method.
+func (m *MockAccountService) GetAccountHierarchy(ctx context.Context, accountNumber string, balanceDate time.Time) (models.AccountHierarchy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountHierarchy", ctx, accountNumber, balanceDate)
+	ret0, _ := ret[0].(models.AccountHierarchy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountHierarchy indicates an expected call of GetAccountHierarchy.
+func (mr *MockAccountServiceMockRecorder) GetAccountHierarchy(ctx, accountNumber, balanceDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountHierarchy", reflect.TypeOf((*MockAccountService)(nil).GetAccountHierarchy), ctx, accountNumber, balanceDate)
+}
+
+// GetAccountHistory mocks base method.
+func (m *MockAccountService) GetAccountHistory(ctx context.Context, opts models.AccountChangeLogFilterOptions) ([]models.AccountChangeLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountHistory", ctx, opts)
+	ret0, _ := ret[0].([]models.AccountChangeLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountHistory indicates an expected call of GetAccountHistory.
+func (mr *MockAccountServiceMockRecorder) GetAccountHistory(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountHistory", reflect.TypeOf((*MockAccountService)(nil).GetAccountHistory), ctx, opts)
+}
+
 // GetAccountList mocks base method.
 func (m *MockAccountService) GetAccountList(ctx context.Context, opts models.AccountFilterOptions) ([]models.GetAccountOut, int, error) {
 	m.ctrl.T.Helper()
@@ -190,6 +295,36 @@ func (mr *MockAccountServiceMockRecorder) GetAccountList(ctx, opts any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountList", reflect.TypeOf((*MockAccountService)(nil).GetAccountList), ctx, opts)
 }
 
+// GetAccountRelationshipRuleById mocks base method.
+func (m *MockAccountService) GetAccountRelationshipRuleById(ctx context.Context, id int) (*models.AccountRelationshipRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountRelationshipRuleById", ctx, id)
+	ret0, _ := ret[0].(*models.AccountRelationshipRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountRelationshipRuleById indicates an expected call of GetAccountRelationshipRuleById.
+func (mr *MockAccountServiceMockRecorder) GetAccountRelationshipRuleById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountRelationshipRuleById", reflect.TypeOf((*MockAccountService)(nil).GetAccountRelationshipRuleById), ctx, id)
+}
+
+// GetAccountRelationshipRules mocks base method.
+func (m *MockAccountService) GetAccountRelationshipRules(ctx context.Context, opts models.AccountRelationshipRuleFilterOptions) ([]models.AccountRelationshipRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountRelationshipRules", ctx, opts)
+	ret0, _ := ret[0].([]models.AccountRelationshipRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountRelationshipRules indicates an expected call of GetAccountRelationshipRules.
+func (mr *MockAccountServiceMockRecorder) GetAccountRelationshipRules(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountRelationshipRules", reflect.TypeOf((*MockAccountService)(nil).GetAccountRelationshipRules), ctx, opts)
+}
+
 // GetAllAccountNumbersByParam mocks base method.
 func (m *MockAccountService) GetAllAccountNumbersByParam(ctx context.Context, in models.GetAllAccountNumbersByParamIn) ([]models.GetAllAccountNumbersByParamOut, error) {
 	m.ctrl.T.Helper()
@@ -294,6 +429,50 @@ func (mr *MockAccountServiceMockRecorder) ProcessUploadAccounts(ctx, file any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessUploadAccounts", reflect.TypeOf((*MockAccountService)(nil).ProcessUploadAccounts), ctx, file)
 }
 
+// RemoveAccountParent mocks base method.
+func (m *MockAccountService) RemoveAccountParent(ctx context.Context, accountNumber string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAccountParent", ctx, accountNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAccountParent indicates an expected call of RemoveAccountParent.
+func (mr *MockAccountServiceMockRecorder) RemoveAccountParent(ctx, accountNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAccountParent", reflect.TypeOf((*MockAccountService)(nil).RemoveAccountParent), ctx, accountNumber)
+}
+
+// SeedAccountRelationshipRules mocks base method.
+func (m *MockAccountService) SeedAccountRelationshipRules(ctx context.Context) ([]models.AccountRelationshipRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeedAccountRelationshipRules", ctx)
+	ret0, _ := ret[0].([]models.AccountRelationshipRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeedAccountRelationshipRules indicates an expected call of SeedAccountRelationshipRules.
+func (mr *MockAccountServiceMockRecorder) SeedAccountRelationshipRules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedAccountRelationshipRules", reflect.TypeOf((*MockAccountService)(nil).SeedAccountRelationshipRules), ctx)
+}
+
+// SetAccountParent mocks base method.
+func (m *MockAccountService) SetAccountParent(ctx context.Context, in models.AccountRelation) (*models.AccountRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccountParent", ctx, in)
+	ret0, _ := ret[0].(*models.AccountRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAccountParent indicates an expected call of SetAccountParent.
+func (mr *MockAccountServiceMockRecorder) SetAccountParent(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountParent", reflect.TypeOf((*MockAccountService)(nil).SetAccountParent), ctx, in)
+}
+
 // Update mocks base method.
 func (m *MockAccountService) Update(ctx context.Context, in models.UpdateAccount) (models.UpdateAccount, error) {
 	m.ctrl.T.Helper()
@@ -338,6 +517,36 @@ func (mr *MockAccountServiceMockRecorder) UpdateAccountEntity(ctx, in any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountEntity", reflect.TypeOf((*MockAccountService)(nil).UpdateAccountEntity), ctx, in)
 }
 
+// UpdateAccountRelationshipRule mocks base method.
+func (m *MockAccountService) UpdateAccountRelationshipRule(ctx context.Context, in models.AccountRelationshipRule) (*models.AccountRelationshipRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountRelationshipRule", ctx, in)
+	ret0, _ := ret[0].(*models.AccountRelationshipRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountRelationshipRule indicates an expected call of UpdateAccountRelationshipRule.
+func (mr *MockAccountServiceMockRecorder) UpdateAccountRelationshipRule(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountRelationshipRule", reflect.TypeOf((*MockAccountService)(nil).UpdateAccountRelationshipRule), ctx, in)
+}
+
+// UpdateAccountStatus mocks base method.
+func (m *MockAccountService) UpdateAccountStatus(ctx context.Context, in models.UpdateAccountStatus) (models.UpdateAccountStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountStatus", ctx, in)
+	ret0, _ := ret[0].(models.UpdateAccountStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountStatus indicates an expected call of UpdateAccountStatus.
+func (mr *MockAccountServiceMockRecorder) UpdateAccountStatus(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatus", reflect.TypeOf((*MockAccountService)(nil).UpdateAccountStatus), ctx, in)
+}
+
 // UpdateCategoryCodeSeq mocks base method.
 func (m *MockAccountService) UpdateCategoryCodeSeq(ctx context.Context, in models.DoUpdateCategoryCodeSeqRequest) error {
 	m.ctrl.T.Helper()
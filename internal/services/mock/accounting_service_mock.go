@@ -43,6 +43,66 @@ func (m *MockAccountingService) EXPECT() *MockAccountingServiceMockRecorder {
 	return m.recorder
 }
 
+// CheckLedgerIntegrity mocks base method.
+func (m *MockAccountingService) CheckLedgerIntegrity(ctx context.Context, in models.LedgerIntegrityRequest) (models.LedgerIntegrityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLedgerIntegrity", ctx, in)
+	ret0, _ := ret[0].(models.LedgerIntegrityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckLedgerIntegrity indicates an expected call of CheckLedgerIntegrity.
+func (mr *MockAccountingServiceMockRecorder) CheckLedgerIntegrity(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLedgerIntegrity", reflect.TypeOf((*MockAccountingService)(nil).CheckLedgerIntegrity), ctx, in)
+}
+
+// ClearSuspenseAccounts mocks base method.
+func (m *MockAccountingService) ClearSuspenseAccounts(ctx context.Context, date time.Time) (models.ClearSuspenseAccountsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearSuspenseAccounts", ctx, date)
+	ret0, _ := ret[0].(models.ClearSuspenseAccountsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearSuspenseAccounts indicates an expected call of ClearSuspenseAccounts.
+func (mr *MockAccountingServiceMockRecorder) ClearSuspenseAccounts(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearSuspenseAccounts", reflect.TypeOf((*MockAccountingService)(nil).ClearSuspenseAccounts), ctx, date)
+}
+
+// CloseFiscalYear mocks base method.
+func (m *MockAccountingService) CloseFiscalYear(ctx context.Context, in models.YearEndClosingRequest) (models.YearEndClosing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseFiscalYear", ctx, in)
+	ret0, _ := ret[0].(models.YearEndClosing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseFiscalYear indicates an expected call of CloseFiscalYear.
+func (mr *MockAccountingServiceMockRecorder) CloseFiscalYear(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseFiscalYear", reflect.TypeOf((*MockAccountingService)(nil).CloseFiscalYear), ctx, in)
+}
+
+// CreateRecurringJournal mocks base method.
+func (m *MockAccountingService) CreateRecurringJournal(ctx context.Context, req models.CreateRecurringJournalRequest) (*models.RecurringJournal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurringJournal", ctx, req)
+	ret0, _ := ret[0].(*models.RecurringJournal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurringJournal indicates an expected call of CreateRecurringJournal.
+func (mr *MockAccountingServiceMockRecorder) CreateRecurringJournal(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurringJournal", reflect.TypeOf((*MockAccountingService)(nil).CreateRecurringJournal), ctx, req)
+}
+
 // DownloadCSVGetBalanceSheet mocks base method.
 func (m *MockAccountingService) DownloadCSVGetBalanceSheet(ctx context.Context, opts models.BalanceSheetFilterOptions, resp models.GetBalanceSheetResponse) (*bytes.Buffer, string, error) {
 	m.ctrl.T.Helper()
@@ -59,6 +119,38 @@ func (mr *MockAccountingServiceMockRecorder) DownloadCSVGetBalanceSheet(ctx, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadCSVGetBalanceSheet", reflect.TypeOf((*MockAccountingService)(nil).DownloadCSVGetBalanceSheet), ctx, opts, resp)
 }
 
+// DownloadCSVGetCashFlow mocks base method.
+func (m *MockAccountingService) DownloadCSVGetCashFlow(ctx context.Context, opts models.CashFlowFilterOptions, resp models.GetCashFlowResponse) (*bytes.Buffer, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadCSVGetCashFlow", ctx, opts, resp)
+	ret0, _ := ret[0].(*bytes.Buffer)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadCSVGetCashFlow indicates an expected call of DownloadCSVGetCashFlow.
+func (mr *MockAccountingServiceMockRecorder) DownloadCSVGetCashFlow(ctx, opts, resp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadCSVGetCashFlow", reflect.TypeOf((*MockAccountingService)(nil).DownloadCSVGetCashFlow), ctx, opts, resp)
+}
+
+// DownloadCSVGetProfitLoss mocks base method.
+func (m *MockAccountingService) DownloadCSVGetProfitLoss(ctx context.Context, opts models.ProfitLossFilterOptions, resp models.GetProfitLossResponse) (*bytes.Buffer, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadCSVGetProfitLoss", ctx, opts, resp)
+	ret0, _ := ret[0].(*bytes.Buffer)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadCSVGetProfitLoss indicates an expected call of DownloadCSVGetProfitLoss.
+func (mr *MockAccountingServiceMockRecorder) DownloadCSVGetProfitLoss(ctx, opts, resp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadCSVGetProfitLoss", reflect.TypeOf((*MockAccountingService)(nil).DownloadCSVGetProfitLoss), ctx, opts, resp)
+}
+
 // DownloadSubLedgerCSV mocks base method.
 func (m *MockAccountingService) DownloadSubLedgerCSV(ctx context.Context, opts models.SubLedgerFilterOptions) (*bytes.Buffer, string, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +252,36 @@ func (mr *MockAccountingServiceMockRecorder) GetBalanceSheet(ctx, opts any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceSheet", reflect.TypeOf((*MockAccountingService)(nil).GetBalanceSheet), ctx, opts)
 }
 
+// GetCashFlow mocks base method.
+func (m *MockAccountingService) GetCashFlow(ctx context.Context, opts models.CashFlowFilterOptions) (models.GetCashFlowResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCashFlow", ctx, opts)
+	ret0, _ := ret[0].(models.GetCashFlowResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCashFlow indicates an expected call of GetCashFlow.
+func (mr *MockAccountingServiceMockRecorder) GetCashFlow(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCashFlow", reflect.TypeOf((*MockAccountingService)(nil).GetCashFlow), ctx, opts)
+}
+
+// GetConsolidatedTrialBalance mocks base method.
+func (m *MockAccountingService) GetConsolidatedTrialBalance(ctx context.Context, opts models.ConsolidatedTrialBalanceFilterOptions) (models.GetConsolidatedTrialBalanceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsolidatedTrialBalance", ctx, opts)
+	ret0, _ := ret[0].(models.GetConsolidatedTrialBalanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsolidatedTrialBalance indicates an expected call of GetConsolidatedTrialBalance.
+func (mr *MockAccountingServiceMockRecorder) GetConsolidatedTrialBalance(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsolidatedTrialBalance", reflect.TypeOf((*MockAccountingService)(nil).GetConsolidatedTrialBalance), ctx, opts)
+}
+
 // GetGeneralLedger mocks base method.
 func (m *MockAccountingService) GetGeneralLedger(ctx context.Context, opts models.SubLedgerFilterOptions) (models.SubLedgerAccountResponse, []models.GetSubLedgerOut, int, error) {
 	m.ctrl.T.Helper()
@@ -177,6 +299,51 @@ func (mr *MockAccountingServiceMockRecorder) GetGeneralLedger(ctx, opts any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeneralLedger", reflect.TypeOf((*MockAccountingService)(nil).GetGeneralLedger), ctx, opts)
 }
 
+// GetProfitLoss mocks base method.
+func (m *MockAccountingService) GetProfitLoss(ctx context.Context, opts models.ProfitLossFilterOptions) (models.GetProfitLossResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfitLoss", ctx, opts)
+	ret0, _ := ret[0].(models.GetProfitLossResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfitLoss indicates an expected call of GetProfitLoss.
+func (mr *MockAccountingServiceMockRecorder) GetProfitLoss(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfitLoss", reflect.TypeOf((*MockAccountingService)(nil).GetProfitLoss), ctx, opts)
+}
+
+// GetRecurringJournalById mocks base method.
+func (m *MockAccountingService) GetRecurringJournalById(ctx context.Context, id int) (*models.RecurringJournal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringJournalById", ctx, id)
+	ret0, _ := ret[0].(*models.RecurringJournal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringJournalById indicates an expected call of GetRecurringJournalById.
+func (mr *MockAccountingServiceMockRecorder) GetRecurringJournalById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringJournalById", reflect.TypeOf((*MockAccountingService)(nil).GetRecurringJournalById), ctx, id)
+}
+
+// GetRecurringJournals mocks base method.
+func (m *MockAccountingService) GetRecurringJournals(ctx context.Context, opts models.RecurringJournalFilterOptions) ([]models.RecurringJournal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringJournals", ctx, opts)
+	ret0, _ := ret[0].([]models.RecurringJournal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringJournals indicates an expected call of GetRecurringJournals.
+func (mr *MockAccountingServiceMockRecorder) GetRecurringJournals(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringJournals", reflect.TypeOf((*MockAccountingService)(nil).GetRecurringJournals), ctx, opts)
+}
+
 // GetSubLedger mocks base method.
 func (m *MockAccountingService) GetSubLedger(ctx context.Context, opts models.SubLedgerFilterOptions) (models.SubLedgerAccountResponse, []models.GetSubLedgerOut, int, error) {
 	m.ctrl.T.Helper()
@@ -225,6 +392,21 @@ func (mr *MockAccountingServiceMockRecorder) GetSubLedgerCount(ctx, opts any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubLedgerCount", reflect.TypeOf((*MockAccountingService)(nil).GetSubLedgerCount), ctx, opts)
 }
 
+// GetSuspenseAgeing mocks base method.
+func (m *MockAccountingService) GetSuspenseAgeing(ctx context.Context, opts models.SuspenseAgeingFilterOptions) (*models.SuspenseAgeing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuspenseAgeing", ctx, opts)
+	ret0, _ := ret[0].(*models.SuspenseAgeing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuspenseAgeing indicates an expected call of GetSuspenseAgeing.
+func (mr *MockAccountingServiceMockRecorder) GetSuspenseAgeing(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuspenseAgeing", reflect.TypeOf((*MockAccountingService)(nil).GetSuspenseAgeing), ctx, opts)
+}
+
 // GetTrialBalance mocks base method.
 func (m *MockAccountingService) GetTrialBalance(ctx context.Context, opts models.TrialBalanceFilterOptions) (models.GetTrialBalanceResponses, error) {
 	m.ctrl.T.Helper()
@@ -287,6 +469,35 @@ func (mr *MockAccountingServiceMockRecorder) GetTrialBalanceFromGCS(ctx, opts an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalanceFromGCS", reflect.TypeOf((*MockAccountingService)(nil).GetTrialBalanceFromGCS), ctx, opts)
 }
 
+// RevalueForeignCurrencyBalances mocks base method.
+func (m *MockAccountingService) RevalueForeignCurrencyBalances(ctx context.Context, date time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevalueForeignCurrencyBalances", ctx, date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevalueForeignCurrencyBalances indicates an expected call of RevalueForeignCurrencyBalances.
+func (mr *MockAccountingServiceMockRecorder) RevalueForeignCurrencyBalances(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevalueForeignCurrencyBalances", reflect.TypeOf((*MockAccountingService)(nil).RevalueForeignCurrencyBalances), ctx, date)
+}
+
+// RunRecurringJournals mocks base method.
+func (m *MockAccountingService) RunRecurringJournals(ctx context.Context, date time.Time) (models.RunRecurringJournalResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRecurringJournals", ctx, date)
+	ret0, _ := ret[0].(models.RunRecurringJournalResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunRecurringJournals indicates an expected call of RunRecurringJournals.
+func (mr *MockAccountingServiceMockRecorder) RunRecurringJournals(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRecurringJournals", reflect.TypeOf((*MockAccountingService)(nil).RunRecurringJournals), ctx, date)
+}
+
 // SendEmailTrialBalanceDetails mocks base method.
 func (m *MockAccountingService) SendEmailTrialBalanceDetails(ctx context.Context, opts models.TrialBalanceDetailsFilterOptions) error {
 	m.ctrl.T.Helper()
@@ -10,6 +10,7 @@
 package mock
 
 import (
+	bytes "bytes"
 	context "context"
 	multipart "mime/multipart"
 	reflect "reflect"
@@ -42,6 +43,21 @@ func (m *MockJournalService) EXPECT() *MockJournalServiceMockRecorder {
 	return m.recorder
 }
 
+// ApproveJournalDraft mocks base method.
+func (m *MockJournalService) ApproveJournalDraft(ctx context.Context, req models.ApproveJournalDraftRequest) (*models.JournalDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveJournalDraft", ctx, req)
+	ret0, _ := ret[0].(*models.JournalDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveJournalDraft indicates an expected call of ApproveJournalDraft.
+func (mr *MockJournalServiceMockRecorder) ApproveJournalDraft(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveJournalDraft", reflect.TypeOf((*MockJournalService)(nil).ApproveJournalDraft), ctx, req)
+}
+
 // ConsumerInsertTransaction mocks base method.
 func (m *MockJournalService) ConsumerInsertTransaction(ctx context.Context, req models.JournalRequest) error {
 	m.ctrl.T.Helper()
@@ -56,6 +72,37 @@ func (mr *MockJournalServiceMockRecorder) ConsumerInsertTransaction(ctx, req any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumerInsertTransaction", reflect.TypeOf((*MockJournalService)(nil).ConsumerInsertTransaction), ctx, req)
 }
 
+// CreateJournalDraft mocks base method.
+func (m *MockJournalService) CreateJournalDraft(ctx context.Context, req models.CreateJournalDraftRequest) (*models.JournalDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJournalDraft", ctx, req)
+	ret0, _ := ret[0].(*models.JournalDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJournalDraft indicates an expected call of CreateJournalDraft.
+func (mr *MockJournalServiceMockRecorder) CreateJournalDraft(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJournalDraft", reflect.TypeOf((*MockJournalService)(nil).CreateJournalDraft), ctx, req)
+}
+
+// DownloadCSVUploadJournalReport mocks base method.
+func (m *MockJournalService) DownloadCSVUploadJournalReport(ctx context.Context, report models.UploadJournalReport) (*bytes.Buffer, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadCSVUploadJournalReport", ctx, report)
+	ret0, _ := ret[0].(*bytes.Buffer)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadCSVUploadJournalReport indicates an expected call of DownloadCSVUploadJournalReport.
+func (mr *MockJournalServiceMockRecorder) DownloadCSVUploadJournalReport(ctx, report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadCSVUploadJournalReport", reflect.TypeOf((*MockJournalService)(nil).DownloadCSVUploadJournalReport), ctx, report)
+}
+
 // GetJournalByTransactionId mocks base method.
 func (m *MockJournalService) GetJournalByTransactionId(ctx context.Context, transactionId string) ([]models.GetJournalDetailOut, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +118,36 @@ func (mr *MockJournalServiceMockRecorder) GetJournalByTransactionId(ctx, transac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalByTransactionId", reflect.TypeOf((*MockJournalService)(nil).GetJournalByTransactionId), ctx, transactionId)
 }
 
+// GetJournalDraftById mocks base method.
+func (m *MockJournalService) GetJournalDraftById(ctx context.Context, id int) (*models.JournalDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournalDraftById", ctx, id)
+	ret0, _ := ret[0].(*models.JournalDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournalDraftById indicates an expected call of GetJournalDraftById.
+func (mr *MockJournalServiceMockRecorder) GetJournalDraftById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalDraftById", reflect.TypeOf((*MockJournalService)(nil).GetJournalDraftById), ctx, id)
+}
+
+// GetJournalDrafts mocks base method.
+func (m *MockJournalService) GetJournalDrafts(ctx context.Context, opts models.JournalDraftFilterOptions) ([]models.JournalDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJournalDrafts", ctx, opts)
+	ret0, _ := ret[0].([]models.JournalDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJournalDrafts indicates an expected call of GetJournalDrafts.
+func (mr *MockJournalServiceMockRecorder) GetJournalDrafts(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJournalDrafts", reflect.TypeOf((*MockJournalService)(nil).GetJournalDrafts), ctx, opts)
+}
+
 // InsertJournalTransaction mocks base method.
 func (m *MockJournalService) InsertJournalTransaction(ctx context.Context, request models.JournalRequest) ([]models.JournalEntryCreatedRequest, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +191,21 @@ func (mr *MockJournalServiceMockRecorder) PublishJournalTransaction(ctx, req any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishJournalTransaction", reflect.TypeOf((*MockJournalService)(nil).PublishJournalTransaction), ctx, req)
 }
 
+// RejectJournalDraft mocks base method.
+func (m *MockJournalService) RejectJournalDraft(ctx context.Context, req models.RejectJournalDraftRequest) (*models.JournalDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectJournalDraft", ctx, req)
+	ret0, _ := ret[0].(*models.JournalDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectJournalDraft indicates an expected call of RejectJournalDraft.
+func (mr *MockJournalServiceMockRecorder) RejectJournalDraft(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectJournalDraft", reflect.TypeOf((*MockJournalService)(nil).RejectJournalDraft), ctx, req)
+}
+
 // RetryPublishToJournalEntryCreated mocks base method.
 func (m *MockJournalService) RetryPublishToJournalEntryCreated(ctx context.Context, data models.JournalEntryCreatedRequest) error {
 	m.ctrl.T.Helper()
@@ -127,3 +219,48 @@ func (mr *MockJournalServiceMockRecorder) RetryPublishToJournalEntryCreated(ctx,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryPublishToJournalEntryCreated", reflect.TypeOf((*MockJournalService)(nil).RetryPublishToJournalEntryCreated), ctx, data)
 }
+
+// ReverseJournal mocks base method.
+func (m *MockJournalService) ReverseJournal(ctx context.Context, req models.ReverseJournalRequest) (models.JournalRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseJournal", ctx, req)
+	ret0, _ := ret[0].(models.JournalRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseJournal indicates an expected call of ReverseJournal.
+func (mr *MockJournalServiceMockRecorder) ReverseJournal(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseJournal", reflect.TypeOf((*MockJournalService)(nil).ReverseJournal), ctx, req)
+}
+
+// SubmitJournalDraft mocks base method.
+func (m *MockJournalService) SubmitJournalDraft(ctx context.Context, req models.SubmitJournalDraftRequest) (*models.JournalDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitJournalDraft", ctx, req)
+	ret0, _ := ret[0].(*models.JournalDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitJournalDraft indicates an expected call of SubmitJournalDraft.
+func (mr *MockJournalServiceMockRecorder) SubmitJournalDraft(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitJournalDraft", reflect.TypeOf((*MockJournalService)(nil).SubmitJournalDraft), ctx, req)
+}
+
+// ValidateUploadJournal mocks base method.
+func (m *MockJournalService) ValidateUploadJournal(ctx context.Context, file *multipart.FileHeader) (models.UploadJournalReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateUploadJournal", ctx, file)
+	ret0, _ := ret[0].(models.UploadJournalReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateUploadJournal indicates an expected call of ValidateUploadJournal.
+func (mr *MockJournalServiceMockRecorder) ValidateUploadJournal(ctx, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUploadJournal", reflect.TypeOf((*MockJournalService)(nil).ValidateUploadJournal), ctx, file)
+}
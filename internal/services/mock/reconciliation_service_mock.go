@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/reconciliation_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/reconciliation_service.go -destination=./internal/services/mock/reconciliation_service_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "bitbucket.org/Amartha/go-accounting/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReconciliationService is a mock of ReconciliationService interface.
+type MockReconciliationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReconciliationServiceMockRecorder is the mock recorder for MockReconciliationService.
+type MockReconciliationServiceMockRecorder struct {
+	mock *MockReconciliationService
+}
+
+// NewMockReconciliationService creates a new mock instance.
+func NewMockReconciliationService(ctrl *gomock.Controller) *MockReconciliationService {
+	mock := &MockReconciliationService{ctrl: ctrl}
+	mock.recorder = &MockReconciliationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliationService) EXPECT() *MockReconciliationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetReconciliationOutstanding mocks base method.
+func (m *MockReconciliationService) GetReconciliationOutstanding(ctx context.Context, opts models.BankStatementLineFilterOptions) (*models.ReconciliationOutstanding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconciliationOutstanding", ctx, opts)
+	ret0, _ := ret[0].(*models.ReconciliationOutstanding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconciliationOutstanding indicates an expected call of GetReconciliationOutstanding.
+func (mr *MockReconciliationServiceMockRecorder) GetReconciliationOutstanding(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconciliationOutstanding", reflect.TypeOf((*MockReconciliationService)(nil).GetReconciliationOutstanding), ctx, opts)
+}
+
+// ImportBankStatement mocks base method.
+func (m *MockReconciliationService) ImportBankStatement(ctx context.Context, in models.ImportBankStatementRequest) (*models.BankStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBankStatement", ctx, in)
+	ret0, _ := ret[0].(*models.BankStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBankStatement indicates an expected call of ImportBankStatement.
+func (mr *MockReconciliationServiceMockRecorder) ImportBankStatement(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBankStatement", reflect.TypeOf((*MockReconciliationService)(nil).ImportBankStatement), ctx, in)
+}
+
+// MatchBankStatementLine mocks base method.
+func (m *MockReconciliationService) MatchBankStatementLine(ctx context.Context, req models.MatchBankStatementLineRequest) (*models.BankStatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchBankStatementLine", ctx, req)
+	ret0, _ := ret[0].(*models.BankStatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchBankStatementLine indicates an expected call of MatchBankStatementLine.
+func (mr *MockReconciliationServiceMockRecorder) MatchBankStatementLine(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchBankStatementLine", reflect.TypeOf((*MockReconciliationService)(nil).MatchBankStatementLine), ctx, req)
+}
+
+// UnmatchBankStatementLine mocks base method.
+func (m *MockReconciliationService) UnmatchBankStatementLine(ctx context.Context, req models.UnmatchBankStatementLineRequest) (*models.BankStatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmatchBankStatementLine", ctx, req)
+	ret0, _ := ret[0].(*models.BankStatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnmatchBankStatementLine indicates an expected call of UnmatchBankStatementLine.
+func (mr *MockReconciliationServiceMockRecorder) UnmatchBankStatementLine(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmatchBankStatementLine", reflect.TypeOf((*MockReconciliationService)(nil).UnmatchBankStatementLine), ctx, req)
+}
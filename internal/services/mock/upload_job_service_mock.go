@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/upload_job_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/upload_job_service.go -destination=./internal/services/mock/upload_job_service_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "bitbucket.org/Amartha/go-accounting/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUploadJobService is a mock of UploadJobService interface.
+type MockUploadJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadJobServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUploadJobServiceMockRecorder is the mock recorder for MockUploadJobService.
+type MockUploadJobServiceMockRecorder struct {
+	mock *MockUploadJobService
+}
+
+// NewMockUploadJobService creates a new mock instance.
+func NewMockUploadJobService(ctrl *gomock.Controller) *MockUploadJobService {
+	mock := &MockUploadJobService{ctrl: ctrl}
+	mock.recorder = &MockUploadJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadJobService) EXPECT() *MockUploadJobServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateUploadJob mocks base method.
+func (m *MockUploadJobService) CreateUploadJob(ctx context.Context, in models.CreateUploadJobRequest) (*models.UploadJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUploadJob", ctx, in)
+	ret0, _ := ret[0].(*models.UploadJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUploadJob indicates an expected call of CreateUploadJob.
+func (mr *MockUploadJobServiceMockRecorder) CreateUploadJob(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUploadJob", reflect.TypeOf((*MockUploadJobService)(nil).CreateUploadJob), ctx, in)
+}
+
+// GetUploadJobById mocks base method.
+func (m *MockUploadJobService) GetUploadJobById(ctx context.Context, id int) (*models.UploadJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUploadJobById", ctx, id)
+	ret0, _ := ret[0].(*models.UploadJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUploadJobById indicates an expected call of GetUploadJobById.
+func (mr *MockUploadJobServiceMockRecorder) GetUploadJobById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUploadJobById", reflect.TypeOf((*MockUploadJobService)(nil).GetUploadJobById), ctx, id)
+}
+
+// ProcessUploadJob mocks base method.
+func (m *MockUploadJobService) ProcessUploadJob(ctx context.Context, in models.UploadJobMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessUploadJob", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessUploadJob indicates an expected call of ProcessUploadJob.
+func (mr *MockUploadJobServiceMockRecorder) ProcessUploadJob(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessUploadJob", reflect.TypeOf((*MockUploadJobService)(nil).ProcessUploadJob), ctx, in)
+}
@@ -0,0 +1,407 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+)
+
+type ReconciliationService interface {
+	ImportBankStatement(ctx context.Context, in models.ImportBankStatementRequest) (out *models.BankStatement, err error)
+	GetReconciliationOutstanding(ctx context.Context, opts models.BankStatementLineFilterOptions) (out *models.ReconciliationOutstanding, err error)
+	MatchBankStatementLine(ctx context.Context, req models.MatchBankStatementLineRequest) (out *models.BankStatementLine, err error)
+	UnmatchBankStatementLine(ctx context.Context, req models.UnmatchBankStatementLineRequest) (out *models.BankStatementLine, err error)
+}
+
+type reconciliationService service
+
+var _ ReconciliationService = (*reconciliationService)(nil)
+
+// defaultMatchDateWindowDays is used when the date window of the auto match is not configured.
+const defaultMatchDateWindowDays = 3
+
+/*
+1. make sure the account exists
+2. reject the statement file when the same file is already imported to the account
+3. parse the statement file, the whole file is rejected when any line is invalid
+4. auto match the lines with the splits of the account
+5. store the statement & the lines in one transaction
+*/
+func (rs *reconciliationService) ImportBankStatement(ctx context.Context, in models.ImportBankStatementRequest) (out *models.BankStatement, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	account, err := rs.srv.mySqlRepo.GetAccountRepository().CheckAccountNumberIsExist(ctx, in.AccountNumber)
+	if err != nil {
+		err = checkDatabaseError(err)
+		return nil, err
+	}
+	if account == nil {
+		err = models.GetErrMap(models.ErrKeyAccountNumberNotFound, in.AccountNumber)
+		return nil, err
+	}
+
+	src, err := in.File.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	checksum, err := bankStatementChecksum(src)
+	if err != nil {
+		return nil, err
+	}
+	isExist, err := rs.srv.mySqlRepo.GetAccountingRepository().CheckBankStatementIsExist(ctx, in.AccountNumber, checksum)
+	if err != nil {
+		return nil, err
+	}
+	if isExist {
+		err = models.GetErrMap(models.ErrKeyBankStatementIsImported, in.File.Filename)
+		return nil, err
+	}
+
+	lines, err := readBankStatement(rs.srv.file, src, in.Format)
+	if err != nil {
+		err = models.GetErrMap(models.ErrKeyInvalidBankStatement, err.Error())
+		return nil, err
+	}
+	for i := range lines {
+		lines[i].AccountNumber = in.AccountNumber
+		lines[i].Status = models.BankStatementLineStatusUnmatched
+	}
+
+	if err = rs.autoMatchBankStatementLines(ctx, in.AccountNumber, lines); err != nil {
+		return nil, err
+	}
+
+	out = &models.BankStatement{
+		AccountNumber: in.AccountNumber,
+		Format:        in.Format,
+		Filename:      in.File.Filename,
+		ImportedBy:    in.ImportedBy,
+		Checksum:      checksum,
+		CreatedAt:     atime.Now(),
+	}
+	if err = rs.srv.mySqlRepo.Atomic(ctx, func(actx context.Context, r mysql.SQLRepository) (err error) {
+		out.ID, err = r.GetAccountingRepository().InsertBankStatement(actx, *out)
+		if err != nil {
+			return
+		}
+
+		for i := range lines {
+			lines[i].StatementID = out.ID
+		}
+		return r.GetAccountingRepository().InsertBankStatementLines(actx, lines)
+	}); err != nil {
+		return nil, err
+	}
+
+	out.Lines, err = rs.srv.mySqlRepo.GetAccountingRepository().GetBankStatementLines(ctx, models.BankStatementLineFilterOptions{
+		StatementID: out.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// bankStatementChecksum returns the sha256 of the statement file & rewinds the file to be parsed.
+func bankStatementChecksum(src multipart.File) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, src); err != nil {
+		return "", err
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// GetReconciliationOutstanding returns the unmatched statement lines & the splits of the account in the period which are not matched with any line.
+func (rs *reconciliationService) GetReconciliationOutstanding(ctx context.Context, opts models.BankStatementLineFilterOptions) (out *models.ReconciliationOutstanding, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out = &models.ReconciliationOutstanding{
+		AccountNumber: opts.AccountNumber,
+		StartDate:     opts.StartDate,
+		EndDate:       opts.EndDate,
+	}
+
+	opts.Status = models.BankStatementLineStatusUnmatched
+	out.StatementLines, err = rs.srv.mySqlRepo.GetAccountingRepository().GetBankStatementLines(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out.LedgerItems, err = rs.getUnmatchedSplits(ctx, models.SubLedgerFilterOptions{
+		AccountNumber: opts.AccountNumber,
+		StartDate:     opts.StartDate,
+		EndDate:       opts.EndDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+/*
+1. the line must be unmatched
+2. the journal must be a split of the line account with the same amount & the matching side
+3. the journal must not be matched with another line
+4. match the line, no row is updated when the line is matched by another request
+*/
+func (rs *reconciliationService) MatchBankStatementLine(ctx context.Context, req models.MatchBankStatementLineRequest) (out *models.BankStatementLine, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = rs.getBankStatementLineById(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if out.Status == models.BankStatementLineStatusMatched {
+		err = models.GetErrMap(models.ErrKeyBankStatementLineIsMatched, out.JournalId)
+		return nil, err
+	}
+
+	journals, err := rs.srv.mySqlRepo.GetAccountingRepository().GetJournalDetailByTransactionId(ctx, req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	var journal *models.GetJournalDetailOut
+	for i, v := range journals {
+		if v.JournalId == req.JournalId {
+			journal = &journals[i]
+			break
+		}
+	}
+	if journal == nil {
+		err = models.GetErrMap(models.ErrKeyJournalIdNotFound, req.JournalId)
+		return nil, err
+	}
+	if journal.AccountNumber != out.AccountNumber || !journal.Amount.Equal(out.Amount) || journal.IsDebit != out.IsLedgerDebit() {
+		err = models.GetErrMap(models.ErrKeyBankStatementLineMismatch, "account, amount & side of the journal must be the same as the line")
+		return nil, err
+	}
+
+	matched, err := rs.srv.mySqlRepo.GetAccountingRepository().GetBankStatementLinesByJournalIds(ctx, []string{req.JournalId})
+	if err != nil {
+		return nil, err
+	}
+	if len(matched) > 0 {
+		err = models.GetErrMap(models.ErrKeyJournalIsReconciled, strconv.Itoa(matched[0].ID))
+		return nil, err
+	}
+
+	now := atime.Now()
+	out.Status = models.BankStatementLineStatusMatched
+	out.TransactionId = req.TransactionId
+	out.JournalId = req.JournalId
+	out.MatchType = models.BankStatementMatchTypeManual
+	out.MatchedBy = req.MatchedBy
+	out.MatchedAt = &now
+	if err = rs.updateBankStatementLineMatch(ctx, *out, models.BankStatementLineStatusUnmatched); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// UnmatchBankStatementLine moves the matched line back to the outstanding items, both auto & manual matches can be unmatched.
+func (rs *reconciliationService) UnmatchBankStatementLine(ctx context.Context, req models.UnmatchBankStatementLineRequest) (out *models.BankStatementLine, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = rs.getBankStatementLineById(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if out.Status != models.BankStatementLineStatusMatched {
+		err = models.GetErrMap(models.ErrKeyBankStatementLineNotMatched, strconv.Itoa(out.ID))
+		return nil, err
+	}
+
+	out.Status = models.BankStatementLineStatusUnmatched
+	out.TransactionId = ""
+	out.JournalId = ""
+	out.MatchType = ""
+	out.MatchedBy = ""
+	out.MatchedAt = nil
+	if err = rs.updateBankStatementLineMatch(ctx, *out, models.BankStatementLineStatusMatched); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+/*
+autoMatchBankStatementLines matches the lines with the unmatched splits of the account on the same amount & side within the date window,
+1. a split whose reference number or transaction id is the reference number of the line, the closest date wins
+2. otherwise the only split with the same amount & side, a line with more candidates is left for the manual match
+*/
+func (rs *reconciliationService) autoMatchBankStatementLines(ctx context.Context, accountNumber string, lines []models.BankStatementLine) error {
+	window := rs.srv.conf.Reconciliation.MatchDateWindowDays
+	if window <= 0 {
+		window = defaultMatchDateWindowDays
+	}
+
+	startDate, endDate := lines[0].LineDate, lines[0].LineDate
+	for _, v := range lines {
+		if v.LineDate.Before(startDate) {
+			startDate = v.LineDate
+		}
+		if v.LineDate.After(endDate) {
+			endDate = v.LineDate
+		}
+	}
+	startDate, endDate = atime.StartDateEndDate(startDate.AddDate(0, 0, -window), endDate.AddDate(0, 0, window))
+
+	splits, err := rs.getUnmatchedSplits(ctx, models.SubLedgerFilterOptions{
+		AccountNumber: accountNumber,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := atime.Now()
+	used := make([]bool, len(splits))
+	match := func(line *models.BankStatementLine, i int) {
+		used[i] = true
+		line.Status = models.BankStatementLineStatusMatched
+		line.TransactionId = splits[i].TransactionID
+		line.JournalId = splits[i].JournalID
+		line.MatchType = models.BankStatementMatchTypeAuto
+		line.MatchedAt = &now
+	}
+
+	for i := range lines {
+		if lines[i].ReferenceNumber == "" {
+			continue
+		}
+		best, bestDiff := -1, time.Duration(0)
+		for j, s := range splits {
+			if used[j] || (s.ReferenceNumber != lines[i].ReferenceNumber && s.TransactionID != lines[i].ReferenceNumber) {
+				continue
+			}
+			diff, ok := bankStatementLineDateDiff(lines[i], s, window)
+			if !ok || !isBankStatementLineAmount(lines[i], s) {
+				continue
+			}
+			if best < 0 || diff < bestDiff {
+				best, bestDiff = j, diff
+			}
+		}
+		if best >= 0 {
+			match(&lines[i], best)
+		}
+	}
+
+	for i := range lines {
+		if lines[i].Status == models.BankStatementLineStatusMatched {
+			continue
+		}
+		candidates := []int{}
+		for j, s := range splits {
+			if _, ok := bankStatementLineDateDiff(lines[i], s, window); ok && !used[j] && isBankStatementLineAmount(lines[i], s) {
+				candidates = append(candidates, j)
+			}
+		}
+		if len(candidates) == 1 {
+			match(&lines[i], candidates[0])
+		}
+	}
+
+	return nil
+}
+
+// getUnmatchedSplits returns the splits of the sub ledger which are not matched with any statement line.
+func (rs *reconciliationService) getUnmatchedSplits(ctx context.Context, opts models.SubLedgerFilterOptions) (out []models.GetSubLedgerOut, err error) {
+	splits, err := rs.srv.mySqlRepo.GetAccountingRepository().GetSubLedger(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	journalIds := make([]string, 0, len(splits))
+	for _, v := range splits {
+		journalIds = append(journalIds, v.JournalID)
+	}
+	matched, err := rs.srv.mySqlRepo.GetAccountingRepository().GetBankStatementLinesByJournalIds(ctx, journalIds)
+	if err != nil {
+		return nil, err
+	}
+
+	matchedJournalIds := make(map[string]bool, len(matched))
+	for _, v := range matched {
+		matchedJournalIds[v.JournalId] = true
+	}
+	for _, v := range splits {
+		if !matchedJournalIds[v.JournalID] {
+			out = append(out, v)
+		}
+	}
+
+	return out, nil
+}
+
+func (rs *reconciliationService) getBankStatementLineById(ctx context.Context, id int) (*models.BankStatementLine, error) {
+	line, err := rs.srv.mySqlRepo.GetAccountingRepository().GetBankStatementLineById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if line == nil {
+		return nil, models.GetErrMap(models.ErrKeyBankStatementLineNotFound, strconv.Itoa(id))
+	}
+
+	return line, nil
+}
+
+// updateBankStatementLineMatch moves the line from the given status, no row is updated when the line is moved by another request.
+func (rs *reconciliationService) updateBankStatementLineMatch(ctx context.Context, line models.BankStatementLine, fromStatus string) error {
+	err := rs.srv.mySqlRepo.GetAccountingRepository().UpdateBankStatementLineMatch(ctx, line, fromStatus)
+	if errors.Is(err, models.ErrNoRowsAffected) {
+		if fromStatus == models.BankStatementLineStatusUnmatched {
+			return models.GetErrMap(models.ErrKeyBankStatementLineIsMatched, strconv.Itoa(line.ID))
+		}
+		return models.GetErrMap(models.ErrKeyBankStatementLineNotMatched, strconv.Itoa(line.ID))
+	}
+
+	return err
+}
+
+// isBankStatementLineAmount returns true when the split is on the matching side of the line with the same amount.
+func isBankStatementLineAmount(line models.BankStatementLine, split models.GetSubLedgerOut) bool {
+	amount := split.Credit
+	if line.IsLedgerDebit() {
+		amount = split.Debit
+	}
+
+	return amount.IsPositive() && amount.Equal(line.Amount)
+}
+
+// bankStatementLineDateDiff returns the days between the statement date & the transaction date, ok is false when it is outside the window.
+func bankStatementLineDateDiff(line models.BankStatementLine, split models.GetSubLedgerOut, window int) (diff time.Duration, ok bool) {
+	diff = atime.ToZeroTime(split.TransactionDate.In(atime.GetLocation())).Sub(atime.ToZeroTime(line.LineDate))
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return diff, diff <= time.Duration(window)*24*time.Hour
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/file"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// mt940DateFormat is the value date of a :61: statement line, e.g. 240423.
+	mt940DateFormat = "060102"
+
+	// mt940NoReference is the customer reference of a mt940 line without reference, the bank reference is used instead.
+	mt940NoReference = "NONREF"
+)
+
+var (
+	// mt940Tag matches the first line of a mt940 field, e.g. :61: or :60F:.
+	mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+
+	// mt940StatementLine matches the :61: field, value date, optional entry date, debit/credit mark, optional funds code,
+	// amount, transaction type & the references.
+	mt940StatementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)[A-Z]?(\d+,\d*)([A-Z][A-Z0-9]{3})(.*)$`)
+)
+
+// bankStatementDateFormats are the accepted date formats of a csv statement.
+var bankStatementDateFormats = []string{
+	atime.DateFormatYYYYMMDD,
+	atime.DateFormatDDMMYYYYWithSlash,
+}
+
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// readBankStatement parses the statement file by the format, the statement is rejected when any line is invalid.
+func readBankStatement(f file.IOFile, src io.Reader, format string) (lines []models.BankStatementLine, err error) {
+	switch format {
+	case models.BankStatementFormatCSV:
+		records, errRead := f.CSVReadAll(src)
+		if errRead != nil {
+			return nil, errRead
+		}
+		lines, err = parseCSVBankStatement(records)
+	case models.BankStatementFormatMT940:
+		lines, err = parseMT940BankStatement(src)
+	default:
+		err = fmt.Errorf("format %s is not supported", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("statement has no line")
+	}
+
+	return lines, nil
+}
+
+// parseCSVBankStatement parses the rows by the header, either the debit or the credit of a row must be filled.
+func parseCSVBankStatement(records [][]string) (lines []models.BankStatementLine, err error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, v := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(v, "\ufeff")))] = i
+	}
+	for _, v := range []string{models.BankStatementColumnDate, models.BankStatementColumnDebit, models.BankStatementColumnCredit} {
+		if _, ok := columns[v]; !ok {
+			return nil, fmt.Errorf("column %s is required", v)
+		}
+	}
+
+	value := func(r []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(r) {
+			return ""
+		}
+		return strings.TrimSpace(r[i])
+	}
+
+	for i, r := range records[1:] {
+		if strings.TrimSpace(strings.Join(r, "")) == "" {
+			continue
+		}
+
+		line, errLine := func() (line models.BankStatementLine, err error) {
+			line.LineDate, err = parseBankStatementDate(value(r, models.BankStatementColumnDate))
+			if err != nil {
+				return
+			}
+
+			debit, credit := value(r, models.BankStatementColumnDebit), value(r, models.BankStatementColumnCredit)
+			if (debit == "") == (credit == "") {
+				err = fmt.Errorf("either %s or %s must be filled", models.BankStatementColumnDebit, models.BankStatementColumnCredit)
+				return
+			}
+
+			column, amount := models.BankStatementColumnDebit, debit
+			if credit != "" {
+				column, amount = models.BankStatementColumnCredit, credit
+			}
+			if line.Amount, err = decimal.NewFromString(amount); err != nil {
+				err = fmt.Errorf("invalid %s: %w", column, err)
+				return
+			}
+			if !line.Amount.IsPositive() {
+				err = fmt.Errorf("%s must be greater than zero", column)
+				return
+			}
+
+			line.IsCredit = credit != ""
+			line.ReferenceNumber = value(r, models.BankStatementColumnReferenceNumber)
+			line.Description = value(r, models.BankStatementColumnDescription)
+			return
+		}()
+		if errLine != nil {
+			// the header is the first row
+			return nil, fmt.Errorf("row %d: %w", i+2, errLine)
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+func parseBankStatementDate(v string) (date time.Time, err error) {
+	for _, layout := range bankStatementDateFormats {
+		if date, err = atime.ParseStringToDatetime(layout, v); err == nil {
+			return date, nil
+		}
+	}
+
+	return date, fmt.Errorf("invalid %s %s", models.BankStatementColumnDate, v)
+}
+
+/*
+parseMT940BankStatement parses the statement lines of a mt940 file,
+1. a field starts with the tag & continues until the next tag, the block trailer "-" is ignored
+2. every :61: field is a line, the :86: field following it is the description of the line
+*/
+func parseMT940BankStatement(src io.Reader) (lines []models.BankStatementLine, err error) {
+	var fields []mt940Field
+	scanner := bufio.NewScanner(src)
+	for scanner.Scan() {
+		text := strings.TrimRight(scanner.Text(), "\r ")
+		if match := mt940Tag.FindStringSubmatch(text); match != nil {
+			fields = append(fields, mt940Field{tag: match[1], value: match[2]})
+			continue
+		}
+		if text == "" || strings.HasPrefix(text, "-") || len(fields) == 0 {
+			continue
+		}
+		fields[len(fields)-1].value += "\n" + text
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, v := range fields {
+		switch v.tag {
+		case "61":
+			line, errLine := parseMT940StatementLine(v.value)
+			if errLine != nil {
+				return nil, fmt.Errorf("field %d: %w", i+1, errLine)
+			}
+			lines = append(lines, line)
+		case "86":
+			if i > 0 && fields[i-1].tag == "61" {
+				lines[len(lines)-1].Description = strings.Join(strings.Fields(v.value), " ")
+			}
+		}
+	}
+
+	return lines, nil
+}
+
+// parseMT940StatementLine parses the first line of a :61: field, a reversal of a credit is a debit & vice versa.
+func parseMT940StatementLine(v string) (line models.BankStatementLine, err error) {
+	first := strings.SplitN(v, "\n", 2)[0]
+	match := mt940StatementLine.FindStringSubmatch(first)
+	if match == nil {
+		return line, fmt.Errorf("invalid statement line %s", first)
+	}
+
+	if line.LineDate, err = atime.ParseStringToDatetime(mt940DateFormat, match[1]); err != nil {
+		return line, fmt.Errorf("invalid value date %s", match[1])
+	}
+	if line.Amount, err = decimal.NewFromString(strings.Replace(match[4], ",", ".", 1)); err != nil {
+		return line, fmt.Errorf("invalid amount %s", match[4])
+	}
+	line.IsCredit = match[3] == "C" || match[3] == "RD"
+
+	reference, bankReference, _ := strings.Cut(match[6], "//")
+	line.ReferenceNumber = strings.TrimSpace(reference)
+	if line.ReferenceNumber == "" || line.ReferenceNumber == mt940NoReference {
+		line.ReferenceNumber = strings.TrimSpace(bankReference)
+	}
+
+	return line, nil
+}
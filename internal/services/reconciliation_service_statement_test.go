@@ -0,0 +1,142 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func statementDate(t *testing.T, v string) time.Time {
+	t.Helper()
+
+	date, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDD, v)
+	require.NoError(t, err)
+	return date
+}
+
+func Test_parseCSVBankStatement(t *testing.T) {
+	header := []string{"\ufeffDate", "reference_number", "description", "debit", "credit"}
+
+	tests := []struct {
+		name    string
+		records [][]string
+		want    []models.BankStatementLine
+		wantErr string
+	}{
+		{
+			name: "success case",
+			records: [][]string{
+				header,
+				{"2024-04-23", "ref-1", "VA repayment", "", "100000.50"},
+				{"", "", "", "", ""},
+				{"24/04/2024", "", "bank charge", "5000", ""},
+			},
+			want: []models.BankStatementLine{
+				{LineDate: statementDate(t, "2024-04-23"), Amount: decimal.RequireFromString("100000.50"), IsCredit: true, ReferenceNumber: "ref-1", Description: "VA repayment"},
+				{LineDate: statementDate(t, "2024-04-24"), Amount: decimal.NewFromInt(5000), Description: "bank charge"},
+			},
+		},
+		{
+			name:    "error case - missing column",
+			records: [][]string{{"date", "debit"}},
+			wantErr: "column credit is required",
+		},
+		{
+			name:    "error case - invalid date",
+			records: [][]string{header, {"23-04-2024", "ref-1", "", "", "100"}},
+			wantErr: "row 2: invalid date 23-04-2024",
+		},
+		{
+			name:    "error case - both debit & credit",
+			records: [][]string{header, {"2024-04-23", "ref-1", "", "100", "100"}},
+			wantErr: "row 2: either debit or credit must be filled",
+		},
+		{
+			name:    "error case - invalid amount",
+			records: [][]string{header, {"2024-04-23", "ref-1", "", "1OO", ""}},
+			wantErr: "row 2: invalid debit: can't convert 1OO to decimal",
+		},
+		{
+			name:    "error case - zero amount",
+			records: [][]string{header, {"2024-04-23", "ref-1", "", "", "0"}},
+			wantErr: "row 2: credit must be greater than zero",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCSVBankStatement(tt.records)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseMT940BankStatement(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []models.BankStatementLine
+		wantErr string
+	}{
+		{
+			name: "success case",
+			content: "{1:F01BANKIDJAXXXX0000000000}{2:I940BANKIDJAXXXXN}{4:\r\n" +
+				":20:STMT240423\r\n" +
+				":25:1234567890\r\n" +
+				":28C:1/1\r\n" +
+				":60F:C240422IDR1000000,00\r\n" +
+				":61:2404230423C100000,50NTRFref-1//BANKREF1\r\n" +
+				":86:VA repayment\r\n" +
+				"borrower 123\r\n" +
+				":61:240424D5000,NCHGNONREF//BANKREF2\r\n" +
+				":61:240424RD250,00NTRFref-3\r\n" +
+				":86:reversal\r\n" +
+				":62F:C240424IDR1095000,50\r\n" +
+				"-}",
+			want: []models.BankStatementLine{
+				{LineDate: statementDate(t, "2024-04-23"), Amount: decimal.RequireFromString("100000.50"), IsCredit: true, ReferenceNumber: "ref-1", Description: "VA repayment borrower 123"},
+				{LineDate: statementDate(t, "2024-04-24"), Amount: decimal.NewFromInt(5000), ReferenceNumber: "BANKREF2"},
+				{LineDate: statementDate(t, "2024-04-24"), Amount: decimal.NewFromInt(250), IsCredit: true, ReferenceNumber: "ref-3", Description: "reversal"},
+			},
+		},
+		{
+			name:    "error case - invalid statement line",
+			content: ":20:STMT240423\n:61:240423X100,00NTRFref-1\n",
+			wantErr: "field 2: invalid statement line 240423X100,00NTRFref-1",
+		},
+		{
+			name:    "error case - invalid value date",
+			content: ":61:241323C100,00NTRFref-1\n",
+			wantErr: "field 1: invalid value date 241323",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMT940BankStatement(strings.NewReader(tt.content))
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i, v := range tt.want {
+				assert.True(t, v.Amount.Equal(got[i].Amount), "amount of line %d", i)
+				got[i].Amount = v.Amount
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,502 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"mime/multipart"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func bankStatementFile(t *testing.T, filename, content string) *multipart.FileHeader {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	form, err := multipart.NewReader(body, w.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	return form.File["file"][0]
+}
+
+func reconciliationDate(t *testing.T, v string) time.Time {
+	t.Helper()
+
+	date, err := atime.ParseStringToDatetime(atime.DateFormatYYYYMMDDWithTime, v)
+	require.NoError(t, err)
+	return date
+}
+
+func Test_reconciliationService_ImportBankStatement(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	const accountNumber = "142001000000001"
+	mt940 := ":20:STMT240423\n" +
+		":25:1234567890\n" +
+		":61:2404230423C100000,00NTRFref-1//BANKREF1\n" +
+		":86:VA repayment\n" +
+		":61:240424D5000,00NCHGNONREF//BANKREF2\n" +
+		":61:240424C250,00NTRFref-3\n" +
+		"-"
+	splits := []models.GetSubLedgerOut{
+		{TransactionID: "trx-0", ReferenceNumber: "other", TransactionDate: reconciliationDate(t, "2024-04-23 10:00:00"), Debit: decimal.NewFromInt(100000), JournalID: "journal-0"},
+		{TransactionID: "trx-1", ReferenceNumber: "ref-1", TransactionDate: reconciliationDate(t, "2024-04-22 23:00:00"), Debit: decimal.NewFromInt(100000), JournalID: "journal-1"},
+		{TransactionID: "trx-2", ReferenceNumber: "fee", TransactionDate: reconciliationDate(t, "2024-04-26 08:00:00"), Credit: decimal.NewFromInt(5000), JournalID: "journal-2"},
+		{TransactionID: "trx-4", ReferenceNumber: "x", TransactionDate: reconciliationDate(t, "2024-04-24 08:00:00"), Debit: decimal.NewFromInt(250), JournalID: "journal-4"},
+		{TransactionID: "trx-5", ReferenceNumber: "y", TransactionDate: reconciliationDate(t, "2024-04-24 09:00:00"), Debit: decimal.NewFromInt(250), JournalID: "journal-5"},
+		{TransactionID: "trx-6", ReferenceNumber: "fee", TransactionDate: reconciliationDate(t, "2024-04-24 09:00:00"), Credit: decimal.NewFromInt(5000), JournalID: "journal-6"},
+	}
+
+	tests := []struct {
+		name    string
+		req     models.ImportBankStatementRequest
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success case - lines are matched by reference then by the only candidate",
+			req: models.ImportBankStatementRequest{
+				AccountNumber: accountNumber,
+				Format:        models.BankStatementFormatMT940,
+				ImportedBy:    "tono@amartha.com",
+				File:          bankStatementFile(t, "statement.sta", mt940),
+			},
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().CheckAccountNumberIsExist(gomock.Any(), accountNumber).Return(&models.CheckAccountNumberIsExist{}, nil)
+				testHelper.mockAcctRepository.EXPECT().CheckBankStatementIsExist(gomock.Any(), accountNumber, gomock.Any()).Return(false, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetSubLedger(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, opts models.SubLedgerFilterOptions) ([]models.GetSubLedgerOut, error) {
+						assert.Equal(t, accountNumber, opts.AccountNumber)
+						assert.Equal(t, "2024-04-20 00:00:00", opts.StartDate.Format(atime.DateFormatYYYYMMDDWithTime))
+						assert.Equal(t, "2024-04-27 23:59:59", opts.EndDate.Format(atime.DateFormatYYYYMMDDWithTime))
+						return splits, nil
+					})
+				testHelper.mockAcctRepository.EXPECT().
+					GetBankStatementLinesByJournalIds(gomock.Any(), []string{"journal-0", "journal-1", "journal-2", "journal-4", "journal-5", "journal-6"}).
+					Return([]models.BankStatementLine{{ID: 9, JournalId: "journal-6"}}, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAcctRepository.EXPECT().
+							InsertBankStatement(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, in models.BankStatement) (int, error) {
+								assert.Equal(t, "statement.sta", in.Filename)
+								assert.Equal(t, models.BankStatementFormatMT940, in.Format)
+								assert.Equal(t, fmt.Sprintf("%x", sha256.Sum256([]byte(mt940))), in.Checksum)
+								return 3, nil
+							})
+						testHelper.mockAcctRepository.EXPECT().
+							InsertBankStatementLines(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(ctx context.Context, in []models.BankStatementLine) error {
+								require.Len(t, in, 3)
+								assert.Equal(t, 3, in[0].StatementID)
+								assert.Equal(t, accountNumber, in[0].AccountNumber)
+								assert.Equal(t, "journal-1", in[0].JournalId)
+								assert.Equal(t, "trx-1", in[0].TransactionId)
+								assert.Equal(t, models.BankStatementMatchTypeAuto, in[0].MatchType)
+								assert.Equal(t, "journal-2", in[1].JournalId)
+								assert.Equal(t, models.BankStatementLineStatusMatched, in[1].Status)
+								assert.Equal(t, models.BankStatementLineStatusUnmatched, in[2].Status)
+								assert.Empty(t, in[2].JournalId)
+								return nil
+							})
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockAcctRepository.EXPECT().
+					GetBankStatementLines(gomock.Any(), models.BankStatementLineFilterOptions{StatementID: 3}).
+					Return([]models.BankStatementLine{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+			},
+		},
+		{
+			name: "error case - account not found",
+			req: models.ImportBankStatementRequest{
+				AccountNumber: accountNumber,
+				Format:        models.BankStatementFormatMT940,
+				File:          bankStatementFile(t, "statement.sta", mt940),
+			},
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().CheckAccountNumberIsExist(gomock.Any(), accountNumber).Return(nil, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyAccountNumberNotFound, accountNumber),
+		},
+		{
+			name: "error case - statement is already imported",
+			req: models.ImportBankStatementRequest{
+				AccountNumber: accountNumber,
+				Format:        models.BankStatementFormatMT940,
+				File:          bankStatementFile(t, "statement.sta", mt940),
+			},
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().CheckAccountNumberIsExist(gomock.Any(), accountNumber).Return(&models.CheckAccountNumberIsExist{}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					CheckBankStatementIsExist(gomock.Any(), accountNumber, fmt.Sprintf("%x", sha256.Sum256([]byte(mt940)))).
+					Return(true, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyBankStatementIsImported, "statement.sta"),
+		},
+		{
+			name: "error case - invalid csv statement",
+			req: models.ImportBankStatementRequest{
+				AccountNumber: accountNumber,
+				Format:        models.BankStatementFormatCSV,
+				File:          bankStatementFile(t, "statement.csv", "date,debit\n"),
+			},
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().CheckAccountNumberIsExist(gomock.Any(), accountNumber).Return(&models.CheckAccountNumberIsExist{}, nil)
+				testHelper.mockAcctRepository.EXPECT().CheckBankStatementIsExist(gomock.Any(), accountNumber, gomock.Any()).Return(false, nil)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{{"date", "debit"}}, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyInvalidBankStatement, "column credit is required"),
+		},
+		{
+			name: "error case - statement has no line",
+			req: models.ImportBankStatementRequest{
+				AccountNumber: accountNumber,
+				Format:        models.BankStatementFormatMT940,
+				File:          bankStatementFile(t, "statement.sta", ":20:STMT240423\n"),
+			},
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().CheckAccountNumberIsExist(gomock.Any(), accountNumber).Return(&models.CheckAccountNumberIsExist{}, nil)
+				testHelper.mockAcctRepository.EXPECT().CheckBankStatementIsExist(gomock.Any(), accountNumber, gomock.Any()).Return(false, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyInvalidBankStatement, "statement has no line"),
+		},
+		{
+			name: "error case - get sub ledger",
+			req: models.ImportBankStatementRequest{
+				AccountNumber: accountNumber,
+				Format:        models.BankStatementFormatMT940,
+				File:          bankStatementFile(t, "statement.sta", mt940),
+			},
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().CheckAccountNumberIsExist(gomock.Any(), accountNumber).Return(&models.CheckAccountNumberIsExist{}, nil)
+				testHelper.mockAcctRepository.EXPECT().CheckBankStatementIsExist(gomock.Any(), accountNumber, gomock.Any()).Return(false, nil)
+				testHelper.mockAcctRepository.EXPECT().GetSubLedger(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "error case - insert statement",
+			req: models.ImportBankStatementRequest{
+				AccountNumber: accountNumber,
+				Format:        models.BankStatementFormatMT940,
+				File:          bankStatementFile(t, "statement.sta", mt940),
+			},
+			doMock: func() {
+				testHelper.mockAccRepository.EXPECT().CheckAccountNumberIsExist(gomock.Any(), accountNumber).Return(&models.CheckAccountNumberIsExist{}, nil)
+				testHelper.mockAcctRepository.EXPECT().CheckBankStatementIsExist(gomock.Any(), accountNumber, gomock.Any()).Return(false, nil)
+				testHelper.mockAcctRepository.EXPECT().GetSubLedger(gomock.Any(), gomock.Any()).Return(nil, nil)
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLinesByJournalIds(gomock.Any(), []string{}).Return(nil, nil)
+				testHelper.mockMySQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := testHelper.reconciliationService.ImportBankStatement(context.TODO(), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, out)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 3, out.ID)
+			assert.Len(t, out.Lines, 3)
+		})
+	}
+}
+
+func Test_reconciliationService_GetReconciliationOutstanding(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	opts := models.BankStatementLineFilterOptions{
+		AccountNumber: "142001000000001",
+		StartDate:     reconciliationDate(t, "2024-04-01 00:00:00"),
+		EndDate:       reconciliationDate(t, "2024-04-30 23:59:59"),
+	}
+	wantOpts := opts
+	wantOpts.Status = models.BankStatementLineStatusUnmatched
+	subLedgerOpts := models.SubLedgerFilterOptions{
+		AccountNumber: opts.AccountNumber,
+		StartDate:     opts.StartDate,
+		EndDate:       opts.EndDate,
+	}
+
+	tests := []struct {
+		name    string
+		doMock  func()
+		check   func(t *testing.T, out *models.ReconciliationOutstanding)
+		wantErr bool
+	}{
+		{
+			name: "success case - matched splits are excluded",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetBankStatementLines(gomock.Any(), wantOpts).
+					Return([]models.BankStatementLine{{ID: 2, Status: models.BankStatementLineStatusUnmatched}}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetSubLedger(gomock.Any(), subLedgerOpts).
+					Return([]models.GetSubLedgerOut{{JournalID: "journal-1"}, {JournalID: "journal-2"}}, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetBankStatementLinesByJournalIds(gomock.Any(), []string{"journal-1", "journal-2"}).
+					Return([]models.BankStatementLine{{ID: 1, JournalId: "journal-1"}}, nil)
+			},
+			check: func(t *testing.T, out *models.ReconciliationOutstanding) {
+				assert.Len(t, out.StatementLines, 1)
+				require.Len(t, out.LedgerItems, 1)
+				assert.Equal(t, "journal-2", out.LedgerItems[0].JournalID)
+			},
+		},
+		{
+			name: "error case - get statement lines",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLines(gomock.Any(), wantOpts).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - get matched lines",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLines(gomock.Any(), wantOpts).Return(nil, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetSubLedger(gomock.Any(), subLedgerOpts).
+					Return([]models.GetSubLedgerOut{{JournalID: "journal-1"}}, nil)
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLinesByJournalIds(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := testHelper.reconciliationService.GetReconciliationOutstanding(context.TODO(), opts)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.check != nil {
+				tt.check(t, out)
+			}
+		})
+	}
+}
+
+func Test_reconciliationService_MatchBankStatementLine(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	req := models.MatchBankStatementLineRequest{
+		ID:            1,
+		TransactionId: "trx-1",
+		JournalId:     "journal-1",
+		MatchedBy:     "tono@amartha.com",
+	}
+	line := func() *models.BankStatementLine {
+		return &models.BankStatementLine{
+			ID:            1,
+			AccountNumber: "142001000000001",
+			Amount:        decimal.NewFromInt(100000),
+			IsCredit:      true,
+			Status:        models.BankStatementLineStatusUnmatched,
+		}
+	}
+	journals := []models.GetJournalDetailOut{
+		{TransactionId: "trx-1", JournalId: "journal-1", AccountNumber: "142001000000001", Amount: decimal.NewFromInt(100000), IsDebit: true},
+		{TransactionId: "trx-1", JournalId: "journal-2", AccountNumber: "211001000381110", Amount: decimal.NewFromInt(100000)},
+	}
+
+	tests := []struct {
+		name    string
+		req     models.MatchBankStatementLineRequest
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success case",
+			req:  req,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLineById(gomock.Any(), 1).Return(line(), nil)
+				testHelper.mockAcctRepository.EXPECT().GetJournalDetailByTransactionId(gomock.Any(), "trx-1").Return(journals, nil)
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLinesByJournalIds(gomock.Any(), []string{"journal-1"}).Return(nil, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					UpdateBankStatementLineMatch(gomock.Any(), gomock.Any(), models.BankStatementLineStatusUnmatched).
+					DoAndReturn(func(ctx context.Context, in models.BankStatementLine, fromStatus string) error {
+						assert.Equal(t, models.BankStatementLineStatusMatched, in.Status)
+						assert.Equal(t, models.BankStatementMatchTypeManual, in.MatchType)
+						assert.Equal(t, "journal-1", in.JournalId)
+						assert.Equal(t, "tono@amartha.com", in.MatchedBy)
+						assert.NotNil(t, in.MatchedAt)
+						return nil
+					})
+			},
+		},
+		{
+			name: "error case - line not found",
+			req:  req,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLineById(gomock.Any(), 1).Return(nil, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyBankStatementLineNotFound, "1"),
+		},
+		{
+			name: "error case - line is matched",
+			req:  req,
+			doMock: func() {
+				l := line()
+				l.Status = models.BankStatementLineStatusMatched
+				l.JournalId = "journal-9"
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLineById(gomock.Any(), 1).Return(l, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyBankStatementLineIsMatched, "journal-9"),
+		},
+		{
+			name: "error case - journal not found",
+			req: func() models.MatchBankStatementLineRequest {
+				r := req
+				r.JournalId = "journal-3"
+				return r
+			}(),
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLineById(gomock.Any(), 1).Return(line(), nil)
+				testHelper.mockAcctRepository.EXPECT().GetJournalDetailByTransactionId(gomock.Any(), "trx-1").Return(journals, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyJournalIdNotFound, "journal-3"),
+		},
+		{
+			name: "error case - journal is on the other side",
+			req: func() models.MatchBankStatementLineRequest {
+				r := req
+				r.JournalId = "journal-2"
+				return r
+			}(),
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLineById(gomock.Any(), 1).Return(line(), nil)
+				testHelper.mockAcctRepository.EXPECT().GetJournalDetailByTransactionId(gomock.Any(), "trx-1").Return(journals, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyBankStatementLineMismatch, "account, amount & side of the journal must be the same as the line"),
+		},
+		{
+			name: "error case - journal is matched with another line",
+			req:  req,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLineById(gomock.Any(), 1).Return(line(), nil)
+				testHelper.mockAcctRepository.EXPECT().GetJournalDetailByTransactionId(gomock.Any(), "trx-1").Return(journals, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					GetBankStatementLinesByJournalIds(gomock.Any(), []string{"journal-1"}).
+					Return([]models.BankStatementLine{{ID: 7, JournalId: "journal-1"}}, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyJournalIsReconciled, "7"),
+		},
+		{
+			name: "error case - line is matched by another request",
+			req:  req,
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLineById(gomock.Any(), 1).Return(line(), nil)
+				testHelper.mockAcctRepository.EXPECT().GetJournalDetailByTransactionId(gomock.Any(), "trx-1").Return(journals, nil)
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLinesByJournalIds(gomock.Any(), []string{"journal-1"}).Return(nil, nil)
+				testHelper.mockAcctRepository.EXPECT().
+					UpdateBankStatementLineMatch(gomock.Any(), gomock.Any(), models.BankStatementLineStatusUnmatched).
+					Return(models.ErrNoRowsAffected)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyBankStatementLineIsMatched, "1"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := testHelper.reconciliationService.MatchBankStatementLine(context.TODO(), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, out)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, models.BankStatementLineStatusMatched, out.Status)
+		})
+	}
+}
+
+func Test_reconciliationService_UnmatchBankStatementLine(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	matchedAt := atime.Now()
+	matched := func() *models.BankStatementLine {
+		return &models.BankStatementLine{
+			ID:            1,
+			Status:        models.BankStatementLineStatusMatched,
+			TransactionId: "trx-1",
+			JournalId:     "journal-1",
+			MatchType:     models.BankStatementMatchTypeAuto,
+			MatchedAt:     &matchedAt,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "success case",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLineById(gomock.Any(), 1).Return(matched(), nil)
+				testHelper.mockAcctRepository.EXPECT().
+					UpdateBankStatementLineMatch(gomock.Any(), models.BankStatementLine{ID: 1, Status: models.BankStatementLineStatusUnmatched}, models.BankStatementLineStatusMatched).
+					Return(nil)
+			},
+		},
+		{
+			name: "error case - line is not matched",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().
+					GetBankStatementLineById(gomock.Any(), 1).
+					Return(&models.BankStatementLine{ID: 1, Status: models.BankStatementLineStatusUnmatched}, nil)
+			},
+			wantErr: models.GetErrMap(models.ErrKeyBankStatementLineNotMatched, "1"),
+		},
+		{
+			name: "error case - database",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetBankStatementLineById(gomock.Any(), 1).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doMock()
+
+			out, err := testHelper.reconciliationService.UnmatchBankStatementLine(context.TODO(), models.UnmatchBankStatementLineRequest{ID: 1})
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, out)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, models.BankStatementLineStatusUnmatched, out.Status)
+			assert.Empty(t, out.JournalId)
+		})
+	}
+}
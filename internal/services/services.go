@@ -62,6 +62,8 @@ type Services struct {
 	Migration          *migrationService
 	PublisherService   *publisherService
 	TrialBalance       *trialBalance
+	UploadJob          *uploadJobService
+	Reconciliation     *reconciliationService
 }
 
 func New(
@@ -114,6 +116,8 @@ func New(
 	srv.Migration = (*migrationService)(&srv.common)
 	srv.PublisherService = (*publisherService)(&srv.common)
 	srv.TrialBalance = (*trialBalance)(&srv.common)
+	srv.UploadJob = (*uploadJobService)(&srv.common)
+	srv.Reconciliation = (*reconciliationService)(&srv.common)
 
 	return srv
 }
@@ -33,7 +33,7 @@ func TestMain(m *testing.M) {
 
 type testServiceHelper struct {
 	mockCtrl *gomock.Controller
-	config   config.Configuration
+	config   *config.Configuration
 	mockFlag *mockFlag.MockFlaggerClient
 
 	mockAccRepository                *mockmysql.MockAccountRepository
@@ -59,9 +59,11 @@ type testServiceHelper struct {
 	migrationService          services.MigrationService
 	productTypeService        services.ProductTypeService
 	publisherService          services.PublisherService
+	reconciliationService     services.ReconciliationService
 	retryService              services.RetryService
 	subCategoryService        services.SubCategoryService
 	trialBalanceService       services.TrialBalanceService
+	uploadJobService          services.UploadJobService
 
 	mockAcuanClient            *mockAC.MockAcuanClient
 	mockCacheRepository        *mockcache.MockCacheRepository
@@ -125,9 +127,6 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 			IsCreateAccountT24:       true,
 			LimitAccountSubLedger:    5,
 			LimitAccountTrialBalance: 10,
-			InvestedAccountNumber:    make(map[string]string),
-			ReceivablesAccountNumber: make(map[string]string),
-			MultiLoanAccount:         make(map[string]string),
 			ChunkSizeAccountBalance:  10,
 			LoanPartnerAccountConfig: map[string]config.LoanPartnerAccountConfig{
 				"wht23_26": {
@@ -150,10 +149,6 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 			BulkLimit: 1,
 		},
 	}
-	conf.AccountConfig.InvestedAccountNumber["21101"] = "21201"
-	conf.AccountConfig.InvestedAccountNumber["21102"] = "21202"
-	conf.AccountConfig.ReceivablesAccountNumber["21102"] = "14201"
-	conf.AccountConfig.MultiLoanAccount["13101"] = "21303"
 
 	serv := services.New(
 		&conf,
@@ -175,7 +170,7 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 
 	return testServiceHelper{
 		mockCtrl: mockCtrl,
-		config:   conf,
+		config:   &conf,
 		mockFlag: mockFlag,
 
 		mockAccRepository:                mockAccountRepository,
@@ -214,8 +209,10 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 		migrationService:          serv.Migration,
 		productTypeService:        serv.ProductType,
 		publisherService:          serv.PublisherService,
+		reconciliationService:     serv.Reconciliation,
 		retryService:              serv.RetryService,
 		subCategoryService:        serv.SubCategory,
 		trialBalanceService:       serv.TrialBalance,
+		uploadJobService:          serv.UploadJob,
 	}
 }
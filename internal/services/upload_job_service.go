@@ -0,0 +1,473 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/google/uuid"
+)
+
+type UploadJobService interface {
+	CreateUploadJob(ctx context.Context, in models.CreateUploadJobRequest) (out *models.UploadJob, err error)
+	GetUploadJobById(ctx context.Context, id int) (out *models.UploadJob, err error)
+	ProcessUploadJob(ctx context.Context, in models.UploadJobMessage) (err error)
+}
+
+type uploadJobService service
+
+var _ UploadJobService = (*uploadJobService)(nil)
+
+const (
+	// uploadJobProgressBatch is the number of processed rows between progress updates of a job.
+	uploadJobProgressBatch = 500
+
+	// uploadJobMaxStoredFailures is the max failures stored on the job, every failure is written to the error file.
+	uploadJobMaxStoredFailures = 100
+
+	// uploadJobStaleTimeout is the time since the last progress update of a processing job before it is reclaimed,
+	// the worker that claimed the job is considered dead.
+	uploadJobStaleTimeout = 30 * time.Minute
+)
+
+/*
+1. store the uploaded file to gcs, the file is not processed inside the http request
+2. insert the job as pending
+3. publish the job to the upload job stream, the worker processes the file in the background,
+the job is failed when it can not be published so it does not stay pending forever
+*/
+func (us *uploadJobService) CreateUploadJob(ctx context.Context, in models.CreateUploadJobRequest) (out *models.UploadJob, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	src, err := in.File.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	fp := models.CloudStoragePayload{
+		Filename: fmt.Sprintf("%s_%s", uuid.New().String(), in.File.Filename),
+		Path:     fmt.Sprintf("%s/%s", models.UploadJobDir, in.Type),
+	}
+	if err = us.writeToGCS(ctx, fp, func(w io.Writer) error {
+		return us.srv.file.CopyFile(w, src)
+	}); err != nil {
+		return nil, err
+	}
+
+	job := models.UploadJob{
+		Type:      in.Type,
+		Filename:  in.File.Filename,
+		FilePath:  fp.GetFilePath(),
+		Topic:     in.Topic,
+		CreatedBy: in.CreatedBy,
+		Reason:    in.Reason,
+		Status:    models.UploadJobStatusPending,
+	}
+	job.ID, err = us.srv.mySqlRepo.GetAccountingRepository().InsertUploadJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = us.srv.publisher.PublishSyncWithKeyAndLog(ctx,
+		"publish upload job to upload_job_stream",
+		us.srv.conf.Kafka.Publishers.UploadJobStream.Topic,
+		strconv.Itoa(job.ID),
+		models.UploadJobMessage{ID: job.ID},
+	); err != nil {
+		finishedAt := atime.Now()
+		job.Status = models.UploadJobStatusFailed
+		job.ErrorMessage = fmt.Sprintf("failed to publish the job: %s", err.Error())
+		job.FinishedAt = &finishedAt
+		if errUpdate := us.updateUploadJob(ctx, job, models.UploadJobStatusPending); errUpdate != nil {
+			xlog.Warn(ctx, "[CREATE.UPLOAD.JOB]", xlog.Int("id", job.ID), xlog.String("description", "failed to fail the unpublished job"), xlog.Err(errUpdate))
+		}
+		return nil, err
+	}
+
+	now := atime.Now()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	return &job, nil
+}
+
+// GetUploadJobById returns the progress of the job & the signed url of the error file when there is any failed row.
+func (us *uploadJobService) GetUploadJobById(ctx context.Context, id int) (out *models.UploadJob, err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	out, err = us.srv.mySqlRepo.GetAccountingRepository().GetUploadJobById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		err = models.GetErrMap(models.ErrKeyUploadJobNotFound, strconv.Itoa(id))
+		return nil, err
+	}
+
+	if out.ErrorFilePath != "" {
+		out.ErrorFileURL, err = us.srv.cloudStorageRepo.GetSignedURL(
+			models.NewCloudStoragePayload(out.ErrorFilePath),
+			us.srv.conf.CloudStorageConfig.UploadJobURLDuration,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+/*
+1. claim the pending job, a redelivered message of a claimed job is skipped
+unless the job is stale, a processing job without progress update within the timeout is reclaimed from the start.
+every claim increases the attempt of the job, the updates of the previous attempt are rejected so its worker stops
+2. read the file from gcs
+3. process every row by the type of the job, the progress is updated every batch of rows
+- journal: the rows are grouped into journals by the transaction id, every journal is validated before any journal is published, nothing is published when a journal is invalid
+- account: every row is published to the pas account stream
+- publisher: every message of the json file is published to the topic
+- account bulk update: every row updates an account with the creator & the reason of the job, the updated accounts are published every bulkUpdateAccountsFlushRows rows
+4. write the failed rows to the error file & finish the job
+*/
+func (us *uploadJobService) ProcessUploadJob(ctx context.Context, in models.UploadJobMessage) (err error) {
+	defer func() {
+		logService(ctx, err)
+	}()
+
+	job, err := us.srv.mySqlRepo.GetAccountingRepository().GetUploadJobById(ctx, in.ID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		err = models.GetErrMap(models.ErrKeyUploadJobNotFound, strconv.Itoa(in.ID))
+		return err
+	}
+
+	now := atime.Now()
+	staleBefore := now.Add(-uploadJobStaleTimeout)
+	switch {
+	case job.Status == models.UploadJobStatusPending:
+	case job.Status == models.UploadJobStatusProcessing && job.UpdatedAt.Before(staleBefore):
+		xlog.Warn(ctx, "[PROCESS.UPLOAD.JOB]", xlog.Int("id", job.ID), xlog.Time("updated-at", job.UpdatedAt), xlog.String("description", "reclaim stale job"))
+	default:
+		xlog.Info(ctx, "[PROCESS.UPLOAD.JOB]", xlog.Int("id", job.ID), xlog.String("status", job.Status), xlog.String("description", "job is already claimed"))
+		return nil
+	}
+	*job = models.UploadJob{
+		ID:        job.ID,
+		Type:      job.Type,
+		Filename:  job.Filename,
+		FilePath:  job.FilePath,
+		Topic:     job.Topic,
+		CreatedBy: job.CreatedBy,
+		Reason:    job.Reason,
+		Status:    models.UploadJobStatusProcessing,
+		Attempt:   job.Attempt,
+		StartedAt: &now,
+		CreatedAt: job.CreatedAt,
+	}
+	if err = us.srv.mySqlRepo.GetAccountingRepository().ClaimUploadJob(ctx, *job, staleBefore); err != nil {
+		if errors.Is(err, models.ErrNoRowsAffected) {
+			return nil
+		}
+		return err
+	}
+	job.Attempt++
+
+	header, rows, firstRow, err := us.readUploadJob(ctx, *job)
+	if err == nil {
+		job.TotalRows = len(rows)
+		switch job.Type {
+		case models.UploadJobTypeJournal:
+			err = us.processUploadJobJournal(ctx, job, header, rows, firstRow)
+		case models.UploadJobTypeAccount:
+			err = us.processUploadJobRows(ctx, job, rows, firstRow, func(row int, r []string) error {
+				account, err := parseUploadAccount(r)
+				if err != nil {
+					return err
+				}
+				return us.srv.Account.publishUploadAccount(ctx, fmt.Sprintf("upload-job-%d-row-%d", job.ID, row), account)
+			})
+		case models.UploadJobTypePublisher:
+			err = us.processUploadJobRows(ctx, job, rows, firstRow, func(row int, r []string) error {
+				var message map[string]interface{}
+				if err := json.Unmarshal([]byte(r[0]), &message); err != nil {
+					return err
+				}
+				return us.srv.PublisherService.publish(ctx, job.Topic, message)
+			})
+		case models.UploadJobTypeAccountBulkUpdate:
+			err = us.processUploadJobAccountBulkUpdate(ctx, job, header, rows, firstRow)
+		default:
+			err = fmt.Errorf("invalid upload job type %s", job.Type)
+		}
+	}
+	if err == nil && len(job.Failures) > 0 {
+		err = us.writeUploadJobErrorFile(ctx, job, header)
+	}
+	if err != nil {
+		if isUploadJobReclaimed(ctx, *job, err) {
+			return nil
+		}
+		return us.failUploadJob(ctx, job, err)
+	}
+
+	finishedAt := atime.Now()
+	if job.Status == models.UploadJobStatusProcessing {
+		job.Status = models.UploadJobStatusCompleted
+	}
+	job.FinishedAt = &finishedAt
+	if err = us.updateUploadJob(ctx, *job, models.UploadJobStatusProcessing); err != nil {
+		if isUploadJobReclaimed(ctx, *job, err) {
+			return nil
+		}
+		return err
+	}
+
+	xlog.Info(ctx, "[PROCESS.UPLOAD.JOB]",
+		xlog.Int("id", job.ID),
+		xlog.String("status", job.Status),
+		xlog.Int("success-rows", job.SuccessRows),
+		xlog.Int("failed-rows", job.FailedRows),
+	)
+
+	return nil
+}
+
+// processUploadJobJournal publishes the journals only when every journal is valid, so a file is never partially posted.
+// The rows with the same transaction id are a journal, every row of a failed journal is a failed row.
+func (us *uploadJobService) processUploadJobJournal(ctx context.Context, job *models.UploadJob, header []string, rows [][]string, firstRow int) error {
+	uploadJournals, err := parseUploadJournals(header, rows)
+	if err != nil {
+		return err
+	}
+
+	addFailure := func(uj *uploadJournal, err error) {
+		for _, i := range uj.rows {
+			job.AddFailure(firstRow+i, rows[i], err)
+		}
+	}
+
+	for i, uj := range uploadJournals {
+		err := uj.err
+		if err == nil {
+			_, _, err = us.srv.Journal.validateTransaction(ctx, uj.journal)
+		}
+		if err != nil {
+			addFailure(uj, err)
+		}
+
+		// nothing is processed while validating, the heartbeat keeps the job from being reclaimed
+		if (i+1)%uploadJobProgressBatch == 0 {
+			if err := us.heartbeatUploadJob(ctx, *job); err != nil {
+				return err
+			}
+		}
+	}
+	if job.FailedRows > 0 {
+		job.Status = models.UploadJobStatusFailed
+		job.ErrorMessage = fmt.Sprintf("%d of %d rows are invalid, no journal is published", job.FailedRows, job.TotalRows)
+		return nil
+	}
+
+	for _, uj := range uploadJournals {
+		previousRows := job.ProcessedRows
+		if err := us.srv.Journal.publishToJournalStream(ctx, uj.journal); err != nil {
+			addFailure(uj, err)
+		} else {
+			for range uj.rows {
+				job.AddSuccess()
+			}
+		}
+		if err := us.saveUploadJobProgress(ctx, *job, previousRows); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processUploadJobAccountBulkUpdate updates the account of every row, the columns are matched by the header name.
+// The accounts updated before the job stops are still published, they are already stored.
+func (us *uploadJobService) processUploadJobAccountBulkUpdate(ctx context.Context, job *models.UploadJob, header []string, rows [][]string, firstRow int) error {
+	columns, err := parseBulkUpdateAccountColumns(header)
+	if err != nil {
+		return err
+	}
+
+	updater := us.srv.Account.newAccountBulkUpdater(job.CreatedBy, job.Reason)
+	defer updater.flush(ctx)
+
+	return us.processUploadJobRows(ctx, job, rows, firstRow, func(row int, r []string) error {
+		return updater.update(ctx, parseBulkUpdateAccount(columns, r, row))
+	})
+}
+
+// processUploadJobRows processes every row independently, a failed row does not stop the next rows.
+// row is the row number of the row in the file.
+func (us *uploadJobService) processUploadJobRows(ctx context.Context, job *models.UploadJob, rows [][]string, firstRow int, process func(row int, r []string) error) error {
+	for i, r := range rows {
+		if err := process(firstRow+i, r); err != nil {
+			job.AddFailure(firstRow+i, r, err)
+		} else {
+			job.AddSuccess()
+		}
+		if err := us.saveUploadJobProgress(ctx, *job, job.ProcessedRows-1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readUploadJob reads the rows of the uploaded csv or xlsx file, every message of a json file is a row with a single column.
+// firstRow is the row number of the first row in the file.
+func (us *uploadJobService) readUploadJob(ctx context.Context, job models.UploadJob) (header []string, rows [][]string, firstRow int, err error) {
+	payload := models.NewCloudStoragePayload(job.FilePath)
+	reader, err := us.srv.cloudStorageRepo.NewReader(ctx, &payload)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	if job.Type == models.UploadJobTypePublisher {
+		data, errRead := us.srv.file.ReadAll(reader)
+		if errRead != nil {
+			err = errRead
+			return
+		}
+
+		var messages []json.RawMessage
+		if err = json.Unmarshal(data, &messages); err != nil {
+			return
+		}
+		for _, v := range messages {
+			rows = append(rows, []string{string(v)})
+		}
+
+		return []string{"message"}, rows, 1, nil
+	}
+
+	records, err := readUploadRecords(us.srv.file, reader, job.Filename)
+	if err != nil {
+		return
+	}
+	if len(records) == 0 {
+		return nil, nil, 2, nil
+	}
+
+	return records[0], records[1:], 2, nil
+}
+
+// writeUploadJobErrorFile writes the failed rows with the row number & the error, so the rows can be fixed & uploaded again.
+func (us *uploadJobService) writeUploadJobErrorFile(ctx context.Context, job *models.UploadJob, header []string) error {
+	fp := models.CloudStoragePayload{
+		Filename: fmt.Sprintf("%d_errors.csv", job.ID),
+		Path:     fmt.Sprintf("%s/%s", models.UploadJobDir, job.Type),
+	}
+
+	body := make([][]string, 0, len(job.Failures))
+	for _, v := range job.Failures {
+		record := append([]string{}, v.Record...)
+		body = append(body, append(record, strconv.Itoa(v.Row), v.Error))
+	}
+
+	err := us.writeToGCS(ctx, fp, func(w io.Writer) error {
+		us.srv.file.NewCSVWriter(w)
+		if err := us.srv.file.CSVWriteHeader(ctx, append(append([]string{}, header...), "upload_row", "upload_error")); err != nil {
+			return fmt.Errorf("failed to write header: %w", err)
+		}
+		if err := us.srv.file.CSVWriteAll(ctx, body); err != nil {
+			return fmt.Errorf("failed to write body: %w", err)
+		}
+		return us.srv.file.CSVProcessWrite(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	job.ErrorFilePath = fp.GetFilePath()
+
+	return nil
+}
+
+func (us *uploadJobService) writeToGCS(ctx context.Context, fp models.CloudStoragePayload, write func(w io.Writer) error) error {
+	w := us.srv.cloudStorageRepo.NewWriter(ctx, &fp)
+	if err := write(w); err != nil {
+		w.Close()
+		return err
+	}
+
+	return w.Close()
+}
+
+// failUploadJob finishes the job as failed when the file cannot be processed.
+func (us *uploadJobService) failUploadJob(ctx context.Context, job *models.UploadJob, cause error) error {
+	finishedAt := atime.Now()
+	job.Status = models.UploadJobStatusFailed
+	job.ErrorMessage = cause.Error()
+	job.FinishedAt = &finishedAt
+	if err := us.updateUploadJob(ctx, *job, models.UploadJobStatusProcessing); err != nil {
+		if isUploadJobReclaimed(ctx, *job, err) {
+			return nil
+		}
+		return err
+	}
+
+	return cause
+}
+
+// saveUploadJobProgress updates the progress every batch of rows.
+func (us *uploadJobService) saveUploadJobProgress(ctx context.Context, job models.UploadJob, previousRows int) error {
+	if job.ProcessedRows/uploadJobProgressBatch == previousRows/uploadJobProgressBatch {
+		return nil
+	}
+
+	return us.heartbeatUploadJob(ctx, job)
+}
+
+// heartbeatUploadJob stores the job so it is not reclaimed as stale, a failed update is retried by the next batch.
+// models.ErrNoRowsAffected is returned when the job is claimed by another worker, the worker must stop processing the job.
+func (us *uploadJobService) heartbeatUploadJob(ctx context.Context, job models.UploadJob) error {
+	err := us.updateUploadJob(ctx, job, models.UploadJobStatusProcessing)
+	if errors.Is(err, models.ErrNoRowsAffected) {
+		return err
+	}
+	if err != nil {
+		xlog.Warn(ctx, "[PROCESS.UPLOAD.JOB]", xlog.Int("id", job.ID), xlog.String("description", "failed to update progress"), xlog.Err(err))
+	}
+
+	return nil
+}
+
+// isUploadJobReclaimed reports whether the job is claimed by another worker since this worker claimed it.
+func isUploadJobReclaimed(ctx context.Context, job models.UploadJob, err error) bool {
+	if !errors.Is(err, models.ErrNoRowsAffected) {
+		return false
+	}
+
+	xlog.Warn(ctx, "[PROCESS.UPLOAD.JOB]", xlog.Int("id", job.ID), xlog.Int("attempt", job.Attempt), xlog.String("description", "job is claimed by another worker, stop processing"))
+	return true
+}
+
+// updateUploadJob stores the job with the first failures only, every failure is available in the error file.
+func (us *uploadJobService) updateUploadJob(ctx context.Context, job models.UploadJob, fromStatus string) error {
+	if len(job.Failures) > uploadJobMaxStoredFailures {
+		job.Failures = job.Failures[:uploadJobMaxStoredFailures]
+	}
+
+	return us.srv.mySqlRepo.GetAccountingRepository().UpdateUploadJob(ctx, job, fromStatus)
+}
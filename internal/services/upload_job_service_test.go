@@ -0,0 +1,451 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-accounting/internal/models"
+	"bitbucket.org/Amartha/go-accounting/internal/pkg/atime"
+	"bitbucket.org/Amartha/go-accounting/internal/repositories/mysql"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_uploadJobService_CreateUploadJob(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	f := createMultipartFormData(t)
+	req := models.CreateUploadJobRequest{
+		Type: models.UploadJobTypeJournal,
+		File: f,
+	}
+
+	tests := []struct {
+		name    string
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success case",
+			doMock: func() {
+				tempFile, _ := os.CreateTemp("", "test_mock_gcs")
+				testHelper.mockCloudStorageRepository.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(tempFile)
+				testHelper.mockFile.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockAcctRepository.EXPECT().InsertUploadJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in models.UploadJob) (int, error) {
+						assert.Equal(t, models.UploadJobStatusPending, in.Status)
+						assert.Equal(t, f.Filename, in.Filename)
+						assert.True(t, strings.HasPrefix(in.FilePath, "upload_jobs/journal/"))
+						return 1, nil
+					})
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), "1", models.UploadJobMessage{ID: 1}).
+					Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error case - write file",
+			doMock: func() {
+				tempFile, _ := os.CreateTemp("", "test_mock_gcs")
+				testHelper.mockCloudStorageRepository.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(tempFile)
+				testHelper.mockFile.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - insert job",
+			doMock: func() {
+				tempFile, _ := os.CreateTemp("", "test_mock_gcs")
+				testHelper.mockCloudStorageRepository.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(tempFile)
+				testHelper.mockFile.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockAcctRepository.EXPECT().InsertUploadJob(gomock.Any(), gomock.Any()).Return(0, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - publish job",
+			doMock: func() {
+				tempFile, _ := os.CreateTemp("", "test_mock_gcs")
+				testHelper.mockCloudStorageRepository.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(tempFile)
+				testHelper.mockFile.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockAcctRepository.EXPECT().InsertUploadJob(gomock.Any(), gomock.Any()).Return(1, nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(assert.AnError)
+				testHelper.mockAcctRepository.EXPECT().UpdateUploadJob(gomock.Any(), gomock.Any(), models.UploadJobStatusPending).
+					DoAndReturn(func(ctx context.Context, in models.UploadJob, fromStatus string) error {
+						assert.Equal(t, 1, in.ID)
+						assert.Equal(t, models.UploadJobStatusFailed, in.Status)
+						assert.NotNil(t, in.FinishedAt)
+						return nil
+					})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			got, err := testHelper.uploadJobService.CreateUploadJob(context.TODO(), req)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, 1, got.ID)
+				assert.Equal(t, models.UploadJobStatusPending, got.Status)
+			}
+		})
+	}
+}
+
+func Test_uploadJobService_GetUploadJobById(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	tests := []struct {
+		name    string
+		doMock  func()
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success case - with error file",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(&models.UploadJob{
+					ID:            1,
+					Status:        models.UploadJobStatusCompleted,
+					ErrorFilePath: "upload_jobs/account/1_errors.csv",
+				}, nil)
+				testHelper.mockCloudStorageRepository.EXPECT().
+					GetSignedURL(models.CloudStoragePayload{Path: "upload_jobs/account", Filename: "1_errors.csv"}, gomock.Any()).
+					Return("https://storage.googleapis.com/upload_jobs/account/1_errors.csv", nil)
+			},
+			want: "https://storage.googleapis.com/upload_jobs/account/1_errors.csv",
+		},
+		{
+			name: "success case - without error file",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(&models.UploadJob{
+					ID:     1,
+					Status: models.UploadJobStatusProcessing,
+				}, nil)
+			},
+		},
+		{
+			name: "error case - not found",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - database error",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - signed url",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(&models.UploadJob{
+					ID:            1,
+					ErrorFilePath: "upload_jobs/account/1_errors.csv",
+				}, nil)
+				testHelper.mockCloudStorageRepository.EXPECT().GetSignedURL(gomock.Any(), gomock.Any()).Return("", assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			got, err := testHelper.uploadJobService.GetUploadJobById(context.TODO(), 1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got.ErrorFileURL)
+			}
+		})
+	}
+}
+
+func Test_uploadJobService_ProcessUploadJob(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	journalHeader := []string{"reference_number", "transaction_id", "order_type", "transaction_date", "processing_date", "currency", "transaction_type1", "transaction_type_name1", "account_debit1", "account_credit1", "narrative1", "amount1", "transaction_type2", "transaction_type_name2", "account_debit2", "account_credit2", "narrative2", "amount2", "metadata"}
+	journalRow := []string{"001-TRX-MANUAL-23042024-1", "b13583d7-adba-4aba-9261-3dee86867ec7s", "TUP", "2024-04-23 09:20:00", "2024-04-23 09:20:00", "IDR", "TUPIN", "Amartha Top Up represents Institutional Lender", "142001000000001", "211001000381110", "Amartha Top Up represents Institutional Lender", "3368495938", "", "", "", "", "", "", ""}
+	invalidJournalRow := append([]string{}, journalRow...)
+	invalidJournalRow[1], invalidJournalRow[11] = "c2a7c1f0-5b1e-4f0e-9d55-0d0c3a6b1e21", "3368495938A"
+	accountHeader := []string{"legacy_id", "name", "owner_id", "product_type_code", "entity_code", "category_code", "sub_category_code", "currency", "alt_id"}
+	accountRow := []string{"11100100000004", "KAS Teller Point 1", "1234567", "1001", "001", "111", "11101", "IDR", ""}
+
+	pendingJob := func(jobType string) *models.UploadJob {
+		return &models.UploadJob{
+			ID:       1,
+			Type:     jobType,
+			Filename: "upload.csv",
+			FilePath: "upload_jobs/" + jobType + "/upload.csv",
+			Topic:    "journal_stream",
+			Status:   models.UploadJobStatusPending,
+		}
+	}
+	claimJob := func(jobType string) {
+		testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(pendingJob(jobType), nil)
+		testHelper.mockAcctRepository.EXPECT().ClaimUploadJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	}
+	readFile := func(jobType string) {
+		testHelper.mockCloudStorageRepository.EXPECT().
+			NewReader(gomock.Any(), &models.CloudStoragePayload{Path: "upload_jobs/" + jobType, Filename: "upload.csv"}).
+			Return(io.NopCloser(strings.NewReader("")), nil)
+	}
+	writeErrorFile := func() {
+		tempFile, _ := os.CreateTemp("", "test_mock_gcs")
+		testHelper.mockCloudStorageRepository.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(tempFile)
+		testHelper.mockFile.EXPECT().NewCSVWriter(gomock.Any())
+		testHelper.mockFile.EXPECT().CSVWriteHeader(gomock.Any(), gomock.Any()).Return(nil)
+		testHelper.mockFile.EXPECT().CSVWriteAll(gomock.Any(), gomock.Any()).Return(nil)
+		testHelper.mockFile.EXPECT().CSVProcessWrite(gomock.Any()).Return(nil)
+	}
+	finishJob := func(check func(job models.UploadJob)) {
+		testHelper.mockAcctRepository.EXPECT().UpdateUploadJob(gomock.Any(), gomock.Any(), models.UploadJobStatusProcessing).
+			DoAndReturn(func(ctx context.Context, in models.UploadJob, fromStatus string) error {
+				check(in)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name    string
+		doMock  func()
+		wantErr bool
+	}{
+		{
+			name: "success case - journal",
+			doMock: func() {
+				claimJob(models.UploadJobTypeJournal)
+				readFile(models.UploadJobTypeJournal)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{journalHeader, journalRow}, nil)
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).Return(false, nil)
+				testHelper.mockPublisher.EXPECT().PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				finishJob(func(job models.UploadJob) {
+					assert.Equal(t, models.UploadJobStatusCompleted, job.Status)
+					assert.Equal(t, 1, job.Attempt)
+					assert.Equal(t, 1, job.TotalRows)
+					assert.Equal(t, 1, job.SuccessRows)
+					assert.NotNil(t, job.FinishedAt)
+				})
+			},
+		},
+		{
+			name: "success case - journal with invalid row is not published",
+			doMock: func() {
+				claimJob(models.UploadJobTypeJournal)
+				readFile(models.UploadJobTypeJournal)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{journalHeader, journalRow, invalidJournalRow}, nil)
+				testHelper.mockAcctRepository.EXPECT().CheckTransactionIdIsExist(gomock.Any(), gomock.Any()).Return(false, nil)
+				writeErrorFile()
+				finishJob(func(job models.UploadJob) {
+					assert.Equal(t, models.UploadJobStatusFailed, job.Status)
+					assert.Equal(t, 2, job.TotalRows)
+					assert.Equal(t, 0, job.SuccessRows)
+					assert.Equal(t, 1, job.FailedRows)
+					assert.Equal(t, 3, job.Failures[0].Row)
+					assert.Equal(t, "upload_jobs/journal/1_errors.csv", job.ErrorFilePath)
+					assert.Equal(t, "1 of 2 rows are invalid, no journal is published", job.ErrorMessage)
+				})
+			},
+		},
+		{
+			name: "success case - account with partial failure",
+			doMock: func() {
+				claimJob(models.UploadJobTypeAccount)
+				readFile(models.UploadJobTypeAccount)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{accountHeader, accountRow, accountRow[:3]}, nil)
+				testHelper.mockPublisher.EXPECT().PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), "upload-job-1-row-2", gomock.Any()).Return(nil)
+				writeErrorFile()
+				finishJob(func(job models.UploadJob) {
+					assert.Equal(t, models.UploadJobStatusCompleted, job.Status)
+					assert.Equal(t, 2, job.ProcessedRows)
+					assert.Equal(t, 1, job.SuccessRows)
+					assert.Equal(t, 1, job.FailedRows)
+					assert.Equal(t, "record has 3 columns, expected 9 columns", job.Failures[0].Error)
+				})
+			},
+		},
+		{
+			name: "success case - account bulk update with partial failure",
+			doMock: func() {
+				job := pendingJob(models.UploadJobTypeAccountBulkUpdate)
+				job.CreatedBy, job.Reason = "tono@amartha.com", "rename"
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(job, nil)
+				testHelper.mockAcctRepository.EXPECT().ClaimUploadJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				readFile(models.UploadJobTypeAccountBulkUpdate)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{
+					{"\ufeffName", "account_number"},
+					{"Lender Yang Baik", "21100100000001"},
+					{"Lender", "21100100000001"},
+				}, nil)
+				testHelper.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), "21100100000001").Return(models.GetAccountOut{
+					AccountNumber: "21100100000001",
+					AccountName:   "Lender",
+					OwnerID:       "12345",
+				}, nil)
+				testHelper.mockMySQLRepository.EXPECT().
+					Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r mysql.SQLRepository) error) error {
+						testHelper.mockAccRepository.EXPECT().Update(ctx, models.UpdateAccount{
+							AccountNumber: "21100100000001",
+							Name:          "Lender Yang Baik",
+							OwnerID:       "12345",
+							ChangedBy:     "tono@amartha.com",
+							Reason:        "rename",
+						}).Return(nil)
+						testHelper.mockAccRepository.EXPECT().InsertChangeLogs(ctx, gomock.Len(1)).Return(nil)
+						return steps(ctx, testHelper.mockMySQLRepository)
+					})
+				testHelper.mockCacheRepository.EXPECT().Del(gomock.Any(), "pas_account_key_21100100000001").Return(nil)
+				testHelper.mockAcuanClient.EXPECT().PublishAccountsOneByOne(gomock.Any(), gomock.Len(1))
+				writeErrorFile()
+				finishJob(func(job models.UploadJob) {
+					assert.Equal(t, models.UploadJobStatusCompleted, job.Status)
+					assert.Equal(t, 2, job.ProcessedRows)
+					assert.Equal(t, 1, job.SuccessRows)
+					assert.Equal(t, 1, job.FailedRows)
+					assert.Equal(t, 3, job.Failures[0].Row)
+					assert.Equal(t, "account number is duplicated in the bulk update caused by row 2", job.Failures[0].Error)
+				})
+			},
+		},
+		{
+			name: "error case - account bulk update without account number column",
+			doMock: func() {
+				claimJob(models.UploadJobTypeAccountBulkUpdate)
+				readFile(models.UploadJobTypeAccountBulkUpdate)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{{"name"}, {"Lender Yang Baik"}}, nil)
+				finishJob(func(job models.UploadJob) {
+					assert.Equal(t, models.UploadJobStatusFailed, job.Status)
+					assert.Equal(t, "code: INVALID_VALUES, message: bulk update account file is invalid caused by column account_number is required", job.ErrorMessage)
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "success case - publisher",
+			doMock: func() {
+				claimJob(models.UploadJobTypePublisher)
+				readFile(models.UploadJobTypePublisher)
+				testHelper.mockFile.EXPECT().ReadAll(gomock.Any()).Return([]byte(`[{"transactionId":"1"},"invalid"]`), nil)
+				testHelper.mockPublisher.EXPECT().
+					PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), "journal_stream", gomock.Any(), map[string]interface{}{"transactionId": "1"}).
+					Return(nil)
+				writeErrorFile()
+				finishJob(func(job models.UploadJob) {
+					assert.Equal(t, models.UploadJobStatusCompleted, job.Status)
+					assert.Equal(t, 1, job.SuccessRows)
+					assert.Equal(t, 2, job.Failures[0].Row)
+				})
+			},
+		},
+		{
+			name: "success case - job is already claimed",
+			doMock: func() {
+				job := pendingJob(models.UploadJobTypeJournal)
+				job.Status = models.UploadJobStatusProcessing
+				job.UpdatedAt = atime.Now()
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(job, nil)
+			},
+		},
+		{
+			name: "success case - stale job is reclaimed",
+			doMock: func() {
+				job := pendingJob(models.UploadJobTypeAccount)
+				job.Status = models.UploadJobStatusProcessing
+				job.Attempt = 1
+				job.ProcessedRows = 1
+				job.UpdatedAt = atime.Now().Add(-time.Hour)
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(job, nil)
+				testHelper.mockAcctRepository.EXPECT().ClaimUploadJob(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in models.UploadJob, staleBefore time.Time) error {
+						assert.Equal(t, models.UploadJobStatusProcessing, in.Status)
+						assert.Equal(t, 1, in.Attempt)
+						assert.Equal(t, 0, in.ProcessedRows)
+						assert.True(t, job.UpdatedAt.Before(staleBefore))
+						return nil
+					})
+				readFile(models.UploadJobTypeAccount)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{accountHeader, accountRow}, nil)
+				testHelper.mockPublisher.EXPECT().PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				finishJob(func(job models.UploadJob) {
+					assert.Equal(t, models.UploadJobStatusCompleted, job.Status)
+					assert.Equal(t, 2, job.Attempt)
+					assert.Equal(t, 1, job.ProcessedRows)
+				})
+			},
+		},
+		{
+			name: "success case - job is reclaimed by another worker while it is processed",
+			doMock: func() {
+				claimJob(models.UploadJobTypeAccount)
+				readFile(models.UploadJobTypeAccount)
+				testHelper.mockFile.EXPECT().CSVReadAll(gomock.Any()).Return([][]string{accountHeader, accountRow}, nil)
+				testHelper.mockPublisher.EXPECT().PublishSyncWithKeyAndLog(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				testHelper.mockAcctRepository.EXPECT().UpdateUploadJob(gomock.Any(), gomock.Any(), models.UploadJobStatusProcessing).Return(models.ErrNoRowsAffected)
+			},
+		},
+		{
+			name: "success case - stale job is reclaimed by another worker",
+			doMock: func() {
+				job := pendingJob(models.UploadJobTypeAccount)
+				job.Status = models.UploadJobStatusProcessing
+				job.UpdatedAt = atime.Now().Add(-time.Hour)
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(job, nil)
+				testHelper.mockAcctRepository.EXPECT().ClaimUploadJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.ErrNoRowsAffected)
+			},
+		},
+		{
+			name: "success case - job is claimed by another worker",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(pendingJob(models.UploadJobTypeJournal), nil)
+				testHelper.mockAcctRepository.EXPECT().ClaimUploadJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.ErrNoRowsAffected)
+			},
+		},
+		{
+			name: "error case - job not found",
+			doMock: func() {
+				testHelper.mockAcctRepository.EXPECT().GetUploadJobById(gomock.Any(), 1).Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error case - read file",
+			doMock: func() {
+				claimJob(models.UploadJobTypeAccount)
+				testHelper.mockCloudStorageRepository.EXPECT().NewReader(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+				finishJob(func(job models.UploadJob) {
+					assert.Equal(t, models.UploadJobStatusFailed, job.Status)
+					assert.Equal(t, assert.AnError.Error(), job.ErrorMessage)
+				})
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+			err := testHelper.uploadJobService.ProcessUploadJob(context.TODO(), models.UploadJobMessage{ID: 1})
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+-- ********************************************************************************
+-- PROGRAM       :  add-upload-job-attempt.sql
+-- DESCRIPTION   :  Add the attempt of the upload job, it is increased on every claim
+--                  and every progress update checks it, so the worker of a reclaimed
+--                  job stops instead of overwriting the progress of the new worker
+-- RUN           :  mysql -h <host> -u <user> -p <database> < add-upload-job-attempt.sql
+-- ********************************************************************************
+
+ALTER TABLE acct_upload_jobs
+    ADD COLUMN attempt INT NOT NULL DEFAULT 0 AFTER status;
@@ -0,0 +1,10 @@
+-- ********************************************************************************
+-- PROGRAM       :  add-upload-job-created-by-reason.sql
+-- DESCRIPTION   :  Add the creator & the reason of the upload job, an account bulk
+--                  update job writes them to the change logs of the updated accounts
+-- RUN           :  mysql -h <host> -u <user> -p <database> < add-upload-job-created-by-reason.sql
+-- ********************************************************************************
+
+ALTER TABLE acct_upload_jobs
+    ADD COLUMN created_by VARCHAR(100) NULL AFTER topic,
+    ADD COLUMN reason VARCHAR(255) NULL AFTER created_by;
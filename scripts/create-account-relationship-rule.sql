@@ -0,0 +1,26 @@
+-- ********************************************************************************
+-- PROGRAM       :  create-account-relationship-rule.sql
+-- DESCRIPTION   :  Create the table of the account relationship rules, the rules are
+--                  seeded from the invested_account_number, receivables_account_number,
+--                  multi_loan_account and lender_insti_receivables_account config of
+--                  the environment by the job after the table is created:
+--                  go run cmd/job/main.go run -v=v1 -n=SeedAccountRelationshipRules
+--                  the config stays the fallback of the rules until they are verified
+-- RUN           :  mysql -h <host> -u <user> -p <database> < create-account-relationship-rule.sql
+-- ********************************************************************************
+
+CREATE TABLE IF NOT EXISTS acct_account_relationship_rule (
+    id                       INT          NOT NULL AUTO_INCREMENT,
+    source_sub_category_code VARCHAR(5)   NOT NULL,
+    source_account_number    VARCHAR(20)  NULL,
+    target_sub_category_code VARCHAR(5)   NOT NULL,
+    target_account_number    VARCHAR(20)  NULL,
+    relation                 VARCHAR(30)  NOT NULL,
+    cardinality              VARCHAR(10)  NOT NULL,
+    name_template            VARCHAR(100) NULL,
+    status                   VARCHAR(10)  NOT NULL,
+    created_at               DATETIME(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at               DATETIME(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (id),
+    KEY idx_acct_account_relationship_rule_source (source_sub_category_code, relation, source_account_number)
+);